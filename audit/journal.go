@@ -0,0 +1,254 @@
+package audit
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
+	"github.com/xchapter7x/lo"
+)
+
+const (
+	Success = "success"
+	Failure = "failure"
+)
+
+// Entry - a single line of the journal
+type Entry struct {
+	Timestamp    string             `json:"timestamp"`
+	RunID        string             `json:"run_id"`
+	ConfigCommit string             `json:"config_commit,omitempty"`
+	Entity       changes.EntityType `json:"entity"`
+	Action       changes.Action     `json:"action"`
+	Name         string             `json:"name"`
+	Org          string             `json:"org,omitempty"`
+	Space        string             `json:"space,omitempty"`
+	GUID         string             `json:"guid,omitempty"`
+	Before       json.RawMessage    `json:"before,omitempty"`
+	After        json.RawMessage    `json:"after,omitempty"`
+	Result       string             `json:"result"`
+	Error        string             `json:"error,omitempty"`
+	PrevHash     string             `json:"prev_hash,omitempty"`
+	Hash         string             `json:"hash,omitempty"`
+}
+
+// Journal - writes every applied change as a JSON Lines entry.  When chained
+// each entry holds the sha256 of the entry before it so removing or editing a
+// line breaks the chain.  A nil Journal is valid and writes nothing.
+type Journal struct {
+	mutex        sync.Mutex
+	out          io.Writer
+	closer       io.Closer
+	runID        string
+	configCommit string
+	chain        bool
+	lastHash     string
+	now          func() time.Time
+}
+
+// NewJournal -
+func NewJournal(out io.Writer, runID, configCommit string, chain bool) *Journal {
+	return &Journal{
+		out:          out,
+		runID:        runID,
+		configCommit: configCommit,
+		chain:        chain,
+		now:          time.Now,
+	}
+}
+
+// Open - appends to the journal at path, creating it when it doesn't exist.  A
+// chained journal continues from the hash of the last entry in the file.
+func Open(path, runID, configCommit string, chain bool) (*Journal, error) {
+	var lastHash string
+	if chain {
+		var err error
+		lastHash, err = lastHashOf(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open audit journal %s: %v", path, err)
+	}
+	journal := NewJournal(file, runID, configCommit, chain)
+	journal.closer = file
+	journal.lastHash = lastHash
+	return journal, nil
+}
+
+// Applied - writes an entry for the change.  Failing to write is logged rather
+// than failing the change that has already been made.
+func (j *Journal) Applied(change changes.Change, guid string, err error) {
+	if j == nil {
+		return
+	}
+	entry := Entry{
+		RunID:        j.runID,
+		ConfigCommit: j.configCommit,
+		Entity:       change.Entity,
+		Action:       change.Action,
+		Name:         change.Name,
+		Org:          change.Org,
+		Space:        change.Space,
+		GUID:         guid,
+		Result:       Success,
+	}
+	if err != nil {
+		entry.Result = Failure
+		entry.Error = err.Error()
+	}
+	if writeErr := j.write(entry, change.Before, change.After); writeErr != nil {
+		lo.G.Errorf("unable to write audit journal entry for %s %s %s: %v", change.Action, change.Entity, change.Name, writeErr)
+	}
+}
+
+func (j *Journal) write(entry Entry, before, after interface{}) error {
+	var err error
+	if entry.Before, err = rawJSON(before); err != nil {
+		return err
+	}
+	if entry.After, err = rawJSON(after); err != nil {
+		return err
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	entry.Timestamp = j.now().UTC().Format(time.RFC3339Nano)
+	if j.chain {
+		entry.PrevHash = j.lastHash
+		if entry.Hash, err = hashOf(entry); err != nil {
+			return err
+		}
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := j.out.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	j.lastHash = entry.Hash
+	return nil
+}
+
+// Close - closes the file of a journal returned by Open
+func (j *Journal) Close() error {
+	if j == nil || j.closer == nil {
+		return nil
+	}
+	return j.closer.Close()
+}
+
+// Verify - checks every entry of a chained journal holds the hash of the entry
+// before it and hashes to the value it records, and returns the hash of the
+// last entry.  The first entry must hold anchor, which is empty for a journal
+// kept from its first entry or the hash of the last entry rotated out of it,
+// so entries removed from the start are detected.  Entries removed from the
+// end can only be detected by comparing the hash returned with one kept
+// elsewhere.
+func Verify(in io.Reader, anchor string) (string, error) {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	lastHash := anchor
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return "", fmt.Errorf("line %d: %v", line, err)
+		}
+		if entry.Hash == "" {
+			return "", fmt.Errorf("line %d: entry has no hash", line)
+		}
+		if entry.PrevHash != lastHash {
+			return "", fmt.Errorf("line %d: previous hash %s doesn't match %s", line, entry.PrevHash, lastHash)
+		}
+		expected, err := hashOf(entry)
+		if err != nil {
+			return "", fmt.Errorf("line %d: %v", line, err)
+		}
+		if entry.Hash != expected {
+			return "", fmt.Errorf("line %d: hash %s doesn't match contents", line, entry.Hash)
+		}
+		lastHash = entry.Hash
+	}
+	return lastHash, scanner.Err()
+}
+
+// NewRunID - a sortable, unique id for a run
+func NewRunID() string {
+	random := make([]byte, 4)
+	_, _ = rand.Read(random)
+	return fmt.Sprintf("%s-%s", time.Now().UTC().Format("20060102T150405Z"), hex.EncodeToString(random))
+}
+
+// ConfigCommit - the git commit checked out in the config directory or an empty
+// string when it isn't a git repository
+func ConfigCommit(configDir string) string {
+	output, err := exec.Command("git", "-C", configDir, "rev-parse", "HEAD").Output()
+	if err != nil {
+		lo.G.Debugf("unable to determine git commit of %s: %v", configDir, err)
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func hashOf(entry Entry) (string, error) {
+	entry.Hash = ""
+	bytes, err := json.Marshal(entry)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(bytes)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func rawJSON(value interface{}) (json.RawMessage, error) {
+	if value == nil {
+		return nil, nil
+	}
+	return json.Marshal(value)
+}
+
+func lastHashOf(path string) (string, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("unable to read audit journal %s: %v", path, err)
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	var lastLine string
+	for scanner.Scan() {
+		if text := strings.TrimSpace(scanner.Text()); text != "" {
+			lastLine = text
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("unable to read audit journal %s: %v", path, err)
+	}
+	if lastLine == "" {
+		return "", nil
+	}
+	var entry Entry
+	if err := json.Unmarshal([]byte(lastLine), &entry); err != nil {
+		return "", fmt.Errorf("unable to read last entry of audit journal %s: %v", path, err)
+	}
+	return entry.Hash, nil
+}
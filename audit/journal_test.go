@@ -0,0 +1,125 @@
+package audit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vmwarepivotallabs/cf-mgmt/audit"
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
+)
+
+var _ = Describe("Journal", func() {
+	entries := func(out string) []audit.Entry {
+		var result []audit.Entry
+		for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+			var entry audit.Entry
+			Expect(json.Unmarshal([]byte(line), &entry)).Should(Succeed())
+			result = append(result, entry)
+		}
+		return result
+	}
+
+	It("ignores changes when nil", func() {
+		var journal *audit.Journal
+		journal.Applied(changes.Change{Entity: changes.Org, Action: changes.Create, Name: "foo"}, "foo-guid", nil)
+		Expect(journal.Close()).Should(Succeed())
+	})
+
+	It("writes an entry for each applied change", func() {
+		out := &bytes.Buffer{}
+		journal := audit.NewJournal(out, "run-1", "abc123", false)
+		journal.Applied(changes.Change{Entity: changes.Org, Action: changes.Update, Name: "new", Org: "new", Before: "old", After: "new"}, "org-guid", nil)
+		journal.Applied(changes.Change{Entity: changes.Space, Action: changes.Delete, Name: "dev", Org: "new", Space: "dev"}, "space-guid", errors.New("boom"))
+
+		written := entries(out.String())
+		Expect(written).Should(HaveLen(2))
+		Expect(written[0].Timestamp).ShouldNot(BeEmpty())
+		Expect(written[0].RunID).Should(Equal("run-1"))
+		Expect(written[0].ConfigCommit).Should(Equal("abc123"))
+		Expect(written[0].Entity).Should(Equal(changes.Org))
+		Expect(written[0].Action).Should(Equal(changes.Update))
+		Expect(written[0].GUID).Should(Equal("org-guid"))
+		Expect(string(written[0].Before)).Should(Equal(`"old"`))
+		Expect(string(written[0].After)).Should(Equal(`"new"`))
+		Expect(written[0].Result).Should(Equal(audit.Success))
+		Expect(written[0].Hash).Should(BeEmpty())
+		Expect(written[1].Space).Should(Equal("dev"))
+		Expect(written[1].Result).Should(Equal(audit.Failure))
+		Expect(written[1].Error).Should(Equal("boom"))
+	})
+
+	Context("hash chaining", func() {
+		var out *bytes.Buffer
+		BeforeEach(func() {
+			out = &bytes.Buffer{}
+			journal := audit.NewJournal(out, "run-1", "", true)
+			journal.Applied(changes.Change{Entity: changes.Org, Action: changes.Create, Name: "a"}, "a-guid", nil)
+			journal.Applied(changes.Change{Entity: changes.OrgRole, Action: changes.Assign, Name: "user", Org: "a", After: map[string]string{"role": "<manager>"}}, "user-guid", nil)
+			journal.Applied(changes.Change{Entity: changes.Org, Action: changes.Delete, Name: "b"}, "b-guid", nil)
+		})
+
+		It("links each entry to the one before it", func() {
+			written := entries(out.String())
+			Expect(written[0].PrevHash).Should(BeEmpty())
+			Expect(written[1].PrevHash).Should(Equal(written[0].Hash))
+			Expect(written[2].PrevHash).Should(Equal(written[1].Hash))
+			lastHash, err := audit.Verify(strings.NewReader(out.String()), "")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(lastHash).Should(Equal(written[2].Hash))
+		})
+
+		It("detects an edited entry", func() {
+			edited := strings.Replace(out.String(), `"name":"b"`, `"name":"c"`, 1)
+			_, err := audit.Verify(strings.NewReader(edited), "")
+			Expect(err).Should(MatchError(ContainSubstring("line 3: hash")))
+		})
+
+		It("detects a removed entry", func() {
+			lines := strings.Split(out.String(), "\n")
+			removed := strings.Join(append([]string{lines[0]}, lines[2:]...), "\n")
+			_, err := audit.Verify(strings.NewReader(removed), "")
+			Expect(err).Should(MatchError(ContainSubstring("line 2: previous hash")))
+		})
+
+		It("detects entries removed from the start", func() {
+			lines := strings.Split(out.String(), "\n")
+			_, err := audit.Verify(strings.NewReader(strings.Join(lines[1:], "\n")), "")
+			Expect(err).Should(MatchError(ContainSubstring("line 1: previous hash")))
+		})
+
+		It("verifies a rotated log from its anchor", func() {
+			written := entries(out.String())
+			lines := strings.Split(out.String(), "\n")
+			_, err := audit.Verify(strings.NewReader(strings.Join(lines[1:], "\n")), written[0].Hash)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	It("continues the chain of an existing file", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "audit.jsonl")
+		journal, err := audit.Open(path, "run-1", "", true)
+		Expect(err).ShouldNot(HaveOccurred())
+		journal.Applied(changes.Change{Entity: changes.Org, Action: changes.Create, Name: "a"}, "a-guid", nil)
+		Expect(journal.Close()).Should(Succeed())
+
+		journal, err = audit.Open(path, "run-2", "", true)
+		Expect(err).ShouldNot(HaveOccurred())
+		journal.Applied(changes.Change{Entity: changes.Org, Action: changes.Create, Name: "b"}, "b-guid", nil)
+		Expect(journal.Close()).Should(Succeed())
+
+		contents, err := os.ReadFile(path)
+		Expect(err).ShouldNot(HaveOccurred())
+		written := entries(string(contents))
+		Expect(written).Should(HaveLen(2))
+		Expect(written[1].RunID).Should(Equal("run-2"))
+		Expect(written[1].PrevHash).Should(Equal(written[0].Hash))
+		_, err = audit.Verify(bytes.NewReader(contents), "")
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,211 @@
+package changes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Action - the kind of modification a manager makes to an entity
+type Action string
+
+const (
+	Create   Action = "create"
+	Update   Action = "update"
+	Delete   Action = "delete"
+	Assign   Action = "assign"
+	Unassign Action = "unassign"
+)
+
+// Destructive - true for actions that remove something from the foundation
+func (a Action) Destructive() bool {
+	return a == Delete || a == Unassign
+}
+
+// EntityType - the kind of cloud foundry entity a change applies to
+type EntityType string
+
+const (
+	Org              EntityType = "org"
+	OrgMetadata      EntityType = "org-metadata"
+	OrgQuota         EntityType = "org-quota"
+	OrgRole          EntityType = "org-role"
+	Space            EntityType = "space"
+	SpaceMetadata    EntityType = "space-metadata"
+	SpaceQuota       EntityType = "space-quota"
+	SpaceRole        EntityType = "space-role"
+	SpaceSSH         EntityType = "space-ssh"
+	SecurityGroup    EntityType = "security-group"
+	User             EntityType = "user"
+	PrivateDomain    EntityType = "private-domain"
+	SharedDomain     EntityType = "shared-domain"
+	IsolationSegment EntityType = "isolation-segment"
+	ServiceAccess    EntityType = "service-access"
+	Setting          EntityType = "setting"
+)
+
+// Change - a single modification a manager made or, when peeking, would make
+type Change struct {
+	Entity EntityType  `json:"entity" yaml:"entity"`
+	Action Action      `json:"action" yaml:"action"`
+	Name   string      `json:"name" yaml:"name"`
+	Org    string      `json:"org,omitempty" yaml:"org,omitempty"`
+	Space  string      `json:"space,omitempty" yaml:"space,omitempty"`
+	Before interface{} `json:"before,omitempty" yaml:"before,omitempty"`
+	After  interface{} `json:"after,omitempty" yaml:"after,omitempty"`
+}
+
+// String - the change in a single line, e.g. "create space dev in org1"
+func (c Change) String() string {
+	description := fmt.Sprintf("%s %s %s", c.Action, c.Entity, c.Name)
+	switch {
+	case c.Space != "" && c.Space != c.Name:
+		return fmt.Sprintf("%s in %s/%s", description, c.Org, c.Space)
+	case c.Org != "" && c.Org != c.Name:
+		return fmt.Sprintf("%s in %s", description, c.Org)
+	}
+	return description
+}
+
+// Sink - receives each change once a manager has applied it to the foundation,
+// along with the guid of the entity and the error, if any, it failed with
+type Sink interface {
+	Applied(change Change, guid string, err error)
+}
+
+// Recorder - collects the changes reported by managers during a run.  A nil
+// Recorder is valid and discards everything so managers built without one
+// behave exactly as before.
+type Recorder struct {
+	mutex           sync.Mutex
+	changes         []recordedChange
+	step            int
+	sortWithinSteps bool
+	sinks           []Sink
+}
+
+type recordedChange struct {
+	change Change
+	step   int
+}
+
+// NewRecorder -
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Record - appends a change
+func (r *Recorder) Record(change Change) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.changes = append(r.changes, recordedChange{change: change, step: r.step})
+}
+
+// AddSink - forwards the outcome of every applied change to sink
+func (r *Recorder) AddSink(sink Sink) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.sinks = append(r.sinks, sink)
+}
+
+// Applied - reports that a recorded change was made, or failed with err.
+// Managers call it after the cloud foundry or uaa api call, so it is never
+// called when peeking.
+func (r *Recorder) Applied(change Change, guid string, err error) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	sinks := r.sinks
+	r.mutex.Unlock()
+	for _, sink := range sinks {
+		sink.Applied(change, guid, err)
+	}
+}
+
+// Reset - discards the recorded changes so the recorder can be reused for
+// another run
+func (r *Recorder) Reset() {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.changes = nil
+	r.step = 0
+}
+
+// BeginStep - marks the start of the next apply step
+func (r *Recorder) BeginStep() {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.step++
+}
+
+// SortWithinSteps - orders the changes of each step by org, space, entity, name
+// and action rather than by when they were recorded, so runs that reconcile orgs
+// concurrently always produce the same plan
+func (r *Recorder) SortWithinSteps() {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.sortWithinSteps = true
+}
+
+// Changes - returns a copy of the changes in the order they were recorded
+func (r *Recorder) Changes() []Change {
+	if r == nil {
+		return nil
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	recorded := make([]recordedChange, len(r.changes))
+	copy(recorded, r.changes)
+	if r.sortWithinSteps {
+		sort.SliceStable(recorded, func(i, j int) bool {
+			a, b := recorded[i], recorded[j]
+			if a.step != b.step {
+				return a.step < b.step
+			}
+			if a.change.Org != b.change.Org {
+				return a.change.Org < b.change.Org
+			}
+			if a.change.Space != b.change.Space {
+				return a.change.Space < b.change.Space
+			}
+			if a.change.Entity != b.change.Entity {
+				return a.change.Entity < b.change.Entity
+			}
+			if a.change.Name != b.change.Name {
+				return a.change.Name < b.change.Name
+			}
+			return a.change.Action < b.change.Action
+		})
+	}
+	result := make([]Change, len(recorded))
+	for i, recordedChange := range recorded {
+		result[i] = recordedChange.change
+	}
+	return result
+}
+
+// SplitEntityName - splits the org/space names used by role and user managers
+func SplitEntityName(entityName string) (string, string) {
+	parts := strings.SplitN(entityName, "/", 2)
+	if len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+	return entityName, ""
+}
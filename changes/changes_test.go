@@ -0,0 +1,206 @@
+package changes_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/vmwarepivotallabs/cf-mgmt/changes"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var _ = Describe("Changes", func() {
+	Context("Recorder", func() {
+		It("ignores changes when nil", func() {
+			var recorder *Recorder
+			recorder.Record(Change{Entity: Org, Action: Create, Name: "foo"})
+			Expect(recorder.Changes()).Should(BeEmpty())
+		})
+
+		It("returns changes in recorded order", func() {
+			recorder := NewRecorder()
+			recorder.Record(Change{Entity: Org, Action: Create, Name: "foo"})
+			recorder.Record(Change{Entity: Space, Action: Delete, Name: "bar", Org: "foo", Space: "bar"})
+			Expect(recorder.Changes()).Should(Equal([]Change{
+				{Entity: Org, Action: Create, Name: "foo"},
+				{Entity: Space, Action: Delete, Name: "bar", Org: "foo", Space: "bar"},
+			}))
+		})
+
+		It("forwards applied changes to the sink", func() {
+			recorder := NewRecorder()
+			recorder.Applied(Change{Entity: Org, Action: Create, Name: "ignored"}, "ignored-guid", nil)
+			sink := &fakeSink{}
+			recorder.AddSink(sink)
+			recorder.Applied(Change{Entity: Org, Action: Create, Name: "foo"}, "foo-guid", nil)
+			Expect(sink.changes).Should(Equal([]Change{{Entity: Org, Action: Create, Name: "foo"}}))
+			Expect(sink.guids).Should(Equal([]string{"foo-guid"}))
+			Expect(recorder.Changes()).Should(BeEmpty())
+		})
+
+		It("discards changes on reset", func() {
+			recorder := NewRecorder()
+			recorder.Record(Change{Entity: Org, Action: Create, Name: "foo"})
+			recorder.Reset()
+			recorder.Record(Change{Entity: Org, Action: Create, Name: "bar"})
+			Expect(recorder.Changes()).Should(Equal([]Change{{Entity: Org, Action: Create, Name: "bar"}}))
+		})
+
+		It("sorts changes by org and space within each step", func() {
+			recorder := NewRecorder()
+			recorder.SortWithinSteps()
+			recorder.BeginStep()
+			recorder.Record(Change{Entity: Space, Action: Create, Name: "b", Org: "org2", Space: "b"})
+			recorder.Record(Change{Entity: Space, Action: Create, Name: "a", Org: "org1", Space: "a"})
+			recorder.BeginStep()
+			recorder.Record(Change{Entity: SpaceRole, Action: Assign, Name: "user2", Org: "org1", Space: "b"})
+			recorder.Record(Change{Entity: SpaceRole, Action: Assign, Name: "user1", Org: "org1", Space: "a"})
+			recorder.Record(Change{Entity: SpaceRole, Action: Assign, Name: "user3", Org: "org1", Space: "a"})
+			var names []string
+			for _, change := range recorder.Changes() {
+				names = append(names, change.Name)
+			}
+			Expect(names).Should(Equal([]string{"a", "b", "user1", "user3", "user2"}))
+		})
+
+		It("orders changes of the same space by entity, name and action", func() {
+			recorder := NewRecorder()
+			recorder.SortWithinSteps()
+			recorder.Record(Change{Entity: SpaceRole, Action: Unassign, Name: "user2", Org: "org1", Space: "a"})
+			recorder.Record(Change{Entity: SpaceRole, Action: Assign, Name: "user2", Org: "org1", Space: "a"})
+			recorder.Record(Change{Entity: SpaceRole, Action: Assign, Name: "user1", Org: "org1", Space: "a"})
+			recorder.Record(Change{Entity: SpaceQuota, Action: Assign, Name: "small", Org: "org1", Space: "a"})
+			Expect(recorder.Changes()).Should(Equal([]Change{
+				{Entity: SpaceQuota, Action: Assign, Name: "small", Org: "org1", Space: "a"},
+				{Entity: SpaceRole, Action: Assign, Name: "user1", Org: "org1", Space: "a"},
+				{Entity: SpaceRole, Action: Assign, Name: "user2", Org: "org1", Space: "a"},
+				{Entity: SpaceRole, Action: Unassign, Name: "user2", Org: "org1", Space: "a"},
+			}))
+		})
+	})
+
+	Context("SplitEntityName", func() {
+		It("splits org/space", func() {
+			org, space := SplitEntityName("org/space")
+			Expect(org).Should(Equal("org"))
+			Expect(space).Should(Equal("space"))
+		})
+		It("returns org only", func() {
+			org, space := SplitEntityName("org")
+			Expect(org).Should(Equal("org"))
+			Expect(space).Should(BeEmpty())
+		})
+	})
+
+	Context("Outcomes", func() {
+		It("keeps applied changes by whether they failed", func() {
+			recorder := NewRecorder()
+			outcomes := NewOutcomes()
+			recorder.AddSink(outcomes)
+			recorder.Applied(Change{Entity: Org, Action: Create, Name: "foo"}, "foo-guid", nil)
+			recorder.Applied(Change{Entity: Space, Action: Create, Name: "bar", Org: "foo", Space: "bar"}, "", errors.New("failed"))
+			Expect(outcomes.Succeeded()).Should(Equal([]Change{{Entity: Org, Action: Create, Name: "foo"}}))
+			Expect(outcomes.Failed()).Should(Equal([]Change{{Entity: Space, Action: Create, Name: "bar", Org: "foo", Space: "bar"}}))
+			outcomes.Reset()
+			Expect(outcomes.Succeeded()).Should(BeEmpty())
+			Expect(outcomes.Failed()).Should(BeEmpty())
+		})
+
+		It("describes changes in a line", func() {
+			Expect(Change{Entity: Org, Action: Create, Name: "foo", Org: "foo"}.String()).Should(Equal("create org foo"))
+			Expect(Change{Entity: Space, Action: Delete, Name: "bar", Org: "foo", Space: "bar"}.String()).Should(Equal("delete space bar in foo"))
+			Expect(Change{Entity: SpaceRole, Action: Assign, Name: "user1", Org: "foo", Space: "bar"}.String()).Should(Equal("assign space-role user1 in foo/bar"))
+		})
+	})
+
+	Context("Plan", func() {
+		var plan *Plan
+		BeforeEach(func() {
+			plan = NewPlan([]Change{
+				{Entity: Org, Action: Create, Name: "foo"},
+				{Entity: Space, Action: Delete, Name: "bar", Org: "foo", Space: "bar"},
+				{Entity: SpaceRole, Action: Unassign, Name: "user|1", Org: "foo", Space: "bar", Before: "developer"},
+				{Entity: SpaceSSH, Action: Update, Name: "bar", Org: "foo", Space: "bar", Before: false, After: true},
+			})
+		})
+
+		It("summarizes actions", func() {
+			Expect(plan.Summary).Should(Equal(Summary{
+				Create:      1,
+				Update:      1,
+				Delete:      1,
+				Unassign:    1,
+				Destructive: 2,
+			}))
+		})
+
+		It("writes json", func() {
+			var buffer bytes.Buffer
+			Expect(plan.Write(&buffer, FormatJSON)).Should(Succeed())
+			result := &Plan{}
+			Expect(json.Unmarshal(buffer.Bytes(), result)).Should(Succeed())
+			Expect(result.Summary).Should(Equal(plan.Summary))
+			Expect(result.Changes).Should(HaveLen(4))
+			Expect(result.Changes[1].Action).Should(Equal(Delete))
+		})
+
+		It("writes yaml", func() {
+			var buffer bytes.Buffer
+			Expect(plan.Write(&buffer, FormatYAML)).Should(Succeed())
+			result := &Plan{}
+			Expect(yaml.Unmarshal(buffer.Bytes(), result)).Should(Succeed())
+			Expect(result.Summary).Should(Equal(plan.Summary))
+			Expect(result.Changes).Should(HaveLen(4))
+			Expect(result.Changes[3].After).Should(Equal(true))
+		})
+
+		It("writes markdown", func() {
+			var buffer bytes.Buffer
+			Expect(plan.Write(&buffer, FormatMarkdown)).Should(Succeed())
+			Expect(buffer.String()).Should(ContainSubstring("plan contains **2** destructive change(s)"))
+			Expect(buffer.String()).Should(ContainSubstring("| delete | space | foo | bar | bar |  |  |"))
+			Expect(buffer.String()).Should(ContainSubstring(`user\|1`))
+		})
+
+		It("writes markdown without changes", func() {
+			var buffer bytes.Buffer
+			Expect(NewPlan(nil).Write(&buffer, FormatMarkdown)).Should(Succeed())
+			Expect(buffer.String()).Should(ContainSubstring("No changes."))
+		})
+
+		It("writes text", func() {
+			var buffer bytes.Buffer
+			Expect(plan.Write(&buffer, FormatText)).Should(Succeed())
+			Expect(buffer.String()).Should(Equal(`create org foo
+delete space bar in foo
+unassign space-role user|1 in foo/bar: developer
+update space-ssh bar in foo: false -> true
+1 to create, 1 to update, 1 to delete, 0 to assign, 1 to unassign
+`))
+		})
+
+		It("titles markdown", func() {
+			var buffer bytes.Buffer
+			plan.Title = "cf-mgmt-config diff"
+			Expect(plan.Write(&buffer, FormatMarkdown)).Should(Succeed())
+			Expect(buffer.String()).Should(HavePrefix("## cf-mgmt-config diff\n"))
+		})
+
+		It("errors on unknown format", func() {
+			var buffer bytes.Buffer
+			Expect(plan.Write(&buffer, "xml")).Should(HaveOccurred())
+		})
+	})
+})
+
+type fakeSink struct {
+	changes []Change
+	guids   []string
+}
+
+func (s *fakeSink) Applied(change Change, guid string, err error) {
+	s.changes = append(s.changes, change)
+	s.guids = append(s.guids, guid)
+}
@@ -0,0 +1,58 @@
+package changes
+
+import "sync"
+
+// Outcomes - a Sink that keeps the changes applied during a run, so a run that
+// is interrupted can report what it got done
+type Outcomes struct {
+	mutex     sync.Mutex
+	succeeded []Change
+	failed    []Change
+}
+
+// NewOutcomes -
+func NewOutcomes() *Outcomes {
+	return &Outcomes{}
+}
+
+// Applied - keeps change as succeeded or, when err is set, failed
+func (o *Outcomes) Applied(change Change, guid string, err error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if err != nil {
+		o.failed = append(o.failed, change)
+		return
+	}
+	o.succeeded = append(o.succeeded, change)
+}
+
+// Succeeded - the changes applied without error, in the order they were made
+func (o *Outcomes) Succeeded() []Change {
+	if o == nil {
+		return nil
+	}
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	return append([]Change(nil), o.succeeded...)
+}
+
+// Failed - the changes whose api call failed, in the order they were made
+func (o *Outcomes) Failed() []Change {
+	if o == nil {
+		return nil
+	}
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	return append([]Change(nil), o.failed...)
+}
+
+// Reset - discards the outcomes so they can be collected for another run
+func (o *Outcomes) Reset() {
+	if o == nil {
+		return
+	}
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.succeeded = nil
+	o.failed = nil
+}
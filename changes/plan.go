@@ -0,0 +1,188 @@
+package changes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	FormatJSON     = "json"
+	FormatYAML     = "yaml"
+	FormatMarkdown = "markdown"
+	FormatText     = "text"
+)
+
+// Summary - number of changes per action
+type Summary struct {
+	Create      int `json:"create" yaml:"create"`
+	Update      int `json:"update" yaml:"update"`
+	Delete      int `json:"delete" yaml:"delete"`
+	Assign      int `json:"assign" yaml:"assign"`
+	Unassign    int `json:"unassign" yaml:"unassign"`
+	Destructive int `json:"destructive" yaml:"destructive"`
+}
+
+// Plan - the full change set of a run
+type Plan struct {
+	// Title - the heading of the markdown, cf-mgmt plan when empty
+	Title   string   `json:"-" yaml:"-"`
+	Summary Summary  `json:"summary" yaml:"summary"`
+	Changes []Change `json:"changes" yaml:"changes"`
+}
+
+// NewPlan - builds a plan with its summary from a list of changes
+func NewPlan(changeList []Change) *Plan {
+	plan := &Plan{Changes: changeList}
+	if plan.Changes == nil {
+		plan.Changes = []Change{}
+	}
+	for _, change := range plan.Changes {
+		switch change.Action {
+		case Create:
+			plan.Summary.Create++
+		case Update:
+			plan.Summary.Update++
+		case Delete:
+			plan.Summary.Delete++
+		case Assign:
+			plan.Summary.Assign++
+		case Unassign:
+			plan.Summary.Unassign++
+		}
+		if change.Action.Destructive() {
+			plan.Summary.Destructive++
+		}
+	}
+	return plan
+}
+
+// Write - renders the plan in the given format
+func (p *Plan) Write(w io.Writer, format string) error {
+	switch strings.ToLower(format) {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(p)
+	case FormatYAML:
+		// round trip through json so before/after values use the same keys in both formats
+		jsonBytes, err := json.Marshal(p)
+		if err != nil {
+			return err
+		}
+		var document interface{}
+		if err = json.Unmarshal(jsonBytes, &document); err != nil {
+			return err
+		}
+		bytes, err := yaml.Marshal(document)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(bytes)
+		return err
+	case FormatMarkdown:
+		return p.writeMarkdown(w)
+	case FormatText:
+		return p.writeText(w)
+	default:
+		return fmt.Errorf("unsupported output format [%s], must be one of %s, %s, %s or %s", format, FormatJSON, FormatYAML, FormatMarkdown, FormatText)
+	}
+}
+
+// writeText - a line for each change with its before and after values, then
+// the summary
+func (p *Plan) writeText(w io.Writer) error {
+	var sb strings.Builder
+	for _, change := range p.Changes {
+		sb.WriteString(change.String())
+		switch {
+		case change.Before != nil && change.After != nil:
+			fmt.Fprintf(&sb, ": %s -> %s", textValue(change.Before), textValue(change.After))
+		case change.After != nil:
+			fmt.Fprintf(&sb, ": %s", textValue(change.After))
+		case change.Before != nil:
+			fmt.Fprintf(&sb, ": %s", textValue(change.Before))
+		}
+		sb.WriteString("\n")
+	}
+	if len(p.Changes) == 0 {
+		sb.WriteString("No changes.\n")
+	} else {
+		fmt.Fprintf(&sb, "%d to create, %d to update, %d to delete, %d to assign, %d to unassign\n",
+			p.Summary.Create, p.Summary.Update, p.Summary.Delete, p.Summary.Assign, p.Summary.Unassign)
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func textValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case bool, int, int64, float64:
+		return fmt.Sprintf("%v", v)
+	}
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(bytes)
+}
+
+func (p *Plan) writeMarkdown(w io.Writer) error {
+	var sb strings.Builder
+	title := p.Title
+	if title == "" {
+		title = "cf-mgmt plan"
+	}
+	fmt.Fprintf(&sb, "## %s\n\n", title)
+	fmt.Fprintf(&sb, "**%d** to create, **%d** to update, **%d** to delete, **%d** to assign, **%d** to unassign\n\n",
+		p.Summary.Create, p.Summary.Update, p.Summary.Delete, p.Summary.Assign, p.Summary.Unassign)
+	if p.Summary.Destructive > 0 {
+		fmt.Fprintf(&sb, ":warning: plan contains **%d** destructive change(s)\n\n", p.Summary.Destructive)
+	}
+	if len(p.Changes) == 0 {
+		sb.WriteString("No changes.\n")
+		_, err := io.WriteString(w, sb.String())
+		return err
+	}
+	sb.WriteString("| Action | Entity | Org | Space | Name | Before | After |\n")
+	sb.WriteString("| --- | --- | --- | --- | --- | --- | --- |\n")
+	for _, change := range p.Changes {
+		fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s | %s | %s |\n",
+			change.Action,
+			change.Entity,
+			markdownCell(change.Org),
+			markdownCell(change.Space),
+			markdownCell(change.Name),
+			markdownValue(change.Before),
+			markdownValue(change.After))
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func markdownValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	switch v := value.(type) {
+	case string:
+		return markdownCell(v)
+	case bool, int, int64, float64:
+		return fmt.Sprintf("%v", v)
+	}
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return markdownCell(fmt.Sprintf("%v", value))
+	}
+	return "`" + strings.ReplaceAll(string(bytes), "|", "\\|") + "`"
+}
+
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.ReplaceAll(value, "\n", " ")
+}
@@ -1,13 +1,23 @@
 package commands
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/vmwarepivotallabs/cf-mgmt/failures"
+	"github.com/vmwarepivotallabs/cf-mgmt/util"
+	"github.com/xchapter7x/lo"
 )
 
 type ApplyCommand struct {
 	BaseCFConfigCommand
 	BasePeekCommand
 	BaseLDAPCommand
+	ContinueOnError bool   `long:"continue-on-error" env:"CONTINUE_ON_ERROR" description:"keep applying the remaining orgs, spaces and steps when one fails and report every failure at the end"`
+	MetricsTextfile string `long:"metrics-textfile" env:"METRICS_TEXTFILE" description:"write prometheus metrics for the run to this file at the end of the run, e.g. for the node exporter textfile collector"`
 }
 
 // Execute - applies all the config in order
@@ -21,108 +31,158 @@ func (c *ApplyCommand) Execute([]string) error {
 	if ldapMgr != nil {
 		defer ldapMgr.Close()
 	}
-	if cfMgmt, err = InitializePeekManagers(c.BaseCFConfigCommand, c.Peek, ldapMgr); err != nil {
-		return err
-	}
-	fmt.Println("*********  Creating Orgs")
-	if err = cfMgmt.OrgManager.CreateOrgs(); err != nil {
-		return err
-	}
-
-	fmt.Println("*********  Update Orgs Metadata")
-	if err = cfMgmt.OrgManager.UpdateOrgsMetadata(); err != nil {
-		return err
-	}
-
-	fmt.Println("*********  Delete Orgs")
-	if err = cfMgmt.OrgManager.DeleteOrgs(); err != nil {
-		return err
-	}
-
-	fmt.Println("*********  Update Org Users")
-	if errs := cfMgmt.UserManager.UpdateOrgUsers(); len(errs) > 0 {
-		return fmt.Errorf("got errors processing org users %v", errs)
-	}
-
-	fmt.Println("*********  Create Global Security Groups")
-	if err = cfMgmt.SecurityGroupManager.CreateGlobalSecurityGroups(); err != nil {
-		return err
-	}
-
-	fmt.Println("*********  Assign Default Security Groups")
-	if err = cfMgmt.SecurityGroupManager.AssignDefaultSecurityGroups(); err != nil {
-		return err
-	}
-
-	fmt.Println("*********  Create Private Domains")
-	if err = cfMgmt.PrivateDomainManager.CreatePrivateDomains(); err != nil {
-		return err
-	}
-
-	fmt.Println("*********  Share Private Domains")
-	if err = cfMgmt.PrivateDomainManager.SharePrivateDomains(); err != nil {
-		return err
+	var collector *failures.Collector
+	if c.ContinueOnError {
+		collector = failures.NewCollector()
 	}
-
-	fmt.Println("*********  Create Org Quotas")
-	if err = cfMgmt.QuotaManager.CreateOrgQuotas(); err != nil {
+	if cfMgmt, err = initializeManagers(c.BaseCFConfigCommand, c.Peek, ldapMgr, collector); err != nil {
 		return err
 	}
-
-	fmt.Println("*********  Create Spaces")
-	if err = cfMgmt.SpaceManager.CreateSpaces(); err != nil {
-		return err
-	}
-
-	fmt.Println("*********  Delete Spaces")
-	if err = cfMgmt.SpaceManager.DeleteSpaces(); err != nil {
-		return err
-	}
-
-	fmt.Println("*********  Update Spaces")
-	if err = cfMgmt.SpaceManager.UpdateSpaces(); err != nil {
-		return err
-	}
-
-	fmt.Println("*********  Update Spaces Metadata")
-	if err = cfMgmt.SpaceManager.UpdateSpacesMetadata(); err != nil {
-		return err
+	defer cfMgmt.Journal.Close()
+	ctx, stop := c.runContext()
+	defer stop()
+	err = cfMgmt.Apply(ctx, os.Stdout)
+	if c.MetricsTextfile != "" {
+		if metricsErr := cfMgmt.Metrics.Registry.WriteTextfile(c.MetricsTextfile); metricsErr != nil {
+			lo.G.Error(metricsErr)
+		}
 	}
+	return err
+}
 
-	fmt.Println("*********  Update Space Users")
-	if errs := cfMgmt.UserManager.UpdateSpaceUsers(); len(errs) > 0 {
-		return fmt.Errorf("got errors processing space users %v", errs)
-	}
+// applyStep - a single stage of apply
+type applyStep struct {
+	Name string
+	Run  func(ctx context.Context, cfMgmt *CFMgmt) error
+}
 
-	fmt.Println("*********  Create Space Quotas")
-	if err = cfMgmt.QuotaManager.CreateSpaceQuotas(); err != nil {
-		return err
-	}
+// foundationWideSteps - steps that reconcile the whole foundation rather than
+// individual orgs and spaces, skipped when only some orgs or spaces are selected
+var foundationWideSteps = map[string]bool{
+	"Create Global Security Groups":  true,
+	"Assign Default Security Groups": true,
+	"Isolation Segments":             true,
+	"Service Access":                 true,
+	"Shared Domains":                 true,
+}
 
-	fmt.Println("*********  Create Application Security Groups")
-	if err = cfMgmt.SecurityGroupManager.CreateApplicationSecurityGroups(); err != nil {
-		return err
-	}
+// applySteps - the order in which apply and plan process the configuration
+var applySteps = []applyStep{
+	{"Creating Orgs", func(ctx context.Context, c *CFMgmt) error { return c.OrgManager.CreateOrgs(ctx) }},
+	{"Update Orgs Metadata", func(ctx context.Context, c *CFMgmt) error { return c.OrgManager.UpdateOrgsMetadata(ctx) }},
+	{"Delete Orgs", func(ctx context.Context, c *CFMgmt) error { return c.OrgManager.DeleteOrgs(ctx) }},
+	{"Update Org Users", func(ctx context.Context, c *CFMgmt) error {
+		if errs := c.UserManager.UpdateOrgUsers(ctx); len(errs) > 0 {
+			return fmt.Errorf("got errors processing org users %v", errs)
+		}
+		return nil
+	}},
+	{"Create Global Security Groups", func(ctx context.Context, c *CFMgmt) error {
+		return c.SecurityGroupManager.CreateGlobalSecurityGroups(ctx)
+	}},
+	{"Assign Default Security Groups", func(ctx context.Context, c *CFMgmt) error {
+		return c.SecurityGroupManager.AssignDefaultSecurityGroups(ctx)
+	}},
+	{"Create Private Domains", func(ctx context.Context, c *CFMgmt) error { return c.PrivateDomainManager.CreatePrivateDomains(ctx) }},
+	{"Share Private Domains", func(ctx context.Context, c *CFMgmt) error { return c.PrivateDomainManager.SharePrivateDomains(ctx) }},
+	{"Create Org Quotas", func(ctx context.Context, c *CFMgmt) error { return c.QuotaManager.CreateOrgQuotas(ctx) }},
+	{"Create Spaces", func(ctx context.Context, c *CFMgmt) error { return c.SpaceManager.CreateSpaces(ctx) }},
+	{"Delete Spaces", func(ctx context.Context, c *CFMgmt) error { return c.SpaceManager.DeleteSpaces(ctx) }},
+	{"Update Spaces", func(ctx context.Context, c *CFMgmt) error { return c.SpaceManager.UpdateSpaces(ctx) }},
+	{"Update Spaces Metadata", func(ctx context.Context, c *CFMgmt) error { return c.SpaceManager.UpdateSpacesMetadata(ctx) }},
+	{"Update Space Users", func(ctx context.Context, c *CFMgmt) error {
+		if errs := c.UserManager.UpdateSpaceUsers(ctx); len(errs) > 0 {
+			return fmt.Errorf("got errors processing space users %v", errs)
+		}
+		return nil
+	}},
+	{"Create Space Quotas", func(ctx context.Context, c *CFMgmt) error { return c.QuotaManager.CreateSpaceQuotas(ctx) }},
+	{"Create Application Security Groups", func(ctx context.Context, c *CFMgmt) error {
+		return c.SecurityGroupManager.CreateApplicationSecurityGroups(ctx)
+	}},
+	{"Isolation Segments", func(ctx context.Context, c *CFMgmt) error { return c.IsolationSegmentManager.Apply(ctx) }},
+	{"Service Access", func(ctx context.Context, c *CFMgmt) error { return c.ServiceAccessManager.Apply(ctx) }},
+	{"Cleanup Org Users", func(ctx context.Context, c *CFMgmt) error {
+		if errs := c.UserManager.CleanupOrgUsers(ctx); len(errs) > 0 {
+			return fmt.Errorf("got errors processing cleanup org users %v", errs)
+		}
+		return nil
+	}},
+	{"Shared Domains", func(ctx context.Context, c *CFMgmt) error { return c.SharedDomainManager.Apply(ctx) }},
+}
 
-	fmt.Println("*********  Isolation Segments")
-	if err = cfMgmt.IsolationSegmentManager.Apply(); err != nil {
-		return err
-	}
+// Apply - runs every apply step in order, writing a banner for each step to out.
+// When failures are being collected a failing step doesn't stop the run, instead
+// a summary of everything that failed is written once all steps have run.  When
+// ctx is cancelled the step in progress stops starting new changes and a summary
+// of what was and wasn't applied is written instead.
+func (c *CFMgmt) Apply(ctx context.Context, out io.Writer) error {
+	start := time.Now()
+	c.Retries.ResetRetries()
+	c.Outcomes.Reset()
+	if c.UserManager != nil {
+		// the role-removals deletion limit counts the user steps of a run together
+		c.UserManager.ResetRoleRemovals()
+	}
+	err := c.apply(ctx, out)
+	if retries := util.RetrySummary(c.Retries.Retries()); retries != "" {
+		fmt.Fprintf(out, "*********  Retried %s\n", retries)
+	}
+	c.Metrics.ObserveRun(time.Since(start), err)
+	return err
+}
 
-	fmt.Println("*********  Service Access")
-	if err = cfMgmt.ServiceAccessManager.Apply(); err != nil {
+func (c *CFMgmt) apply(ctx context.Context, out io.Writer) error {
+	for i, step := range applySteps {
+		if err := ctx.Err(); err != nil {
+			c.writeInterrupted(out, err, applySteps[i:])
+			return err
+		}
+		fmt.Fprintf(out, "*********  %s\n", step.Name)
+		c.Recorder.BeginStep()
+		c.Failures.BeginStep(step.Name)
+		if foundationWideSteps[step.Name] && c.Selection != nil {
+			lo.G.Infof("skipping [%s] as it works on the whole foundation and only some orgs or spaces are selected", step.Name)
+			continue
+		}
+		stepStart := time.Now()
+		err := step.Run(ctx, c)
+		c.Metrics.ObserveStep(step.Name, time.Since(stepStart), err)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			c.writeInterrupted(out, ctxErr, applySteps[i+1:])
+			return ctxErr
+		}
+		if err := c.Failures.Add("", "", err); err != nil {
+			return err
+		}
+	}
+	if err := c.Failures.Err(); err != nil {
+		fmt.Fprintf(out, "*********  Failures\n")
+		c.Failures.WriteSummary(out)
 		return err
 	}
+	return nil
+}
 
-	fmt.Println("*********  Cleanup Org Users")
-	if errs := cfMgmt.UserManager.CleanupOrgUsers(); len(errs) > 0 {
-		return fmt.Errorf("got errors processing cleanup org users %v", errs)
-	}
-
-	fmt.Println("*********  Shared Domains")
-	if err = cfMgmt.SharedDomainManager.Apply(); err != nil {
-		return err
+// writeInterrupted - writes the changes applied before ctx was cancelled and the
+// steps that were not run
+func (c *CFMgmt) writeInterrupted(out io.Writer, cause error, notRun []applyStep) {
+	fmt.Fprintf(out, "*********  Interrupted (%v)\n", cause)
+	succeeded, failed := c.Outcomes.Succeeded(), c.Outcomes.Failed()
+	fmt.Fprintf(out, "applied %d change(s)\n", len(succeeded))
+	for _, change := range succeeded {
+		fmt.Fprintf(out, "  %s\n", change)
+	}
+	if len(failed) > 0 {
+		fmt.Fprintf(out, "failed to apply %d change(s)\n", len(failed))
+		for _, change := range failed {
+			fmt.Fprintf(out, "  %s\n", change)
+		}
+	}
+	if len(notRun) > 0 {
+		fmt.Fprintf(out, "did not run %d step(s)\n", len(notRun))
+		for _, step := range notRun {
+			fmt.Fprintf(out, "  %s\n", step.Name)
+		}
 	}
-
-	return nil
 }
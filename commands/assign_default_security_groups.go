@@ -7,10 +7,15 @@ type AssignDefaultSecurityGroups struct {
 
 // Execute - creates security groups
 func (c *AssignDefaultSecurityGroups) Execute([]string) error {
+	if err := c.requireFoundationWide("assign-default-security-groups"); err != nil {
+		return err
+	}
 	var cfMgmt *CFMgmt
 	var err error
 	if cfMgmt, err = InitializePeekManagers(c.BaseCFConfigCommand, c.Peek, nil); err == nil {
-		err = cfMgmt.SecurityGroupManager.AssignDefaultSecurityGroups()
+		ctx, stop := c.runContext()
+		defer stop()
+		err = cfMgmt.SecurityGroupManager.AssignDefaultSecurityGroups(ctx)
 	}
 	return err
 }
@@ -27,8 +27,11 @@ type CfMgmtCommand struct {
 	SharedDomainsCommand             SharedDomainsCommand             `command:"shared-domains" description:"adds/removes shared domains"`
 	UpdateOrgsMetadataCommand        UpdateOrgsMetadataCommand        `command:"update-orgs-metadata" description:"updates organizations metadata for each orgConfig.yml"`
 	ApplyCommand                     ApplyCommand                     `command:"apply" description:"applies the configuration to your target foundation"`
-	ExportConfigurationCommand       ExportConfigurationCommand       `command:"export-config" description:"Exports org and space configurations from an existing Cloud Foundry instance. [Warning: This operation will delete existing config folder]"`
+	PlanCommand                      PlanCommand                      `command:"plan" description:"outputs the changes apply would make to your target foundation as json, yaml or markdown"`
+	ServeCommand                     ServeCommand                     `command:"serve" description:"applies the configuration on an interval and whenever it changes, serving health and status over http"`
+	ExportConfigurationCommand       ExportConfigurationCommand       `command:"export-config" description:"Exports org and space configurations from an existing Cloud Foundry instance. [Warning: This operation will delete existing config folder unless --merge is used]"`
 	ExportServiceAccessCommand       ExportServiceAccessCommand       `command:"export-service-access-config" description:"reverse engineer service access into cf-mgmt.yml and remove from orgConfig.yml(s) if present"`
+	VerifyAuditLogCommand            VerifyAuditLogCommand            `command:"verify-audit-log" description:"checks the hash chain of an audit log written with --audit-hash-chain"`
 }
 
 var CfMgmt CfMgmtCommand
@@ -13,7 +13,9 @@ func (c *CleanupOrgUsersCommand) Execute([]string) error {
 	if err != nil {
 		return err
 	}
-	errs := cfMgmt.UserManager.CleanupOrgUsers()
+	ctx, stop := c.runContext()
+	defer stop()
+	errs := cfMgmt.UserManager.CleanupOrgUsers(ctx)
 	if len(errs) > 0 {
 		return fmt.Errorf("got errors processing cleanup users %v", errs)
 	}
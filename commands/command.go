@@ -1,14 +1,87 @@
 package commands
 
-import "github.com/vmwarepivotallabs/cf-mgmt/configcommands"
+import (
+	"fmt"
+	"time"
+
+	"github.com/vmwarepivotallabs/cf-mgmt/config"
+	"github.com/vmwarepivotallabs/cf-mgmt/configcommands"
+	"github.com/vmwarepivotallabs/cf-mgmt/util"
+	"github.com/xchapter7x/lo"
+)
 
 // BaseCFConfigCommand - base command that has details to connect to cloud foundry instance
 type BaseCFConfigCommand struct {
 	configcommands.BaseConfigCommand
-	SystemDomain string `long:"system-domain" env:"SYSTEM_DOMAIN"  description:"system domain"`
-	UserID       string `long:"user-id" env:"USER_ID"  description:"user id that has privileges to create/update/delete users, orgs and spaces"`
-	Password     string `long:"password" env:"PASSWORD"  description:"password for user account [optional if client secret is provided]"`
-	ClientSecret string `long:"client-secret" env:"CLIENT_SECRET" description:"secret for user account that has sufficient privileges to create/update/delete users, orgs and spaces]"`
+	configcommands.BaseVarsCommand
+	SystemDomain        string        `long:"system-domain" env:"SYSTEM_DOMAIN"  description:"system domain"`
+	APIURL              string        `long:"api-url" env:"API_URL" description:"cloud controller url, the uaa, login and routing api urls are discovered from it [defaults to https://api.<system-domain>]"`
+	UserID              string        `long:"user-id" env:"USER_ID"  description:"user id that has privileges to create/update/delete users, orgs and spaces"`
+	Password            string        `long:"password" env:"PASSWORD"  description:"password for user account [optional if client secret is provided]"`
+	ClientSecret        string        `long:"client-secret" env:"CLIENT_SECRET" description:"secret for user account that has sufficient privileges to create/update/delete users, orgs and spaces]"`
+	Parallelism         int           `long:"parallelism" env:"PARALLELISM" default:"1" description:"number of orgs to reconcile concurrently when updating spaces, space users, space quotas and application security groups. The plan and the log lines of each org are kept in config order"`
+	Orgs                []string      `long:"org" env:"ORGS" env-delim:"," description:"only process this org. Repeat the flag to specify multiple orgs"`
+	OrgRegex            string        `long:"org-regex" env:"ORG_REGEX" description:"only process orgs whose name matches this regular expression"`
+	Spaces              []string      `long:"space" env:"SPACES" env-delim:"," description:"only process this space of the selected orgs. Repeat the flag to specify multiple spaces"`
+	MaxRetries          int           `long:"max-retries" env:"MAX_RETRIES" default:"3" description:"times to retry a cloud foundry, uaa or routing api request that failed with a network error, 429, 502, 503 or 504 when it is safe to"`
+	RetryBackoff        time.Duration `long:"retry-backoff" env:"RETRY_BACKOFF" default:"1s" description:"wait before the first retry, doubling for each retry up to 30s, unless the response asks for longer with Retry-After"`
+	RequestsPerSecond   float64       `long:"requests-per-second" env:"REQUESTS_PER_SECOND" default:"0" description:"most requests to make to the cloud foundry, uaa and routing apis per second, 0 is unlimited"`
+	Timeout             time.Duration `long:"timeout" env:"TIMEOUT" default:"0" description:"stop starting new changes once the run has taken this long, letting the ones in progress finish, 0 is no limit"`
+	RequestTimeout      time.Duration `long:"request-timeout" env:"REQUEST_TIMEOUT" default:"5m" description:"give up on a cloud foundry, uaa or routing api request, including its retries, after this long"`
+	AllowLargeDeletions bool          `long:"allow-large-deletions" env:"ALLOW_LARGE_DELETIONS" description:"delete orgs, spaces, users and domains even when it exceeds the deletion-limits in cf-mgmt.yml"`
+	LabelSelector       string        `long:"label-selector" env:"LABEL_SELECTOR" description:"only process orgs and spaces whose configured metadata labels match, e.g. env=prod,tier!=gold,team,!legacy"`
+	AuditLog            string        `long:"audit-log" env:"AUDIT_LOG" description:"append a JSON Lines entry for every change made to cloud foundry or uaa to this file"`
+	AuditHashChain      bool          `long:"audit-hash-chain" env:"AUDIT_HASH_CHAIN" description:"chain the audit log entries with sha256 hashes so edited or removed entries can be detected"`
+	AuditRunID          string        `long:"audit-run-id" env:"AUDIT_RUN_ID" description:"run id written to the audit log [defaults to a generated id]"`
+	AuditConfigCommit   string        `long:"audit-config-commit" env:"AUDIT_CONFIG_COMMIT" description:"config commit written to the audit log [defaults to the git commit of the config directory]"`
+	SkipSSLValidation   string        `long:"skip-ssl-validation" env:"SKIP_SSL_VALIDATION" optional:"yes" optional-value:"true" choice:"true" choice:"false" description:"skip verifying the cloud foundry, uaa and routing api certificates [defaults to true unless ca-cert is set, will default to false in a future major release]"`
+	CACert              string        `long:"ca-cert" env:"CA_CERT" description:"PEM file of CA certificates to trust, in addition to the system ones, when verifying the cloud foundry, uaa and routing api certificates"`
+	ClientCert          string        `long:"client-cert" env:"CLIENT_CERT" description:"PEM file of a client certificate to present to the cloud foundry, uaa and routing apis"`
+	ClientKey           string        `long:"client-key" env:"CLIENT_KEY" description:"PEM file of the private key of client-cert"`
+}
+
+// Selection - the orgs and spaces selected by the filter options or nil when none are set
+func (c BaseCFConfigCommand) Selection() (*config.Selection, error) {
+	return config.NewSelection(c.Orgs, c.OrgRegex, c.Spaces, c.LabelSelector)
+}
+
+// configReader - the config read from config-dir and its overlays with the
+// ((var)) placeholders resolved, strictly when asked to
+func (c BaseCFConfigCommand) configReader() (config.Manager, error) {
+	layers, err := c.ReadLayers(c.ConfigLayers())
+	if err != nil {
+		return nil, err
+	}
+	return config.NewLayeredManager(layers), nil
+}
+
+// TLSOptions - how to verify the cloud foundry, uaa and routing apis.  Until
+// verification becomes the default it is only on when asked for, either
+// explicitly or by providing a ca cert.
+func (c BaseCFConfigCommand) TLSOptions() util.TLSOptions {
+	skip := c.SkipSSLValidation == "true" || (c.SkipSSLValidation == "" && c.CACert == "")
+	if skip {
+		lo.G.Warning("Skipping verification of the cloud foundry, uaa and routing api certificates. Set --skip-ssl-validation=false or --ca-cert to verify them, verification will be on by default in a future major release")
+	}
+	return util.TLSOptions{
+		SkipSSLValidation: skip,
+		CACertFile:        c.CACert,
+		ClientCertFile:    c.ClientCert,
+		ClientKeyFile:     c.ClientKey,
+	}
+}
+
+// requireFoundationWide - errors when org or space filters are set for a command
+// that always works on the whole foundation
+func (c BaseCFConfigCommand) requireFoundationWide(command string) error {
+	selection, err := c.Selection()
+	if err != nil {
+		return err
+	}
+	if selection != nil {
+		return fmt.Errorf("%s works on the whole foundation and can't be combined with --org, --org-regex, --space or --label-selector", command)
+	}
+	return nil
 }
 
 // BaseLDAPCommand - base command that has ldap password
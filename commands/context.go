@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/xchapter7x/lo"
+)
+
+// interruptedExitCode - the exit code when a second signal stops cf-mgmt
+// without waiting for the changes in progress
+const interruptedExitCode = 130
+
+// runContext - a context that is cancelled on SIGINT or SIGTERM, or once
+// timeout has passed when it is set.  Managers stop starting new changes when
+// it is cancelled and let the api calls in progress finish; a second signal
+// exits straight away.  The returned func releases the signal handler.
+func (c BaseCFConfigCommand) runContext() (context.Context, context.CancelFunc) {
+	return signalContext(context.Background(), c.Timeout)
+}
+
+func signalContext(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	if timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, timeout)
+		cancelParent := cancel
+		cancel = func() {
+			cancelTimeout()
+			cancelParent()
+		}
+	}
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case sig := <-signals:
+			lo.G.Warningf("received %s, finishing the changes in progress before stopping, send it again to stop straight away", sig)
+			cancel()
+		case <-done:
+			return
+		}
+		select {
+		case sig := <-signals:
+			lo.G.Errorf("received %s again, stopping without finishing the changes in progress", sig)
+			os.Exit(interruptedExitCode)
+		case <-done:
+		}
+	}()
+	return ctx, func() {
+		signal.Stop(signals)
+		close(done)
+		cancel()
+	}
+}
@@ -10,7 +10,9 @@ func (c *CreateOrgsCommand) Execute([]string) error {
 	var cfMgmt *CFMgmt
 	var err error
 	if cfMgmt, err = InitializePeekManagers(c.BaseCFConfigCommand, c.Peek, nil); err == nil {
-		err = cfMgmt.OrgManager.CreateOrgs()
+		ctx, stop := c.runContext()
+		defer stop()
+		err = cfMgmt.OrgManager.CreateOrgs(ctx)
 	}
 	return err
 }
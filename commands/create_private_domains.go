@@ -10,7 +10,9 @@ func (c *CreatePrivateDomainsCommand) Execute([]string) error {
 	var cfMgmt *CFMgmt
 	var err error
 	if cfMgmt, err = InitializePeekManagers(c.BaseCFConfigCommand, c.Peek, nil); err == nil {
-		err = cfMgmt.PrivateDomainManager.CreatePrivateDomains()
+		ctx, stop := c.runContext()
+		defer stop()
+		err = cfMgmt.PrivateDomainManager.CreatePrivateDomains(ctx)
 	}
 	return err
 }
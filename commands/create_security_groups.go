@@ -7,10 +7,15 @@ type CreateSecurityGroupsCommand struct {
 
 // Execute - creates security groups
 func (c *CreateSecurityGroupsCommand) Execute([]string) error {
+	if err := c.requireFoundationWide("create-security-groups"); err != nil {
+		return err
+	}
 	var cfMgmt *CFMgmt
 	var err error
 	if cfMgmt, err = InitializePeekManagers(c.BaseCFConfigCommand, c.Peek, nil); err == nil {
-		err = cfMgmt.SecurityGroupManager.CreateGlobalSecurityGroups()
+		ctx, stop := c.runContext()
+		defer stop()
+		err = cfMgmt.SecurityGroupManager.CreateGlobalSecurityGroups(ctx)
 	}
 	return err
 }
@@ -10,7 +10,9 @@ func (c *CreateSpaceSecurityGroupsCommand) Execute([]string) error {
 	var cfMgmt *CFMgmt
 	var err error
 	if cfMgmt, err = InitializePeekManagers(c.BaseCFConfigCommand, c.Peek, nil); err == nil {
-		err = cfMgmt.SecurityGroupManager.CreateApplicationSecurityGroups()
+		ctx, stop := c.runContext()
+		defer stop()
+		err = cfMgmt.SecurityGroupManager.CreateApplicationSecurityGroups(ctx)
 	}
 	return err
 }
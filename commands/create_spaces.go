@@ -10,7 +10,9 @@ func (c *CreateSpacesCommand) Execute([]string) error {
 	var cfMgmt *CFMgmt
 	var err error
 	if cfMgmt, err = InitializePeekManagers(c.BaseCFConfigCommand, c.Peek, nil); err == nil {
-		err = cfMgmt.SpaceManager.CreateSpaces()
+		ctx, stop := c.runContext()
+		defer stop()
+		err = cfMgmt.SpaceManager.CreateSpaces(ctx)
 	}
 	return err
 }
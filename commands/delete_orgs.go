@@ -10,7 +10,9 @@ func (c *DeleteOrgsCommand) Execute([]string) error {
 	var cfMgmt *CFMgmt
 	var err error
 	if cfMgmt, err = InitializePeekManagers(c.BaseCFConfigCommand, c.Peek, nil); err == nil {
-		err = cfMgmt.OrgManager.DeleteOrgs()
+		ctx, stop := c.runContext()
+		defer stop()
+		err = cfMgmt.OrgManager.DeleteOrgs(ctx)
 	}
 	return err
 }
@@ -10,7 +10,9 @@ func (c *DeleteSpacesCommand) Execute([]string) error {
 	var cfMgmt *CFMgmt
 	var err error
 	if cfMgmt, err = InitializePeekManagers(c.BaseCFConfigCommand, c.Peek, nil); err == nil {
-		err = cfMgmt.SpaceManager.DeleteSpaces()
+		ctx, stop := c.runContext()
+		defer stop()
+		err = cfMgmt.SpaceManager.DeleteSpaces(ctx)
 	}
 	return err
 }
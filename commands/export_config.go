@@ -1,6 +1,8 @@
 package commands
 
 import (
+	"fmt"
+
 	"github.com/vmwarepivotallabs/cf-mgmt/config"
 	"github.com/vmwarepivotallabs/cf-mgmt/export"
 	"github.com/xchapter7x/lo"
@@ -13,10 +15,18 @@ type ExportConfigurationCommand struct {
 	SkipSpaces            bool     `long:"skip-spaces" description:"Will not export space configurations"`
 	SkipRoutingGroups     bool     `long:"skip-routing-groups" description:"Will not export routing groups. Set to true if tcp routing is not configured"`
 	DisableMetadataPrefix bool     `long:"disable-metadata-prefix" description:"Disable using metadata prefixes"`
+	OrgDefaults           bool     `long:"org-defaults" description:"Move the values every org has in common to orgDefaults.yml"`
+	Merge                 bool     `long:"merge" description:"Merge the foundation into the existing config rather than replacing it, and print what changed"`
 }
 
 // Execute - initializes cf-mgmt configuration
 func (c *ExportConfigurationCommand) Execute([]string) error {
+	if err := c.requireFoundationWide("export-config"); err != nil {
+		return err
+	}
+	if c.Merge && c.OrgDefaults {
+		return fmt.Errorf("--org-defaults can't be used with --merge, which leaves orgDefaults.yml as it is")
+	}
 	if cfMgmt, err := InitializeManagers(c.BaseCFConfigCommand); err != nil {
 		lo.G.Errorf("Unable to initialize cf-mgmt. Error : %s", err)
 		return err
@@ -35,6 +45,7 @@ func (c *ExportConfigurationCommand) Execute([]string) error {
 			cfMgmt.RoleManager,
 		)
 		exportManager.SkipRoutingGroups = c.SkipRoutingGroups
+		exportManager.FactorOrgDefaults = c.OrgDefaults
 		excludedOrgs := make(map[string]string)
 		for _, org := range config.DefaultProtectedOrgs {
 			excludedOrgs[org] = org
@@ -49,7 +60,24 @@ func (c *ExportConfigurationCommand) Execute([]string) error {
 		lo.G.Infof("Orgs excluded from export by default: %v ", config.DefaultProtectedOrgs)
 		lo.G.Infof("Orgs excluded from export by user:  %v ", c.ExcludedOrgs)
 		lo.G.Infof("Spaces excluded from export by user:  %v ", c.ExcludedSpaces)
-		err = exportManager.ExportConfig(excludedOrgs, excludedSpaces, c.SkipSpaces, !c.DisableMetadataPrefix)
+		ctx, stop := c.runContext()
+		defer stop()
+		if c.Merge {
+			if exportManager.ConfigLayers, err = c.ReadLayers(c.ConfigLayers()); err != nil {
+				return err
+			}
+			changes, err := exportManager.MergeConfig(ctx, excludedOrgs, excludedSpaces, c.SkipSpaces, !c.DisableMetadataPrefix)
+			if err != nil {
+				lo.G.Errorf("Export failed with error:  %s", err)
+				return err
+			}
+			for _, change := range changes {
+				fmt.Println(fmt.Sprintf("%s/%s", c.ConfigDirectory, change))
+			}
+			fmt.Println(fmt.Sprintf("Merged %d changes into %s", len(changes), c.ConfigDirectory))
+			return nil
+		}
+		err = exportManager.ExportConfig(ctx, excludedOrgs, excludedSpaces, c.SkipSpaces, !c.DisableMetadataPrefix)
 		if err != nil {
 			lo.G.Errorf("Export failed with error:  %s", err)
 			return err
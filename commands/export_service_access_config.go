@@ -10,6 +10,9 @@ type ExportServiceAccessCommand struct {
 
 // ExportServiceAccessCommand - updates commands to reverse engineer service access into cf-mgmt.yml and remove from orgConfig.yml if present
 func (c *ExportServiceAccessCommand) Execute([]string) error {
+	if err := c.requireFoundationWide("export-service-access-config"); err != nil {
+		return err
+	}
 	var cfMgmt *CFMgmt
 	var err error
 	if cfMgmt, err = InitializeManagers(c.BaseCFConfigCommand); err == nil {
@@ -25,7 +28,9 @@ func (c *ExportServiceAccessCommand) Execute([]string) error {
 			cfMgmt.ServiceAccessManager,
 			cfMgmt.QuotaManager, cfMgmt.RoleManager)
 
-		return exportManager.ExportServiceAccess()
+		ctx, stop := c.runContext()
+		defer stop()
+		return exportManager.ExportServiceAccess(ctx)
 	}
 	return err
 }
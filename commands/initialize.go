@@ -1,21 +1,25 @@
 package commands
 
 import (
-	"crypto/tls"
 	"fmt"
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
-	routing_api "code.cloudfoundry.org/routing-api"
 	cfclient "github.com/cloudfoundry-community/go-cfclient"
 	v3cfclient "github.com/cloudfoundry-community/go-cfclient/v3/client"
 	v3config "github.com/cloudfoundry-community/go-cfclient/v3/config"
 
+	"github.com/vmwarepivotallabs/cf-mgmt/audit"
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
 	"github.com/vmwarepivotallabs/cf-mgmt/config"
 	"github.com/vmwarepivotallabs/cf-mgmt/configcommands"
+	"github.com/vmwarepivotallabs/cf-mgmt/endpoints"
+	"github.com/vmwarepivotallabs/cf-mgmt/failures"
 	"github.com/vmwarepivotallabs/cf-mgmt/isosegment"
 	"github.com/vmwarepivotallabs/cf-mgmt/ldap"
+	"github.com/vmwarepivotallabs/cf-mgmt/metrics"
 	"github.com/vmwarepivotallabs/cf-mgmt/organization"
 	"github.com/vmwarepivotallabs/cf-mgmt/organizationreader"
 	"github.com/vmwarepivotallabs/cf-mgmt/privatedomain"
@@ -47,6 +51,13 @@ type CFMgmt struct {
 	ServiceAccessManager    *serviceaccess.Manager
 	SharedDomainManager     *shareddomain.Manager
 	RoleManager             role.Manager
+	Recorder                *changes.Recorder
+	Outcomes                *changes.Outcomes
+	Journal                 *audit.Journal
+	Metrics                 *metrics.Metrics
+	Retries                 *util.RetryTransport
+	Failures                *failures.Collector
+	Selection               *config.Selection
 }
 
 type Initialize struct {
@@ -59,7 +70,10 @@ func InitializeManagers(baseCommand BaseCFConfigCommand) (*CFMgmt, error) {
 }
 
 func InitializeLdapManager(baseCommand BaseCFConfigCommand, ldapCommand BaseLDAPCommand) (*ldap.Manager, error) {
-	cfg := config.NewManager(baseCommand.ConfigDirectory)
+	cfg, err := baseCommand.configReader()
+	if err != nil {
+		return nil, err
+	}
 	ldapConfig, err := cfg.LdapConfig(ldapCommand.LdapUser, ldapCommand.LdapPassword, ldapCommand.LdapServer)
 	if err != nil {
 		return nil, err
@@ -75,25 +89,84 @@ func InitializeLdapManager(baseCommand BaseCFConfigCommand, ldapCommand BaseLDAP
 }
 
 func InitializePeekManagers(baseCommand BaseCFConfigCommand, peek bool, ldapMgr *ldap.Manager) (*CFMgmt, error) {
+	return initializeManagers(baseCommand, peek, ldapMgr, nil)
+}
+
+// maxRetryBackoff - the longest wait between retries, unless the api asks for
+// longer with Retry-After
+const maxRetryBackoff = 30 * time.Second
+
+// openJournal - opens the audit journal, defaulting the run id and taking the
+// config commit from the config directory when they aren't given
+func openJournal(baseCommand BaseCFConfigCommand) (*audit.Journal, error) {
+	runID := baseCommand.AuditRunID
+	if runID == "" {
+		runID = audit.NewRunID()
+	}
+	configCommit := baseCommand.AuditConfigCommit
+	if configCommit == "" {
+		configCommit = audit.ConfigCommit(baseCommand.ConfigDirectory)
+	}
+	lo.G.Infof("Writing audit journal for run %s to %s", runID, baseCommand.AuditLog)
+	return audit.Open(baseCommand.AuditLog, runID, configCommit, baseCommand.AuditHashChain)
+}
+
+// initializeManagers - builds the managers, collecting per org and space failures in
+// collector instead of stopping at the first one when it is set
+func initializeManagers(baseCommand BaseCFConfigCommand, peek bool, ldapMgr *ldap.Manager, collector *failures.Collector) (*CFMgmt, error) {
 	lo.G.Debugf("Using %s of cf-mgmt", configcommands.GetFormattedVersion())
-	if baseCommand.SystemDomain == "" ||
+	if (baseCommand.SystemDomain == "" && baseCommand.APIURL == "") ||
 		baseCommand.UserID == "" ||
 		baseCommand.ClientSecret == "" {
-		return nil, fmt.Errorf("must set system-domain, user-id, client-secret properties")
+		return nil, fmt.Errorf("must set system-domain or api-url, user-id, client-secret properties")
 	}
 
-	cfg := config.NewManager(baseCommand.ConfigDirectory)
-	var err error
+	selection, err := baseCommand.Selection()
+	if err != nil {
+		return nil, err
+	}
+	reader, err := baseCommand.configReader()
+	if err != nil {
+		return nil, err
+	}
+	cfg := config.NewSelectedReader(reader, selection)
 	cfMgmt := &CFMgmt{}
+	cfMgmt.Selection = selection
 	cfMgmt.ConfigDirectory = baseCommand.ConfigDirectory
 	cfMgmt.SystemDomain = baseCommand.SystemDomain
-	cfMgmt.ConfigManager = config.NewManager(cfMgmt.ConfigDirectory)
+	cfMgmt.ConfigManager = config.NewManager(cfMgmt.ConfigDirectory, baseCommand.Overlays...)
+	cfMgmt.Recorder = changes.NewRecorder()
+	cfMgmt.Failures = collector
+	if baseCommand.Parallelism > 1 {
+		// changes from concurrently reconciled orgs are recorded in any order
+		cfMgmt.Recorder.SortWithinSteps()
+	}
+	cfMgmt.Metrics = metrics.New()
+	if !peek {
+		cfMgmt.Recorder.AddSink(cfMgmt.Metrics)
+		cfMgmt.Outcomes = changes.NewOutcomes()
+		cfMgmt.Recorder.AddSink(cfMgmt.Outcomes)
+	}
+	if ldapMgr != nil {
+		ldapMgr.Metrics = cfMgmt.Metrics
+	}
+	if baseCommand.AuditLog != "" && !peek {
+		journal, err := openJournal(baseCommand)
+		if err != nil {
+			return nil, err
+		}
+		cfMgmt.Journal = journal
+		cfMgmt.Recorder.AddSink(journal)
+	}
 
+	tlsOptions := baseCommand.TLSOptions()
+	tlsConfig, err := tlsOptions.Config()
+	if err != nil {
+		return nil, err
+	}
 	httpClient := &http.Client{
 		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
+			TLSClientConfig: tlsConfig,
 		},
 	}
 	if strings.EqualFold(os.Getenv("LOG_LEVEL"), "trace") {
@@ -102,47 +175,49 @@ func InitializePeekManagers(baseCommand BaseCFConfigCommand, peek bool, ldapMgr
 			Transport: loggingTranport,
 		}
 	}
+	limiter := util.NewRateLimiter(baseCommand.RequestsPerSecond)
+	cfMgmt.Retries = util.NewRetryTransport(metrics.NewTransport(httpClient.Transport, cfMgmt.Metrics), baseCommand.MaxRetries, baseCommand.RetryBackoff, maxRetryBackoff, limiter)
+	cfMgmt.Retries.OnRetry = func(reason string) { cfMgmt.Metrics.APIRetries.Inc(reason) }
+	httpClient = &http.Client{
+		Transport: cfMgmt.Retries,
+		Timeout:   baseCommand.RequestTimeout,
+	}
+
+	apiEndpoints, err := endpoints.Discover(httpClient, baseCommand.APIURL, baseCommand.SystemDomain)
+	if err != nil {
+		return nil, err
+	}
 
 	userAgent := fmt.Sprintf("cf-mgmt/%s", configcommands.VERSION)
-	uaaMgr, err := uaa.NewDefaultUAAManager(cfMgmt.SystemDomain, baseCommand.UserID, baseCommand.ClientSecret, userAgent, httpClient, peek)
+	uaaMgr, err := uaa.NewDefaultUAAManager(apiEndpoints.UAA, baseCommand.UserID, baseCommand.ClientSecret, userAgent, httpClient, tlsOptions.SkipSSLValidation, cfMgmt.Recorder, peek)
 	if err != nil {
 		return nil, err
 	}
 	cfMgmt.UAAManager = uaaMgr
 
 	var c *cfclient.Config
-	var cv3 *v3config.Config
 	if baseCommand.Password != "" {
 		lo.G.Warning("Password parameter is deprecated, create uaa client and client-secret instead")
 		c = &cfclient.Config{
-			ApiAddress: fmt.Sprintf("https://api.%s", cfMgmt.SystemDomain),
+			ApiAddress: apiEndpoints.API,
 			Username:   baseCommand.UserID,
 			Password:   baseCommand.Password,
 			UserAgent:  userAgent,
 		}
-		cv3, err = v3config.NewUserPassword(fmt.Sprintf("https://api.%s", cfMgmt.SystemDomain),
-			baseCommand.UserID,
-			baseCommand.Password)
-		if err != nil {
-			return nil, err
-		}
 	} else {
 		c = &cfclient.Config{
-			ApiAddress:   fmt.Sprintf("https://api.%s", cfMgmt.SystemDomain),
+			ApiAddress:   apiEndpoints.API,
 			ClientID:     baseCommand.UserID,
 			ClientSecret: baseCommand.ClientSecret,
 			UserAgent:    userAgent,
 		}
-		cv3, err = v3config.NewClientSecret(fmt.Sprintf("https://api.%s", cfMgmt.SystemDomain),
-			baseCommand.UserID,
-			baseCommand.ClientSecret)
-		if err != nil {
-			return nil, err
-		}
 	}
 	c.HttpClient = httpClient
-	cv3.UserAgent = userAgent
-	cv3.WithHTTPClient(httpClient)
+	c.SkipSslValidation = tlsOptions.SkipSSLValidation
+	cv3, err := newV3Config(baseCommand, apiEndpoints.API, userAgent, httpClient, tlsOptions.SkipSSLValidation)
+	if err != nil {
+		return nil, err
+	}
 
 	client, err := cfclient.NewClient(c)
 	if err != nil {
@@ -155,32 +230,46 @@ func InitializePeekManagers(baseCommand BaseCFConfigCommand, peek bool, ldapMgr
 	}
 
 	cfMgmt.OrgReader = organizationreader.NewReader(client, v3client.Organizations, cfg, peek)
-	cfMgmt.SpaceManager = space.NewManager(v3client.Spaces, v3client.SpaceFeatures, cfMgmt.UAAManager, cfMgmt.OrgReader, cfg, peek)
-	cfMgmt.OrgManager = organization.NewManager(v3client.Organizations, cfMgmt.OrgReader, cfg, peek)
-	cfMgmt.RoleManager = role.New(v3client.Roles, v3client.Users, v3client.Jobs, uaaMgr, peek)
+	cfMgmt.SpaceManager = space.NewManager(v3client.Spaces, v3client.SpaceFeatures, cfMgmt.UAAManager, cfMgmt.OrgReader, cfg, baseCommand.Parallelism, baseCommand.AllowLargeDeletions, cfMgmt.Failures, cfMgmt.Recorder, peek)
+	cfMgmt.OrgManager = organization.NewManager(v3client.Organizations, cfMgmt.OrgReader, cfg, baseCommand.AllowLargeDeletions, cfMgmt.Failures, cfMgmt.Recorder, peek)
+	cfMgmt.RoleManager = role.New(v3client.Roles, v3client.Users, v3client.Jobs, uaaMgr, cfMgmt.Recorder, peek)
 
-	userManager, err := user.NewManager(cfg, cfMgmt.SpaceManager, cfMgmt.OrgReader, cfMgmt.UAAManager, cfMgmt.RoleManager, ldapMgr, peek)
+	userManager, err := user.NewManager(cfg, cfMgmt.SpaceManager, cfMgmt.OrgReader, cfMgmt.UAAManager, cfMgmt.RoleManager, ldapMgr, baseCommand.Parallelism, baseCommand.AllowLargeDeletions, cfMgmt.Failures, cfMgmt.Recorder, peek)
 	if err != nil {
 		return nil, err
 	}
 	cfMgmt.UserManager = userManager
-	cfMgmt.SecurityGroupManager = securitygroup.NewManager(v3client.SecurityGroups, cfMgmt.SpaceManager, cfg, peek)
-	cfMgmt.QuotaManager = quota.NewManager(v3client.SpaceQuotas, v3client.OrganizationQuotas, cfMgmt.SpaceManager, cfMgmt.OrgReader, cfg, peek)
-	cfMgmt.PrivateDomainManager = privatedomain.NewManager(client, cfMgmt.OrgReader, cfg, peek)
-	if isoSegmentManager, err := isosegment.NewManager(client, cfg, cfMgmt.OrgReader, cfMgmt.SpaceManager, peek); err == nil {
+	cfMgmt.SecurityGroupManager = securitygroup.NewManager(v3client.SecurityGroups, cfMgmt.SpaceManager, cfg, baseCommand.Parallelism, cfMgmt.Failures, cfMgmt.Recorder, peek)
+	cfMgmt.QuotaManager = quota.NewManager(v3client.SpaceQuotas, v3client.OrganizationQuotas, cfMgmt.SpaceManager, cfMgmt.OrgReader, cfg, baseCommand.Parallelism, cfMgmt.Failures, cfMgmt.Recorder, peek)
+	cfMgmt.PrivateDomainManager = privatedomain.NewManager(client, cfMgmt.OrgReader, cfg, baseCommand.AllowLargeDeletions, cfMgmt.Failures, cfMgmt.Recorder, peek)
+	if isoSegmentManager, err := isosegment.NewManager(client, cfg, cfMgmt.OrgReader, cfMgmt.SpaceManager, cfMgmt.Recorder, peek); err == nil {
 		cfMgmt.IsolationSegmentManager = isoSegmentManager
 	} else {
 		return nil, err
 	}
-	cfMgmt.ServiceAccessManager = serviceaccess.NewManager(client, cfMgmt.OrgReader, cfg, peek)
-	token, err := client.GetToken()
+	cfMgmt.ServiceAccessManager = serviceaccess.NewManager(client, cfMgmt.OrgReader, cfg, cfMgmt.Recorder, peek)
+	routingAPIClient := shareddomain.NewRoutingAPIClient(apiEndpoints.Routing, httpClient, client.GetToken)
+	cfMgmt.SharedDomainManager = shareddomain.NewManager(client, routingAPIClient, cfg, baseCommand.AllowLargeDeletions, cfMgmt.Recorder, peek)
+	return cfMgmt, nil
+}
+
+// newV3Config - the config of the v3 client, using httpClient and keeping its
+// request timeout, which WithHTTPClient would otherwise reset to the default
+// of go-cfclient for every client sharing it
+func newV3Config(baseCommand BaseCFConfigCommand, apiURL, userAgent string, httpClient *http.Client, skipSSLValidation bool) (*v3config.Config, error) {
+	var cv3 *v3config.Config
+	var err error
+	if baseCommand.Password != "" {
+		cv3, err = v3config.NewUserPassword(apiURL, baseCommand.UserID, baseCommand.Password)
+	} else {
+		cv3, err = v3config.NewClientSecret(apiURL, baseCommand.UserID, baseCommand.ClientSecret)
+	}
 	if err != nil {
 		return nil, err
 	}
-	//needs to not include bearer prefix
-	token = strings.Replace(token, "bearer ", "", 1)
-	routingAPIClient := routing_api.NewClient(c.ApiAddress, true)
-	routingAPIClient.SetToken(token)
-	cfMgmt.SharedDomainManager = shareddomain.NewManager(client, routingAPIClient, cfg, peek)
-	return cfMgmt, nil
+	cv3.UserAgent = userAgent
+	cv3.WithHTTPClient(httpClient)
+	cv3.WithRequestTimeout(baseCommand.RequestTimeout)
+	cv3.WithSkipTLSValidation(skipSSLValidation)
+	return cv3, nil
 }
@@ -0,0 +1,21 @@
+package commands
+
+import (
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("newV3Config", func() {
+	It("keeps the request timeout of the shared http client", func() {
+		httpClient := &http.Client{Timeout: 7 * time.Minute}
+		baseCommand := BaseCFConfigCommand{UserID: "cf-mgmt", ClientSecret: "secret", RequestTimeout: 7 * time.Minute}
+		cv3, err := newV3Config(baseCommand, "https://api.example.com", "cf-mgmt/dev", httpClient, false)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cv3.HTTPClient()).Should(BeIdenticalTo(httpClient))
+		Expect(cv3.RequestTimeout()).Should(Equal(7 * time.Minute))
+		Expect(httpClient.Timeout).Should(Equal(7 * time.Minute))
+	})
+})
@@ -7,11 +7,16 @@ type IsolationSegmentsCommand struct {
 
 // Execute - updates spaces
 func (c *IsolationSegmentsCommand) Execute([]string) error {
+	if err := c.requireFoundationWide("isolation-segments"); err != nil {
+		return err
+	}
 	cfMgmt, err := InitializePeekManagers(c.BaseCFConfigCommand, c.Peek, nil)
 	if err != nil {
 		return err
 	}
 
-	return cfMgmt.IsolationSegmentManager.Apply()
+	ctx, stop := c.runContext()
+	defer stop()
+	return cfMgmt.IsolationSegmentManager.Apply(ctx)
 
 }
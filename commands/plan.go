@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
+)
+
+type PlanCommand struct {
+	BaseCFConfigCommand
+	BaseLDAPCommand
+	Output            string `long:"output" env:"OUTPUT" default:"markdown" choice:"json" choice:"yaml" choice:"markdown" description:"format of the generated plan"`
+	FailOnDestructive bool   `long:"fail-on-destructive" env:"FAIL_ON_DESTRUCTIVE" description:"exit with an error when the plan contains delete or unassign actions"`
+}
+
+// Execute - runs every apply step in peek mode and writes the resulting change set to stdout
+func (c *PlanCommand) Execute([]string) error {
+	var cfMgmt *CFMgmt
+	var err error
+	ldapMgr, err := InitializeLdapManager(c.BaseCFConfigCommand, c.BaseLDAPCommand)
+	if err != nil {
+		return err
+	}
+	if ldapMgr != nil {
+		defer ldapMgr.Close()
+	}
+	if cfMgmt, err = InitializePeekManagers(c.BaseCFConfigCommand, true, ldapMgr); err != nil {
+		return err
+	}
+	ctx, stop := c.runContext()
+	defer stop()
+	// step banners go to stderr so stdout only contains the plan
+	if err = cfMgmt.Apply(ctx, os.Stderr); err != nil {
+		return err
+	}
+	plan := changes.NewPlan(cfMgmt.Recorder.Changes())
+	if err = plan.Write(os.Stdout, c.Output); err != nil {
+		return err
+	}
+	if c.FailOnDestructive && plan.Summary.Destructive > 0 {
+		return fmt.Errorf("plan contains %d destructive change(s)", plan.Summary.Destructive)
+	}
+	return nil
+}
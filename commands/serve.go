@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/vmwarepivotallabs/cf-mgmt/failures"
+	"github.com/vmwarepivotallabs/cf-mgmt/serve"
+	"github.com/xchapter7x/lo"
+)
+
+type ServeCommand struct {
+	BaseCFConfigCommand
+	BaseLDAPCommand
+	ContinueOnError         bool          `long:"continue-on-error" env:"CONTINUE_ON_ERROR" description:"keep applying the remaining orgs, spaces and steps when one fails and report every failure at the end of the run"`
+	Listen                  string        `long:"listen" env:"LISTEN" default:":8080" description:"address to serve /healthz, /readyz, /status and /metrics on"`
+	Interval                time.Duration `long:"interval" env:"INTERVAL" default:"10m" description:"how often to apply the configuration, 0 only applies it when the config directory changes"`
+	WatchInterval           time.Duration `long:"watch-interval" env:"WATCH_INTERVAL" default:"30s" description:"how often to check the config directory for changes, 0 disables watching"`
+	UAAUsersRefreshInterval time.Duration `long:"uaa-users-refresh-interval" env:"UAA_USERS_REFRESH_INTERVAL" default:"1h" description:"how often to reload every user from uaa rather than reuse the users cached by earlier runs"`
+}
+
+// usersClearer - a uaa manager whose cached users can be discarded
+type usersClearer interface {
+	ClearUsers()
+}
+
+// Execute - applies the configuration repeatedly until interrupted
+func (c *ServeCommand) Execute([]string) error {
+	if c.Interval <= 0 && c.WatchInterval <= 0 {
+		return fmt.Errorf("at least one of interval or watch-interval must be set")
+	}
+	ldapMgr, err := InitializeLdapManager(c.BaseCFConfigCommand, c.BaseLDAPCommand)
+	if err != nil {
+		return err
+	}
+	if ldapMgr != nil {
+		defer ldapMgr.Close()
+	}
+	var collector *failures.Collector
+	if c.ContinueOnError {
+		collector = failures.NewCollector()
+	}
+	cfMgmt, err := initializeManagers(c.BaseCFConfigCommand, false, ldapMgr, collector)
+	if err != nil {
+		return err
+	}
+	defer cfMgmt.Journal.Close()
+
+	// a signal stops the run in progress at the next change and then the daemon,
+	// the timeout only limits each run
+	ctx, stop := signalContext(context.Background(), 0)
+	defer stop()
+	usersLoaded := time.Now()
+	daemon := serve.NewDaemon(c.ConfigDirectory, c.Interval, c.WatchInterval, func() (int, error) {
+		cfMgmt.Recorder.Reset()
+		cfMgmt.Failures.Reset()
+		if ldapMgr != nil {
+			ldapMgr.ClearCache()
+		}
+		if c.UAAUsersRefreshInterval > 0 && time.Since(usersLoaded) >= c.UAAUsersRefreshInterval {
+			if clearer, ok := cfMgmt.UAAManager.(usersClearer); ok {
+				lo.G.Info("reloading users from uaa")
+				clearer.ClearUsers()
+			}
+			usersLoaded = time.Now()
+		}
+		runCtx, cancel := ctx, context.CancelFunc(func() {})
+		if c.Timeout > 0 {
+			runCtx, cancel = context.WithTimeout(ctx, c.Timeout)
+		}
+		defer cancel()
+		err := cfMgmt.Apply(runCtx, os.Stdout)
+		return len(cfMgmt.Recorder.Changes()), err
+	})
+
+	daemon.Overlays = c.Overlays
+
+	listener, err := net.Listen("tcp", c.Listen)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %s: %v", c.Listen, err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", cfMgmt.Metrics.Registry.Handler())
+	mux.Handle("/", daemon.Handler())
+	server := &http.Server{Handler: mux}
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			lo.G.Errorf("status server stopped: %v", err)
+		}
+	}()
+	lo.G.Infof("serving status and metrics on %s", listener.Addr())
+
+	daemon.Start(ctx.Done())
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return server.Shutdown(shutdownCtx)
+}
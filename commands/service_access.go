@@ -7,10 +7,15 @@ type ServiceAccessCommand struct {
 
 // Execute - enables/disables service access
 func (c *ServiceAccessCommand) Execute([]string) error {
+	if err := c.requireFoundationWide("service-access"); err != nil {
+		return err
+	}
 	var cfMgmt *CFMgmt
 	var err error
 	if cfMgmt, err = InitializePeekManagers(c.BaseCFConfigCommand, c.Peek, nil); err == nil {
-		err = cfMgmt.ServiceAccessManager.Apply()
+		ctx, stop := c.runContext()
+		defer stop()
+		err = cfMgmt.ServiceAccessManager.Apply(ctx)
 	}
 	return err
 }
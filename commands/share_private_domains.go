@@ -10,7 +10,9 @@ func (c *SharePrivateDomainsCommand) Execute([]string) error {
 	var cfMgmt *CFMgmt
 	var err error
 	if cfMgmt, err = InitializePeekManagers(c.BaseCFConfigCommand, c.Peek, nil); err == nil {
-		err = cfMgmt.PrivateDomainManager.SharePrivateDomains()
+		ctx, stop := c.runContext()
+		defer stop()
+		err = cfMgmt.PrivateDomainManager.SharePrivateDomains(ctx)
 	}
 	return err
 }
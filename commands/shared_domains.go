@@ -7,10 +7,15 @@ type SharedDomainsCommand struct {
 
 // Execute - adds/removes shared domains
 func (c *SharedDomainsCommand) Execute([]string) error {
+	if err := c.requireFoundationWide("shared-domains"); err != nil {
+		return err
+	}
 	var cfMgmt *CFMgmt
 	var err error
 	if cfMgmt, err = InitializePeekManagers(c.BaseCFConfigCommand, c.Peek, nil); err == nil {
-		err = cfMgmt.SharedDomainManager.Apply()
+		ctx, stop := c.runContext()
+		defer stop()
+		err = cfMgmt.SharedDomainManager.Apply(ctx)
 	}
 	return err
 }
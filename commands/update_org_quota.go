@@ -10,7 +10,9 @@ func (c *UpdateOrgQuotasCommand) Execute([]string) error {
 	var cfMgmt *CFMgmt
 	var err error
 	if cfMgmt, err = InitializePeekManagers(c.BaseCFConfigCommand, c.Peek, nil); err == nil {
-		err = cfMgmt.QuotaManager.CreateOrgQuotas()
+		ctx, stop := c.runContext()
+		defer stop()
+		err = cfMgmt.QuotaManager.CreateOrgQuotas(ctx)
 	}
 	return err
 }
@@ -18,7 +18,9 @@ func (c *UpdateOrgUsersCommand) Execute([]string) error {
 		defer ldapMgr.Close()
 	}
 	if cfMgmt, err := InitializePeekManagers(c.BaseCFConfigCommand, c.Peek, ldapMgr); err == nil {
-		errs := cfMgmt.UserManager.UpdateOrgUsers()
+		ctx, stop := c.runContext()
+		defer stop()
+		errs := cfMgmt.UserManager.UpdateOrgUsers(ctx)
 		if len(errs) > 0 {
 			return fmt.Errorf("got errors processing update org users %v", errs)
 		}
@@ -10,7 +10,9 @@ func (c *UpdateOrgsMetadataCommand) Execute([]string) error {
 	var cfMgmt *CFMgmt
 	var err error
 	if cfMgmt, err = InitializePeekManagers(c.BaseCFConfigCommand, c.Peek, nil); err == nil {
-		err = cfMgmt.OrgManager.UpdateOrgsMetadata()
+		ctx, stop := c.runContext()
+		defer stop()
+		err = cfMgmt.OrgManager.UpdateOrgsMetadata(ctx)
 	}
 	return err
 }
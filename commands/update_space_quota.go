@@ -10,7 +10,9 @@ func (c *UpdateSpaceQuotasCommand) Execute([]string) error {
 	var cfMgmt *CFMgmt
 	var err error
 	if cfMgmt, err = InitializePeekManagers(c.BaseCFConfigCommand, c.Peek, nil); err == nil {
-		err = cfMgmt.QuotaManager.CreateSpaceQuotas()
+		ctx, stop := c.runContext()
+		defer stop()
+		err = cfMgmt.QuotaManager.CreateSpaceQuotas(ctx)
 	}
 	return err
 }
@@ -21,7 +21,9 @@ func (c *UpdateSpaceUsersCommand) Execute([]string) error {
 	if err != nil {
 		return err
 	}
-	errs := cfMgmt.UserManager.UpdateSpaceUsers()
+	ctx, stop := c.runContext()
+	defer stop()
+	errs := cfMgmt.UserManager.UpdateSpaceUsers(ctx)
 	if len(errs) > 0 {
 		return fmt.Errorf("got errors processing update space users %v", errs)
 	}
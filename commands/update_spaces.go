@@ -10,7 +10,9 @@ func (c *UpdateSpacesCommand) Execute([]string) error {
 	var cfMgmt *CFMgmt
 	var err error
 	if cfMgmt, err = InitializePeekManagers(c.BaseCFConfigCommand, c.Peek, nil); err == nil {
-		err = cfMgmt.SpaceManager.UpdateSpaces()
+		ctx, stop := c.runContext()
+		defer stop()
+		err = cfMgmt.SpaceManager.UpdateSpaces(ctx)
 	}
 	return err
 }
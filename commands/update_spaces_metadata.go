@@ -10,7 +10,9 @@ func (c *UpdateSpacesMetadataCommand) Execute([]string) error {
 	var cfMgmt *CFMgmt
 	var err error
 	if cfMgmt, err = InitializePeekManagers(c.BaseCFConfigCommand, c.Peek, nil); err == nil {
-		err = cfMgmt.SpaceManager.UpdateSpacesMetadata()
+		ctx, stop := c.runContext()
+		defer stop()
+		err = cfMgmt.SpaceManager.UpdateSpacesMetadata(ctx)
 	}
 	return err
 }
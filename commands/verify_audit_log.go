@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/vmwarepivotallabs/cf-mgmt/audit"
+	"github.com/xchapter7x/lo"
+)
+
+type VerifyAuditLogCommand struct {
+	AuditLog string `long:"audit-log" env:"AUDIT_LOG" required:"true" description:"audit log written with --audit-hash-chain to verify"`
+	Anchor   string `long:"anchor" env:"AUDIT_ANCHOR" description:"hash the first entry must hold, the hash of the last entry rotated out of the audit log [defaults to none, for a log kept from its first entry]"`
+}
+
+// Execute - checks the hash chain of an audit log
+func (c *VerifyAuditLogCommand) Execute([]string) error {
+	file, err := os.Open(c.AuditLog)
+	if err != nil {
+		return fmt.Errorf("unable to open audit log %s: %v", c.AuditLog, err)
+	}
+	defer file.Close()
+	lastHash, err := audit.Verify(file, c.Anchor)
+	if err != nil {
+		return fmt.Errorf("audit log %s failed verification: %v", c.AuditLog, err)
+	}
+	lo.G.Infof("audit log %s verified, its last entry hashes to %s", c.AuditLog, lastHash)
+	return nil
+}
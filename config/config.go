@@ -45,6 +45,7 @@ type Updater interface {
 
 	SaveOrgs(*Orgs) error
 	SaveGlobalConfig(*GlobalConfig) error
+	SaveOrgDefaults(*OrgConfig) error
 	SaveOrgQuota(*OrgQuota) error
 	SaveSpaceQuota(*SpaceQuota) error
 }
@@ -60,6 +61,7 @@ type Reader interface {
 	GetDefaultASGConfigs() ([]ASGConfig, error)
 	GetGlobalConfig() (*GlobalConfig, error)
 	GetSpaceDefaults() (*SpaceConfig, error)
+	GetOrgDefaults() (*OrgConfig, error)
 	GetOrgConfig(orgName string) (*OrgConfig, error)
 	GetSpaceConfig(orgName, spaceName string) (*SpaceConfig, error)
 	LdapConfig(bindUser, bindPassword, ldapServer string) (*LdapConfig, error)
@@ -70,9 +72,9 @@ type Reader interface {
 }
 
 // NewManager creates a Manager that is backed by a set of YAML
-// files in the specified configuration directory.
-func NewManager(configDir string) Manager {
-	return &yamlManager{
-		ConfigDir: configDir,
-	}
+// files in the specified configuration directory, with the files of any
+// overlay directories merged onto them in order.  Changes are saved to
+// the configuration directory.
+func NewManager(configDir string, overlays ...string) Manager {
+	return NewLayeredManager(Layers{Base: configDir, Overlays: overlays})
 }
@@ -0,0 +1,39 @@
+package config
+
+import "fmt"
+
+// DeletionLimits caps how much of each kind of entity a single run may delete so
+// an accidentally emptied config file or a broken LDAP group can't wipe the
+// foundation.
+type DeletionLimits struct {
+	Orgs           DeletionLimit `yaml:"orgs,omitempty" description:"limit on the orgs deleted"`
+	Spaces         DeletionLimit `yaml:"spaces,omitempty" description:"limit on the spaces deleted"`
+	RoleRemovals   DeletionLimit `yaml:"role-removals,omitempty" description:"limit on the users removed from roles"`
+	PrivateDomains DeletionLimit `yaml:"private-domains,omitempty" description:"limit on the private domains deleted"`
+	SharedDomains  DeletionLimit `yaml:"shared-domains,omitempty" description:"limit on the shared domains deleted"`
+}
+
+// DeletionLimit is an absolute and a percentage limit, either of which can be
+// left at 0 to not be enforced.
+type DeletionLimit struct {
+	Max        int     `yaml:"max,omitempty" description:"most that can be deleted, 0 is no limit"`
+	MaxPercent float64 `yaml:"max-percent,omitempty" description:"most that can be deleted as a percentage of those that exist, 0 is no limit"`
+}
+
+// Check returns an error when deleting count out of total entities exceeds the
+// limit.
+func (l DeletionLimit) Check(entity string, count, total int) error {
+	if count == 0 {
+		return nil
+	}
+	if l.Max > 0 && count > l.Max {
+		return fmt.Errorf("refusing to delete %d of %d %s as it exceeds the limit of %d set in cf-mgmt.yml deletion-limits, use --allow-large-deletions to override", count, total, entity, l.Max)
+	}
+	if l.MaxPercent > 0 && total > 0 {
+		percent := float64(count) * 100 / float64(total)
+		if percent > l.MaxPercent {
+			return fmt.Errorf("refusing to delete %d of %d %s (%.1f%%) as it exceeds the limit of %g%% set in cf-mgmt.yml deletion-limits, use --allow-large-deletions to override", count, total, entity, percent, l.MaxPercent)
+		}
+	}
+	return nil
+}
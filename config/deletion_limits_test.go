@@ -0,0 +1,26 @@
+package config_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vmwarepivotallabs/cf-mgmt/config"
+)
+
+var _ = Describe("DeletionLimit", func() {
+	It("allows any deletion when no limit is set", func() {
+		Expect(config.DeletionLimit{}.Check("orgs", 10, 10)).Should(Succeed())
+	})
+	It("allows deleting nothing", func() {
+		Expect(config.DeletionLimit{Max: 1, MaxPercent: 1}.Check("orgs", 0, 10)).Should(Succeed())
+	})
+	It("enforces the absolute limit", func() {
+		limit := config.DeletionLimit{Max: 2}
+		Expect(limit.Check("orgs", 2, 10)).Should(Succeed())
+		Expect(limit.Check("orgs", 3, 10)).Should(MatchError("refusing to delete 3 of 10 orgs as it exceeds the limit of 2 set in cf-mgmt.yml deletion-limits, use --allow-large-deletions to override"))
+	})
+	It("enforces the percentage limit", func() {
+		limit := config.DeletionLimit{MaxPercent: 25}
+		Expect(limit.Check("spaces", 1, 4)).Should(Succeed())
+		Expect(limit.Check("spaces", 2, 4)).Should(MatchError("refusing to delete 2 of 4 spaces (50.0%) as it exceeds the limit of 25% set in cf-mgmt.yml deletion-limits, use --allow-large-deletions to override"))
+	})
+})
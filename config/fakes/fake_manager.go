@@ -235,6 +235,18 @@ type FakeManager struct {
 		result1 []config.OrgConfig
 		result2 error
 	}
+	GetOrgDefaultsStub        func() (*config.OrgConfig, error)
+	getOrgDefaultsMutex       sync.RWMutex
+	getOrgDefaultsArgsForCall []struct {
+	}
+	getOrgDefaultsReturns struct {
+		result1 *config.OrgConfig
+		result2 error
+	}
+	getOrgDefaultsReturnsOnCall map[int]struct {
+		result1 *config.OrgConfig
+		result2 error
+	}
 	GetOrgQuotaStub        func(string) (*config.OrgQuota, error)
 	getOrgQuotaMutex       sync.RWMutex
 	getOrgQuotaArgsForCall []struct {
@@ -409,6 +421,17 @@ type FakeManager struct {
 	saveOrgConfigReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SaveOrgDefaultsStub        func(*config.OrgConfig) error
+	saveOrgDefaultsMutex       sync.RWMutex
+	saveOrgDefaultsArgsForCall []struct {
+		arg1 *config.OrgConfig
+	}
+	saveOrgDefaultsReturns struct {
+		result1 error
+	}
+	saveOrgDefaultsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveOrgQuotaStub        func(*config.OrgQuota) error
 	saveOrgQuotaMutex       sync.RWMutex
 	saveOrgQuotaArgsForCall []struct {
@@ -1642,6 +1665,62 @@ func (fake *FakeManager) GetOrgConfigsReturnsOnCall(i int, result1 []config.OrgC
 	}{result1, result2}
 }
 
+func (fake *FakeManager) GetOrgDefaults() (*config.OrgConfig, error) {
+	fake.getOrgDefaultsMutex.Lock()
+	ret, specificReturn := fake.getOrgDefaultsReturnsOnCall[len(fake.getOrgDefaultsArgsForCall)]
+	fake.getOrgDefaultsArgsForCall = append(fake.getOrgDefaultsArgsForCall, struct {
+	}{})
+	stub := fake.GetOrgDefaultsStub
+	fakeReturns := fake.getOrgDefaultsReturns
+	fake.recordInvocation("GetOrgDefaults", []interface{}{})
+	fake.getOrgDefaultsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeManager) GetOrgDefaultsCallCount() int {
+	fake.getOrgDefaultsMutex.RLock()
+	defer fake.getOrgDefaultsMutex.RUnlock()
+	return len(fake.getOrgDefaultsArgsForCall)
+}
+
+func (fake *FakeManager) GetOrgDefaultsCalls(stub func() (*config.OrgConfig, error)) {
+	fake.getOrgDefaultsMutex.Lock()
+	defer fake.getOrgDefaultsMutex.Unlock()
+	fake.GetOrgDefaultsStub = stub
+}
+
+func (fake *FakeManager) GetOrgDefaultsReturns(result1 *config.OrgConfig, result2 error) {
+	fake.getOrgDefaultsMutex.Lock()
+	defer fake.getOrgDefaultsMutex.Unlock()
+	fake.GetOrgDefaultsStub = nil
+	fake.getOrgDefaultsReturns = struct {
+		result1 *config.OrgConfig
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeManager) GetOrgDefaultsReturnsOnCall(i int, result1 *config.OrgConfig, result2 error) {
+	fake.getOrgDefaultsMutex.Lock()
+	defer fake.getOrgDefaultsMutex.Unlock()
+	fake.GetOrgDefaultsStub = nil
+	if fake.getOrgDefaultsReturnsOnCall == nil {
+		fake.getOrgDefaultsReturnsOnCall = make(map[int]struct {
+			result1 *config.OrgConfig
+			result2 error
+		})
+	}
+	fake.getOrgDefaultsReturnsOnCall[i] = struct {
+		result1 *config.OrgConfig
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeManager) GetOrgQuota(arg1 string) (*config.OrgQuota, error) {
 	fake.getOrgQuotaMutex.Lock()
 	ret, specificReturn := fake.getOrgQuotaReturnsOnCall[len(fake.getOrgQuotaArgsForCall)]
@@ -2498,6 +2577,67 @@ func (fake *FakeManager) SaveOrgConfigReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeManager) SaveOrgDefaults(arg1 *config.OrgConfig) error {
+	fake.saveOrgDefaultsMutex.Lock()
+	ret, specificReturn := fake.saveOrgDefaultsReturnsOnCall[len(fake.saveOrgDefaultsArgsForCall)]
+	fake.saveOrgDefaultsArgsForCall = append(fake.saveOrgDefaultsArgsForCall, struct {
+		arg1 *config.OrgConfig
+	}{arg1})
+	stub := fake.SaveOrgDefaultsStub
+	fakeReturns := fake.saveOrgDefaultsReturns
+	fake.recordInvocation("SaveOrgDefaults", []interface{}{arg1})
+	fake.saveOrgDefaultsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeManager) SaveOrgDefaultsCallCount() int {
+	fake.saveOrgDefaultsMutex.RLock()
+	defer fake.saveOrgDefaultsMutex.RUnlock()
+	return len(fake.saveOrgDefaultsArgsForCall)
+}
+
+func (fake *FakeManager) SaveOrgDefaultsCalls(stub func(*config.OrgConfig) error) {
+	fake.saveOrgDefaultsMutex.Lock()
+	defer fake.saveOrgDefaultsMutex.Unlock()
+	fake.SaveOrgDefaultsStub = stub
+}
+
+func (fake *FakeManager) SaveOrgDefaultsArgsForCall(i int) *config.OrgConfig {
+	fake.saveOrgDefaultsMutex.RLock()
+	defer fake.saveOrgDefaultsMutex.RUnlock()
+	argsForCall := fake.saveOrgDefaultsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeManager) SaveOrgDefaultsReturns(result1 error) {
+	fake.saveOrgDefaultsMutex.Lock()
+	defer fake.saveOrgDefaultsMutex.Unlock()
+	fake.SaveOrgDefaultsStub = nil
+	fake.saveOrgDefaultsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeManager) SaveOrgDefaultsReturnsOnCall(i int, result1 error) {
+	fake.saveOrgDefaultsMutex.Lock()
+	defer fake.saveOrgDefaultsMutex.Unlock()
+	fake.SaveOrgDefaultsStub = nil
+	if fake.saveOrgDefaultsReturnsOnCall == nil {
+		fake.saveOrgDefaultsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveOrgDefaultsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeManager) SaveOrgQuota(arg1 *config.OrgQuota) error {
 	fake.saveOrgQuotaMutex.Lock()
 	ret, specificReturn := fake.saveOrgQuotaReturnsOnCall[len(fake.saveOrgQuotaArgsForCall)]
@@ -2900,6 +3040,8 @@ func (fake *FakeManager) Invocations() map[string][][]interface{} {
 	defer fake.getOrgConfigMutex.RUnlock()
 	fake.getOrgConfigsMutex.RLock()
 	defer fake.getOrgConfigsMutex.RUnlock()
+	fake.getOrgDefaultsMutex.RLock()
+	defer fake.getOrgDefaultsMutex.RUnlock()
 	fake.getOrgQuotaMutex.RLock()
 	defer fake.getOrgQuotaMutex.RUnlock()
 	fake.getOrgQuotasMutex.RLock()
@@ -2928,6 +3070,8 @@ func (fake *FakeManager) Invocations() map[string][][]interface{} {
 	defer fake.saveGlobalConfigMutex.RUnlock()
 	fake.saveOrgConfigMutex.RLock()
 	defer fake.saveOrgConfigMutex.RUnlock()
+	fake.saveOrgDefaultsMutex.RLock()
+	defer fake.saveOrgDefaultsMutex.RUnlock()
 	fake.saveOrgQuotaMutex.RLock()
 	defer fake.saveOrgQuotaMutex.RUnlock()
 	fake.saveOrgSpacesMutex.RLock()
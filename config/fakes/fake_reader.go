@@ -69,6 +69,18 @@ type FakeReader struct {
 		result1 []config.OrgConfig
 		result2 error
 	}
+	GetOrgDefaultsStub        func() (*config.OrgConfig, error)
+	getOrgDefaultsMutex       sync.RWMutex
+	getOrgDefaultsArgsForCall []struct {
+	}
+	getOrgDefaultsReturns struct {
+		result1 *config.OrgConfig
+		result2 error
+	}
+	getOrgDefaultsReturnsOnCall map[int]struct {
+		result1 *config.OrgConfig
+		result2 error
+	}
 	GetOrgQuotaStub        func(string) (*config.OrgQuota, error)
 	getOrgQuotaMutex       sync.RWMutex
 	getOrgQuotaArgsForCall []struct {
@@ -503,6 +515,62 @@ func (fake *FakeReader) GetOrgConfigsReturnsOnCall(i int, result1 []config.OrgCo
 	}{result1, result2}
 }
 
+func (fake *FakeReader) GetOrgDefaults() (*config.OrgConfig, error) {
+	fake.getOrgDefaultsMutex.Lock()
+	ret, specificReturn := fake.getOrgDefaultsReturnsOnCall[len(fake.getOrgDefaultsArgsForCall)]
+	fake.getOrgDefaultsArgsForCall = append(fake.getOrgDefaultsArgsForCall, struct {
+	}{})
+	stub := fake.GetOrgDefaultsStub
+	fakeReturns := fake.getOrgDefaultsReturns
+	fake.recordInvocation("GetOrgDefaults", []interface{}{})
+	fake.getOrgDefaultsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeReader) GetOrgDefaultsCallCount() int {
+	fake.getOrgDefaultsMutex.RLock()
+	defer fake.getOrgDefaultsMutex.RUnlock()
+	return len(fake.getOrgDefaultsArgsForCall)
+}
+
+func (fake *FakeReader) GetOrgDefaultsCalls(stub func() (*config.OrgConfig, error)) {
+	fake.getOrgDefaultsMutex.Lock()
+	defer fake.getOrgDefaultsMutex.Unlock()
+	fake.GetOrgDefaultsStub = stub
+}
+
+func (fake *FakeReader) GetOrgDefaultsReturns(result1 *config.OrgConfig, result2 error) {
+	fake.getOrgDefaultsMutex.Lock()
+	defer fake.getOrgDefaultsMutex.Unlock()
+	fake.GetOrgDefaultsStub = nil
+	fake.getOrgDefaultsReturns = struct {
+		result1 *config.OrgConfig
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeReader) GetOrgDefaultsReturnsOnCall(i int, result1 *config.OrgConfig, result2 error) {
+	fake.getOrgDefaultsMutex.Lock()
+	defer fake.getOrgDefaultsMutex.Unlock()
+	fake.GetOrgDefaultsStub = nil
+	if fake.getOrgDefaultsReturnsOnCall == nil {
+		fake.getOrgDefaultsReturnsOnCall = make(map[int]struct {
+			result1 *config.OrgConfig
+			result2 error
+		})
+	}
+	fake.getOrgDefaultsReturnsOnCall[i] = struct {
+		result1 *config.OrgConfig
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeReader) GetOrgQuota(arg1 string) (*config.OrgQuota, error) {
 	fake.getOrgQuotaMutex.Lock()
 	ret, specificReturn := fake.getOrgQuotaReturnsOnCall[len(fake.getOrgQuotaArgsForCall)]
@@ -1184,6 +1252,8 @@ func (fake *FakeReader) Invocations() map[string][][]interface{} {
 	defer fake.getOrgConfigMutex.RUnlock()
 	fake.getOrgConfigsMutex.RLock()
 	defer fake.getOrgConfigsMutex.RUnlock()
+	fake.getOrgDefaultsMutex.RLock()
+	defer fake.getOrgDefaultsMutex.RUnlock()
 	fake.getOrgQuotaMutex.RLock()
 	defer fake.getOrgQuotaMutex.RUnlock()
 	fake.getOrgQuotasMutex.RLock()
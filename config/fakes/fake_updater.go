@@ -218,6 +218,17 @@ type FakeUpdater struct {
 	saveOrgConfigReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SaveOrgDefaultsStub        func(*config.OrgConfig) error
+	saveOrgDefaultsMutex       sync.RWMutex
+	saveOrgDefaultsArgsForCall []struct {
+		arg1 *config.OrgConfig
+	}
+	saveOrgDefaultsReturns struct {
+		result1 error
+	}
+	saveOrgDefaultsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveOrgQuotaStub        func(*config.OrgQuota) error
 	saveOrgQuotaMutex       sync.RWMutex
 	saveOrgQuotaArgsForCall []struct {
@@ -1395,6 +1406,67 @@ func (fake *FakeUpdater) SaveOrgConfigReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeUpdater) SaveOrgDefaults(arg1 *config.OrgConfig) error {
+	fake.saveOrgDefaultsMutex.Lock()
+	ret, specificReturn := fake.saveOrgDefaultsReturnsOnCall[len(fake.saveOrgDefaultsArgsForCall)]
+	fake.saveOrgDefaultsArgsForCall = append(fake.saveOrgDefaultsArgsForCall, struct {
+		arg1 *config.OrgConfig
+	}{arg1})
+	stub := fake.SaveOrgDefaultsStub
+	fakeReturns := fake.saveOrgDefaultsReturns
+	fake.recordInvocation("SaveOrgDefaults", []interface{}{arg1})
+	fake.saveOrgDefaultsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUpdater) SaveOrgDefaultsCallCount() int {
+	fake.saveOrgDefaultsMutex.RLock()
+	defer fake.saveOrgDefaultsMutex.RUnlock()
+	return len(fake.saveOrgDefaultsArgsForCall)
+}
+
+func (fake *FakeUpdater) SaveOrgDefaultsCalls(stub func(*config.OrgConfig) error) {
+	fake.saveOrgDefaultsMutex.Lock()
+	defer fake.saveOrgDefaultsMutex.Unlock()
+	fake.SaveOrgDefaultsStub = stub
+}
+
+func (fake *FakeUpdater) SaveOrgDefaultsArgsForCall(i int) *config.OrgConfig {
+	fake.saveOrgDefaultsMutex.RLock()
+	defer fake.saveOrgDefaultsMutex.RUnlock()
+	argsForCall := fake.saveOrgDefaultsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeUpdater) SaveOrgDefaultsReturns(result1 error) {
+	fake.saveOrgDefaultsMutex.Lock()
+	defer fake.saveOrgDefaultsMutex.Unlock()
+	fake.SaveOrgDefaultsStub = nil
+	fake.saveOrgDefaultsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUpdater) SaveOrgDefaultsReturnsOnCall(i int, result1 error) {
+	fake.saveOrgDefaultsMutex.Lock()
+	defer fake.saveOrgDefaultsMutex.Unlock()
+	fake.SaveOrgDefaultsStub = nil
+	if fake.saveOrgDefaultsReturnsOnCall == nil {
+		fake.saveOrgDefaultsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveOrgDefaultsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeUpdater) SaveOrgQuota(arg1 *config.OrgQuota) error {
 	fake.saveOrgQuotaMutex.Lock()
 	ret, specificReturn := fake.saveOrgQuotaReturnsOnCall[len(fake.saveOrgQuotaArgsForCall)]
@@ -1739,6 +1811,8 @@ func (fake *FakeUpdater) Invocations() map[string][][]interface{} {
 	defer fake.saveGlobalConfigMutex.RUnlock()
 	fake.saveOrgConfigMutex.RLock()
 	defer fake.saveOrgConfigMutex.RUnlock()
+	fake.saveOrgDefaultsMutex.RLock()
+	defer fake.saveOrgDefaultsMutex.RUnlock()
 	fake.saveOrgQuotaMutex.RLock()
 	defer fake.saveOrgQuotaMutex.RUnlock()
 	fake.saveOrgSpacesMutex.RLock()
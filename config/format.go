@@ -0,0 +1,171 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// userLists - the lists of users and groups of a role, which are kept sorted
+var userLists = []string{"ldap_users", "users", "saml_users", "ldap_groups"}
+
+// Format - rewrites the config files in configDir in their canonical form and
+// returns those that weren't in it, or, when check, only returns them.  In the
+// canonical form the keys of a file are in the order of the type it is read
+// into, followed by any it doesn't have, the keys of maps and the users and
+// groups of roles are sorted, with the repeated ones dropped, memory limits are
+// written with their unit, -1 limits as unlimited and booleans as true or
+// false.  configDir can be an overlay.
+func Format(configDir string, check bool) ([]string, error) {
+	if !FileOrDirectoryExists(configDir) {
+		return nil, fmt.Errorf("config directory %s doesn't exist", configDir)
+	}
+	var unformatted []string
+	for _, file := range configFiles {
+		for _, pattern := range file.files {
+			matches, err := filepath.Glob(filepath.Join(configDir, pattern))
+			if err != nil {
+				return nil, err
+			}
+			for _, match := range matches {
+				data, err := LoadFileBytes(match)
+				if err != nil {
+					return nil, err
+				}
+				formatted, err := formatFile(match, data, file.dataType)
+				if err != nil {
+					return nil, err
+				}
+				if bytes.Equal(formatted, data) {
+					continue
+				}
+				relativePath, err := filepath.Rel(configDir, match)
+				if err != nil {
+					return nil, err
+				}
+				unformatted = append(unformatted, filepath.ToSlash(relativePath))
+				if check {
+					continue
+				}
+				if err := WriteFileBytes(match, formatted); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	sort.Strings(unformatted)
+	return unformatted, nil
+}
+
+// formatFile - the yaml document data, of a file read into dataType, in its
+// canonical form
+func formatFile(fileName string, data []byte, dataType interface{}) ([]byte, error) {
+	document := yaml.MapSlice{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, errors.Wrapf(err, "Error unmarshalling file %s", fileName)
+	}
+	if len(document) == 0 {
+		// nothing to format, such as an overlay file left empty
+		return data, nil
+	}
+	return yaml.Marshal(formatValue(document, reflect.TypeOf(dataType)))
+}
+
+// formatValue - value, read from yaml for a field of type t, in its canonical
+// form
+func formatValue(value interface{}, t reflect.Type) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if document, ok := value.(yaml.MapSlice); ok {
+			return formatStruct(document, t)
+		}
+	case reflect.Slice:
+		if list, ok := value.([]interface{}); ok {
+			formatted := make([]interface{}, 0, len(list))
+			for _, item := range list {
+				formatted = append(formatted, formatValue(item, t.Elem()))
+			}
+			return formatted
+		}
+	case reflect.Map:
+		if document, ok := value.(yaml.MapSlice); ok {
+			formatted := make(yaml.MapSlice, 0, len(document))
+			for _, item := range document {
+				formatted = append(formatted, yaml.MapItem{Key: item.Key, Value: formatValue(item.Value, t.Elem())})
+			}
+			sort.SliceStable(formatted, func(i, j int) bool {
+				return fmt.Sprint(formatted[i].Key) < fmt.Sprint(formatted[j].Key)
+			})
+			return formatted
+		}
+	case reflect.Bool:
+		if s, ok := value.(string); ok {
+			if b, err := strconv.ParseBool(s); err == nil {
+				return b
+			}
+		}
+	}
+	return value
+}
+
+// formatStruct - document with the keys of t in their order, followed by those
+// t doesn't have in the order they were in
+func formatStruct(document yaml.MapSlice, t reflect.Type) yaml.MapSlice {
+	formatted := make(yaml.MapSlice, 0, len(document))
+	var known []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := yamlKey(field)
+		value, ok := mapLookup(document, key)
+		if key == "" || key == "-" || !ok {
+			continue
+		}
+		known = append(known, key)
+		value = formatValue(value, field.Type)
+		if !isUnset(value) && (contains(memoryFields, key) || contains(integerFields, key)) {
+			if normalized := normalizedQuotaValue(key, fmt.Sprint(value)); normalized != fmt.Sprint(value) {
+				value = normalized
+			}
+		}
+		if list, ok := value.([]interface{}); ok && t == reflect.TypeOf(UserMgmt{}) && contains(userLists, key) {
+			value = sortedUnique(list)
+		}
+		formatted = append(formatted, yaml.MapItem{Key: key, Value: value})
+	}
+	for _, item := range document {
+		if !contains(known, fmt.Sprint(item.Key)) {
+			formatted = append(formatted, item)
+		}
+	}
+	return formatted
+}
+
+// sortedUnique - list sorted, ignoring case, without the values it repeats
+func sortedUnique(list []interface{}) []interface{} {
+	unique := make([]interface{}, 0, len(list))
+	seen := make(map[string]bool)
+	for _, item := range list {
+		if key := fmt.Sprint(item); !seen[key] {
+			seen[key] = true
+			unique = append(unique, item)
+		}
+	}
+	sort.SliceStable(unique, func(i, j int) bool {
+		a, b := fmt.Sprint(unique[i]), fmt.Sprint(unique[j])
+		if !strings.EqualFold(a, b) {
+			return strings.ToLower(a) < strings.ToLower(b)
+		}
+		return a < b
+	})
+	return unique
+}
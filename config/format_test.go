@@ -0,0 +1,82 @@
+package config_test
+
+import (
+	"os"
+	"path"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vmwarepivotallabs/cf-mgmt/config"
+)
+
+var _ = Describe("Format", func() {
+	var (
+		pwd, _    = os.Getwd()
+		configDir = path.Join(pwd, "_testFormat")
+	)
+
+	BeforeEach(func() {
+		Expect(os.CopyFS(configDir, os.DirFS("./fixtures/format"))).Should(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(configDir)).Should(Succeed())
+	})
+
+	readFile := func(relativePath string) string {
+		data, err := os.ReadFile(path.Join(configDir, relativePath))
+		Expect(err).ShouldNot(HaveOccurred())
+		return string(data)
+	}
+
+	It("rewrites the files that aren't formatted", func() {
+		files, err := config.Format(configDir, false)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(files).Should(Equal([]string{"cf-mgmt.yml", "org1/orgConfig.yml", "org1/space1/spaceConfig.yml", "org_quotas/large.yml", "orgs.yml"}))
+		Expect(readFile("org1/orgConfig.yml")).Should(Equal(`org: org1
+org-manager:
+  users:
+  - alice
+  - bob
+  - Zed
+  ldap_groups:
+  - managers
+memory-limit: 10G
+total-routes: unlimited
+enable-remove-users: true
+metadata:
+  labels:
+    env: prod
+    team: core
+`))
+		Expect(readFile("cf-mgmt.yml")).Should(ContainSubstring("  a.example.com:\n    internal: true\n  b.example.com:\n"))
+		Expect(readFile("cf-mgmt.yml")).Should(HaveSuffix("enable-remove-shared-domains: false\n"))
+		Expect(readFile("org_quotas/large.yml")).Should(HavePrefix("memory-limit: 2G\n"))
+
+		orgConfig, err := config.NewManager(configDir).GetOrgConfig("org1")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(orgConfig.RemoveUsers).Should(BeTrue())
+	})
+
+	It("keeps the keys the type doesn't have and placeholders", func() {
+		_, err := config.Format(configDir, false)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(readFile("org1/space1/spaceConfig.yml")).Should(Equal("org: org1\nspace: space1\nallow-ssh: true\nmemory-limit: ((space1_memory))\nsome-new-key: kept\n"))
+	})
+
+	It("only lists the files when checking", func() {
+		before := readFile("org1/orgConfig.yml")
+		files, err := config.Format(configDir, true)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(files).Should(ContainElement("org1/orgConfig.yml"))
+		Expect(readFile("org1/orgConfig.yml")).Should(Equal(before))
+	})
+
+	It("leaves formatted files as they are", func() {
+		_, err := config.Format(configDir, false)
+		Expect(err).ShouldNot(HaveOccurred())
+		files, err := config.Format(configDir, true)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(files).Should(BeEmpty())
+	})
+})
@@ -4,19 +4,21 @@ import "strings"
 
 // GlobalConfig configuration for global settings
 type GlobalConfig struct {
-	EnableDeleteIsolationSegments  bool                    `yaml:"enable-delete-isolation-segments"`
-	EnableUnassignSecurityGroups   bool                    `yaml:"enable-unassign-security-groups"`
-	SkipUnassignSecurityGroupRegex string                  `yaml:"skip-unassign-security-group-regex"`
-	RunningSecurityGroups          []string                `yaml:"running-security-groups"`
-	StagingSecurityGroups          []string                `yaml:"staging-security-groups"`
-	SharedDomains                  map[string]SharedDomain `yaml:"shared-domains"`
-	EnableDeleteSharedDomains      bool                    `yaml:"enable-remove-shared-domains"`
-	MetadataPrefix                 string                  `yaml:"metadata-prefix"`
-	UseMetadataPrefix              bool                    `yaml:"use-metadata-prefix"`
-	EnableServiceAccess            bool                    `yaml:"enable-service-access"`
-	IgnoreLegacyServiceAccess      bool                    `yaml:"ignore-legacy-service-access"`
-	ServiceAccess                  []*Broker               `yaml:"service-access"`
-	ProtectedUsers                 []string                `yaml:"protected-users"`
+	SchemaVersion                  int                     `yaml:"schema-version,omitempty" description:"version of the config layout, see cf-mgmt-config migrate"`
+	EnableDeleteIsolationSegments  bool                    `yaml:"enable-delete-isolation-segments" description:"delete the isolation segments that aren't configured"`
+	EnableUnassignSecurityGroups   bool                    `yaml:"enable-unassign-security-groups" description:"unbind the running and staging security groups that aren't configured"`
+	SkipUnassignSecurityGroupRegex string                  `yaml:"skip-unassign-security-group-regex" description:"security groups that are never unbound, as a re2 regular expression"`
+	RunningSecurityGroups          []string                `yaml:"running-security-groups" description:"security groups in default_asgs bound to running apps"`
+	StagingSecurityGroups          []string                `yaml:"staging-security-groups" description:"security groups in default_asgs bound to staging apps"`
+	SharedDomains                  map[string]SharedDomain `yaml:"shared-domains" description:"shared domains by name"`
+	EnableDeleteSharedDomains      bool                    `yaml:"enable-remove-shared-domains" description:"delete the shared domains that aren't configured"`
+	MetadataPrefix                 string                  `yaml:"metadata-prefix" description:"prefix of the metadata cf-mgmt sets"`
+	UseMetadataPrefix              bool                    `yaml:"use-metadata-prefix" description:"prefix the labels and annotations of orgs and spaces with metadata-prefix"`
+	EnableServiceAccess            bool                    `yaml:"enable-service-access" description:"manage which orgs can use which service plans"`
+	IgnoreLegacyServiceAccess      bool                    `yaml:"ignore-legacy-service-access" description:"ignore the service-access of orgConfig.yml files"`
+	ServiceAccess                  []*Broker               `yaml:"service-access" description:"access to the plans of each service of each broker, plans that aren't listed are public"`
+	ProtectedUsers                 []string                `yaml:"protected-users" description:"users that are never removed from roles, as re2 regular expressions"`
+	DeletionLimits                 DeletionLimits          `yaml:"deletion-limits,omitempty" description:"most orgs, spaces, role removals and domains a run can delete, unless --allow-large-deletions is set"`
 }
 
 type PlanInfo struct {
@@ -47,49 +49,70 @@ func (b *Broker) GetService(serviceName string) *Service {
 	return newService
 }
 
+// GetPlanInfo - the access configured for a plan.  A broker, service or plan
+// named * matches any and, when several entries match, the one that names the
+// most of them exactly wins.  Plans that aren't configured have all access.
 func (g *GlobalConfig) GetPlanInfo(brokerName, serviceName, planName string) PlanInfo {
-	planInfo := PlanInfo{}
+	//default to always have plan enabled
+	planInfo := PlanInfo{AllAccess: true}
+	best := -1
 	for _, broker := range g.ServiceAccess {
-		if strings.EqualFold(brokerName, broker.Name) {
-			for _, service := range broker.Services {
-				if strings.EqualFold(serviceName, service.Name) {
-					for _, plan := range service.NoAccessPlans {
-						if strings.EqualFold(planName, plan) {
-							planInfo.NoAccess = true
-							return planInfo
-						}
-					}
-					for _, plan := range service.LimitedAccessPlans {
-						if strings.EqualFold(planName, plan.Name) {
-							planInfo.Limited = true
-							planInfo.Orgs = plan.Orgs
-							return planInfo
-						}
-					}
+		if !matchesName(broker.Name, brokerName) {
+			continue
+		}
+		for _, service := range broker.Services {
+			if !matchesName(service.Name, serviceName) {
+				continue
+			}
+			for _, plan := range service.NoAccessPlans {
+				if score := exactNames(broker.Name, service.Name, plan); matchesName(plan, planName) && score > best {
+					planInfo, best = PlanInfo{NoAccess: true}, score
+				}
+			}
+			for _, plan := range service.LimitedAccessPlans {
+				if score := exactNames(broker.Name, service.Name, plan.Name); matchesName(plan.Name, planName) && score > best {
+					planInfo, best = PlanInfo{Limited: true, Orgs: plan.Orgs}, score
+				}
+			}
+			for _, plan := range service.AllAccessPlans {
+				if score := exactNames(broker.Name, service.Name, plan); matchesName(plan, planName) && score > best {
+					planInfo, best = PlanInfo{AllAccess: true}, score
 				}
 			}
 		}
 	}
-	//default to always have plan enabled
-	planInfo.AllAccess = true
 	return planInfo
 }
 
+func matchesName(configured, name string) bool {
+	return configured == AnyName || strings.EqualFold(configured, name)
+}
+
+func exactNames(names ...string) int {
+	exact := 0
+	for _, name := range names {
+		if name != AnyName {
+			exact++
+		}
+	}
+	return exact
+}
+
 type SharedDomain struct {
-	Internal    bool   `yaml:"internal"`
-	RouterGroup string `yaml:"router-group,omitempty"`
+	Internal    bool   `yaml:"internal" description:"the domain is only for internal routes"`
+	RouterGroup string `yaml:"router-group,omitempty" description:"router group of a tcp domain"`
 }
 
 type Broker struct {
-	Name     string `yaml:"broker"`
-	Services []*Service
+	Name     string     `yaml:"broker" description:"name of the service broker, * for any"`
+	Services []*Service `description:"services of the broker"`
 }
 
 type Service struct {
-	Name               string            `yaml:"service"`
-	AllAccessPlans     []string          `yaml:"all_access_plans,omitempty"`
-	LimitedAccessPlans []*PlanVisibility `yaml:"limited_access_plans,omitempty"`
-	NoAccessPlans      []string          `yaml:"no_access_plans,omitempty"`
+	Name               string            `yaml:"service" description:"name of the service, * for any"`
+	AllAccessPlans     []string          `yaml:"all_access_plans,omitempty" description:"plans all orgs can use"`
+	LimitedAccessPlans []*PlanVisibility `yaml:"limited_access_plans,omitempty" description:"plans only some orgs, and the protected orgs, can use"`
+	NoAccessPlans      []string          `yaml:"no_access_plans,omitempty" description:"plans no org can use"`
 }
 
 func (s *Service) AddAllAccessPlan(planName string) {
@@ -188,6 +211,6 @@ func (s *Service) LimitedAccessPlanNames() []string {
 }
 
 type PlanVisibility struct {
-	Name string   `yaml:"plan,omitempty"`
-	Orgs []string `yaml:"orgs,omitempty"`
+	Name string   `yaml:"plan,omitempty" description:"name of the plan, * for any"`
+	Orgs []string `yaml:"orgs,omitempty" description:"orgs that can use the plan"`
 }
@@ -0,0 +1,518 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Layers - a base config directory and the overlay directories merged onto it.
+// Each yaml file of an overlay is merged onto the same file of the layers before
+// it: maps are merged key by key and any other value, lists included, replaces
+// the one below.  The orgs of orgs.yml and the spaces of spaces.yml are the
+// exception, an overlay adds to them and removes from them by listing names
+// under remove-orgs and remove-spaces.  Other files, such as security group
+// json, are taken from the last layer that has them.
+type Layers struct {
+	Base     string
+	Overlays []string
+	// Write - the layer changes are saved to, Base when empty.  Yaml saved to an
+	// overlay only holds the values that differ from the layers before it.
+	Write string
+	// Variables - resolves the ((var)) placeholders of the files, which are left
+	// as is when nil
+	Variables *Variables
+	// Strict - fail on keys that the files shouldn't have, such as misspelled
+	// ones, rather than ignore them, and warn about deprecated keys
+	Strict bool
+}
+
+// NewLayeredManager creates a Manager that reads the merged layers and saves
+// changes to the write layer
+func NewLayeredManager(layers Layers) Manager {
+	return &yamlManager{
+		ConfigDir: layers.Base,
+		Overlays:  layers.Overlays,
+		WriteDir:  layers.Write,
+		Variables: layers.Variables,
+		Strict:    layers.Strict,
+	}
+}
+
+// listRule - a list of names in a file that overlays add to rather than replace
+type listRule struct {
+	key       string
+	removeKey string
+}
+
+var listRules = map[string]listRule{
+	"orgs.yml":   {key: "orgs", removeKey: "remove-orgs"},
+	"spaces.yml": {key: "spaces", removeKey: "remove-spaces"},
+}
+
+func (m *yamlManager) layers() []string {
+	return append([]string{m.ConfigDir}, m.Overlays...)
+}
+
+// writeLayer - the index in layers and directory of the layer changes are saved to
+func (m *yamlManager) writeLayer() (int, string, error) {
+	if m.WriteDir == "" {
+		return 0, m.ConfigDir, nil
+	}
+	for i, layer := range m.layers() {
+		if filepath.Clean(layer) == filepath.Clean(m.WriteDir) {
+			return i, layer, nil
+		}
+	}
+	return 0, "", fmt.Errorf("layer %s is neither the config directory nor one of its overlays", m.WriteDir)
+}
+
+// resolve - the path of relativePath in the last layer that has it, or in the
+// base when none do
+func (m *yamlManager) resolve(relativePath string) string {
+	layers := m.layers()
+	for i := len(layers) - 1; i > 0; i-- {
+		if filePath := filepath.Join(layers[i], relativePath); FileOrDirectoryExists(filePath) {
+			return filePath
+		}
+	}
+	return filepath.Join(m.ConfigDir, relativePath)
+}
+
+// exists - true when any layer has relativePath
+func (m *yamlManager) exists(relativePath string) bool {
+	return FileOrDirectoryExists(m.resolve(relativePath))
+}
+
+// findFiles - the paths, relative to their layer, of the files under relativeDir
+// of any layer that end with pattern.  The base files come first in the order
+// they are found, followed by those only in the overlays.  Only the base
+// directory itself has to exist, the overlays can add orgs it doesn't have.
+func (m *yamlManager) findFiles(relativeDir, pattern string) ([]string, error) {
+	var result []string
+	seen := make(map[string]bool)
+	for i, layer := range m.layers() {
+		dir := filepath.Join(layer, relativeDir)
+		if (i > 0 || filepath.Clean(relativeDir) != ".") && !FileOrDirectoryExists(dir) {
+			continue
+		}
+		files, err := FindFiles(dir, pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			relativePath, err := filepath.Rel(layer, file)
+			if err != nil {
+				return nil, err
+			}
+			if !seen[relativePath] {
+				seen[relativePath] = true
+				result = append(result, relativePath)
+			}
+		}
+	}
+	return result, nil
+}
+
+// loadFile - unmarshals relativePath, merged across the layers, into dataType
+func (m *yamlManager) loadFile(relativePath string, dataType interface{}) error {
+	if err := m.checkFile(relativePath, dataType); err != nil {
+		return err
+	}
+	if len(m.Overlays) == 0 {
+		return m.Variables.LoadFile(filepath.Join(m.ConfigDir, relativePath), dataType)
+	}
+	merged, found, err := m.mergedFile(relativePath, len(m.layers()))
+	if err != nil {
+		return err
+	}
+	if !found {
+		// reports the missing file the same way as without overlays
+		return m.Variables.LoadFile(filepath.Join(m.ConfigDir, relativePath), dataType)
+	}
+	data, err := yaml.Marshal(merged)
+	if err != nil {
+		return errors.Wrapf(err, "Error merging file %s", relativePath)
+	}
+	if err = yaml.Unmarshal(data, dataType); err != nil {
+		return errors.Wrapf(err, "Error unmarshalling merged file %s", relativePath)
+	}
+	return nil
+}
+
+// readFile - the contents of relativePath in the last layer that has it, with
+// its placeholders resolved
+func (m *yamlManager) readFile(relativePath string) ([]byte, error) {
+	filePath := m.resolve(relativePath)
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return m.Variables.InterpolateText(filePath, data)
+}
+
+// mergedFile - relativePath of the first count layers merged in order, and
+// whether any of them has it
+func (m *yamlManager) mergedFile(relativePath string, count int) (yaml.MapSlice, bool, error) {
+	rule, hasRule := listRules[filepath.Base(relativePath)]
+	var merged yaml.MapSlice
+	found := false
+	for _, layer := range m.layers()[:count] {
+		document, ok, err := m.loadMapSlice(filepath.Join(layer, relativePath))
+		if err != nil {
+			return nil, false, err
+		}
+		if !ok {
+			continue
+		}
+		if !found {
+			merged, found = document, true
+			continue
+		}
+		if hasRule {
+			merged = mergeList(merged, document, rule)
+			document = withoutKeys(document, rule.key, rule.removeKey)
+		}
+		merged = mergeMaps(merged, document)
+	}
+	if hasRule {
+		merged = withoutKeys(merged, rule.removeKey)
+	}
+	return merged, found, nil
+}
+
+// removedNames - the names the overlays of relativePath remove that no later
+// layer adds back
+func (m *yamlManager) removedNames(relativePath string) (map[string]bool, error) {
+	rule, ok := listRules[filepath.Base(relativePath)]
+	if !ok || len(m.Overlays) == 0 {
+		return nil, nil
+	}
+	removed := make(map[string]bool)
+	for _, layer := range m.Overlays {
+		document, found, err := m.loadMapSlice(filepath.Join(layer, relativePath))
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+		for _, name := range stringList(mapValue(document, rule.removeKey)) {
+			removed[name] = true
+		}
+		for _, name := range stringList(mapValue(document, rule.key)) {
+			delete(removed, name)
+		}
+	}
+	return removed, nil
+}
+
+// writeFile - saves dataType to relativePath of the write layer.  In an overlay
+// only the values that differ from the layers before it are saved, with null
+// clearing a value they set.
+func (m *yamlManager) writeFile(relativePath string, dataType interface{}) error {
+	return m.writeFileWithout(relativePath, dataType, nil)
+}
+
+// writeFileWithout - writeFile leaving out the values strip takes out of the
+// yaml, such as the ones a defaults file provides
+func (m *yamlManager) writeFileWithout(relativePath string, dataType interface{}, strip func(yaml.MapSlice) yaml.MapSlice) error {
+	index, dir, err := m.writeLayer()
+	if err != nil {
+		return err
+	}
+	filePath := filepath.Join(dir, relativePath)
+	if index == 0 && strip == nil {
+		return WriteFile(filePath, dataType)
+	}
+	value, err := toMapSlice(dataType)
+	if err != nil {
+		return err
+	}
+	if strip != nil {
+		value = strip(value)
+	}
+	if index == 0 {
+		return WriteFile(filePath, value)
+	}
+	// an overlay only has the directories of the files it overrides
+	if err = m.mkdirAll(filepath.Dir(relativePath)); err != nil {
+		return err
+	}
+	lower, found, err := m.mergedFile(relativePath, index)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return WriteFile(filePath, value)
+	}
+	if lower, err = normalized(lower, dataType); err != nil {
+		return err
+	}
+	if strip != nil {
+		lower = strip(lower)
+	}
+	rule, hasRule := listRules[filepath.Base(relativePath)]
+	diff := yaml.MapSlice{}
+	if hasRule {
+		diff = listDiff(lower, value, rule)
+		lower, value = withoutKeys(lower, rule.key), withoutKeys(value, rule.key)
+	}
+	diff = append(diff, mapDiff(lower, value)...)
+	return WriteFile(filePath, diff)
+}
+
+// writeFileBytes - saves data to relativePath of the write layer as is
+func (m *yamlManager) writeFileBytes(relativePath string, data []byte) error {
+	index, dir, err := m.writeLayer()
+	if err != nil {
+		return err
+	}
+	if index > 0 {
+		if err = m.mkdirAll(filepath.Dir(relativePath)); err != nil {
+			return err
+		}
+	}
+	return WriteFileBytes(filepath.Join(dir, relativePath), data)
+}
+
+// mkdirAll - creates relativePath in the write layer
+func (m *yamlManager) mkdirAll(relativePath string) error {
+	_, dir, err := m.writeLayer()
+	if err != nil {
+		return err
+	}
+	directory := filepath.Join(dir, relativePath)
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return fmt.Errorf("cannot create directory %s: %v", directory, err)
+	}
+	return nil
+}
+
+// removeAll - removes relativePath from the write layer
+func (m *yamlManager) removeAll(relativePath string) error {
+	_, dir, err := m.writeLayer()
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(filepath.Join(dir, relativePath))
+}
+
+// normalized - document loaded the way dataType is loaded, so the values that
+// document leaves out are compared with their defaults
+func normalized(document yaml.MapSlice, dataType interface{}) (yaml.MapSlice, error) {
+	var loaded interface{}
+	switch dataType.(type) {
+	case *OrgConfig:
+		loaded = newOrgConfig()
+	case *SpaceConfig:
+		loaded = newSpaceConfig()
+	default:
+		dataTypeType := reflect.TypeOf(dataType)
+		if dataTypeType.Kind() == reflect.Ptr {
+			dataTypeType = dataTypeType.Elem()
+		}
+		loaded = reflect.New(dataTypeType).Interface()
+	}
+	data, err := yaml.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	if err = yaml.Unmarshal(data, loaded); err != nil {
+		return nil, err
+	}
+	return toMapSlice(loaded)
+}
+
+func toMapSlice(dataType interface{}) (yaml.MapSlice, error) {
+	data, err := yaml.Marshal(dataType)
+	if err != nil {
+		return nil, err
+	}
+	document := yaml.MapSlice{}
+	if err = yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+func (m *yamlManager) loadMapSlice(filePath string) (yaml.MapSlice, bool, error) {
+	if !FileOrDirectoryExists(filePath) {
+		return nil, false, nil
+	}
+	document := yaml.MapSlice{}
+	if err := m.Variables.LoadFile(filePath, &document); err != nil {
+		return nil, false, err
+	}
+	return document, true, nil
+}
+
+// mergeMaps - overlay merged onto base, key by key for nested maps
+func mergeMaps(base, overlay yaml.MapSlice) yaml.MapSlice {
+	result := append(yaml.MapSlice{}, base...)
+	for _, item := range overlay {
+		i := mapIndex(result, item.Key)
+		if i < 0 {
+			result = append(result, item)
+			continue
+		}
+		baseMap, baseIsMap := result[i].Value.(yaml.MapSlice)
+		overlayMap, overlayIsMap := item.Value.(yaml.MapSlice)
+		if baseIsMap && overlayIsMap {
+			result[i].Value = mergeMaps(baseMap, overlayMap)
+		} else {
+			result[i].Value = item.Value
+		}
+	}
+	return result
+}
+
+// mergeList - base with the names overlay adds to and removes from the list of rule
+func mergeList(base, overlay yaml.MapSlice, rule listRule) yaml.MapSlice {
+	added, hasAdded := mapLookup(overlay, rule.key)
+	removed, hasRemoved := mapLookup(overlay, rule.removeKey)
+	if !hasAdded && !hasRemoved {
+		return base
+	}
+	names := []interface{}{}
+	for _, name := range subtract(append(stringList(mapValue(base, rule.key)), stringList(added)...), stringList(removed)) {
+		if !containsName(names, name) {
+			names = append(names, name)
+		}
+	}
+	result := append(yaml.MapSlice{}, base...)
+	if i := mapIndex(result, rule.key); i >= 0 {
+		result[i].Value = names
+		return result
+	}
+	return append(result, yaml.MapItem{Key: rule.key, Value: names})
+}
+
+func containsName(names []interface{}, name string) bool {
+	for _, existing := range names {
+		if existing == name {
+			return true
+		}
+	}
+	return false
+}
+
+// listDiff - the names value adds to and removes from the list of rule in lower
+func listDiff(lower, value yaml.MapSlice, rule listRule) yaml.MapSlice {
+	lowerNames, valueNames := stringList(mapValue(lower, rule.key)), stringList(mapValue(value, rule.key))
+	var diff yaml.MapSlice
+	if added := subtract(valueNames, lowerNames); len(added) > 0 {
+		diff = append(diff, yaml.MapItem{Key: rule.key, Value: added})
+	}
+	if removed := subtract(lowerNames, valueNames); len(removed) > 0 {
+		diff = append(diff, yaml.MapItem{Key: rule.removeKey, Value: removed})
+	}
+	return diff
+}
+
+// mapDiff - the values of value that differ from lower, with nil for the keys
+// of lower that value doesn't have
+func mapDiff(lower, value yaml.MapSlice) yaml.MapSlice {
+	diff := yaml.MapSlice{}
+	for _, item := range value {
+		lowerValue, ok := mapLookup(lower, fmt.Sprint(item.Key))
+		if !ok {
+			diff = append(diff, item)
+			continue
+		}
+		lowerMap, lowerIsMap := lowerValue.(yaml.MapSlice)
+		valueMap, valueIsMap := item.Value.(yaml.MapSlice)
+		if lowerIsMap && valueIsMap {
+			if nested := mapDiff(lowerMap, valueMap); len(nested) > 0 {
+				diff = append(diff, yaml.MapItem{Key: item.Key, Value: nested})
+			}
+			continue
+		}
+		if !reflect.DeepEqual(lowerValue, item.Value) {
+			diff = append(diff, item)
+		}
+	}
+	for _, item := range lower {
+		if mapIndex(value, item.Key) < 0 && item.Value != nil {
+			diff = append(diff, yaml.MapItem{Key: item.Key, Value: nil})
+		}
+	}
+	return diff
+}
+
+func mapIndex(document yaml.MapSlice, key interface{}) int {
+	for i, item := range document {
+		if fmt.Sprint(item.Key) == fmt.Sprint(key) {
+			return i
+		}
+	}
+	return -1
+}
+
+func mapLookup(document yaml.MapSlice, key string) (interface{}, bool) {
+	if i := mapIndex(document, key); i >= 0 {
+		return document[i].Value, true
+	}
+	return nil, false
+}
+
+func mapValue(document yaml.MapSlice, key string) interface{} {
+	value, _ := mapLookup(document, key)
+	return value
+}
+
+func withoutKeys(document yaml.MapSlice, keys ...string) yaml.MapSlice {
+	var result yaml.MapSlice
+	for _, item := range document {
+		keep := true
+		for _, key := range keys {
+			if fmt.Sprint(item.Key) == key {
+				keep = false
+			}
+		}
+		if keep {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func stringList(value interface{}) []string {
+	list, _ := value.([]interface{})
+	result := make([]string, 0, len(list))
+	for _, item := range list {
+		result = append(result, fmt.Sprint(item))
+	}
+	return result
+}
+
+func subtract(names, namesToRemove []string) []string {
+	remove := make(map[string]bool)
+	for _, name := range namesToRemove {
+		remove[name] = true
+	}
+	var result []string
+	for _, name := range names {
+		if !remove[name] {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// requireBaseLayer - an error when changes are saved to an overlay, for the
+// changes that can only be made to the config directory
+func (m *yamlManager) requireBaseLayer(change string) error {
+	index, _, err := m.writeLayer()
+	if err != nil {
+		return err
+	}
+	if index > 0 {
+		return fmt.Errorf("%s is only supported in the config directory, not in overlay %s", change, m.WriteDir)
+	}
+	return nil
+}
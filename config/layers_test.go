@@ -0,0 +1,135 @@
+package config_test
+
+import (
+	"os"
+	"path"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vmwarepivotallabs/cf-mgmt/config"
+)
+
+var _ = Describe("Layers", func() {
+	var configManager config.Manager
+
+	BeforeEach(func() {
+		configManager = config.NewManager("./fixtures/overlay/base", "./fixtures/overlay/prod")
+	})
+
+	Context("reading", func() {
+		It("adds and removes orgs", func() {
+			orgs, err := configManager.Orgs()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(orgs.Orgs).Should(Equal([]string{"org1", "org3"}))
+			Expect(orgs.EnableDeleteOrgs).Should(BeTrue())
+			Expect(orgs.ProtectedOrgs).Should(Equal([]string{"system"}))
+		})
+
+		It("merges org configs and leaves out removed orgs", func() {
+			orgConfigs, err := configManager.GetOrgConfigs()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(orgConfigs).Should(HaveLen(2))
+			Expect(orgConfigs[0].Org).Should(Equal("org1"))
+			Expect(orgConfigs[0].MemoryLimit).Should(Equal("100G"))
+			Expect(orgConfigs[0].Manager.LDAPUsers).Should(Equal([]string{"prod-manager"}))
+			Expect(orgConfigs[0].Manager.Users).Should(Equal([]string{"admin"}))
+			Expect(orgConfigs[0].PrivateDomains).Should(Equal([]string{"org1.base.example.com"}))
+			Expect(orgConfigs[1].Org).Should(Equal("org3"))
+		})
+
+		It("removes spaces", func() {
+			spaces, err := configManager.OrgSpaces("org1")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(spaces.Spaces).Should(Equal([]string{"space1"}))
+			Expect(spaces.EnableDeleteSpaces).Should(BeTrue())
+		})
+
+		It("merges space configs and takes the security group from the overlay", func() {
+			spaceConfigs, err := configManager.GetSpaceConfigs()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(spaceConfigs).Should(HaveLen(1))
+			Expect(spaceConfigs[0].Space).Should(Equal("space1"))
+			Expect(spaceConfigs[0].AllowSSH).Should(BeTrue())
+			Expect(spaceConfigs[0].EnableSecurityGroup).Should(BeTrue())
+			Expect(spaceConfigs[0].SecurityGroupContents).Should(Equal("[]\n"))
+		})
+
+		It("merges the global config and quotas", func() {
+			globalConfig, err := configManager.GetGlobalConfig()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(globalConfig.EnableDeleteIsolationSegments).Should(BeTrue())
+			Expect(globalConfig.RunningSecurityGroups).Should(Equal([]string{"all_access"}))
+
+			orgQuota, err := configManager.GetOrgQuota("default")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(orgQuota.MemoryLimit).Should(Equal("100G"))
+			Expect(orgQuota.TotalRoutes).Should(Equal("100"))
+		})
+	})
+
+	Context("writing", func() {
+		var (
+			pwd, _  = os.Getwd()
+			baseDir = path.Join(pwd, "_testLayersBase")
+			prodDir = path.Join(pwd, "_testLayersProd")
+		)
+
+		BeforeEach(func() {
+			Expect(os.CopyFS(baseDir, os.DirFS("./fixtures/overlay/base"))).Should(Succeed())
+			Expect(os.MkdirAll(prodDir, 0755)).Should(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(baseDir)).Should(Succeed())
+			Expect(os.RemoveAll(prodDir)).Should(Succeed())
+		})
+
+		It("saves only the differences to an overlay", func() {
+			configManager = config.NewLayeredManager(config.Layers{Base: baseDir, Overlays: []string{prodDir}, Write: prodDir})
+			orgConfig, err := configManager.GetOrgConfig("org1")
+			Expect(err).ShouldNot(HaveOccurred())
+			orgConfig.MemoryLimit = "20G"
+			Expect(configManager.SaveOrgConfig(orgConfig)).Should(Succeed())
+
+			data, err := os.ReadFile(path.Join(prodDir, "org1", "orgConfig.yml"))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(data)).Should(Equal("memory-limit: 20G\n"))
+
+			orgConfig, err = config.NewManager(baseDir).GetOrgConfig("org1")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(orgConfig.MemoryLimit).Should(Equal("10G"))
+		})
+
+		It("saves quota changes to an overlay", func() {
+			configManager = config.NewLayeredManager(config.Layers{Base: baseDir, Overlays: []string{prodDir}, Write: prodDir})
+			orgQuota, err := configManager.GetOrgQuota("default")
+			Expect(err).ShouldNot(HaveOccurred())
+			orgQuota.TotalRoutes = "200"
+			Expect(configManager.AddOrgQuota(*orgQuota)).Should(Succeed())
+
+			data, err := os.ReadFile(path.Join(prodDir, "org_quotas", "default.yml"))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(data)).Should(Equal("total-routes: \"200\"\n"))
+		})
+
+		It("saves added and removed orgs to an overlay", func() {
+			configManager = config.NewLayeredManager(config.Layers{Base: baseDir, Overlays: []string{prodDir}, Write: prodDir})
+			Expect(configManager.DeleteOrgConfig("org2")).Should(Succeed())
+			Expect(configManager.AddOrgToConfig(&config.OrgConfig{Org: "org3"})).Should(Succeed())
+
+			data, err := os.ReadFile(path.Join(prodDir, "orgs.yml"))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(data)).Should(Equal("orgs:\n- org3\nremove-orgs:\n- org2\n"))
+			Expect(path.Join(baseDir, "org2", "orgConfig.yml")).Should(BeAnExistingFile())
+
+			orgs, err := configManager.Orgs()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(orgs.Orgs).Should(Equal([]string{"org1", "org3"}))
+		})
+
+		It("errors when the layer isn't one of the config directories", func() {
+			configManager = config.NewLayeredManager(config.Layers{Base: baseDir, Write: prodDir})
+			Expect(configManager.SaveGlobalConfig(&config.GlobalConfig{})).Should(MatchError(ContainSubstring("neither the config directory nor one of its overlays")))
+		})
+	})
+})
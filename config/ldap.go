@@ -2,23 +2,24 @@ package config
 
 // Config -
 type LdapConfig struct {
-	Enabled            bool   `yaml:"enabled"`
-	LdapHost           string `yaml:"ldapHost"`
-	LdapPort           int    `yaml:"ldapPort"`
-	TLS                bool   `yaml:"use_tls"`
-	BindDN             string `yaml:"bindDN"`
-	BindPassword       string `yaml:"bindPwd,omitempty"`
-	UserSearchBase     string `yaml:"userSearchBase"`
-	UserNameAttribute  string `yaml:"userNameAttribute"`
-	UserMailAttribute  string `yaml:"userMailAttribute"`
-	UserObjectClass    string `yaml:"userObjectClass"`
-	GroupSearchBase    string `yaml:"groupSearchBase"`
-	GroupAttribute     string `yaml:"groupAttribute"`
-	GroupObjectClass   string `yaml:"groupObjectClass"`
-	Origin             string `yaml:"origin"`
-	InsecureSkipVerify string `yaml:"insecure_skip_verify"`
-	CACert             string `yaml:"ca_cert"`
-	UseIDForSAMLUser   bool   `yaml:"useIDForSAMLUser"`
-	MinTLSVersion      string `yaml:"minTLSVersion"`
-	MaxTLSVersion      string `yaml:"maxTLSVersion"`
+	Enabled            bool   `yaml:"enabled" description:"look up users and groups in ldap"`
+	LdapHost           string `yaml:"ldapHost" description:"host name or ip address of the ldap server, without ldap://"`
+	LdapPort           int    `yaml:"ldapPort" description:"port of the ldap server"`
+	TLS                bool   `yaml:"use_tls" description:"connect to the ldap server with tls"`
+	BindDN             string `yaml:"bindDN" description:"dn of the user cf-mgmt binds as"`
+	BindPassword       string `yaml:"bindPwd,omitempty" description:"password of bindDN, deprecated, use --ldap-password"`
+	UserSearchBase     string `yaml:"userSearchBase" description:"dn users are searched under"`
+	UserNameAttribute  string `yaml:"userNameAttribute" description:"attribute with the user name, such as uid"`
+	UserMailAttribute  string `yaml:"userMailAttribute" description:"attribute with the email address of a user, such as mail"`
+	UserObjectClass    string `yaml:"userObjectClass" description:"object class of users, such as inetOrgPerson"`
+	GroupSearchBase    string `yaml:"groupSearchBase" description:"dn groups are searched under"`
+	GroupAttribute     string `yaml:"groupAttribute" description:"attribute with the members of a group, such as member"`
+	GroupObjectClass   string `yaml:"groupObjectClass" description:"object class of groups, such as groupOfNames"`
+	Origin             string `yaml:"origin" description:"uaa origin of the ldap users"`
+	InsecureSkipVerify string `yaml:"insecure_skip_verify" description:"skip verifying the certificate of the ldap server" enum:"true,false"`
+	CACert             string `yaml:"ca_cert" description:"pem of the CA that signed the certificate of the ldap server"`
+	UseIDForSAMLUser   bool   `yaml:"useIDForSAMLUser" description:"use the user id from ldap rather than the email address as the id of saml users"`
+	AllowEmptyGroups   bool   `yaml:"allowEmptyGroups,omitempty" description:"only warn about groups without members rather than refusing to remove users from the roles they grant"`
+	MinTLSVersion      string `yaml:"minTLSVersion" description:"lowest tls version, 1.0 when blank" enum:"1.0,1.1,1.2,1.3"`
+	MaxTLSVersion      string `yaml:"maxTLSVersion" description:"highest tls version, 1.3 when blank" enum:"1.0,1.1,1.2,1.3"`
 }
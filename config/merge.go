@@ -0,0 +1,697 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/xchapter7x/lo"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	orgQuotaKeys = []string{"memory-limit", "instance-memory-limit", "total-routes", "total-services", "paid-service-plans-allowed",
+		"total_private_domains", "total_reserved_route_ports", "total_service_keys", "app_instance_limit", "app_task_limit",
+		"log_rate_limit_bytes_per_second"}
+	spaceQuotaKeys = []string{"memory-limit", "instance-memory-limit", "total-routes", "total-services", "paid-service-plans-allowed",
+		"total_reserved_route_ports", "total_service_keys", "app_instance_limit", "app_task_limit", "log_rate_limit_bytes_per_second"}
+)
+
+// Merge - merges the config exported from a foundation to exportDir into the
+// config of layers and returns what it changed.  The config is read as cf-mgmt
+// reads it, with its overlays and variables, and the changes are saved to the
+// base directory.  The orgs, spaces, quotas and security groups it doesn't have
+// are added, and of those it has only the keys whose values drifted from the
+// foundation are updated, in the file they are in.  Nothing is removed, the
+// other keys and files, such as ldap.yml and the defaults, are left as they
+// are, and the ldap users of a role with ldap_groups are taken to be members of
+// those groups rather than listed.  Keys that hold a ((var)) placeholder or are
+// set by an overlay aren't overwritten, a warning is logged when they drifted.
+func Merge(layers Layers, exportDir string) ([]string, error) {
+	if !FileOrDirectoryExists(filepath.Join(layers.Base, "orgs.yml")) {
+		return nil, fmt.Errorf("there is no config in %s to merge into, export without --merge", layers.Base)
+	}
+	m := &merge{
+		dir:      layers.Base,
+		overlays: layers.Overlays,
+		current:  NewLayeredManager(layers),
+		exported: NewManager(exportDir),
+	}
+	if err := m.merge(exportDir); err != nil {
+		return nil, err
+	}
+	return m.changes, nil
+}
+
+type merge struct {
+	dir      string
+	overlays []string
+	current  Manager
+	exported Manager
+	changes  []string
+}
+
+func (m *merge) change(relativePath, format string, args ...interface{}) {
+	m.changes = append(m.changes, fmt.Sprintf("%s: %s", filepath.ToSlash(relativePath), fmt.Sprintf(format, args...)))
+}
+
+func (m *merge) merge(exportDir string) error {
+	currentOrgs, err := m.current.GetOrgConfigs()
+	if err != nil {
+		return err
+	}
+	exportedOrgs, err := m.exported.GetOrgConfigs()
+	if err != nil {
+		return err
+	}
+	currentSpaces, err := m.current.GetSpaceConfigs()
+	if err != nil {
+		return err
+	}
+	exportedSpaces, err := m.exported.GetSpaceConfigs()
+	if err != nil {
+		return err
+	}
+	if err = m.mergeOrgs(exportDir, currentOrgs, exportedOrgs, currentSpaces, exportedSpaces); err != nil {
+		return err
+	}
+	if err = m.mergeOrgQuotas(exportDir, currentOrgs); err != nil {
+		return err
+	}
+	if err = m.mergeSecurityGroups("asgs", m.current.GetASGConfigs, m.exported.GetASGConfigs); err != nil {
+		return err
+	}
+	if err = m.mergeSecurityGroups("default_asgs", m.current.GetDefaultASGConfigs, m.exported.GetDefaultASGConfigs); err != nil {
+		return err
+	}
+	return m.mergeGlobal()
+}
+
+// mergeOrgs - adds the orgs, with their spaces, configDir doesn't have to it
+// and orgs.yml, and merges the others
+func (m *merge) mergeOrgs(exportDir string, currentOrgs, exportedOrgs []OrgConfig, currentSpaces, exportedSpaces []SpaceConfig) error {
+	var added []string
+	for _, exportedOrg := range exportedOrgs {
+		currentOrg := findOrg(currentOrgs, exportedOrg.Org)
+		if currentOrg == nil {
+			if err := copyFiles(filepath.Join(exportDir, exportedOrg.Org), filepath.Join(m.dir, exportedOrg.Org)); err != nil {
+				return err
+			}
+			m.change(exportedOrg.Org, "added org %s", exportedOrg.Org)
+			added = append(added, exportedOrg.Org)
+			continue
+		}
+		if err := m.mergeOrg(*currentOrg, exportedOrg); err != nil {
+			return err
+		}
+		if err := m.mergeSpaces(exportDir, currentOrg.Org, currentSpaces, exportedSpaces); err != nil {
+			return err
+		}
+		if err := m.mergeSpaceQuotas(exportDir, currentOrg.Org); err != nil {
+			return err
+		}
+	}
+	if len(added) == 0 {
+		return nil
+	}
+	return m.addToList("orgs.yml", "orgs", added)
+}
+
+func (m *merge) mergeOrg(current, exported OrgConfig) error {
+	keys := []string{"private-domains", "shared-private-domains", "default_isolation_segment", "metadata"}
+	var currentQuota, exportedQuota interface{}
+	if current.EnableOrgQuota && strings.EqualFold(exported.NamedQuota, current.Org) {
+		// the org's own quota, which is exported as a named quota
+		quota, err := m.exported.GetOrgQuota(exported.NamedQuota)
+		if err != nil {
+			return err
+		}
+		if quota != nil {
+			currentQuota, exportedQuota = current.GetQuota(), *quota
+		}
+	} else {
+		keys = append(keys, "enable-org-quota", "named_quota")
+	}
+	relativePath := filepath.Join(current.Org, "orgConfig.yml")
+	return m.mergeFile(relativePath, func(document yaml.MapSlice) (yaml.MapSlice, error) {
+		document, err := m.mergeKeys(relativePath, document, current, exported, keys)
+		if err != nil {
+			return nil, err
+		}
+		if currentQuota != nil {
+			if document, err = m.mergeKeys(relativePath, document, currentQuota, exportedQuota, orgQuotaKeys); err != nil {
+				return nil, err
+			}
+		}
+		document = m.mergeRole(relativePath, document, "org-billingmanager", current.BillingManager, exported.BillingManager, current.GetBillingManagerGroups())
+		document = m.mergeRole(relativePath, document, "org-manager", current.Manager, exported.Manager, current.GetManagerGroups())
+		return m.mergeRole(relativePath, document, "org-auditor", current.Auditor, exported.Auditor, current.GetAuditorGroups()), nil
+	})
+}
+
+// mergeSpaces - adds the spaces of org configDir doesn't have to it and the
+// spaces.yml of org, and merges the others
+func (m *merge) mergeSpaces(exportDir, org string, currentSpaces, exportedSpaces []SpaceConfig) error {
+	var added []string
+	for _, exportedSpace := range exportedSpaces {
+		if !strings.EqualFold(exportedSpace.Org, org) {
+			continue
+		}
+		currentSpace := findSpace(currentSpaces, org, exportedSpace.Space)
+		if currentSpace == nil {
+			if err := copyFiles(filepath.Join(exportDir, exportedSpace.Org, exportedSpace.Space), filepath.Join(m.dir, org, exportedSpace.Space)); err != nil {
+				return err
+			}
+			m.change(filepath.Join(org, exportedSpace.Space), "added space %s", exportedSpace.Space)
+			added = append(added, exportedSpace.Space)
+			continue
+		}
+		if err := m.mergeSpace(*currentSpace, exportedSpace); err != nil {
+			return err
+		}
+	}
+	if len(added) == 0 {
+		return nil
+	}
+	return m.addToList(filepath.Join(org, "spaces.yml"), "spaces", added)
+}
+
+func (m *merge) mergeSpace(current, exported SpaceConfig) error {
+	keys := []string{"isolation_segment", "named-security-groups", "enable-security-group", "metadata", "enable-space-quota", "named_quota"}
+	if current.AllowSSHUntil == "" {
+		keys = append(keys, "allow-ssh")
+	}
+	if exported.EnableSpaceQuota {
+		keys = append(keys, spaceQuotaKeys...)
+	}
+	spaceDir := filepath.Join(current.Org, current.Space)
+	relativePath := filepath.Join(spaceDir, "spaceConfig.yml")
+	err := m.mergeFile(relativePath, func(document yaml.MapSlice) (yaml.MapSlice, error) {
+		document, err := m.mergeKeys(relativePath, document, current, exported, keys)
+		if err != nil {
+			return nil, err
+		}
+		document = m.mergeRole(relativePath, document, "space-developer", current.Developer, exported.Developer, current.GetDeveloperGroups())
+		document = m.mergeRole(relativePath, document, "space-manager", current.Manager, exported.Manager, current.GetManagerGroups())
+		document = m.mergeRole(relativePath, document, "space-auditor", current.Auditor, exported.Auditor, current.GetAuditorGroups())
+		return m.mergeRole(relativePath, document, "space-supporter", current.Supporter, exported.Supporter, current.GetSupporterGroups()), nil
+	})
+	if err != nil || !exported.EnableSecurityGroup || sameRules(current.SecurityGroupContents, exported.SecurityGroupContents) {
+		return err
+	}
+	relativePath = filepath.Join(spaceDir, "security-group.json")
+	if reason := m.fileLeftAsIs(relativePath); reason != "" {
+		lo.G.Warningf("%s: left the rules as they are as %s", filepath.ToSlash(relativePath), reason)
+		return nil
+	}
+	if err = WriteFileBytes(filepath.Join(m.dir, relativePath), []byte(exported.SecurityGroupContents)); err != nil {
+		return err
+	}
+	m.change(relativePath, "updated the rules")
+	return nil
+}
+
+func (m *merge) mergeSpaceQuotas(exportDir, org string) error {
+	exportedQuotas, err := m.exported.GetSpaceQuotas(org)
+	if err != nil {
+		return err
+	}
+	for _, exportedQuota := range exportedQuotas {
+		currentQuota, err := m.current.GetSpaceQuota(exportedQuota.Name, org)
+		if err != nil {
+			return err
+		}
+		if err := m.mergeQuota(exportDir, filepath.Join(org, "space_quotas"), currentQuota, exportedQuota.Name, exportedQuota, spaceQuotaKeys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeOrgQuotas - merges the org quotas, but for those of orgs with their own
+// quota, which are merged into their orgConfig.yml
+func (m *merge) mergeOrgQuotas(exportDir string, currentOrgs []OrgConfig) error {
+	exportedQuotas, err := m.exported.GetOrgQuotas()
+	if err != nil {
+		return err
+	}
+	for _, exportedQuota := range exportedQuotas {
+		if org := findOrg(currentOrgs, exportedQuota.Name); org != nil && org.EnableOrgQuota {
+			continue
+		}
+		currentQuota, err := m.current.GetOrgQuota(exportedQuota.Name)
+		if err != nil {
+			return err
+		}
+		if err := m.mergeQuota(exportDir, "org_quotas", currentQuota, exportedQuota.Name, exportedQuota, orgQuotaKeys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeQuota - adds the quota name of quotaDir, or merges it when current,
+// the OrgQuota or SpaceQuota configDir has, isn't nil
+func (m *merge) mergeQuota(exportDir, quotaDir string, current interface{}, name string, exported interface{}, keys []string) error {
+	if reflect.ValueOf(current).IsNil() {
+		relativePath := filepath.Join(quotaDir, name+".yml")
+		if err := copyFiles(filepath.Join(exportDir, relativePath), filepath.Join(m.dir, relativePath)); err != nil {
+			return err
+		}
+		m.change(relativePath, "added quota %s", name)
+		return nil
+	}
+	// the file of the quota configDir has, whose name can differ in case
+	currentName := reflect.ValueOf(current).Elem().FieldByName("Name").String()
+	relativePath := filepath.Join(quotaDir, currentName+".yml")
+	return m.mergeFile(relativePath, func(document yaml.MapSlice) (yaml.MapSlice, error) {
+		return m.mergeKeys(relativePath, document, current, exported, keys)
+	})
+}
+
+// mergeSecurityGroups - adds the security groups of dir configDir doesn't have
+// and replaces the rules of those that drifted
+func (m *merge) mergeSecurityGroups(dir string, currentASGs, exportedASGs func() ([]ASGConfig, error)) error {
+	current, err := currentASGs()
+	if err != nil {
+		return err
+	}
+	exported, err := exportedASGs()
+	if err != nil {
+		return err
+	}
+	for _, exportedASG := range exported {
+		relativePath := filepath.Join(dir, exportedASG.Name+".json")
+		action := "added security group " + exportedASG.Name
+		for _, currentASG := range current {
+			if currentASG.Name == exportedASG.Name {
+				action = "updated the rules"
+				if sameRules(currentASG.Rules, exportedASG.Rules) {
+					action = ""
+				}
+			}
+		}
+		if action == "" {
+			continue
+		}
+		if reason := m.fileLeftAsIs(relativePath); reason != "" {
+			lo.G.Warningf("%s: left the rules as they are as %s", filepath.ToSlash(relativePath), reason)
+			continue
+		}
+		if err := os.MkdirAll(filepath.Join(m.dir, dir), 0755); err != nil {
+			return err
+		}
+		if err := WriteFileBytes(filepath.Join(m.dir, relativePath), []byte(exportedASG.Rules)); err != nil {
+			return err
+		}
+		m.change(relativePath, action)
+	}
+	return nil
+}
+
+// mergeGlobal - merges the running and staging security groups, the shared
+// domains and the access of each plan of cf-mgmt.yml, leaving its settings as
+// they are
+func (m *merge) mergeGlobal() error {
+	current, err := m.current.GetGlobalConfig()
+	if err != nil {
+		return err
+	}
+	exported, err := m.exported.GetGlobalConfig()
+	if err != nil {
+		return err
+	}
+	return m.mergeFile("cf-mgmt.yml", func(document yaml.MapSlice) (yaml.MapSlice, error) {
+		document, err := m.mergeKeys("cf-mgmt.yml", document, current, exported, []string{"running-security-groups", "staging-security-groups", "shared-domains"})
+		if err != nil {
+			return nil, err
+		}
+		changes := m.mergeServiceAccess(current, exported)
+		if len(changes) == 0 {
+			return document, nil
+		}
+		if reason := m.leftAsIs("cf-mgmt.yml", document, "service-access"); reason != "" {
+			lo.G.Warningf("cf-mgmt.yml: left service-access as it is as %s, the foundation would %s", reason, strings.Join(changes, ", "))
+			return document, nil
+		}
+		for _, change := range changes {
+			m.change("cf-mgmt.yml", change)
+		}
+		serviceAccess, err := toMapSlice(&GlobalConfig{ServiceAccess: current.ServiceAccess})
+		if err != nil {
+			return nil, err
+		}
+		return setValue(document, "service-access", mapValue(serviceAccess, "service-access")), nil
+	})
+}
+
+// mergeServiceAccess - updates the service-access of current for each plan
+// whose access drifted from exported, naming the plan exactly so that it wins
+// over the entries with *, and returns what it updated
+func (m *merge) mergeServiceAccess(current, exported *GlobalConfig) []string {
+	var changes []string
+	for _, broker := range exported.ServiceAccess {
+		for _, service := range broker.Services {
+			var plans []string
+			plans = append(plans, service.AllAccessPlans...)
+			plans = append(plans, service.NoAccessPlans...)
+			plans = append(plans, service.LimitedAccessPlanNames()...)
+			for _, plan := range plans {
+				exportedInfo := exported.GetPlanInfo(broker.Name, service.Name, plan)
+				if samePlanInfo(current.GetPlanInfo(broker.Name, service.Name, plan), exportedInfo) {
+					continue
+				}
+				currentService := current.GetBroker(broker.Name).GetService(service.Name)
+				name := fmt.Sprintf("%s/%s/%s", broker.Name, service.Name, plan)
+				switch {
+				case exportedInfo.NoAccess:
+					currentService.AddNoAccessPlan(plan)
+					changes = append(changes, fmt.Sprintf("set plan %s to no access", name))
+				case exportedInfo.Limited:
+					currentService.AddLimitedAccessPlan(plan, nil, nil)
+					currentService.GetLimitedPlan(plan).Orgs = exportedInfo.Orgs
+					changes = append(changes, fmt.Sprintf("limited plan %s to orgs %v", name, exportedInfo.Orgs))
+				default:
+					currentService.AddAllAccessPlan(plan)
+					changes = append(changes, fmt.Sprintf("set plan %s to all access", name))
+				}
+			}
+		}
+	}
+	return changes
+}
+
+func samePlanInfo(planInfo, otherPlanInfo PlanInfo) bool {
+	return planInfo.AllAccess == otherPlanInfo.AllAccess && planInfo.NoAccess == otherPlanInfo.NoAccess &&
+		planInfo.Limited == otherPlanInfo.Limited && sameValue("", toList(planInfo.Orgs), toList(otherPlanInfo.Orgs))
+}
+
+// mergeFile - applies merge to the yaml document of relativePath and saves it
+// when it changed
+func (m *merge) mergeFile(relativePath string, merge func(yaml.MapSlice) (yaml.MapSlice, error)) error {
+	file := filepath.Join(m.dir, relativePath)
+	document := yaml.MapSlice{}
+	if FileOrDirectoryExists(file) {
+		if err := LoadFile(file, &document); err != nil {
+			return err
+		}
+	}
+	merged, err := merge(document)
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(merged, document) {
+		return nil
+	}
+	return WriteFile(file, merged)
+}
+
+// addToList - adds names to the list of key in relativePath
+func (m *merge) addToList(relativePath, key string, names []string) error {
+	return m.mergeFile(relativePath, func(document yaml.MapSlice) (yaml.MapSlice, error) {
+		return setValue(document, key, append(stringList(mapValue(document, key)), names...)), nil
+	})
+}
+
+// mergeKeys - document with the keys whose value in exported drifted from
+// that in current, the config as cf-mgmt reads it, defaults included.  Lists
+// get the values they miss added and those they have too many removed, the
+// other values are replaced.
+func (m *merge) mergeKeys(relativePath string, document yaml.MapSlice, current, exported interface{}, keys []string) (yaml.MapSlice, error) {
+	currentValues, err := toMapSlice(current)
+	if err != nil {
+		return nil, err
+	}
+	exportedValues, err := toMapSlice(exported)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		currentValue, exportedValue := mapValue(currentValues, key), mapValue(exportedValues, key)
+		if sameValue(key, currentValue, exportedValue) {
+			continue
+		}
+		if reason := m.leftAsIs(relativePath, document, key); reason != "" {
+			lo.G.Warningf("%s: left %s as it is as %s, the foundation has %v", filepath.ToSlash(relativePath), key, reason, exportedValue)
+			continue
+		}
+		if _, ok := exportedValue.([]interface{}); ok {
+			document = m.mergeList(relativePath, document, key, key, stringList(currentValue), stringList(exportedValue))
+			continue
+		}
+		document = setValue(document, key, exportedValue)
+		if _, ok := exportedValue.(yaml.MapSlice); ok {
+			m.change(relativePath, "updated %s", key)
+		} else if plainValue(key, exportedValue) == nil {
+			m.change(relativePath, "unset %s", key)
+		} else {
+			m.change(relativePath, "set %s to %v", key, exportedValue)
+		}
+	}
+	return document, nil
+}
+
+// mergeRole - document with the users of role merged, but for the ldap users
+// of a role with groups, which are left to the groups
+func (m *merge) mergeRole(relativePath string, document yaml.MapSlice, role string, current, exported UserMgmt, groups []string) yaml.MapSlice {
+	roleDocument, _ := mapValue(document, role).(yaml.MapSlice)
+	if reason := m.leftAsIs(relativePath, document, role); reason != "" {
+		if !sameUsers(current, exported, groups) {
+			lo.G.Warningf("%s: left the users of %s as they are as %s", filepath.ToSlash(relativePath), role, reason)
+		}
+		return document
+	}
+	merged := m.mergeList(relativePath, roleDocument, "users", role+" users", current.Users, exported.Users)
+	merged = m.mergeList(relativePath, merged, "saml_users", role+" saml_users", current.SamlUsers, exported.SamlUsers)
+	if len(groups) == 0 {
+		merged = m.mergeList(relativePath, merged, "ldap_users", role+" ldap_users", current.LDAPUsers, exported.LDAPUsers)
+	} else if users := missing(exported.LDAPUsers, current.LDAPUsers); len(users) > 0 {
+		sort.Strings(groups)
+		lo.G.Infof("%s: leaving ldap users %s of %s to ldap_groups %s", filepath.ToSlash(relativePath), strings.Join(users, ", "), role, strings.Join(groups, ", "))
+	}
+	if reflect.DeepEqual(merged, roleDocument) {
+		return document
+	}
+	return setValue(document, role, merged)
+}
+
+// mergeList - document with the list of key having the values of exported
+// current misses added and those exported doesn't have removed, when they are
+// in document rather than the defaults
+func (m *merge) mergeList(relativePath string, document yaml.MapSlice, key, name string, current, exported []string) yaml.MapSlice {
+	list := stringList(mapValue(document, key))
+	added := missing(exported, current)
+	removed := missing(current, exported)
+	var kept []string
+	for _, value := range list {
+		if len(missing([]string{value}, removed)) == 1 {
+			kept = append(kept, value)
+		}
+	}
+	removed = missing(list, kept)
+	added = missing(added, kept)
+	if len(added) == 0 && len(removed) == 0 {
+		return document
+	}
+	if len(added) > 0 {
+		m.change(relativePath, "added %s to %s", strings.Join(added, ", "), name)
+	}
+	if len(removed) > 0 {
+		m.change(relativePath, "removed %s from %s", strings.Join(removed, ", "), name)
+	}
+	return setValue(document, key, append(kept, added...))
+}
+
+// leftAsIs - why the drifted key of document, the yaml of relativePath, is
+// left as it is rather than merged, or "" when it is merged
+func (m *merge) leftAsIs(relativePath string, document yaml.MapSlice, key string) string {
+	if hasPlaceholder(mapValue(document, key)) {
+		return "it holds a ((var)) placeholder"
+	}
+	for i := len(m.overlays) - 1; i >= 0; i-- {
+		file := filepath.Join(m.overlays[i], relativePath)
+		if !FileOrDirectoryExists(file) {
+			continue
+		}
+		overlay := yaml.MapSlice{}
+		if err := LoadFile(file, &overlay); err != nil {
+			return fmt.Sprintf("overlay %s can't be read: %s", m.overlays[i], err)
+		}
+		if _, ok := mapLookup(overlay, key); ok {
+			return fmt.Sprintf("overlay %s sets it", m.overlays[i])
+		}
+	}
+	return ""
+}
+
+// fileLeftAsIs - why relativePath, a file that drifted, is left as it is
+// rather than replaced, or "" when it is replaced
+func (m *merge) fileLeftAsIs(relativePath string) string {
+	for i := len(m.overlays) - 1; i >= 0; i-- {
+		if FileOrDirectoryExists(filepath.Join(m.overlays[i], relativePath)) {
+			return fmt.Sprintf("overlay %s has it", m.overlays[i])
+		}
+	}
+	file := filepath.Join(m.dir, relativePath)
+	if !FileOrDirectoryExists(file) {
+		return ""
+	}
+	data, err := LoadFileBytes(file)
+	if err != nil {
+		return fmt.Sprintf("it can't be read: %s", err)
+	}
+	if placeholder.Match(data) {
+		return "it holds a ((var)) placeholder"
+	}
+	return ""
+}
+
+// hasPlaceholder - whether value, or any value within it, holds a ((var)) placeholder
+func hasPlaceholder(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return placeholder.MatchString(v)
+	case yaml.MapSlice:
+		for _, item := range v {
+			if hasPlaceholder(item.Value) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if hasPlaceholder(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sameUsers - whether the users of a role mergeRole would merge are the same
+func sameUsers(current, exported UserMgmt, groups []string) bool {
+	same := func(list, otherList []string) bool {
+		return len(missing(list, otherList)) == 0 && len(missing(otherList, list)) == 0
+	}
+	return same(current.Users, exported.Users) && same(current.SamlUsers, exported.SamlUsers) &&
+		(len(groups) > 0 || same(current.LDAPUsers, exported.LDAPUsers))
+}
+
+// missing - the values of list that otherList doesn't have, ignoring case
+func missing(list, otherList []string) []string {
+	var result []string
+	for _, value := range list {
+		found := false
+		for _, otherValue := range otherList {
+			if strings.EqualFold(value, otherValue) {
+				found = true
+			}
+		}
+		if !found {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// sameValue - whether the yaml values of key are the same once unset values,
+// the order of lists and the way quota values are written are left aside
+func sameValue(key string, value, otherValue interface{}) bool {
+	return reflect.DeepEqual(plainValue(key, value), plainValue(key, otherValue))
+}
+
+func plainValue(key string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		result := make(map[string]interface{})
+		for _, item := range v {
+			if plain := plainValue(fmt.Sprint(item.Key), item.Value); plain != nil {
+				result[fmt.Sprint(item.Key)] = plain
+			}
+		}
+		if len(result) == 0 {
+			return nil
+		}
+		return result
+	case []interface{}:
+		if len(v) == 0 {
+			return nil
+		}
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			list = append(list, strings.ToLower(fmt.Sprint(plainValue("", item))))
+		}
+		sort.Strings(list)
+		return list
+	}
+	if isUnset(value) || value == false {
+		return nil
+	}
+	if contains(memoryFields, key) || contains(integerFields, key) {
+		return normalizedQuotaValue(key, fmt.Sprint(value))
+	}
+	return fmt.Sprint(value)
+}
+
+func toList(values []string) []interface{} {
+	list := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		list = append(list, value)
+	}
+	return list
+}
+
+// sameRules - whether two security group definitions have the same rules
+func sameRules(rules, otherRules string) bool {
+	var value, otherValue interface{}
+	if json.Unmarshal([]byte(rules), &value) != nil || json.Unmarshal([]byte(otherRules), &otherValue) != nil {
+		return strings.TrimSpace(rules) == strings.TrimSpace(otherRules)
+	}
+	return reflect.DeepEqual(value, otherValue)
+}
+
+func findOrg(orgConfigs []OrgConfig, org string) *OrgConfig {
+	for i := range orgConfigs {
+		if strings.EqualFold(orgConfigs[i].Org, org) {
+			return &orgConfigs[i]
+		}
+	}
+	return nil
+}
+
+func findSpace(spaceConfigs []SpaceConfig, org, space string) *SpaceConfig {
+	for i := range spaceConfigs {
+		if strings.EqualFold(spaceConfigs[i].Org, org) && strings.EqualFold(spaceConfigs[i].Space, space) {
+			return &spaceConfigs[i]
+		}
+	}
+	return nil
+}
+
+// copyFiles - copies the file, or the directory with its files, from to to
+func copyFiles(from, to string) error {
+	return filepath.Walk(from, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(from, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(to, relativePath)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		data, err := LoadFileBytes(path)
+		if err != nil {
+			return err
+		}
+		return WriteFileBytes(target, data)
+	})
+}
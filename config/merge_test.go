@@ -0,0 +1,137 @@
+package config_test
+
+import (
+	"os"
+	"path"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vmwarepivotallabs/cf-mgmt/config"
+)
+
+var _ = Describe("Merge", func() {
+	var (
+		pwd, _    = os.Getwd()
+		configDir = path.Join(pwd, "_testMerge")
+		exportDir = path.Join(pwd, "fixtures", "merge", "exported")
+	)
+
+	BeforeEach(func() {
+		Expect(os.CopyFS(configDir, os.DirFS("./fixtures/merge/current"))).Should(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(configDir)).Should(Succeed())
+	})
+
+	It("reports what drifted from the foundation", func() {
+		changes, err := config.Merge(config.Layers{Base: configDir}, exportDir)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(changes).Should(ConsistOf(
+			"org1/orgConfig.yml: added org1.internal to private-domains",
+			"org1/orgConfig.yml: added dave to org-auditor ldap_users",
+			"org1/dev/spaceConfig.yml: set allow-ssh to true",
+			"org1/test: added space test",
+			"org2: added org org2",
+			"org_quotas/medium.yml: added quota medium",
+			"org_quotas/small.yml: set total-routes to 20",
+			"cf-mgmt.yml: added dns to running-security-groups",
+			"cf-mgmt.yml: updated shared-domains",
+			"cf-mgmt.yml: limited plan p-mysql/mysql/small to orgs [org1 org2]",
+		))
+
+		changes, err = config.Merge(config.Layers{Base: configDir}, exportDir)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(changes).Should(BeEmpty())
+	})
+
+	It("adds the missing orgs and spaces", func() {
+		_, err := config.Merge(config.Layers{Base: configDir}, exportDir)
+		Expect(err).ShouldNot(HaveOccurred())
+		configManager := config.NewManager(configDir)
+		orgs, err := configManager.Orgs()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(orgs.Orgs).Should(Equal([]string{"org1", "org2"}))
+		Expect(orgs.EnableDeleteOrgs).Should(BeTrue())
+		orgConfig, err := configManager.GetOrgConfig("org2")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(orgConfig.Manager.Users).Should(Equal([]string{"frank"}))
+		spaceConfig, err := configManager.GetSpaceConfig("org1", "test")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(spaceConfig.Developer.Users).Should(Equal([]string{"erin"}))
+	})
+
+	It("only updates the keys that drifted and keeps ldap group mappings", func() {
+		_, err := config.Merge(config.Layers{Base: configDir}, exportDir)
+		Expect(err).ShouldNot(HaveOccurred())
+		data, err := os.ReadFile(path.Join(configDir, "org1", "orgConfig.yml"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(data)).Should(Equal(`org: org1
+org-manager:
+  users:
+  - admin
+  ldap_groups:
+  - org1-managers
+org-auditor:
+  ldap_users:
+  - alice
+  - dave
+private-domains:
+- org1.example.com
+- org1.internal
+enable-remove-users: true
+named_quota: small
+`))
+		data, err = os.ReadFile(path.Join(configDir, "org_quotas", "small.yml"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(data)).Should(Equal("memory-limit: 10G\ntotal-routes: \"20\"\n"))
+	})
+
+	It("leaves the files that didn't drift as they are", func() {
+		_, err := config.Merge(config.Layers{Base: configDir}, exportDir)
+		Expect(err).ShouldNot(HaveOccurred())
+		for _, file := range []string{"ldap.yml", "asgs/dns.json"} {
+			data, err := os.ReadFile(path.Join(configDir, file))
+			Expect(err).ShouldNot(HaveOccurred())
+			original, err := os.ReadFile(path.Join("fixtures", "merge", "current", file))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(data).Should(Equal(original), file)
+		}
+	})
+
+	It("leaves the values that hold a placeholder as they are", func() {
+		quota := "memory-limit: 10G\ntotal-routes: ((routes))\n"
+		Expect(os.WriteFile(path.Join(configDir, "org_quotas", "small.yml"), []byte(quota), 0644)).Should(Succeed())
+		varsFile := path.Join(GinkgoT().TempDir(), "vars.yml")
+		Expect(os.WriteFile(varsFile, []byte("routes: \"10\"\n"), 0644)).Should(Succeed())
+		variables, err := config.NewVariables(varsFile)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		changes, err := config.Merge(config.Layers{Base: configDir, Variables: variables}, exportDir)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(changes).ShouldNot(ContainElement(HavePrefix("org_quotas/small.yml")))
+		data, err := os.ReadFile(path.Join(configDir, "org_quotas", "small.yml"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(data)).Should(Equal(quota))
+	})
+
+	It("leaves the values an overlay sets as they are", func() {
+		overlayDir := GinkgoT().TempDir()
+		Expect(os.MkdirAll(path.Join(overlayDir, "org1", "dev"), 0755)).Should(Succeed())
+		Expect(os.WriteFile(path.Join(overlayDir, "org1", "dev", "spaceConfig.yml"), []byte("allow-ssh: false\n"), 0644)).Should(Succeed())
+
+		changes, err := config.Merge(config.Layers{Base: configDir, Overlays: []string{overlayDir}}, exportDir)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(changes).ShouldNot(ContainElement("org1/dev/spaceConfig.yml: set allow-ssh to true"))
+		data, err := os.ReadFile(path.Join(configDir, "org1", "dev", "spaceConfig.yml"))
+		Expect(err).ShouldNot(HaveOccurred())
+		original, err := os.ReadFile(path.Join("fixtures", "merge", "current", "org1", "dev", "spaceConfig.yml"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(data).Should(Equal(original))
+	})
+
+	It("errors when there is no config to merge into", func() {
+		_, err := config.Merge(config.Layers{Base: path.Join(configDir, "missing")}, exportDir)
+		Expect(err).Should(MatchError(ContainSubstring("export without --merge")))
+	})
+})
@@ -1,6 +1,6 @@
 package config
 
 type Metadata struct {
-	Annotations map[string]string `yaml:"annotations"`
-	Labels      map[string]string `yaml:"labels"`
+	Annotations map[string]string `yaml:"annotations" description:"annotations by key"`
+	Labels      map[string]string `yaml:"labels" description:"labels by key"`
 }
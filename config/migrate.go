@@ -0,0 +1,312 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+
+	"github.com/xchapter7x/lo"
+	"gopkg.in/yaml.v2"
+)
+
+// SchemaVersion - the version of the config layout this cf-mgmt reads, recorded
+// as schema-version in cf-mgmt.yml.  A config without one is version 1, the
+// layout from before versions were recorded, which `cf-mgmt-config migrate`
+// updates.
+const SchemaVersion = 2
+
+// AnyName - the broker, service or plan name in service-access that matches any
+const AnyName = "*"
+
+var (
+	orgRoles      = []string{"org-billingmanager", "org-manager", "org-auditor"}
+	memoryFields  = []string{"memory-limit", "instance-memory-limit"}
+	integerFields = []string{"total-routes", "total-services", "total_private_domains", "total_reserved_route_ports",
+		"total_service_keys", "app_instance_limit", "app_task_limit", "log_rate_limit_bytes_per_second"}
+)
+
+// checkSchemaVersion - errors when the config is for a newer cf-mgmt and warns,
+// once, when it is for an older one
+func (m *yamlManager) checkSchemaVersion(globalConfig *GlobalConfig) error {
+	version := globalConfig.SchemaVersion
+	if version == 0 {
+		version = 1
+	}
+	if version > SchemaVersion {
+		return fmt.Errorf("config %s has schema-version %d but this cf-mgmt only reads up to schema-version %d, upgrade cf-mgmt", m.ConfigDir, version, SchemaVersion)
+	}
+	if version < SchemaVersion {
+		warning := fmt.Sprintf("config %s has schema-version %d, run cf-mgmt-config migrate to update it to %d", m.ConfigDir, version, SchemaVersion)
+		if _, warned := m.warned.LoadOrStore(warning, true); !warned {
+			lo.G.Warning(warning)
+		}
+	}
+	return nil
+}
+
+// Migrate - rewrites the config in configDir to SchemaVersion and returns what
+// it changed.  The group of a role, in org-<role>-group, space-<role>-group or
+// its ldap_group, moves to its ldap_groups, the service-access of orgs moves to
+// cf-mgmt.yml and memory limits are written with their unit.  configDir can be
+// an overlay, whose files are migrated as they are.
+func Migrate(configDir string) ([]string, error) {
+	m := &migration{dir: configDir}
+	if err := m.migrate(); err != nil {
+		return nil, err
+	}
+	return m.changes, nil
+}
+
+type migration struct {
+	dir     string
+	changes []string
+	// serviceAccess - the orgs of each plan of each service in the service-access of orgs
+	serviceAccess map[string]map[string][]string
+	legacyOrgs    []string
+}
+
+func (m *migration) change(relativePath, format string, args ...interface{}) {
+	m.changes = append(m.changes, fmt.Sprintf("%s: %s", filepath.ToSlash(relativePath), fmt.Sprintf(format, args...)))
+}
+
+func (m *migration) migrate() error {
+	if !FileOrDirectoryExists(m.dir) {
+		return fmt.Errorf("config directory %s doesn't exist", m.dir)
+	}
+	m.serviceAccess = make(map[string]map[string][]string)
+	for _, pattern := range []string{"orgConfig.yml", orgDefaultsFile} {
+		if err := m.migrateFiles(pattern, m.migrateOrg); err != nil {
+			return err
+		}
+	}
+	for _, pattern := range []string{"spaceConfig.yml", spaceDefaultsFile} {
+		if err := m.migrateFiles(pattern, m.migrateSpace); err != nil {
+			return err
+		}
+	}
+	if err := m.migrateFiles(".yml", m.migrateQuota); err != nil {
+		return err
+	}
+	return m.migrateGlobal()
+}
+
+// migrateFiles - applies migrate to the files in the config ending with pattern
+// and saves those it changes
+func (m *migration) migrateFiles(pattern string, migrate func(string, yaml.MapSlice) yaml.MapSlice) error {
+	files, err := FindFiles(m.dir, pattern)
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		relativePath, err := filepath.Rel(m.dir, file)
+		if err != nil {
+			return err
+		}
+		document := yaml.MapSlice{}
+		if err := LoadFile(file, &document); err != nil {
+			return err
+		}
+		migrated := migrate(relativePath, document)
+		if reflect.DeepEqual(migrated, document) {
+			continue
+		}
+		if err := WriteFile(file, migrated); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *migration) migrateOrg(relativePath string, document yaml.MapSlice) yaml.MapSlice {
+	document = m.foldGroups(relativePath, document, orgRoles)
+	if access, ok := mapValue(document, "service-access").(yaml.MapSlice); ok && filepath.Base(relativePath) == "orgConfig.yml" {
+		org := fmt.Sprint(mapValue(document, "org"))
+		m.legacyOrgs = append(m.legacyOrgs, org)
+		for _, item := range access {
+			service := fmt.Sprint(item.Key)
+			if m.serviceAccess[service] == nil {
+				m.serviceAccess[service] = make(map[string][]string)
+			}
+			for _, plan := range stringList(item.Value) {
+				m.serviceAccess[service][plan] = append(m.serviceAccess[service][plan], org)
+			}
+		}
+		document = withoutKeys(document, "service-access")
+		m.change(relativePath, "moved service-access to cf-mgmt.yml")
+	}
+	return m.normalizeQuota(relativePath, document)
+}
+
+func (m *migration) migrateSpace(relativePath string, document yaml.MapSlice) yaml.MapSlice {
+	document = m.foldGroups(relativePath, document, spaceRoles)
+	return m.normalizeQuota(relativePath, document)
+}
+
+func (m *migration) migrateQuota(relativePath string, document yaml.MapSlice) yaml.MapSlice {
+	switch filepath.Base(filepath.Dir(relativePath)) {
+	case "org_quotas", "space_quotas":
+		return m.normalizeQuota(relativePath, document)
+	}
+	return document
+}
+
+// foldGroups - document with the group of each role, in <role>-group or its
+// ldap_group, added to its ldap_groups
+func (m *migration) foldGroups(relativePath string, document yaml.MapSlice, roles []string) yaml.MapSlice {
+	for _, role := range roles {
+		roleDocument, _ := mapValue(document, role).(yaml.MapSlice)
+		var groups []interface{}
+		var moved []string
+		if group := mapValue(document, role+"-group"); !isUnset(group) {
+			groups = append(groups, group)
+			moved = append(moved, role+"-group")
+		}
+		if _, ok := mapLookup(document, role+"-group"); ok {
+			document = withoutKeys(document, role+"-group")
+		}
+		if group := mapValue(roleDocument, "ldap_group"); !isUnset(group) {
+			groups = append(groups, group)
+			moved = append(moved, role+".ldap_group")
+		}
+		if _, ok := mapLookup(roleDocument, "ldap_group"); ok {
+			roleDocument = withoutKeys(roleDocument, "ldap_group")
+			document = setValue(document, role, roleDocument)
+		}
+		if len(groups) == 0 {
+			continue
+		}
+		listed, _ := mapValue(roleDocument, "ldap_groups").([]interface{})
+		roleDocument = setValue(roleDocument, "ldap_groups", union(listed, groups))
+		document = setValue(document, role, roleDocument)
+		for _, key := range moved {
+			m.change(relativePath, "moved %s to %s.ldap_groups", key, role)
+		}
+	}
+	return document
+}
+
+// normalizeQuota - document with memory limits written with their unit and -1
+// written as unlimited
+func (m *migration) normalizeQuota(relativePath string, document yaml.MapSlice) yaml.MapSlice {
+	for _, key := range append(append([]string{}, memoryFields...), integerFields...) {
+		value, ok := mapLookup(document, key)
+		if !ok || isUnset(value) {
+			continue
+		}
+		normalized := normalizedQuotaValue(key, fmt.Sprint(value))
+		if normalized != fmt.Sprint(value) {
+			document = setValue(document, key, normalized)
+			m.change(relativePath, "rewrote %s %v as %s", key, value, normalized)
+		}
+	}
+	return document
+}
+
+func normalizedQuotaValue(key, value string) string {
+	if !contains(memoryFields, key) {
+		if i, err := ToInteger(value); err == nil && i == nil {
+			return UNLIMITED
+		}
+		return value
+	}
+	megabytes, err := ToMegabytes(value)
+	if err != nil {
+		return value
+	}
+	if megabytes == nil {
+		return UNLIMITED
+	}
+	// only a size that reads back the same, otherwise in megabytes
+	if size := ByteSize(megabytes); sameMegabytes(size, *megabytes) {
+		return size
+	}
+	return fmt.Sprintf("%dM", *megabytes)
+}
+
+func sameMegabytes(size string, megabytes int) bool {
+	value, err := ToMegabytes(size)
+	return err == nil && value != nil && *value == megabytes
+}
+
+// migrateGlobal - records the schema version in cf-mgmt.yml along with the
+// service-access moved from the orgs
+func (m *migration) migrateGlobal() error {
+	file := filepath.Join(m.dir, "cf-mgmt.yml")
+	if !FileOrDirectoryExists(file) && len(m.legacyOrgs) == 0 {
+		// an overlay that doesn't change cf-mgmt.yml
+		return nil
+	}
+	document := yaml.MapSlice{}
+	if FileOrDirectoryExists(file) {
+		if err := LoadFile(file, &document); err != nil {
+			return err
+		}
+	}
+	changes := len(m.changes)
+	if len(m.legacyOrgs) > 0 {
+		if ignored, _ := mapValue(document, "ignore-legacy-service-access").(bool); ignored {
+			m.change("cf-mgmt.yml", "dropped the service-access of orgs %v as ignore-legacy-service-access is set", m.legacyOrgs)
+		} else {
+			serviceAccess, err := toMapSlice(&GlobalConfig{ServiceAccess: m.globalServiceAccess()})
+			if err != nil {
+				return err
+			}
+			document = setValue(document, "service-access", mapValue(serviceAccess, "service-access"))
+			document = setValue(document, "ignore-legacy-service-access", true)
+			m.change("cf-mgmt.yml", "replaced service-access with that of orgs %v, limiting every other plan to the protected orgs as before", m.legacyOrgs)
+		}
+	}
+	if version, _ := mapValue(document, "schema-version").(int); version != SchemaVersion {
+		document = append(yaml.MapSlice{{Key: "schema-version", Value: SchemaVersion}}, withoutKeys(document, "schema-version")...)
+		m.change("cf-mgmt.yml", "set schema-version to %d", SchemaVersion)
+	}
+	if len(m.changes) == changes {
+		return nil
+	}
+	return WriteFile(file, document)
+}
+
+// globalServiceAccess - the service-access of orgs as that of cf-mgmt.yml: the
+// plans of each service limited to the orgs that listed them, or listed all its
+// plans, and every other plan, as the orgs only gave access to what they
+// listed, limited to the protected orgs
+func (m *migration) globalServiceAccess() []*Broker {
+	broker := &Broker{Name: AnyName}
+	services := make([]string, 0, len(m.serviceAccess))
+	for service := range m.serviceAccess {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	for _, serviceName := range services {
+		service := &Service{Name: serviceName}
+		plans := make([]string, 0, len(m.serviceAccess[serviceName]))
+		for plan := range m.serviceAccess[serviceName] {
+			plans = append(plans, plan)
+		}
+		sort.Strings(plans)
+		allPlanOrgs := m.serviceAccess[serviceName][AnyName]
+		for _, plan := range plans {
+			orgs := m.serviceAccess[serviceName][plan]
+			if plan != AnyName {
+				// a plan named exactly wins over *, so it needs the orgs of * too
+				orgs = append(orgs, subtract(allPlanOrgs, orgs)...)
+			}
+			service.LimitedAccessPlans = append(service.LimitedAccessPlans, &PlanVisibility{Name: plan, Orgs: orgs})
+		}
+		broker.Services = append(broker.Services, service)
+	}
+	broker.Services = append(broker.Services, &Service{Name: AnyName, LimitedAccessPlans: []*PlanVisibility{{Name: AnyName}}})
+	return []*Broker{broker}
+}
+
+// setValue - document with key set to value, in its place when it has key
+func setValue(document yaml.MapSlice, key string, value interface{}) yaml.MapSlice {
+	result := append(yaml.MapSlice{}, document...)
+	if i := mapIndex(result, key); i >= 0 {
+		result[i].Value = value
+		return result
+	}
+	return append(result, yaml.MapItem{Key: key, Value: value})
+}
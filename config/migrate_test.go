@@ -0,0 +1,96 @@
+package config_test
+
+import (
+	"os"
+	"path"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vmwarepivotallabs/cf-mgmt/config"
+)
+
+var _ = Describe("Migrate", func() {
+	var (
+		pwd, _    = os.Getwd()
+		configDir = path.Join(pwd, "_testMigrate")
+	)
+
+	BeforeEach(func() {
+		Expect(os.CopyFS(configDir, os.DirFS("./fixtures/migrate"))).Should(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(configDir)).Should(Succeed())
+	})
+
+	It("moves the groups of roles to their ldap_groups", func() {
+		_, err := config.Migrate(configDir)
+		Expect(err).ShouldNot(HaveOccurred())
+		data, err := os.ReadFile(path.Join(configDir, "org1", "orgConfig.yml"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(data)).Should(Equal("org: org1\norg-manager:\n  ldap_groups:\n  - org1-leads\n  - org1-managers\n  - org1-admins\n"))
+
+		spaceConfig, err := config.NewManager(configDir).GetSpaceConfig("org1", "space1")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(spaceConfig.DeveloperGroup).Should(BeEmpty())
+		Expect(spaceConfig.Developer.LDAPGroups).Should(Equal([]string{"space1-developers"}))
+	})
+
+	It("writes quotas with their units", func() {
+		changes, err := config.Migrate(configDir)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(changes).Should(ContainElements(
+			"org1/space1/spaceConfig.yml: rewrote memory-limit 1536 as 1.5G",
+			"org1/space1/spaceConfig.yml: rewrote instance-memory-limit 1100 as 1100M",
+			"org1/space1/spaceConfig.yml: rewrote total-routes -1 as unlimited",
+			"org_quotas/large.yml: rewrote memory-limit 102400 as 100G",
+		))
+		Expect(changes).ShouldNot(ContainElement(HavePrefix("org1/space_quotas/")))
+		quotas, err := config.NewManager(configDir).GetOrgQuotas()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(quotas[0].TotalServices).Should(Equal("unlimited"))
+	})
+
+	It("moves the service access of orgs to cf-mgmt.yml", func() {
+		_, err := config.Migrate(configDir)
+		Expect(err).ShouldNot(HaveOccurred())
+		configManager := config.NewManager(configDir)
+		orgConfigs, err := configManager.GetOrgConfigs()
+		Expect(err).ShouldNot(HaveOccurred())
+		for _, orgConfig := range orgConfigs {
+			Expect(orgConfig.ServiceAccess).Should(BeEmpty())
+		}
+		globalConfig, err := configManager.GetGlobalConfig()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(globalConfig.SchemaVersion).Should(Equal(config.SchemaVersion))
+		Expect(globalConfig.MetadataPrefix).Should(Equal("cf-mgmt.example.com"))
+		Expect(globalConfig.IgnoreLegacyServiceAccess).Should(BeTrue())
+		Expect(globalConfig.GetPlanInfo("mysql-broker", "p-mysql", "large")).Should(Equal(config.PlanInfo{Limited: true, Orgs: []string{"org2", "org1"}}))
+		Expect(globalConfig.GetPlanInfo("mysql-broker", "p-mysql", "small")).Should(Equal(config.PlanInfo{Limited: true, Orgs: []string{"org1"}}))
+		Expect(globalConfig.GetPlanInfo("redis-broker", "p-redis", "small")).Should(Equal(config.PlanInfo{Limited: true, Orgs: []string{"org1"}}))
+		Expect(globalConfig.GetPlanInfo("redis-broker", "p-redis", "large")).Should(Equal(config.PlanInfo{Limited: true}))
+	})
+
+	It("changes nothing once migrated", func() {
+		_, err := config.Migrate(configDir)
+		Expect(err).ShouldNot(HaveOccurred())
+		changes, err := config.Migrate(configDir)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(changes).Should(BeEmpty())
+	})
+
+	It("refuses a config for a newer cf-mgmt", func() {
+		Expect(os.WriteFile(path.Join(configDir, "cf-mgmt.yml"), []byte("schema-version: 99\n"), 0755)).Should(Succeed())
+		_, err := config.NewManager(configDir).GetGlobalConfig()
+		Expect(err).Should(MatchError(ContainSubstring("has schema-version 99 but this cf-mgmt only reads up to schema-version 2")))
+	})
+
+	It("prefers the service access that names a plan exactly", func() {
+		globalConfig := &config.GlobalConfig{ServiceAccess: []*config.Broker{
+			{Name: "*", Services: []*config.Service{{Name: "*", NoAccessPlans: []string{"*"}}}},
+			{Name: "broker", Services: []*config.Service{{Name: "service", AllAccessPlans: []string{"plan"}}}},
+		}}
+		Expect(globalConfig.GetPlanInfo("broker", "service", "plan")).Should(Equal(config.PlanInfo{AllAccess: true}))
+		Expect(globalConfig.GetPlanInfo("broker", "service", "other")).Should(Equal(config.PlanInfo{NoAccess: true}))
+	})
+})
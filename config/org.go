@@ -8,35 +8,36 @@ import (
 
 // OrgConfig describes configuration for an org.
 type OrgConfig struct {
-	Org                        string              `yaml:"org"`
-	OriginalOrg                string              `yaml:"original-org,omitempty"`
-	BillingManagerGroup        string              `yaml:"org-billingmanager-group,omitempty"`
-	ManagerGroup               string              `yaml:"org-manager-group,omitempty"`
-	AuditorGroup               string              `yaml:"org-auditor-group,omitempty"`
-	BillingManager             UserMgmt            `yaml:"org-billingmanager"`
-	Manager                    UserMgmt            `yaml:"org-manager"`
-	Auditor                    UserMgmt            `yaml:"org-auditor"`
-	PrivateDomains             []string            `yaml:"private-domains"`
-	RemovePrivateDomains       bool                `yaml:"enable-remove-private-domains"`
-	SharedPrivateDomains       []string            `yaml:"shared-private-domains"`
-	RemoveSharedPrivateDomains bool                `yaml:"enable-remove-shared-private-domains"`
-	EnableOrgQuota             bool                `yaml:"enable-org-quota"`
-	MemoryLimit                string              `yaml:"memory-limit,omitempty"`
-	InstanceMemoryLimit        string              `yaml:"instance-memory-limit,omitempty"`
-	TotalRoutes                string              `yaml:"total-routes,omitempty"`
-	TotalServices              string              `yaml:"total-services,omitempty"`
-	PaidServicePlansAllowed    bool                `yaml:"paid-service-plans-allowed"`
-	RemoveUsers                bool                `yaml:"enable-remove-users"`
-	TotalPrivateDomains        string              `yaml:"total_private_domains,omitempty"`
-	TotalReservedRoutePorts    string              `yaml:"total_reserved_route_ports,omitempty"`
-	TotalServiceKeys           string              `yaml:"total_service_keys,omitempty"`
-	AppInstanceLimit           string              `yaml:"app_instance_limit,omitempty"`
-	AppTaskLimit               string              `yaml:"app_task_limit,omitempty"`
-	LogRateLimitBytesPerSecond string              `yaml:"log_rate_limit_bytes_per_second,omitempty"`
-	DefaultIsoSegment          string              `yaml:"default_isolation_segment"`
-	ServiceAccess              map[string][]string `yaml:"service-access,omitempty"`
-	NamedQuota                 string              `yaml:"named_quota"`
-	Metadata                   *Metadata           `yaml:"metadata"`
+	Org                        string              `yaml:"org" description:"name of the org, the same as its directory"`
+	OriginalOrg                string              `yaml:"original-org,omitempty" description:"name the org had before it was renamed, for the rename to be applied"`
+	BillingManagerGroup        string              `yaml:"org-billingmanager-group,omitempty" description:"ldap group given the billing manager role, deprecated, use org-billingmanager.ldap_groups"`
+	ManagerGroup               string              `yaml:"org-manager-group,omitempty" description:"ldap group given the org manager role, deprecated, use org-manager.ldap_groups"`
+	AuditorGroup               string              `yaml:"org-auditor-group,omitempty" description:"ldap group given the org auditor role, deprecated, use org-auditor.ldap_groups"`
+	BillingManager             UserMgmt            `yaml:"org-billingmanager" description:"users and groups given the billing manager role"`
+	Manager                    UserMgmt            `yaml:"org-manager" description:"users and groups given the org manager role"`
+	Auditor                    UserMgmt            `yaml:"org-auditor" description:"users and groups given the org auditor role"`
+	PrivateDomains             []string            `yaml:"private-domains" description:"private domains owned by the org"`
+	RemovePrivateDomains       bool                `yaml:"enable-remove-private-domains" description:"delete the private domains of the org that aren't configured"`
+	SharedPrivateDomains       []string            `yaml:"shared-private-domains" description:"private domains of other orgs shared with the org"`
+	RemoveSharedPrivateDomains bool                `yaml:"enable-remove-shared-private-domains" description:"unshare the private domains shared with the org that aren't configured"`
+	EnableOrgQuota             bool                `yaml:"enable-org-quota" description:"give the org its own quota from the limits below, cannot be used with named_quota"`
+	MemoryLimit                string              `yaml:"memory-limit,omitempty" description:"total memory of the org, such as 10G, 512M or unlimited"`
+	InstanceMemoryLimit        string              `yaml:"instance-memory-limit,omitempty" description:"memory of an app instance, such as 1G, 512M or unlimited"`
+	TotalRoutes                string              `yaml:"total-routes,omitempty" description:"number of routes or unlimited"`
+	TotalServices              string              `yaml:"total-services,omitempty" description:"number of service instances or unlimited"`
+	PaidServicePlansAllowed    bool                `yaml:"paid-service-plans-allowed" description:"allow service instances of paid plans"`
+	RemoveUsers                bool                `yaml:"enable-remove-users" description:"remove users from the org roles they aren't configured for"`
+	TotalPrivateDomains        string              `yaml:"total_private_domains,omitempty" description:"number of private domains or unlimited"`
+	TotalReservedRoutePorts    string              `yaml:"total_reserved_route_ports,omitempty" description:"number of reserved route ports or unlimited"`
+	TotalServiceKeys           string              `yaml:"total_service_keys,omitempty" description:"number of service keys or unlimited"`
+	AppInstanceLimit           string              `yaml:"app_instance_limit,omitempty" description:"number of app instances or unlimited"`
+	AppTaskLimit               string              `yaml:"app_task_limit,omitempty" description:"number of running tasks or unlimited"`
+	LogRateLimitBytesPerSecond string              `yaml:"log_rate_limit_bytes_per_second,omitempty" description:"log rate of an app instance in bytes per second or unlimited"`
+	DefaultIsoSegment          string              `yaml:"default_isolation_segment" description:"isolation segment the spaces of the org run in by default"`
+	ServiceAccess              map[string][]string `yaml:"service-access,omitempty" description:"services and their plans the org can use, deprecated, use service-access in cf-mgmt.yml"`
+	NamedQuota                 string              `yaml:"named_quota" description:"name of a quota in org_quotas, cannot be used with enable-org-quota"`
+	Metadata                   *Metadata           `yaml:"metadata" description:"labels and annotations of the org"`
+	IgnoreOrgDefaults          bool                `yaml:"ignore-org-defaults,omitempty" description:"leave out the values of orgDefaults.yml"`
 }
 
 func (o *OrgConfig) GetQuota() OrgQuota {
@@ -58,24 +59,24 @@ func (o *OrgConfig) GetQuota() OrgQuota {
 
 type OrgQuota struct {
 	Name                       string `yaml:"-"`
-	TotalPrivateDomains        string `yaml:"total_private_domains"`
-	TotalReservedRoutePorts    string `yaml:"total_reserved_route_ports"`
-	TotalServiceKeys           string `yaml:"total_service_keys"`
-	AppInstanceLimit           string `yaml:"app_instance_limit"`
-	AppTaskLimit               string `yaml:"app_task_limit"`
-	MemoryLimit                string `yaml:"memory-limit"`
-	InstanceMemoryLimit        string `yaml:"instance-memory-limit"`
-	TotalRoutes                string `yaml:"total-routes"`
-	TotalServices              string `yaml:"total-services"`
-	PaidServicePlansAllowed    bool   `yaml:"paid-service-plans-allowed"`
-	LogRateLimitBytesPerSecond string `yaml:"log_rate_limit_bytes_per_second"`
+	TotalPrivateDomains        string `yaml:"total_private_domains" description:"number of private domains or unlimited"`
+	TotalReservedRoutePorts    string `yaml:"total_reserved_route_ports" description:"number of reserved route ports or unlimited"`
+	TotalServiceKeys           string `yaml:"total_service_keys" description:"number of service keys or unlimited"`
+	AppInstanceLimit           string `yaml:"app_instance_limit" description:"number of app instances or unlimited"`
+	AppTaskLimit               string `yaml:"app_task_limit" description:"number of running tasks or unlimited"`
+	MemoryLimit                string `yaml:"memory-limit" description:"total memory, such as 10G, 512M or unlimited"`
+	InstanceMemoryLimit        string `yaml:"instance-memory-limit" description:"memory of an app instance, such as 1G, 512M or unlimited"`
+	TotalRoutes                string `yaml:"total-routes" description:"number of routes or unlimited"`
+	TotalServices              string `yaml:"total-services" description:"number of service instances or unlimited"`
+	PaidServicePlansAllowed    bool   `yaml:"paid-service-plans-allowed" description:"allow service instances of paid plans"`
+	LogRateLimitBytesPerSecond string `yaml:"log_rate_limit_bytes_per_second" description:"log rate of an app instance in bytes per second or unlimited"`
 }
 
 // Orgs contains cf-mgmt configuration for all orgs.
 type Orgs struct {
-	Orgs             []string `yaml:"orgs"`
-	EnableDeleteOrgs bool     `yaml:"enable-delete-orgs"`
-	ProtectedOrgs    []string `yaml:"protected_orgs"`
+	Orgs             []string `yaml:"orgs" description:"orgs managed by cf-mgmt, each with a directory"`
+	EnableDeleteOrgs bool     `yaml:"enable-delete-orgs" description:"delete the orgs that aren't listed or protected"`
+	ProtectedOrgs    []string `yaml:"protected_orgs" description:"orgs that are never deleted, as re2 regular expressions"`
 }
 
 func (o *Orgs) ProtectedOrgList() []string {
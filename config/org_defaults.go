@@ -0,0 +1,262 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+
+	"gopkg.in/yaml.v2"
+)
+
+// orgDefaultsFile - the values merged into every org that doesn't set them
+const orgDefaultsFile = "orgDefaults.yml"
+
+// orgDefaults - orgDefaults.yml without the keys that name an org, or nil when
+// there isn't one
+func (m *yamlManager) orgDefaults() (yaml.MapSlice, error) {
+	if !m.exists(orgDefaultsFile) {
+		return nil, nil
+	}
+	if err := m.checkFile(orgDefaultsFile, &OrgConfig{}); err != nil {
+		return nil, err
+	}
+	defaults := yaml.MapSlice{}
+	if err := m.loadFile(orgDefaultsFile, &defaults); err != nil {
+		return nil, err
+	}
+	return withoutKeys(defaults, "org", "original-org", "ignore-org-defaults"), nil
+}
+
+// loadWithDefaults - loads relativePath into dataType with defaults merged in,
+// unless the file opts out by setting optOutKey to true
+func (m *yamlManager) loadWithDefaults(relativePath string, defaults yaml.MapSlice, optOutKey string, dataType interface{}) error {
+	if defaults == nil {
+		return m.loadFile(relativePath, dataType)
+	}
+	if err := m.checkFile(relativePath, dataType); err != nil {
+		return err
+	}
+	document := yaml.MapSlice{}
+	if err := m.loadFile(relativePath, &document); err != nil {
+		return err
+	}
+	if optOut, _ := mapValue(document, optOutKey).(bool); !optOut {
+		document = mergeDefaults(defaults, document)
+	}
+	data, err := yaml.Marshal(document)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, dataType)
+}
+
+// mergeDefaults - document with the values of defaults it doesn't set.  A key
+// that is missing, null or an empty string isn't set, lists are unioned and
+// maps are merged key by key with the same rules.
+func mergeDefaults(defaults, document yaml.MapSlice) yaml.MapSlice {
+	result := append(yaml.MapSlice{}, document...)
+	for _, item := range defaults {
+		i := mapIndex(result, item.Key)
+		if i < 0 {
+			result = append(result, item)
+			continue
+		}
+		if isUnset(result[i].Value) {
+			result[i].Value = item.Value
+			continue
+		}
+		switch value := result[i].Value.(type) {
+		case []interface{}:
+			if defaultList, ok := item.Value.([]interface{}); ok {
+				result[i].Value = union(value, defaultList)
+			}
+		case yaml.MapSlice:
+			if defaultMap, ok := item.Value.(yaml.MapSlice); ok {
+				result[i].Value = mergeDefaults(defaultMap, value)
+			}
+		}
+	}
+	return result
+}
+
+// withoutDefaults - document without the values that merging defaults gives
+// back: those equal to the default, the items of the default lists and, unless
+// explicit sets them, the zero values of the keys defaults has
+func withoutDefaults(document, defaults, explicit yaml.MapSlice) yaml.MapSlice {
+	result := yaml.MapSlice{}
+	for _, item := range document {
+		defaultValue, hasDefault := mapLookup(defaults, fmt.Sprint(item.Key))
+		if !hasDefault {
+			result = append(result, item)
+			continue
+		}
+		explicitValue, isExplicit := mapLookup(explicit, fmt.Sprint(item.Key))
+		isExplicit = isExplicit && !isUnset(explicitValue)
+		switch value := item.Value.(type) {
+		case []interface{}:
+			if defaultList, ok := defaultValue.([]interface{}); ok {
+				remaining := difference(value, defaultList)
+				if len(remaining) > 0 {
+					result = append(result, yaml.MapItem{Key: item.Key, Value: remaining})
+				}
+				continue
+			}
+		case yaml.MapSlice:
+			if defaultMap, ok := defaultValue.(yaml.MapSlice); ok {
+				explicitMap, _ := explicitValue.(yaml.MapSlice)
+				if remaining := withoutDefaults(value, defaultMap, explicitMap); len(remaining) > 0 {
+					result = append(result, yaml.MapItem{Key: item.Key, Value: remaining})
+				}
+				continue
+			}
+		}
+		if reflect.DeepEqual(item.Value, defaultValue) || (!isExplicit && isZero(item.Value)) {
+			continue
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// CommonOrgConfig - the values orgConfigs all have in common, other than the
+// ones an org has when its orgConfig.yml doesn't set them, or nil when there
+// are none
+func CommonOrgConfig(orgConfigs []OrgConfig) (*OrgConfig, error) {
+	var documents []yaml.MapSlice
+	for i := range orgConfigs {
+		document, err := toMapSlice(&orgConfigs[i])
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, withoutKeys(document, "org", "original-org"))
+	}
+	unset, err := toMapSlice(newOrgConfig())
+	if err != nil {
+		return nil, err
+	}
+	common := commonValues(documents, unset)
+	if len(common) == 0 {
+		return nil, nil
+	}
+	data, err := yaml.Marshal(common)
+	if err != nil {
+		return nil, err
+	}
+	orgDefaults := &OrgConfig{}
+	if err = yaml.Unmarshal(data, orgDefaults); err != nil {
+		return nil, err
+	}
+	return orgDefaults, nil
+}
+
+// commonValues - the values every document has in common, leaving out those
+// equal to baseline.  Lists hold the items they all have and maps the keys
+// they all have the same value for.
+func commonValues(documents []yaml.MapSlice, baseline yaml.MapSlice) yaml.MapSlice {
+	if len(documents) == 0 {
+		return nil
+	}
+	result := yaml.MapSlice{}
+	for _, item := range documents[0] {
+		values := []interface{}{item.Value}
+		for _, document := range documents[1:] {
+			value, ok := mapLookup(document, fmt.Sprint(item.Key))
+			if !ok {
+				values = nil
+				break
+			}
+			values = append(values, value)
+		}
+		if values == nil {
+			continue
+		}
+		baselineValue, _ := mapLookup(baseline, fmt.Sprint(item.Key))
+		if common, ok := commonValue(values, baselineValue); ok {
+			result = append(result, yaml.MapItem{Key: item.Key, Value: common})
+		}
+	}
+	return result
+}
+
+func commonValue(values []interface{}, baselineValue interface{}) (interface{}, bool) {
+	switch first := values[0].(type) {
+	case []interface{}:
+		common := first
+		for _, value := range values[1:] {
+			list, ok := value.([]interface{})
+			if !ok {
+				return nil, false
+			}
+			common = intersection(common, list)
+		}
+		return common, len(common) > 0
+	case yaml.MapSlice:
+		var documents []yaml.MapSlice
+		for _, value := range values {
+			document, ok := value.(yaml.MapSlice)
+			if !ok {
+				return nil, false
+			}
+			documents = append(documents, document)
+		}
+		baselineMap, _ := baselineValue.(yaml.MapSlice)
+		common := commonValues(documents, baselineMap)
+		return common, len(common) > 0
+	}
+	for _, value := range values[1:] {
+		if !reflect.DeepEqual(value, values[0]) {
+			return nil, false
+		}
+	}
+	return values[0], !isZero(values[0]) && !reflect.DeepEqual(values[0], baselineValue)
+}
+
+// isUnset - true for the values that leave a key to its default
+func isUnset(value interface{}) bool {
+	return value == nil || value == ""
+}
+
+func isZero(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	return reflect.ValueOf(value).IsZero()
+}
+
+func union(list, otherList []interface{}) []interface{} {
+	result := append([]interface{}{}, list...)
+	for _, item := range otherList {
+		if !containsItem(result, item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func intersection(list, otherList []interface{}) []interface{} {
+	result := []interface{}{}
+	for _, item := range list {
+		if containsItem(otherList, item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func difference(list, listToRemove []interface{}) []interface{} {
+	result := []interface{}{}
+	for _, item := range list {
+		if !containsItem(listToRemove, item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func containsItem(list []interface{}, item interface{}) bool {
+	for _, existing := range list {
+		if reflect.DeepEqual(existing, item) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+package config_test
+
+import (
+	"os"
+	"path"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vmwarepivotallabs/cf-mgmt/config"
+)
+
+var _ = Describe("Org defaults", func() {
+	Context("reading", func() {
+		var orgConfigs map[string]config.OrgConfig
+
+		BeforeEach(func() {
+			configs, err := config.NewManager("./fixtures/org-defaults").GetOrgConfigs()
+			Expect(err).ShouldNot(HaveOccurred())
+			orgConfigs = make(map[string]config.OrgConfig)
+			for _, orgConfig := range configs {
+				orgConfigs[orgConfig.Org] = orgConfig
+			}
+		})
+
+		It("merges the defaults an org doesn't set", func() {
+			orgConfig := orgConfigs["org1"]
+			Expect(orgConfig.RemoveUsers).Should(BeTrue())
+			Expect(orgConfig.DefaultIsoSegment).Should(Equal("shared-iso"))
+		})
+
+		It("keeps the values an org sets", func() {
+			orgConfig := orgConfigs["org1"]
+			Expect(orgConfig.NamedQuota).Should(Equal("large"))
+			Expect(orgConfig.Metadata.Labels).Should(Equal(map[string]string{"tier": "gold", "managed-by": "cf-mgmt"}))
+			Expect(orgConfigs["org3"].RemoveUsers).Should(BeFalse())
+		})
+
+		It("unions lists", func() {
+			Expect(orgConfigs["org1"].Auditor.LDAPGroups).Should(Equal([]string{"org1-auditors", "security-auditors"}))
+		})
+
+		It("treats null as not set", func() {
+			Expect(orgConfigs["org3"].Metadata.Labels).Should(HaveKeyWithValue("tier", "bronze"))
+		})
+
+		It("leaves out the defaults for orgs that opt out", func() {
+			orgConfig := orgConfigs["org2"]
+			Expect(orgConfig.RemoveUsers).Should(BeFalse())
+			Expect(orgConfig.NamedQuota).Should(BeEmpty())
+			Expect(orgConfig.Auditor.LDAPGroups).Should(BeEmpty())
+		})
+
+		It("merges the defaults into a single org", func() {
+			orgConfig, err := config.NewManager("./fixtures/org-defaults").GetOrgConfig("org3")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(orgConfig.NamedQuota).Should(Equal("default"))
+		})
+	})
+
+	Context("writing", func() {
+		var (
+			pwd, _        = os.Getwd()
+			configDir     = path.Join(pwd, "_testOrgDefaults")
+			configManager config.Manager
+		)
+
+		BeforeEach(func() {
+			Expect(os.CopyFS(configDir, os.DirFS("./fixtures/org-defaults"))).Should(Succeed())
+			configManager = config.NewManager(configDir)
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(configDir)).Should(Succeed())
+		})
+
+		It("leaves out the values the defaults give back", func() {
+			orgConfig, err := configManager.GetOrgConfig("org1")
+			Expect(err).ShouldNot(HaveOccurred())
+			orgConfig.Auditor.LDAPGroups = append(orgConfig.Auditor.LDAPGroups, "org1-more-auditors")
+			Expect(configManager.SaveOrgConfig(orgConfig)).Should(Succeed())
+
+			saved := &config.OrgConfig{}
+			Expect(config.LoadFile(path.Join(configDir, "org1", "orgConfig.yml"), saved)).Should(Succeed())
+			Expect(saved.Auditor.LDAPGroups).Should(Equal([]string{"org1-auditors", "org1-more-auditors"}))
+			Expect(saved.DefaultIsoSegment).Should(BeEmpty())
+			Expect(saved.RemoveUsers).Should(BeFalse())
+			Expect(saved.NamedQuota).Should(Equal("large"))
+			Expect(saved.Metadata.Labels).Should(Equal(map[string]string{"tier": "gold"}))
+
+			reread, err := configManager.GetOrgConfig("org1")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(reread.RemoveUsers).Should(BeTrue())
+			Expect(reread.DefaultIsoSegment).Should(Equal("shared-iso"))
+		})
+
+		It("keeps the zero values an org sets explicitly", func() {
+			orgConfig, err := configManager.GetOrgConfig("org3")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(configManager.SaveOrgConfig(orgConfig)).Should(Succeed())
+
+			reread, err := configManager.GetOrgConfig("org3")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(reread.RemoveUsers).Should(BeFalse())
+		})
+
+		It("gives new orgs the defaults", func() {
+			Expect(configManager.AddOrgToConfig(&config.OrgConfig{Org: "org4"})).Should(Succeed())
+			orgConfig, err := configManager.GetOrgConfig("org4")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(orgConfig.RemoveUsers).Should(BeTrue())
+			Expect(orgConfig.NamedQuota).Should(Equal("default"))
+		})
+
+		It("saves the defaults without the values that are the same as unset", func() {
+			Expect(configManager.SaveOrgDefaults(&config.OrgConfig{RemoveUsers: true, NamedQuota: "default"})).Should(Succeed())
+			data, err := os.ReadFile(path.Join(configDir, "orgDefaults.yml"))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(data)).Should(Equal("enable-remove-users: true\nnamed_quota: default\n"))
+		})
+	})
+
+	It("finds the values orgs have in common", func() {
+		orgDefaults, err := config.CommonOrgConfig([]config.OrgConfig{
+			{Org: "org1", RemoveUsers: true, NamedQuota: "default", Auditor: config.UserMgmt{LDAPGroups: []string{"auditors", "org1-auditors"}}},
+			{Org: "org2", RemoveUsers: true, NamedQuota: "large", Auditor: config.UserMgmt{LDAPGroups: []string{"org2-auditors", "auditors"}}},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(orgDefaults.Org).Should(BeEmpty())
+		Expect(orgDefaults.RemoveUsers).Should(BeTrue())
+		Expect(orgDefaults.NamedQuota).Should(BeEmpty())
+		Expect(orgDefaults.Auditor.LDAPGroups).Should(Equal([]string{"auditors"}))
+
+		orgDefaults, err = config.CommonOrgConfig([]config.OrgConfig{{Org: "org1"}, {Org: "org2", RemoveUsers: true}})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(orgDefaults).Should(BeNil())
+	})
+})
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Render - writes each yaml and json file of layers to out as cf-mgmt reads it,
+// merged across the layers and with its placeholders resolved.  The files of
+// the orgs and spaces the overlays remove are left out.
+func Render(layers Layers, out io.Writer) error {
+	m := NewLayeredManager(layers).(*yamlManager)
+	var files []string
+	for _, pattern := range []string{".yml", ".json"} {
+		found, err := m.findFiles(".", pattern)
+		if err != nil {
+			return err
+		}
+		files = append(files, found...)
+	}
+	sort.Strings(files)
+	for _, relativePath := range files {
+		if hidden(relativePath) {
+			continue
+		}
+		removed, err := m.isRemoved(relativePath)
+		if err != nil {
+			return err
+		}
+		if removed {
+			continue
+		}
+		data, err := m.renderFile(relativePath)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "--- # %s\n%s", filepath.ToSlash(relativePath), data)
+		if len(data) > 0 && !strings.HasSuffix(string(data), "\n") {
+			fmt.Fprintln(out)
+		}
+	}
+	return nil
+}
+
+func (m *yamlManager) renderFile(relativePath string) ([]byte, error) {
+	if filepath.Ext(relativePath) == ".json" {
+		return m.readFile(relativePath)
+	}
+	merged, _, err := m.mergedFile(relativePath, len(m.layers()))
+	if err != nil {
+		return nil, err
+	}
+	data, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error merging file %s", relativePath)
+	}
+	return data, nil
+}
+
+// isRemoved - true when relativePath belongs to an org or space the overlays remove
+func (m *yamlManager) isRemoved(relativePath string) (bool, error) {
+	parts := strings.Split(filepath.ToSlash(relativePath), "/")
+	if len(parts) < 2 {
+		return false, nil
+	}
+	removedOrgs, err := m.removedNames("orgs.yml")
+	if err != nil {
+		return false, err
+	}
+	if removedOrgs[parts[0]] {
+		return true, nil
+	}
+	if len(parts) < 3 {
+		return false, nil
+	}
+	removedSpaces, err := m.removedNames(filepath.Join(parts[0], "spaces.yml"))
+	if err != nil {
+		return false, err
+	}
+	return removedSpaces[parts[1]], nil
+}
+
+// hidden - true for the files under a directory such as .git
+func hidden(relativePath string) bool {
+	for _, part := range strings.Split(filepath.ToSlash(relativePath), "/") {
+		if strings.HasPrefix(part, ".") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,239 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+	// memoryPattern - the values ToMegabytes reads
+	memoryPattern = `^(|-1|unlimited|Unlimited|UNLIMITED|-?\d+|-?\d+\.?\d*[KMGTkmgt][Bb]?)$`
+	// integerPattern - the values ToInteger reads
+	integerPattern = `^(|-1|unlimited|Unlimited|UNLIMITED|\d+)$`
+)
+
+// JSONSchema - a JSON Schema, draft-07, of a config file or of a key in one
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 interface{}            `json:"type,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Enum                 []interface{}          `json:"enum,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Deprecated           bool                   `json:"deprecated,omitempty"`
+}
+
+// SchemaFile - the JSON Schema of a kind of config file
+type SchemaFile struct {
+	// Name - the schema is written to <Name>.schema.json
+	Name string
+	// Files - globs, relative to the config directory, of the files it is for
+	Files  []string
+	Schema *JSONSchema
+}
+
+// FileName - the name the schema is written to
+func (s SchemaFile) FileName() string {
+	return s.Name + ".schema.json"
+}
+
+// JSON - the schema, indented
+func (s SchemaFile) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(s.Schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// configFile - a kind of config file, the files of that kind, as globs
+// relative to the config directory, and the type they are read into
+type configFile struct {
+	name        string
+	files       []string
+	dataType    interface{}
+	title       string
+	description string
+	// extraKeys - the keys the files can have that the type doesn't
+	extraKeys map[string]*JSONSchema
+}
+
+var configFiles = []configFile{
+	{
+		name:        "cf-mgmt",
+		files:       []string{"cf-mgmt.yml"},
+		dataType:    &GlobalConfig{},
+		title:       "cf-mgmt.yml",
+		description: "settings that aren't for an org or space",
+	},
+	{
+		name:        "ldap",
+		files:       []string{"ldap.yml"},
+		dataType:    &LdapConfig{},
+		title:       "ldap.yml",
+		description: "the ldap server users and groups are looked up in",
+	},
+	{
+		name:        "orgs",
+		files:       []string{"orgs.yml"},
+		dataType:    &Orgs{},
+		title:       "orgs.yml",
+		description: "the orgs managed by cf-mgmt",
+		extraKeys:   removeKeySchema(listRules["orgs.yml"].removeKey, "orgs an overlay removes from those of the layers below it"),
+	},
+	{
+		name:        "orgConfig",
+		files:       []string{"*/orgConfig.yml", orgDefaultsFile},
+		dataType:    &OrgConfig{},
+		title:       "orgConfig.yml",
+		description: "the config of an org, or the defaults of every org",
+	},
+	{
+		name:        "orgQuota",
+		files:       []string{"org_quotas/*.yml"},
+		dataType:    &OrgQuota{},
+		title:       "org quota",
+		description: "a quota orgs can name in named_quota",
+		extraKeys:   quotaNameSchema,
+	},
+	{
+		name:        "spaces",
+		files:       []string{"*/spaces.yml"},
+		dataType:    &Spaces{},
+		title:       "spaces.yml",
+		description: "the spaces of an org managed by cf-mgmt",
+		extraKeys:   removeKeySchema(listRules["spaces.yml"].removeKey, "spaces an overlay removes from those of the layers below it"),
+	},
+	{
+		name:        "spaceConfig",
+		files:       []string{"*/*/spaceConfig.yml", spaceDefaultsFile, "*/" + spaceDefaultsFile},
+		dataType:    &SpaceConfig{},
+		title:       "spaceConfig.yml",
+		description: "the config of a space, or the defaults of every space",
+	},
+	{
+		name:        "spaceQuota",
+		files:       []string{"*/space_quotas/*.yml"},
+		dataType:    &SpaceQuota{},
+		title:       "space quota",
+		description: "a quota the spaces of an org can name in named_quota",
+		extraKeys:   quotaNameSchema,
+	},
+}
+
+var quotaNameSchema = map[string]*JSONSchema{
+	"name": {Description: "name of the quota, taken from the name of the file", Type: []string{"string", "null"}},
+}
+
+func removeKeySchema(key, description string) map[string]*JSONSchema {
+	return map[string]*JSONSchema{key: {Description: description, Type: []string{"array", "null"}, Items: &JSONSchema{Type: "string"}}}
+}
+
+// SchemaFiles - the JSON Schemas of the config files, generated from the types
+// they are read into
+func SchemaFiles() []SchemaFile {
+	var schemaFiles []SchemaFile
+	for _, file := range configFiles {
+		schemaFiles = append(schemaFiles, SchemaFile{Name: file.name, Files: file.files, Schema: fileSchema(file)})
+	}
+	return schemaFiles
+}
+
+// fileSchema - the schema of the type of file, with the extra keys it can have
+func fileSchema(file configFile) *JSONSchema {
+	schema := typeSchema(reflect.TypeOf(file.dataType))
+	schema.Schema = jsonSchemaDraft
+	schema.Title = file.title
+	schema.Description = file.description
+	for key, property := range file.extraKeys {
+		schema.Properties[key] = property
+	}
+	return schema
+}
+
+func typeSchema(t reflect.Type) *JSONSchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		schema := &JSONSchema{Type: "object", Properties: map[string]*JSONSchema{}, AdditionalProperties: false}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			key := yamlKey(field)
+			if key == "" || key == "-" {
+				continue
+			}
+			schema.Properties[key] = fieldSchema(t, field, key)
+		}
+		return schema
+	case reflect.Slice:
+		return &JSONSchema{Type: "array", Items: typeSchema(t.Elem())}
+	case reflect.Map:
+		return &JSONSchema{Type: "object", AdditionalProperties: typeSchema(t.Elem())}
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &JSONSchema{Type: "integer"}
+	}
+	return &JSONSchema{Type: "string"}
+}
+
+// fieldSchema - the schema of a key of a struct, which, as in yaml, can be left
+// empty
+func fieldSchema(t reflect.Type, field reflect.StructField, key string) *JSONSchema {
+	schema := typeSchema(field.Type)
+	schema.Description = field.Tag.Get("description")
+	schema.Format = field.Tag.Get("format")
+	if _, ok := deprecatedKeys[t][key]; ok {
+		schema.Deprecated = true
+	}
+	switch {
+	case contains(memoryFields, key):
+		schema.Type = "string"
+		schema.Pattern = memoryPattern
+	case contains(integerFields, key):
+		schema.Type = "string"
+		schema.Pattern = integerPattern
+	}
+	if enum := field.Tag.Get("enum"); enum != "" {
+		schema.Enum = enumValues(strings.Split(enum, ","))
+	}
+	switch {
+	case schema.Enum != nil:
+		// the enum has any boolean or number yaml reads its values as
+		schema.Type = nil
+		schema.Enum = append(schema.Enum, nil)
+	case schema.Pattern != "":
+		// yaml reads an unquoted 512 as a number, which cf-mgmt takes as written
+		schema.Type = []string{"string", "integer", "null"}
+	default:
+		schema.Type = []string{schema.Type.(string), "null"}
+	}
+	return schema
+}
+
+// enumValues - values, the blank value and, as yaml reads those unquoted as
+// booleans and numbers, each of them that reads back the same as a boolean or
+// number
+func enumValues(values []string) []interface{} {
+	enum := []interface{}{""}
+	for _, value := range values {
+		enum = append(enum, value)
+	}
+	for _, value := range values {
+		if b, err := strconv.ParseBool(value); err == nil && strconv.FormatBool(b) == value {
+			enum = append(enum, b)
+		} else if f, err := strconv.ParseFloat(value, 64); err == nil && strconv.FormatFloat(f, 'f', -1, 64) == value {
+			enum = append(enum, f)
+		}
+	}
+	return enum
+}
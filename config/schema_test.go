@@ -0,0 +1,79 @@
+package config_test
+
+import (
+	"encoding/json"
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vmwarepivotallabs/cf-mgmt/config"
+)
+
+var _ = Describe("Schema", func() {
+	schemaOf := func(name string) *config.JSONSchema {
+		for _, file := range config.SchemaFiles() {
+			if file.Name == name {
+				return file.Schema
+			}
+		}
+		Fail("no schema " + name)
+		return nil
+	}
+
+	It("has a schema for each kind of config file", func() {
+		var names []string
+		for _, file := range config.SchemaFiles() {
+			names = append(names, file.FileName())
+			Expect(file.Schema.Schema).Should(Equal("http://json-schema.org/draft-07/schema#"))
+			Expect(file.Schema.AdditionalProperties).Should(Equal(false))
+			data, err := file.JSON()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(json.Valid(data)).Should(BeTrue())
+		}
+		Expect(names).Should(ConsistOf("cf-mgmt.schema.json", "ldap.schema.json", "orgs.schema.json", "orgConfig.schema.json",
+			"orgQuota.schema.json", "spaces.schema.json", "spaceConfig.schema.json", "spaceQuota.schema.json"))
+	})
+
+	It("describes the keys and those of the structs they hold", func() {
+		orgConfig := schemaOf("orgConfig")
+		Expect(orgConfig.Properties).Should(HaveKey("original-org"))
+		Expect(orgConfig.Properties["named_quota"].Description).Should(Equal("name of a quota in org_quotas, cannot be used with enable-org-quota"))
+		Expect(orgConfig.Properties["org-manager"].Properties).Should(HaveKey("ldap_groups"))
+		Expect(orgConfig.Properties["org-manager"].Properties["ldap_group"].Deprecated).Should(BeTrue())
+		Expect(orgConfig.Properties["org-manager-group"].Deprecated).Should(BeTrue())
+		Expect(orgConfig.Properties["metadata"].Properties["labels"].AdditionalProperties).Should(Equal(&config.JSONSchema{Type: "string"}))
+
+		global := schemaOf("cf-mgmt")
+		Expect(global.Properties["service-access"].Items.Properties["services"].Items.Properties).Should(HaveKey("limited_access_plans"))
+		Expect(global.Properties["deletion-limits"].Properties["orgs"].Properties).Should(HaveKey("max-percent"))
+	})
+
+	It("allows the keys only overlays and quota files have", func() {
+		Expect(schemaOf("orgs").Properties).Should(HaveKey("remove-orgs"))
+		Expect(schemaOf("spaces").Properties).Should(HaveKey("remove-spaces"))
+		Expect(schemaOf("orgQuota").Properties).Should(HaveKey("name"))
+		Expect(schemaOf("spaceQuota").Properties).Should(HaveKey("name"))
+	})
+
+	It("matches the quota values cf-mgmt reads", func() {
+		memory := regexp.MustCompile(schemaOf("spaceQuota").Properties["memory-limit"].Pattern)
+		for _, value := range []string{"", "-1", "unlimited", "1024", "10G", "1.5GB", "512m"} {
+			_, err := config.ToMegabytes(value)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(memory.MatchString(value)).Should(BeTrue(), value)
+		}
+		Expect(memory.MatchString("10 gigs")).Should(BeFalse())
+
+		routes := regexp.MustCompile(schemaOf("orgConfig").Properties["total-routes"].Pattern)
+		Expect(routes.MatchString("unlimited")).Should(BeTrue())
+		Expect(routes.MatchString("100")).Should(BeTrue())
+		Expect(routes.MatchString("10G")).Should(BeFalse())
+	})
+
+	It("has enums and formats", func() {
+		ldap := schemaOf("ldap")
+		Expect(ldap.Properties["minTLSVersion"].Enum).Should(Equal([]interface{}{"", "1.0", "1.1", "1.2", "1.3", 1.1, 1.2, 1.3, nil}))
+		Expect(ldap.Properties["insecure_skip_verify"].Enum).Should(ContainElements("true", true, "false", false))
+		Expect(schemaOf("spaceConfig").Properties["allow-ssh-until"].Format).Should(Equal("date-time"))
+	})
+})
@@ -0,0 +1,97 @@
+package config
+
+// selectedReader narrows the orgs and spaces a Reader returns to a Selection.
+// orgs.yml is returned in full as it is the list of every org cf-mgmt manages
+// and is used to decide what isn't managed.
+type selectedReader struct {
+	Reader
+	selection *Selection
+}
+
+// NewSelectedReader returns a Reader that only returns the org and space
+// configurations included in selection.  The reader is returned unchanged when
+// selection is nil.
+func NewSelectedReader(reader Reader, selection *Selection) Reader {
+	if selection == nil {
+		return reader
+	}
+	return &selectedReader{Reader: reader, selection: selection}
+}
+
+// SelectionOf returns the Selection a Reader was narrowed to or nil when it
+// returns the whole configuration.
+func SelectionOf(reader Reader) *Selection {
+	if selected, ok := reader.(*selectedReader); ok {
+		return selected.selection
+	}
+	return nil
+}
+
+func (r *selectedReader) GetOrgConfigs() ([]OrgConfig, error) {
+	orgConfigs, err := r.Reader.GetOrgConfigs()
+	if err != nil {
+		return nil, err
+	}
+	var result []OrgConfig
+	for i := range orgConfigs {
+		if r.selection.IncludesOrg(&orgConfigs[i]) {
+			result = append(result, orgConfigs[i])
+		}
+	}
+	return result, nil
+}
+
+func (r *selectedReader) GetSpaceConfigs() ([]SpaceConfig, error) {
+	orgConfigs, err := r.orgConfigsByName()
+	if err != nil {
+		return nil, err
+	}
+	spaceConfigs, err := r.Reader.GetSpaceConfigs()
+	if err != nil {
+		return nil, err
+	}
+	var result []SpaceConfig
+	for i := range spaceConfigs {
+		if r.selection.IncludesSpace(orgConfigs[spaceConfigs[i].Org], &spaceConfigs[i]) {
+			result = append(result, spaceConfigs[i])
+		}
+	}
+	return result, nil
+}
+
+// Spaces returns the spaces.yml of the selected orgs, each with its full list of
+// spaces so spaces that are configured but not selected are never considered
+// for deletion.
+func (r *selectedReader) Spaces() ([]Spaces, error) {
+	orgConfigs, err := r.orgConfigsByName()
+	if err != nil {
+		return nil, err
+	}
+	spaces, err := r.Reader.Spaces()
+	if err != nil {
+		return nil, err
+	}
+	var result []Spaces
+	for _, orgSpaces := range spaces {
+		orgConfig, ok := orgConfigs[orgSpaces.Org]
+		if !ok {
+			orgConfig = &OrgConfig{Org: orgSpaces.Org}
+		}
+		if r.selection.IncludesOrg(orgConfig) {
+			result = append(result, orgSpaces)
+		}
+	}
+	return result, nil
+}
+
+func (r *selectedReader) orgConfigsByName() (map[string]*OrgConfig, error) {
+	orgConfigs, err := r.Reader.GetOrgConfigs()
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]*OrgConfig)
+	for i := range orgConfigs {
+		result[orgConfigs[i].Org] = &orgConfigs[i]
+	}
+	return result, nil
+}
@@ -0,0 +1,203 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Selection restricts a run to some of the configured orgs and spaces.  A nil
+// Selection selects everything.
+type Selection struct {
+	Orgs          []string
+	OrgRegex      *regexp.Regexp
+	Spaces        []string
+	LabelSelector []LabelRequirement
+}
+
+// LabelRequirement is a single term of a label selector such as env=prod,
+// env!=prod, env or !env.
+type LabelRequirement struct {
+	Key      string
+	Value    string
+	Operator string
+}
+
+const (
+	labelEquals    = "="
+	labelNotEquals = "!="
+	labelExists    = "exists"
+	labelNotExists = "!"
+)
+
+// NewSelection builds a Selection from command line filters, returning nil when
+// no filter is set.
+func NewSelection(orgs []string, orgRegex string, spaces []string, labelSelector string) (*Selection, error) {
+	if len(orgs) == 0 && orgRegex == "" && len(spaces) == 0 && labelSelector == "" {
+		return nil, nil
+	}
+	selection := &Selection{
+		Orgs:   orgs,
+		Spaces: spaces,
+	}
+	if orgRegex != "" {
+		regex, err := regexp.Compile(orgRegex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid org regex [%s]: %v", orgRegex, err)
+		}
+		selection.OrgRegex = regex
+	}
+	if labelSelector != "" {
+		requirements, err := ParseLabelSelector(labelSelector)
+		if err != nil {
+			return nil, err
+		}
+		selection.LabelSelector = requirements
+	}
+	if len(spaces) > 0 && len(orgs) == 0 && selection.OrgRegex == nil && len(selection.LabelSelector) == 0 {
+		return nil, fmt.Errorf("space filter must be combined with an org, org regex or label selector filter")
+	}
+	return selection, nil
+}
+
+// ParseLabelSelector parses a comma separated list of key=value, key!=value, key
+// and !key requirements.
+func ParseLabelSelector(labelSelector string) ([]LabelRequirement, error) {
+	var requirements []LabelRequirement
+	for _, term := range strings.Split(labelSelector, ",") {
+		term = strings.TrimSpace(term)
+		var requirement LabelRequirement
+		switch {
+		case term == "":
+			return nil, fmt.Errorf("invalid label selector [%s]: empty requirement", labelSelector)
+		case strings.Contains(term, labelNotEquals):
+			parts := strings.SplitN(term, labelNotEquals, 2)
+			requirement = LabelRequirement{Key: strings.TrimSpace(parts[0]), Value: strings.TrimSpace(parts[1]), Operator: labelNotEquals}
+		case strings.Contains(term, labelEquals):
+			parts := strings.SplitN(strings.Replace(term, "==", "=", 1), labelEquals, 2)
+			requirement = LabelRequirement{Key: strings.TrimSpace(parts[0]), Value: strings.TrimSpace(parts[1]), Operator: labelEquals}
+		case strings.HasPrefix(term, labelNotExists):
+			requirement = LabelRequirement{Key: strings.TrimSpace(strings.TrimPrefix(term, labelNotExists)), Operator: labelNotExists}
+		default:
+			requirement = LabelRequirement{Key: term, Operator: labelExists}
+		}
+		if requirement.Key == "" {
+			return nil, fmt.Errorf("invalid label selector [%s]: missing key in [%s]", labelSelector, term)
+		}
+		requirements = append(requirements, requirement)
+	}
+	return requirements, nil
+}
+
+// Matches returns true when the labels satisfy the requirement.
+func (r LabelRequirement) Matches(labels map[string]string) bool {
+	value, ok := labels[r.Key]
+	switch r.Operator {
+	case labelEquals:
+		return ok && value == r.Value
+	case labelNotEquals:
+		return !ok || value != r.Value
+	case labelNotExists:
+		return !ok
+	default:
+		return ok
+	}
+}
+
+// IncludesOrg returns true when the configured org is selected.
+func (s *Selection) IncludesOrg(orgConfig *OrgConfig) bool {
+	if s == nil {
+		return true
+	}
+	return s.includesOrgName(orgConfig.Org) && s.matchesLabels(orgLabels(orgConfig))
+}
+
+// IncludesSpace returns true when the configured space is selected.  Labels of
+// the org apply to its spaces unless the space overrides them.
+func (s *Selection) IncludesSpace(orgConfig *OrgConfig, spaceConfig *SpaceConfig) bool {
+	if s == nil {
+		return true
+	}
+	if !s.includesOrgName(spaceConfig.Org) || !s.includesSpaceName(spaceConfig.Space) {
+		return false
+	}
+	labels := make(map[string]string)
+	for key, value := range orgLabels(orgConfig) {
+		labels[key] = value
+	}
+	if spaceConfig.Metadata != nil {
+		for key, value := range spaceConfig.Metadata.Labels {
+			labels[key] = value
+		}
+	}
+	return s.matchesLabels(labels)
+}
+
+// IncludesUnconfiguredOrg returns true when an org that isn't in the
+// configuration may be deleted.  Orgs without configuration have no labels so a
+// label selector never selects them.
+func (s *Selection) IncludesUnconfiguredOrg(orgName string) bool {
+	if s == nil {
+		return true
+	}
+	return len(s.LabelSelector) == 0 && s.includesOrgName(orgName)
+}
+
+// IncludesUnconfiguredSpace returns true when a space that isn't in the
+// configuration of a selected org may be deleted.
+func (s *Selection) IncludesUnconfiguredSpace(orgName, spaceName string) bool {
+	if s == nil {
+		return true
+	}
+	return len(s.LabelSelector) == 0 && s.includesOrgName(orgName) && s.includesSpaceName(spaceName)
+}
+
+// containsFold - names of orgs and spaces are matched without regard to case as
+// cloud controller treats them that way
+func containsFold(names []string, name string) bool {
+	for _, item := range names {
+		if strings.EqualFold(item, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Selection) includesOrgName(orgName string) bool {
+	if len(s.Orgs) > 0 && !containsFold(s.Orgs, orgName) {
+		return false
+	}
+	if s.OrgRegex != nil && !s.OrgRegex.MatchString(orgName) {
+		return false
+	}
+	return true
+}
+
+func (s *Selection) includesSpaceName(spaceName string) bool {
+	return len(s.Spaces) == 0 || containsFold(s.Spaces, spaceName)
+}
+
+func (s *Selection) matchesLabels(labels map[string]string) bool {
+	for _, requirement := range s.LabelSelector {
+		if !requirement.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+func orgLabels(orgConfig *OrgConfig) map[string]string {
+	if orgConfig == nil || orgConfig.Metadata == nil {
+		return nil
+	}
+	return orgConfig.Metadata.Labels
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
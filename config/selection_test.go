@@ -0,0 +1,147 @@
+package config_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vmwarepivotallabs/cf-mgmt/config"
+	"github.com/vmwarepivotallabs/cf-mgmt/config/fakes"
+)
+
+var _ = Describe("Selection", func() {
+	Context("NewSelection", func() {
+		It("returns nil without filters", func() {
+			selection, err := config.NewSelection(nil, "", nil, "")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(selection).Should(BeNil())
+		})
+		It("errors on an invalid org regex", func() {
+			_, err := config.NewSelection(nil, "(", nil, "")
+			Expect(err).Should(HaveOccurred())
+		})
+		It("errors on an invalid label selector", func() {
+			_, err := config.NewSelection(nil, "", nil, "env=prod,,team")
+			Expect(err).Should(HaveOccurred())
+			_, err = config.NewSelection(nil, "", nil, "=prod")
+			Expect(err).Should(HaveOccurred())
+		})
+		It("errors on a space filter without an org filter", func() {
+			_, err := config.NewSelection(nil, "", []string{"dev"}, "")
+			Expect(err).Should(MatchError("space filter must be combined with an org, org regex or label selector filter"))
+		})
+	})
+
+	Context("ParseLabelSelector", func() {
+		It("parses every kind of requirement", func() {
+			requirements, err := config.ParseLabelSelector("env=prod, tier!=gold,team,!legacy,zone==a")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(requirements).Should(HaveLen(5))
+			labels := map[string]string{"env": "prod", "tier": "silver", "team": "a", "zone": "a"}
+			for _, requirement := range requirements {
+				Expect(requirement.Matches(labels)).Should(BeTrue(), requirement.Key)
+			}
+			Expect(requirements[3].Matches(map[string]string{"legacy": "true"})).Should(BeFalse())
+			Expect(requirements[1].Matches(map[string]string{"tier": "gold"})).Should(BeFalse())
+		})
+	})
+
+	Context("Includes", func() {
+		var (
+			prodOrg *config.OrgConfig
+			devOrg  *config.OrgConfig
+		)
+		BeforeEach(func() {
+			prodOrg = &config.OrgConfig{Org: "team-prod", Metadata: &config.Metadata{Labels: map[string]string{"env": "prod"}}}
+			devOrg = &config.OrgConfig{Org: "team-dev"}
+		})
+		It("includes everything when nil", func() {
+			var selection *config.Selection
+			Expect(selection.IncludesOrg(devOrg)).Should(BeTrue())
+			Expect(selection.IncludesSpace(devOrg, &config.SpaceConfig{Org: "team-dev", Space: "space"})).Should(BeTrue())
+			Expect(selection.IncludesUnconfiguredOrg("anything")).Should(BeTrue())
+			Expect(selection.IncludesUnconfiguredSpace("anything", "space")).Should(BeTrue())
+		})
+		It("selects orgs by name and regex", func() {
+			selection, err := config.NewSelection([]string{"team-prod", "other"}, "^team-", nil, "")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(selection.IncludesOrg(prodOrg)).Should(BeTrue())
+			Expect(selection.IncludesOrg(devOrg)).Should(BeFalse())
+			Expect(selection.IncludesUnconfiguredOrg("other")).Should(BeFalse())
+		})
+		It("selects spaces of the selected orgs", func() {
+			selection, err := config.NewSelection(nil, "^team-", []string{"dev"}, "")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(selection.IncludesSpace(devOrg, &config.SpaceConfig{Org: "team-dev", Space: "dev"})).Should(BeTrue())
+			Expect(selection.IncludesSpace(devOrg, &config.SpaceConfig{Org: "team-dev", Space: "test"})).Should(BeFalse())
+			Expect(selection.IncludesUnconfiguredSpace("team-dev", "dev")).Should(BeTrue())
+			Expect(selection.IncludesUnconfiguredSpace("team-dev", "test")).Should(BeFalse())
+		})
+		It("matches org and space names without regard to case", func() {
+			selection, err := config.NewSelection([]string{"Team-Dev"}, "", []string{"DEV"}, "")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(selection.IncludesOrg(devOrg)).Should(BeTrue())
+			Expect(selection.IncludesSpace(devOrg, &config.SpaceConfig{Org: "team-dev", Space: "dev"})).Should(BeTrue())
+			Expect(selection.IncludesUnconfiguredSpace("TEAM-DEV", "Dev")).Should(BeTrue())
+			Expect(selection.IncludesUnconfiguredOrg("team-prod")).Should(BeFalse())
+		})
+		It("selects spaces by their own and their org's labels", func() {
+			selection, err := config.NewSelection(nil, "", nil, "env=prod")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(selection.IncludesOrg(prodOrg)).Should(BeTrue())
+			Expect(selection.IncludesOrg(devOrg)).Should(BeFalse())
+			Expect(selection.IncludesSpace(prodOrg, &config.SpaceConfig{Org: "team-prod", Space: "a"})).Should(BeTrue())
+			Expect(selection.IncludesSpace(prodOrg, &config.SpaceConfig{Org: "team-prod", Space: "b", Metadata: &config.Metadata{Labels: map[string]string{"env": "test"}}})).Should(BeFalse())
+			Expect(selection.IncludesSpace(devOrg, &config.SpaceConfig{Org: "team-dev", Space: "c", Metadata: &config.Metadata{Labels: map[string]string{"env": "prod"}}})).Should(BeTrue())
+		})
+		It("never selects unconfigured orgs and spaces with a label selector", func() {
+			selection, err := config.NewSelection(nil, "", nil, "!legacy")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(selection.IncludesUnconfiguredOrg("team-old")).Should(BeFalse())
+			Expect(selection.IncludesUnconfiguredSpace("team-prod", "old")).Should(BeFalse())
+		})
+	})
+
+	Context("NewSelectedReader", func() {
+		var fakeReader *fakes.FakeReader
+		BeforeEach(func() {
+			fakeReader = new(fakes.FakeReader)
+			fakeReader.OrgsReturns(&config.Orgs{Orgs: []string{"team-a", "team-b"}}, nil)
+			fakeReader.GetOrgConfigsReturns([]config.OrgConfig{{Org: "team-a"}, {Org: "team-b"}}, nil)
+			fakeReader.GetSpaceConfigsReturns([]config.SpaceConfig{
+				{Org: "team-a", Space: "dev"},
+				{Org: "team-a", Space: "test"},
+				{Org: "team-b", Space: "dev"},
+			}, nil)
+			fakeReader.SpacesReturns([]config.Spaces{
+				{Org: "team-a", Spaces: []string{"dev", "test"}},
+				{Org: "team-b", Spaces: []string{"dev"}},
+			}, nil)
+		})
+		It("returns the reader unchanged without a selection", func() {
+			reader := config.NewSelectedReader(fakeReader, nil)
+			Expect(reader).Should(BeIdenticalTo(fakeReader))
+			Expect(config.SelectionOf(reader)).Should(BeNil())
+		})
+		It("only returns the selected orgs and spaces", func() {
+			selection, err := config.NewSelection([]string{"team-a"}, "", []string{"dev"}, "")
+			Expect(err).ShouldNot(HaveOccurred())
+			reader := config.NewSelectedReader(fakeReader, selection)
+			Expect(config.SelectionOf(reader)).Should(Equal(selection))
+
+			orgs, err := reader.Orgs()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(orgs.Orgs).Should(ConsistOf("team-a", "team-b"))
+
+			orgConfigs, err := reader.GetOrgConfigs()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(orgConfigs).Should(ConsistOf(config.OrgConfig{Org: "team-a"}))
+
+			spaceConfigs, err := reader.GetSpaceConfigs()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(spaceConfigs).Should(ConsistOf(config.SpaceConfig{Org: "team-a", Space: "dev"}))
+
+			spaces, err := reader.Spaces()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(spaces).Should(ConsistOf(config.Spaces{Org: "team-a", Spaces: []string{"dev", "test"}}))
+		})
+	})
+})
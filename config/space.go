@@ -8,45 +8,45 @@ import (
 
 // Spaces describes cf-mgmt config for all spaces.
 type Spaces struct {
-	Org                string   `yaml:"org"`
-	Spaces             []string `yaml:"spaces"`
-	EnableDeleteSpaces bool     `yaml:"enable-delete-spaces"`
+	Org                string   `yaml:"org" description:"name of the org"`
+	Spaces             []string `yaml:"spaces" description:"spaces of the org managed by cf-mgmt, each with a directory"`
+	EnableDeleteSpaces bool     `yaml:"enable-delete-spaces" description:"delete the spaces of the org that aren't listed"`
 }
 
 // SpaceConfig describes attributes for a space.
 type SpaceConfig struct {
-	Org                         string    `yaml:"org"`
-	Space                       string    `yaml:"space"`
-	OriginalSpace               string    `yaml:"original-space,omitempty"`
-	Developer                   UserMgmt  `yaml:"space-developer"`
-	Manager                     UserMgmt  `yaml:"space-manager"`
-	Auditor                     UserMgmt  `yaml:"space-auditor"`
-	Supporter                   UserMgmt  `yaml:"space-supporter"`
-	DeveloperGroup              string    `yaml:"space-developer-group,omitempty"`
-	ManagerGroup                string    `yaml:"space-manager-group,omitempty"`
-	AuditorGroup                string    `yaml:"space-auditor-group,omitempty"`
-	SupporterGroup              string    `yaml:"space-supporter-group,omitempty"`
-	AllowSSH                    bool      `yaml:"allow-ssh"`
-	AllowSSHUntil               string    `yaml:"allow-ssh-until,omitempty"`
-	EnableSpaceQuota            bool      `yaml:"enable-space-quota"`
-	EnableSecurityGroup         bool      `yaml:"enable-security-group"`
-	EnableUnassignSecurityGroup bool      `yaml:"enable-unassign-security-group"`
-	SecurityGroupContents       string    `yaml:"security-group-contents,omitempty"`
-	RemoveUsers                 bool      `yaml:"enable-remove-users"`
-	IsoSegment                  string    `yaml:"isolation_segment"`
-	ASGs                        []string  `yaml:"named-security-groups"`
-	MemoryLimit                 string    `yaml:"memory-limit,omitempty"`
-	InstanceMemoryLimit         string    `yaml:"instance-memory-limit,omitempty"`
-	TotalRoutes                 string    `yaml:"total-routes,omitempty"`
-	TotalServices               string    `yaml:"total-services,omitempty"`
-	PaidServicePlansAllowed     bool      `yaml:"paid-service-plans-allowed"`
-	TotalReservedRoutePorts     string    `yaml:"total_reserved_route_ports,omitempty"`
-	TotalServiceKeys            string    `yaml:"total_service_keys,omitempty"`
-	AppInstanceLimit            string    `yaml:"app_instance_limit,omitempty"`
-	AppTaskLimit                string    `yaml:"app_task_limit,omitempty"`
-	LogRateLimitBytesPerSecond  string    `yaml:"log_rate_limit_bytes_per_second,omitempty"`
-	NamedQuota                  string    `yaml:"named_quota"`
-	Metadata                    *Metadata `yaml:"metadata"`
+	Org                         string    `yaml:"org" description:"name of the org of the space"`
+	Space                       string    `yaml:"space" description:"name of the space, the same as its directory"`
+	OriginalSpace               string    `yaml:"original-space,omitempty" description:"name the space had before it was renamed, for the rename to be applied"`
+	Developer                   UserMgmt  `yaml:"space-developer" description:"users and groups given the space developer role"`
+	Manager                     UserMgmt  `yaml:"space-manager" description:"users and groups given the space manager role"`
+	Auditor                     UserMgmt  `yaml:"space-auditor" description:"users and groups given the space auditor role"`
+	Supporter                   UserMgmt  `yaml:"space-supporter" description:"users and groups given the space supporter role"`
+	DeveloperGroup              string    `yaml:"space-developer-group,omitempty" description:"ldap group given the space developer role, deprecated, use space-developer.ldap_groups"`
+	ManagerGroup                string    `yaml:"space-manager-group,omitempty" description:"ldap group given the space manager role, deprecated, use space-manager.ldap_groups"`
+	AuditorGroup                string    `yaml:"space-auditor-group,omitempty" description:"ldap group given the space auditor role, deprecated, use space-auditor.ldap_groups"`
+	SupporterGroup              string    `yaml:"space-supporter-group,omitempty" description:"ldap group given the space supporter role, deprecated, use space-supporter.ldap_groups"`
+	AllowSSH                    bool      `yaml:"allow-ssh" description:"allow cf ssh to the apps of the space"`
+	AllowSSHUntil               string    `yaml:"allow-ssh-until,omitempty" description:"allow cf ssh until this RFC3339 time, such as 2006-01-02T15:04:05Z" format:"date-time"`
+	EnableSpaceQuota            bool      `yaml:"enable-space-quota" description:"give the space its own quota from the limits below, cannot be used with named_quota"`
+	EnableSecurityGroup         bool      `yaml:"enable-security-group" description:"bind the security group in security-group.json of the space directory"`
+	EnableUnassignSecurityGroup bool      `yaml:"enable-unassign-security-group" description:"unbind the security groups of the space that aren't configured"`
+	SecurityGroupContents       string    `yaml:"security-group-contents,omitempty" description:"rules of the security group of the space, read from security-group.json"`
+	RemoveUsers                 bool      `yaml:"enable-remove-users" description:"remove users from the space roles they aren't configured for"`
+	IsoSegment                  string    `yaml:"isolation_segment" description:"isolation segment the space runs in"`
+	ASGs                        []string  `yaml:"named-security-groups" description:"security groups in asgs bound to the space"`
+	MemoryLimit                 string    `yaml:"memory-limit,omitempty" description:"total memory of the space, such as 10G, 512M or unlimited"`
+	InstanceMemoryLimit         string    `yaml:"instance-memory-limit,omitempty" description:"memory of an app instance, such as 1G, 512M or unlimited"`
+	TotalRoutes                 string    `yaml:"total-routes,omitempty" description:"number of routes or unlimited"`
+	TotalServices               string    `yaml:"total-services,omitempty" description:"number of service instances or unlimited"`
+	PaidServicePlansAllowed     bool      `yaml:"paid-service-plans-allowed" description:"allow service instances of paid plans"`
+	TotalReservedRoutePorts     string    `yaml:"total_reserved_route_ports,omitempty" description:"number of reserved route ports or unlimited"`
+	TotalServiceKeys            string    `yaml:"total_service_keys,omitempty" description:"number of service keys or unlimited"`
+	AppInstanceLimit            string    `yaml:"app_instance_limit,omitempty" description:"number of app instances or unlimited"`
+	AppTaskLimit                string    `yaml:"app_task_limit,omitempty" description:"number of running tasks or unlimited"`
+	LogRateLimitBytesPerSecond  string    `yaml:"log_rate_limit_bytes_per_second,omitempty" description:"log rate of an app instance in bytes per second or unlimited"`
+	NamedQuota                  string    `yaml:"named_quota" description:"name of a quota in the space_quotas of the org, cannot be used with enable-space-quota"`
+	Metadata                    *Metadata `yaml:"metadata" description:"labels and annotations of the space"`
 }
 
 func (s *SpaceConfig) GetSecurityGroupContents() string {
@@ -76,16 +76,16 @@ func (s *SpaceConfig) GetQuota() SpaceQuota {
 type SpaceQuota struct {
 	Name                       string `yaml:"-"`
 	Org                        string `yaml:"-"`
-	MemoryLimit                string `yaml:"memory-limit"`
-	InstanceMemoryLimit        string `yaml:"instance-memory-limit"`
-	TotalRoutes                string `yaml:"total-routes"`
-	TotalServices              string `yaml:"total-services"`
-	PaidServicePlansAllowed    bool   `yaml:"paid-service-plans-allowed"`
-	TotalReservedRoutePorts    string `yaml:"total_reserved_route_ports"`
-	TotalServiceKeys           string `yaml:"total_service_keys"`
-	AppInstanceLimit           string `yaml:"app_instance_limit"`
-	AppTaskLimit               string `yaml:"app_task_limit"`
-	LogRateLimitBytesPerSecond string `yaml:"log_rate_limit_bytes_per_second"`
+	MemoryLimit                string `yaml:"memory-limit" description:"total memory, such as 10G, 512M or unlimited"`
+	InstanceMemoryLimit        string `yaml:"instance-memory-limit" description:"memory of an app instance, such as 1G, 512M or unlimited"`
+	TotalRoutes                string `yaml:"total-routes" description:"number of routes or unlimited"`
+	TotalServices              string `yaml:"total-services" description:"number of service instances or unlimited"`
+	PaidServicePlansAllowed    bool   `yaml:"paid-service-plans-allowed" description:"allow service instances of paid plans"`
+	TotalReservedRoutePorts    string `yaml:"total_reserved_route_ports" description:"number of reserved route ports or unlimited"`
+	TotalServiceKeys           string `yaml:"total_service_keys" description:"number of service keys or unlimited"`
+	AppInstanceLimit           string `yaml:"app_instance_limit" description:"number of app instances or unlimited"`
+	AppTaskLimit               string `yaml:"app_task_limit" description:"number of running tasks or unlimited"`
+	LogRateLimitBytesPerSecond string `yaml:"log_rate_limit_bytes_per_second" description:"log rate of an app instance in bytes per second or unlimited"`
 }
 
 func (s *SpaceQuota) IsUnlimitedMemory() bool {
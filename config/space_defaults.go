@@ -0,0 +1,65 @@
+package config
+
+import (
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+// spaceDefaultsFile - the values merged into every space that doesn't set them,
+// at the top of the config for all spaces and in an org directory for its spaces
+const spaceDefaultsFile = "spaceDefaults.yml"
+
+var spaceRoles = []string{"space-developer", "space-manager", "space-auditor", "space-supporter"}
+
+// orgSpaceDefaults - the global space defaults with those of org merged onto them
+func (m *yamlManager) orgSpaceDefaults(org string) (yaml.MapSlice, error) {
+	defaults, err := m.spaceDefaults(spaceDefaultsFile)
+	if err != nil {
+		return nil, err
+	}
+	orgDefaults, err := m.spaceDefaults(filepath.Join(org, spaceDefaultsFile))
+	if err != nil {
+		return nil, err
+	}
+	if orgDefaults == nil {
+		return defaults, nil
+	}
+	return mergeDefaults(defaults, orgDefaults), nil
+}
+
+// spaceDefaults - relativePath without the keys that name a space, or nil when
+// there isn't one.  The ldap_group of a role, and the space-<role>-group it
+// replaced, are moved to its ldap_groups so that they add to the groups of a
+// space rather than give way to its ldap_group.
+func (m *yamlManager) spaceDefaults(relativePath string) (yaml.MapSlice, error) {
+	if !m.exists(relativePath) {
+		return nil, nil
+	}
+	if err := m.checkFile(relativePath, &SpaceConfig{}); err != nil {
+		return nil, err
+	}
+	document := yaml.MapSlice{}
+	if err := m.loadFile(relativePath, &document); err != nil {
+		return nil, err
+	}
+	defaults := withoutKeys(document, "org", "space", "original-space", "security-group-contents")
+	for _, role := range spaceRoles {
+		var groups []interface{}
+		roleDefaults, _ := mapValue(defaults, role).(yaml.MapSlice)
+		if group := mapValue(roleDefaults, "ldap_group"); !isUnset(group) {
+			groups = append(groups, group)
+		}
+		if group := mapValue(defaults, role+"-group"); !isUnset(group) {
+			groups = append(groups, group)
+		}
+		if len(groups) == 0 {
+			continue
+		}
+		listed, _ := mapValue(roleDefaults, "ldap_groups").([]interface{})
+		roleDefaults = append(withoutKeys(roleDefaults, "ldap_group", "ldap_groups"), yaml.MapItem{Key: "ldap_groups", Value: union(listed, groups)})
+		defaults = withoutKeys(defaults, role, role+"-group")
+		defaults = append(defaults, yaml.MapItem{Key: role, Value: roleDefaults})
+	}
+	return defaults, nil
+}
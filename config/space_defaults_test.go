@@ -0,0 +1,56 @@
+package config_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vmwarepivotallabs/cf-mgmt/config"
+)
+
+var _ = Describe("Space defaults", func() {
+	var spaceConfigs map[string]config.SpaceConfig
+
+	BeforeEach(func() {
+		configs, err := config.NewManager("./fixtures/space-defaults-hierarchy").GetSpaceConfigs()
+		Expect(err).ShouldNot(HaveOccurred())
+		spaceConfigs = make(map[string]config.SpaceConfig)
+		for _, spaceConfig := range configs {
+			spaceConfigs[spaceConfig.Space] = spaceConfig
+		}
+	})
+
+	It("merges the global and org defaults a space doesn't set", func() {
+		spaceConfig := spaceConfigs["space2"]
+		Expect(spaceConfig.AllowSSH).Should(BeTrue())
+		Expect(spaceConfig.RemoveUsers).Should(BeTrue())
+		Expect(spaceConfig.NamedQuota).Should(Equal("small"))
+		Expect(spaceConfig.IsoSegment).Should(Equal("iso1"))
+		Expect(spaceConfig.Supporter.LDAPGroups).Should(Equal([]string{"support-team"}))
+		Expect(spaceConfig.Metadata.Labels).Should(Equal(map[string]string{"managed-by": "cf-mgmt", "team": "org1"}))
+	})
+
+	It("unions the lists of every level", func() {
+		Expect(spaceConfigs["space2"].ASGs).Should(Equal([]string{"space2-asg", "org1-asg", "all-spaces"}))
+		Expect(spaceConfigs["space1"].ASGs).Should(Equal([]string{"org1-asg", "all-spaces"}))
+	})
+
+	It("keeps the values a space sets", func() {
+		spaceConfig := spaceConfigs["space1"]
+		Expect(spaceConfig.AllowSSH).Should(BeFalse())
+		Expect(spaceConfig.NamedQuota).Should(Equal("large"))
+	})
+
+	It("only applies the org defaults to the spaces of the org", func() {
+		spaceConfig := spaceConfigs["space3"]
+		Expect(spaceConfig.AllowSSH).Should(BeTrue())
+		Expect(spaceConfig.NamedQuota).Should(BeEmpty())
+		Expect(spaceConfig.IsoSegment).Should(BeEmpty())
+		Expect(spaceConfig.ASGs).Should(Equal([]string{"all-spaces"}))
+	})
+
+	It("leaves the defaults out of a single space", func() {
+		spaceConfig, err := config.NewManager("./fixtures/space-defaults-hierarchy").GetSpaceConfig("org1", "space2")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(spaceConfig.AllowSSH).Should(BeFalse())
+		Expect(spaceConfig.ASGs).Should(Equal([]string{"space2-asg"}))
+	})
+})
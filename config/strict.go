@@ -0,0 +1,216 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/xchapter7x/lo"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	unknownKeyError   = regexp.MustCompile(`^line (\d+): field (.+) not found in type (\S+)$`)
+	duplicateKeyError = regexp.MustCompile(`^line (\d+): (?:key|field) "?([^"]+)"? already set in (?:map|type \S+)$`)
+)
+
+// deprecatedKeys - the keys that still work but have been replaced, by the
+// type that has them, with what to use instead
+var deprecatedKeys = map[reflect.Type]map[string]string{
+	reflect.TypeOf(OrgConfig{}): {
+		"org-billingmanager-group": "org-billingmanager.ldap_groups",
+		"org-manager-group":        "org-manager.ldap_groups",
+		"org-auditor-group":        "org-auditor.ldap_groups",
+		"service-access":           "service-access in cf-mgmt.yml",
+	},
+	reflect.TypeOf(SpaceConfig{}): {
+		"space-developer-group": "space-developer.ldap_groups",
+		"space-manager-group":   "space-manager.ldap_groups",
+		"space-auditor-group":   "space-auditor.ldap_groups",
+		"space-supporter-group": "space-supporter.ldap_groups",
+	},
+	reflect.TypeOf(UserMgmt{}): {
+		"ldap_group": "ldap_groups",
+	},
+}
+
+// checkFile - when strict, errors on the keys of relativePath, in any layer,
+// that dataType doesn't have and warns about the deprecated ones
+func (m *yamlManager) checkFile(relativePath string, dataType interface{}) error {
+	if !m.Strict {
+		return nil
+	}
+	var allowed []string
+	if rule, ok := listRules[filepath.Base(relativePath)]; ok {
+		allowed = append(allowed, rule.removeKey)
+	}
+	switch dataType.(type) {
+	case *OrgQuota, *SpaceQuota:
+		// the name of a quota is taken from its file, though it can be in it
+		allowed = append(allowed, "name")
+	}
+	for _, layer := range m.layers() {
+		filePath := filepath.Join(layer, relativePath)
+		if !FileOrDirectoryExists(filePath) {
+			continue
+		}
+		// keys are never placeholders, so they are checked before the variables
+		// are resolved
+		data, err := LoadFileBytes(filePath)
+		if err != nil {
+			return err
+		}
+		if err = checkKeys(filePath, data, dataType, allowed...); err != nil {
+			return err
+		}
+		for _, warning := range deprecations(data, dataType) {
+			if _, warned := m.warned.LoadOrStore(filePath+warning, true); !warned {
+				lo.G.Warningf("%s: %s", filePath, warning)
+			}
+		}
+	}
+	return nil
+}
+
+// checkKeys - errors, with their lines, on the keys of the yaml document data
+// that dataType doesn't have, other than allowed, and on the keys it repeats.
+// Other errors are left to the unmarshalling of the file.
+func checkKeys(fileName string, data []byte, dataType interface{}, allowed ...string) error {
+	t := reflect.TypeOf(dataType)
+	if t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	typeError, ok := yaml.UnmarshalStrict(data, reflect.New(t.Elem()).Interface()).(*yaml.TypeError)
+	if !ok {
+		return nil
+	}
+	known := knownKeys(t.Elem(), map[string][]string{})
+	var problems []string
+	for _, message := range typeError.Errors {
+		if parts := unknownKeyError.FindStringSubmatch(message); parts != nil {
+			if contains(allowed, parts[2]) {
+				continue
+			}
+			problem := fmt.Sprintf("line %s: unknown key %s", parts[1], parts[2])
+			if suggestion := closest(parts[2], known[parts[3]]); suggestion != "" {
+				problem += fmt.Sprintf(", did you mean %s?", suggestion)
+			}
+			problems = append(problems, problem)
+		} else if parts := duplicateKeyError.FindStringSubmatch(message); parts != nil {
+			problems = append(problems, fmt.Sprintf("line %s: key %s is repeated", parts[1], parts[2]))
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("Error in file %s: %s", fileName, strings.Join(problems, "; "))
+}
+
+// knownKeys - the yaml keys of t and of the structs it holds, by type name
+func knownKeys(t reflect.Type, known map[string][]string) map[string][]string {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		return knownKeys(t.Elem(), known)
+	case reflect.Struct:
+	default:
+		return known
+	}
+	if _, ok := known[t.String()]; ok {
+		return known
+	}
+	known[t.String()] = []string{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if key := yamlKey(field); key != "" && key != "-" {
+			known[t.String()] = append(known[t.String()], key)
+		}
+		knownKeys(field.Type, known)
+	}
+	return known
+}
+
+func yamlKey(field reflect.StructField) string {
+	if field.PkgPath != "" {
+		return ""
+	}
+	key := strings.Split(field.Tag.Get("yaml"), ",")[0]
+	if key == "" {
+		return strings.ToLower(field.Name)
+	}
+	return key
+}
+
+// deprecations - a warning for each deprecated key of the yaml document data
+func deprecations(data []byte, dataType interface{}) []string {
+	document := yaml.MapSlice{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil
+	}
+	var warnings []string
+	deprecatedIn(document, reflect.TypeOf(dataType), "", &warnings)
+	return warnings
+}
+
+func deprecatedIn(document yaml.MapSlice, t reflect.Type, path string, warnings *[]string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	for _, item := range document {
+		key := fmt.Sprint(item.Key)
+		if replacement, ok := deprecatedKeys[t][key]; ok {
+			*warnings = append(*warnings, fmt.Sprintf("%s%s is deprecated, use %s instead", path, key, replacement))
+		}
+		value, ok := item.Value.(yaml.MapSlice)
+		if !ok {
+			continue
+		}
+		for i := 0; i < t.NumField(); i++ {
+			if yamlKey(t.Field(i)) == key {
+				deprecatedIn(value, t.Field(i).Type, path+key+".", warnings)
+			}
+		}
+	}
+}
+
+// closest - the key most like key, or "" when none are close enough to be a
+// likely misspelling
+func closest(key string, keys []string) string {
+	sorted := append([]string{}, keys...)
+	sort.Strings(sorted)
+	best, bestDistance := "", len(key)/3+2
+	for _, candidate := range sorted {
+		if distance := editDistance(key, candidate); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+// editDistance - the levenshtein distance between a and b, treating - and _ as
+// the same
+func editDistance(a, b string) int {
+	a, b = strings.ReplaceAll(a, "_", "-"), strings.ReplaceAll(b, "_", "-")
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
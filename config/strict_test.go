@@ -0,0 +1,36 @@
+package config_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vmwarepivotallabs/cf-mgmt/config"
+)
+
+var _ = Describe("Strict", func() {
+	It("ignores unknown keys unless strict", func() {
+		orgConfig, err := config.NewManager("./fixtures/strict").GetOrgConfig("org1")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(orgConfig.RemoveUsers).Should(BeFalse())
+	})
+
+	It("fails on unknown keys with their line and the closest known key", func() {
+		configManager := config.NewLayeredManager(config.Layers{Base: "./fixtures/strict", Strict: true})
+		_, err := configManager.GetOrgConfig("org1")
+		Expect(err).Should(MatchError("Error in file fixtures/strict/org1/orgConfig.yml: line 2: unknown key enable-remove-user, did you mean enable-remove-users?; line 6: unknown key ldap_grups, did you mean ldap_groups?"))
+	})
+
+	It("fails on repeated keys", func() {
+		configManager := config.NewLayeredManager(config.Layers{Base: "./fixtures/strict", Strict: true})
+		_, err := configManager.GetSpaceConfigs()
+		Expect(err).Should(MatchError(ContainSubstring("line 3: unknown key named-security-group, did you mean named-security-groups?")))
+		Expect(err).Should(MatchError(ContainSubstring("line 6: key allow-ssh is repeated")))
+	})
+
+	It("allows the keys overlays remove orgs and spaces with", func() {
+		configManager := config.NewLayeredManager(config.Layers{Base: "./fixtures/overlay/base", Overlays: []string{"./fixtures/overlay/prod"}, Strict: true})
+		_, err := configManager.GetSpaceConfigs()
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = configManager.GetOrgQuotas()
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+})
@@ -2,11 +2,11 @@ package config
 
 // UserMgmt specifies users and groups that can be associated to a particular org or space.
 type UserMgmt struct {
-	LDAPUsers  []string `yaml:"ldap_users"`
-	Users      []string `yaml:"users"`
-	SamlUsers  []string `yaml:"saml_users"`
-	LDAPGroup  string   `yaml:"ldap_group,omitempty"`
-	LDAPGroups []string `yaml:"ldap_groups"`
+	LDAPUsers  []string `yaml:"ldap_users" description:"ldap users given the role, created in cf when they don't exist"`
+	Users      []string `yaml:"users" description:"users given the role, which must already exist in cf"`
+	SamlUsers  []string `yaml:"saml_users" description:"email addresses of saml users given the role"`
+	LDAPGroup  string   `yaml:"ldap_group,omitempty" description:"ldap group whose users are given the role, deprecated, use ldap_groups"`
+	LDAPGroups []string `yaml:"ldap_groups" description:"ldap groups whose users are given the role"`
 }
 
 // UserOrigin is an enum type encoding from what source a user originated.
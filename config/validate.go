@@ -0,0 +1,325 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// defaultOrgQuota - the org quota every cloud foundry has, which orgs can name
+// without it being in org_quotas
+const defaultOrgQuota = "default"
+
+// Problem - something wrong with the configuration, in File at Field when it
+// is about one value
+type Problem struct {
+	File    string
+	Field   string
+	Message string
+}
+
+func (p Problem) String() string {
+	if p.Field == "" {
+		return fmt.Sprintf("%s: %s", p.File, p.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", p.File, p.Field, p.Message)
+}
+
+// Validate - checks the configuration of layers without connecting to cloud
+// foundry and returns every problem found: orgs and spaces that are listed
+// without a config file or the other way round, names listed twice, named
+// quotas, asgs and shared private domains that aren't configured, isolation
+// segments named with different cases and quota and time values that can't be
+// parsed
+func Validate(layers Layers) ([]Problem, error) {
+	v := &validator{m: NewLayeredManager(layers).(*yamlManager)}
+	if err := v.validate(); err != nil {
+		return nil, err
+	}
+	return v.problems, nil
+}
+
+type validator struct {
+	m        *yamlManager
+	problems []Problem
+	// isolationSegments - the first reference to each isolation segment by lower case name
+	isolationSegments map[string]reference
+	privateDomains    map[string]string
+	sharedDomains     []reference
+}
+
+type reference struct {
+	name, file, field string
+}
+
+func (v *validator) add(file, field, format string, args ...interface{}) {
+	v.problems = append(v.problems, Problem{File: filepath.ToSlash(file), Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) validate() error {
+	v.isolationSegments = make(map[string]reference)
+	v.privateDomains = make(map[string]string)
+
+	orgs, err := v.m.Orgs()
+	if err != nil {
+		v.add("orgs.yml", "", "%v", err)
+		return nil
+	}
+	duplicateOrgs := v.duplicates("orgs.yml", "orgs", orgs.Orgs)
+
+	asgs := make(map[string]bool)
+	asgConfigs, err := v.m.GetASGConfigs()
+	if err != nil {
+		v.add("asgs", "", "%v", err)
+	}
+	for _, asgConfig := range asgConfigs {
+		asgs[asgConfig.Name] = true
+	}
+
+	orgQuotas := map[string]bool{defaultOrgQuota: true}
+	quotas, err := v.m.GetOrgQuotas()
+	if err != nil {
+		v.add("org_quotas", "", "%v", err)
+	}
+	for _, quota := range quotas {
+		orgQuotas[quota.Name] = true
+		v.orgQuotaValues(path.Join("org_quotas", quota.Name+".yml"), quota)
+	}
+
+	configured, err := v.orgConfigs(orgs, orgQuotas)
+	if err != nil {
+		return err
+	}
+	for i, org := range orgs.Orgs {
+		if duplicateOrgs[i] {
+			continue
+		}
+		if !configured[org] {
+			v.add("orgs.yml", fmt.Sprintf("orgs[%d]", i), "org %s has no %s", org, path.Join(org, "orgConfig.yml"))
+			continue
+		}
+		if err := v.spaceConfigs(org, asgs); err != nil {
+			return err
+		}
+	}
+
+	for _, shared := range v.sharedDomains {
+		if owner, ok := v.privateDomains[shared.name]; !ok {
+			v.add(shared.file, shared.field, "private domain %s isn't one of the private-domains of an org", shared.name)
+		} else if shared.file == path.Join(owner, "orgConfig.yml") {
+			v.add(shared.file, shared.field, "private domain %s is shared with the org that owns it", shared.name)
+		}
+	}
+	return nil
+}
+
+// orgConfigs - checks the orgConfig.yml files and returns the orgs that have one
+func (v *validator) orgConfigs(orgs *Orgs, orgQuotas map[string]bool) (map[string]bool, error) {
+	files, err := v.m.findFiles(".", "orgConfig.yml")
+	if err != nil {
+		return nil, err
+	}
+	defaults, err := v.m.orgDefaults()
+	if err != nil {
+		v.add(orgDefaultsFile, "", "%v", err)
+		defaults = nil
+	}
+	configured := make(map[string]bool)
+	for _, f := range files {
+		if removed, err := v.m.isRemoved(f); err != nil || removed {
+			continue
+		}
+		orgConfig := newOrgConfig()
+		if err := v.m.loadWithDefaults(f, defaults, "ignore-org-defaults", orgConfig); err != nil {
+			v.add(f, "", "%v", err)
+			continue
+		}
+		orgDir := filepath.Base(filepath.Dir(f))
+		if orgConfig.Org != orgDir {
+			v.add(f, "org", "org %s is in the directory of org %s", orgConfig.Org, orgDir)
+		}
+		configured[orgConfig.Org] = true
+		if !orgs.Contains(orgConfig.Org) {
+			v.add(f, "org", "org %s isn't listed in orgs.yml", orgConfig.Org)
+		}
+		if orgConfig.NamedQuota != "" {
+			if orgConfig.EnableOrgQuota {
+				v.add(f, "named_quota", "named_quota can't be used with enable-org-quota")
+			}
+			if !orgQuotas[orgConfig.NamedQuota] {
+				v.add(f, "named_quota", "org quota %s isn't in org_quotas", orgConfig.NamedQuota)
+			}
+		}
+		if orgConfig.EnableOrgQuota {
+			v.orgQuotaValues(f, orgConfig.GetQuota())
+		}
+		v.isolationSegment(f, "default_isolation_segment", orgConfig.DefaultIsoSegment)
+		for _, domain := range orgConfig.PrivateDomains {
+			v.privateDomains[domain] = orgConfig.Org
+		}
+		for i, domain := range orgConfig.SharedPrivateDomains {
+			v.sharedDomains = append(v.sharedDomains, reference{name: domain, file: f, field: fmt.Sprintf("shared-private-domains[%d]", i)})
+		}
+	}
+	return configured, nil
+}
+
+// spaceConfigs - checks spaces.yml and the spaceConfig.yml files of org
+func (v *validator) spaceConfigs(org string, asgs map[string]bool) error {
+	spacesFile := path.Join(org, "spaces.yml")
+	spaces, err := v.m.OrgSpaces(org)
+	if err != nil {
+		v.add(spacesFile, "", "%v", err)
+		return nil
+	}
+	if spaces.Org != org {
+		v.add(spacesFile, "org", "org %s is in the directory of org %s", spaces.Org, org)
+	}
+	duplicateSpaces := v.duplicates(spacesFile, "spaces", spaces.Spaces)
+
+	spaceQuotas := make(map[string]bool)
+	quotas, err := v.m.GetSpaceQuotas(org)
+	if err != nil {
+		v.add(path.Join(org, "space_quotas"), "", "%v", err)
+	}
+	for _, quota := range quotas {
+		spaceQuotas[quota.Name] = true
+		v.spaceQuotaValues(path.Join(org, "space_quotas", quota.Name+".yml"), quota)
+	}
+
+	files, err := v.m.findFiles(org, "spaceConfig.yml")
+	if err != nil {
+		return err
+	}
+	defaults, err := v.m.orgSpaceDefaults(org)
+	if err != nil {
+		v.add(path.Join(org, spaceDefaultsFile), "", "%v", err)
+		defaults = nil
+	}
+	configured := make(map[string]bool)
+	for _, f := range files {
+		if removed, err := v.m.isRemoved(f); err != nil || removed {
+			continue
+		}
+		spaceConfig := newSpaceConfig()
+		if err := v.m.loadWithDefaults(f, defaults, "", spaceConfig); err != nil {
+			v.add(f, "", "%v", err)
+			continue
+		}
+		spaceDir := filepath.Base(filepath.Dir(f))
+		if spaceConfig.Org != org {
+			v.add(f, "org", "org %s is in the directory of org %s", spaceConfig.Org, org)
+		}
+		if spaceConfig.Space != spaceDir {
+			v.add(f, "space", "space %s is in the directory of space %s", spaceConfig.Space, spaceDir)
+		}
+		configured[spaceConfig.Space] = true
+		if !spaces.Contains(spaceConfig.Space) {
+			v.add(f, "space", "space %s isn't listed in %s", spaceConfig.Space, spacesFile)
+		}
+		if spaceConfig.NamedQuota != "" {
+			if spaceConfig.EnableSpaceQuota {
+				v.add(f, "named_quota", "named_quota can't be used with enable-space-quota")
+			}
+			if !spaceQuotas[spaceConfig.NamedQuota] {
+				v.add(f, "named_quota", "space quota %s isn't in %s", spaceConfig.NamedQuota, path.Join(org, "space_quotas"))
+			}
+		}
+		if spaceConfig.EnableSpaceQuota {
+			v.spaceQuotaValues(f, spaceConfig.GetQuota())
+		}
+		if spaceConfig.AllowSSHUntil != "" {
+			if _, err := time.Parse(time.RFC3339, spaceConfig.AllowSSHUntil); err != nil {
+				v.add(f, "allow-ssh-until", "%s isn't an RFC3339 time such as 2006-01-02T15:04:05Z", spaceConfig.AllowSSHUntil)
+			}
+		}
+		for i, asg := range spaceConfig.ASGs {
+			if !asgs[asg] {
+				v.add(f, fmt.Sprintf("named-security-groups[%d]", i), "asg %s isn't in the asgs directory", asg)
+			}
+		}
+		if spaceConfig.EnableSecurityGroup {
+			if securityGroupFile := path.Join(filepath.Dir(f), "security-group.json"); !v.m.exists(securityGroupFile) {
+				v.add(f, "enable-security-group", "%s doesn't exist", filepath.ToSlash(securityGroupFile))
+			}
+		}
+		v.isolationSegment(f, "isolation_segment", spaceConfig.IsoSegment)
+	}
+	for i, space := range spaces.Spaces {
+		if !duplicateSpaces[i] && !configured[space] {
+			v.add(spacesFile, fmt.Sprintf("spaces[%d]", i), "space %s has no %s", space, path.Join(org, space, "spaceConfig.yml"))
+		}
+	}
+	return nil
+}
+
+// duplicates - names listed more than once, ignoring case as cloud foundry
+// does, returning the indexes of the repeats
+func (v *validator) duplicates(file, field string, names []string) map[int]bool {
+	repeats := make(map[int]bool)
+	seen := make(map[string]int)
+	for i, name := range names {
+		if first, ok := seen[strings.ToLower(name)]; ok {
+			v.add(file, fmt.Sprintf("%s[%d]", field, i), "%s is already listed as %s[%d]", name, field, first)
+			repeats[i] = true
+			continue
+		}
+		seen[strings.ToLower(name)] = i
+	}
+	return repeats
+}
+
+// isolationSegment - an isolation segment named with a different case somewhere
+// else, which cloud foundry treats as the same segment
+func (v *validator) isolationSegment(file, field, name string) {
+	if name == "" {
+		return
+	}
+	first, ok := v.isolationSegments[strings.ToLower(name)]
+	if !ok {
+		v.isolationSegments[strings.ToLower(name)] = reference{name: name, file: file, field: field}
+		return
+	}
+	if first.name != name {
+		v.add(file, field, "isolation segment %s is named %s in %s", name, first.name, filepath.ToSlash(first.file))
+	}
+}
+
+func (v *validator) orgQuotaValues(file string, quota OrgQuota) {
+	v.megabytes(file, "memory-limit", quota.MemoryLimit)
+	v.megabytes(file, "instance-memory-limit", quota.InstanceMemoryLimit)
+	v.integer(file, "total-routes", quota.TotalRoutes)
+	v.integer(file, "total-services", quota.TotalServices)
+	v.integer(file, "total_private_domains", quota.TotalPrivateDomains)
+	v.integer(file, "total_reserved_route_ports", quota.TotalReservedRoutePorts)
+	v.integer(file, "total_service_keys", quota.TotalServiceKeys)
+	v.integer(file, "app_instance_limit", quota.AppInstanceLimit)
+	v.integer(file, "app_task_limit", quota.AppTaskLimit)
+	v.integer(file, "log_rate_limit_bytes_per_second", quota.LogRateLimitBytesPerSecond)
+}
+
+func (v *validator) spaceQuotaValues(file string, quota SpaceQuota) {
+	v.megabytes(file, "memory-limit", quota.MemoryLimit)
+	v.megabytes(file, "instance-memory-limit", quota.InstanceMemoryLimit)
+	v.integer(file, "total-routes", quota.TotalRoutes)
+	v.integer(file, "total-services", quota.TotalServices)
+	v.integer(file, "total_reserved_route_ports", quota.TotalReservedRoutePorts)
+	v.integer(file, "total_service_keys", quota.TotalServiceKeys)
+	v.integer(file, "app_instance_limit", quota.AppInstanceLimit)
+	v.integer(file, "app_task_limit", quota.AppTaskLimit)
+	v.integer(file, "log_rate_limit_bytes_per_second", quota.LogRateLimitBytesPerSecond)
+}
+
+func (v *validator) megabytes(file, field, value string) {
+	if _, err := ToMegabytes(value); err != nil {
+		v.add(file, field, "%s isn't a memory size such as 512M, 10G or unlimited", value)
+	}
+}
+
+func (v *validator) integer(file, field, value string) {
+	if _, err := ToInteger(value); err != nil {
+		v.add(file, field, "%s isn't a number or unlimited", value)
+	}
+}
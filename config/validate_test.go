@@ -0,0 +1,67 @@
+package config_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vmwarepivotallabs/cf-mgmt/config"
+)
+
+var _ = Describe("Validate", func() {
+	var problems []string
+
+	BeforeEach(func() {
+		found, err := config.Validate(config.Layers{Base: "./fixtures/validate"})
+		Expect(err).ShouldNot(HaveOccurred())
+		problems = nil
+		for _, problem := range found {
+			problems = append(problems, problem.String())
+		}
+	})
+
+	It("finds orgs and spaces listed twice or without a config file", func() {
+		Expect(problems).Should(ContainElements(
+			"orgs.yml: orgs[2]: ORG1 is already listed as orgs[0]",
+			"orgs.yml: orgs[3]: org org3 has no org3/orgConfig.yml",
+			"org1/spaces.yml: spaces[1]: DEV is already listed as spaces[0]",
+			"org1/Dev2/spaceConfig.yml: space: space dev2 is in the directory of space Dev2",
+			"org1/Dev2/spaceConfig.yml: space: space dev2 isn't listed in org1/spaces.yml",
+		))
+		Expect(problems).ShouldNot(ContainElement(ContainSubstring("ORG1 has no")))
+	})
+
+	It("finds references to things that aren't configured", func() {
+		Expect(problems).Should(ContainElements(
+			"org1/orgConfig.yml: named_quota: org quota small isn't in org_quotas",
+			"org1/dev/spaceConfig.yml: named_quota: named_quota can't be used with enable-space-quota",
+			"org1/dev/spaceConfig.yml: named-security-groups[1]: asg db-access isn't in the asgs directory",
+			"org1/dev/spaceConfig.yml: enable-security-group: org1/dev/security-group.json doesn't exist",
+			"org1/dev/spaceConfig.yml: isolation_segment: isolation segment ISO-1 is named iso-1 in org1/orgConfig.yml",
+			"org1/orgConfig.yml: shared-private-domains[0]: private domain org1.example.com is shared with the org that owns it",
+			"org2/orgConfig.yml: shared-private-domains[1]: private domain unknown.example.com isn't one of the private-domains of an org",
+		))
+		Expect(problems).ShouldNot(ContainElement(ContainSubstring("org quota large")))
+	})
+
+	It("finds values that can't be parsed", func() {
+		Expect(problems).Should(ContainElements(
+			"org_quotas/large.yml: memory-limit: 100X isn't a memory size such as 512M, 10G or unlimited",
+			"org1/space_quotas/small.yml: total-services: ten isn't a number or unlimited",
+			"org1/dev/spaceConfig.yml: allow-ssh-until: tomorrow isn't an RFC3339 time such as 2006-01-02T15:04:05Z",
+		))
+		Expect(problems).ShouldNot(ContainElement(HavePrefix("org2/prod/")))
+	})
+
+	It("finds no problems in a valid configuration", func() {
+		variables, err := config.NewVariables("./fixtures/variables/vars.yml")
+		Expect(err).ShouldNot(HaveOccurred())
+		found, err := config.Validate(config.Layers{Base: "./fixtures/variables/config", Variables: variables})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(found).Should(BeEmpty())
+	})
+
+	It("validates orgs that only an overlay has", func() {
+		found, err := config.Validate(config.Layers{Base: "./fixtures/overlay/base", Overlays: []string{"./fixtures/overlay/prod"}})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(found).Should(BeEmpty())
+	})
+})
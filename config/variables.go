@@ -0,0 +1,314 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/xchapter7x/lo"
+	"gopkg.in/yaml.v2"
+)
+
+// VariableEnvPrefix - the prefix of the environment variables that hold the
+// values of ((var)) placeholders, ((ldap.group)) is read from CF_MGMT_VAR_LDAP_GROUP
+const VariableEnvPrefix = "CF_MGMT_VAR_"
+
+var placeholder = regexp.MustCompile(`\(\(\s*([-\w./]+)\s*\)\)`)
+
+var notEnvCharacter = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// SecretResolver - looks up the variables that are neither in the vars files
+// nor in the environment, such as secrets kept in credhub or vault
+type SecretResolver interface {
+	Resolve(name string) (value string, found bool, err error)
+}
+
+// Variables - resolves the ((var)) placeholders of config files.  A variable is
+// looked up in the vars files, then in the environment and then with the secret
+// resolver, and the first that has it wins.  A dotted name such as ((ldap.group))
+// looks up a nested key of the vars files.
+type Variables struct {
+	values   yaml.MapSlice
+	Resolver SecretResolver
+	// Strict - fail on placeholders that have no value rather than leave them as is
+	Strict bool
+}
+
+// NewVariables - variables read from varsFiles, later files taking precedence
+func NewVariables(varsFiles ...string) (*Variables, error) {
+	variables := &Variables{}
+	for _, varsFile := range varsFiles {
+		values := yaml.MapSlice{}
+		if err := LoadFile(varsFile, &values); err != nil {
+			return nil, err
+		}
+		variables.values = mergeMaps(variables.values, values)
+	}
+	return variables, nil
+}
+
+// Lookup - the value of variable name
+func (v *Variables) Lookup(name string) (interface{}, bool, error) {
+	value, isText, found, err := v.lookup(name)
+	if isText {
+		return scalarOrDocument(value.(string)), found, err
+	}
+	return value, found, err
+}
+
+// lookup - the value of variable name as the vars files have it, or the text
+// of the environment variable or secret that has it
+func (v *Variables) lookup(name string) (value interface{}, isText bool, found bool, err error) {
+	if value, ok := nestedLookup(v.values, strings.Split(name, ".")); ok {
+		return value, false, true, nil
+	}
+	if value, ok := os.LookupEnv(VariableEnvName(name)); ok {
+		return value, true, true, nil
+	}
+	if v.Resolver == nil {
+		return nil, false, false, nil
+	}
+	text, found, err := v.Resolver.Resolve(name)
+	if err != nil {
+		return nil, false, false, errors.Wrapf(err, "Error resolving variable %s", name)
+	}
+	if !found {
+		return nil, false, false, nil
+	}
+	return text, true, true, nil
+}
+
+// VariableEnvName - the environment variable that holds the value of variable name
+func VariableEnvName(name string) string {
+	return VariableEnvPrefix + notEnvCharacter.ReplaceAllString(strings.ToUpper(name), "_")
+}
+
+// LoadFile - unmarshals configFile into dataType once its placeholders are
+// resolved.  With nil variables the file is loaded as is.
+func (v *Variables) LoadFile(configFile string, dataType interface{}) error {
+	if v == nil {
+		return LoadFile(configFile, dataType)
+	}
+	data, err := LoadFileBytes(configFile)
+	if err != nil {
+		return err
+	}
+	if data, err = v.Interpolate(configFile, data); err != nil {
+		return err
+	}
+	if err = yaml.Unmarshal(data, dataType); err != nil {
+		return errors.Wrapf(err, "Error unmarshalling file %s", configFile)
+	}
+	return nil
+}
+
+// Interpolate - the yaml document data with its placeholders resolved.  A
+// placeholder that is a whole value is replaced by the value of the variable,
+// lists and maps included, one inside a string by its text.
+func (v *Variables) Interpolate(fileName string, data []byte) ([]byte, error) {
+	if v == nil || !placeholder.Match(data) {
+		return data, nil
+	}
+	document, err := unmarshalDocument(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error unmarshalling file %s", fileName)
+	}
+	unresolved := make(map[string]bool)
+	document, err = v.interpolate(document, unresolved)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error resolving variables of file %s", fileName)
+	}
+	if err = v.checkResolved(fileName, unresolved); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(document)
+}
+
+// InterpolateText - data with its placeholders replaced by the text of their
+// variables, for files that are passed on as is such as security group json
+func (v *Variables) InterpolateText(fileName string, data []byte) ([]byte, error) {
+	if v == nil || !placeholder.Match(data) {
+		return data, nil
+	}
+	unresolved := make(map[string]bool)
+	text, err := v.replace(string(data), unresolved)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error resolving variables of file %s", fileName)
+	}
+	if err = v.checkResolved(fileName, unresolved); err != nil {
+		return nil, err
+	}
+	return []byte(text), nil
+}
+
+func (v *Variables) checkResolved(fileName string, unresolved map[string]bool) error {
+	if len(unresolved) == 0 {
+		return nil
+	}
+	var names []string
+	for name := range unresolved {
+		names = append(names, fmt.Sprintf("((%s))", name))
+	}
+	sort.Strings(names)
+	if v.Strict {
+		return fmt.Errorf("unresolved variables %s in file %s", strings.Join(names, ", "), fileName)
+	}
+	lo.G.Warningf("leaving unresolved variables %s in file %s", strings.Join(names, ", "), fileName)
+	return nil
+}
+
+func (v *Variables) interpolate(value interface{}, unresolved map[string]bool) (interface{}, error) {
+	switch value := value.(type) {
+	case yaml.MapSlice:
+		result := make(yaml.MapSlice, 0, len(value))
+		for _, item := range value {
+			resolved, err := v.interpolate(item.Value, unresolved)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, yaml.MapItem{Key: item.Key, Value: resolved})
+		}
+		return result, nil
+	case map[interface{}]interface{}:
+		result := make(map[interface{}]interface{}, len(value))
+		for key, item := range value {
+			resolved, err := v.interpolate(item, unresolved)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = resolved
+		}
+		return result, nil
+	case []interface{}:
+		result := make([]interface{}, 0, len(value))
+		for _, item := range value {
+			resolved, err := v.interpolate(item, unresolved)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, resolved)
+		}
+		return result, nil
+	case string:
+		if match := placeholder.FindStringSubmatch(value); match != nil && match[0] == value {
+			resolved, found, err := v.Lookup(match[1])
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				unresolved[match[1]] = true
+				return value, nil
+			}
+			return resolved, nil
+		}
+		return v.replace(value, unresolved)
+	default:
+		return value, nil
+	}
+}
+
+// replace - text with each placeholder replaced by the text of its variable
+func (v *Variables) replace(text string, unresolved map[string]bool) (string, error) {
+	var lookupErr error
+	result := placeholder.ReplaceAllStringFunc(text, func(match string) string {
+		name := placeholder.FindStringSubmatch(match)[1]
+		value, isText, found, err := v.lookup(name)
+		if err != nil {
+			lookupErr = err
+			return match
+		}
+		if !found {
+			unresolved[name] = true
+			return match
+		}
+		if isText {
+			// the text of the environment variable or secret as it is
+			return value.(string)
+		}
+		switch value.(type) {
+		case yaml.MapSlice, []interface{}:
+			lookupErr = fmt.Errorf("variable ((%s)) is a list or map so it can only be a whole value", name)
+			return match
+		}
+		return fmt.Sprint(value)
+	})
+	return result, lookupErr
+}
+
+func nestedLookup(values yaml.MapSlice, keys []string) (interface{}, bool) {
+	value, ok := mapLookup(values, keys[0])
+	if !ok || len(keys) == 1 {
+		return value, ok
+	}
+	nested, isMap := value.(yaml.MapSlice)
+	if !isMap {
+		return nil, false
+	}
+	return nestedLookup(nested, keys[1:])
+}
+
+// scalarOrDocument - value parsed as yaml when it is a list or map, or a
+// number or boolean that reads back as the same text, such as 8080 or true,
+// so that environment variables and secrets can hold them, otherwise value
+// itself, keeping text such as 0123, 1e3 or no as it is
+func scalarOrDocument(value string) interface{} {
+	if strings.TrimSpace(value) == "" {
+		return value
+	}
+	document, err := unmarshalDocument([]byte(value))
+	if err != nil || document == nil {
+		return value
+	}
+	switch document.(type) {
+	case yaml.MapSlice, []interface{}:
+		return document
+	case string:
+		return value
+	}
+	if fmt.Sprint(document) == strings.TrimSpace(value) {
+		return document
+	}
+	return value
+}
+
+// unmarshalDocument - data as a yaml.MapSlice, keeping the order of its keys,
+// or as whatever else it holds when it isn't a map
+func unmarshalDocument(data []byte) (interface{}, error) {
+	mapSlice := yaml.MapSlice{}
+	if err := yaml.Unmarshal(data, &mapSlice); err == nil {
+		return mapSlice, nil
+	}
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// CommandResolver - a SecretResolver that runs Command with the name of the
+// variable as its last argument and takes what it prints as the value.  A
+// command that prints nothing hasn't found the variable.
+type CommandResolver struct {
+	Command string
+}
+
+// Resolve -
+func (r CommandResolver) Resolve(name string) (string, bool, error) {
+	args := strings.Fields(r.Command)
+	if len(args) == 0 {
+		return "", false, fmt.Errorf("secret command is empty")
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(args[0], append(args[1:], name)...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return "", false, fmt.Errorf("secret command %s failed for %s: %v %s", r.Command, name, err, strings.TrimSpace(stderr.String()))
+	}
+	value := strings.TrimRight(stdout.String(), "\r\n")
+	return value, value != "", nil
+}
@@ -0,0 +1,140 @@
+package config_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vmwarepivotallabs/cf-mgmt/config"
+)
+
+type fakeResolver map[string]string
+
+func (r fakeResolver) Resolve(name string) (string, bool, error) {
+	if name == "broken" {
+		return "", false, errors.New("vault is sealed")
+	}
+	value, ok := r[name]
+	return value, ok, nil
+}
+
+var _ = Describe("Variables", func() {
+	var variables *config.Variables
+
+	BeforeEach(func() {
+		var err error
+		variables, err = config.NewVariables("./fixtures/variables/vars.yml", "./fixtures/variables/prod-vars.yml")
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("CF_MGMT_VAR_DOMAIN")
+		os.Unsetenv("CF_MGMT_VAR_SPACE_DEVELOPERS")
+		os.Unsetenv("CF_MGMT_VAR_SUFFIX")
+		os.Unsetenv("CF_MGMT_VAR_FLAG")
+	})
+
+	It("resolves placeholders from the vars files, later files taking precedence", func() {
+		configManager := config.NewLayeredManager(config.Layers{Base: "./fixtures/variables/config", Variables: variables})
+		orgConfig, err := configManager.GetOrgConfig("org1")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(orgConfig.Manager.LDAPGroup).Should(Equal("prod-managers"))
+		Expect(orgConfig.Manager.Users).Should(Equal([]string{"admin"}))
+		Expect(orgConfig.PrivateDomains).Should(Equal([]string{"org1.example.com"}))
+		Expect(orgConfig.MemoryLimit).Should(Equal("100G"))
+	})
+
+	It("resolves placeholders in security group json", func() {
+		configManager := config.NewLayeredManager(config.Layers{Base: "./fixtures/variables/config", Variables: variables})
+		spaceConfigs, err := configManager.GetSpaceConfigs()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(spaceConfigs).Should(HaveLen(1))
+		Expect(spaceConfigs[0].SecurityGroupContents).Should(ContainSubstring(`"destination": "10.0.0.0/24"`))
+	})
+
+	It("leaves placeholders as is without variables", func() {
+		spaceConfigs, err := config.NewManager("./fixtures/variables/config").GetSpaceConfigs()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(spaceConfigs[0].SecurityGroupContents).Should(ContainSubstring(`"destination": "((database_cidr))"`))
+	})
+
+	It("prefers the vars files over the environment", func() {
+		os.Setenv("CF_MGMT_VAR_DOMAIN", "env.example.com")
+		value, found, err := variables.Lookup("domain")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(found).Should(BeTrue())
+		Expect(value).Should(Equal("example.com"))
+	})
+
+	It("reads typed values from the environment", func() {
+		os.Setenv("CF_MGMT_VAR_SPACE_DEVELOPERS", "[alice, bob]")
+		data, err := variables.Interpolate("spaceConfig.yml", []byte("space-developer:\n  users: ((space-developers))\n"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(data)).Should(Equal("space-developer:\n  users:\n  - alice\n  - bob\n"))
+	})
+
+	It("keeps the text of environment variables and secrets inside strings", func() {
+		os.Setenv("CF_MGMT_VAR_SUFFIX", "0123")
+		os.Setenv("CF_MGMT_VAR_FLAG", "no")
+		variables.Resolver = fakeResolver{"mode": "0777", "limit": "1e3", "answer": "yes"}
+		data, err := variables.Interpolate("orgConfig.yml", []byte("org: team-((suffix))\nname: grp-((flag))\nmode: m-((mode))-((limit))-((answer))\n"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(data)).Should(Equal("org: team-0123\nname: grp-no\nmode: m-0777-1e3-yes\n"))
+
+		text, err := variables.InterpolateText("security-group.json", []byte(`{"ports": "((mode))"}`))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(text)).Should(Equal(`{"ports": "0777"}`))
+	})
+
+	It("only reads whole values as numbers or booleans when they read back the same", func() {
+		os.Setenv("CF_MGMT_VAR_SUFFIX", "0123")
+		os.Setenv("CF_MGMT_VAR_FLAG", "no")
+		variables.Resolver = fakeResolver{"limit": "1e3", "ssh": "true", "routes": "100"}
+		data, err := variables.Interpolate("spaceConfig.yml", []byte("space: ((suffix))\nisolation_segment: ((flag))\nmemory-limit: ((limit))\nallow-ssh: ((ssh))\ntotal-routes: ((routes))\n"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(data)).Should(Equal("space: \"0123\"\nisolation_segment: \"no\"\nmemory-limit: \"1e3\"\nallow-ssh: true\ntotal-routes: 100\n"))
+	})
+
+	It("falls back to the secret resolver", func() {
+		variables.Resolver = fakeResolver{"ldap_password": "s3cret"}
+		data, err := variables.Interpolate("ldap.yml", []byte("bind-password: ((ldap_password))\n"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(data)).Should(Equal("bind-password: s3cret\n"))
+
+		_, err = variables.Interpolate("ldap.yml", []byte("bind-password: ((broken))\n"))
+		Expect(err).Should(MatchError(ContainSubstring("vault is sealed")))
+	})
+
+	It("fails on unresolved variables in strict mode", func() {
+		data, err := variables.Interpolate("orgConfig.yml", []byte("memory-limit: ((missing))\n"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(data)).Should(Equal("memory-limit: ((missing))\n"))
+
+		variables.Strict = true
+		_, err = variables.Interpolate("orgConfig.yml", []byte("memory-limit: ((missing))\nquota: ((also_missing))\n"))
+		Expect(err).Should(MatchError("unresolved variables ((also_missing)), ((missing)) in file orgConfig.yml"))
+	})
+
+	It("errors on a list inside a string", func() {
+		_, err := variables.Interpolate("orgConfig.yml", []byte("org: org-((org_managers))\n"))
+		Expect(err).Should(MatchError(ContainSubstring("is a list or map so it can only be a whole value")))
+	})
+
+	It("renders the resolved configuration", func() {
+		out := &bytes.Buffer{}
+		Expect(config.Render(config.Layers{Base: "./fixtures/variables/config", Variables: variables}, out)).Should(Succeed())
+		Expect(out.String()).Should(ContainSubstring("--- # org1/orgConfig.yml\norg: org1\norg-manager:\n  ldap_group: prod-managers\n"))
+		Expect(out.String()).Should(ContainSubstring("--- # org1/space1/security-group.json\n[{\"protocol\": \"tcp\", \"destination\": \"10.0.0.0/24\", \"ports\": \"5432\"}]\n"))
+		Expect(out.String()).Should(ContainSubstring("--- # orgs.yml\n"))
+	})
+
+	It("leaves the orgs an overlay removes out of the render", func() {
+		out := &bytes.Buffer{}
+		Expect(config.Render(config.Layers{Base: "./fixtures/overlay/base", Overlays: []string{"./fixtures/overlay/prod"}}, out)).Should(Succeed())
+		Expect(out.String()).Should(ContainSubstring("--- # org3/orgConfig.yml\n"))
+		Expect(out.String()).ShouldNot(ContainSubstring("org2/"))
+		Expect(out.String()).ShouldNot(ContainSubstring("org1/space2/"))
+	})
+})
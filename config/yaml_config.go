@@ -6,17 +6,25 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/xchapter7x/lo"
+	"gopkg.in/yaml.v2"
 )
 
 const UNLIMITED = "unlimited"
 
 // yamlManager is the default implementation of Manager.
-// It is backed by a directory of YAML files.
+// It is backed by a directory of YAML files and the overlays merged onto it.
 type yamlManager struct {
 	ConfigDir string
+	Overlays  []string
+	WriteDir  string
+	Variables *Variables
+	Strict    bool
+	// warned - the deprecation warnings already logged
+	warned sync.Map
 }
 
 // Orgs reads the config for all orgs.
@@ -24,26 +32,25 @@ func (m *yamlManager) Orgs() (*Orgs, error) {
 	configFile := filepath.Join(m.ConfigDir, "orgs.yml")
 	lo.G.Debug("Processing org file", configFile)
 	input := &Orgs{}
-	if err := LoadFile(configFile, &input); err != nil {
+	if err := m.loadFile("orgs.yml", &input); err != nil {
 		return nil, err
 	}
 	return input, nil
 }
 
 func (m *yamlManager) GetDefaultASGConfigs() ([]ASGConfig, error) {
-	filePath := path.Join(m.ConfigDir, "default_asgs")
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+	if !m.exists("default_asgs") {
 		lo.G.Infof("No default asgs found.  Create directory default_asgs and add asg definition(s)")
 		return nil, nil
 	}
-	files, err := FindFiles(filePath, ".json")
+	files, err := m.findFiles("default_asgs", ".json")
 	if err != nil {
 		return nil, err
 	}
 	var result []ASGConfig
 	for _, securityGroupFile := range files {
 		lo.G.Debug("Loading security group contents", securityGroupFile)
-		bytes, err := os.ReadFile(securityGroupFile)
+		bytes, err := m.readFile(securityGroupFile)
 		if err != nil {
 			return nil, errors.Wrapf(err, "Error reading file %s", securityGroupFile)
 		}
@@ -58,19 +65,18 @@ func (m *yamlManager) GetDefaultASGConfigs() ([]ASGConfig, error) {
 
 // GetASGConfigs reads all ASGs from the cf-mgmt configuration.
 func (m *yamlManager) GetASGConfigs() ([]ASGConfig, error) {
-	filePath := path.Join(m.ConfigDir, "asgs")
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+	if !m.exists("asgs") {
 		lo.G.Infof("No asgs found.  Create directory asgs and add asg definition(s)")
 		return nil, nil
 	}
-	files, err := FindFiles(filePath, ".json")
+	files, err := m.findFiles("asgs", ".json")
 	if err != nil {
 		return nil, err
 	}
 	var result []ASGConfig
 	for _, securityGroupFile := range files {
 		lo.G.Debug("Loading security group contents", securityGroupFile)
-		bytes, err := os.ReadFile(securityGroupFile)
+		bytes, err := m.readFile(securityGroupFile)
 		if err != nil {
 			return nil, errors.Wrapf(err, "Error reading file %s", securityGroupFile)
 		}
@@ -86,7 +92,10 @@ func (m *yamlManager) GetASGConfigs() ([]ASGConfig, error) {
 // GetIsolationSegmentConfig reads isolation segment config
 func (m *yamlManager) GetGlobalConfig() (*GlobalConfig, error) {
 	globalConfig := &GlobalConfig{}
-	LoadFile(path.Join(m.ConfigDir, "cf-mgmt.yml"), globalConfig)
+	m.loadFile("cf-mgmt.yml", globalConfig)
+	if err := m.checkSchemaVersion(globalConfig); err != nil {
+		return nil, err
+	}
 	if len(globalConfig.MetadataPrefix) == 0 {
 		globalConfig.MetadataPrefix = "cf-mgmt.pivotal.io"
 	}
@@ -95,45 +104,60 @@ func (m *yamlManager) GetGlobalConfig() (*GlobalConfig, error) {
 
 // GetOrgConfigs reads all orgs from the cf-mgmt configuration.
 func (m *yamlManager) GetOrgConfigs() ([]OrgConfig, error) {
-	files, err := FindFiles(m.ConfigDir, "orgConfig.yml")
+	files, err := m.findFiles(".", "orgConfig.yml")
 	if err != nil {
 		return nil, err
 	}
-	result := make([]OrgConfig, len(files))
-	for i, f := range files {
-		result[i].AppTaskLimit = UNLIMITED
-		result[i].AppInstanceLimit = UNLIMITED
-		result[i].TotalReservedRoutePorts = UNLIMITED
-		result[i].TotalPrivateDomains = UNLIMITED
-		result[i].TotalServiceKeys = UNLIMITED
-		result[i].InstanceMemoryLimit = UNLIMITED
-
-		if err = LoadFile(f, &result[i]); err != nil {
+	removedOrgs, err := m.removedNames("orgs.yml")
+	if err != nil {
+		return nil, err
+	}
+	defaults, err := m.orgDefaults()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]OrgConfig, 0, len(files))
+	for _, f := range files {
+		orgConfig := newOrgConfig()
+		if err = m.loadWithDefaults(f, defaults, "ignore-org-defaults", orgConfig); err != nil {
 			lo.G.Error(err)
 			return nil, err
 		}
+		if removedOrgs[orgConfig.Org] {
+			continue
+		}
+		result = append(result, *orgConfig)
 	}
 	return result, nil
 }
 
 func (m *yamlManager) SaveOrgSpaces(spaces *Spaces) error {
-	return WriteFile(filepath.Join(m.ConfigDir, spaces.Org, "spaces.yml"), spaces)
+	return m.writeFile(filepath.Join(spaces.Org, "spaces.yml"), spaces)
 }
 
 func (m *yamlManager) Spaces() ([]Spaces, error) {
-	files, err := FindFiles(m.ConfigDir, "spaces.yml")
+	files, err := m.findFiles(".", "spaces.yml")
+	if err != nil {
+		return nil, err
+	}
+	removedOrgs, err := m.removedNames("orgs.yml")
 	if err != nil {
 		return nil, err
 	}
 
-	spaceList := make([]Spaces, len(files))
-	for i, f := range files {
+	spaceList := make([]Spaces, 0, len(files))
+	for _, f := range files {
 		lo.G.Debug("Processing space file", f)
 
-		if err = LoadFile(f, &spaceList[i]); err != nil {
+		spaces := Spaces{}
+		if err = m.loadFile(f, &spaces); err != nil {
 			lo.G.Errorf("reading config for space %s: %v", f, err)
 			return nil, err
 		}
+		if removedOrgs[spaces.Org] {
+			continue
+		}
+		spaceList = append(spaceList, spaces)
 	}
 	return spaceList, nil
 }
@@ -151,49 +175,43 @@ func (m *yamlManager) OrgSpaces(orgName string) (*Spaces, error) {
 }
 
 func (m *yamlManager) GetSpaceConfigs() ([]SpaceConfig, error) {
-
-	spaceDefaults := SpaceConfig{}
-	LoadFile(filepath.Join(m.ConfigDir, "spaceDefaults.yml"), &spaceDefaults)
-
-	files, err := FindFiles(m.ConfigDir, "spaceConfig.yml")
+	files, err := m.findFiles(".", "spaceConfig.yml")
+	if err != nil {
+		return nil, err
+	}
+	removedOrgs, err := m.removedNames("orgs.yml")
 	if err != nil {
 		return nil, err
 	}
+	orgDefaults := make(map[string]yaml.MapSlice)
 	result := make([]SpaceConfig, len(files))
+	removed := make([]bool, len(files))
 	for i, f := range files {
-		result[i].AppInstanceLimit = UNLIMITED
-		result[i].AppTaskLimit = UNLIMITED
-		result[i].TotalReservedRoutePorts = UNLIMITED
-		result[i].TotalServiceKeys = UNLIMITED
-		result[i].InstanceMemoryLimit = UNLIMITED
-		result[i].TotalRoutes = UNLIMITED
-		result[i].TotalServices = UNLIMITED
-		result[i].PaidServicePlansAllowed = false
-
-		if err = LoadFile(f, &result[i]); err != nil {
+		orgDir := filepath.Dir(filepath.Dir(f))
+		spaceDefaults, ok := orgDefaults[orgDir]
+		if !ok {
+			if spaceDefaults, err = m.orgSpaceDefaults(orgDir); err != nil {
+				return nil, err
+			}
+			orgDefaults[orgDir] = spaceDefaults
+		}
+		result[i] = *newSpaceConfig()
+		if err = m.loadWithDefaults(f, spaceDefaults, "", &result[i]); err != nil {
 			return nil, err
 		}
-
-		result[i].Developer.LDAPUsers = append(result[i].Developer.LDAPUsers, spaceDefaults.Developer.LDAPUsers...)
-		result[i].Developer.Users = append(result[i].Developer.Users, spaceDefaults.Developer.Users...)
-		result[i].Developer.SamlUsers = append(result[i].Developer.SamlUsers, spaceDefaults.Developer.SamlUsers...)
-
-		result[i].Auditor.LDAPUsers = append(result[i].Auditor.LDAPUsers, spaceDefaults.Auditor.LDAPUsers...)
-		result[i].Auditor.Users = append(result[i].Auditor.Users, spaceDefaults.Auditor.Users...)
-		result[i].Auditor.SamlUsers = append(result[i].Auditor.SamlUsers, spaceDefaults.Auditor.SamlUsers...)
-
-		result[i].Manager.LDAPUsers = append(result[i].Manager.LDAPUsers, spaceDefaults.Manager.LDAPUsers...)
-		result[i].Manager.Users = append(result[i].Manager.Users, spaceDefaults.Manager.Users...)
-		result[i].Manager.SamlUsers = append(result[i].Manager.SamlUsers, spaceDefaults.Manager.SamlUsers...)
-
-		result[i].Developer.LDAPGroups = append(result[i].GetDeveloperGroups(), spaceDefaults.GetDeveloperGroups()...)
-		result[i].Auditor.LDAPGroups = append(result[i].GetAuditorGroups(), spaceDefaults.GetAuditorGroups()...)
-		result[i].Manager.LDAPGroups = append(result[i].GetManagerGroups(), spaceDefaults.GetManagerGroups()...)
+		removedSpaces, err := m.removedNames(filepath.Join(orgDir, "spaces.yml"))
+		if err != nil {
+			return nil, err
+		}
+		if removedOrgs[result[i].Org] || removedSpaces[result[i].Space] {
+			removed[i] = true
+			continue
+		}
 
 		if result[i].EnableSecurityGroup {
 			securityGroupFile := strings.Replace(f, "spaceConfig.yml", "security-group.json", -1)
 			lo.G.Debug("Loading security group contents", securityGroupFile)
-			bytes, err := os.ReadFile(securityGroupFile)
+			bytes, err := m.readFile(securityGroupFile)
 			if err != nil {
 				return nil, err
 			}
@@ -201,7 +219,42 @@ func (m *yamlManager) GetSpaceConfigs() ([]SpaceConfig, error) {
 			result[i].SecurityGroupContents = string(bytes)
 		}
 	}
-	return result, nil
+	if len(m.Overlays) == 0 {
+		return result, nil
+	}
+	var kept []SpaceConfig
+	for i := range result {
+		if !removed[i] {
+			kept = append(kept, result[i])
+		}
+	}
+	return kept, nil
+}
+
+// newOrgConfig - an org config with the defaults for the values its file leaves out
+func newOrgConfig() *OrgConfig {
+	return &OrgConfig{
+		AppTaskLimit:            UNLIMITED,
+		AppInstanceLimit:        UNLIMITED,
+		TotalReservedRoutePorts: UNLIMITED,
+		TotalPrivateDomains:     UNLIMITED,
+		TotalServiceKeys:        UNLIMITED,
+		InstanceMemoryLimit:     UNLIMITED,
+	}
+}
+
+// newSpaceConfig - a space config with the defaults for the values its file leaves out
+func newSpaceConfig() *SpaceConfig {
+	return &SpaceConfig{
+		AppInstanceLimit:        UNLIMITED,
+		AppTaskLimit:            UNLIMITED,
+		TotalReservedRoutePorts: UNLIMITED,
+		TotalServiceKeys:        UNLIMITED,
+		InstanceMemoryLimit:     UNLIMITED,
+		TotalRoutes:             UNLIMITED,
+		TotalServices:           UNLIMITED,
+		PaidServicePlansAllowed: false,
+	}
 }
 
 func (m *yamlManager) GetOrgConfig(orgName string) (*OrgConfig, error) {
@@ -232,17 +285,34 @@ func (m *yamlManager) SaveOrgConfig(orgConfig *OrgConfig) error {
 		}
 	}
 
-	directory := fmt.Sprintf("%s/%s", m.ConfigDir, orgConfig.Org)
-	if _, err := os.Stat(directory); os.IsNotExist(err) {
-		if err := os.MkdirAll(directory, 0755); err != nil {
+	if err := m.mkdirAll(orgConfig.Org); err != nil {
+		return err
+	}
+
+	relativePath := filepath.Join(orgConfig.Org, "orgConfig.yml")
+	defaults, err := m.orgDefaults()
+	if err != nil {
+		return err
+	}
+	if defaults == nil || orgConfig.IgnoreOrgDefaults {
+		return m.writeFile(relativePath, orgConfig)
+	}
+	// leaves out what orgDefaults.yml gives back when the org is read
+	explicit := yaml.MapSlice{}
+	if m.exists(relativePath) {
+		if err = m.loadFile(relativePath, &explicit); err != nil {
 			return err
 		}
 	}
-
-	return WriteFile(filepath.Join(m.ConfigDir, orgConfig.Org, "orgConfig.yml"), orgConfig)
+	return m.writeFileWithout(relativePath, orgConfig, func(document yaml.MapSlice) yaml.MapSlice {
+		return withoutDefaults(document, defaults, explicit)
+	})
 }
 
 func (m *yamlManager) RenameOrgConfig(orgConfig *OrgConfig) error {
+	if err := m.requireBaseLayer("renaming an org"); err != nil {
+		return err
+	}
 	newDirectory := fmt.Sprintf("%s/%s", m.ConfigDir, orgConfig.Org)
 	originalDirectory := fmt.Sprintf("%s/%s", m.ConfigDir, orgConfig.OriginalOrg)
 
@@ -254,6 +324,9 @@ func (m *yamlManager) RenameOrgConfig(orgConfig *OrgConfig) error {
 }
 
 func (m *yamlManager) RenameSpaceConfig(spaceConfig *SpaceConfig) error {
+	if err := m.requireBaseLayer("renaming a space"); err != nil {
+		return err
+	}
 	newDirectory := path.Join(m.ConfigDir, spaceConfig.Org, spaceConfig.Space)
 	originalDirectory := path.Join(m.ConfigDir, spaceConfig.Org, spaceConfig.OriginalSpace)
 
@@ -265,8 +338,8 @@ func (m *yamlManager) RenameSpaceConfig(spaceConfig *SpaceConfig) error {
 }
 
 func (m *yamlManager) GetSpaceConfig(orgName, spaceName string) (*SpaceConfig, error) {
-	targetPath := path.Join(m.ConfigDir, orgName, spaceName)
-	files, err := FindFiles(targetPath, "spaceConfig.yml")
+	targetPath := path.Join(orgName, spaceName)
+	files, err := m.findFiles(targetPath, "spaceConfig.yml")
 	if err != nil {
 		return nil, fmt.Errorf("Space [%s] not found in org [%s] config", spaceName, orgName)
 	}
@@ -275,7 +348,7 @@ func (m *yamlManager) GetSpaceConfig(orgName, spaceName string) (*SpaceConfig, e
 	}
 
 	result := &SpaceConfig{}
-	if err = LoadFile(files[0], &result); err != nil {
+	if err = m.loadFile(files[0], &result); err != nil {
 		return nil, err
 	}
 	return result, nil
@@ -295,14 +368,17 @@ func (m *yamlManager) SaveSpaceConfig(spaceConfig *SpaceConfig) error {
 			return err
 		}
 	}
-	if err := os.MkdirAll(fmt.Sprintf("%s/%s/%s", m.ConfigDir, spaceConfig.Org, spaceConfig.Space), 0755); err != nil {
+	if err := m.mkdirAll(path.Join(spaceConfig.Org, spaceConfig.Space)); err != nil {
 		return err
 	}
-	err = m.AddSecurityGroupToSpace(orgName, spaceName, []byte("[]"))
-	if err != nil {
-		return err
+	// an overlay keeps the security group of the layers before it
+	if index, _, _ := m.writeLayer(); index == 0 || !m.exists(path.Join(orgName, spaceName, "security-group.json")) {
+		err = m.AddSecurityGroupToSpace(orgName, spaceName, []byte("[]"))
+		if err != nil {
+			return err
+		}
 	}
-	return WriteFile(fmt.Sprintf("%s/%s/%s/spaceConfig.yml", m.ConfigDir, spaceConfig.Org, spaceConfig.Space), spaceConfig)
+	return m.writeFile(path.Join(spaceConfig.Org, spaceConfig.Space, "spaceConfig.yml"), spaceConfig)
 }
 
 func (m *yamlManager) DeleteOrgConfig(orgName string) error {
@@ -321,7 +397,7 @@ func (m *yamlManager) DeleteOrgConfig(orgName string) error {
 		if err := m.SaveOrgs(orgs); err != nil {
 			return err
 		}
-		os.RemoveAll(path.Join(m.ConfigDir, orgName))
+		m.removeAll(orgName)
 	}
 	return nil
 }
@@ -342,7 +418,7 @@ func (m *yamlManager) DeleteSpaceConfig(orgName, spaceName string) error {
 		if err := m.SaveOrgSpaces(spaces); err != nil {
 			return err
 		}
-		os.RemoveAll(path.Join(m.ConfigDir, orgName, spaceName))
+		m.removeAll(path.Join(orgName, spaceName))
 	}
 	return nil
 }
@@ -350,18 +426,41 @@ func (m *yamlManager) DeleteSpaceConfig(orgName, spaceName string) error {
 // GetSpaceDefaults returns the default space configuration, if one was provided.
 // If no space defaults were configured, a nil config and a nil error are returned.
 func (m *yamlManager) GetSpaceDefaults() (*SpaceConfig, error) {
-	fp := filepath.Join(m.ConfigDir, "spaceDefaults.yml")
-
-	if !FileOrDirectoryExists(fp) {
+	if !m.exists("spaceDefaults.yml") {
 		return nil, nil
 	}
 	result := SpaceConfig{}
-	err := LoadFile(fp, &result)
+	err := m.loadFile("spaceDefaults.yml", &result)
 	return &result, err
 }
 
+// GetOrgDefaults returns the values merged into every org, if they were provided.
+// If no org defaults were configured, a nil config and a nil error are returned.
+func (m *yamlManager) GetOrgDefaults() (*OrgConfig, error) {
+	if !m.exists(orgDefaultsFile) {
+		return nil, nil
+	}
+	result := OrgConfig{}
+	err := m.loadFile(orgDefaultsFile, &result)
+	return &result, err
+}
+
+// SaveOrgDefaults saves the values merged into every org, leaving out the ones
+// that are the same as when orgDefaults.yml doesn't set them
+func (m *yamlManager) SaveOrgDefaults(orgDefaults *OrgConfig) error {
+	document, err := toMapSlice(orgDefaults)
+	if err != nil {
+		return err
+	}
+	unset, err := toMapSlice(newOrgConfig())
+	if err != nil {
+		return err
+	}
+	return m.writeFile(orgDefaultsFile, withoutDefaults(withoutKeys(document, "org"), unset, nil))
+}
+
 func (m *yamlManager) SaveOrgs(orgs *Orgs) error {
-	if err := WriteFile(fmt.Sprintf("%s/orgs.yml", m.ConfigDir), orgs); err != nil {
+	if err := m.writeFile("orgs.yml", orgs); err != nil {
 		return err
 	}
 	return nil
@@ -406,8 +505,7 @@ func (m *yamlManager) AddSpaceToConfig(spaceConfig *SpaceConfig) error {
 
 // AddSecurityGroupToSpace - adds security group json to org/space location
 func (m *yamlManager) AddSecurityGroupToSpace(orgName, spaceName string, securityGroupDefinition []byte) error {
-	securityGroupFilePath := fmt.Sprintf("%s/%s/%s/security-group.json", m.ConfigDir, orgName, spaceName)
-	if err := WriteFileBytes(securityGroupFilePath, securityGroupDefinition); err != nil {
+	if err := m.writeFileBytes(path.Join(orgName, spaceName, "security-group.json"), securityGroupDefinition); err != nil {
 		return err
 	}
 	return nil
@@ -416,28 +514,28 @@ func (m *yamlManager) AddSecurityGroupToSpace(orgName, spaceName string, securit
 // AddSecurityGroup - adds security group json to org/space location
 func (m *yamlManager) AddSecurityGroup(securityGroupName string, securityGroupDefinition []byte) error {
 	lo.G.Infof("Writing out bytes for security group %s", securityGroupName)
-	return WriteFileBytes(fmt.Sprintf("%s/asgs/%s.json", m.ConfigDir, securityGroupName), securityGroupDefinition)
+	return m.writeFileBytes(path.Join("asgs", securityGroupName+".json"), securityGroupDefinition)
 }
 
 // AddDefaultSecurityGroup - adds security group json to org/space location
 func (m *yamlManager) AddDefaultSecurityGroup(securityGroupName string, securityGroupDefinition []byte) error {
 	lo.G.Infof("Writing out bytes for security group %s", securityGroupName)
-	return WriteFileBytes(fmt.Sprintf("%s/default_asgs/%s.json", m.ConfigDir, securityGroupName), securityGroupDefinition)
+	return m.writeFileBytes(path.Join("default_asgs", securityGroupName+".json"), securityGroupDefinition)
 }
 
 func (m *yamlManager) AddOrgQuota(orgQuota OrgQuota) error {
 	lo.G.Infof("Writing out orgQuota %s", orgQuota.Name)
-	return WriteFile(fmt.Sprintf("%s/org_quotas/%s.yml", m.ConfigDir, orgQuota.Name), orgQuota)
+	return m.writeFile(path.Join("org_quotas", orgQuota.Name+".yml"), orgQuota)
 }
 
 func (m *yamlManager) AddSpaceQuota(spaceQuota SpaceQuota) error {
-	quotasDir := path.Join(m.ConfigDir, spaceQuota.Org, "space_quotas")
-	if err := os.MkdirAll(quotasDir, 0755); err != nil {
+	quotasDir := path.Join(spaceQuota.Org, "space_quotas")
+	if err := m.mkdirAll(quotasDir); err != nil {
 		lo.G.Errorf("Error creating config directory %s. Error : %s", quotasDir, err)
-		return fmt.Errorf("cannot create directory %s: %v", quotasDir, err)
+		return err
 	}
 	lo.G.Infof("Writing out spaceQuota %s for org %s", spaceQuota.Name, spaceQuota.Org)
-	return WriteFile(fmt.Sprintf("%s/%s/space_quotas/%s.yml", m.ConfigDir, spaceQuota.Org, spaceQuota.Name), spaceQuota)
+	return m.writeFile(path.Join(quotasDir, spaceQuota.Name+".yml"), spaceQuota)
 }
 
 // CreateConfigIfNotExists initializes a new configuration directory.
@@ -483,7 +581,7 @@ func (m *yamlManager) CreateConfigIfNotExists(uaaOrigin string) error {
 	}
 	lo.G.Infof("OrgQuotas directory %s created", orgQuotasDir)
 
-	if err := m.SaveGlobalConfig(&GlobalConfig{}); err != nil {
+	if err := m.SaveGlobalConfig(&GlobalConfig{SchemaVersion: SchemaVersion}); err != nil {
 		return err
 	}
 	if err := WriteFile(fmt.Sprintf("%s/ldap.yml", m.ConfigDir), &LdapConfig{TLS: false, Origin: uaaOrigin}); err != nil {
@@ -507,7 +605,7 @@ func (m *yamlManager) CreateConfigIfNotExists(uaaOrigin string) error {
 }
 
 func (m *yamlManager) SaveGlobalConfig(globalConfig *GlobalConfig) error {
-	return WriteFile(fmt.Sprintf("%s/cf-mgmt.yml", m.ConfigDir), globalConfig)
+	return m.writeFile("cf-mgmt.yml", globalConfig)
 }
 
 // DeleteConfigIfExists deletes config directory if it exists.
@@ -526,7 +624,7 @@ func (m *yamlManager) DeleteConfigIfExists() error {
 
 func (m *yamlManager) LdapConfig(ldapBindUser, ldapBindPassword, ldapServer string) (*LdapConfig, error) {
 	config := &LdapConfig{}
-	err := LoadFile(path.Join(m.ConfigDir, "ldap.yml"), config)
+	err := m.loadFile("ldap.yml", config)
 	if err != nil {
 		return nil, err
 	}
@@ -553,19 +651,18 @@ func (m *yamlManager) LdapConfig(ldapBindUser, ldapBindPassword, ldapServer stri
 }
 
 func (m *yamlManager) GetOrgQuotas() ([]OrgQuota, error) {
-	filePath := path.Join(m.ConfigDir, "org_quotas")
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+	if !m.exists("org_quotas") {
 		lo.G.Infof("No org quotas found.  Create directory org_quotas and add org quota definition(s)")
 		return nil, nil
 	}
-	files, err := FindFiles(filePath, ".yml")
+	files, err := m.findFiles("org_quotas", ".yml")
 	if err != nil {
 		return nil, err
 	}
 	var result []OrgQuota
 	for _, orgQuotaFile := range files {
 		orgQuota := &OrgQuota{}
-		err = LoadFile(orgQuotaFile, orgQuota)
+		err = m.loadFile(orgQuotaFile, orgQuota)
 		if err != nil {
 			return nil, err
 		}
@@ -589,28 +686,28 @@ func (m *yamlManager) GetOrgQuota(name string) (*OrgQuota, error) {
 }
 
 func (m *yamlManager) SaveOrgQuota(orgQuota *OrgQuota) error {
-	orgQuotaPath := path.Join(m.ConfigDir, "org_quotas")
-	if err := os.MkdirAll(orgQuotaPath, 0755); err != nil {
-		return fmt.Errorf("cannot create directory %s: %v", orgQuotaPath, err)
+	orgQuotaPath := "org_quotas"
+	if err := m.mkdirAll(orgQuotaPath); err != nil {
+		return err
 	}
 	fmt.Println(fmt.Sprintf("Saving Named Org Quote %s", orgQuota.Name))
-	return WriteFile(fmt.Sprintf("%s/%s.yml", orgQuotaPath, orgQuota.Name), orgQuota)
+	return m.writeFile(path.Join(orgQuotaPath, orgQuota.Name+".yml"), orgQuota)
 }
 
 func (m *yamlManager) GetSpaceQuotas(org string) ([]SpaceQuota, error) {
-	filePath := path.Join(m.ConfigDir, org, "space_quotas")
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+	filePath := path.Join(org, "space_quotas")
+	if !m.exists(filePath) {
 		lo.G.Infof("No space quotas found. Create directory space_quotas for org %s and add space quota definition(s)", org)
 		return nil, nil
 	}
-	files, err := FindFiles(filePath, ".yml")
+	files, err := m.findFiles(filePath, ".yml")
 	if err != nil {
 		return nil, err
 	}
 	var result []SpaceQuota
 	for _, spaceQuotaFile := range files {
 		spaceQuota := &SpaceQuota{}
-		err = LoadFile(spaceQuotaFile, spaceQuota)
+		err = m.loadFile(spaceQuotaFile, spaceQuota)
 		if err != nil {
 			return nil, err
 		}
@@ -635,13 +732,13 @@ func (m *yamlManager) GetSpaceQuota(name, org string) (*SpaceQuota, error) {
 }
 
 func (m *yamlManager) SaveSpaceQuota(spaceQuota *SpaceQuota) error {
-	spaceQuotaPath := path.Join(m.ConfigDir, spaceQuota.Org, "space_quotas")
-	if err := os.MkdirAll(spaceQuotaPath, 0755); err != nil {
-		return fmt.Errorf("cannot create directory %s: %v", spaceQuotaPath, err)
+	spaceQuotaPath := path.Join(spaceQuota.Org, "space_quotas")
+	if err := m.mkdirAll(spaceQuotaPath); err != nil {
+		return err
 	}
-	targetFile := fmt.Sprintf("%s/%s.yml", spaceQuotaPath, spaceQuota.Name)
+	targetFile := path.Join(spaceQuotaPath, spaceQuota.Name+".yml")
 	fmt.Println(fmt.Sprintf("Saving Named Space Quote %s for org %s", spaceQuota.Name, spaceQuota.Org))
-	return WriteFile(targetFile, spaceQuota)
+	return m.writeFile(targetFile, spaceQuota)
 }
 
 type userRole int
@@ -228,6 +228,7 @@ var _ = Describe("CF-Mgmt Config", func() {
 				Expect(cfg.Developer.LDAPUsers).Should(ConsistOf("default-ldap-user", "space1-ldap-user"))
 				Expect(cfg.Developer.Users).Should(ConsistOf("default-user@test.com", "space-1-user@test.com"))
 				Expect(cfg.Developer.LDAPGroup).Should(BeEquivalentTo("space-1-ldap-group"))
+				Expect(cfg.GetDeveloperGroups()).Should(ConsistOf("space-1-ldap-group", "default-ldap-group"))
 
 				Expect(cfg.Auditor.LDAPUsers).Should(ConsistOf("default-ldap-user", "space1-ldap-user"))
 				Expect(cfg.Auditor.Users).Should(ConsistOf("default-user@test.com", "space-1-user@test.com"))
@@ -10,7 +10,7 @@ import (
 
 type AddASGToConfigurationCommand struct {
 	ConfigManager config.Manager
-	BaseConfigCommand
+	BaseLayeredConfigCommand
 	ASGName  string `long:"asg" description:"ASG name" required:"true"`
 	FilePath string `long:"path" description:"path to asg definition"`
 	Override bool   `long:"override" description:"override current definition"`
@@ -61,11 +61,11 @@ func (c *AddASGToConfigurationCommand) Execute([]string) error {
 		securityGroupsBytes = bytes
 	}
 	if c.ASGType == "space" {
-		if err := config.NewManager(c.ConfigDirectory).AddSecurityGroup(c.ASGName, securityGroupsBytes); err != nil {
+		if err := c.newConfigManager().AddSecurityGroup(c.ASGName, securityGroupsBytes); err != nil {
 			return err
 		}
 	} else {
-		if err := config.NewManager(c.ConfigDirectory).AddDefaultSecurityGroup(c.ASGName, securityGroupsBytes); err != nil {
+		if err := c.newConfigManager().AddDefaultSecurityGroup(c.ASGName, securityGroupsBytes); err != nil {
 			return err
 		}
 	}
@@ -75,6 +75,6 @@ func (c *AddASGToConfigurationCommand) Execute([]string) error {
 
 func (c *AddASGToConfigurationCommand) initConfig() {
 	if c.ConfigManager == nil {
-		c.ConfigManager = config.NewManager(c.ConfigDirectory)
+		c.ConfigManager = c.newConfigManager()
 	}
 }
@@ -11,7 +11,7 @@ import (
 
 type AddOrgToConfigurationCommand struct {
 	ConfigManager config.Manager
-	BaseConfigCommand
+	BaseLayeredConfigCommand
 	OrgName                 string      `long:"org" description:"Org name" required:"true"`
 	PrivateDomains          []string    `long:"private-domain" description:"Private Domain(s) to add, specify multiple times"`
 	SharedPrivateDomains    []string    `long:"shared-private-domain" description:"Shared Private Domain(s) to add, specify multiple times"`
@@ -79,10 +79,10 @@ func (c *AddOrgToConfigurationCommand) Execute([]string) error {
 		return errors.New(errorString)
 	}
 
-	if err := config.NewManager(c.ConfigDirectory).AddOrgToConfig(orgConfig); err != nil {
+	if err := c.newConfigManager().AddOrgToConfig(orgConfig); err != nil {
 		return err
 	}
-	if err := config.NewManager(c.ConfigDirectory).SaveOrgSpaces(orgSpaces); err != nil {
+	if err := c.newConfigManager().SaveOrgSpaces(orgSpaces); err != nil {
 		return err
 	}
 	fmt.Println(fmt.Sprintf("The org [%s] has been added", c.OrgName))
@@ -101,6 +101,6 @@ func (c *AddOrgToConfigurationCommand) updateUsers(orgConfig *config.OrgConfig,
 
 func (c *AddOrgToConfigurationCommand) initConfig() {
 	if c.ConfigManager == nil {
-		c.ConfigManager = config.NewManager(c.ConfigDirectory)
+		c.ConfigManager = c.newConfigManager()
 	}
 }
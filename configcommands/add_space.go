@@ -12,7 +12,7 @@ import (
 
 type AddSpaceToConfigurationCommand struct {
 	ConfigManager config.Manager
-	BaseConfigCommand
+	BaseLayeredConfigCommand
 	OrgName                     string      `long:"org" description:"Org name" required:"true"`
 	SpaceName                   string      `long:"space" description:"Space name" required:"true"`
 	AllowSSH                    string      `long:"allow-ssh" description:"Enable the application ssh" choice:"true" choice:"false"`
@@ -66,7 +66,7 @@ func (c *AddSpaceToConfigurationCommand) Execute([]string) error {
 		return errors.New(errorString)
 	}
 
-	if err := config.NewManager(c.ConfigDirectory).AddSpaceToConfig(spaceConfig); err != nil {
+	if err := c.newConfigManager().AddSpaceToConfig(spaceConfig); err != nil {
 		return err
 	}
 	fmt.Println(fmt.Sprintf("The org/space [%s/%s] has been updated", c.OrgName, c.SpaceName))
@@ -107,6 +107,6 @@ func (c *AddSpaceToConfigurationCommand) updateUsers(spaceConfig *config.SpaceCo
 
 func (c *AddSpaceToConfigurationCommand) initConfig() {
 	if c.ConfigManager == nil {
-		c.ConfigManager = config.NewManager(c.ConfigDirectory)
+		c.ConfigManager = c.newConfigManager()
 	}
 }
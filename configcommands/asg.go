@@ -9,7 +9,7 @@ import (
 
 type ASGToConfigurationCommand struct {
 	ConfigManager config.Manager
-	BaseConfigCommand
+	BaseLayeredConfigCommand
 	ASGName  string `long:"asg" description:"ASG name" required:"true"`
 	FilePath string `long:"path" description:"path to asg definition"`
 	Override bool   `long:"override" description:"override current definition"`
@@ -59,11 +59,11 @@ func (c *ASGToConfigurationCommand) Execute([]string) error {
 		securityGroupsBytes = bytes
 	}
 	if c.ASGType == "space" {
-		if err := config.NewManager(c.ConfigDirectory).AddSecurityGroup(c.ASGName, securityGroupsBytes); err != nil {
+		if err := c.newConfigManager().AddSecurityGroup(c.ASGName, securityGroupsBytes); err != nil {
 			return err
 		}
 	} else {
-		if err := config.NewManager(c.ConfigDirectory).AddDefaultSecurityGroup(c.ASGName, securityGroupsBytes); err != nil {
+		if err := c.newConfigManager().AddDefaultSecurityGroup(c.ASGName, securityGroupsBytes); err != nil {
 			return err
 		}
 	}
@@ -73,6 +73,6 @@ func (c *ASGToConfigurationCommand) Execute([]string) error {
 
 func (c *ASGToConfigurationCommand) initConfig() {
 	if c.ConfigManager == nil {
-		c.ConfigManager = config.NewManager(c.ConfigDirectory)
+		c.ConfigManager = c.newConfigManager()
 	}
 }
@@ -21,6 +21,12 @@ type CfMgmtConfigCommand struct {
 	OrgNamedQuotaConfigurationCommand   OrgNamedQuotaConfigurationCommand   `command:"named-org-quota" description:"creates/updates named org quota"`
 	SpaceNamedQuotaConfigurationCommand SpaceNamedQuotaConfigurationCommand `command:"named-space-quota" description:"creates/updates named space quota"`
 	ClearUsersCommand                   ClearUsersCommand                   `command:"clear-users" description:"updates all configuration but removes any user/group mapping"`
+	RenderConfigurationCommand          RenderConfigurationCommand          `command:"render" description:"prints the configuration as cf-mgmt reads it, merged across the overlays and with its ((var)) placeholders resolved"`
+	ValidateConfigurationCommand        ValidateConfigurationCommand        `command:"validate" description:"checks the configuration for references to quotas, asgs, domains, orgs and spaces that aren't configured and values that can't be parsed"`
+	MigrateConfigurationCommand         MigrateConfigurationCommand         `command:"migrate" description:"rewrites the configuration from an older schema-version to the current one"`
+	FormatConfigurationCommand          FormatConfigurationCommand          `command:"fmt" description:"rewrites the configuration files in a canonical form, or with --check lists those that aren't"`
+	DiffConfigurationCommand            DiffConfigurationCommand            `command:"diff" description:"prints the orgs, spaces, roles, quotas, security groups and service access that differ between two configurations, or a configuration and a git revision of it"`
+	SchemaCommand                       SchemaCommand                       `command:"schema" description:"writes JSON Schemas of the configuration files for editors and pre-commit hooks to check them with"`
 }
 
 var CfMgmtConfig CfMgmtConfigCommand
@@ -6,7 +6,7 @@ import (
 
 type ClearUsersCommand struct {
 	ConfigManager config.Manager
-	BaseConfigCommand
+	BaseLayeredConfigCommand
 }
 
 // Execute - updates org configuration`
@@ -81,6 +81,6 @@ func (c *ClearUsersCommand) Execute(args []string) error {
 
 func (c *ClearUsersCommand) initConfig() {
 	if c.ConfigManager == nil {
-		c.ConfigManager = config.NewManager(c.ConfigDirectory)
+		c.ConfigManager = c.newConfigManager()
 	}
 }
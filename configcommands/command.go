@@ -7,9 +7,60 @@ import (
 	"github.com/vmwarepivotallabs/cf-mgmt/config"
 )
 
-// BaseConfigCommand - commmand that specifies config-dir
+// BaseConfigCommand - commmand that specifies config-dir and the overlays merged onto it
 type BaseConfigCommand struct {
-	ConfigDirectory string `long:"config-dir" env:"CONFIG_DIR" default:"config" description:"Name of the config directory"`
+	ConfigDirectory string   `long:"config-dir" env:"CONFIG_DIR" default:"config" description:"Name of the config directory"`
+	Overlays        []string `long:"overlay" env:"OVERLAYS" env-delim:"," description:"config directory whose files are merged onto config-dir. Repeat the flag to merge several, in order"`
+}
+
+// ConfigLayers - config-dir and its overlays
+func (c BaseConfigCommand) ConfigLayers() config.Layers {
+	return config.Layers{Base: c.ConfigDirectory, Overlays: c.Overlays}
+}
+
+// BaseVarsCommand - command that reads the config as cf-mgmt does, resolving its
+// ((var)) placeholders
+type BaseVarsCommand struct {
+	VarsFiles     []string `long:"vars-file" env:"VARS_FILES" env-delim:"," description:"yaml file of values for the ((var)) placeholders in the config. Repeat the flag to load several, later files taking precedence. Placeholders are also resolved from CF_MGMT_VAR_<NAME> environment variables"`
+	StrictVars    bool     `long:"strict-vars" env:"STRICT_VARS" description:"fail when a ((var)) placeholder has no value rather than leave it in place"`
+	SecretCommand string   `long:"secret-command" env:"SECRET_COMMAND" description:"command run with the name of a variable that is neither in a vars file nor in the environment, what it prints is the value"`
+	StrictYAML    bool     `long:"strict-yaml" env:"STRICT_YAML" description:"fail on unknown keys in the config files, such as misspelled ones, rather than ignore them and warn about deprecated keys. Will be the default in a future major release"`
+}
+
+// ReadLayers - layers with the variables and strictness they are read with
+func (c BaseVarsCommand) ReadLayers(layers config.Layers) (config.Layers, error) {
+	variables, err := c.Variables()
+	if err != nil {
+		return layers, err
+	}
+	layers.Variables = variables
+	layers.Strict = c.StrictYAML
+	return layers, nil
+}
+
+// Variables - the variables that resolve the placeholders of the config
+func (c BaseVarsCommand) Variables() (*config.Variables, error) {
+	variables, err := config.NewVariables(c.VarsFiles...)
+	if err != nil {
+		return nil, err
+	}
+	variables.Strict = c.StrictVars
+	if c.SecretCommand != "" {
+		variables.Resolver = config.CommandResolver{Command: c.SecretCommand}
+	}
+	return variables, nil
+}
+
+// BaseLayeredConfigCommand - command that saves changes to config-dir or one of its overlays
+type BaseLayeredConfigCommand struct {
+	BaseConfigCommand
+	Layer string `long:"layer" env:"LAYER" description:"config directory to save changes to, config-dir or one of the overlays. Only the values that differ from the layers before it are saved to an overlay [defaults to config-dir]"`
+}
+
+func (c BaseLayeredConfigCommand) newConfigManager() config.Manager {
+	layers := c.ConfigLayers()
+	layers.Write = c.Layer
+	return config.NewLayeredManager(layers)
 }
 
 type UserRole struct {
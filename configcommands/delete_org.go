@@ -1,20 +1,16 @@
 package configcommands
 
-import (
-	"fmt"
-
-	"github.com/vmwarepivotallabs/cf-mgmt/config"
-)
+import "fmt"
 
 type DeleteOrgConfigurationCommand struct {
-	BaseConfigCommand
+	BaseLayeredConfigCommand
 	OrgName         string `long:"org" description:"Org name to delete" required:"true"`
 	ConfirmDeletion bool   `long:"confirm-deletion" description:"Confirm Deletion" required:"true"`
 }
 
 // Execute - deletes org from config
 func (c *DeleteOrgConfigurationCommand) Execute([]string) error {
-	if err := config.NewManager(c.ConfigDirectory).DeleteOrgConfig(c.OrgName); err != nil {
+	if err := c.newConfigManager().DeleteOrgConfig(c.OrgName); err != nil {
 		return err
 	}
 
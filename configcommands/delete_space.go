@@ -1,13 +1,9 @@
 package configcommands
 
-import (
-	"fmt"
-
-	"github.com/vmwarepivotallabs/cf-mgmt/config"
-)
+import "fmt"
 
 type DeleteSpaceConfigurationCommand struct {
-	BaseConfigCommand
+	BaseLayeredConfigCommand
 	OrgName         string `long:"org" description:"Org name of space to delete" required:"true"`
 	SpaceName       string `long:"space" description:"Space name to delete" required:"true"`
 	ConfirmDeletion bool   `long:"confirm-deletion" description:"Confirm Deletion" required:"true"`
@@ -15,7 +11,7 @@ type DeleteSpaceConfigurationCommand struct {
 
 // Execute - deletes space from config
 func (c *DeleteSpaceConfigurationCommand) Execute([]string) error {
-	if err := config.NewManager(c.ConfigDirectory).DeleteSpaceConfig(c.OrgName, c.SpaceName); err != nil {
+	if err := c.newConfigManager().DeleteSpaceConfig(c.OrgName, c.SpaceName); err != nil {
 		return err
 	}
 
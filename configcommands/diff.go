@@ -0,0 +1,58 @@
+package configcommands
+
+import (
+	"errors"
+	"os"
+
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
+	"github.com/vmwarepivotallabs/cf-mgmt/config"
+	"github.com/vmwarepivotallabs/cf-mgmt/configdiff"
+)
+
+type DiffConfigurationCommand struct {
+	BaseConfigCommand
+	BaseVarsCommand
+	GitRef string `long:"git-ref" env:"GIT_REF" description:"git revision of the repository of config-dir to compare config-dir and its overlays at with them as they are on disk, rather than compare two directories"`
+	Output string `long:"output" env:"OUTPUT" default:"text" choice:"text" choice:"json" choice:"markdown" description:"format of the differences"`
+	Args   struct {
+		From string `positional-arg-name:"from-dir" description:"config directory to compare from"`
+		To   string `positional-arg-name:"to-dir" description:"config directory to compare to"`
+	} `positional-args:"yes"`
+}
+
+// Execute - prints the logical differences between two configurations
+func (c *DiffConfigurationCommand) Execute([]string) error {
+	fromLayers, toLayers, cleanup, err := c.layers()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	if fromLayers, err = c.ReadLayers(fromLayers); err != nil {
+		return err
+	}
+	if toLayers, err = c.ReadLayers(toLayers); err != nil {
+		return err
+	}
+	differences, err := configdiff.Diff(config.NewLayeredManager(fromLayers), config.NewLayeredManager(toLayers))
+	if err != nil {
+		return err
+	}
+	plan := changes.NewPlan(differences)
+	plan.Title = "cf-mgmt-config diff"
+	return plan.Write(os.Stdout, c.Output)
+}
+
+// layers - the configs compared and what removes any copy made of them
+func (c *DiffConfigurationCommand) layers() (config.Layers, config.Layers, func(), error) {
+	if c.GitRef != "" {
+		if c.Args.From != "" {
+			return config.Layers{}, config.Layers{}, nil, errors.New("compare either config-dir at --git-ref or two directories, not both")
+		}
+		fromLayers, cleanup, err := configdiff.LayersAt(c.ConfigLayers(), c.GitRef)
+		return fromLayers, c.ConfigLayers(), cleanup, err
+	}
+	if c.Args.From == "" || c.Args.To == "" {
+		return config.Layers{}, config.Layers{}, nil, errors.New("give the two directories to compare, or --git-ref to compare config-dir at a git revision")
+	}
+	return config.Layers{Base: c.Args.From}, config.Layers{Base: c.Args.To}, func() {}, nil
+}
@@ -0,0 +1,31 @@
+package configcommands
+
+import (
+	"fmt"
+
+	"github.com/vmwarepivotallabs/cf-mgmt/config"
+)
+
+type FormatConfigurationCommand struct {
+	BaseConfigCommand
+	Check bool `long:"check" description:"only list the files that aren't formatted, failing when there are any"`
+}
+
+// Execute - rewrites the files of config-dir and its overlays in their canonical form
+func (c *FormatConfigurationCommand) Execute([]string) error {
+	count := 0
+	for _, dir := range append([]string{c.ConfigDirectory}, c.Overlays...) {
+		files, err := config.Format(dir, c.Check)
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			fmt.Println(fmt.Sprintf("%s/%s", dir, file))
+		}
+		count += len(files)
+	}
+	if c.Check && count > 0 {
+		return fmt.Errorf("%d files aren't formatted, run cf-mgmt-config fmt", count)
+	}
+	return nil
+}
@@ -10,7 +10,7 @@ import (
 
 type GlobalConfigurationCommand struct {
 	ConfigManager config.Manager
-	BaseConfigCommand
+	BaseLayeredConfigCommand
 	EnableDeleteIsolationSegments  string              `long:"enable-delete-isolation-segments" description:"Enable removing isolation segments" choice:"true" choice:"false"`
 	EnableDeleteSharedDomains      string              `long:"enable-delete-shared-domains" description:"Enable removing shared domains" choice:"true" choice:"false"`
 	EnableServiceAccess            string              `long:"enable-service-access" description:"Enable managing service access" choice:"true" choice:"false"`
@@ -127,7 +127,7 @@ func (c *GlobalConfigurationCommand) updateSecGroups(current, additions, removal
 
 func (c *GlobalConfigurationCommand) initConfig() {
 	if c.ConfigManager == nil {
-		c.ConfigManager = config.NewManager(c.ConfigDirectory)
+		c.ConfigManager = c.newConfigManager()
 	}
 }
 
@@ -0,0 +1,26 @@
+package configcommands
+
+import (
+	"fmt"
+
+	"github.com/vmwarepivotallabs/cf-mgmt/config"
+)
+
+type MigrateConfigurationCommand struct {
+	BaseConfigCommand
+}
+
+// Execute - rewrites config-dir and its overlays to the current schema version
+func (c *MigrateConfigurationCommand) Execute([]string) error {
+	for _, dir := range append([]string{c.ConfigDirectory}, c.Overlays...) {
+		changes, err := config.Migrate(dir)
+		if err != nil {
+			return err
+		}
+		for _, change := range changes {
+			fmt.Println(fmt.Sprintf("%s/%s", dir, change))
+		}
+	}
+	fmt.Println(fmt.Sprintf("The config is at schema-version %d", config.SchemaVersion))
+	return nil
+}
@@ -8,7 +8,7 @@ import (
 
 type OrgNamedQuotaConfigurationCommand struct {
 	ConfigManager config.Manager
-	BaseConfigCommand
+	BaseLayeredConfigCommand
 	Name  string        `long:"name" description:"Name of quota" required:"true"`
 	Quota NamedOrgQuota `group:"quota"`
 }
@@ -58,6 +58,6 @@ func updateOrgNamedQuotaConfig(namedOrgQuota *config.OrgQuota, orgQuota NamedOrg
 
 func (c *OrgNamedQuotaConfigurationCommand) initConfig() {
 	if c.ConfigManager == nil {
-		c.ConfigManager = config.NewManager(c.ConfigDirectory)
+		c.ConfigManager = c.newConfigManager()
 	}
 }
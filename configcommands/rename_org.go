@@ -8,7 +8,7 @@ import (
 
 type RenameOrgConfigurationCommand struct {
 	ConfigManager config.Manager
-	BaseConfigCommand
+	BaseLayeredConfigCommand
 	OrgName    string `long:"org" description:"Org name" required:"true"`
 	NewOrgName string `long:"new-org" description:"Org name to rename to" required:"true"`
 }
@@ -64,6 +64,6 @@ func (c *RenameOrgConfigurationCommand) Execute(args []string) error {
 
 func (c *RenameOrgConfigurationCommand) initConfig() {
 	if c.ConfigManager == nil {
-		c.ConfigManager = config.NewManager(c.ConfigDirectory)
+		c.ConfigManager = c.newConfigManager()
 	}
 }
@@ -8,7 +8,7 @@ import (
 
 type RenameSpaceConfigurationCommand struct {
 	ConfigManager config.Manager
-	BaseConfigCommand
+	BaseLayeredConfigCommand
 	OrgName      string `long:"org" description:"Org name" required:"true"`
 	SpaceName    string `long:"space" description:"Space name" required:"true"`
 	NewSpaceName string `long:"new-space" description:"Space name to rename to" required:"true"`
@@ -44,6 +44,6 @@ func (c *RenameSpaceConfigurationCommand) Execute(args []string) error {
 
 func (c *RenameSpaceConfigurationCommand) initConfig() {
 	if c.ConfigManager == nil {
-		c.ConfigManager = config.NewManager(c.ConfigDirectory)
+		c.ConfigManager = c.newConfigManager()
 	}
 }
@@ -0,0 +1,21 @@
+package configcommands
+
+import (
+	"os"
+
+	"github.com/vmwarepivotallabs/cf-mgmt/config"
+)
+
+type RenderConfigurationCommand struct {
+	BaseConfigCommand
+	BaseVarsCommand
+}
+
+// Execute - prints the configuration merged across the overlays with its variables resolved
+func (c *RenderConfigurationCommand) Execute([]string) error {
+	layers, err := c.ReadLayers(c.ConfigLayers())
+	if err != nil {
+		return err
+	}
+	return config.Render(layers, os.Stdout)
+}
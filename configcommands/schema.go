@@ -0,0 +1,33 @@
+package configcommands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/vmwarepivotallabs/cf-mgmt/config"
+)
+
+type SchemaCommand struct {
+	TargetDirectory string `long:"target-dir" default:"." description:"Name of the target directory to write the schemas into"`
+}
+
+// Execute - writes the JSON Schema of each kind of config file
+func (c *SchemaCommand) Execute([]string) error {
+	if err := os.MkdirAll(c.TargetDirectory, 0755); err != nil {
+		return errors.Wrapf(err, "Error creating directory %s", c.TargetDirectory)
+	}
+	for _, schema := range config.SchemaFiles() {
+		data, err := schema.JSON()
+		if err != nil {
+			return err
+		}
+		file := filepath.Join(c.TargetDirectory, schema.FileName())
+		if err := os.WriteFile(file, data, 0644); err != nil {
+			return errors.Wrapf(err, "Error writing %s", file)
+		}
+		fmt.Println(fmt.Sprintf("%s is the schema of %v", file, schema.Files))
+	}
+	return nil
+}
@@ -11,7 +11,7 @@ import (
 
 type SpaceConfigurationCommand struct {
 	ConfigManager config.Manager
-	BaseConfigCommand
+	BaseLayeredConfigCommand
 	OrgName                     string     `long:"org" description:"Org name" required:"true"`
 	SpaceName                   string     `long:"space" description:"Space name" required:"true"`
 	AllowSSH                    string     `long:"allow-ssh" description:"Enable the application ssh" choice:"true" choice:"false"`
@@ -174,6 +174,6 @@ func (c *SpaceConfigurationCommand) updateUsers(spaceConfig *config.SpaceConfig,
 
 func (c *SpaceConfigurationCommand) initConfig() {
 	if c.ConfigManager == nil {
-		c.ConfigManager = config.NewManager(c.ConfigDirectory)
+		c.ConfigManager = c.newConfigManager()
 	}
 }
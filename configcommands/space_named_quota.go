@@ -8,7 +8,7 @@ import (
 
 type SpaceNamedQuotaConfigurationCommand struct {
 	ConfigManager config.Manager
-	BaseConfigCommand
+	BaseLayeredConfigCommand
 	Name  string          `long:"name" description:"Name of quota" required:"true"`
 	Org   string          `long:"org" description:"Name of org" required:"true"`
 	Quota NamedSpaceQuota `group:"quota"`
@@ -60,6 +60,6 @@ func updateSpaceNamedQuotaConfig(namedSpaceQuota *config.SpaceQuota, spaceQuota
 
 func (c *SpaceNamedQuotaConfigurationCommand) initConfig() {
 	if c.ConfigManager == nil {
-		c.ConfigManager = config.NewManager(c.ConfigDirectory)
+		c.ConfigManager = c.newConfigManager()
 	}
 }
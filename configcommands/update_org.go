@@ -10,7 +10,7 @@ import (
 
 type UpdateOrgConfigurationCommand struct {
 	ConfigManager config.Manager
-	BaseConfigCommand
+	BaseLayeredConfigCommand
 	OrgName                          string        `long:"org" description:"Org name" required:"true"`
 	PrivateDomains                   []string      `long:"private-domain" description:"Private Domain(s) to add, specify multiple times"`
 	PrivateDomainsToRemove           []string      `long:"private-domain-to-remove" description:"Private Domain(s) to remove, specify multiple times"`
@@ -127,6 +127,6 @@ func (c *UpdateOrgConfigurationCommand) updateUsers(orgConfig *config.OrgConfig,
 
 func (c *UpdateOrgConfigurationCommand) initConfig() {
 	if c.ConfigManager == nil {
-		c.ConfigManager = config.NewManager(c.ConfigDirectory)
+		c.ConfigManager = c.newConfigManager()
 	}
 }
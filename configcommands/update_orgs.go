@@ -9,7 +9,7 @@ import (
 
 type UpdateOrgsConfigurationCommand struct {
 	ConfigManager config.Manager
-	BaseConfigCommand
+	BaseLayeredConfigCommand
 	EnableDeleteOrgs      string   `long:"enable-delete-orgs" description:"Enable delete orgs option" choice:"true" choice:"false"`
 	ProtectedOrgsToAdd    []string `long:"protected-org" description:"Add org(s) to protected org list, specify multiple times. Uses re2 syntax: https://github.com/google/re2/wiki/Syntax"`
 	ProtectedOrgsToRemove []string `long:"protected-org-to-remove" description:"Remove org(s) from protected org list, specify multiple times"`
@@ -39,6 +39,6 @@ func (c *UpdateOrgsConfigurationCommand) Execute(args []string) error {
 
 func (c *UpdateOrgsConfigurationCommand) initConfig() {
 	if c.ConfigManager == nil {
-		c.ConfigManager = config.NewManager(c.ConfigDirectory)
+		c.ConfigManager = c.newConfigManager()
 	}
 }
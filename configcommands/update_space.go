@@ -12,7 +12,7 @@ import (
 
 type UpdateSpaceConfigurationCommand struct {
 	ConfigManager config.Manager
-	BaseConfigCommand
+	BaseLayeredConfigCommand
 	OrgName                     string     `long:"org" description:"Org name" required:"true"`
 	SpaceName                   string     `long:"space" description:"Space name" required:"true"`
 	AllowSSH                    string     `long:"allow-ssh" description:"Enable the application ssh" choice:"true" choice:"false"`
@@ -115,6 +115,6 @@ func (c *UpdateSpaceConfigurationCommand) updateUsers(spaceConfig *config.SpaceC
 
 func (c *UpdateSpaceConfigurationCommand) initConfig() {
 	if c.ConfigManager == nil {
-		c.ConfigManager = config.NewManager(c.ConfigDirectory)
+		c.ConfigManager = c.newConfigManager()
 	}
 }
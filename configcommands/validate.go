@@ -0,0 +1,32 @@
+package configcommands
+
+import (
+	"fmt"
+
+	"github.com/vmwarepivotallabs/cf-mgmt/config"
+)
+
+type ValidateConfigurationCommand struct {
+	BaseConfigCommand
+	BaseVarsCommand
+}
+
+// Execute - prints the problems found in the configuration and fails when there are any
+func (c *ValidateConfigurationCommand) Execute([]string) error {
+	layers, err := c.ReadLayers(c.ConfigLayers())
+	if err != nil {
+		return err
+	}
+	problems, err := config.Validate(layers)
+	if err != nil {
+		return err
+	}
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%d problems found in the configuration", len(problems))
+	}
+	fmt.Println("The configuration is valid")
+	return nil
+}
@@ -0,0 +1,635 @@
+package configdiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
+	"github.com/vmwarepivotallabs/cf-mgmt/config"
+	"github.com/vmwarepivotallabs/cf-mgmt/securitygroup"
+)
+
+var (
+	orgRoles   = []string{"org-billingmanager", "org-manager", "org-auditor"}
+	spaceRoles = []string{"space-manager", "space-developer", "space-auditor", "space-supporter"}
+	// roleKeys - the keys of org and space configs that are compared as roles
+	roleKeys = []string{
+		"org-billingmanager", "org-manager", "org-auditor", "org-billingmanager-group", "org-manager-group", "org-auditor-group",
+		"space-manager", "space-developer", "space-auditor", "space-supporter",
+		"space-manager-group", "space-developer-group", "space-auditor-group", "space-supporter-group",
+	}
+)
+
+// Diff - the changes from the config read by from to that read by to: the
+// orgs, spaces, quotas, security groups and shared domains created, updated
+// and deleted, the users and groups assigned and unassigned roles, the service
+// plans made visible to orgs or hidden and the settings changed.  The configs
+// alone are compared, so they are the changes cf-mgmt would make to a
+// foundation that matched from.
+func Diff(from, to config.Reader) ([]changes.Change, error) {
+	d := &differ{from: from, to: to}
+	var err error
+	if d.fromGlobal, err = from.GetGlobalConfig(); err != nil {
+		return nil, err
+	}
+	if d.toGlobal, err = to.GetGlobalConfig(); err != nil {
+		return nil, err
+	}
+	for _, step := range []func() error{d.settings, d.sharedDomains, d.securityGroups, d.orgQuotas, d.orgs, d.spaces, d.serviceAccess} {
+		if err := step(); err != nil {
+			return nil, err
+		}
+	}
+	return d.changes, nil
+}
+
+type differ struct {
+	from, to             config.Reader
+	changes              []changes.Change
+	fromGlobal, toGlobal *config.GlobalConfig
+	// fromOrgs, toOrgs - the org configs by name
+	fromOrgs, toOrgs map[string]*config.OrgConfig
+}
+
+func (d *differ) record(change changes.Change) {
+	d.changes = append(d.changes, change)
+}
+
+// settings - the keys of cf-mgmt.yml, orgs.yml and each spaces.yml that aren't
+// compared on their own
+func (d *differ) settings() error {
+	d.updatedSettings("", d.fromGlobal, d.toGlobal, "shared-domains", "running-security-groups", "staging-security-groups", "service-access")
+	fromOrgs, err := d.from.Orgs()
+	if err != nil {
+		return err
+	}
+	toOrgs, err := d.to.Orgs()
+	if err != nil {
+		return err
+	}
+	d.updatedSettings("", fromOrgs, toOrgs, "orgs")
+	return nil
+}
+
+func (d *differ) updatedSettings(org string, from, to interface{}, skip ...string) {
+	before, after := changedFields(from, to, skip...)
+	for _, key := range mapKeys(after) {
+		d.record(changes.Change{Entity: changes.Setting, Action: changes.Update, Name: key, Org: org, Before: before[key], After: after[key]})
+	}
+}
+
+func (d *differ) sharedDomains() error {
+	for _, name := range mapKeys(d.fromGlobal.SharedDomains, d.toGlobal.SharedDomains) {
+		fromDomain, inFrom := d.fromGlobal.SharedDomains[name]
+		toDomain, inTo := d.toGlobal.SharedDomains[name]
+		switch {
+		case !inFrom:
+			d.record(changes.Change{Entity: changes.SharedDomain, Action: changes.Create, Name: name, After: setFields(toDomain)})
+		case !inTo:
+			d.record(changes.Change{Entity: changes.SharedDomain, Action: changes.Delete, Name: name, Before: setFields(fromDomain)})
+		default:
+			if before, after := changedFields(fromDomain, toDomain); len(after) > 0 {
+				d.record(changes.Change{Entity: changes.SharedDomain, Action: changes.Update, Name: name, Before: before, After: after})
+			}
+		}
+	}
+	return nil
+}
+
+// securityGroups - the security groups of asgs and default_asgs, their rules
+// compared as json, and those bound to running and staging apps
+func (d *differ) securityGroups() error {
+	fromGroups, err := securityGroupRules(d.from)
+	if err != nil {
+		return err
+	}
+	toGroups, err := securityGroupRules(d.to)
+	if err != nil {
+		return err
+	}
+	for _, name := range mapKeys(fromGroups, toGroups) {
+		fromRules, inFrom := fromGroups[name]
+		toRules, inTo := toGroups[name]
+		switch {
+		case !inFrom:
+			d.record(changes.Change{Entity: changes.SecurityGroup, Action: changes.Create, Name: name, After: rulesValue(toRules)})
+		case !inTo:
+			d.record(changes.Change{Entity: changes.SecurityGroup, Action: changes.Delete, Name: name, Before: rulesValue(fromRules)})
+		case !sameRules(fromRules, toRules):
+			d.record(changes.Change{Entity: changes.SecurityGroup, Action: changes.Update, Name: name, Before: rulesValue(fromRules), After: rulesValue(toRules)})
+		}
+	}
+	d.boundSecurityGroups(changes.Change{After: "running"}, d.fromGlobal.RunningSecurityGroups, d.toGlobal.RunningSecurityGroups)
+	d.boundSecurityGroups(changes.Change{After: "staging"}, d.fromGlobal.StagingSecurityGroups, d.toGlobal.StagingSecurityGroups)
+	return nil
+}
+
+// boundSecurityGroups - assigns the security groups in to that aren't in from,
+// and unassigns those in from that aren't in to, with the org, space and
+// binding of change
+func (d *differ) boundSecurityGroups(change changes.Change, from, to []string) {
+	change.Entity = changes.SecurityGroup
+	added, removed := listDiff(from, to)
+	for _, name := range added {
+		assign := change
+		assign.Action, assign.Name = changes.Assign, name
+		d.record(assign)
+	}
+	for _, name := range removed {
+		unassign := change
+		unassign.Action, unassign.Name, unassign.Before, unassign.After = changes.Unassign, name, change.After, nil
+		d.record(unassign)
+	}
+}
+
+func securityGroupRules(reader config.Reader) (map[string]string, error) {
+	rules := make(map[string]string)
+	asgs, err := reader.GetASGConfigs()
+	if err != nil {
+		return nil, err
+	}
+	defaultASGs, err := reader.GetDefaultASGConfigs()
+	if err != nil {
+		return nil, err
+	}
+	for _, asg := range append(asgs, defaultASGs...) {
+		rules[asg.Name] = asg.Rules
+	}
+	return rules, nil
+}
+
+func sameRules(from, to string) bool {
+	match, err := securitygroup.DoesJsonMatch(from, to)
+	if err != nil {
+		// not json, which cf-mgmt would fail on, so compared as written
+		return strings.TrimSpace(from) == strings.TrimSpace(to)
+	}
+	return match
+}
+
+// rulesValue - rules as json, so they are shown as such rather than as a string
+func rulesValue(rules string) interface{} {
+	var value interface{}
+	if err := json.Unmarshal([]byte(rules), &value); err != nil {
+		return rules
+	}
+	return value
+}
+
+func (d *differ) orgQuotas() error {
+	fromQuotas, err := d.from.GetOrgQuotas()
+	if err != nil {
+		return err
+	}
+	toQuotas, err := d.to.GetOrgQuotas()
+	if err != nil {
+		return err
+	}
+	fromByName, toByName := make(map[string]config.OrgQuota), make(map[string]config.OrgQuota)
+	for _, quota := range fromQuotas {
+		fromByName[quota.Name] = quota
+	}
+	for _, quota := range toQuotas {
+		toByName[quota.Name] = quota
+	}
+	for _, name := range mapKeys(fromByName, toByName) {
+		fromQuota, inFrom := fromByName[name]
+		toQuota, inTo := toByName[name]
+		d.quota(changes.Change{Entity: changes.OrgQuota, Name: name}, inFrom, fromQuota, inTo, toQuota)
+	}
+	return nil
+}
+
+// quota - creates, deletes or updates the quota of change, with its values or
+// those that changed
+func (d *differ) quota(change changes.Change, inFrom bool, from interface{}, inTo bool, to interface{}) {
+	switch {
+	case inFrom && inTo:
+		before, after := changedFields(from, to)
+		if len(after) == 0 {
+			return
+		}
+		change.Action, change.Before, change.After = changes.Update, before, after
+	case inTo:
+		change.Action, change.After = changes.Create, setFields(to)
+	case inFrom:
+		change.Action, change.Before = changes.Delete, setFields(from)
+	default:
+		return
+	}
+	d.record(change)
+}
+
+func (d *differ) orgs() error {
+	fromConfigs, err := d.from.GetOrgConfigs()
+	if err != nil {
+		return err
+	}
+	toConfigs, err := d.to.GetOrgConfigs()
+	if err != nil {
+		return err
+	}
+	d.fromOrgs, d.toOrgs = make(map[string]*config.OrgConfig), make(map[string]*config.OrgConfig)
+	for i := range fromConfigs {
+		d.fromOrgs[fromConfigs[i].Org] = &fromConfigs[i]
+	}
+	for i := range toConfigs {
+		d.toOrgs[toConfigs[i].Org] = &toConfigs[i]
+	}
+	for _, name := range mapKeys(d.fromOrgs, d.toOrgs) {
+		from, to := d.fromOrgs[name], d.toOrgs[name]
+		if to == nil {
+			d.record(changes.Change{Entity: changes.Org, Action: changes.Delete, Name: name})
+			continue
+		}
+		skip := append(append([]string{"org", "original-org", "named_quota", "enable-org-quota", "service-access"}, roleKeys...), quotaKeys(config.OrgQuota{})...)
+		if from == nil {
+			from = &config.OrgConfig{}
+			_, after := changedFields(from, to, skip...)
+			d.record(changes.Change{Entity: changes.Org, Action: changes.Create, Name: name, After: nilIfEmpty(after)})
+		} else if before, after := changedFields(from, to, skip...); len(after) > 0 {
+			d.record(changes.Change{Entity: changes.Org, Action: changes.Update, Name: name, Before: before, After: after})
+		}
+		for i, role := range orgRoles {
+			fromGroups := [][]string{from.GetBillingManagerGroups(), from.GetManagerGroups(), from.GetAuditorGroups()}[i]
+			toGroups := [][]string{to.GetBillingManagerGroups(), to.GetManagerGroups(), to.GetAuditorGroups()}[i]
+			fromRole := []config.UserMgmt{from.BillingManager, from.Manager, from.Auditor}[i]
+			toRole := []config.UserMgmt{to.BillingManager, to.Manager, to.Auditor}[i]
+			d.roles(changes.Change{Entity: changes.OrgRole, Org: name}, role, fromRole, fromGroups, toRole, toGroups)
+		}
+		change := changes.Change{Entity: changes.OrgQuota, Name: name, Org: name}
+		d.quota(change, from.EnableOrgQuota, from.GetQuota(), to.EnableOrgQuota, to.GetQuota())
+		d.namedQuota(change, from.NamedQuota, to.NamedQuota)
+	}
+	return nil
+}
+
+func (d *differ) spaces() error {
+	fromConfigs, err := d.from.GetSpaceConfigs()
+	if err != nil {
+		return err
+	}
+	toConfigs, err := d.to.GetSpaceConfigs()
+	if err != nil {
+		return err
+	}
+	fromSpaces, toSpaces := make(map[string]*config.SpaceConfig), make(map[string]*config.SpaceConfig)
+	for i := range fromConfigs {
+		fromSpaces[fromConfigs[i].Org+"/"+fromConfigs[i].Space] = &fromConfigs[i]
+	}
+	for i := range toConfigs {
+		toSpaces[toConfigs[i].Org+"/"+toConfigs[i].Space] = &toConfigs[i]
+	}
+	for _, key := range mapKeys(fromSpaces, toSpaces) {
+		orgName, spaceName := changes.SplitEntityName(key)
+		if d.toOrgs[orgName] == nil {
+			// deleted with its org
+			continue
+		}
+		from, to := fromSpaces[key], toSpaces[key]
+		if to == nil {
+			d.record(changes.Change{Entity: changes.Space, Action: changes.Delete, Name: spaceName, Org: orgName, Space: spaceName})
+			continue
+		}
+		skip := append(append([]string{"org", "space", "original-space", "named_quota", "enable-space-quota",
+			"enable-security-group", "security-group-contents", "named-security-groups"}, roleKeys...), quotaKeys(config.SpaceQuota{})...)
+		if from == nil {
+			from = &config.SpaceConfig{}
+			_, after := changedFields(from, to, skip...)
+			d.record(changes.Change{Entity: changes.Space, Action: changes.Create, Name: spaceName, Org: orgName, Space: spaceName, After: nilIfEmpty(after)})
+		} else if before, after := changedFields(from, to, skip...); len(after) > 0 {
+			d.record(changes.Change{Entity: changes.Space, Action: changes.Update, Name: spaceName, Org: orgName, Space: spaceName, Before: before, After: after})
+		}
+		for i, role := range spaceRoles {
+			fromGroups := [][]string{from.GetManagerGroups(), from.GetDeveloperGroups(), from.GetAuditorGroups(), from.GetSupporterGroups()}[i]
+			toGroups := [][]string{to.GetManagerGroups(), to.GetDeveloperGroups(), to.GetAuditorGroups(), to.GetSupporterGroups()}[i]
+			fromRole := []config.UserMgmt{from.Manager, from.Developer, from.Auditor, from.Supporter}[i]
+			toRole := []config.UserMgmt{to.Manager, to.Developer, to.Auditor, to.Supporter}[i]
+			d.roles(changes.Change{Entity: changes.SpaceRole, Org: orgName, Space: spaceName}, role, fromRole, fromGroups, toRole, toGroups)
+		}
+		change := changes.Change{Entity: changes.SpaceQuota, Name: spaceName, Org: orgName, Space: spaceName}
+		d.quota(change, from.EnableSpaceQuota, from.GetQuota(), to.EnableSpaceQuota, to.GetQuota())
+		d.namedQuota(change, from.NamedQuota, to.NamedQuota)
+
+		securityGroup := changes.Change{Entity: changes.SecurityGroup, Name: fmt.Sprintf("%s-%s", orgName, spaceName), Org: orgName, Space: spaceName}
+		switch {
+		case from.EnableSecurityGroup && to.EnableSecurityGroup:
+			if !sameRules(from.GetSecurityGroupContents(), to.GetSecurityGroupContents()) {
+				securityGroup.Action = changes.Update
+				securityGroup.Before, securityGroup.After = rulesValue(from.GetSecurityGroupContents()), rulesValue(to.GetSecurityGroupContents())
+				d.record(securityGroup)
+			}
+		case to.EnableSecurityGroup:
+			securityGroup.Action, securityGroup.After = changes.Create, rulesValue(to.GetSecurityGroupContents())
+			d.record(securityGroup)
+		case from.EnableSecurityGroup:
+			securityGroup.Action, securityGroup.Before = changes.Delete, rulesValue(from.GetSecurityGroupContents())
+			d.record(securityGroup)
+		}
+		d.boundSecurityGroups(changes.Change{Org: orgName, Space: spaceName}, from.ASGs, to.ASGs)
+	}
+	return d.spaceQuotasAndSettings()
+}
+
+// spaceQuotasAndSettings - the space quotas and spaces.yml of each org that
+// isn't deleted
+func (d *differ) spaceQuotasAndSettings() error {
+	for _, orgName := range mapKeys(d.toOrgs) {
+		var fromQuotas []config.SpaceQuota
+		fromSpaces := &config.Spaces{}
+		if d.fromOrgs[orgName] != nil {
+			var err error
+			if fromQuotas, err = d.from.GetSpaceQuotas(orgName); err != nil {
+				return err
+			}
+			if fromSpaces, err = d.from.OrgSpaces(orgName); err != nil {
+				return err
+			}
+		}
+		toQuotas, err := d.to.GetSpaceQuotas(orgName)
+		if err != nil {
+			return err
+		}
+		fromByName, toByName := make(map[string]config.SpaceQuota), make(map[string]config.SpaceQuota)
+		for _, quota := range fromQuotas {
+			fromByName[quota.Name] = quota
+		}
+		for _, quota := range toQuotas {
+			toByName[quota.Name] = quota
+		}
+		for _, name := range mapKeys(fromByName, toByName) {
+			fromQuota, inFrom := fromByName[name]
+			toQuota, inTo := toByName[name]
+			d.quota(changes.Change{Entity: changes.SpaceQuota, Name: name, Org: orgName}, inFrom, fromQuota, inTo, toQuota)
+		}
+		toSpaces, err := d.to.OrgSpaces(orgName)
+		if err != nil {
+			return err
+		}
+		d.updatedSettings(orgName, fromSpaces, toSpaces, "org", "spaces")
+	}
+	return nil
+}
+
+// roles - assigns the users and groups of the role in to that aren't in from
+// and unassigns those in from that aren't in to, each with its kind.  Names are
+// compared without regard to case as cf-mgmt matches them that way when it
+// applies them
+func (d *differ) roles(change changes.Change, role string, from config.UserMgmt, fromGroups []string, to config.UserMgmt, toGroups []string) {
+	lists := []struct {
+		kind     string
+		from, to []string
+	}{
+		{"ldap user", from.LDAPUsers, to.LDAPUsers},
+		{"user", from.Users, to.Users},
+		{"saml user", from.SamlUsers, to.SamlUsers},
+		{"ldap group", fromGroups, toGroups},
+	}
+	for _, list := range lists {
+		added, removed := listDiffFold(list.from, list.to)
+		for _, name := range added {
+			assign := change
+			assign.Action, assign.Name, assign.After = changes.Assign, name, fmt.Sprintf("%s %s", role, list.kind)
+			d.record(assign)
+		}
+		for _, name := range removed {
+			unassign := change
+			unassign.Action, unassign.Name, unassign.Before = changes.Unassign, name, fmt.Sprintf("%s %s", role, list.kind)
+			d.record(unassign)
+		}
+	}
+}
+
+// namedQuota - assigns the named quota of to or, when it has none, unassigns
+// that of from
+func (d *differ) namedQuota(change changes.Change, from, to string) {
+	if from == to {
+		return
+	}
+	if to != "" {
+		change.Action, change.Name, change.After = changes.Assign, to, to
+	} else {
+		change.Action, change.Name, change.Before = changes.Unassign, from, from
+	}
+	d.record(change)
+}
+
+// serviceAccess - the access to each plan of service-access in cf-mgmt.yml, a
+// plan that isn't listed being public
+func (d *differ) serviceAccess() error {
+	fromPlans, toPlans := planAccess(d.fromGlobal), planAccess(d.toGlobal)
+	for _, name := range mapKeys(fromPlans, toPlans) {
+		from, to := fromPlans[name], toPlans[name]
+		if from.access == "" {
+			from.access = "public"
+		}
+		if to.access == "" {
+			to.access = "public"
+		}
+		if from.access != to.access {
+			d.record(changes.Change{Entity: changes.ServiceAccess, Action: changes.Update, Name: name, Before: from.access, After: to.access})
+		}
+		added, removed := listDiff(from.orgs, to.orgs)
+		for _, org := range added {
+			d.record(changes.Change{Entity: changes.ServiceAccess, Action: changes.Assign, Name: name, Org: org})
+		}
+		for _, org := range removed {
+			d.record(changes.Change{Entity: changes.ServiceAccess, Action: changes.Unassign, Name: name, Org: org})
+		}
+	}
+	return nil
+}
+
+type access struct {
+	// access - public, private or limited
+	access string
+	// orgs - the orgs of a limited plan
+	orgs []string
+}
+
+// planAccess - the access to each plan, by broker/service/plan
+func planAccess(globalConfig *config.GlobalConfig) map[string]access {
+	plans := make(map[string]access)
+	for _, broker := range globalConfig.ServiceAccess {
+		for _, service := range broker.Services {
+			name := func(plan string) string {
+				return fmt.Sprintf("%s/%s/%s", broker.Name, service.Name, plan)
+			}
+			for _, plan := range service.AllAccessPlans {
+				plans[name(plan)] = access{access: "public"}
+			}
+			for _, plan := range service.NoAccessPlans {
+				plans[name(plan)] = access{access: "private"}
+			}
+			for _, plan := range service.LimitedAccessPlans {
+				plans[name(plan.Name)] = access{access: "limited", orgs: plan.Orgs}
+			}
+		}
+	}
+	return plans
+}
+
+// fields - the values of the yaml keys of the struct v
+func fields(v interface{}) map[string]interface{} {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	result := make(map[string]interface{})
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		key := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if field.PkgPath != "" || key == "-" {
+			continue
+		}
+		if key == "" {
+			key = strings.ToLower(field.Name)
+		}
+		result[key] = plainValue(value.Field(i))
+	}
+	return result
+}
+
+// plainValue - value with the structs it points to or is as the values of
+// their yaml keys, so they are shown with those keys
+func plainValue(value reflect.Value) interface{} {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return nil
+		}
+		return plainValue(value.Elem())
+	case reflect.Struct:
+		return fields(value.Interface())
+	}
+	return value.Interface()
+}
+
+// setFields - the values of the yaml keys of the struct v that aren't empty
+func setFields(v interface{}) map[string]interface{} {
+	result := fields(v)
+	for key, value := range result {
+		if isEmpty(value) {
+			delete(result, key)
+		}
+	}
+	return result
+}
+
+// quotaKeys - the yaml keys of the quota type
+func quotaKeys(quota interface{}) []string {
+	return mapKeys(fields(quota))
+}
+
+// changedFields - the values in from and to of the yaml keys of the structs
+// that differ, other than skip.  Empty values are the same.
+func changedFields(from, to interface{}, skip ...string) (map[string]interface{}, map[string]interface{}) {
+	fromFields, toFields := fields(from), fields(to)
+	before, after := make(map[string]interface{}), make(map[string]interface{})
+	for key, toValue := range toFields {
+		if contains(skip, key) || sameValue(fromFields[key], toValue) {
+			continue
+		}
+		before[key], after[key] = fromFields[key], toValue
+	}
+	return before, after
+}
+
+func sameValue(a, b interface{}) bool {
+	if isEmpty(a) && isEmpty(b) {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func isEmpty(v interface{}) bool {
+	value := reflect.ValueOf(v)
+	if !value.IsValid() {
+		return true
+	}
+	switch value.Kind() {
+	case reflect.Slice:
+		return value.Len() == 0
+	case reflect.Map:
+		if values, ok := v.(map[string]interface{}); ok {
+			// the fields of a struct
+			for _, fieldValue := range values {
+				if !isEmpty(fieldValue) {
+					return false
+				}
+			}
+			return true
+		}
+		return value.Len() == 0
+	}
+	return value.IsZero()
+}
+
+func nilIfEmpty(values map[string]interface{}) interface{} {
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+// listDiff - the values of to that aren't in from and those of from that
+// aren't in to
+func listDiff(from, to []string) ([]string, []string) {
+	return diffLists(from, to, contains)
+}
+
+// listDiffFold - listDiff with values that differ only in case being the same
+func listDiffFold(from, to []string) ([]string, []string) {
+	return diffLists(from, to, containsFold)
+}
+
+func diffLists(from, to []string, contains func(list []string, value string) bool) ([]string, []string) {
+	var added, removed []string
+	for _, value := range to {
+		if !contains(from, value) && !contains(added, value) {
+			added = append(added, value)
+		}
+	}
+	for _, value := range from {
+		if !contains(to, value) && !contains(removed, value) {
+			removed = append(removed, value)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// mapKeys - the keys of the maps, sorted
+func mapKeys(maps ...interface{}) []string {
+	union := make(map[string]bool)
+	for _, m := range maps {
+		for _, key := range reflect.ValueOf(m).MapKeys() {
+			union[key.String()] = true
+		}
+	}
+	keys := make([]string, 0, len(union))
+	for key := range union {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
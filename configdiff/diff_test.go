@@ -0,0 +1,128 @@
+package configdiff_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
+	"github.com/vmwarepivotallabs/cf-mgmt/config"
+	"github.com/vmwarepivotallabs/cf-mgmt/configdiff"
+)
+
+var _ = Describe("Diff", func() {
+	diff := func(from, to string) []changes.Change {
+		differences, err := configdiff.Diff(config.NewManager(from), config.NewManager(to))
+		Expect(err).ShouldNot(HaveOccurred())
+		return differences
+	}
+
+	It("finds nothing between a config and itself", func() {
+		Expect(diff("./fixtures/from", "./fixtures/from")).Should(BeEmpty())
+	})
+
+	It("creates and deletes orgs and spaces", func() {
+		differences := diff("./fixtures/from", "./fixtures/to")
+		Expect(differences).Should(ContainElements(
+			changes.Change{Entity: changes.Org, Action: changes.Delete, Name: "org2"},
+			changes.Change{Entity: changes.Org, Action: changes.Create, Name: "org3"},
+			changes.Change{Entity: changes.Space, Action: changes.Create, Name: "test", Org: "org1", Space: "test"},
+		))
+	})
+
+	It("assigns and unassigns users and groups by role", func() {
+		differences := diff("./fixtures/from", "./fixtures/to")
+		Expect(differences).Should(ContainElements(
+			changes.Change{Entity: changes.OrgRole, Action: changes.Assign, Name: "dave", Org: "org1", After: "org-manager ldap user"},
+			changes.Change{Entity: changes.OrgRole, Action: changes.Unassign, Name: "alice", Org: "org1", Before: "org-manager ldap user"},
+			changes.Change{Entity: changes.OrgRole, Action: changes.Assign, Name: "carol", Org: "org3", After: "org-auditor user"},
+			changes.Change{Entity: changes.SpaceRole, Action: changes.Assign, Name: "developers", Org: "org1", Space: "dev", After: "space-developer ldap group"},
+		))
+		Expect(differences).ShouldNot(ContainElement(HaveField("Name", WithTransform(strings.ToLower, Equal("org1-managers")))))
+	})
+
+	It("updates quotas with the values that changed", func() {
+		differences := diff("./fixtures/from", "./fixtures/to")
+		Expect(differences).Should(ContainElements(
+			changes.Change{Entity: changes.OrgQuota, Action: changes.Update, Name: "org1", Org: "org1",
+				Before: map[string]interface{}{"memory-limit": "10G"}, After: map[string]interface{}{"memory-limit": "20G"}},
+			changes.Change{Entity: changes.SpaceQuota, Action: changes.Update, Name: "small", Org: "org1",
+				Before: map[string]interface{}{"memory-limit": "1G"}, After: map[string]interface{}{"memory-limit": "2G"}},
+			changes.Change{Entity: changes.OrgQuota, Action: changes.Assign, Name: "large", Org: "org3", After: "large"},
+		))
+	})
+
+	It("compares the rules of security groups as json", func() {
+		differences := diff("./fixtures/from", "./fixtures/to")
+		Expect(differences).ShouldNot(ContainElement(And(HaveField("Name", "all-access"), HaveField("Action", changes.Update))))
+		Expect(differences).Should(ContainElements(
+			HaveField("String()", "create security-group dns"),
+			changes.Change{Entity: changes.SecurityGroup, Action: changes.Assign, Name: "dns", After: "running"},
+			changes.Change{Entity: changes.SecurityGroup, Action: changes.Unassign, Name: "all-access", Org: "org1", Space: "dev"},
+			HaveField("String()", "create security-group org1-test in org1/test"),
+		))
+	})
+
+	It("assigns and unassigns service plans", func() {
+		differences := diff("./fixtures/from", "./fixtures/to")
+		Expect(differences).Should(ContainElements(
+			changes.Change{Entity: changes.ServiceAccess, Action: changes.Assign, Name: "p-mysql/p-mysql/large", Org: "org3"},
+			changes.Change{Entity: changes.ServiceAccess, Action: changes.Unassign, Name: "p-mysql/p-mysql/large", Org: "org1"},
+			changes.Change{Entity: changes.ServiceAccess, Action: changes.Update, Name: "p-mysql/p-mysql/xlarge", Before: "public", After: "private"},
+		))
+	})
+
+	It("updates settings", func() {
+		differences := diff("./fixtures/from", "./fixtures/to")
+		Expect(differences).Should(ContainElements(
+			changes.Change{Entity: changes.Setting, Action: changes.Update, Name: "enable-delete-isolation-segments", Before: false, After: true},
+			changes.Change{Entity: changes.Setting, Action: changes.Update, Name: "enable-delete-spaces", Org: "org1", Before: false, After: true},
+			changes.Change{Entity: changes.Space, Action: changes.Update, Name: "dev", Org: "org1", Space: "dev",
+				Before: map[string]interface{}{"allow-ssh": true}, After: map[string]interface{}{"allow-ssh": false}},
+		))
+	})
+
+	Context("at a git revision", func() {
+		var repository string
+
+		git := func(args ...string) {
+			output, err := exec.Command("git", append([]string{"-C", repository, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...).CombinedOutput()
+			Expect(err).ShouldNot(HaveOccurred(), string(output))
+		}
+
+		BeforeEach(func() {
+			repository = GinkgoT().TempDir()
+			Expect(os.CopyFS(filepath.Join(repository, "config"), os.DirFS("./fixtures/from"))).Should(Succeed())
+			git("init", "-q")
+			git("add", ".")
+			git("commit", "-q", "-m", "from")
+			Expect(os.RemoveAll(filepath.Join(repository, "config"))).Should(Succeed())
+			Expect(os.CopyFS(filepath.Join(repository, "config"), os.DirFS("./fixtures/to"))).Should(Succeed())
+		})
+
+		It("compares the config at the revision with the config on disk", func() {
+			layers := config.Layers{Base: filepath.Join(repository, "config")}
+			atRef, cleanup, err := configdiff.LayersAt(layers, "HEAD")
+			Expect(err).ShouldNot(HaveOccurred())
+			defer cleanup()
+			differences, err := configdiff.Diff(config.NewLayeredManager(atRef), config.NewLayeredManager(layers))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(differences).Should(Equal(diff("./fixtures/from", "./fixtures/to")))
+		})
+
+		It("fails on an unknown revision", func() {
+			_, _, err := configdiff.LayersAt(config.Layers{Base: filepath.Join(repository, "config")}, "no-such-ref")
+			Expect(err).Should(MatchError(ContainSubstring("git archive --format=tar --end-of-options no-such-ref")))
+		})
+
+		It("doesn't read a revision as an option", func() {
+			output := filepath.Join(GinkgoT().TempDir(), "config.tar")
+			_, _, err := configdiff.LayersAt(config.Layers{Base: filepath.Join(repository, "config")}, "--output="+output)
+			Expect(err).Should(HaveOccurred())
+			Expect(output).ShouldNot(BeAnExistingFile())
+		})
+	})
+})
@@ -0,0 +1,121 @@
+package configdiff
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vmwarepivotallabs/cf-mgmt/config"
+)
+
+// LayersAt - layers as they are at ref of the local git repository of their
+// base directory, extracted to a temporary directory that cleanup removes
+func LayersAt(layers config.Layers, ref string) (config.Layers, func(), error) {
+	repository, err := git(layers.Base, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return config.Layers{}, nil, err
+	}
+	repository = strings.TrimSpace(repository)
+	// a ref starting with - would otherwise be read as an option of git archive
+	archive, err := git(repository, "archive", "--format=tar", "--end-of-options", ref)
+	if err != nil {
+		return config.Layers{}, nil, err
+	}
+	dir, err := os.MkdirTemp("", "cf-mgmt-diff")
+	if err != nil {
+		return config.Layers{}, nil, err
+	}
+	cleanup := func() {
+		os.RemoveAll(dir)
+	}
+	if err = extract(archive, dir); err != nil {
+		cleanup()
+		return config.Layers{}, nil, errors.Wrapf(err, "Error extracting %s of %s", ref, repository)
+	}
+	// the layers at ref, and the variables, are read as they are
+	atRef := layers
+	atRef.Write = ""
+	if atRef.Base, err = inCopy(repository, dir, layers.Base); err != nil {
+		cleanup()
+		return config.Layers{}, nil, err
+	}
+	atRef.Overlays = nil
+	for _, overlay := range layers.Overlays {
+		overlayAtRef, err := inCopy(repository, dir, overlay)
+		if err != nil {
+			cleanup()
+			return config.Layers{}, nil, err
+		}
+		atRef.Overlays = append(atRef.Overlays, overlayAtRef)
+	}
+	return atRef, cleanup, nil
+}
+
+func git(dir string, args ...string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s in %s failed: %s", strings.Join(args, " "), dir, strings.TrimSpace(stderr.String()))
+	}
+	return string(output), nil
+}
+
+// inCopy - the path in copyDir of path in repository
+func inCopy(repository, copyDir, path string) (string, error) {
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	// the repository is reported with symlinks resolved
+	if resolved, err := filepath.EvalSymlinks(absolutePath); err == nil {
+		absolutePath = resolved
+	}
+	relativePath, err := filepath.Rel(repository, absolutePath)
+	if err != nil || relativePath == ".." || strings.HasPrefix(relativePath, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s isn't in the git repository %s", path, repository)
+	}
+	return filepath.Join(copyDir, relativePath), nil
+}
+
+// extract - writes the directories and files of the tar archive to dir
+func extract(archive string, dir string) error {
+	reader := tar.NewReader(strings.NewReader(archive))
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if target != dir && !strings.HasPrefix(target, dir+string(filepath.Separator)) {
+			return fmt.Errorf("%s is outside of the archive", header.Name)
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			data, err := io.ReadAll(reader)
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(target, data, 0644); err != nil {
+				return err
+			}
+		}
+	}
+}
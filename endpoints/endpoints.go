@@ -0,0 +1,126 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/xchapter7x/lo"
+)
+
+// Endpoints - the urls of the apis cf-mgmt talks to
+type Endpoints struct {
+	API     string
+	UAA     string
+	Login   string
+	Routing string
+}
+
+// FromSystemDomain - the endpoints of a foundation that follows the api., uaa.
+// and login. naming convention
+func FromSystemDomain(systemDomain string) *Endpoints {
+	api := fmt.Sprintf("https://api.%s", systemDomain)
+	return &Endpoints{
+		API:     api,
+		UAA:     fmt.Sprintf("https://uaa.%s", systemDomain),
+		Login:   fmt.Sprintf("https://login.%s", systemDomain),
+		Routing: api,
+	}
+}
+
+type rootLinks struct {
+	Links map[string]*struct {
+		Href string `json:"href"`
+	} `json:"links"`
+}
+
+type v2Info struct {
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	RoutingEndpoint       string `json:"routing_endpoint"`
+}
+
+// Discover - reads the uaa, login and routing endpoints from the links of the
+// cloud controller root, or from /v2/info on cloud controllers without them.
+// Endpoints that can't be discovered are taken from the system domain, and
+// the api url defaults to https://api.<system domain>.
+func Discover(httpClient *http.Client, apiURL, systemDomain string) (*Endpoints, error) {
+	if apiURL == "" && systemDomain == "" {
+		return nil, fmt.Errorf("must set api-url or system-domain")
+	}
+	var result *Endpoints
+	if systemDomain != "" {
+		result = FromSystemDomain(systemDomain)
+	} else {
+		result = &Endpoints{}
+	}
+	if apiURL != "" {
+		result.API = strings.TrimRight(apiURL, "/")
+		result.Routing = result.API
+	}
+
+	discovered := &Endpoints{}
+	var root rootLinks
+	rootErr := getJSON(httpClient, result.API+"/", &root)
+	if rootErr == nil {
+		discovered.UAA = root.href("uaa")
+		discovered.Login = root.href("login")
+		discovered.Routing = root.href("routing")
+	}
+	if discovered.UAA == "" || discovered.Login == "" || discovered.Routing == "" {
+		var info v2Info
+		if err := getJSON(httpClient, result.API+"/v2/info", &info); err == nil {
+			discovered.UAA = firstOf(discovered.UAA, info.TokenEndpoint)
+			discovered.Login = firstOf(discovered.Login, info.AuthorizationEndpoint)
+			discovered.Routing = firstOf(discovered.Routing, info.RoutingEndpoint)
+		} else if rootErr != nil {
+			lo.G.Debugf("unable to discover endpoints from %s: %v, %v", result.API, rootErr, err)
+		}
+	}
+
+	result.UAA = firstOf(strings.TrimRight(discovered.UAA, "/"), result.UAA)
+	result.Login = firstOf(strings.TrimRight(discovered.Login, "/"), result.Login)
+	if discovered.Routing != "" {
+		// the routing api client adds the /routing prefix to every path itself
+		result.Routing = strings.TrimSuffix(strings.TrimRight(discovered.Routing, "/"), "/routing")
+	}
+	if result.UAA == "" {
+		return nil, fmt.Errorf("unable to discover the uaa endpoint from %s, set system-domain to use https://uaa.<system-domain>", result.API)
+	}
+	if result.Login == "" {
+		result.Login = result.UAA
+	}
+	lo.G.Debugf("Using api %s, uaa %s, login %s and routing api %s", result.API, result.UAA, result.Login, result.Routing)
+	return result, nil
+}
+
+func (r rootLinks) href(name string) string {
+	if link, ok := r.Links[name]; ok && link != nil {
+		return link.Href
+	}
+	return ""
+}
+
+func getJSON(httpClient *http.Client, url string, result interface{}) error {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		return fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func firstOf(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,76 @@
+package endpoints_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vmwarepivotallabs/cf-mgmt/endpoints"
+)
+
+var _ = Describe("Discover", func() {
+	var (
+		server    *httptest.Server
+		responses map[string]string
+	)
+	BeforeEach(func() {
+		responses = map[string]string{}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			response, ok := responses[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(response))
+		}))
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("uses the links of the cloud controller root", func() {
+		responses["/"] = `{"links":{
+			"uaa":{"href":"https://uaa.internal.example.com"},
+			"login":{"href":"https://login.example.com/"},
+			"routing":{"href":"https://routing.example.com/routing"}}}`
+		result, err := endpoints.Discover(server.Client(), server.URL+"/", "")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result).Should(Equal(&endpoints.Endpoints{
+			API:     server.URL,
+			UAA:     "https://uaa.internal.example.com",
+			Login:   "https://login.example.com",
+			Routing: "https://routing.example.com",
+		}))
+	})
+
+	It("falls back to /v2/info", func() {
+		responses["/v2/info"] = `{"authorization_endpoint":"https://login.example.com","token_endpoint":"https://uaa.example.com","routing_endpoint":"https://api.example.com/routing"}`
+		result, err := endpoints.Discover(server.Client(), server.URL, "")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result.UAA).Should(Equal("https://uaa.example.com"))
+		Expect(result.Login).Should(Equal("https://login.example.com"))
+		Expect(result.Routing).Should(Equal("https://api.example.com"))
+	})
+
+	It("falls back to the system domain", func() {
+		result, err := endpoints.Discover(server.Client(), server.URL, "sys.example.com")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result).Should(Equal(&endpoints.Endpoints{
+			API:     server.URL,
+			UAA:     "https://uaa.sys.example.com",
+			Login:   "https://login.sys.example.com",
+			Routing: server.URL,
+		}))
+	})
+
+	It("errors when uaa can't be found", func() {
+		_, err := endpoints.Discover(server.Client(), server.URL, "")
+		Expect(err).Should(MatchError(ContainSubstring("unable to discover the uaa endpoint")))
+	})
+
+	It("requires the api url or system domain", func() {
+		_, err := endpoints.Discover(server.Client(), "", "")
+		Expect(err).Should(MatchError("must set api-url or system-domain"))
+	})
+})
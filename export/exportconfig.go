@@ -1,7 +1,9 @@
 package export
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"strings"
 
 	"code.cloudfoundry.org/routing-api/models"
@@ -39,6 +41,7 @@ func NewExportManager(
 	quotaMgr *quota.Manager,
 	roleMgr role.Manager) *Manager {
 	return &Manager{
+		ConfigLayers:         config.Layers{Base: configDir},
 		ConfigMgr:            config.NewManager(configDir),
 		UAAMgr:               uaaMgr,
 		SpaceManager:         spaceManager,
@@ -55,6 +58,8 @@ func NewExportManager(
 }
 
 type Manager struct {
+	// ConfigLayers - the config MergeConfig merges into, read with its overlays and variables
+	ConfigLayers         config.Layers
 	ConfigMgr            config.Manager
 	UAAMgr               uaa.Manager
 	SpaceManager         space.Manager
@@ -69,9 +74,11 @@ type Manager struct {
 	QuotaManager         *quota.Manager
 	SkipSpaces           bool
 	SkipRoutingGroups    bool
+	// FactorOrgDefaults - moves the values every org has in common to orgDefaults.yml
+	FactorOrgDefaults bool
 }
 
-func (im *Manager) ExportServiceAccess() error {
+func (im *Manager) ExportServiceAccess(ctx context.Context) error {
 
 	orgConfigs, err := im.ConfigMgr.GetOrgConfigs()
 	if err != nil {
@@ -92,12 +99,12 @@ func (im *Manager) ExportServiceAccess() error {
 	if err != nil {
 		return err
 	}
-	orgs, err := im.OrgReader.ListOrgs()
+	orgs, err := im.OrgReader.ListOrgs(ctx)
 	if err != nil {
 		lo.G.Errorf("Unable to retrieve orgs. Error : %s", err)
 		return err
 	}
-	err = im.exportServiceAccess(globalConfig, orgs)
+	err = im.exportServiceAccess(ctx, globalConfig, orgs)
 	if err != nil {
 		return err
 	}
@@ -112,34 +119,34 @@ func (im *Manager) ExportServiceAccess() error {
 
 // ExportConfig Imports org and space configuration from an existing CF instance
 // Entries part of excludedOrgs and excludedSpaces are not included in the import
-func (im *Manager) ExportConfig(excludedOrgs, excludedSpaces map[string]string, skipSpaces, useMetadataPrefix bool) error {
+func (im *Manager) ExportConfig(ctx context.Context, excludedOrgs, excludedSpaces map[string]string, skipSpaces, useMetadataPrefix bool) error {
 	//Get all the users from the foundation
-	uaaUsers, err := im.UAAMgr.ListUsers()
+	uaaUsers, err := im.UAAMgr.ListUsers(ctx)
 	if err != nil {
 		lo.G.Error("Unable to retrieve users")
 		return err
 	}
 	lo.G.Debugf("uaa user id map %v", uaaUsers)
 	//Get all the orgs
-	orgs, err := im.OrgReader.ListOrgs()
+	orgs, err := im.OrgReader.ListOrgs(ctx)
 	if err != nil {
 		lo.G.Errorf("Unable to retrieve orgs. Error : %s", err)
 		return err
 	}
 
-	securityGroups, err := im.SecurityGroupManager.ListNonDefaultSecurityGroups()
+	securityGroups, err := im.SecurityGroupManager.ListNonDefaultSecurityGroups(ctx)
 	if err != nil {
 		lo.G.Errorf("Unable to retrieve security groups. Error : %s", err)
 		return err
 	}
 
-	defaultSecurityGroups, err := im.SecurityGroupManager.ListDefaultSecurityGroups()
+	defaultSecurityGroups, err := im.SecurityGroupManager.ListDefaultSecurityGroups(ctx)
 	if err != nil {
 		lo.G.Errorf("Unable to retrieve security groups. Error : %s", err)
 		return err
 	}
 
-	isolationSegments, err := im.IsoSegmentManager.ListIsolationSegments()
+	isolationSegments, err := im.IsoSegmentManager.ListIsolationSegments(ctx)
 	if err != nil {
 		lo.G.Errorf("Unable to retrieve isolation segments. Error : %s", err)
 		return err
@@ -176,7 +183,7 @@ func (im *Manager) ExportConfig(excludedOrgs, excludedSpaces map[string]string,
 	globalConfig.EnableServiceAccess = true
 	globalConfig.UseMetadataPrefix = useMetadataPrefix
 
-	err = im.exportServiceAccess(globalConfig, orgs)
+	err = im.exportServiceAccess(ctx, globalConfig, orgs)
 	if err != nil {
 		return err
 	}
@@ -191,13 +198,13 @@ func (im *Manager) ExportConfig(excludedOrgs, excludedSpaces map[string]string,
 		lo.G.Infof("Processing org: %s ", orgName)
 		orgConfig := &config.OrgConfig{Org: orgName}
 		//Add users
-		err = im.addOrgUsers(orgConfig, org.GUID)
+		err = im.addOrgUsers(ctx, orgConfig, org.GUID)
 		if err != nil {
 			return err
 		}
 		//Add Quota definition if applicable
 		if org.Relationships.Quota.Data != nil {
-			orgQuota, err := im.QuotaManager.GetOrgQuota(org.Relationships.Quota.Data.GUID)
+			orgQuota, err := im.QuotaManager.GetOrgQuota(ctx, org.Relationships.Quota.Data.GUID)
 			if err != nil {
 				return err
 			}
@@ -206,7 +213,7 @@ func (im *Manager) ExportConfig(excludedOrgs, excludedSpaces map[string]string,
 				orgConfig.NamedQuota = orgQuota.Name
 			}
 		}
-		orgIsolationSegmentGUID, err := im.OrgReader.GetDefaultIsolationSegment(org)
+		orgIsolationSegmentGUID, err := im.OrgReader.GetDefaultIsolationSegment(ctx, org)
 		if err != nil {
 			return err
 		}
@@ -218,7 +225,7 @@ func (im *Manager) ExportConfig(excludedOrgs, excludedSpaces map[string]string,
 			}
 		}
 
-		privatedomains, err := im.PrivateDomainManager.ListOrgSharedPrivateDomains(org.GUID)
+		privatedomains, err := im.PrivateDomainManager.ListOrgSharedPrivateDomains(ctx, org.GUID)
 		if err != nil {
 			return err
 		}
@@ -226,7 +233,7 @@ func (im *Manager) ExportConfig(excludedOrgs, excludedSpaces map[string]string,
 			orgConfig.SharedPrivateDomains = append(orgConfig.SharedPrivateDomains, privatedomain)
 		}
 
-		privatedomains, err = im.PrivateDomainManager.ListOrgOwnedPrivateDomains(org.GUID)
+		privatedomains, err = im.PrivateDomainManager.ListOrgOwnedPrivateDomains(ctx, org.GUID)
 		if err != nil {
 			return err
 		}
@@ -267,7 +274,7 @@ func (im *Manager) ExportConfig(excludedOrgs, excludedSpaces map[string]string,
 		}
 		lo.G.Infof("Done creating org %s", orgConfig.Org)
 		if !skipSpaces {
-			err := im.processSpaces(globalConfig, orgConfig, org.GUID, excludedSpaces, isolationSegments, securityGroups)
+			err := im.processSpaces(ctx, globalConfig, orgConfig, org.GUID, excludedSpaces, isolationSegments, securityGroups)
 			if err != nil {
 				return errors.Wrapf(err, "Processing org %s", orgConfig.Org)
 			}
@@ -276,7 +283,7 @@ func (im *Manager) ExportConfig(excludedOrgs, excludedSpaces map[string]string,
 
 	for sgName, sgInfo := range securityGroups {
 		lo.G.Infof("Adding security group %s", sgName)
-		if rules, err := im.SecurityGroupManager.GetSecurityGroupRules(sgInfo.GUID); err == nil {
+		if rules, err := im.SecurityGroupManager.GetSecurityGroupRules(ctx, sgInfo.GUID); err == nil {
 			lo.G.Infof("Adding rules for %s", sgName)
 			im.ConfigMgr.AddSecurityGroup(sgName, rules)
 		} else {
@@ -292,7 +299,7 @@ func (im *Manager) ExportConfig(excludedOrgs, excludedSpaces map[string]string,
 		if sgInfo.GloballyEnabled.Staging {
 			globalConfig.StagingSecurityGroups = append(globalConfig.StagingSecurityGroups, sgName)
 		}
-		if rules, err := im.SecurityGroupManager.GetSecurityGroupRules(sgInfo.GUID); err == nil {
+		if rules, err := im.SecurityGroupManager.GetSecurityGroupRules(ctx, sgInfo.GUID); err == nil {
 			lo.G.Infof("Adding rules for %s", sgName)
 			im.ConfigMgr.AddDefaultSecurityGroup(sgName, rules)
 		} else {
@@ -300,7 +307,7 @@ func (im *Manager) ExportConfig(excludedOrgs, excludedSpaces map[string]string,
 		}
 	}
 
-	orgQuotas, err := im.QuotaManager.ListAllOrgQuotas()
+	orgQuotas, err := im.QuotaManager.ListAllOrgQuotas(ctx)
 	if err != nil {
 		return err
 	}
@@ -356,15 +363,71 @@ func (im *Manager) ExportConfig(excludedOrgs, excludedSpaces map[string]string,
 		}
 		globalConfig.SharedDomains[sharedDomain.Name] = sharedDomainConfig
 	}
-	return im.ConfigMgr.SaveGlobalConfig(globalConfig)
+	if err = im.ConfigMgr.SaveGlobalConfig(globalConfig); err != nil {
+		return err
+	}
+	if im.FactorOrgDefaults {
+		return im.factorOrgDefaults()
+	}
+	return nil
+}
+
+// MergeConfig - exports the foundation as ExportConfig does, but to a temporary
+// directory that is then merged into the existing config, see config.Merge,
+// and returns what the merge changed
+func (im *Manager) MergeConfig(ctx context.Context, excludedOrgs, excludedSpaces map[string]string, skipSpaces, useMetadataPrefix bool) ([]string, error) {
+	exportDir, err := os.MkdirTemp("", "cf-mgmt-export")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(exportDir)
+	configMgr, factorOrgDefaults := im.ConfigMgr, im.FactorOrgDefaults
+	im.ConfigMgr, im.FactorOrgDefaults = config.NewManager(exportDir), false
+	err = im.ExportConfig(ctx, excludedOrgs, excludedSpaces, skipSpaces, useMetadataPrefix)
+	im.ConfigMgr, im.FactorOrgDefaults = configMgr, factorOrgDefaults
+	if err != nil {
+		return nil, err
+	}
+	lo.G.Infof("Merging the export into %s", im.ConfigLayers.Base)
+	return config.Merge(im.ConfigLayers, exportDir)
+}
+
+// factorOrgDefaults - saves the values the exported orgs have in common to
+// orgDefaults.yml and takes them out of each orgConfig.yml
+func (im *Manager) factorOrgDefaults() error {
+	orgConfigs, err := im.ConfigMgr.GetOrgConfigs()
+	if err != nil {
+		return err
+	}
+	if len(orgConfigs) < 2 {
+		return nil
+	}
+	orgDefaults, err := config.CommonOrgConfig(orgConfigs)
+	if err != nil {
+		return err
+	}
+	if orgDefaults == nil {
+		lo.G.Info("The orgs have no values in common to move to orgDefaults.yml")
+		return nil
+	}
+	lo.G.Info("Moving the values the orgs have in common to orgDefaults.yml")
+	if err = im.ConfigMgr.SaveOrgDefaults(orgDefaults); err != nil {
+		return err
+	}
+	for i := range orgConfigs {
+		if err = im.ConfigMgr.SaveOrgConfig(&orgConfigs[i]); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (im *Manager) processSpaces(globalConfig *config.GlobalConfig, orgConfig *config.OrgConfig, orgGUID string, excludedSpaces map[string]string, isolationSegments []cfclient.IsolationSegment, securityGroups map[string]*resource.SecurityGroup) error {
+func (im *Manager) processSpaces(ctx context.Context, globalConfig *config.GlobalConfig, orgConfig *config.OrgConfig, orgGUID string, excludedSpaces map[string]string, isolationSegments []cfclient.IsolationSegment, securityGroups map[string]*resource.SecurityGroup) error {
 	lo.G.Infof("Listing spaces for org %s", orgConfig.Org)
-	spaces, _ := im.SpaceManager.ListSpaces(orgGUID)
+	spaces, _ := im.SpaceManager.ListSpaces(ctx, orgGUID)
 	lo.G.Infof("Found %d Spaces for org %s", len(spaces), orgConfig.Org)
 
-	spaceQuotas, err := im.QuotaManager.ListAllSpaceQuotasForOrg(orgGUID)
+	spaceQuotas, err := im.QuotaManager.ListAllSpaceQuotasForOrg(ctx, orgGUID)
 	if err != nil {
 		return err
 	}
@@ -401,13 +464,13 @@ func (im *Manager) processSpaces(globalConfig *config.GlobalConfig, orgConfig *c
 
 		spaceConfig := &config.SpaceConfig{Org: orgConfig.Org, Space: spaceName, EnableUnassignSecurityGroup: true}
 		//Add users
-		err = im.addSpaceUsers(spaceConfig, orgSpace.GUID)
+		err = im.addSpaceUsers(ctx, spaceConfig, orgSpace.GUID)
 		if err != nil {
 			return err
 		}
 		//Add Quota definition if applicable
 		if orgSpace.Relationships.Quota.Data != nil {
-			quota, err := im.QuotaManager.GetSpaceQuota(orgSpace.Relationships.Quota.Data.GUID)
+			quota, err := im.QuotaManager.GetSpaceQuota(ctx, orgSpace.Relationships.Quota.Data.GUID)
 			if err != nil {
 				return err
 			}
@@ -440,7 +503,7 @@ func (im *Manager) processSpaces(globalConfig *config.GlobalConfig, orgConfig *c
 			spaceConfig.AppTaskLimit = orgConfig.AppTaskLimit
 			spaceConfig.LogRateLimitBytesPerSecond = orgConfig.LogRateLimitBytesPerSecond
 		}
-		isoSegGUID, err := im.SpaceManager.GetSpaceIsolationSegmentGUID(orgSpace)
+		isoSegGUID, err := im.SpaceManager.GetSpaceIsolationSegmentGUID(ctx, orgSpace)
 		if err != nil {
 			return err
 		}
@@ -452,7 +515,7 @@ func (im *Manager) processSpaces(globalConfig *config.GlobalConfig, orgConfig *c
 			}
 
 		}
-		sshEnabled, err := im.SpaceManager.IsSSHEnabled(orgSpace)
+		sshEnabled, err := im.SpaceManager.IsSSHEnabled(ctx, orgSpace)
 		if err != nil {
 			return err
 		}
@@ -461,7 +524,7 @@ func (im *Manager) processSpaces(globalConfig *config.GlobalConfig, orgConfig *c
 		}
 
 		spaceSGName := fmt.Sprintf("%s-%s", orgConfig.Org, spaceName)
-		if spaceSGNames, err := im.SecurityGroupManager.ListSpaceSecurityGroups(orgSpace.GUID); err == nil {
+		if spaceSGNames, err := im.SecurityGroupManager.ListSpaceSecurityGroups(ctx, orgSpace.GUID); err == nil {
 			for securityGroupName := range spaceSGNames {
 				lo.G.Infof("Adding named security group [%s] to space [%s]", securityGroupName, spaceName)
 				if securityGroupName != spaceSGName {
@@ -499,7 +562,7 @@ func (im *Manager) processSpaces(globalConfig *config.GlobalConfig, orgConfig *c
 
 		if sgInfo, ok := securityGroups[spaceSGName]; ok {
 			delete(securityGroups, spaceSGName)
-			if rules, err := im.SecurityGroupManager.GetSecurityGroupRules(sgInfo.GUID); err == nil {
+			if rules, err := im.SecurityGroupManager.GetSecurityGroupRules(ctx, sgInfo.GUID); err == nil {
 				err = im.ConfigMgr.AddSecurityGroupToSpace(orgConfig.Org, spaceName, rules)
 				if err != nil {
 					return err
@@ -510,7 +573,7 @@ func (im *Manager) processSpaces(globalConfig *config.GlobalConfig, orgConfig *c
 	return nil
 }
 
-func (im *Manager) exportServiceAccess(globalConfig *config.GlobalConfig, orgs []*resource.Organization) error {
+func (im *Manager) exportServiceAccess(ctx context.Context, globalConfig *config.GlobalConfig, orgs []*resource.Organization) error {
 	globalConfig.ServiceAccess = nil
 	serviceInfo, err := im.ServiceAccessManager.ListServiceInfo()
 	if err != nil {
@@ -574,8 +637,8 @@ func (im *Manager) doesSpaceExist(spaces []*resource.Space, spaceName string) bo
 	return false
 }
 
-func (im *Manager) addOrgUsers(orgConfig *config.OrgConfig, orgGUID string) error {
-	_, managerRoleUsers, billingManagerRoleUsers, auditorRoleUsers, err := im.RoleManager.ListOrgUsersByRole(orgGUID)
+func (im *Manager) addOrgUsers(ctx context.Context, orgConfig *config.OrgConfig, orgGUID string) error {
+	_, managerRoleUsers, billingManagerRoleUsers, auditorRoleUsers, err := im.RoleManager.ListOrgUsersByRole(ctx, orgGUID)
 	if err != nil {
 		return err
 	}
@@ -585,8 +648,8 @@ func (im *Manager) addOrgUsers(orgConfig *config.OrgConfig, orgGUID string) erro
 	return nil
 }
 
-func (im *Manager) addSpaceUsers(spaceConfig *config.SpaceConfig, spaceGUID string) error {
-	managerRoleUsers, developerRoleUsers, auditorRoleUsers, supporterRoleUsers, err := im.RoleManager.ListSpaceUsersByRole(spaceGUID)
+func (im *Manager) addSpaceUsers(ctx context.Context, spaceConfig *config.SpaceConfig, spaceGUID string) error {
+	managerRoleUsers, developerRoleUsers, auditorRoleUsers, supporterRoleUsers, err := im.RoleManager.ListSpaceUsersByRole(ctx, spaceGUID)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,131 @@
+package failures
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/tabwriter"
+)
+
+// Failure - an error reconciling a single org or space, or a whole step when
+// neither is set
+type Failure struct {
+	Step  string
+	Org   string
+	Space string
+	Err   error
+}
+
+// Collector - collects the failures of a continue-on-error run.  A nil Collector
+// is valid and hands every error straight back so managers built without one
+// stop at the first failure exactly as before.
+type Collector struct {
+	mutex    sync.Mutex
+	step     string
+	failures []Failure
+}
+
+// NewCollector -
+func NewCollector() *Collector {
+	return &Collector{}
+}
+
+// BeginStep - marks the start of the named apply step
+func (c *Collector) BeginStep(name string) {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.step = name
+}
+
+// Reset - discards the failures so the collector can be reused for another run
+func (c *Collector) Reset() {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.step = ""
+	c.failures = nil
+}
+
+// Add - records err against the org and space (either may be empty) and returns
+// nil so the caller moves on to the next entity.  Without a collector err is
+// returned unchanged.
+func (c *Collector) Add(org, space string, err error) error {
+	if err == nil || c == nil {
+		return err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.failures = append(c.failures, Failure{Step: c.step, Org: org, Space: space, Err: err})
+	return nil
+}
+
+// Skip - true when an earlier failure means the org, or the space of the org
+// when one is given, should not be reconciled any further
+func (c *Collector) Skip(org, space string) bool {
+	if c == nil {
+		return false
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, failure := range c.failures {
+		if org == "" || !strings.EqualFold(failure.Org, org) {
+			continue
+		}
+		if failure.Space == "" || (space != "" && strings.EqualFold(failure.Space, space)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Failures - returns a copy of the failures in the order they were recorded
+func (c *Collector) Failures() []Failure {
+	if c == nil {
+		return nil
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	result := make([]Failure, len(c.failures))
+	copy(result, c.failures)
+	return result
+}
+
+// Err - an error summarising the run or nil when nothing failed
+func (c *Collector) Err() error {
+	failures := c.Failures()
+	if len(failures) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d failure(s) while applying configuration", len(failures))
+}
+
+// WriteSummary - writes a table of the failed steps, orgs and spaces
+func (c *Collector) WriteSummary(out io.Writer) error {
+	failures := c.Failures()
+	if len(failures) == 0 {
+		return nil
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STEP\tORG\tSPACE\tERROR")
+	for _, failure := range failures {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", failure.Step, orDash(failure.Org), orDash(failure.Space), oneLine(failure.Err))
+	}
+	return w.Flush()
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func oneLine(err error) string {
+	return strings.Join(strings.Fields(err.Error()), " ")
+}
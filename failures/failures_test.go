@@ -0,0 +1,76 @@
+package failures_test
+
+import (
+	"bytes"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vmwarepivotallabs/cf-mgmt/failures"
+)
+
+var _ = Describe("Collector", func() {
+	var collector *failures.Collector
+	BeforeEach(func() {
+		collector = failures.NewCollector()
+	})
+
+	It("returns errors unchanged when nil", func() {
+		var nilCollector *failures.Collector
+		Expect(nilCollector.Add("org1", "", fmt.Errorf("boom"))).To(MatchError("boom"))
+		Expect(nilCollector.Skip("org1", "")).To(BeFalse())
+		Expect(nilCollector.Err()).ToNot(HaveOccurred())
+	})
+
+	It("records failures against the current step", func() {
+		collector.BeginStep("Create Spaces")
+		Expect(collector.Add("org1", "space1", fmt.Errorf("boom"))).To(Succeed())
+		Expect(collector.Add("org1", "space2", nil)).To(Succeed())
+		Expect(collector.Failures()).To(HaveLen(1))
+		failure := collector.Failures()[0]
+		Expect(failure.Step).To(Equal("Create Spaces"))
+		Expect(failure.Org).To(Equal("org1"))
+		Expect(failure.Space).To(Equal("space1"))
+		Expect(collector.Err()).To(MatchError("1 failure(s) while applying configuration"))
+	})
+
+	It("skips every space of a failed org", func() {
+		collector.Add("org1", "", fmt.Errorf("boom"))
+		Expect(collector.Skip("org1", "")).To(BeTrue())
+		Expect(collector.Skip("ORG1", "space1")).To(BeTrue())
+		Expect(collector.Skip("org2", "space1")).To(BeFalse())
+	})
+
+	It("skips only the failed space of an org", func() {
+		collector.Add("org1", "space1", fmt.Errorf("boom"))
+		Expect(collector.Skip("org1", "")).To(BeFalse())
+		Expect(collector.Skip("org1", "space1")).To(BeTrue())
+		Expect(collector.Skip("org1", "space2")).To(BeFalse())
+	})
+
+	It("doesn't skip anything for a failed step", func() {
+		collector.Add("", "", fmt.Errorf("boom"))
+		Expect(collector.Skip("org1", "")).To(BeFalse())
+		Expect(collector.Skip("org1", "space1")).To(BeFalse())
+	})
+
+	It("forgets failures on reset", func() {
+		Expect(collector.Add("org1", "", fmt.Errorf("boom"))).To(Succeed())
+		collector.Reset()
+		Expect(collector.Skip("org1", "")).To(BeFalse())
+		Expect(collector.Err()).ToNot(HaveOccurred())
+	})
+
+	It("writes a summary table", func() {
+		collector.BeginStep("Update Spaces")
+		collector.Add("org1", "space1", fmt.Errorf("unable to\nfind space"))
+		collector.BeginStep("Service Access")
+		collector.Add("", "", fmt.Errorf("boom"))
+		var out bytes.Buffer
+		Expect(collector.WriteSummary(&out)).To(Succeed())
+		Expect(out.String()).To(Equal(
+			"STEP            ORG   SPACE   ERROR\n" +
+				"Update Spaces   org1  space1  unable to find space\n" +
+				"Service Access  -     -       boom\n"))
+	})
+})
@@ -1,12 +1,14 @@
 package isosegment
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 
 	"github.com/pkg/errors"
 
 	cfclient "github.com/cloudfoundry-community/go-cfclient"
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
 	"github.com/vmwarepivotallabs/cf-mgmt/config"
 	"github.com/vmwarepivotallabs/cf-mgmt/organizationreader"
 	"github.com/vmwarepivotallabs/cf-mgmt/space"
@@ -14,7 +16,7 @@ import (
 )
 
 // NewManager -
-func NewManager(client CFClient, cfg config.Reader, orgReader organizationreader.Reader, spaceManager space.Manager, peek bool) (Manager, error) {
+func NewManager(client CFClient, cfg config.Reader, orgReader organizationreader.Reader, spaceManager space.Manager, recorder *changes.Recorder, peek bool) (Manager, error) {
 	globalCfg, err := cfg.GetGlobalConfig()
 	if err != nil {
 		return nil, err
@@ -25,6 +27,7 @@ func NewManager(client CFClient, cfg config.Reader, orgReader organizationreader
 		Client:       client,
 		OrgReader:    orgReader,
 		SpaceManager: spaceManager,
+		Recorder:     recorder,
 		Peek:         peek,
 		CleanUp:      globalCfg.EnableDeleteIsolationSegments,
 	}, nil
@@ -38,40 +41,41 @@ type Updater struct {
 	Client       CFClient
 	OrgReader    organizationreader.Reader
 	SpaceManager space.Manager
+	Recorder     *changes.Recorder
 	Peek         bool
 	CleanUp      bool
 }
 
-func (u *Updater) Apply() error {
+func (u *Updater) Apply(ctx context.Context) error {
 	lo.G.Debugf("Creating iso segments")
-	if err := u.Create(); err != nil {
+	if err := u.Create(ctx); err != nil {
 		return err
 	}
 	lo.G.Debugf("entitling iso segments")
-	if err := u.Entitle(); err != nil {
+	if err := u.Entitle(ctx); err != nil {
 		return err
 	}
 	lo.G.Debugf("update orgs")
-	if err := u.UpdateOrgs(); err != nil {
+	if err := u.UpdateOrgs(ctx); err != nil {
 		return err
 	}
 	lo.G.Debugf("update spaces")
-	if err := u.UpdateSpaces(); err != nil {
+	if err := u.UpdateSpaces(ctx); err != nil {
 		return err
 	}
 	lo.G.Debugf("unentitling iso segments")
-	if err := u.Unentitle(); err != nil {
+	if err := u.Unentitle(ctx); err != nil {
 		return err
 	}
 	lo.G.Debugf("removing iso segments")
-	if err := u.Remove(); err != nil {
+	if err := u.Remove(ctx); err != nil {
 		return err
 	}
 	return nil
 }
 
 // Create creates any isolation segments that do not yet exist,
-func (u *Updater) Create() error {
+func (u *Updater) Create(ctx context.Context) error {
 	desired, err := u.allDesiredSegments()
 	if err != nil {
 		return err
@@ -83,6 +87,9 @@ func (u *Updater) Create() error {
 
 	c := classify(desired, current)
 	for i := range c.missing {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		err := u.create(&c.missing[i])
 		if err != nil {
 			return err
@@ -92,7 +99,7 @@ func (u *Updater) Create() error {
 }
 
 // Create creates any isolation segments that do not yet exist,
-func (u *Updater) Remove() error {
+func (u *Updater) Remove(ctx context.Context) error {
 	desired, err := u.allDesiredSegments()
 	if err != nil {
 		return err
@@ -104,6 +111,9 @@ func (u *Updater) Remove() error {
 
 	c := classify(desired, current)
 	for i := range c.extra {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		err := u.delete(&c.extra[i])
 		if err != nil {
 			return err
@@ -112,7 +122,7 @@ func (u *Updater) Remove() error {
 	return nil
 }
 
-func (u *Updater) Unentitle() error {
+func (u *Updater) Unentitle(ctx context.Context) error {
 	spaces, err := u.Cfg.GetSpaceConfigs()
 	if err != nil {
 		return err
@@ -123,7 +133,7 @@ func (u *Updater) Unentitle() error {
 		return err
 	}
 
-	isolationSegmentsMap, err := u.isolationSegmentMap()
+	isolationSegmentsMap, err := u.isolationSegmentMap(ctx)
 	if err != nil {
 		return err
 	}
@@ -133,7 +143,7 @@ func (u *Updater) Unentitle() error {
 	// org's default segment
 	sm := make(map[string][]*cfclient.IsolationSegment)
 	for _, space := range spaces {
-		org, err := u.OrgReader.FindOrg(space.Org)
+		org, err := u.OrgReader.FindOrg(ctx, space.Org)
 		if err != nil {
 			return errors.Wrap(err, "finding org for space configs")
 		}
@@ -150,7 +160,7 @@ func (u *Updater) Unentitle() error {
 		}
 	}
 	for _, orgConfig := range orgs {
-		org, err := u.OrgReader.FindOrg(orgConfig.Org)
+		org, err := u.OrgReader.FindOrg(ctx, orgConfig.Org)
 		if err != nil {
 			return errors.Wrap(err, "finding org for org configs")
 		}
@@ -168,6 +178,9 @@ func (u *Updater) Unentitle() error {
 	}
 
 	for orgGUID, segments := range sm {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		orgIsolationSegments, err := u.Client.ListIsolationSegmentsByQuery(url.Values{
 			"organization_guids": []string{orgGUID},
 		})
@@ -183,7 +196,10 @@ func (u *Updater) Unentitle() error {
 		}
 		c := classify(desiredSegments, orgIsolationSegments)
 		for i := range c.extra {
-			err := u.revoke(&c.extra[i], orgGUID)
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			err := u.revoke(ctx, &c.extra[i], orgGUID)
 			if err != nil {
 				return err
 			}
@@ -193,7 +209,7 @@ func (u *Updater) Unentitle() error {
 }
 
 // Entitle ensures that each org is entitled to the isolation segments it needs to use.
-func (u *Updater) Entitle() error {
+func (u *Updater) Entitle(ctx context.Context) error {
 	spaces, err := u.Cfg.GetSpaceConfigs()
 	if err != nil {
 		return err
@@ -204,7 +220,7 @@ func (u *Updater) Entitle() error {
 		return err
 	}
 
-	isolationSegmentsMap, err := u.isolationSegmentMap()
+	isolationSegmentsMap, err := u.isolationSegmentMap(ctx)
 	if err != nil {
 		return err
 	}
@@ -215,7 +231,7 @@ func (u *Updater) Entitle() error {
 	for _, space := range spaces {
 		if s := space.IsoSegment; s != "" {
 			if isosegment, ok := isolationSegmentsMap[s]; ok {
-				org, err := u.OrgReader.FindOrg(space.Org)
+				org, err := u.OrgReader.FindOrg(ctx, space.Org)
 				if err != nil {
 					return errors.Wrap(err, "finding org for space configs in entitle")
 				}
@@ -229,7 +245,7 @@ func (u *Updater) Entitle() error {
 	}
 	for _, orgConfig := range orgs {
 		if s := orgConfig.DefaultIsoSegment; s != "" {
-			org, err := u.OrgReader.FindOrg(orgConfig.Org)
+			org, err := u.OrgReader.FindOrg(ctx, orgConfig.Org)
 			if err != nil {
 				return errors.Wrap(err, "finding org for org configs in entitle")
 			}
@@ -244,6 +260,9 @@ func (u *Updater) Entitle() error {
 	}
 
 	for orgGUID, desiredSegments := range sm {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		orgIsolationSegments, err := u.Client.ListIsolationSegmentsByQuery(url.Values{
 			"organization_guids": []string{orgGUID},
 		})
@@ -253,7 +272,10 @@ func (u *Updater) Entitle() error {
 
 		c := classify(desiredSegments, orgIsolationSegments)
 		for i := range c.missing {
-			err := u.entitle(&c.missing[i], orgGUID)
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			err := u.entitle(ctx, &c.missing[i], orgGUID)
 			if err != nil {
 				return err
 			}
@@ -264,17 +286,20 @@ func (u *Updater) Entitle() error {
 
 // UpdateOrgs sets the default isolation segment for each org,
 // as specified in the cf-mgmt config.
-func (u *Updater) UpdateOrgs() error {
+func (u *Updater) UpdateOrgs(ctx context.Context) error {
 	ocs, err := u.Cfg.GetOrgConfigs()
 	if err != nil {
 		return err
 	}
 	for _, oc := range ocs {
-		org, err := u.OrgReader.FindOrg(oc.Org)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		org, err := u.OrgReader.FindOrg(ctx, oc.Org)
 		if err != nil {
 			return errors.Wrap(err, "finding org for org configs in update orgs")
 		}
-		isolationSegmentMap, err := u.isolationSegmentMap()
+		isolationSegmentMap, err := u.isolationSegmentMap(ctx)
 		if err != nil {
 			return err
 		}
@@ -283,11 +308,12 @@ func (u *Updater) UpdateOrgs() error {
 		if err != nil {
 			return err
 		}
-		orgIsolationSegmentGUID, err := u.OrgReader.GetDefaultIsolationSegment(org)
+		orgIsolationSegmentGUID, err := u.OrgReader.GetDefaultIsolationSegment(ctx, org)
 		if err != nil {
 			return errors.Wrap(err, "finding org default isolation segment")
 		}
 		if orgIsolationSegmentGUID != isolationSegmentGUID {
+			change := u.recordDefault(oc.Org, "", oc.DefaultIsoSegment, orgIsolationSegmentGUID)
 			if u.Peek {
 				if isolationSegmentGUID != "" {
 					lo.G.Infof("[dry-run]: set default isolation segment for org %s to %s", oc.Org, oc.DefaultIsoSegment)
@@ -300,12 +326,14 @@ func (u *Updater) UpdateOrgs() error {
 			if isolationSegmentGUID != "" {
 				lo.G.Infof("set default isolation segment for org %s to %s", oc.Org, oc.DefaultIsoSegment)
 				err = u.Client.DefaultIsolationSegmentForOrg(org.GUID, isolationSegmentGUID)
+				u.Recorder.Applied(change, org.GUID, err)
 				if err != nil {
 					return errors.Wrap(err, "setting org default isolation segment")
 				}
 			} else {
 				lo.G.Infof("reset default isolation segment for org %s", oc.Org)
 				err = u.Client.ResetDefaultIsolationSegmentForOrg(org.GUID)
+				u.Recorder.Applied(change, org.GUID, err)
 				if err != nil {
 					return errors.Wrap(err, "reset org default isolation segment")
 				}
@@ -333,18 +361,21 @@ func (u *Updater) getIsolationSegmentGUID(isolationSegmentName string, isolation
 
 // UpdateSpaces sets the isolation segment for each space,
 // as specified in the cf-mgmt config.
-func (u *Updater) UpdateSpaces() error {
+func (u *Updater) UpdateSpaces(ctx context.Context) error {
 	scs, err := u.Cfg.GetSpaceConfigs()
 	if err != nil {
 		return err
 	}
 
-	isolationSegmentMap, err := u.isolationSegmentMap()
+	isolationSegmentMap, err := u.isolationSegmentMap(ctx)
 	if err != nil {
 		return err
 	}
 	for _, sc := range scs {
-		space, err := u.SpaceManager.FindSpace(sc.Org, sc.Space)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		space, err := u.SpaceManager.FindSpace(ctx, sc.Org, sc.Space)
 		if err != nil {
 			return err
 		}
@@ -352,11 +383,12 @@ func (u *Updater) UpdateSpaces() error {
 		if err != nil {
 			return err
 		}
-		spaceIsoSegGUID, err := u.SpaceManager.GetSpaceIsolationSegmentGUID(space)
+		spaceIsoSegGUID, err := u.SpaceManager.GetSpaceIsolationSegmentGUID(ctx, space)
 		if err != nil {
 			return err
 		}
 		if spaceIsoSegGUID != isolationSegmentGUID {
+			change := u.recordDefault(sc.Org, sc.Space, sc.IsoSegment, spaceIsoSegGUID)
 			if u.Peek {
 				if sc.IsoSegment != "" {
 					lo.G.Infof("[dry-run]: set isolation segment for space %s to %s (org %s)", sc.Space, sc.IsoSegment, sc.Org)
@@ -368,12 +400,14 @@ func (u *Updater) UpdateSpaces() error {
 			if sc.IsoSegment != "" {
 				lo.G.Infof("set isolation segment for space %s to %s (org %s)", sc.Space, sc.IsoSegment, sc.Org)
 				err = u.Client.IsolationSegmentForSpace(space.GUID, isolationSegmentGUID)
+				u.Recorder.Applied(change, space.GUID, err)
 				if err != nil {
 					return err
 				}
 			} else {
 				lo.G.Infof("reset isolation segment for space %s (org %s)", sc.Space, sc.Org)
 				err = u.Client.ResetIsolationSegmentForSpace(space.GUID)
+				u.Recorder.Applied(change, space.GUID, err)
 				if err != nil {
 					return err
 				}
@@ -384,14 +418,44 @@ func (u *Updater) UpdateSpaces() error {
 	return nil
 }
 
+// recordDefault - an empty segment name means the org or space is reset to the platform default
+func (u *Updater) recordDefault(orgName, spaceName, segmentName, currentGUID string) changes.Change {
+	change := changes.Change{Entity: changes.IsolationSegment, Action: changes.Assign, Name: segmentName, Org: orgName, Space: spaceName, Before: currentGUID, After: segmentName}
+	if segmentName == "" {
+		change.Action = changes.Unassign
+		change.Name = currentGUID
+		change.After = nil
+	}
+	u.Recorder.Record(change)
+	return change
+}
+
+func (u *Updater) orgName(ctx context.Context, orgGUID string) string {
+	if u.Recorder == nil {
+		return orgGUID
+	}
+	org, err := u.OrgReader.FindOrgByGUID(ctx, orgGUID)
+	if err != nil || org == nil {
+		return orgGUID
+	}
+	return org.Name
+}
+
 func (u *Updater) create(s *cfclient.IsolationSegment) error {
+	change := changes.Change{Entity: changes.IsolationSegment, Action: changes.Create, Name: s.Name}
+	u.Recorder.Record(change)
 	if u.Peek {
 		lo.G.Info("[dry-run]: create segment", s.Name)
 		return nil
 	}
 
 	lo.G.Info("create segment", s.Name)
-	_, err := u.Client.CreateIsolationSegment(s.Name)
+	segment, err := u.Client.CreateIsolationSegment(s.Name)
+	var guid string
+	if segment != nil {
+		guid = segment.GUID
+	}
+	u.Recorder.Applied(change, guid, err)
 	return err
 }
 
@@ -402,33 +466,45 @@ func (u *Updater) delete(s *cfclient.IsolationSegment) error {
 	if s.Name == "shared" {
 		return nil
 	}
+	change := changes.Change{Entity: changes.IsolationSegment, Action: changes.Delete, Name: s.Name}
+	u.Recorder.Record(change)
 	if u.Peek {
 		lo.G.Infof("[dry-run]: delete segment %s (%s)", s.Name, s.GUID)
 		return nil
 	}
 	lo.G.Infof("delete segment %s (%s)", s.Name, s.GUID)
-	return u.Client.DeleteIsolationSegmentByGUID(s.GUID)
+	err := u.Client.DeleteIsolationSegmentByGUID(s.GUID)
+	u.Recorder.Applied(change, s.GUID, err)
+	return err
 }
 
-func (u *Updater) entitle(s *cfclient.IsolationSegment, orgGUID string) error {
+func (u *Updater) entitle(ctx context.Context, s *cfclient.IsolationSegment, orgGUID string) error {
+	change := changes.Change{Entity: changes.IsolationSegment, Action: changes.Assign, Name: s.Name, Org: u.orgName(ctx, orgGUID), After: "entitled"}
+	u.Recorder.Record(change)
 	if u.Peek {
 		lo.G.Infof("[dry-run]: entitle org %s to iso segment %s", orgGUID, s.Name)
 		return nil
 	}
 	lo.G.Infof("entitle org %s to iso segment %s", orgGUID, s.Name)
-	return u.Client.AddIsolationSegmentToOrg(s.GUID, orgGUID)
+	err := u.Client.AddIsolationSegmentToOrg(s.GUID, orgGUID)
+	u.Recorder.Applied(change, s.GUID, err)
+	return err
 }
 
-func (u *Updater) revoke(s *cfclient.IsolationSegment, orgGUID string) error {
+func (u *Updater) revoke(ctx context.Context, s *cfclient.IsolationSegment, orgGUID string) error {
 	if !u.CleanUp {
 		return nil
 	}
+	change := changes.Change{Entity: changes.IsolationSegment, Action: changes.Unassign, Name: s.Name, Org: u.orgName(ctx, orgGUID), Before: "entitled"}
+	u.Recorder.Record(change)
 	if u.Peek {
 		lo.G.Infof("[dry-run]: revoke iso segment %s from org %s", s.Name, orgGUID)
 		return nil
 	}
 	lo.G.Infof("revoke iso segment %s (%s) from org %s", s.Name, s.GUID, orgGUID)
-	return u.Client.RemoveIsolationSegmentFromOrg(s.GUID, orgGUID)
+	err := u.Client.RemoveIsolationSegmentFromOrg(s.GUID, orgGUID)
+	u.Recorder.Applied(change, s.GUID, err)
+	return err
 }
 
 // allDesiredSegments iterates through the cf-mgmt configuration for all
@@ -463,8 +539,8 @@ func (u *Updater) allDesiredSegments() ([]cfclient.IsolationSegment, error) {
 	return result, nil
 }
 
-func (u *Updater) isolationSegmentMap() (map[string]cfclient.IsolationSegment, error) {
-	isolationSegments, err := u.ListIsolationSegments()
+func (u *Updater) isolationSegmentMap(ctx context.Context) (map[string]cfclient.IsolationSegment, error) {
+	isolationSegments, err := u.ListIsolationSegments(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -476,7 +552,7 @@ func (u *Updater) isolationSegmentMap() (map[string]cfclient.IsolationSegment, e
 	return isolationSegmentsMap, nil
 }
 
-func (m *Updater) ListIsolationSegments() ([]cfclient.IsolationSegment, error) {
+func (m *Updater) ListIsolationSegments(ctx context.Context) ([]cfclient.IsolationSegment, error) {
 	isolationSegments, err := m.Client.ListIsolationSegments()
 	if err != nil {
 		return nil, err
@@ -1,6 +1,8 @@
 package isosegment_test
 
 import (
+	"context"
+
 	"errors"
 
 	cfclient "github.com/cloudfoundry-community/go-cfclient"
@@ -42,7 +44,7 @@ var _ = Describe("Isolation Segments", func() {
 			})
 
 			It("fails", func() {
-				Expect(u.Create()).ShouldNot(Succeed())
+				Expect(u.Create(context.Background())).ShouldNot(Succeed())
 			})
 		})
 
@@ -52,7 +54,7 @@ var _ = Describe("Isolation Segments", func() {
 			})
 
 			It("creates isolation segments", func() {
-				Expect(u.Create()).Should(Succeed())
+				Expect(u.Create(context.Background())).Should(Succeed())
 				Expect(client.CreateIsolationSegmentCallCount()).Should(Equal(2))
 				var createdIsoSegments []string
 				createdIsoSegments = append(createdIsoSegments, client.CreateIsolationSegmentArgsForCall(0))
@@ -62,7 +64,7 @@ var _ = Describe("Isolation Segments", func() {
 
 			It("doesnt create isolation segments when DryRun is enabled", func() {
 				u.Peek = true
-				Ω(u.Create()).Should(Succeed())
+				Ω(u.Create(context.Background())).Should(Succeed())
 				Expect(client.CreateIsolationSegmentCallCount()).Should(Equal(0))
 			})
 		})
@@ -76,7 +78,7 @@ var _ = Describe("Isolation Segments", func() {
 					{Name: "extra", GUID: "extra_guid"},
 				}
 				client.ListIsolationSegmentsReturns(seg, nil)
-				Ω(u.Remove()).Should(Succeed())
+				Ω(u.Remove(context.Background())).Should(Succeed())
 				Expect(client.DeleteIsolationSegmentByGUIDCallCount()).Should(Equal(2))
 				var deletedIsoSegments []string
 				deletedIsoSegments = append(deletedIsoSegments, client.DeleteIsolationSegmentByGUIDArgsForCall(0))
@@ -93,7 +95,7 @@ var _ = Describe("Isolation Segments", func() {
 					{Name: "extra", GUID: "extra_guid"},
 				}
 				client.ListIsolationSegmentsReturns(seg, nil)
-				Ω(u.Remove()).Should(Succeed())
+				Ω(u.Remove(context.Background())).Should(Succeed())
 				Expect(client.DeleteIsolationSegmentByGUIDCallCount()).Should(Equal(0))
 
 			})
@@ -108,7 +110,7 @@ var _ = Describe("Isolation Segments", func() {
 					{Name: "extra"},
 				}
 				client.ListIsolationSegmentsReturns(seg, nil)
-				Ω(u.Remove()).Should(Succeed())
+				Ω(u.Remove(context.Background())).Should(Succeed())
 				Expect(client.DeleteIsolationSegmentByGUIDCallCount()).Should(Equal(0))
 			})
 		})
@@ -124,7 +126,7 @@ var _ = Describe("Isolation Segments", func() {
 			})
 
 			It("creates no isolation segments", func() {
-				Ω(u.Create()).Should(Succeed())
+				Ω(u.Create(context.Background())).Should(Succeed())
 				Expect(client.CreateIsolationSegmentCallCount()).Should(Equal(0))
 			})
 		})
@@ -136,7 +138,7 @@ var _ = Describe("Isolation Segments", func() {
 			})
 
 			It("creates isolation segments", func() {
-				Ω(u.Create()).Should(Succeed())
+				Ω(u.Create(context.Background())).Should(Succeed())
 				Expect(client.CreateIsolationSegmentCallCount()).Should(Equal(2))
 				var createdIsoSegments []string
 				createdIsoSegments = append(createdIsoSegments, client.CreateIsolationSegmentArgsForCall(0))
@@ -158,7 +160,7 @@ var _ = Describe("Isolation Segments", func() {
 
 			It("makes no changes", func() {
 				client.ListIsolationSegmentsByQueryReturns([]cfclient.IsolationSegment{{Name: "iso01"}}, nil)
-				Ω(u.Entitle()).Should(Succeed())
+				Ω(u.Entitle(context.Background())).Should(Succeed())
 			})
 		})
 
@@ -173,7 +175,7 @@ var _ = Describe("Isolation Segments", func() {
 			It("entitles both orgs to their isolation segments", func() {
 				By("entitling org1 to iso00 (used by one of its spaces)")
 				orgReader.FindOrgReturns(&resource.Organization{Name: "org1", GUID: "org1_guid"}, nil)
-				Ω(u.Entitle()).Should(Succeed())
+				Ω(u.Entitle(context.Background())).Should(Succeed())
 				Expect(client.AddIsolationSegmentToOrgCallCount()).Should(Equal(2))
 				var isoSegmentGUIDs []string
 				isolationSegmentGUID, orgGUID := client.AddIsolationSegmentToOrgArgsForCall(0)
@@ -188,7 +190,7 @@ var _ = Describe("Isolation Segments", func() {
 			It("makes no change when DryRun is enabled", func() {
 				u.Peek = true
 				orgReader.FindOrgReturns(&resource.Organization{Name: "org1", GUID: "org1_guid"}, nil)
-				Ω(u.Entitle()).Should(Succeed())
+				Ω(u.Entitle(context.Background())).Should(Succeed())
 				Expect(client.AddIsolationSegmentToOrgCallCount()).Should(Equal(0))
 			})
 		})
@@ -208,7 +210,7 @@ var _ = Describe("Isolation Segments", func() {
 			})
 
 			It("revokes org2's access to the extra isolation segment when CleanUp is enabled", func() {
-				Ω(u.Unentitle()).Should(Succeed())
+				Ω(u.Unentitle(context.Background())).Should(Succeed())
 				Expect(client.RemoveIsolationSegmentFromOrgCallCount()).Should(Equal(1))
 				isoGUID, orgGUID := client.RemoveIsolationSegmentFromOrgArgsForCall(0)
 				Expect(isoGUID).Should(Equal("extra_guid"))
@@ -217,13 +219,13 @@ var _ = Describe("Isolation Segments", func() {
 
 			It("does not revoke access when CleanUp is disabled", func() {
 				u.CleanUp = false
-				Ω(u.Entitle()).Should(Succeed())
+				Ω(u.Entitle(context.Background())).Should(Succeed())
 				Expect(client.RemoveIsolationSegmentFromOrgCallCount()).Should(Equal(0))
 			})
 
 			It("makes no changes when DryRun is enabled", func() {
 				u.Peek = true
-				Ω(u.Entitle()).Should(Succeed())
+				Ω(u.Entitle(context.Background())).Should(Succeed())
 				Expect(client.RemoveIsolationSegmentFromOrgCallCount()).Should(Equal(0))
 			})
 		})
@@ -237,7 +239,7 @@ var _ = Describe("Isolation Segments", func() {
 					{Name: "iso01", GUID: "iso01_guid"},
 					{Name: "default_iso", GUID: "default_iso_guid"},
 				}, nil)
-				Ω(u.UpdateOrgs()).Should(Succeed())
+				Ω(u.UpdateOrgs(context.Background())).Should(Succeed())
 				Expect(client.DefaultIsolationSegmentForOrgCallCount()).Should(Equal(1))
 				orgGUID, isoSegmentGUID := client.DefaultIsolationSegmentForOrgArgsForCall(0)
 				Expect(orgGUID).Should(Equal("org1_guid"))
@@ -256,7 +258,7 @@ var _ = Describe("Isolation Segments", func() {
 					{Name: "iso01", GUID: "iso01_guid"},
 					{Name: "default_iso", GUID: "default_iso_guid"},
 				}, nil)
-				Ω(u.UpdateOrgs()).Should(Succeed())
+				Ω(u.UpdateOrgs(context.Background())).Should(Succeed())
 				Expect(client.DefaultIsolationSegmentForOrgCallCount()).Should(Equal(0))
 				Expect(client.ResetDefaultIsolationSegmentForOrgCallCount()).Should(Equal(1))
 				orgGUID := client.ResetDefaultIsolationSegmentForOrgArgsForCall(0)
@@ -268,7 +270,7 @@ var _ = Describe("Isolation Segments", func() {
 				})
 
 				It("does not modify org isolation segments", func() {
-					Ω(u.UpdateOrgs()).Should(Succeed())
+					Ω(u.UpdateOrgs(context.Background())).Should(Succeed())
 					Expect(client.DefaultIsolationSegmentForOrgCallCount()).Should(Equal(0))
 					Expect(client.ResetDefaultIsolationSegmentForOrgCallCount()).Should(Equal(0))
 				})
@@ -281,7 +283,7 @@ var _ = Describe("Isolation Segments", func() {
 			})
 
 			It("does not modify org isolation segments", func() {
-				Ω(u.UpdateOrgs()).Should(Succeed())
+				Ω(u.UpdateOrgs(context.Background())).Should(Succeed())
 				Expect(client.DefaultIsolationSegmentForOrgCallCount()).Should(Equal(0))
 				Expect(client.ResetDefaultIsolationSegmentForOrgCallCount()).Should(Equal(0))
 			})
@@ -296,7 +298,7 @@ var _ = Describe("Isolation Segments", func() {
 					{Name: "default_iso", GUID: "default_iso_guid"},
 				}, nil)
 				client.DefaultIsolationSegmentForOrgReturns(errors.New("error"))
-				Ω(u.UpdateOrgs()).ShouldNot(Succeed())
+				Ω(u.UpdateOrgs(context.Background())).ShouldNot(Succeed())
 			})
 		})
 	})
@@ -309,7 +311,7 @@ var _ = Describe("Isolation Segments", func() {
 					{Name: "default_iso", GUID: "default_iso_guid"},
 				}, nil)
 				spaceManager.FindSpaceReturns(&resource.Space{Name: "org1space2", GUID: "space_guid"}, nil)
-				Ω(u.UpdateSpaces()).Should(Succeed())
+				Ω(u.UpdateSpaces(context.Background())).Should(Succeed())
 				Expect(client.IsolationSegmentForSpaceCallCount()).Should(Equal(1))
 				spaceGUID, isolationSegmentGUID := client.IsolationSegmentForSpaceArgsForCall(0)
 				Expect(spaceGUID).Should(Equal("space_guid"))
@@ -328,7 +330,7 @@ var _ = Describe("Isolation Segments", func() {
 				}, nil)
 				spaceManager.FindSpaceReturns(&resource.Space{Name: "org1space2", GUID: "space_guid"}, nil)
 				spaceManager.GetSpaceIsolationSegmentGUIDReturns("foo", nil)
-				Ω(u.UpdateSpaces()).Should(Succeed())
+				Ω(u.UpdateSpaces(context.Background())).Should(Succeed())
 				Expect(client.ResetIsolationSegmentForSpaceCallCount()).Should(Equal(1))
 				spaceGUID := client.ResetIsolationSegmentForSpaceArgsForCall(0)
 				Expect(spaceGUID).Should(Equal("space_guid"))
@@ -339,7 +341,7 @@ var _ = Describe("Isolation Segments", func() {
 				})
 
 				It("does not modify space isolation segments", func() {
-					Ω(u.UpdateSpaces()).Should(Succeed())
+					Ω(u.UpdateSpaces(context.Background())).Should(Succeed())
 					Expect(client.IsolationSegmentForSpaceCallCount()).Should(Equal(0))
 					Expect(client.ResetIsolationSegmentForSpaceCallCount()).Should(Equal(0))
 				})
@@ -352,7 +354,7 @@ var _ = Describe("Isolation Segments", func() {
 			})
 
 			It("does not modify space isolation segments", func() {
-				Ω(u.UpdateSpaces()).Should(Succeed())
+				Ω(u.UpdateSpaces(context.Background())).Should(Succeed())
 				Expect(client.IsolationSegmentForSpaceCallCount()).Should(Equal(0))
 				Expect(client.ResetIsolationSegmentForSpaceCallCount()).Should(Equal(0))
 			})
@@ -367,7 +369,7 @@ var _ = Describe("Isolation Segments", func() {
 				}, nil)
 				spaceManager.FindSpaceReturns(&resource.Space{Name: "org1space2", GUID: "space_guid"}, nil)
 				client.IsolationSegmentForSpaceReturns(errors.New("error"))
-				Ω(u.UpdateSpaces()).ShouldNot(Succeed())
+				Ω(u.UpdateSpaces(context.Background())).ShouldNot(Succeed())
 			})
 		})
 	})
@@ -1,20 +1,21 @@
 package isosegment
 
 import (
+	"context"
 	"net/url"
 
 	cfclient "github.com/cloudfoundry-community/go-cfclient"
 )
 
 type Manager interface {
-	Apply() error
-	Create() error
-	Remove() error
-	Entitle() error
-	Unentitle() error
-	UpdateOrgs() error
-	UpdateSpaces() error
-	ListIsolationSegments() ([]cfclient.IsolationSegment, error)
+	Apply(ctx context.Context) error
+	Create(ctx context.Context) error
+	Remove(ctx context.Context) error
+	Entitle(ctx context.Context) error
+	Unentitle(ctx context.Context) error
+	UpdateOrgs(ctx context.Context) error
+	UpdateSpaces(ctx context.Context) error
+	ListIsolationSegments(ctx context.Context) ([]cfclient.IsolationSegment, error)
 }
 
 type CFClient interface {
@@ -1,12 +1,14 @@
 package ldap
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
 	"log"
 	"os"
 	"strings"
+	"sync"
 
 	l "github.com/go-ldap/ldap/v3"
 	"github.com/vmwarepivotallabs/cf-mgmt/config"
@@ -15,26 +17,64 @@ import (
 
 type Connection interface {
 	Close() error
-	Search(*l.SearchRequest) (*l.SearchResult, error)
+	Search(context.Context, *l.SearchRequest) (*l.SearchResult, error)
 	IsClosing() bool
 }
 
+// conn - a Connection that searches with the context it is given, which the
+// Search of the ldap client doesn't take
+type conn struct {
+	*l.Conn
+}
+
+func (c conn) Search(ctx context.Context, searchRequest *l.SearchRequest) (*l.SearchResult, error) {
+	response := c.Conn.SearchAsync(ctx, searchRequest, 0)
+	result := &l.SearchResult{}
+	for response.Next() {
+		if entry := response.Entry(); entry != nil {
+			result.Entries = append(result.Entries, entry)
+		}
+		if referral := response.Referral(); referral != "" {
+			result.Referrals = append(result.Referrals, referral)
+		}
+	}
+	result.Controls = response.Controls()
+	return result, response.Err()
+}
+
 type RefreshableConnection struct {
 	Connection
 	refreshConnection func() (Connection, error)
+	mutex             sync.Mutex
 }
 
-func (r *RefreshableConnection) Search(searchRequest *l.SearchRequest) (*l.SearchResult, error) {
+func (r *RefreshableConnection) Search(ctx context.Context, searchRequest *l.SearchRequest) (*l.SearchResult, error) {
+	connection, err := r.current()
+	if err != nil {
+		return nil, err
+	}
+	return connection.Search(ctx, searchRequest)
+}
+
+// current - returns the open connection, re-establishing it once when concurrent searches find it closed
+func (r *RefreshableConnection) current() (Connection, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
 	if r.Connection.IsClosing() {
-		err := r.RefreshConnection()
-		if err != nil {
+		if err := r.refresh(); err != nil {
 			return nil, err
 		}
 	}
-	return r.Connection.Search(searchRequest)
+	return r.Connection, nil
 }
 
 func (r *RefreshableConnection) RefreshConnection() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.refresh()
+}
+
+func (r *RefreshableConnection) refresh() error {
 	connection, err := r.refreshConnection()
 	if err != nil {
 		lo.G.Error("Could not re-establish LDAP connection")
@@ -135,5 +175,5 @@ func createConnection(config *config.LdapConfig) (Connection, error) {
 		}
 	}
 
-	return connection, err
+	return conn{connection}, err
 }
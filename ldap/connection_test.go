@@ -1,6 +1,7 @@
 package ldap_test
 
 import (
+	"context"
 	"crypto/tls"
 	"errors"
 
@@ -61,7 +62,7 @@ var _ = Describe("RefreshableConnection_Search", func() {
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(*createConnectionCallCounter).Should(Equal(1))
 
-			_, err = rc.Search(&l.SearchRequest{})
+			_, err = rc.Search(context.Background(), &l.SearchRequest{})
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(*createConnectionCallCounter).Should(Equal(1))
 		})
@@ -75,7 +76,7 @@ var _ = Describe("RefreshableConnection_Search", func() {
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(*createConnectionCallCounter).Should(Equal(1))
 
-			_, err = rc.Search(&l.SearchRequest{})
+			_, err = rc.Search(context.Background(), &l.SearchRequest{})
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(*createConnectionCallCounter).Should(Equal(2))
 		})
@@ -98,7 +99,7 @@ var _ = Describe("RefreshableConnection_Search", func() {
 			})
 
 			throwError = true
-			_, err = rc.Search(&l.SearchRequest{})
+			_, err = rc.Search(context.Background(), &l.SearchRequest{})
 			Expect(err).Should(HaveOccurred())
 			Expect(err).Should(MatchError(errorMsg))
 		})
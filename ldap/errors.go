@@ -0,0 +1,40 @@
+package ldap
+
+import (
+	"errors"
+	"fmt"
+)
+
+// GroupErrorReason - why the members of a group can't be trusted
+type GroupErrorReason string
+
+const (
+	// GroupNotFound - no group matched the name
+	GroupNotFound GroupErrorReason = "not found"
+	// GroupDuplicate - more than one group matched the name
+	GroupDuplicate GroupErrorReason = "multiple groups found"
+	// GroupEmpty - the group, including its nested groups, has no members and
+	// allowEmptyGroups isn't set in ldap.yml
+	GroupEmpty GroupErrorReason = "no members"
+)
+
+// GroupError - returned by GetUserDNs when a group was searched for
+// successfully but its result can't be used to decide who should have a role.
+// Any other error from GetUserDNs is a failure talking to the directory.
+type GroupError struct {
+	Group  string
+	Reason GroupErrorReason
+}
+
+func (e *GroupError) Error() string {
+	return fmt.Sprintf("ldap group [%s]: %s", e.Group, e.Reason)
+}
+
+// AsGroupError - returns the *GroupError err is, or wraps
+func AsGroupError(err error) (*GroupError, bool) {
+	var groupErr *GroupError
+	if errors.As(err, &groupErr) {
+		return groupErr, true
+	}
+	return nil, false
+}
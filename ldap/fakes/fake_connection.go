@@ -2,6 +2,7 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 
 	ldapa "github.com/go-ldap/ldap/v3"
@@ -29,10 +30,11 @@ type FakeConnection struct {
 	isClosingReturnsOnCall map[int]struct {
 		result1 bool
 	}
-	SearchStub        func(*ldapa.SearchRequest) (*ldapa.SearchResult, error)
+	SearchStub        func(context.Context, *ldapa.SearchRequest) (*ldapa.SearchResult, error)
 	searchMutex       sync.RWMutex
 	searchArgsForCall []struct {
-		arg1 *ldapa.SearchRequest
+		arg1 context.Context
+		arg2 *ldapa.SearchRequest
 	}
 	searchReturns struct {
 		result1 *ldapa.SearchResult
@@ -152,18 +154,19 @@ func (fake *FakeConnection) IsClosingReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
-func (fake *FakeConnection) Search(arg1 *ldapa.SearchRequest) (*ldapa.SearchResult, error) {
+func (fake *FakeConnection) Search(arg1 context.Context, arg2 *ldapa.SearchRequest) (*ldapa.SearchResult, error) {
 	fake.searchMutex.Lock()
 	ret, specificReturn := fake.searchReturnsOnCall[len(fake.searchArgsForCall)]
 	fake.searchArgsForCall = append(fake.searchArgsForCall, struct {
-		arg1 *ldapa.SearchRequest
-	}{arg1})
+		arg1 context.Context
+		arg2 *ldapa.SearchRequest
+	}{arg1, arg2})
 	stub := fake.SearchStub
 	fakeReturns := fake.searchReturns
-	fake.recordInvocation("Search", []interface{}{arg1})
+	fake.recordInvocation("Search", []interface{}{arg1, arg2})
 	fake.searchMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -177,17 +180,17 @@ func (fake *FakeConnection) SearchCallCount() int {
 	return len(fake.searchArgsForCall)
 }
 
-func (fake *FakeConnection) SearchCalls(stub func(*ldapa.SearchRequest) (*ldapa.SearchResult, error)) {
+func (fake *FakeConnection) SearchCalls(stub func(context.Context, *ldapa.SearchRequest) (*ldapa.SearchResult, error)) {
 	fake.searchMutex.Lock()
 	defer fake.searchMutex.Unlock()
 	fake.SearchStub = stub
 }
 
-func (fake *FakeConnection) SearchArgsForCall(i int) *ldapa.SearchRequest {
+func (fake *FakeConnection) SearchArgsForCall(i int) (context.Context, *ldapa.SearchRequest) {
 	fake.searchMutex.RLock()
 	defer fake.searchMutex.RUnlock()
 	argsForCall := fake.searchArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeConnection) SearchReturns(result1 *ldapa.SearchResult, result2 error) {
@@ -1,11 +1,13 @@
 package ldap
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	l "github.com/go-ldap/ldap/v3"
 	"github.com/vmwarepivotallabs/cf-mgmt/config"
+	"github.com/vmwarepivotallabs/cf-mgmt/util"
 	"github.com/xchapter7x/lo"
 )
 
@@ -36,45 +38,69 @@ func NewManager(ldapConfig *config.LdapConfig) (*Manager, error) {
 	}, nil
 }
 
-func (m *Manager) groupInCache(groupName string) bool {
+func (m *Manager) groupFromCache(groupName string) ([]string, bool) {
+	m.cacheMutex.RLock()
+	defer m.cacheMutex.RUnlock()
 	if m.groupMap == nil {
-		return false
+		return nil, false
 	}
-	if _, ok := m.groupMap[groupName]; ok {
-		return true
-	}
-
-	return false
+	result, ok := m.groupMap[groupName]
+	return result, ok
 }
 
 func (m *Manager) addGroupToCache(groupName string, result []string) {
+	m.cacheMutex.Lock()
+	defer m.cacheMutex.Unlock()
 	if m.groupMap == nil {
 		m.groupMap = make(map[string][]string)
 	}
 	m.groupMap[groupName] = result
 }
 
-func (m *Manager) userInCache(userFilter string) bool {
+func (m *Manager) userFromCache(userFilter string) (*User, bool) {
+	m.cacheMutex.RLock()
+	defer m.cacheMutex.RUnlock()
 	if m.userMap == nil {
-		return false
-	}
-	if _, ok := m.userMap[userFilter]; ok {
-		return true
+		return nil, false
 	}
-	return false
+	result, ok := m.userMap[userFilter]
+	return result, ok
 }
 
 func (m *Manager) addUserToCache(userFilter string, result *User) {
+	m.cacheMutex.Lock()
+	defer m.cacheMutex.Unlock()
 	if m.userMap == nil {
 		m.userMap = make(map[string]*User)
 	}
 	m.userMap[userFilter] = result
 }
 
-func (m *Manager) GetUserDNs(groupName string) ([]string, error) {
-	if m.groupInCache(groupName) {
+// GetUserDNs - returns the DNs of the members of the group and its nested
+// groups.  A *GroupError is returned when the group or one of its nested groups
+// is missing or ambiguous, or when it has no members, so callers can tell it
+// apart from the directory being unreachable.  allowEmptyGroups in ldap.yml
+// turns a group without members into a warning.
+func (m *Manager) GetUserDNs(ctx context.Context, groupName string) ([]string, error) {
+	userDNs, err := m.getUserDNs(ctx, groupName)
+	if err != nil {
+		return nil, err
+	}
+	if len(userDNs) == 0 {
+		if m.Config.AllowEmptyGroups {
+			lo.G.Warningf("No users found under group: %s", groupName)
+			return nil, nil
+		}
+		lo.G.Errorf("No users found under group: %s", groupName)
+		return nil, &GroupError{Group: groupName, Reason: GroupEmpty}
+	}
+	return userDNs, nil
+}
+
+func (m *Manager) getUserDNs(ctx context.Context, groupName string) ([]string, error) {
+	if userDNs, ok := m.groupFromCache(groupName); ok {
 		lo.G.Debugf("Group %s found in cache", groupName)
-		return m.groupMap[groupName], nil
+		return userDNs, nil
 	}
 	filter := fmt.Sprintf(groupFilter, l.EscapeFilter(groupName))
 	var groupEntry *l.Entry
@@ -87,7 +113,7 @@ func (m *Manager) GetUserDNs(groupName string) ([]string, error) {
 		filter,
 		attributes,
 		nil)
-	sr, err := m.Connection.Search(search)
+	sr, err := m.search(ctx, "group", search)
 	if err != nil {
 		lo.G.Error(err)
 		return nil, err
@@ -95,30 +121,25 @@ func (m *Manager) GetUserDNs(groupName string) ([]string, error) {
 
 	if len(sr.Entries) == 0 {
 		lo.G.Errorf("group not found: %s", groupName)
-		return []string{}, nil
+		return nil, &GroupError{Group: groupName, Reason: GroupNotFound}
 	}
 	if len(sr.Entries) > 1 {
 		lo.G.Errorf("multiple groups found for: %s", groupName)
-		return []string{}, nil
+		return nil, &GroupError{Group: groupName, Reason: GroupDuplicate}
 	}
 
 	groupEntry = sr.Entries[0]
 	userDNList := groupEntry.GetAttributeValues(m.Config.GroupAttribute)
-	if len(userDNList) == 0 {
-		lo.G.Warningf("No users found under group: %s", groupName)
-	}
 
 	userMap := make(map[string]string)
 	for _, userDN := range userDNList {
-		isGroup, nestedGroupName, err := m.IsGroup(userDN)
+		isGroup, nestedGroupName, err := m.IsGroup(ctx, userDN)
 		if err != nil {
 			return nil, err
 		}
 		if isGroup {
-			if err != nil {
-				return nil, err
-			}
-			nestedUsers, err := m.GetUserDNs(nestedGroupName)
+			// a nested group that can't be resolved leaves the members of the group unknown
+			nestedUsers, err := m.getUserDNs(ctx, nestedGroupName)
 			if err != nil {
 				return nil, err
 			}
@@ -153,7 +174,7 @@ func (m *Manager) GroupFilter(userDN string) (string, error) {
 	}
 	return fmt.Sprintf(groupFilterWithObjectClass, groupObjectFilter, escapedCN), nil
 }
-func (m *Manager) IsGroup(DN string) (bool, string, error) {
+func (m *Manager) IsGroup(ctx context.Context, DN string) (bool, string, error) {
 	if strings.Contains(DN, m.Config.GroupSearchBase) {
 		filter, err := m.GroupFilter(DN)
 		if err != nil {
@@ -165,7 +186,7 @@ func (m *Manager) IsGroup(DN string) (bool, string, error) {
 			filter,
 			attributes,
 			nil)
-		sr, err := m.Connection.Search(search)
+		sr, err := m.search(ctx, "is_group", search)
 		if err != nil {
 			return false, "", err
 		}
@@ -179,7 +200,7 @@ func (m *Manager) IsGroup(DN string) (bool, string, error) {
 	}
 }
 
-func (m *Manager) GetUserByDN(userDN string) (*User, error) {
+func (m *Manager) GetUserByDN(ctx context.Context, userDN string) (*User, error) {
 	cn, searchBase, err := ParseUserCN(userDN)
 	if err != nil {
 		return nil, err
@@ -191,18 +212,18 @@ func (m *Manager) GetUserByDN(userDN string) (*User, error) {
 	lo.G.Debug("CN escaped:", userCN)
 
 	filter := m.getUserFilterWithCN(userCN)
-	return m.searchUser(filter, searchBase, "")
+	return m.searchUser(ctx, filter, searchBase, "")
 }
 
-func (m *Manager) GetUserByID(userID string) (*User, error) {
+func (m *Manager) GetUserByID(ctx context.Context, userID string) (*User, error) {
 	filter := m.getUserFilter(userID)
-	return m.searchUser(filter, m.Config.UserSearchBase, userID)
+	return m.searchUser(ctx, filter, m.Config.UserSearchBase, userID)
 }
 
-func (m *Manager) searchUser(filter, searchBase, userID string) (*User, error) {
-	if m.userInCache(filter) {
+func (m *Manager) searchUser(ctx context.Context, filter, searchBase, userID string) (*User, error) {
+	if user, ok := m.userFromCache(filter); ok {
 		lo.G.Debugf("User with filter %s found in cache", filter)
-		return m.userMap[filter], nil
+		return user, nil
 	}
 	lo.G.Debugf("Searching with filter [%s]", filter)
 	lo.G.Debugf("Using user search base: [%s]", searchBase)
@@ -213,7 +234,7 @@ func (m *Manager) searchUser(filter, searchBase, userID string) (*User, error) {
 		attributes,
 		nil)
 
-	sr, err := m.Connection.Search(search)
+	sr, err := m.search(ctx, "user", search)
 	if err != nil {
 		lo.G.Error(err)
 		return nil, err
@@ -256,6 +277,22 @@ func (m *Manager) getUserFilterWithCN(cn string) string {
 	return fmt.Sprintf(userDNFilterWithObjectClass, m.Config.UserObjectClass, cn)
 }
 
+// search - searches the directory, counting the query by operation
+func (m *Manager) search(ctx context.Context, operation string, request *l.SearchRequest) (*l.SearchResult, error) {
+	result, err := m.Connection.Search(util.CallContext(ctx), request)
+	m.Metrics.ObserveLDAPQuery(operation, err)
+	return result, err
+}
+
+// ClearCache - forgets the groups and users looked up so far so the next lookup
+// sees the current directory
+func (m *Manager) ClearCache() {
+	m.cacheMutex.Lock()
+	defer m.cacheMutex.Unlock()
+	m.userMap = nil
+	m.groupMap = nil
+}
+
 func (m *Manager) Close() {
 	if m.Connection != nil {
 		m.Connection.Close()
@@ -1,6 +1,7 @@
 package ldap_test
 
 import (
+	"context"
 	"errors"
 
 	l "github.com/go-ldap/ldap/v3"
@@ -39,7 +40,7 @@ var _ = Describe("Ldap", func() {
 							}},
 					},
 				}, nil)
-				user, err := ldapManager.GetUserByID("cwashburn")
+				user, err := ldapManager.GetUserByID(context.Background(), "cwashburn")
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(user).ShouldNot(BeNil())
 				Expect(user.Email).Should(BeEquivalentTo("cwashburn@foo.com"))
@@ -62,17 +63,26 @@ var _ = Describe("Ldap", func() {
 							}},
 					},
 				}, nil)
-				user, err := ldapManager.GetUserByID("cwashburn")
+				user, err := ldapManager.GetUserByID(context.Background(), "cwashburn")
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(user).Should(BeNil())
 			})
 
 			It("should return error when search fails", func() {
 				connection.SearchReturns(nil, errors.New("Error searching"))
-				_, err := ldapManager.GetUserByID("cwashburn")
+				_, err := ldapManager.GetUserByID(context.Background(), "cwashburn")
 				Expect(err).Should(HaveOccurred())
 				Expect(err.Error()).Should(BeEquivalentTo("Error searching"))
 			})
+
+			It("searches with the context it is given", func() {
+				type key struct{}
+				connection.SearchReturns(&l.SearchResult{}, nil)
+				_, err := ldapManager.GetUserByID(context.WithValue(context.Background(), key{}, "request"), "cwashburn")
+				Expect(err).ShouldNot(HaveOccurred())
+				ctx, _ := connection.SearchArgsForCall(0)
+				Expect(ctx.Value(key{})).Should(Equal("request"))
+			})
 		})
 
 		Context("GetUserByDN()", func() {
@@ -87,14 +97,14 @@ var _ = Describe("Ldap", func() {
 							}},
 					},
 				}, nil)
-				user, err := ldapManager.GetUserByDN("cn=cwashburn,ou=users,dc=pivotal,dc=org")
+				user, err := ldapManager.GetUserByDN(context.Background(), "cn=cwashburn,ou=users,dc=pivotal,dc=org")
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(user).ShouldNot(BeNil())
 				Expect(user.Email).Should(BeEquivalentTo("cwashburn@foo.com"))
 				Expect(user.UserID).Should(BeEquivalentTo("cwashburn"))
 				Expect(user.UserDN).Should(BeEquivalentTo("cn=cwashburn,ou=users,dc=pivotal,dc=org"))
 
-				searchRequest := connection.SearchArgsForCall(0)
+				_, searchRequest := connection.SearchArgsForCall(0)
 				Expect(searchRequest.Filter).Should(BeEquivalentTo("(cn=cwashburn)"))
 			})
 
@@ -109,14 +119,14 @@ var _ = Describe("Ldap", func() {
 							}},
 					},
 				}, nil)
-				user, err := ldapManager.GetUserByDN(`cn=Washburn\, Caleb,ou=users,dc=pivotal,dc=org`)
+				user, err := ldapManager.GetUserByDN(context.Background(), `cn=Washburn\, Caleb,ou=users,dc=pivotal,dc=org`)
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(user).ShouldNot(BeNil())
 				Expect(user.Email).Should(BeEquivalentTo("cwashburn@foo.com"))
 				Expect(user.UserID).Should(BeEquivalentTo("cwashburn"))
 				Expect(user.UserDN).Should(BeEquivalentTo(`cn=Washburn\, Caleb,ou=users,dc=pivotal,dc=org`))
 
-				searchRequest := connection.SearchArgsForCall(0)
+				_, searchRequest := connection.SearchArgsForCall(0)
 				Expect(searchRequest.Filter).Should(BeEquivalentTo("(cn=Washburn, Caleb)"))
 			})
 
@@ -131,14 +141,14 @@ var _ = Describe("Ldap", func() {
 							}},
 					},
 				}, nil)
-				user, err := ldapManager.GetUserByDN("cn=Caleb A. Washburn,ou=users,dc=pivotal,dc=org")
+				user, err := ldapManager.GetUserByDN(context.Background(), "cn=Caleb A. Washburn,ou=users,dc=pivotal,dc=org")
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(user).ShouldNot(BeNil())
 				Expect(user.Email).Should(BeEquivalentTo("cwashburn@foo.com"))
 				Expect(user.UserID).Should(BeEquivalentTo("cwashburn"))
 				Expect(user.UserDN).Should(BeEquivalentTo("cn=Caleb A. Washburn,ou=users,dc=pivotal,dc=org"))
 
-				searchRequest := connection.SearchArgsForCall(0)
+				_, searchRequest := connection.SearchArgsForCall(0)
 				Expect(searchRequest.Filter).Should(BeEquivalentTo("(cn=Caleb A. Washburn)"))
 			})
 
@@ -159,20 +169,20 @@ var _ = Describe("Ldap", func() {
 							}},
 					},
 				}, nil)
-				user, err := ldapManager.GetUserByDN("cn=cwashburn,ou=users,dc=pivotal,dc=org")
+				user, err := ldapManager.GetUserByDN(context.Background(), "cn=cwashburn,ou=users,dc=pivotal,dc=org")
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(user).Should(BeNil())
 			})
 
 			It("should return error when search fails", func() {
 				connection.SearchReturns(nil, errors.New("Error searching"))
-				_, err := ldapManager.GetUserByDN("cn=cwashburn,ou=users,dc=pivotal,dc=org")
+				_, err := ldapManager.GetUserByDN(context.Background(), "cn=cwashburn,ou=users,dc=pivotal,dc=org")
 				Expect(err).Should(HaveOccurred())
 				Expect(err.Error()).Should(BeEquivalentTo("Error searching"))
 			})
 
 			It("should return error when invalid cn", func() {
-				_, err := ldapManager.GetUserByDN("cwashburn")
+				_, err := ldapManager.GetUserByDN(context.Background(), "cwashburn")
 				Expect(err).Should(HaveOccurred())
 				Expect(err.Error()).Should(BeEquivalentTo("cannot find CN for DN: cwashburn"))
 				Expect(connection.SearchCallCount()).Should(Equal(0))
@@ -191,46 +201,90 @@ var _ = Describe("Ldap", func() {
 							}},
 					},
 				}, nil)
-				users, err := ldapManager.GetUserDNs("group1")
+				users, err := ldapManager.GetUserDNs(context.Background(), "group1")
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(len(users)).Should(Equal(3))
 				Expect(users).Should(ConsistOf([]string{"cn=cwashburn,ou=users,dc=pivotal,dc=org", "cn=cwashburn1,ou=users,dc=pivotal,dc=org", `cn=Washburn\, Caleb,ou=users,dc=pivotal,dc=org`}))
 			})
 
-			It("should return empty list when group is not found", func() {
+			It("should return a group error when group is not found", func() {
 				connection.SearchReturns(&l.SearchResult{
 					Entries: []*l.Entry{},
 				}, nil)
-				users, err := ldapManager.GetUserDNs("group1")
-				Expect(err).ShouldNot(HaveOccurred())
+				users, err := ldapManager.GetUserDNs(context.Background(), "group1")
+				Expect(err).Should(MatchError(&ldap.GroupError{Group: "group1", Reason: ldap.GroupNotFound}))
 				Expect(len(users)).Should(Equal(0))
 			})
 
-			It("should return empty list when group has no users", func() {
+			It("should return a group error when group has no users", func() {
 				connection.SearchReturns(&l.SearchResult{
 					Entries: []*l.Entry{
 						{},
 					},
 				}, nil)
-				users, err := ldapManager.GetUserDNs("group1")
+				users, err := ldapManager.GetUserDNs(context.Background(), "group1")
+				Expect(err).Should(MatchError(&ldap.GroupError{Group: "group1", Reason: ldap.GroupEmpty}))
+				Expect(len(users)).Should(Equal(0))
+			})
+
+			It("should return no users when group has no users and empty groups are allowed", func() {
+				ldapConfig.AllowEmptyGroups = true
+				connection.SearchReturns(&l.SearchResult{
+					Entries: []*l.Entry{
+						{},
+					},
+				}, nil)
+				users, err := ldapManager.GetUserDNs(context.Background(), "group1")
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(len(users)).Should(Equal(0))
 			})
-			It("should return empty list when multiple groups are found", func() {
+
+			It("should return a group error when a nested group is not found", func() {
+				connection.SearchStub = func(_ context.Context, request *l.SearchRequest) (*l.SearchResult, error) {
+					switch request.Filter {
+					case "(cn=group1)":
+						return &l.SearchResult{
+							Entries: []*l.Entry{
+								{
+									Attributes: []*l.EntryAttribute{
+										{Name: "member", Values: []string{
+											"cn=cwashburn,ou=users,dc=pivotal,dc=org",
+											"cn=nested_group,ou=groups,dc=pivotal,dc=org",
+										}},
+									}},
+							},
+						}, nil
+					case "(&(objectclass=groupOfNames)(cn=nested_group))":
+						return &l.SearchResult{
+							Entries: []*l.Entry{
+								{
+									Attributes: []*l.EntryAttribute{
+										{Name: "cn", Values: []string{"nested_group"}},
+									}},
+							},
+						}, nil
+					}
+					return &l.SearchResult{}, nil
+				}
+				users, err := ldapManager.GetUserDNs(context.Background(), "group1")
+				Expect(err).Should(MatchError(&ldap.GroupError{Group: "nested_group", Reason: ldap.GroupNotFound}))
+				Expect(users).Should(BeEmpty())
+			})
+			It("should return a group error when multiple groups are found", func() {
 				connection.SearchReturns(&l.SearchResult{
 					Entries: []*l.Entry{
 						{},
 						{},
 					},
 				}, nil)
-				users, err := ldapManager.GetUserDNs("group1")
-				Expect(err).ShouldNot(HaveOccurred())
+				users, err := ldapManager.GetUserDNs(context.Background(), "group1")
+				Expect(err).Should(MatchError(&ldap.GroupError{Group: "group1", Reason: ldap.GroupDuplicate}))
 				Expect(len(users)).Should(Equal(0))
 			})
 
 			It("should return error when search fails", func() {
 				connection.SearchReturns(nil, errors.New("Error searching"))
-				_, err := ldapManager.GetUserDNs("group1")
+				_, err := ldapManager.GetUserDNs(context.Background(), "group1")
 				Expect(err).Should(HaveOccurred())
 				Expect(err.Error()).Should(BeEquivalentTo("Error searching"))
 			})
@@ -257,7 +311,7 @@ var _ = Describe("Ldap", func() {
 
 		Context("IsGroup()", func() {
 			It("Should return false", func() {
-				isGroup, groupName, err := ldapManager.IsGroup("foo")
+				isGroup, groupName, err := ldapManager.IsGroup(context.Background(), "foo")
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(isGroup).Should(BeFalse())
 				Expect(groupName).Should(Equal(""))
@@ -275,7 +329,7 @@ var _ = Describe("Ldap", func() {
 							}},
 					},
 				}, nil)
-				isGroup, groupName, err := ldapManager.IsGroup("cn=nested_group,ou=groups,dc=pivotal,dc=org")
+				isGroup, groupName, err := ldapManager.IsGroup(context.Background(), "cn=nested_group,ou=groups,dc=pivotal,dc=org")
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(isGroup).Should(BeTrue())
 				Expect(groupName).Should(Equal("nested_group"))
@@ -1,7 +1,10 @@
 package ldap
 
 import (
+	"sync"
+
 	"github.com/vmwarepivotallabs/cf-mgmt/config"
+	"github.com/vmwarepivotallabs/cf-mgmt/metrics"
 )
 
 // Manager -
@@ -10,6 +13,8 @@ type Manager struct {
 	Connection Connection
 	groupMap   map[string][]string
 	userMap    map[string]*User
+	cacheMutex sync.RWMutex
+	Metrics    *metrics.Metrics
 }
 
 // User -
@@ -1,6 +1,7 @@
 package ldap_integration_test
 
 import (
+	"context"
 	"os"
 	"strconv"
 
@@ -45,26 +46,26 @@ var _ = Describe("Ldap", func() {
 		})
 		Context("when cn with special characters", func() {
 			It("then it should return 1 Entry", func() {
-				entry, err := ldapManager.GetUserByDN(`cn=Washburn\2c Caleb,ou=users,dc=pivotal,dc=org`)
+				entry, err := ldapManager.GetUserByDN(context.Background(), `cn=Washburn\2c Caleb,ou=users,dc=pivotal,dc=org`)
 				Expect(err).Should(BeNil())
 				Expect(entry).ShouldNot(BeNil())
 			})
 			It("then it should return 1 Entry", func() {
-				entry, err := ldapManager.GetUserByDN("cn=Ekın Toğulmoç 88588,ou=users,dc=pivotal,dc=org")
+				entry, err := ldapManager.GetUserByDN(context.Background(), "cn=Ekın Toğulmoç 88588,ou=users,dc=pivotal,dc=org")
 				Expect(err).Should(BeNil())
 				Expect(entry).ShouldNot(BeNil())
 			})
 		})
 		Context("when cn has a period", func() {
 			It("then it should return 1 Entry", func() {
-				entry, err := ldapManager.GetUserByDN("cn=Caleb A. Washburn,ou=users,dc=pivotal,dc=org")
+				entry, err := ldapManager.GetUserByDN(context.Background(), "cn=Caleb A. Washburn,ou=users,dc=pivotal,dc=org")
 				Expect(err).Should(BeNil())
 				Expect(entry).ShouldNot(BeNil())
 			})
 		})
 		Context("when called with a valid group", func() {
 			It("then it should return 5 users", func() {
-				users, err := ldapManager.GetUserDNs("space_developers")
+				users, err := ldapManager.GetUserDNs(context.Background(), "space_developers")
 				Expect(err).Should(BeNil())
 				Expect(len(users)).Should(Equal(6))
 				Expect(users).To(ConsistOf([]string{
@@ -78,14 +79,14 @@ var _ = Describe("Ldap", func() {
 		})
 		Context("when called with a valid group with special characters", func() {
 			It("then it should return 4 users", func() {
-				users, err := ldapManager.GetUserDNs("special (char) group,name")
+				users, err := ldapManager.GetUserDNs(context.Background(), "special (char) group,name")
 				Expect(err).Should(BeNil())
 				Expect(len(users)).Should(Equal(4))
 			})
 		})
 		Context("GetUser()", func() {
 			It("then it should return 1 user", func() {
-				user, err := ldapManager.GetUserByID("cwashburn")
+				user, err := ldapManager.GetUserByID(context.Background(), "cwashburn")
 				Expect(err).Should(BeNil())
 				Expect(user).ShouldNot(BeNil())
 				Expect(user.UserID).Should(Equal("cwashburn"))
@@ -127,14 +128,14 @@ var _ = Describe("Ldap", func() {
 			})
 			Context("when cn with special characters", func() {
 				It("then it should return 1 Entry", func() {
-					entry, err := ldapManager.GetUserByDN(`cn=Washburn\2c Caleb,ou=users,dc=pivotal,dc=org`)
+					entry, err := ldapManager.GetUserByDN(context.Background(), `cn=Washburn\2c Caleb,ou=users,dc=pivotal,dc=org`)
 					Expect(err).Should(BeNil())
 					Expect(entry).ShouldNot(BeNil())
 				})
 			})
 			Context("GetUser()", func() {
 				It("then it should return 1 user", func() {
-					user, err := ldapManager.GetUserByID("cwashburn")
+					user, err := ldapManager.GetUserByID(context.Background(), "cwashburn")
 					Expect(err).Should(BeNil())
 					Expect(user).ShouldNot(BeNil())
 					Expect(user.UserID).Should(Equal("cwashburn"))
@@ -146,7 +147,7 @@ var _ = Describe("Ldap", func() {
 			Context("GetLdapUser()", func() {
 				It("then it should return 1 user", func() {
 					data, _ := os.ReadFile("./fixtures/user1.txt")
-					user, err := ldapManager.GetUserByDN(string(data))
+					user, err := ldapManager.GetUserByDN(context.Background(), string(data))
 					Expect(err).Should(BeNil())
 					Expect(user).ShouldNot(BeNil())
 					Expect(user.UserID).Should(Equal("cwashburn2"))
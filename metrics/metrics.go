@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
+)
+
+var (
+	// APIBuckets - upper bounds, in seconds, of the api request duration buckets
+	APIBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+	// StepBuckets - upper bounds, in seconds, of the apply step duration buckets
+	StepBuckets = []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120, 300, 600, 1800, 3600}
+)
+
+// Metrics - what cf-mgmt reports about its runs.  A nil Metrics is valid and
+// records nothing.
+type Metrics struct {
+	Registry           *Registry
+	Changes            *Counter
+	StepDuration       *Histogram
+	APIRequestDuration *Histogram
+	APIRetries         *Counter
+	LDAPQueries        *Counter
+	RunDuration        *Gauge
+	LastRunSuccess     *Gauge
+	LastRunTimestamp   *Gauge
+}
+
+// New -
+func New() *Metrics {
+	registry := NewRegistry()
+	return &Metrics{
+		Registry:           registry,
+		Changes:            registry.NewCounter("cf_mgmt_changes_total", "Changes applied to cloud foundry and uaa.", "entity", "action", "result"),
+		StepDuration:       registry.NewHistogram("cf_mgmt_step_duration_seconds", "Duration of each apply step.", StepBuckets, "step", "result"),
+		APIRequestDuration: registry.NewHistogram("cf_mgmt_api_request_duration_seconds", "Duration of requests to the cloud foundry, uaa and routing apis.", APIBuckets, "host", "method", "endpoint", "code"),
+		APIRetries:         registry.NewCounter("cf_mgmt_api_retries_total", "Requests to the cloud foundry and uaa apis that were retried, by status code or network.", "reason"),
+		LDAPQueries:        registry.NewCounter("cf_mgmt_ldap_queries_total", "LDAP searches made.", "operation", "result"),
+		RunDuration:        registry.NewGauge("cf_mgmt_run_duration_seconds", "Duration of the last run."),
+		LastRunSuccess:     registry.NewGauge("cf_mgmt_last_run_success", "1 when the last run succeeded, 0 when it failed."),
+		LastRunTimestamp:   registry.NewGauge("cf_mgmt_last_run_timestamp_seconds", "Unix time the last run finished."),
+	}
+}
+
+// Applied - counts a change applied by a manager, so Metrics can be used as a
+// changes.Sink
+func (m *Metrics) Applied(change changes.Change, guid string, err error) {
+	if m == nil {
+		return
+	}
+	m.Changes.Inc(string(change.Entity), string(change.Action), Result(err))
+}
+
+// ObserveStep -
+func (m *Metrics) ObserveStep(step string, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	m.StepDuration.Observe(duration.Seconds(), step, Result(err))
+}
+
+// ObserveRun - records the outcome of a whole run
+func (m *Metrics) ObserveRun(duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	m.RunDuration.Set(duration.Seconds())
+	success := 1.0
+	if err != nil {
+		success = 0
+	}
+	m.LastRunSuccess.Set(success)
+	m.LastRunTimestamp.Set(float64(time.Now().Unix()))
+}
+
+// ObserveLDAPQuery -
+func (m *Metrics) ObserveLDAPQuery(operation string, err error) {
+	if m == nil {
+		return
+	}
+	m.LDAPQueries.Inc(operation, Result(err))
+}
+
+// Result - the value of the result label for err
+func Result(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
@@ -0,0 +1,111 @@
+package metrics_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
+	"github.com/vmwarepivotallabs/cf-mgmt/metrics"
+)
+
+var _ = Describe("Metrics", func() {
+	text := func(registry *metrics.Registry) string {
+		out := &bytes.Buffer{}
+		Expect(registry.WriteText(out)).Should(Succeed())
+		return out.String()
+	}
+
+	Context("Registry", func() {
+		It("writes counters, gauges and histograms in the text format", func() {
+			registry := metrics.NewRegistry()
+			counter := registry.NewCounter("requests_total", "Requests made.", "code")
+			gauge := registry.NewGauge("up", "Whether it is up.")
+			histogram := registry.NewHistogram("duration_seconds", "How long it took.", []float64{1, 5}, "step")
+			counter.Inc("200")
+			counter.Add(2, "200")
+			counter.Inc(`a"b`)
+			gauge.Set(1)
+			histogram.Observe(0.5, "create")
+			histogram.Observe(3, "create")
+			histogram.Observe(10, "create")
+
+			Expect(text(registry)).Should(Equal(`# HELP requests_total Requests made.
+# TYPE requests_total counter
+requests_total{code="200"} 3
+requests_total{code="a\"b"} 1
+# HELP up Whether it is up.
+# TYPE up gauge
+up 1
+# HELP duration_seconds How long it took.
+# TYPE duration_seconds histogram
+duration_seconds_bucket{step="create",le="1"} 1
+duration_seconds_bucket{step="create",le="5"} 2
+duration_seconds_bucket{step="create",le="+Inf"} 3
+duration_seconds_sum{step="create"} 13.5
+duration_seconds_count{step="create"} 3
+`))
+		})
+
+		It("ignores updates to nil metrics", func() {
+			var counter *metrics.Counter
+			var gauge *metrics.Gauge
+			var histogram *metrics.Histogram
+			counter.Inc("a")
+			gauge.Set(1)
+			histogram.Observe(1)
+		})
+
+		It("writes a textfile", func() {
+			dir, err := os.MkdirTemp("", "cf-mgmt-metrics")
+			Expect(err).ShouldNot(HaveOccurred())
+			defer os.RemoveAll(dir)
+			registry := metrics.NewRegistry()
+			registry.NewGauge("up", "Whether it is up.").Set(1)
+			path := filepath.Join(dir, "cf-mgmt.prom")
+			Expect(registry.WriteTextfile(path)).Should(Succeed())
+			contents, err := os.ReadFile(path)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(contents)).Should(ContainSubstring("up 1\n"))
+			files, err := os.ReadDir(dir)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(files).Should(HaveLen(1))
+		})
+	})
+
+	It("counts applied changes by entity, action and result", func() {
+		m := metrics.New()
+		m.Applied(changes.Change{Entity: changes.Org, Action: changes.Create, Name: "foo"}, "guid", nil)
+		m.Applied(changes.Change{Entity: changes.Org, Action: changes.Create, Name: "bar"}, "", errors.New("boom"))
+		m.ObserveStep("Creating Orgs", time.Second, nil)
+		output := text(m.Registry)
+		Expect(output).Should(ContainSubstring(`cf_mgmt_changes_total{entity="org",action="create",result="success"} 1`))
+		Expect(output).Should(ContainSubstring(`cf_mgmt_changes_total{entity="org",action="create",result="failure"} 1`))
+		Expect(output).Should(ContainSubstring(`cf_mgmt_step_duration_seconds_count{step="Creating Orgs",result="success"} 1`))
+	})
+
+	It("times api requests by endpoint", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+		m := metrics.New()
+		client := &http.Client{Transport: metrics.NewTransport(http.DefaultTransport, m)}
+		resp, err := client.Get(server.URL + "/v3/organizations/6d5b3a3c-6f0e-4a4f-9b1a-3f4c2f1e0a11/users")
+		Expect(err).ShouldNot(HaveOccurred())
+		resp.Body.Close()
+		Expect(text(m.Registry)).Should(ContainSubstring(`cf_mgmt_api_request_duration_seconds_count{host="127.0.0.1",method="GET",endpoint="/v3/organizations/:guid/users",code="404"} 1`))
+	})
+
+	It("replaces ids in endpoints", func() {
+		Expect(metrics.Endpoint("/Users/6d5b3a3c-6f0e-4a4f-9b1a-3f4c2f1e0a11")).Should(Equal("/Users/:guid"))
+		Expect(metrics.Endpoint("/v3/jobs/12")).Should(Equal("/v3/jobs/:guid"))
+		Expect(metrics.Endpoint("/v3/spaces")).Should(Equal("/v3/spaces"))
+	})
+})
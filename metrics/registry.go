@@ -0,0 +1,261 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	counterType   = "counter"
+	gaugeType     = "gauge"
+	histogramType = "histogram"
+)
+
+// Registry - holds metrics and writes them in the prometheus text format
+type Registry struct {
+	mutex    sync.Mutex
+	families []*family
+}
+
+type family struct {
+	name    string
+	help    string
+	kind    string
+	labels  []string
+	buckets []float64
+	series  map[string]*series
+}
+
+type series struct {
+	labelValues []string
+	value       float64
+	counts      []uint64
+	sum         float64
+	count       uint64
+}
+
+// NewRegistry -
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func (r *Registry) register(name, help, kind string, buckets []float64, labels []string) *family {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	f := &family{
+		name:    name,
+		help:    help,
+		kind:    kind,
+		labels:  labels,
+		buckets: buckets,
+		series:  make(map[string]*series),
+	}
+	r.families = append(r.families, f)
+	return f
+}
+
+// with - the series for the label values, created on first use.  Must be called
+// with the registry mutex held.
+func (f *family) with(labelValues []string) *series {
+	if len(labelValues) != len(f.labels) {
+		panic(fmt.Sprintf("metric %s expects %d label values, got %d", f.name, len(f.labels), len(labelValues)))
+	}
+	key := strings.Join(labelValues, "\xff")
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{labelValues: append([]string(nil), labelValues...)}
+		if f.kind == histogramType {
+			s.counts = make([]uint64, len(f.buckets))
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+// Counter - a value that only goes up.  A nil Counter is valid and ignores
+// every update.
+type Counter struct {
+	registry *Registry
+	family   *family
+}
+
+// NewCounter -
+func (r *Registry) NewCounter(name, help string, labels ...string) *Counter {
+	return &Counter{registry: r, family: r.register(name, help, counterType, nil, labels)}
+}
+
+// Inc - adds one to the series with the label values
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add -
+func (c *Counter) Add(value float64, labelValues ...string) {
+	if c == nil {
+		return
+	}
+	c.registry.mutex.Lock()
+	defer c.registry.mutex.Unlock()
+	c.family.with(labelValues).value += value
+}
+
+// Gauge - a value that can be set to anything.  A nil Gauge is valid and
+// ignores every update.
+type Gauge struct {
+	registry *Registry
+	family   *family
+}
+
+// NewGauge -
+func (r *Registry) NewGauge(name, help string, labels ...string) *Gauge {
+	return &Gauge{registry: r, family: r.register(name, help, gaugeType, nil, labels)}
+}
+
+// Set -
+func (g *Gauge) Set(value float64, labelValues ...string) {
+	if g == nil {
+		return
+	}
+	g.registry.mutex.Lock()
+	defer g.registry.mutex.Unlock()
+	g.family.with(labelValues).value = value
+}
+
+// Histogram - counts observations in cumulative buckets.  A nil Histogram is
+// valid and ignores every observation.
+type Histogram struct {
+	registry *Registry
+	family   *family
+}
+
+// NewHistogram - buckets are the upper bounds, in increasing order, of every
+// bucket but +Inf
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	return &Histogram{registry: r, family: r.register(name, help, histogramType, buckets, labels)}
+}
+
+// Observe -
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	if h == nil {
+		return
+	}
+	h.registry.mutex.Lock()
+	defer h.registry.mutex.Unlock()
+	s := h.family.with(labelValues)
+	for i, upperBound := range h.family.buckets {
+		if value <= upperBound {
+			s.counts[i]++
+		}
+	}
+	s.sum += value
+	s.count++
+}
+
+// WriteText - writes every metric in the prometheus text exposition format
+func (r *Registry) WriteText(out io.Writer) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	w := bufio.NewWriter(out)
+	for _, f := range r.families {
+		fmt.Fprintf(w, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+		fmt.Fprintf(w, "# TYPE %s %s\n", f.name, f.kind)
+		keys := make([]string, 0, len(f.series))
+		for key := range f.series {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			s := f.series[key]
+			if f.kind != histogramType {
+				fmt.Fprintf(w, "%s%s %s\n", f.name, labelPairs(f.labels, s.labelValues, "", ""), formatFloat(s.value))
+				continue
+			}
+			for i, upperBound := range f.buckets {
+				fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, labelPairs(f.labels, s.labelValues, "le", formatFloat(upperBound)), s.counts[i])
+			}
+			fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, labelPairs(f.labels, s.labelValues, "le", "+Inf"), s.count)
+			fmt.Fprintf(w, "%s_sum%s %s\n", f.name, labelPairs(f.labels, s.labelValues, "", ""), formatFloat(s.sum))
+			fmt.Fprintf(w, "%s_count%s %d\n", f.name, labelPairs(f.labels, s.labelValues, "", ""), s.count)
+		}
+	}
+	return w.Flush()
+}
+
+// WriteTextfile - writes the metrics to path for the node exporter textfile
+// collector, replacing the file atomically so a half written file is never
+// collected
+func (r *Registry) WriteTextfile(path string) error {
+	tempFile, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("unable to write metrics to %s: %v", path, err)
+	}
+	defer os.Remove(tempFile.Name())
+	if err := r.WriteText(tempFile); err != nil {
+		tempFile.Close()
+		return fmt.Errorf("unable to write metrics to %s: %v", path, err)
+	}
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("unable to write metrics to %s: %v", path, err)
+	}
+	if err := os.Chmod(tempFile.Name(), 0644); err != nil {
+		return fmt.Errorf("unable to write metrics to %s: %v", path, err)
+	}
+	if err := os.Rename(tempFile.Name(), path); err != nil {
+		return fmt.Errorf("unable to write metrics to %s: %v", path, err)
+	}
+	return nil
+}
+
+// Handler - serves the metrics for prometheus to scrape
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		r.WriteText(w)
+	})
+}
+
+func labelPairs(labels, values []string, extraLabel, extraValue string) string {
+	if len(labels) == 0 && extraLabel == "" {
+		return ""
+	}
+	pairs := make([]string, 0, len(labels)+1)
+	for i, label := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", label, escapeLabelValue(values[i])))
+	}
+	if extraLabel != "" {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", extraLabel, extraValue))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+var (
+	helpEscaper       = strings.NewReplacer("\\", `\\`, "\n", `\n`)
+	labelValueEscaper = strings.NewReplacer("\\", `\\`, "\n", `\n`, "\"", `\"`)
+)
+
+func escapeHelp(help string) string {
+	return helpEscaper.Replace(help)
+}
+
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Transport - times every request made through it by host, method, endpoint
+// and status code
+type Transport struct {
+	base    http.RoundTripper
+	metrics *Metrics
+}
+
+// NewTransport -
+func NewTransport(roundTripper http.RoundTripper, metrics *Metrics) *Transport {
+	return &Transport{base: roundTripper, metrics: metrics}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	if t.metrics != nil {
+		t.metrics.APIRequestDuration.Observe(time.Since(start).Seconds(), req.URL.Hostname(), req.Method, Endpoint(req.URL.Path), code)
+	}
+	return resp, err
+}
+
+var idSegment = regexp.MustCompile(`^([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|[0-9]+)$`)
+
+// Endpoint - the path with guids and numeric ids replaced by :guid so requests
+// for different entities share a label value
+func Endpoint(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if idSegment.MatchString(segment) {
+			segments[i] = ":guid"
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -2,15 +2,17 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/vmwarepivotallabs/cf-mgmt/organization"
 )
 
 type FakeManager struct {
-	CreateOrgsStub        func() error
+	CreateOrgsStub        func(context.Context) error
 	createOrgsMutex       sync.RWMutex
 	createOrgsArgsForCall []struct {
+		arg1 context.Context
 	}
 	createOrgsReturns struct {
 		result1 error
@@ -18,9 +20,10 @@ type FakeManager struct {
 	createOrgsReturnsOnCall map[int]struct {
 		result1 error
 	}
-	DeleteOrgsStub        func() error
+	DeleteOrgsStub        func(context.Context) error
 	deleteOrgsMutex       sync.RWMutex
 	deleteOrgsArgsForCall []struct {
+		arg1 context.Context
 	}
 	deleteOrgsReturns struct {
 		result1 error
@@ -28,11 +31,12 @@ type FakeManager struct {
 	deleteOrgsReturnsOnCall map[int]struct {
 		result1 error
 	}
-	RenameOrgStub        func(string, string) error
+	RenameOrgStub        func(context.Context, string, string) error
 	renameOrgMutex       sync.RWMutex
 	renameOrgArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	renameOrgReturns struct {
 		result1 error
@@ -40,9 +44,10 @@ type FakeManager struct {
 	renameOrgReturnsOnCall map[int]struct {
 		result1 error
 	}
-	UpdateOrgsMetadataStub        func() error
+	UpdateOrgsMetadataStub        func(context.Context) error
 	updateOrgsMetadataMutex       sync.RWMutex
 	updateOrgsMetadataArgsForCall []struct {
+		arg1 context.Context
 	}
 	updateOrgsMetadataReturns struct {
 		result1 error
@@ -54,17 +59,18 @@ type FakeManager struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeManager) CreateOrgs() error {
+func (fake *FakeManager) CreateOrgs(arg1 context.Context) error {
 	fake.createOrgsMutex.Lock()
 	ret, specificReturn := fake.createOrgsReturnsOnCall[len(fake.createOrgsArgsForCall)]
 	fake.createOrgsArgsForCall = append(fake.createOrgsArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.CreateOrgsStub
 	fakeReturns := fake.createOrgsReturns
-	fake.recordInvocation("CreateOrgs", []interface{}{})
+	fake.recordInvocation("CreateOrgs", []interface{}{arg1})
 	fake.createOrgsMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -78,12 +84,19 @@ func (fake *FakeManager) CreateOrgsCallCount() int {
 	return len(fake.createOrgsArgsForCall)
 }
 
-func (fake *FakeManager) CreateOrgsCalls(stub func() error) {
+func (fake *FakeManager) CreateOrgsCalls(stub func(context.Context) error) {
 	fake.createOrgsMutex.Lock()
 	defer fake.createOrgsMutex.Unlock()
 	fake.CreateOrgsStub = stub
 }
 
+func (fake *FakeManager) CreateOrgsArgsForCall(i int) context.Context {
+	fake.createOrgsMutex.RLock()
+	defer fake.createOrgsMutex.RUnlock()
+	argsForCall := fake.createOrgsArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeManager) CreateOrgsReturns(result1 error) {
 	fake.createOrgsMutex.Lock()
 	defer fake.createOrgsMutex.Unlock()
@@ -107,17 +120,18 @@ func (fake *FakeManager) CreateOrgsReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeManager) DeleteOrgs() error {
+func (fake *FakeManager) DeleteOrgs(arg1 context.Context) error {
 	fake.deleteOrgsMutex.Lock()
 	ret, specificReturn := fake.deleteOrgsReturnsOnCall[len(fake.deleteOrgsArgsForCall)]
 	fake.deleteOrgsArgsForCall = append(fake.deleteOrgsArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.DeleteOrgsStub
 	fakeReturns := fake.deleteOrgsReturns
-	fake.recordInvocation("DeleteOrgs", []interface{}{})
+	fake.recordInvocation("DeleteOrgs", []interface{}{arg1})
 	fake.deleteOrgsMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -131,12 +145,19 @@ func (fake *FakeManager) DeleteOrgsCallCount() int {
 	return len(fake.deleteOrgsArgsForCall)
 }
 
-func (fake *FakeManager) DeleteOrgsCalls(stub func() error) {
+func (fake *FakeManager) DeleteOrgsCalls(stub func(context.Context) error) {
 	fake.deleteOrgsMutex.Lock()
 	defer fake.deleteOrgsMutex.Unlock()
 	fake.DeleteOrgsStub = stub
 }
 
+func (fake *FakeManager) DeleteOrgsArgsForCall(i int) context.Context {
+	fake.deleteOrgsMutex.RLock()
+	defer fake.deleteOrgsMutex.RUnlock()
+	argsForCall := fake.deleteOrgsArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeManager) DeleteOrgsReturns(result1 error) {
 	fake.deleteOrgsMutex.Lock()
 	defer fake.deleteOrgsMutex.Unlock()
@@ -160,19 +181,20 @@ func (fake *FakeManager) DeleteOrgsReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeManager) RenameOrg(arg1 string, arg2 string) error {
+func (fake *FakeManager) RenameOrg(arg1 context.Context, arg2 string, arg3 string) error {
 	fake.renameOrgMutex.Lock()
 	ret, specificReturn := fake.renameOrgReturnsOnCall[len(fake.renameOrgArgsForCall)]
 	fake.renameOrgArgsForCall = append(fake.renameOrgArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
 	stub := fake.RenameOrgStub
 	fakeReturns := fake.renameOrgReturns
-	fake.recordInvocation("RenameOrg", []interface{}{arg1, arg2})
+	fake.recordInvocation("RenameOrg", []interface{}{arg1, arg2, arg3})
 	fake.renameOrgMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -186,17 +208,17 @@ func (fake *FakeManager) RenameOrgCallCount() int {
 	return len(fake.renameOrgArgsForCall)
 }
 
-func (fake *FakeManager) RenameOrgCalls(stub func(string, string) error) {
+func (fake *FakeManager) RenameOrgCalls(stub func(context.Context, string, string) error) {
 	fake.renameOrgMutex.Lock()
 	defer fake.renameOrgMutex.Unlock()
 	fake.RenameOrgStub = stub
 }
 
-func (fake *FakeManager) RenameOrgArgsForCall(i int) (string, string) {
+func (fake *FakeManager) RenameOrgArgsForCall(i int) (context.Context, string, string) {
 	fake.renameOrgMutex.RLock()
 	defer fake.renameOrgMutex.RUnlock()
 	argsForCall := fake.renameOrgArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeManager) RenameOrgReturns(result1 error) {
@@ -222,17 +244,18 @@ func (fake *FakeManager) RenameOrgReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeManager) UpdateOrgsMetadata() error {
+func (fake *FakeManager) UpdateOrgsMetadata(arg1 context.Context) error {
 	fake.updateOrgsMetadataMutex.Lock()
 	ret, specificReturn := fake.updateOrgsMetadataReturnsOnCall[len(fake.updateOrgsMetadataArgsForCall)]
 	fake.updateOrgsMetadataArgsForCall = append(fake.updateOrgsMetadataArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.UpdateOrgsMetadataStub
 	fakeReturns := fake.updateOrgsMetadataReturns
-	fake.recordInvocation("UpdateOrgsMetadata", []interface{}{})
+	fake.recordInvocation("UpdateOrgsMetadata", []interface{}{arg1})
 	fake.updateOrgsMetadataMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -246,12 +269,19 @@ func (fake *FakeManager) UpdateOrgsMetadataCallCount() int {
 	return len(fake.updateOrgsMetadataArgsForCall)
 }
 
-func (fake *FakeManager) UpdateOrgsMetadataCalls(stub func() error) {
+func (fake *FakeManager) UpdateOrgsMetadataCalls(stub func(context.Context) error) {
 	fake.updateOrgsMetadataMutex.Lock()
 	defer fake.updateOrgsMetadataMutex.Unlock()
 	fake.UpdateOrgsMetadataStub = stub
 }
 
+func (fake *FakeManager) UpdateOrgsMetadataArgsForCall(i int) context.Context {
+	fake.updateOrgsMetadataMutex.RLock()
+	defer fake.updateOrgsMetadataMutex.RUnlock()
+	argsForCall := fake.updateOrgsMetadataArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeManager) UpdateOrgsMetadataReturns(result1 error) {
 	fake.updateOrgsMetadataMutex.Lock()
 	defer fake.updateOrgsMetadataMutex.Unlock()
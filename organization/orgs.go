@@ -6,7 +6,9 @@ import (
 	"strings"
 
 	"github.com/cloudfoundry-community/go-cfclient/v3/resource"
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
 	"github.com/vmwarepivotallabs/cf-mgmt/config"
+	"github.com/vmwarepivotallabs/cf-mgmt/failures"
 	"github.com/vmwarepivotallabs/cf-mgmt/organizationreader"
 	"github.com/vmwarepivotallabs/cf-mgmt/space"
 	"github.com/vmwarepivotallabs/cf-mgmt/util"
@@ -14,33 +16,39 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
-func NewManager(orgClient CFOrgClient, orgReader organizationreader.Reader, cfg config.Reader, peek bool) Manager {
+func NewManager(orgClient CFOrgClient, orgReader organizationreader.Reader, cfg config.Reader, allowLargeDeletions bool, failures *failures.Collector, recorder *changes.Recorder, peek bool) Manager {
 	return &DefaultManager{
-		Cfg:       cfg,
-		OrgReader: orgReader,
-		OrgClient: orgClient,
-		Peek:      peek,
+		Cfg:                 cfg,
+		OrgReader:           orgReader,
+		OrgClient:           orgClient,
+		AllowLargeDeletions: allowLargeDeletions,
+		Failures:            failures,
+		Recorder:            recorder,
+		Peek:                peek,
 	}
 }
 
 // DefaultManager -
 type DefaultManager struct {
-	Cfg       config.Reader
-	OrgReader organizationreader.Reader
-	OrgClient CFOrgClient
-	SpaceMgr  space.Manager
-	Peek      bool
+	Cfg                 config.Reader
+	OrgReader           organizationreader.Reader
+	OrgClient           CFOrgClient
+	SpaceMgr            space.Manager
+	AllowLargeDeletions bool
+	Failures            *failures.Collector
+	Recorder            *changes.Recorder
+	Peek                bool
 }
 
 // CreateOrgs -
-func (m *DefaultManager) CreateOrgs() error {
+func (m *DefaultManager) CreateOrgs(ctx context.Context) error {
 	m.OrgReader.ClearOrgList()
 	desiredOrgs, err := m.Cfg.GetOrgConfigs()
 	if err != nil {
 		return err
 	}
 
-	currentOrgs, err := m.OrgReader.ListOrgs()
+	currentOrgs, err := m.OrgReader.ListOrgs(ctx)
 	if err != nil {
 		return err
 	}
@@ -55,22 +63,10 @@ func (m *DefaultManager) CreateOrgs() error {
 	}
 
 	for _, org := range desiredOrgs {
-		if _, ok := orgsSet[org.Org]; !ok {
-			return fmt.Errorf("[%s] found in an orgConfig but not in orgs.yml", org.Org)
-		}
-		if doesOrgExist(org.Org, currentOrgs) {
-			lo.G.Debugf("[%s] org already exists", org.Org)
-			continue
-		} else if doesOrgExistFromRename(org.OriginalOrg, currentOrgs) {
-			lo.G.Debugf("renamed org [%s] already exists as [%s]", org.Org, org.OriginalOrg)
-			if err := m.RenameOrg(org.OriginalOrg, org.Org); err != nil {
-				return err
-			}
-			continue
-		} else {
-			lo.G.Debugf("[%s] org doesn't exist in list [%v]", org.Org, desiredOrgs)
+		if err := ctx.Err(); err != nil {
+			return err
 		}
-		if err := m.CreateOrg(org.Org, m.orgNames(currentOrgs)); err != nil {
+		if err := m.Failures.Add(org.Org, "", m.createOrg(ctx, org, desiredOrgs, currentOrgs, orgsSet)); err != nil {
 			return err
 		}
 	}
@@ -78,8 +74,24 @@ func (m *DefaultManager) CreateOrgs() error {
 	return nil
 }
 
+func (m *DefaultManager) createOrg(ctx context.Context, org config.OrgConfig, desiredOrgs []config.OrgConfig, currentOrgs []*resource.Organization, orgsSet map[string]struct{}) error {
+	if _, ok := orgsSet[org.Org]; !ok {
+		return fmt.Errorf("[%s] found in an orgConfig but not in orgs.yml", org.Org)
+	}
+	if doesOrgExist(org.Org, currentOrgs) {
+		lo.G.Debugf("[%s] org already exists", org.Org)
+		return nil
+	} else if doesOrgExistFromRename(org.OriginalOrg, currentOrgs) {
+		lo.G.Debugf("renamed org [%s] already exists as [%s]", org.Org, org.OriginalOrg)
+		return m.RenameOrg(ctx, org.OriginalOrg, org.Org)
+	} else {
+		lo.G.Debugf("[%s] org doesn't exist in list [%v]", org.Org, desiredOrgs)
+	}
+	return m.CreateOrg(ctx, org.Org, m.orgNames(currentOrgs))
+}
+
 // DeleteOrgs -
-func (m *DefaultManager) DeleteOrgs() error {
+func (m *DefaultManager) DeleteOrgs(ctx context.Context) error {
 	m.OrgReader.ClearOrgList()
 	orgsConfig, err := m.Cfg.Orgs()
 	if err != nil {
@@ -104,14 +116,19 @@ func (m *DefaultManager) DeleteOrgs() error {
 		configuredOrgs[orgName] = true
 	}
 
-	orgs, err := m.OrgReader.ListOrgs()
+	orgs, err := m.OrgReader.ListOrgs(ctx)
 	if err != nil {
 		return err
 	}
 
+	selection := config.SelectionOf(m.Cfg)
 	orgsToDelete := make([]*resource.Organization, 0)
 	for _, org := range orgs {
 		if _, exists := configuredOrgs[org.Name]; !exists {
+			if !selection.IncludesUnconfiguredOrg(org.Name) {
+				lo.G.Debugf("Org [%s] is not selected - will not be deleted", org.Name)
+				continue
+			}
 			if !util.Matches(org.Name, orgsConfig.ProtectedOrgList()) {
 				if _, renamed := renamedOrgs[org.Name]; !renamed {
 					orgsToDelete = append(orgsToDelete, org)
@@ -122,11 +139,24 @@ func (m *DefaultManager) DeleteOrgs() error {
 		}
 	}
 
+	if len(orgsToDelete) > 0 && !m.AllowLargeDeletions {
+		globalCfg, err := m.Cfg.GetGlobalConfig()
+		if err != nil {
+			return err
+		}
+		if err := globalCfg.DeletionLimits.Orgs.Check("orgs", len(orgsToDelete), len(orgs)); err != nil {
+			return err
+		}
+	}
+
 	for _, org := range orgsToDelete {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		// if err := m.SpaceMgr.DeleteSpacesForOrg(org.GUID, org.Name); err != nil {
 		// 	return err
 		// }
-		if err := m.DeleteOrg(org); err != nil {
+		if err := m.Failures.Add(org.Name, "", m.DeleteOrg(ctx, org)); err != nil {
 			return err
 		}
 	}
@@ -160,15 +190,26 @@ func (m *DefaultManager) orgNames(orgs []*resource.Organization) []string {
 	return orgNames
 }
 
-func (m *DefaultManager) CreateOrg(orgName string, currentOrgs []string) error {
+func (m *DefaultManager) CreateOrg(ctx context.Context, orgName string, currentOrgs []string) error {
+	change := changes.Change{Entity: changes.Org, Action: changes.Create, Name: orgName, Org: orgName}
+	m.Recorder.Record(change)
 	if m.Peek {
 		lo.G.Infof("[dry-run]: create org %s as it doesn't exist in %v", orgName, currentOrgs)
+		m.OrgReader.AddOrgToList(&resource.Organization{
+			Name: orgName,
+			GUID: fmt.Sprintf("%s-dry-run-org-guid", orgName),
+		})
 		return nil
 	}
 	lo.G.Infof("create org %s as it doesn't exist in %v", orgName, currentOrgs)
-	org, err := m.OrgClient.Create(context.Background(), &resource.OrganizationCreate{
+	org, err := m.OrgClient.Create(util.CallContext(ctx), &resource.OrganizationCreate{
 		Name: orgName,
 	})
+	var guid string
+	if org != nil {
+		guid = org.GUID
+	}
+	m.Recorder.Applied(change, guid, err)
 	if err != nil {
 		return err
 	}
@@ -176,47 +217,59 @@ func (m *DefaultManager) CreateOrg(orgName string, currentOrgs []string) error {
 	return nil
 }
 
-func (m *DefaultManager) RenameOrg(originalOrgName, newOrgName string) error {
+func (m *DefaultManager) RenameOrg(ctx context.Context, originalOrgName, newOrgName string) error {
+	change := changes.Change{Entity: changes.Org, Action: changes.Update, Name: newOrgName, Org: newOrgName, Before: originalOrgName, After: newOrgName}
+	m.Recorder.Record(change)
 	if m.Peek {
 		lo.G.Infof("[dry-run]: renaming org %s to %s", originalOrgName, newOrgName)
+		org, err := m.OrgReader.FindOrg(ctx, originalOrgName)
+		if err != nil {
+			return err
+		}
+		renamedOrg := *org
+		renamedOrg.Name = newOrgName
+		m.OrgReader.AddOrgToList(&renamedOrg)
 		return nil
 	}
 	lo.G.Infof("renaming org %s to %s", originalOrgName, newOrgName)
-	org, err := m.OrgReader.FindOrg(originalOrgName)
+	org, err := m.OrgReader.FindOrg(ctx, originalOrgName)
 	if err != nil {
 		return err
 	}
-	_, err = m.updateOrg(org.GUID, &resource.OrganizationUpdate{
+	_, err = m.updateOrg(ctx, change, org.GUID, &resource.OrganizationUpdate{
 		Name: newOrgName,
 	})
 	org.Name = newOrgName
 	return err
 }
 
-func (m *DefaultManager) DeleteOrg(org *resource.Organization) error {
+func (m *DefaultManager) DeleteOrg(ctx context.Context, org *resource.Organization) error {
+	change := changes.Change{Entity: changes.Org, Action: changes.Delete, Name: org.Name, Org: org.Name}
+	m.Recorder.Record(change)
 	if m.Peek {
 		lo.G.Infof("[dry-run]: delete org %s", org.Name)
 		return nil
 	}
 	lo.G.Infof("Deleting [%s] org", org.Name)
-	_, err := m.OrgClient.Delete(context.Background(), org.GUID)
+	_, err := m.OrgClient.Delete(util.CallContext(ctx), org.GUID)
+	m.Recorder.Applied(change, org.GUID, err)
 	return err
 }
 
-func (m *DefaultManager) DeleteOrgByName(orgName string) error {
-	orgs, err := m.OrgReader.ListOrgs()
+func (m *DefaultManager) DeleteOrgByName(ctx context.Context, orgName string) error {
+	orgs, err := m.OrgReader.ListOrgs(ctx)
 	if err != nil {
 		return err
 	}
 	for _, org := range orgs {
 		if org.Name == orgName {
-			return m.DeleteOrg(org)
+			return m.DeleteOrg(ctx, org)
 		}
 	}
 	return fmt.Errorf("org[%s] not found", orgName)
 }
 
-func (m *DefaultManager) updateOrg(orgGUID string, orgRequest *resource.OrganizationUpdate) (*resource.Organization, error) {
+func (m *DefaultManager) updateOrg(ctx context.Context, change changes.Change, orgGUID string, orgRequest *resource.OrganizationUpdate) (*resource.Organization, error) {
 	if m.Peek {
 		lo.G.Infof("[dry-run]: update org %s", orgRequest.Name)
 		return &resource.Organization{
@@ -224,10 +277,12 @@ func (m *DefaultManager) updateOrg(orgGUID string, orgRequest *resource.Organiza
 			Name: orgRequest.Name,
 		}, nil
 	}
-	return m.OrgClient.Update(context.Background(), orgGUID, orgRequest)
+	org, err := m.OrgClient.Update(util.CallContext(ctx), orgGUID, orgRequest)
+	m.Recorder.Applied(change, orgGUID, err)
+	return org, err
 }
 
-func (m *DefaultManager) UpdateOrgsMetadata() error {
+func (m *DefaultManager) UpdateOrgsMetadata(ctx context.Context) error {
 	orgConfigList, err := m.Cfg.GetOrgConfigs()
 	if err != nil {
 		return err
@@ -239,98 +294,114 @@ func (m *DefaultManager) UpdateOrgsMetadata() error {
 	}
 
 	for _, orgConfig := range orgConfigList {
-		if orgConfig.Metadata != nil {
-			org, err := m.OrgReader.FindOrg(orgConfig.Org)
-			if err != nil {
-				return err
-			}
-			if org.Metadata == nil {
-				org.Metadata = &resource.Metadata{}
-			}
-			orgYamlOriginal, err := yaml.Marshal(org.Metadata)
-			if err != nil {
-				return err
-			}
-			//clear any labels that start with the prefix
-			for key, _ := range org.Metadata.Labels {
-				if strings.Contains(key, globalCfg.MetadataPrefix) {
-					org.Metadata.Labels[key] = nil
-				}
-			}
-			if orgConfig.Metadata.Labels != nil {
-				for key, value := range orgConfig.Metadata.Labels {
-					if len(value) > 0 {
-						if globalCfg.UseMetadataPrefix {
-							if strings.Contains(key, globalCfg.MetadataPrefix) {
-								org.Metadata.SetLabel(globalCfg.MetadataPrefix, strings.ReplaceAll(key, globalCfg.MetadataPrefix+"/", ""), value)
-							} else {
-								org.Metadata.SetLabel(globalCfg.MetadataPrefix, key, value)
-							}
-						} else {
-							org.Metadata.SetLabel("", key, value)
-						}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if m.Failures.Skip(orgConfig.Org, "") {
+			lo.G.Infof("skipping org [%s] metadata as the org failed in an earlier step", orgConfig.Org)
+			continue
+		}
+		if err := m.Failures.Add(orgConfig.Org, "", m.updateOrgMetadata(ctx, globalCfg, orgConfig)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *DefaultManager) updateOrgMetadata(ctx context.Context, globalCfg *config.GlobalConfig, orgConfig config.OrgConfig) error {
+	if orgConfig.Metadata == nil {
+		return nil
+	}
+	org, err := m.OrgReader.FindOrg(ctx, orgConfig.Org)
+	if err != nil {
+		return err
+	}
+	if org.Metadata == nil {
+		org.Metadata = &resource.Metadata{}
+	}
+	orgYamlOriginal, err := yaml.Marshal(org.Metadata)
+	if err != nil {
+		return err
+	}
+	//clear any labels that start with the prefix
+	for key, _ := range org.Metadata.Labels {
+		if strings.Contains(key, globalCfg.MetadataPrefix) {
+			org.Metadata.Labels[key] = nil
+		}
+	}
+	if orgConfig.Metadata.Labels != nil {
+		for key, value := range orgConfig.Metadata.Labels {
+			if len(value) > 0 {
+				if globalCfg.UseMetadataPrefix {
+					if strings.Contains(key, globalCfg.MetadataPrefix) {
+						org.Metadata.SetLabel(globalCfg.MetadataPrefix, strings.ReplaceAll(key, globalCfg.MetadataPrefix+"/", ""), value)
 					} else {
-						if globalCfg.UseMetadataPrefix {
-							if strings.Contains(key, globalCfg.MetadataPrefix) {
-								org.Metadata.RemoveLabel(globalCfg.MetadataPrefix, strings.ReplaceAll(key, globalCfg.MetadataPrefix+"/", ""))
-							} else {
-								org.Metadata.RemoveLabel(globalCfg.MetadataPrefix, key)
-							}
-						} else {
-							org.Metadata.RemoveLabel("", key)
-						}
+						org.Metadata.SetLabel(globalCfg.MetadataPrefix, key, value)
 					}
+				} else {
+					org.Metadata.SetLabel("", key, value)
 				}
-			}
-			//clear any Annotations that start with the prefix
-			for key, _ := range org.Metadata.Annotations {
-				if strings.Contains(key, globalCfg.MetadataPrefix) {
-					org.Metadata.Annotations[key] = nil
+			} else {
+				if globalCfg.UseMetadataPrefix {
+					if strings.Contains(key, globalCfg.MetadataPrefix) {
+						org.Metadata.RemoveLabel(globalCfg.MetadataPrefix, strings.ReplaceAll(key, globalCfg.MetadataPrefix+"/", ""))
+					} else {
+						org.Metadata.RemoveLabel(globalCfg.MetadataPrefix, key)
+					}
+				} else {
+					org.Metadata.RemoveLabel("", key)
 				}
 			}
-			if orgConfig.Metadata.Annotations != nil {
-				for key, value := range orgConfig.Metadata.Annotations {
-					if len(value) > 0 {
-						if globalCfg.UseMetadataPrefix {
-							if strings.Contains(key, globalCfg.MetadataPrefix) {
-								org.Metadata.SetAnnotation(globalCfg.MetadataPrefix, strings.ReplaceAll(key, globalCfg.MetadataPrefix+"/", ""), value)
-							} else {
-								org.Metadata.SetAnnotation(globalCfg.MetadataPrefix, key, value)
-							}
-						} else {
-							org.Metadata.SetAnnotation("", key, value)
-						}
+		}
+	}
+	//clear any Annotations that start with the prefix
+	for key, _ := range org.Metadata.Annotations {
+		if strings.Contains(key, globalCfg.MetadataPrefix) {
+			org.Metadata.Annotations[key] = nil
+		}
+	}
+	if orgConfig.Metadata.Annotations != nil {
+		for key, value := range orgConfig.Metadata.Annotations {
+			if len(value) > 0 {
+				if globalCfg.UseMetadataPrefix {
+					if strings.Contains(key, globalCfg.MetadataPrefix) {
+						org.Metadata.SetAnnotation(globalCfg.MetadataPrefix, strings.ReplaceAll(key, globalCfg.MetadataPrefix+"/", ""), value)
 					} else {
-						if globalCfg.UseMetadataPrefix {
-							if strings.Contains(key, globalCfg.MetadataPrefix) {
-								org.Metadata.RemoveAnnotation(globalCfg.MetadataPrefix, strings.ReplaceAll(key, globalCfg.MetadataPrefix+"/", ""))
-							} else {
-								org.Metadata.RemoveAnnotation(globalCfg.MetadataPrefix, key)
-							}
-						} else {
-							org.Metadata.RemoveAnnotation("", key)
-						}
+						org.Metadata.SetAnnotation(globalCfg.MetadataPrefix, key, value)
 					}
+				} else {
+					org.Metadata.SetAnnotation("", key, value)
 				}
-			}
-
-			orgYamlNew, err := yaml.Marshal(org.Metadata)
-			if err != nil {
-				return err
-			}
-			if strings.EqualFold(string(orgYamlNew), string(orgYamlOriginal)) {
-				lo.G.Debugf("No changes to yaml old [%s] and new [%s]", string(orgYamlOriginal), string(orgYamlNew))
 			} else {
-				lo.G.Infof("updating org [%s] metadata as there are changes", org.Name)
-				_, err = m.updateOrg(org.GUID, &resource.OrganizationUpdate{
-					Name:     org.Name,
-					Metadata: org.Metadata,
-				})
-				if err != nil {
-					return err
+				if globalCfg.UseMetadataPrefix {
+					if strings.Contains(key, globalCfg.MetadataPrefix) {
+						org.Metadata.RemoveAnnotation(globalCfg.MetadataPrefix, strings.ReplaceAll(key, globalCfg.MetadataPrefix+"/", ""))
+					} else {
+						org.Metadata.RemoveAnnotation(globalCfg.MetadataPrefix, key)
+					}
+				} else {
+					org.Metadata.RemoveAnnotation("", key)
 				}
 			}
+		}
+	}
 
+	orgYamlNew, err := yaml.Marshal(org.Metadata)
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(string(orgYamlNew), string(orgYamlOriginal)) {
+		lo.G.Debugf("No changes to yaml old [%s] and new [%s]", string(orgYamlOriginal), string(orgYamlNew))
+	} else {
+		lo.G.Infof("updating org [%s] metadata as there are changes", org.Name)
+		change := changes.Change{Entity: changes.OrgMetadata, Action: changes.Update, Name: org.Name, Org: org.Name, Before: string(orgYamlOriginal), After: string(orgYamlNew)}
+		m.Recorder.Record(change)
+		_, err = m.updateOrg(ctx, change, org.GUID, &resource.OrganizationUpdate{
+			Name:     org.Name,
+			Metadata: org.Metadata,
+		})
+		if err != nil {
+			return err
 		}
 	}
 	return nil
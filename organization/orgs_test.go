@@ -1,11 +1,14 @@
 package organization_test
 
 import (
+	"context"
+
 	"fmt"
 
 	"github.com/cloudfoundry-community/go-cfclient/v3/resource"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
 	"github.com/vmwarepivotallabs/cf-mgmt/config"
 	configfakes "github.com/vmwarepivotallabs/cf-mgmt/config/fakes"
 	. "github.com/vmwarepivotallabs/cf-mgmt/organization"
@@ -26,6 +29,7 @@ var _ = Describe("given OrgManager", func() {
 	BeforeEach(func() {
 		fakeReader = new(configfakes.FakeReader)
 		fakeOrgReader = new(orgreaderfakes.FakeReader)
+		fakeReader.GetGlobalConfigReturns(&config.GlobalConfig{}, nil)
 		fakeSpaceMgr = new(spacefakes.FakeManager)
 		fakeOrgClient = new(orgfakes.FakeCFOrgClient)
 		orgManager = DefaultManager{
@@ -50,20 +54,32 @@ var _ = Describe("given OrgManager", func() {
 		It("should create 2", func() {
 			orgs := []*resource.Organization{}
 			fakeOrgReader.ListOrgsReturns(orgs, nil)
-			err := orgManager.CreateOrgs()
+			err := orgManager.CreateOrgs(context.Background())
 			Ω(err).Should(BeNil())
 			Expect(fakeOrgClient.CreateCallCount()).Should(Equal(2))
 		})
+		It("should stop creating orgs once cancelled", func() {
+			orgs := []*resource.Organization{}
+			fakeOrgReader.ListOrgsReturns(orgs, nil)
+			ctx, cancel := context.WithCancel(context.Background())
+			fakeOrgClient.CreateStub = func(context.Context, *resource.OrganizationCreate) (*resource.Organization, error) {
+				cancel()
+				return &resource.Organization{}, nil
+			}
+			err := orgManager.CreateOrgs(ctx)
+			Ω(err).Should(MatchError(context.Canceled))
+			Expect(fakeOrgClient.CreateCallCount()).Should(Equal(1))
+		})
 		It("should error on list orgs", func() {
 			fakeOrgReader.ListOrgsReturns(nil, fmt.Errorf("test"))
-			err := orgManager.CreateOrgs()
+			err := orgManager.CreateOrgs(context.Background())
 			Ω(err).Should(HaveOccurred())
 		})
 		It("should error on create org", func() {
 			orgs := []*resource.Organization{}
 			fakeOrgReader.ListOrgsReturns(orgs, nil)
 			fakeOrgClient.CreateReturns(nil, fmt.Errorf("test"))
-			err := orgManager.CreateOrgs()
+			err := orgManager.CreateOrgs(context.Background())
 			Ω(err).Should(HaveOccurred())
 		})
 		It("should not create any orgs", func() {
@@ -76,7 +92,7 @@ var _ = Describe("given OrgManager", func() {
 				},
 			}
 			fakeOrgReader.ListOrgsReturns(orgs, nil)
-			err := orgManager.CreateOrgs()
+			err := orgManager.CreateOrgs(context.Background())
 			Ω(err).ShouldNot(HaveOccurred())
 			Expect(fakeOrgClient.CreateCallCount()).Should(Equal(0))
 		})
@@ -87,12 +103,28 @@ var _ = Describe("given OrgManager", func() {
 				},
 			}
 			fakeOrgReader.ListOrgsReturns(orgs, nil)
-			err := orgManager.CreateOrgs()
+			err := orgManager.CreateOrgs(context.Background())
 			Ω(err).ShouldNot(HaveOccurred())
 			Expect(fakeOrgClient.CreateCallCount()).Should(Equal(1))
 			_, orgRequest := fakeOrgClient.CreateArgsForCall(0)
 			Expect(orgRequest.Name).Should(Equal("test2"))
 		})
+		It("should add a simulated test2 org when peeking", func() {
+			orgManager.Peek = true
+			orgs := []*resource.Organization{
+				{
+					Name: "test",
+				},
+			}
+			fakeOrgReader.ListOrgsReturns(orgs, nil)
+			err := orgManager.CreateOrgs(context.Background())
+			Ω(err).ShouldNot(HaveOccurred())
+			Expect(fakeOrgClient.CreateCallCount()).Should(Equal(0))
+			Expect(fakeOrgReader.AddOrgToListCallCount()).Should(Equal(1))
+			org := fakeOrgReader.AddOrgToListArgsForCall(0)
+			Expect(org.Name).Should(Equal("test2"))
+			Expect(org.GUID).Should(Equal("test2-dry-run-org-guid"))
+		})
 		It("should not create org if renamed from an org that exists", func() {
 			fakeReader.OrgsReturns(&config.Orgs{
 				Orgs: []string{"test", "new-org"},
@@ -116,7 +148,7 @@ var _ = Describe("given OrgManager", func() {
 				Name: "test2",
 				GUID: "test2-guid",
 			}, nil)
-			err := orgManager.CreateOrgs()
+			err := orgManager.CreateOrgs(context.Background())
 			Ω(err).ShouldNot(HaveOccurred())
 			Expect(fakeOrgClient.CreateCallCount()).Should(Equal(0))
 			Expect(fakeOrgClient.UpdateCallCount()).Should(Equal(1))
@@ -130,7 +162,7 @@ var _ = Describe("given OrgManager", func() {
 				fakeReader.GetOrgConfigsReturns([]config.OrgConfig{}, nil)
 				fakeOrgReader.ListOrgsReturns([]*resource.Organization{}, nil)
 				fakeReader.OrgsReturns(nil, fmt.Errorf("some error"))
-				err := orgManager.CreateOrgs()
+				err := orgManager.CreateOrgs(context.Background())
 				Expect(err).Should(HaveOccurred())
 			})
 		})
@@ -148,7 +180,7 @@ var _ = Describe("given OrgManager", func() {
 					{Name: "in-org-list"},
 				}, nil)
 
-				err := orgManager.CreateOrgs()
+				err := orgManager.CreateOrgs(context.Background())
 				Expect(err).Should(HaveOccurred())
 				Expect(err).Should(MatchError("[not-in-org-list] found in an orgConfig but not in orgs.yml"))
 			})
@@ -167,7 +199,7 @@ var _ = Describe("given OrgManager", func() {
 					{Name: "in-org-list"},
 				}, nil)
 
-				err := orgManager.CreateOrgs()
+				err := orgManager.CreateOrgs(context.Background())
 				Expect(err).Should(HaveOccurred())
 				Expect(err).Should(MatchError("[not-in-org-list] found in an orgConfig but not in orgs.yml"))
 			})
@@ -217,7 +249,7 @@ var _ = Describe("given OrgManager", func() {
 				},
 			}
 			fakeOrgReader.ListOrgsReturns(orgs, nil)
-			err := orgManager.DeleteOrgs()
+			err := orgManager.DeleteOrgs(context.Background())
 			Ω(err).Should(BeNil())
 			Expect(fakeOrgClient.DeleteCallCount()).Should(Equal(4))
 			_, orgGUID := fakeOrgClient.DeleteArgsForCall(0)
@@ -231,6 +263,80 @@ var _ = Describe("given OrgManager", func() {
 		})
 	})
 
+	Context("DeleteOrgs() with deletion limits", func() {
+		BeforeEach(func() {
+			fakeReader.OrgsReturns(&config.Orgs{
+				EnableDeleteOrgs: true,
+				Orgs:             []string{"test"},
+			}, nil)
+			fakeReader.GetOrgConfigReturns(&config.OrgConfig{}, nil)
+			fakeReader.GetGlobalConfigReturns(&config.GlobalConfig{
+				DeletionLimits: config.DeletionLimits{
+					Orgs: config.DeletionLimit{Max: 1},
+				},
+			}, nil)
+			fakeOrgReader.ListOrgsReturns([]*resource.Organization{
+				{Name: "test", GUID: "test-guid"},
+				{Name: "test2", GUID: "test2-guid"},
+				{Name: "test3", GUID: "test3-guid"},
+			}, nil)
+		})
+
+		It("should refuse to delete more orgs than the limit", func() {
+			err := orgManager.DeleteOrgs(context.Background())
+			Ω(err).Should(MatchError("refusing to delete 2 of 3 orgs as it exceeds the limit of 1 set in cf-mgmt.yml deletion-limits, use --allow-large-deletions to override"))
+			Expect(fakeOrgClient.DeleteCallCount()).Should(Equal(0))
+		})
+
+		It("should delete more orgs than the limit when allowed", func() {
+			orgManager.AllowLargeDeletions = true
+			err := orgManager.DeleteOrgs(context.Background())
+			Ω(err).Should(BeNil())
+			Expect(fakeOrgClient.DeleteCallCount()).Should(Equal(2))
+		})
+	})
+
+	Context("DeleteOrgs() with selected orgs", func() {
+		It("should only delete selected orgs", func() {
+			fakeReader.OrgsReturns(&config.Orgs{
+				EnableDeleteOrgs: true,
+				Orgs:             []string{"team-a"},
+			}, nil)
+			fakeReader.GetOrgConfigReturns(&config.OrgConfig{}, nil)
+			selection, err := config.NewSelection(nil, "^team-", nil, "")
+			Ω(err).ShouldNot(HaveOccurred())
+			orgManager.Cfg = config.NewSelectedReader(fakeReader, selection)
+			fakeOrgReader.ListOrgsReturns([]*resource.Organization{
+				{Name: "team-a", GUID: "team-a-guid"},
+				{Name: "team-b", GUID: "team-b-guid"},
+				{Name: "other", GUID: "other-guid"},
+			}, nil)
+			err = orgManager.DeleteOrgs(context.Background())
+			Ω(err).Should(BeNil())
+			Expect(fakeOrgClient.DeleteCallCount()).Should(Equal(1))
+			_, orgGUID := fakeOrgClient.DeleteArgsForCall(0)
+			Expect(orgGUID).Should(Equal("team-b-guid"))
+		})
+
+		It("should not delete orgs when selecting by label", func() {
+			fakeReader.OrgsReturns(&config.Orgs{
+				EnableDeleteOrgs: true,
+				Orgs:             []string{"team-a"},
+			}, nil)
+			fakeReader.GetOrgConfigReturns(&config.OrgConfig{}, nil)
+			selection, err := config.NewSelection(nil, "", nil, "!legacy")
+			Ω(err).ShouldNot(HaveOccurred())
+			orgManager.Cfg = config.NewSelectedReader(fakeReader, selection)
+			fakeOrgReader.ListOrgsReturns([]*resource.Organization{
+				{Name: "team-a", GUID: "team-a-guid"},
+				{Name: "team-b", GUID: "team-b-guid"},
+			}, nil)
+			err = orgManager.DeleteOrgs(context.Background())
+			Ω(err).Should(BeNil())
+			Expect(fakeOrgClient.DeleteCallCount()).Should(Equal(0))
+		})
+	})
+
 	Context("DeleteOrgByName()", func() {
 		var (
 			orgs []*resource.Organization
@@ -259,7 +365,7 @@ var _ = Describe("given OrgManager", func() {
 
 		It("should delete 1", func() {
 			fakeOrgReader.ListOrgsReturns(orgs, nil)
-			err := orgManager.DeleteOrgByName("test2")
+			err := orgManager.DeleteOrgByName(context.Background(), "test2")
 			Ω(err).Should(BeNil())
 			Expect(fakeOrgClient.DeleteCallCount()).Should(Equal(1))
 			_, orgGUID := fakeOrgClient.DeleteArgsForCall(0)
@@ -268,7 +374,7 @@ var _ = Describe("given OrgManager", func() {
 
 		It("should error deleting org that doesn't exist", func() {
 			fakeOrgReader.ListOrgsReturns(orgs, nil)
-			err := orgManager.DeleteOrgByName("foo")
+			err := orgManager.DeleteOrgByName(context.Background(), "foo")
 			Ω(err).Should(HaveOccurred())
 			Expect(fakeOrgClient.DeleteCallCount()).Should(Equal(0))
 		})
@@ -276,9 +382,24 @@ var _ = Describe("given OrgManager", func() {
 		It("should not delete any org", func() {
 			orgManager.Peek = true
 			fakeOrgReader.ListOrgsReturns(orgs, nil)
-			err := orgManager.DeleteOrgByName("test2")
+			err := orgManager.DeleteOrgByName(context.Background(), "test2")
+			Ω(err).Should(BeNil())
+			Expect(fakeOrgClient.DeleteCallCount()).Should(Equal(0))
+		})
+
+		It("should record the delete when peeking", func() {
+			orgManager.Peek = true
+			orgManager.Recorder = changes.NewRecorder()
+			fakeOrgReader.ListOrgsReturns(orgs, nil)
+			err := orgManager.DeleteOrgByName(context.Background(), "test2")
 			Ω(err).Should(BeNil())
 			Expect(fakeOrgClient.DeleteCallCount()).Should(Equal(0))
+			Expect(orgManager.Recorder.Changes()).Should(ConsistOf(changes.Change{
+				Entity: changes.Org,
+				Action: changes.Delete,
+				Name:   "test2",
+				Org:    "test2",
+			}))
 		})
 	})
 })
@@ -8,10 +8,10 @@ import (
 
 // Manager -
 type Manager interface {
-	CreateOrgs() error
-	DeleteOrgs() error
-	RenameOrg(originalOrgName, newOrgName string) error
-	UpdateOrgsMetadata() error
+	CreateOrgs(ctx context.Context) error
+	DeleteOrgs(ctx context.Context) error
+	RenameOrg(ctx context.Context, originalOrgName, newOrgName string) error
+	UpdateOrgsMetadata(ctx context.Context) error
 }
 
 type CFOrgClient interface {
@@ -2,6 +2,7 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/cloudfoundry-community/go-cfclient/v3/resource"
@@ -18,10 +19,11 @@ type FakeReader struct {
 	clearOrgListMutex       sync.RWMutex
 	clearOrgListArgsForCall []struct {
 	}
-	FindOrgStub        func(string) (*resource.Organization, error)
+	FindOrgStub        func(context.Context, string) (*resource.Organization, error)
 	findOrgMutex       sync.RWMutex
 	findOrgArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	findOrgReturns struct {
 		result1 *resource.Organization
@@ -31,10 +33,11 @@ type FakeReader struct {
 		result1 *resource.Organization
 		result2 error
 	}
-	FindOrgByGUIDStub        func(string) (*resource.Organization, error)
+	FindOrgByGUIDStub        func(context.Context, string) (*resource.Organization, error)
 	findOrgByGUIDMutex       sync.RWMutex
 	findOrgByGUIDArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	findOrgByGUIDReturns struct {
 		result1 *resource.Organization
@@ -44,10 +47,11 @@ type FakeReader struct {
 		result1 *resource.Organization
 		result2 error
 	}
-	GetDefaultIsolationSegmentStub        func(*resource.Organization) (string, error)
+	GetDefaultIsolationSegmentStub        func(context.Context, *resource.Organization) (string, error)
 	getDefaultIsolationSegmentMutex       sync.RWMutex
 	getDefaultIsolationSegmentArgsForCall []struct {
-		arg1 *resource.Organization
+		arg1 context.Context
+		arg2 *resource.Organization
 	}
 	getDefaultIsolationSegmentReturns struct {
 		result1 string
@@ -57,10 +61,11 @@ type FakeReader struct {
 		result1 string
 		result2 error
 	}
-	GetOrgGUIDStub        func(string) (string, error)
+	GetOrgGUIDStub        func(context.Context, string) (string, error)
 	getOrgGUIDMutex       sync.RWMutex
 	getOrgGUIDArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	getOrgGUIDReturns struct {
 		result1 string
@@ -70,9 +75,10 @@ type FakeReader struct {
 		result1 string
 		result2 error
 	}
-	ListOrgsStub        func() ([]*resource.Organization, error)
+	ListOrgsStub        func(context.Context) ([]*resource.Organization, error)
 	listOrgsMutex       sync.RWMutex
 	listOrgsArgsForCall []struct {
+		arg1 context.Context
 	}
 	listOrgsReturns struct {
 		result1 []*resource.Organization
@@ -142,18 +148,19 @@ func (fake *FakeReader) ClearOrgListCalls(stub func()) {
 	fake.ClearOrgListStub = stub
 }
 
-func (fake *FakeReader) FindOrg(arg1 string) (*resource.Organization, error) {
+func (fake *FakeReader) FindOrg(arg1 context.Context, arg2 string) (*resource.Organization, error) {
 	fake.findOrgMutex.Lock()
 	ret, specificReturn := fake.findOrgReturnsOnCall[len(fake.findOrgArgsForCall)]
 	fake.findOrgArgsForCall = append(fake.findOrgArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.FindOrgStub
 	fakeReturns := fake.findOrgReturns
-	fake.recordInvocation("FindOrg", []interface{}{arg1})
+	fake.recordInvocation("FindOrg", []interface{}{arg1, arg2})
 	fake.findOrgMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -167,17 +174,17 @@ func (fake *FakeReader) FindOrgCallCount() int {
 	return len(fake.findOrgArgsForCall)
 }
 
-func (fake *FakeReader) FindOrgCalls(stub func(string) (*resource.Organization, error)) {
+func (fake *FakeReader) FindOrgCalls(stub func(context.Context, string) (*resource.Organization, error)) {
 	fake.findOrgMutex.Lock()
 	defer fake.findOrgMutex.Unlock()
 	fake.FindOrgStub = stub
 }
 
-func (fake *FakeReader) FindOrgArgsForCall(i int) string {
+func (fake *FakeReader) FindOrgArgsForCall(i int) (context.Context, string) {
 	fake.findOrgMutex.RLock()
 	defer fake.findOrgMutex.RUnlock()
 	argsForCall := fake.findOrgArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeReader) FindOrgReturns(result1 *resource.Organization, result2 error) {
@@ -206,18 +213,19 @@ func (fake *FakeReader) FindOrgReturnsOnCall(i int, result1 *resource.Organizati
 	}{result1, result2}
 }
 
-func (fake *FakeReader) FindOrgByGUID(arg1 string) (*resource.Organization, error) {
+func (fake *FakeReader) FindOrgByGUID(arg1 context.Context, arg2 string) (*resource.Organization, error) {
 	fake.findOrgByGUIDMutex.Lock()
 	ret, specificReturn := fake.findOrgByGUIDReturnsOnCall[len(fake.findOrgByGUIDArgsForCall)]
 	fake.findOrgByGUIDArgsForCall = append(fake.findOrgByGUIDArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.FindOrgByGUIDStub
 	fakeReturns := fake.findOrgByGUIDReturns
-	fake.recordInvocation("FindOrgByGUID", []interface{}{arg1})
+	fake.recordInvocation("FindOrgByGUID", []interface{}{arg1, arg2})
 	fake.findOrgByGUIDMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -231,17 +239,17 @@ func (fake *FakeReader) FindOrgByGUIDCallCount() int {
 	return len(fake.findOrgByGUIDArgsForCall)
 }
 
-func (fake *FakeReader) FindOrgByGUIDCalls(stub func(string) (*resource.Organization, error)) {
+func (fake *FakeReader) FindOrgByGUIDCalls(stub func(context.Context, string) (*resource.Organization, error)) {
 	fake.findOrgByGUIDMutex.Lock()
 	defer fake.findOrgByGUIDMutex.Unlock()
 	fake.FindOrgByGUIDStub = stub
 }
 
-func (fake *FakeReader) FindOrgByGUIDArgsForCall(i int) string {
+func (fake *FakeReader) FindOrgByGUIDArgsForCall(i int) (context.Context, string) {
 	fake.findOrgByGUIDMutex.RLock()
 	defer fake.findOrgByGUIDMutex.RUnlock()
 	argsForCall := fake.findOrgByGUIDArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeReader) FindOrgByGUIDReturns(result1 *resource.Organization, result2 error) {
@@ -270,18 +278,19 @@ func (fake *FakeReader) FindOrgByGUIDReturnsOnCall(i int, result1 *resource.Orga
 	}{result1, result2}
 }
 
-func (fake *FakeReader) GetDefaultIsolationSegment(arg1 *resource.Organization) (string, error) {
+func (fake *FakeReader) GetDefaultIsolationSegment(arg1 context.Context, arg2 *resource.Organization) (string, error) {
 	fake.getDefaultIsolationSegmentMutex.Lock()
 	ret, specificReturn := fake.getDefaultIsolationSegmentReturnsOnCall[len(fake.getDefaultIsolationSegmentArgsForCall)]
 	fake.getDefaultIsolationSegmentArgsForCall = append(fake.getDefaultIsolationSegmentArgsForCall, struct {
-		arg1 *resource.Organization
-	}{arg1})
+		arg1 context.Context
+		arg2 *resource.Organization
+	}{arg1, arg2})
 	stub := fake.GetDefaultIsolationSegmentStub
 	fakeReturns := fake.getDefaultIsolationSegmentReturns
-	fake.recordInvocation("GetDefaultIsolationSegment", []interface{}{arg1})
+	fake.recordInvocation("GetDefaultIsolationSegment", []interface{}{arg1, arg2})
 	fake.getDefaultIsolationSegmentMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -295,17 +304,17 @@ func (fake *FakeReader) GetDefaultIsolationSegmentCallCount() int {
 	return len(fake.getDefaultIsolationSegmentArgsForCall)
 }
 
-func (fake *FakeReader) GetDefaultIsolationSegmentCalls(stub func(*resource.Organization) (string, error)) {
+func (fake *FakeReader) GetDefaultIsolationSegmentCalls(stub func(context.Context, *resource.Organization) (string, error)) {
 	fake.getDefaultIsolationSegmentMutex.Lock()
 	defer fake.getDefaultIsolationSegmentMutex.Unlock()
 	fake.GetDefaultIsolationSegmentStub = stub
 }
 
-func (fake *FakeReader) GetDefaultIsolationSegmentArgsForCall(i int) *resource.Organization {
+func (fake *FakeReader) GetDefaultIsolationSegmentArgsForCall(i int) (context.Context, *resource.Organization) {
 	fake.getDefaultIsolationSegmentMutex.RLock()
 	defer fake.getDefaultIsolationSegmentMutex.RUnlock()
 	argsForCall := fake.getDefaultIsolationSegmentArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeReader) GetDefaultIsolationSegmentReturns(result1 string, result2 error) {
@@ -334,18 +343,19 @@ func (fake *FakeReader) GetDefaultIsolationSegmentReturnsOnCall(i int, result1 s
 	}{result1, result2}
 }
 
-func (fake *FakeReader) GetOrgGUID(arg1 string) (string, error) {
+func (fake *FakeReader) GetOrgGUID(arg1 context.Context, arg2 string) (string, error) {
 	fake.getOrgGUIDMutex.Lock()
 	ret, specificReturn := fake.getOrgGUIDReturnsOnCall[len(fake.getOrgGUIDArgsForCall)]
 	fake.getOrgGUIDArgsForCall = append(fake.getOrgGUIDArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.GetOrgGUIDStub
 	fakeReturns := fake.getOrgGUIDReturns
-	fake.recordInvocation("GetOrgGUID", []interface{}{arg1})
+	fake.recordInvocation("GetOrgGUID", []interface{}{arg1, arg2})
 	fake.getOrgGUIDMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -359,17 +369,17 @@ func (fake *FakeReader) GetOrgGUIDCallCount() int {
 	return len(fake.getOrgGUIDArgsForCall)
 }
 
-func (fake *FakeReader) GetOrgGUIDCalls(stub func(string) (string, error)) {
+func (fake *FakeReader) GetOrgGUIDCalls(stub func(context.Context, string) (string, error)) {
 	fake.getOrgGUIDMutex.Lock()
 	defer fake.getOrgGUIDMutex.Unlock()
 	fake.GetOrgGUIDStub = stub
 }
 
-func (fake *FakeReader) GetOrgGUIDArgsForCall(i int) string {
+func (fake *FakeReader) GetOrgGUIDArgsForCall(i int) (context.Context, string) {
 	fake.getOrgGUIDMutex.RLock()
 	defer fake.getOrgGUIDMutex.RUnlock()
 	argsForCall := fake.getOrgGUIDArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeReader) GetOrgGUIDReturns(result1 string, result2 error) {
@@ -398,17 +408,18 @@ func (fake *FakeReader) GetOrgGUIDReturnsOnCall(i int, result1 string, result2 e
 	}{result1, result2}
 }
 
-func (fake *FakeReader) ListOrgs() ([]*resource.Organization, error) {
+func (fake *FakeReader) ListOrgs(arg1 context.Context) ([]*resource.Organization, error) {
 	fake.listOrgsMutex.Lock()
 	ret, specificReturn := fake.listOrgsReturnsOnCall[len(fake.listOrgsArgsForCall)]
 	fake.listOrgsArgsForCall = append(fake.listOrgsArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.ListOrgsStub
 	fakeReturns := fake.listOrgsReturns
-	fake.recordInvocation("ListOrgs", []interface{}{})
+	fake.recordInvocation("ListOrgs", []interface{}{arg1})
 	fake.listOrgsMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -422,12 +433,19 @@ func (fake *FakeReader) ListOrgsCallCount() int {
 	return len(fake.listOrgsArgsForCall)
 }
 
-func (fake *FakeReader) ListOrgsCalls(stub func() ([]*resource.Organization, error)) {
+func (fake *FakeReader) ListOrgsCalls(stub func(context.Context) ([]*resource.Organization, error)) {
 	fake.listOrgsMutex.Lock()
 	defer fake.listOrgsMutex.Unlock()
 	fake.ListOrgsStub = stub
 }
 
+func (fake *FakeReader) ListOrgsArgsForCall(i int) context.Context {
+	fake.listOrgsMutex.RLock()
+	defer fake.listOrgsMutex.RUnlock()
+	argsForCall := fake.listOrgsArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeReader) ListOrgsReturns(result1 []*resource.Organization, result2 error) {
 	fake.listOrgsMutex.Lock()
 	defer fake.listOrgsMutex.Unlock()
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/cloudfoundry-community/go-cfclient/v3/client"
 	"github.com/cloudfoundry-community/go-cfclient/v3/resource"
 	"github.com/vmwarepivotallabs/cf-mgmt/config"
+	"github.com/vmwarepivotallabs/cf-mgmt/util"
 	"github.com/xchapter7x/lo"
 )
 
@@ -24,12 +26,16 @@ type DefaultReader struct {
 	Cfg       config.Reader
 	OrgClient CFOrgClient
 	Peek      bool
+	mutex     sync.Mutex
 	orgs      []*resource.Organization
+	// simulated - orgs created or renamed while peeking, layered over the
+	// orgs returned by cloud controller so later steps can see them
+	simulated []*resource.Organization
 }
 
-func (m *DefaultReader) init() error {
+func (m *DefaultReader) init(ctx context.Context) error {
 	if m.orgs == nil {
-		orgs, err := m.OrgClient.ListAll(context.Background(), &client.OrganizationListOptions{
+		orgs, err := m.OrgClient.ListAll(util.CallContext(ctx), &client.OrganizationListOptions{
 			ListOptions: &client.ListOptions{
 				PerPage: 5000,
 			},
@@ -38,23 +44,45 @@ func (m *DefaultReader) init() error {
 			return err
 		}
 		m.orgs = orgs
+		for _, org := range m.simulated {
+			m.orgs = addOrReplaceOrg(m.orgs, org)
+		}
 	}
 	return nil
 }
 
 func (m *DefaultReader) ClearOrgList() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 	m.orgs = nil
 }
 
+// AddOrgToList - adds the org to the cached list, replacing any org with the same guid.
+// When peeking the org is kept in the simulated overlay so it survives ClearOrgList
 func (m *DefaultReader) AddOrgToList(org *resource.Organization) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.Peek {
+		m.simulated = addOrReplaceOrg(m.simulated, org)
+	}
 	if m.orgs == nil {
 		m.orgs = []*resource.Organization{}
 	}
-	m.orgs = append(m.orgs, org)
+	m.orgs = addOrReplaceOrg(m.orgs, org)
+}
+
+func addOrReplaceOrg(orgs []*resource.Organization, org *resource.Organization) []*resource.Organization {
+	for i, existingOrg := range orgs {
+		if org.GUID != "" && existingOrg.GUID == org.GUID {
+			orgs[i] = org
+			return orgs
+		}
+	}
+	return append(orgs, org)
 }
 
-func (m *DefaultReader) GetOrgGUID(orgName string) (string, error) {
-	org, err := m.FindOrg(orgName)
+func (m *DefaultReader) GetOrgGUID(ctx context.Context, orgName string) (string, error) {
+	org, err := m.FindOrg(ctx, orgName)
 	if err != nil {
 		return "", err
 	}
@@ -62,8 +90,8 @@ func (m *DefaultReader) GetOrgGUID(orgName string) (string, error) {
 }
 
 // FindOrg -
-func (m *DefaultReader) FindOrg(orgName string) (*resource.Organization, error) {
-	orgs, err := m.ListOrgs()
+func (m *DefaultReader) FindOrg(ctx context.Context, orgName string) (*resource.Organization, error) {
+	orgs, err := m.ListOrgs(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -82,8 +110,8 @@ func (m *DefaultReader) FindOrg(orgName string) (*resource.Organization, error)
 }
 
 // FindOrgByGUID -
-func (m *DefaultReader) FindOrgByGUID(orgGUID string) (*resource.Organization, error) {
-	orgs, err := m.ListOrgs()
+func (m *DefaultReader) FindOrgByGUID(ctx context.Context, orgGUID string) (*resource.Organization, error) {
+	orgs, err := m.ListOrgs(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -102,8 +130,10 @@ func (m *DefaultReader) FindOrgByGUID(orgGUID string) (*resource.Organization, e
 }
 
 // ListOrgs : Returns all orgs in the given foundation
-func (m *DefaultReader) ListOrgs() ([]*resource.Organization, error) {
-	err := m.init()
+func (m *DefaultReader) ListOrgs(ctx context.Context) ([]*resource.Organization, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	err := m.init(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -111,9 +141,9 @@ func (m *DefaultReader) ListOrgs() ([]*resource.Organization, error) {
 	return m.orgs, nil
 }
 
-func (m *DefaultReader) GetDefaultIsolationSegment(org *resource.Organization) (string, error) {
+func (m *DefaultReader) GetDefaultIsolationSegment(ctx context.Context, org *resource.Organization) (string, error) {
 	if m.Peek && org.GUID == fmt.Sprintf("%s-dry-run-org-guid", org.Name) {
 		return fmt.Sprintf("%s-dry-run-org-isolation-segment-guid", org.Name), nil
 	}
-	return m.OrgClient.GetDefaultIsolationSegment(context.Background(), org.GUID)
+	return m.OrgClient.GetDefaultIsolationSegment(util.CallContext(ctx), org.GUID)
 }
@@ -1,6 +1,7 @@
 package organizationreader_test
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/cloudfoundry-community/go-cfclient/v3/resource"
@@ -39,7 +40,7 @@ var _ = Describe("given OrgManager", func() {
 				},
 			}
 			fakeOrgClient.ListAllReturns(orgs, nil)
-			org, err := orgReader.FindOrg("test")
+			org, err := orgReader.FindOrg(context.Background(), "test")
 			Ω(err).Should(BeNil())
 			Ω(org).ShouldNot(BeNil())
 			Ω(org.Name).Should(Equal("test"))
@@ -48,12 +49,12 @@ var _ = Describe("given OrgManager", func() {
 	It("should return an error for unfound org", func() {
 		orgs := []*resource.Organization{}
 		fakeOrgClient.ListAllReturns(orgs, nil)
-		_, err := orgReader.FindOrg("test")
+		_, err := orgReader.FindOrg(context.Background(), "test")
 		Ω(err).ShouldNot(BeNil())
 	})
 	It("should return an error", func() {
 		fakeOrgClient.ListAllReturns(nil, fmt.Errorf("test"))
-		_, err := orgReader.FindOrg("test")
+		_, err := orgReader.FindOrg(context.Background(), "test")
 		Ω(err).ShouldNot(BeNil())
 	})
 
@@ -66,7 +67,7 @@ var _ = Describe("given OrgManager", func() {
 				},
 			}
 			fakeOrgClient.ListAllReturns(orgs, nil)
-			guid, err := orgReader.GetOrgGUID("test")
+			guid, err := orgReader.GetOrgGUID(context.Background(), "test")
 			Ω(err).Should(BeNil())
 			Ω(guid).ShouldNot(BeNil())
 			Ω(guid).Should(Equal("theGUID"))
@@ -75,8 +76,41 @@ var _ = Describe("given OrgManager", func() {
 
 	It("should return an error", func() {
 		fakeOrgClient.ListAllReturns(nil, fmt.Errorf("test"))
-		guid, err := orgReader.GetOrgGUID("test")
+		guid, err := orgReader.GetOrgGUID(context.Background(), "test")
 		Ω(err).ShouldNot(BeNil())
 		Ω(guid).Should(Equal(""))
 	})
+
+	Context("AddOrgToList()", func() {
+		It("should replace an org with the same guid", func() {
+			fakeOrgClient.ListAllReturns([]*resource.Organization{{Name: "test", GUID: "theGUID"}}, nil)
+			orgReader.AddOrgToList(&resource.Organization{Name: "renamed", GUID: "theGUID"})
+			orgs, err := orgReader.ListOrgs(context.Background())
+			Ω(err).Should(BeNil())
+			Ω(orgs).Should(HaveLen(1))
+			Ω(orgs[0].Name).Should(Equal("renamed"))
+		})
+
+		It("should keep simulated orgs after clearing the list when peeking", func() {
+			orgReader.Peek = true
+			fakeOrgClient.ListAllReturns([]*resource.Organization{{Name: "test", GUID: "theGUID"}}, nil)
+			orgReader.AddOrgToList(&resource.Organization{Name: "new-org", GUID: "new-org-dry-run-org-guid"})
+			orgReader.ClearOrgList()
+			orgs, err := orgReader.ListOrgs(context.Background())
+			Ω(err).Should(BeNil())
+			Ω(orgs).Should(HaveLen(2))
+			org, err := orgReader.FindOrg(context.Background(), "new-org")
+			Ω(err).Should(BeNil())
+			Ω(org.GUID).Should(Equal("new-org-dry-run-org-guid"))
+		})
+
+		It("should not keep added orgs after clearing the list when not peeking", func() {
+			fakeOrgClient.ListAllReturns([]*resource.Organization{{Name: "test", GUID: "theGUID"}}, nil)
+			orgReader.AddOrgToList(&resource.Organization{Name: "new-org", GUID: "new-org-guid"})
+			orgReader.ClearOrgList()
+			orgs, err := orgReader.ListOrgs(context.Background())
+			Ω(err).Should(BeNil())
+			Ω(orgs).Should(HaveLen(1))
+		})
+	})
 })
@@ -10,13 +10,13 @@ import (
 
 // Reader -
 type Reader interface {
-	ListOrgs() ([]*resource.Organization, error)
-	FindOrg(orgName string) (*resource.Organization, error)
-	FindOrgByGUID(orgGUID string) (*resource.Organization, error)
-	GetOrgGUID(orgName string) (string, error)
+	ListOrgs(ctx context.Context) ([]*resource.Organization, error)
+	FindOrg(ctx context.Context, orgName string) (*resource.Organization, error)
+	FindOrgByGUID(ctx context.Context, orgGUID string) (*resource.Organization, error)
+	GetOrgGUID(ctx context.Context, orgName string) (string, error)
 	ClearOrgList()
 	AddOrgToList(org *resource.Organization)
-	GetDefaultIsolationSegment(org *resource.Organization) (string, error)
+	GetDefaultIsolationSegment(ctx context.Context, org *resource.Organization) (string, error)
 }
 
 type CFClient interface {
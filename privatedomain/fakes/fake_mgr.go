@@ -2,6 +2,7 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 
 	cfclient "github.com/cloudfoundry-community/go-cfclient"
@@ -9,9 +10,10 @@ import (
 )
 
 type FakeManager struct {
-	CreatePrivateDomainsStub        func() error
+	CreatePrivateDomainsStub        func(context.Context) error
 	createPrivateDomainsMutex       sync.RWMutex
 	createPrivateDomainsArgsForCall []struct {
+		arg1 context.Context
 	}
 	createPrivateDomainsReturns struct {
 		result1 error
@@ -19,10 +21,11 @@ type FakeManager struct {
 	createPrivateDomainsReturnsOnCall map[int]struct {
 		result1 error
 	}
-	ListOrgOwnedPrivateDomainsStub        func(string) (map[string]cfclient.Domain, error)
+	ListOrgOwnedPrivateDomainsStub        func(context.Context, string) (map[string]cfclient.Domain, error)
 	listOrgOwnedPrivateDomainsMutex       sync.RWMutex
 	listOrgOwnedPrivateDomainsArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	listOrgOwnedPrivateDomainsReturns struct {
 		result1 map[string]cfclient.Domain
@@ -32,10 +35,11 @@ type FakeManager struct {
 		result1 map[string]cfclient.Domain
 		result2 error
 	}
-	ListOrgSharedPrivateDomainsStub        func(string) (map[string]cfclient.Domain, error)
+	ListOrgSharedPrivateDomainsStub        func(context.Context, string) (map[string]cfclient.Domain, error)
 	listOrgSharedPrivateDomainsMutex       sync.RWMutex
 	listOrgSharedPrivateDomainsArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	listOrgSharedPrivateDomainsReturns struct {
 		result1 map[string]cfclient.Domain
@@ -45,9 +49,10 @@ type FakeManager struct {
 		result1 map[string]cfclient.Domain
 		result2 error
 	}
-	SharePrivateDomainsStub        func() error
+	SharePrivateDomainsStub        func(context.Context) error
 	sharePrivateDomainsMutex       sync.RWMutex
 	sharePrivateDomainsArgsForCall []struct {
+		arg1 context.Context
 	}
 	sharePrivateDomainsReturns struct {
 		result1 error
@@ -59,17 +64,18 @@ type FakeManager struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeManager) CreatePrivateDomains() error {
+func (fake *FakeManager) CreatePrivateDomains(arg1 context.Context) error {
 	fake.createPrivateDomainsMutex.Lock()
 	ret, specificReturn := fake.createPrivateDomainsReturnsOnCall[len(fake.createPrivateDomainsArgsForCall)]
 	fake.createPrivateDomainsArgsForCall = append(fake.createPrivateDomainsArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.CreatePrivateDomainsStub
 	fakeReturns := fake.createPrivateDomainsReturns
-	fake.recordInvocation("CreatePrivateDomains", []interface{}{})
+	fake.recordInvocation("CreatePrivateDomains", []interface{}{arg1})
 	fake.createPrivateDomainsMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -83,12 +89,19 @@ func (fake *FakeManager) CreatePrivateDomainsCallCount() int {
 	return len(fake.createPrivateDomainsArgsForCall)
 }
 
-func (fake *FakeManager) CreatePrivateDomainsCalls(stub func() error) {
+func (fake *FakeManager) CreatePrivateDomainsCalls(stub func(context.Context) error) {
 	fake.createPrivateDomainsMutex.Lock()
 	defer fake.createPrivateDomainsMutex.Unlock()
 	fake.CreatePrivateDomainsStub = stub
 }
 
+func (fake *FakeManager) CreatePrivateDomainsArgsForCall(i int) context.Context {
+	fake.createPrivateDomainsMutex.RLock()
+	defer fake.createPrivateDomainsMutex.RUnlock()
+	argsForCall := fake.createPrivateDomainsArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeManager) CreatePrivateDomainsReturns(result1 error) {
 	fake.createPrivateDomainsMutex.Lock()
 	defer fake.createPrivateDomainsMutex.Unlock()
@@ -112,18 +125,19 @@ func (fake *FakeManager) CreatePrivateDomainsReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
-func (fake *FakeManager) ListOrgOwnedPrivateDomains(arg1 string) (map[string]cfclient.Domain, error) {
+func (fake *FakeManager) ListOrgOwnedPrivateDomains(arg1 context.Context, arg2 string) (map[string]cfclient.Domain, error) {
 	fake.listOrgOwnedPrivateDomainsMutex.Lock()
 	ret, specificReturn := fake.listOrgOwnedPrivateDomainsReturnsOnCall[len(fake.listOrgOwnedPrivateDomainsArgsForCall)]
 	fake.listOrgOwnedPrivateDomainsArgsForCall = append(fake.listOrgOwnedPrivateDomainsArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.ListOrgOwnedPrivateDomainsStub
 	fakeReturns := fake.listOrgOwnedPrivateDomainsReturns
-	fake.recordInvocation("ListOrgOwnedPrivateDomains", []interface{}{arg1})
+	fake.recordInvocation("ListOrgOwnedPrivateDomains", []interface{}{arg1, arg2})
 	fake.listOrgOwnedPrivateDomainsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -137,17 +151,17 @@ func (fake *FakeManager) ListOrgOwnedPrivateDomainsCallCount() int {
 	return len(fake.listOrgOwnedPrivateDomainsArgsForCall)
 }
 
-func (fake *FakeManager) ListOrgOwnedPrivateDomainsCalls(stub func(string) (map[string]cfclient.Domain, error)) {
+func (fake *FakeManager) ListOrgOwnedPrivateDomainsCalls(stub func(context.Context, string) (map[string]cfclient.Domain, error)) {
 	fake.listOrgOwnedPrivateDomainsMutex.Lock()
 	defer fake.listOrgOwnedPrivateDomainsMutex.Unlock()
 	fake.ListOrgOwnedPrivateDomainsStub = stub
 }
 
-func (fake *FakeManager) ListOrgOwnedPrivateDomainsArgsForCall(i int) string {
+func (fake *FakeManager) ListOrgOwnedPrivateDomainsArgsForCall(i int) (context.Context, string) {
 	fake.listOrgOwnedPrivateDomainsMutex.RLock()
 	defer fake.listOrgOwnedPrivateDomainsMutex.RUnlock()
 	argsForCall := fake.listOrgOwnedPrivateDomainsArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeManager) ListOrgOwnedPrivateDomainsReturns(result1 map[string]cfclient.Domain, result2 error) {
@@ -176,18 +190,19 @@ func (fake *FakeManager) ListOrgOwnedPrivateDomainsReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
-func (fake *FakeManager) ListOrgSharedPrivateDomains(arg1 string) (map[string]cfclient.Domain, error) {
+func (fake *FakeManager) ListOrgSharedPrivateDomains(arg1 context.Context, arg2 string) (map[string]cfclient.Domain, error) {
 	fake.listOrgSharedPrivateDomainsMutex.Lock()
 	ret, specificReturn := fake.listOrgSharedPrivateDomainsReturnsOnCall[len(fake.listOrgSharedPrivateDomainsArgsForCall)]
 	fake.listOrgSharedPrivateDomainsArgsForCall = append(fake.listOrgSharedPrivateDomainsArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.ListOrgSharedPrivateDomainsStub
 	fakeReturns := fake.listOrgSharedPrivateDomainsReturns
-	fake.recordInvocation("ListOrgSharedPrivateDomains", []interface{}{arg1})
+	fake.recordInvocation("ListOrgSharedPrivateDomains", []interface{}{arg1, arg2})
 	fake.listOrgSharedPrivateDomainsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -201,17 +216,17 @@ func (fake *FakeManager) ListOrgSharedPrivateDomainsCallCount() int {
 	return len(fake.listOrgSharedPrivateDomainsArgsForCall)
 }
 
-func (fake *FakeManager) ListOrgSharedPrivateDomainsCalls(stub func(string) (map[string]cfclient.Domain, error)) {
+func (fake *FakeManager) ListOrgSharedPrivateDomainsCalls(stub func(context.Context, string) (map[string]cfclient.Domain, error)) {
 	fake.listOrgSharedPrivateDomainsMutex.Lock()
 	defer fake.listOrgSharedPrivateDomainsMutex.Unlock()
 	fake.ListOrgSharedPrivateDomainsStub = stub
 }
 
-func (fake *FakeManager) ListOrgSharedPrivateDomainsArgsForCall(i int) string {
+func (fake *FakeManager) ListOrgSharedPrivateDomainsArgsForCall(i int) (context.Context, string) {
 	fake.listOrgSharedPrivateDomainsMutex.RLock()
 	defer fake.listOrgSharedPrivateDomainsMutex.RUnlock()
 	argsForCall := fake.listOrgSharedPrivateDomainsArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeManager) ListOrgSharedPrivateDomainsReturns(result1 map[string]cfclient.Domain, result2 error) {
@@ -240,17 +255,18 @@ func (fake *FakeManager) ListOrgSharedPrivateDomainsReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
-func (fake *FakeManager) SharePrivateDomains() error {
+func (fake *FakeManager) SharePrivateDomains(arg1 context.Context) error {
 	fake.sharePrivateDomainsMutex.Lock()
 	ret, specificReturn := fake.sharePrivateDomainsReturnsOnCall[len(fake.sharePrivateDomainsArgsForCall)]
 	fake.sharePrivateDomainsArgsForCall = append(fake.sharePrivateDomainsArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.SharePrivateDomainsStub
 	fakeReturns := fake.sharePrivateDomainsReturns
-	fake.recordInvocation("SharePrivateDomains", []interface{}{})
+	fake.recordInvocation("SharePrivateDomains", []interface{}{arg1})
 	fake.sharePrivateDomainsMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -264,12 +280,19 @@ func (fake *FakeManager) SharePrivateDomainsCallCount() int {
 	return len(fake.sharePrivateDomainsArgsForCall)
 }
 
-func (fake *FakeManager) SharePrivateDomainsCalls(stub func() error) {
+func (fake *FakeManager) SharePrivateDomainsCalls(stub func(context.Context) error) {
 	fake.sharePrivateDomainsMutex.Lock()
 	defer fake.sharePrivateDomainsMutex.Unlock()
 	fake.SharePrivateDomainsStub = stub
 }
 
+func (fake *FakeManager) SharePrivateDomainsArgsForCall(i int) context.Context {
+	fake.sharePrivateDomainsMutex.RLock()
+	defer fake.sharePrivateDomainsMutex.RUnlock()
+	argsForCall := fake.sharePrivateDomainsArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeManager) SharePrivateDomainsReturns(result1 error) {
 	fake.sharePrivateDomainsMutex.Lock()
 	defer fake.sharePrivateDomainsMutex.Unlock()
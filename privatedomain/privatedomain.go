@@ -1,6 +1,7 @@
 package privatedomain
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
@@ -9,29 +10,39 @@ import (
 
 	cfclient "github.com/cloudfoundry-community/go-cfclient"
 	"github.com/cloudfoundry-community/go-cfclient/v3/resource"
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
 	"github.com/vmwarepivotallabs/cf-mgmt/config"
+	"github.com/vmwarepivotallabs/cf-mgmt/failures"
 	"github.com/vmwarepivotallabs/cf-mgmt/organizationreader"
 	"github.com/xchapter7x/lo"
 )
 
-func NewManager(client CFClient, orgReader organizationreader.Reader, cfg config.Reader, peek bool) Manager {
+func NewManager(client CFClient, orgReader organizationreader.Reader, cfg config.Reader, allowLargeDeletions bool, failures *failures.Collector, recorder *changes.Recorder, peek bool) Manager {
 	return &DefaultManager{
-		Cfg:       cfg,
-		OrgReader: orgReader,
-		Client:    client,
-		Peek:      peek,
+		Cfg:                 cfg,
+		OrgReader:           orgReader,
+		Client:              client,
+		AllowLargeDeletions: allowLargeDeletions,
+		Failures:            failures,
+		Recorder:            recorder,
+		Peek:                peek,
 	}
 }
 
 // DefaultManager -
 type DefaultManager struct {
-	Cfg       config.Reader
-	OrgReader organizationreader.Reader
-	Client    CFClient
-	Peek      bool
+	Cfg                 config.Reader
+	OrgReader           organizationreader.Reader
+	Client              CFClient
+	AllowLargeDeletions bool
+	Failures            *failures.Collector
+	Recorder            *changes.Recorder
+	Peek                bool
+	// simulated - private domains created while peeking so they can be shared by later steps
+	simulated map[string]cfclient.Domain
 }
 
-func (m *DefaultManager) CreatePrivateDomains() error {
+func (m *DefaultManager) CreatePrivateDomains(ctx context.Context) error {
 	orgConfigs, err := m.Cfg.GetOrgConfigs()
 	if err != nil {
 		return err
@@ -41,53 +52,94 @@ func (m *DefaultManager) CreatePrivateDomains() error {
 	if err != nil {
 		return err
 	}
+	totalPrivateDomains := len(allPrivateDomains)
+	var deletions []privateDomainDeletion
 	for _, orgConfig := range orgConfigs {
-		org, err := m.OrgReader.FindOrg(orgConfig.Org)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if m.Failures.Skip(orgConfig.Org, "") {
+			lo.G.Infof("skipping private domains for org [%s] as it failed in an earlier step", orgConfig.Org)
+			continue
+		}
+		orgDeletions, err := m.createPrivateDomains(ctx, orgConfig, allPrivateDomains)
+		if err := m.Failures.Add(orgConfig.Org, "", err); err != nil {
+			return err
+		}
+		deletions = append(deletions, orgDeletions...)
+	}
+
+	if len(deletions) > 0 && !m.AllowLargeDeletions {
+		globalCfg, err := m.Cfg.GetGlobalConfig()
 		if err != nil {
 			return err
 		}
-		privateDomainMap := make(map[string]string)
-		for _, privateDomain := range orgConfig.PrivateDomains {
-			if existingPrivateDomain, ok := allPrivateDomains[privateDomain]; ok {
-				if org.GUID != existingPrivateDomain.OwningOrganizationGuid {
-					existingOrg, err := m.OrgReader.FindOrgByGUID(existingPrivateDomain.OwningOrganizationGuid)
-					if err != nil {
-						return err
-					}
-					return fmt.Errorf("Private Domain %s already exists in org [%s]", privateDomain, existingOrg.Name)
-				}
-			} else {
-				privateDomain, err := m.CreatePrivateDomain(org, privateDomain)
-				if err != nil {
-					return err
-				}
-				allPrivateDomains[privateDomain.Name] = *privateDomain
-			}
-			privateDomainMap[privateDomain] = privateDomain
+		if err := globalCfg.DeletionLimits.PrivateDomains.Check("private domains", len(deletions), totalPrivateDomains); err != nil {
+			return err
 		}
+	}
 
-		if orgConfig.RemovePrivateDomains {
-			orgPrivateDomains, err := m.ListOrgOwnedPrivateDomains(org.GUID)
-			if err != nil {
-				return err
-			}
-			for existingPrivateDomain, privateDomain := range orgPrivateDomains {
-				if _, ok := privateDomainMap[existingPrivateDomain]; !ok {
-					err = m.DeletePrivateDomain(privateDomain)
-					if err != nil {
-						return err
-					}
+	for _, deletion := range deletions {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := m.Failures.Add(deletion.orgName, "", m.DeletePrivateDomain(deletion.domain)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type privateDomainDeletion struct {
+	orgName string
+	domain  cfclient.Domain
+}
+
+// createPrivateDomains - creates the org's missing private domains and returns those
+// that should be removed, which are only deleted once every org has been processed
+func (m *DefaultManager) createPrivateDomains(ctx context.Context, orgConfig config.OrgConfig, allPrivateDomains map[string]cfclient.Domain) ([]privateDomainDeletion, error) {
+	org, err := m.OrgReader.FindOrg(ctx, orgConfig.Org)
+	if err != nil {
+		return nil, err
+	}
+	privateDomainMap := make(map[string]string)
+	for _, privateDomain := range orgConfig.PrivateDomains {
+		if existingPrivateDomain, ok := allPrivateDomains[privateDomain]; ok {
+			if org.GUID != existingPrivateDomain.OwningOrganizationGuid {
+				existingOrg, err := m.OrgReader.FindOrgByGUID(ctx, existingPrivateDomain.OwningOrganizationGuid)
+				if err != nil {
+					return nil, err
 				}
+				return nil, fmt.Errorf("Private Domain %s already exists in org [%s]", privateDomain, existingOrg.Name)
 			}
 		} else {
-			lo.G.Debugf("Private domains will not be removed for org [%s], must set enable-remove-private-domains: true in orgConfig.yml", orgConfig.Org)
+			privateDomain, err := m.CreatePrivateDomain(org, privateDomain)
+			if err != nil {
+				return nil, err
+			}
+			allPrivateDomains[privateDomain.Name] = *privateDomain
 		}
+		privateDomainMap[privateDomain] = privateDomain
 	}
 
-	return nil
+	var deletions []privateDomainDeletion
+	if orgConfig.RemovePrivateDomains {
+		orgPrivateDomains, err := m.ListOrgOwnedPrivateDomains(ctx, org.GUID)
+		if err != nil {
+			return nil, err
+		}
+		for existingPrivateDomain, privateDomain := range orgPrivateDomains {
+			if _, ok := privateDomainMap[existingPrivateDomain]; !ok {
+				deletions = append(deletions, privateDomainDeletion{orgName: orgConfig.Org, domain: privateDomain})
+			}
+		}
+	} else {
+		lo.G.Debugf("Private domains will not be removed for org [%s], must set enable-remove-private-domains: true in orgConfig.yml", orgConfig.Org)
+	}
+	return deletions, nil
 }
 
-func (m *DefaultManager) SharePrivateDomains() error {
+func (m *DefaultManager) SharePrivateDomains(ctx context.Context) error {
 	orgConfigs, err := m.Cfg.GetOrgConfigs()
 	if err != nil {
 		return err
@@ -98,46 +150,60 @@ func (m *DefaultManager) SharePrivateDomains() error {
 		return err
 	}
 	for _, orgConfig := range orgConfigs {
-		org, err := m.OrgReader.FindOrg(orgConfig.Org)
-		if err != nil {
+		if err := ctx.Err(); err != nil {
 			return err
 		}
-		orgSharedPrivateDomains, err := m.ListOrgSharedPrivateDomains(org.GUID)
-		if err != nil {
+		if m.Failures.Skip(orgConfig.Org, "") {
+			lo.G.Infof("skipping shared private domains for org [%s] as it failed in an earlier step", orgConfig.Org)
+			continue
+		}
+		if err := m.Failures.Add(orgConfig.Org, "", m.sharePrivateDomains(ctx, orgConfig, privateDomains)); err != nil {
 			return err
 		}
+	}
 
-		lo.G.Debugf("Org %s Shared Domains %+v", orgConfig.Org, reflect.ValueOf(orgSharedPrivateDomains).MapKeys())
+	return nil
+}
 
-		for _, privateDomainName := range orgConfig.SharedPrivateDomains {
-			if _, ok := orgSharedPrivateDomains[privateDomainName]; !ok {
-				if privateDomain, ok := privateDomains[privateDomainName]; ok {
-					err = m.SharePrivateDomain(org, privateDomain)
-					if err != nil {
-						return err
-					}
-				} else {
-					return fmt.Errorf("Private Domain [%s] is not defined", privateDomainName)
-				}
-			} else {
-				lo.G.Debugf("Org %s already contains shared private domain %s", orgConfig.Org, privateDomainName)
-				delete(orgSharedPrivateDomains, privateDomainName)
-			}
-		}
+func (m *DefaultManager) sharePrivateDomains(ctx context.Context, orgConfig config.OrgConfig, privateDomains map[string]cfclient.Domain) error {
+	org, err := m.OrgReader.FindOrg(ctx, orgConfig.Org)
+	if err != nil {
+		return err
+	}
+	orgSharedPrivateDomains, err := m.ListOrgSharedPrivateDomains(ctx, org.GUID)
+	if err != nil {
+		return err
+	}
 
-		if orgConfig.RemoveSharedPrivateDomains {
-			lo.G.Debugf("Org %s Shared Domains to be removed %+v", orgConfig.Org, reflect.ValueOf(orgSharedPrivateDomains).MapKeys())
-			for _, privateDomain := range orgSharedPrivateDomains {
-				err = m.RemoveSharedPrivateDomain(org, privateDomain)
+	lo.G.Debugf("Org %s Shared Domains %+v", orgConfig.Org, reflect.ValueOf(orgSharedPrivateDomains).MapKeys())
+
+	for _, privateDomainName := range orgConfig.SharedPrivateDomains {
+		if _, ok := orgSharedPrivateDomains[privateDomainName]; !ok {
+			if privateDomain, ok := privateDomains[privateDomainName]; ok {
+				err = m.SharePrivateDomain(org, privateDomain)
 				if err != nil {
 					return err
 				}
+			} else {
+				return fmt.Errorf("Private Domain [%s] is not defined", privateDomainName)
 			}
 		} else {
-			lo.G.Debugf("Shared private domains will not be removed for org [%s], must set enable-remove-shared-private-domains: true in orgConfig.yml", orgConfig.Org)
+			lo.G.Debugf("Org %s already contains shared private domain %s", orgConfig.Org, privateDomainName)
+			delete(orgSharedPrivateDomains, privateDomainName)
 		}
 	}
 
+	if orgConfig.RemoveSharedPrivateDomains {
+		lo.G.Debugf("Org %s Shared Domains to be removed %+v", orgConfig.Org, reflect.ValueOf(orgSharedPrivateDomains).MapKeys())
+		for _, privateDomain := range orgSharedPrivateDomains {
+			err = m.RemoveSharedPrivateDomain(org, privateDomain)
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		lo.G.Debugf("Shared private domains will not be removed for org [%s], must set enable-remove-shared-private-domains: true in orgConfig.yml", orgConfig.Org)
+	}
 	return nil
 }
 
@@ -151,28 +217,47 @@ func (m *DefaultManager) ListAllPrivateDomains() (map[string]cfclient.Domain, er
 	for _, privateDomain := range domains {
 		privateDomainMap[privateDomain.Name] = privateDomain
 	}
+	for name, privateDomain := range m.simulated {
+		privateDomainMap[name] = privateDomain
+	}
 	return privateDomainMap, nil
 }
 
 func (m *DefaultManager) CreatePrivateDomain(org *resource.Organization, privateDomain string) (*cfclient.Domain, error) {
+	change := changes.Change{Entity: changes.PrivateDomain, Action: changes.Create, Name: privateDomain, Org: org.Name}
+	m.Recorder.Record(change)
 	if m.Peek {
 		lo.G.Infof("[dry-run]: create private domain %s for org %s", privateDomain, org.Name)
-		return &cfclient.Domain{Guid: "dry-run-guid", Name: privateDomain, OwningOrganizationGuid: org.GUID}, nil
+		domain := cfclient.Domain{Guid: fmt.Sprintf("%s-dry-run-private-domain-guid", privateDomain), Name: privateDomain, OwningOrganizationGuid: org.GUID}
+		if m.simulated == nil {
+			m.simulated = make(map[string]cfclient.Domain)
+		}
+		m.simulated[privateDomain] = domain
+		return &domain, nil
 	}
 	lo.G.Infof("Creating Private Domain %s for Org %s", privateDomain, org.Name)
-	return m.Client.CreateDomain(privateDomain, org.GUID)
+	domain, err := m.Client.CreateDomain(privateDomain, org.GUID)
+	var guid string
+	if domain != nil {
+		guid = domain.Guid
+	}
+	m.Recorder.Applied(change, guid, err)
+	return domain, err
 }
 func (m *DefaultManager) SharePrivateDomain(org *resource.Organization, domain cfclient.Domain) error {
+	change := changes.Change{Entity: changes.PrivateDomain, Action: changes.Assign, Name: domain.Name, Org: org.Name}
+	m.Recorder.Record(change)
 	if m.Peek {
 		lo.G.Infof("[dry-run]: Share private domain %s for org %s", domain.Name, org.Name)
 		return nil
 	}
 	lo.G.Infof("Share private domain %s for org %s", domain.Name, org.Name)
 	_, err := m.Client.ShareOrgPrivateDomain(org.GUID, domain.Guid)
+	m.Recorder.Applied(change, domain.Guid, err)
 	return err
 }
 
-func (m *DefaultManager) ListOrgSharedPrivateDomains(orgGUID string) (map[string]cfclient.Domain, error) {
+func (m *DefaultManager) ListOrgSharedPrivateDomains(ctx context.Context, orgGUID string) (map[string]cfclient.Domain, error) {
 	orgSharedPrivateDomainMap := make(map[string]cfclient.Domain)
 	orgPrivateDomains, err := m.listOrgPrivateDomains(orgGUID)
 	if err != nil {
@@ -199,7 +284,7 @@ func (m *DefaultManager) listOrgPrivateDomains(orgGUID string) ([]cfclient.Domai
 	return privateDomains, nil
 }
 
-func (m *DefaultManager) ListOrgOwnedPrivateDomains(orgGUID string) (map[string]cfclient.Domain, error) {
+func (m *DefaultManager) ListOrgOwnedPrivateDomains(ctx context.Context, orgGUID string) (map[string]cfclient.Domain, error) {
 	orgOwnedPrivateDomainMap := make(map[string]cfclient.Domain)
 	orgPrivateDomains, err := m.listOrgPrivateDomains(orgGUID)
 	if err != nil {
@@ -214,19 +299,27 @@ func (m *DefaultManager) ListOrgOwnedPrivateDomains(orgGUID string) (map[string]
 }
 
 func (m *DefaultManager) DeletePrivateDomain(domain cfclient.Domain) error {
+	change := changes.Change{Entity: changes.PrivateDomain, Action: changes.Delete, Name: domain.Name}
+	m.Recorder.Record(change)
 	if m.Peek {
 		lo.G.Infof("[dry-run]: Delete private domain %s", domain.Name)
 		return nil
 	}
 	lo.G.Infof("Delete private domain %s", domain.Name)
-	return m.Client.DeleteDomain(domain.Guid)
+	err := m.Client.DeleteDomain(domain.Guid)
+	m.Recorder.Applied(change, domain.Guid, err)
+	return err
 }
 
 func (m *DefaultManager) RemoveSharedPrivateDomain(org *resource.Organization, domain cfclient.Domain) error {
+	change := changes.Change{Entity: changes.PrivateDomain, Action: changes.Unassign, Name: domain.Name, Org: org.Name}
+	m.Recorder.Record(change)
 	if m.Peek {
 		lo.G.Infof("[dry-run]: Unshare private domain %s for org %s", domain.Name, org.Name)
 		return nil
 	}
 	lo.G.Infof("Unshare private domain %s for org %s", domain.Name, org.Name)
-	return m.Client.UnshareOrgPrivateDomain(org.GUID, domain.Guid)
+	err := m.Client.UnshareOrgPrivateDomain(org.GUID, domain.Guid)
+	m.Recorder.Applied(change, domain.Guid, err)
+	return err
 }
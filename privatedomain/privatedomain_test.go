@@ -1,6 +1,8 @@
 package privatedomain_test
 
 import (
+	"context"
+
 	"errors"
 
 	cfclient "github.com/cloudfoundry-community/go-cfclient"
@@ -25,6 +27,7 @@ var _ = Describe("given UserSpaces", func() {
 		client = new(fakes.FakeCFClient)
 		fakeReader = new(configfakes.FakeReader)
 		orgFake = new(orgfakes.FakeReader)
+		fakeReader.GetGlobalConfigReturns(&config.GlobalConfig{}, nil)
 	})
 	Context("Manager()", func() {
 		BeforeEach(func() {
@@ -50,7 +53,7 @@ var _ = Describe("given UserSpaces", func() {
 			})
 			It("should succeed when no private domain doesn't exist", func() {
 				client.CreateDomainReturns(&cfclient.Domain{Name: "test.com", Guid: "test.com-guid"}, nil)
-				err := manager.CreatePrivateDomains()
+				err := manager.CreatePrivateDomains(context.Background())
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(client.CreateDomainCallCount()).Should(Equal(1))
 				domain, orgGUID := client.CreateDomainArgsForCall(0)
@@ -60,7 +63,7 @@ var _ = Describe("given UserSpaces", func() {
 
 			It("should error when no private domain doesn't exist", func() {
 				client.CreateDomainReturns(nil, errors.New("error"))
-				err := manager.CreatePrivateDomains()
+				err := manager.CreatePrivateDomains(context.Background())
 				Expect(err).Should(HaveOccurred())
 				Expect(client.CreateDomainCallCount()).Should(Equal(1))
 				domain, orgGUID := client.CreateDomainArgsForCall(0)
@@ -73,7 +76,7 @@ var _ = Describe("given UserSpaces", func() {
 					{Name: "test.com", OwningOrganizationGuid: "test-guid"},
 				}, nil)
 				client.CreateDomainReturns(&cfclient.Domain{Name: "test.com", Guid: "test.com-guid"}, nil)
-				err := manager.CreatePrivateDomains()
+				err := manager.CreatePrivateDomains(context.Background())
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(client.CreateDomainCallCount()).Should(Equal(0))
 			})
@@ -84,7 +87,7 @@ var _ = Describe("given UserSpaces", func() {
 					{Name: "test.com", OwningOrganizationGuid: "foo-guid"},
 				}, nil)
 				client.CreateDomainReturns(&cfclient.Domain{Name: "test.com", Guid: "test.com-guid"}, nil)
-				err := manager.CreatePrivateDomains()
+				err := manager.CreatePrivateDomains(context.Background())
 				Expect(err).Should(HaveOccurred())
 				Expect(client.CreateDomainCallCount()).Should(Equal(0))
 			})
@@ -103,7 +106,7 @@ var _ = Describe("given UserSpaces", func() {
 				client.ListOrgPrivateDomainsReturns([]cfclient.Domain{
 					{Name: "test.com", Guid: "test.com-guid", OwningOrganizationGuid: "test-guid"},
 				}, nil)
-				err := manager.CreatePrivateDomains()
+				err := manager.CreatePrivateDomains(context.Background())
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(client.CreateDomainCallCount()).Should(Equal(0))
 				Expect(client.DeleteDomainCallCount()).Should(Equal(1))
@@ -126,7 +129,7 @@ var _ = Describe("given UserSpaces", func() {
 					{Name: "test.com", Guid: "test.com-guid", OwningOrganizationGuid: "test-guid"},
 				}, nil)
 				client.DeleteDomainReturns(errors.New("error"))
-				err := manager.CreatePrivateDomains()
+				err := manager.CreatePrivateDomains(context.Background())
 				Expect(err).Should(HaveOccurred())
 				Expect(client.CreateDomainCallCount()).Should(Equal(0))
 				Expect(client.DeleteDomainCallCount()).Should(Equal(1))
@@ -134,30 +137,59 @@ var _ = Describe("given UserSpaces", func() {
 				Expect(guid).Should(Equal("test.com-guid"))
 			})
 
+			It("should refuse to remove more private domains than the limit", func() {
+				fakeReader.GetOrgConfigsReturns([]config.OrgConfig{
+					{
+						Org:                  "test",
+						PrivateDomains:       []string{},
+						RemovePrivateDomains: true,
+					},
+				}, nil)
+				fakeReader.GetGlobalConfigReturns(&config.GlobalConfig{
+					DeletionLimits: config.DeletionLimits{
+						PrivateDomains: config.DeletionLimit{Max: 1},
+					},
+				}, nil)
+				domains := []cfclient.Domain{
+					{Name: "test.com", Guid: "test.com-guid", OwningOrganizationGuid: "test-guid"},
+					{Name: "test2.com", Guid: "test2.com-guid", OwningOrganizationGuid: "test-guid"},
+				}
+				client.ListDomainsReturns(domains, nil)
+				client.ListOrgPrivateDomainsReturns(domains, nil)
+				err := manager.CreatePrivateDomains(context.Background())
+				Expect(err).Should(MatchError("refusing to delete 2 of 2 private domains as it exceeds the limit of 1 set in cf-mgmt.yml deletion-limits, use --allow-large-deletions to override"))
+				Expect(client.DeleteDomainCallCount()).Should(Equal(0))
+
+				manager.AllowLargeDeletions = true
+				err = manager.CreatePrivateDomains(context.Background())
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(client.DeleteDomainCallCount()).Should(Equal(2))
+			})
+
 			It("should error getting org config", func() {
 				fakeReader.GetOrgConfigsReturns(nil, errors.New("error"))
-				err := manager.CreatePrivateDomains()
+				err := manager.CreatePrivateDomains(context.Background())
 				Expect(err).Should(HaveOccurred())
 				Expect(client.CreateDomainCallCount()).Should(Equal(0))
 			})
 
 			It("should error listing orgs", func() {
 				orgFake.FindOrgReturns(&resource.Organization{}, errors.New("org test does not exist"))
-				err := manager.CreatePrivateDomains()
+				err := manager.CreatePrivateDomains(context.Background())
 				Expect(err).Should(HaveOccurred())
 				Expect(client.CreateDomainCallCount()).Should(Equal(0))
 			})
 
 			It("should error listing domains", func() {
 				client.ListDomainsReturns(nil, errors.New("error"))
-				err := manager.CreatePrivateDomains()
+				err := manager.CreatePrivateDomains(context.Background())
 				Expect(err).Should(HaveOccurred())
 				Expect(client.CreateDomainCallCount()).Should(Equal(0))
 			})
 
 			It("should error when org doesn't exist", func() {
 				orgFake.FindOrgReturns(&resource.Organization{}, errors.New("org test does not exist"))
-				err := manager.CreatePrivateDomains()
+				err := manager.CreatePrivateDomains(context.Background())
 				Expect(err).Should(HaveOccurred())
 				Expect(client.CreateDomainCallCount()).Should(Equal(0))
 				Expect(err.Error()).Should(Equal("org test does not exist"))
@@ -178,7 +210,7 @@ var _ = Describe("given UserSpaces", func() {
 					{Name: "test.com", Guid: "test.com-guid", OwningOrganizationGuid: "test-guid"},
 				}, nil)
 				client.ListOrgPrivateDomainsReturns(nil, errors.New("error"))
-				err := manager.CreatePrivateDomains()
+				err := manager.CreatePrivateDomains(context.Background())
 				Expect(err).Should(HaveOccurred())
 				Expect(client.CreateDomainCallCount()).Should(Equal(0))
 				Expect(client.DeleteDomainCallCount()).Should(Equal(0))
@@ -204,7 +236,7 @@ var _ = Describe("given UserSpaces", func() {
 					{Name: "test.com", Guid: "test.com-guid", OwningOrganizationGuid: "test-guid"},
 				}, nil)
 				client.ListOrgPrivateDomainsReturns(nil, nil)
-				err := manager.SharePrivateDomains()
+				err := manager.SharePrivateDomains(context.Background())
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(client.ShareOrgPrivateDomainCallCount()).Should(Equal(1))
 				orgGUID, domainGUID := client.ShareOrgPrivateDomainArgsForCall(0)
@@ -215,7 +247,7 @@ var _ = Describe("given UserSpaces", func() {
 			It("should error when private domain doesn't already exist", func() {
 				client.ListDomainsReturns(nil, nil)
 				client.ListOrgPrivateDomainsReturns(nil, nil)
-				err := manager.SharePrivateDomains()
+				err := manager.SharePrivateDomains(context.Background())
 				Expect(err).Should(HaveOccurred())
 				Expect(err.Error()).Should(Equal("Private Domain [test.com] is not defined"))
 				Expect(client.ShareOrgPrivateDomainCallCount()).Should(Equal(0))
@@ -227,7 +259,7 @@ var _ = Describe("given UserSpaces", func() {
 				}, nil)
 				client.ListOrgPrivateDomainsReturns(nil, nil)
 				client.ShareOrgPrivateDomainReturns(nil, errors.New("error"))
-				err := manager.SharePrivateDomains()
+				err := manager.SharePrivateDomains(context.Background())
 				Expect(err).Should(HaveOccurred())
 				Expect(client.ShareOrgPrivateDomainCallCount()).Should(Equal(1))
 				orgGUID, domainGUID := client.ShareOrgPrivateDomainArgsForCall(0)
@@ -249,7 +281,7 @@ var _ = Describe("given UserSpaces", func() {
 				client.ListOrgPrivateDomainsReturns([]cfclient.Domain{
 					{Name: "test.com", Guid: "test.com-guid", OwningOrganizationGuid: "test-guid"},
 				}, nil)
-				err := manager.SharePrivateDomains()
+				err := manager.SharePrivateDomains(context.Background())
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(client.UnshareOrgPrivateDomainCallCount()).Should(Equal(1))
 				orgGUID, domainGUID := client.UnshareOrgPrivateDomainArgsForCall(0)
@@ -271,7 +303,7 @@ var _ = Describe("given UserSpaces", func() {
 				client.ListOrgPrivateDomainsReturns([]cfclient.Domain{
 					{Name: "test.com", Guid: "test.com-guid", OwningOrganizationGuid: "test-guid"},
 				}, nil)
-				err := manager.SharePrivateDomains()
+				err := manager.SharePrivateDomains(context.Background())
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(client.UnshareOrgPrivateDomainCallCount()).Should(Equal(0))
 
@@ -292,7 +324,7 @@ var _ = Describe("given UserSpaces", func() {
 				client.ListOrgPrivateDomainsReturns([]cfclient.Domain{
 					{Name: "test.com", Guid: "test.com-guid", OwningOrganizationGuid: "test-guid"},
 				}, nil)
-				err := manager.SharePrivateDomains()
+				err := manager.SharePrivateDomains(context.Background())
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(client.ShareOrgPrivateDomainCallCount()).Should(Equal(1))
 				orgGUID, domainGUID := client.ShareOrgPrivateDomainArgsForCall(0)
@@ -320,7 +352,7 @@ var _ = Describe("given UserSpaces", func() {
 					{Name: "test.com", Guid: "test.com-guid", OwningOrganizationGuid: "test-guid"},
 				}, nil)
 				client.UnshareOrgPrivateDomainReturns(errors.New("error"))
-				err := manager.SharePrivateDomains()
+				err := manager.SharePrivateDomains(context.Background())
 				Expect(err).Should(HaveOccurred())
 				Expect(client.UnshareOrgPrivateDomainCallCount()).Should(Equal(1))
 				orgGUID, domainGUID := client.UnshareOrgPrivateDomainArgsForCall(0)
@@ -330,32 +362,32 @@ var _ = Describe("given UserSpaces", func() {
 
 			It("should error getting org config", func() {
 				fakeReader.GetOrgConfigsReturns(nil, errors.New("error"))
-				err := manager.SharePrivateDomains()
+				err := manager.SharePrivateDomains(context.Background())
 				Expect(err).Should(HaveOccurred())
 			})
 
 			It("should error listing orgs", func() {
 				orgFake.ListOrgsReturns(nil, errors.New("error"))
-				err := manager.SharePrivateDomains()
+				err := manager.SharePrivateDomains(context.Background())
 				Expect(err).Should(HaveOccurred())
 			})
 
 			It("should error listing domains", func() {
 				client.ListDomainsReturns(nil, errors.New("error"))
-				err := manager.SharePrivateDomains()
+				err := manager.SharePrivateDomains(context.Background())
 				Expect(err).Should(HaveOccurred())
 			})
 
 			It("should error when org doesn't exist", func() {
 				orgFake.FindOrgReturns(&resource.Organization{}, errors.New("org test2 does not exist"))
-				err := manager.SharePrivateDomains()
+				err := manager.SharePrivateDomains(context.Background())
 				Expect(err).Should(HaveOccurred())
 				Expect(err.Error()).Should(Equal("org test2 does not exist"))
 			})
 
 			It("should error listing org private domains", func() {
 				client.ListOrgPrivateDomainsReturns(nil, errors.New("error"))
-				err := manager.SharePrivateDomains()
+				err := manager.SharePrivateDomains(context.Background())
 				Expect(err).Should(HaveOccurred())
 			})
 		})
@@ -377,6 +409,16 @@ var _ = Describe("given UserSpaces", func() {
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(client.CreateDomainCallCount()).Should(Equal(0))
 			})
+
+			It("should list the peeked domain as a private domain", func() {
+				manager.Peek = true
+				_, err := manager.CreatePrivateDomain(&resource.Organization{Name: "test", GUID: "test-guid"}, "test.com")
+				Expect(err).ShouldNot(HaveOccurred())
+				domains, err := manager.ListAllPrivateDomains()
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(domains).Should(HaveKey("test.com"))
+				Expect(domains["test.com"].OwningOrganizationGuid).Should(Equal("test-guid"))
+			})
 		})
 
 		Context("SharePrivateDomain", func() {
@@ -1,15 +1,17 @@
 package privatedomain
 
 import (
+	"context"
+
 	cfclient "github.com/cloudfoundry-community/go-cfclient"
 )
 
 // Manager -
 type Manager interface {
-	CreatePrivateDomains() error
-	SharePrivateDomains() error
-	ListOrgSharedPrivateDomains(orgGUID string) (map[string]cfclient.Domain, error)
-	ListOrgOwnedPrivateDomains(orgGUID string) (map[string]cfclient.Domain, error)
+	CreatePrivateDomains(ctx context.Context) error
+	SharePrivateDomains(ctx context.Context) error
+	ListOrgSharedPrivateDomains(ctx context.Context, orgGUID string) (map[string]cfclient.Domain, error)
+	ListOrgOwnedPrivateDomains(ctx context.Context, orgGUID string) (map[string]cfclient.Domain, error)
 }
 
 type CFClient interface {
@@ -6,13 +6,17 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 
 	"github.com/cloudfoundry-community/go-cfclient/v3/client"
 	"github.com/cloudfoundry-community/go-cfclient/v3/resource"
 	"github.com/pkg/errors"
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
 	"github.com/vmwarepivotallabs/cf-mgmt/config"
+	"github.com/vmwarepivotallabs/cf-mgmt/failures"
 	"github.com/vmwarepivotallabs/cf-mgmt/organizationreader"
 	"github.com/vmwarepivotallabs/cf-mgmt/space"
+	"github.com/vmwarepivotallabs/cf-mgmt/util"
 	"github.com/xchapter7x/lo"
 )
 
@@ -22,14 +26,17 @@ func NewManager(
 	orgQuotaClient CFOrgQuotaClient,
 	spaceMgr space.Manager,
 	orgReader organizationreader.Reader,
-	cfg config.Reader, peek bool) *Manager {
+	cfg config.Reader, parallelism int, failures *failures.Collector, recorder *changes.Recorder, peek bool) *Manager {
 	return &Manager{
 		Cfg:              cfg,
 		SpaceQuoteClient: spaceQuotaClient,
 		OrgQuoteClient:   orgQuotaClient,
 		SpaceMgr:         spaceMgr,
 		OrgReader:        orgReader,
+		Failures:         failures,
+		Recorder:         recorder,
 		Peek:             peek,
+		Parallelism:      parallelism,
 	}
 }
 
@@ -40,75 +47,93 @@ type Manager struct {
 	OrgQuoteClient   CFOrgQuotaClient
 	SpaceMgr         space.Manager
 	OrgReader        organizationreader.Reader
+	Failures         *failures.Collector
+	Recorder         *changes.Recorder
 	Peek             bool
+	Parallelism      int
 	SpaceQuotas      map[string]map[string]*resource.SpaceQuota
+	mutex            sync.Mutex
+	// simulatedSpaceQuotas - space quotas created while peeking for orgs that don't exist yet
+	simulatedSpaceQuotas map[string]map[string]*resource.SpaceQuota
 }
 
 // CreateSpaceQuotas -
-func (m *Manager) CreateSpaceQuotas() error {
+func (m *Manager) CreateSpaceQuotas(ctx context.Context) error {
 	m.SpaceQuotas = nil
 	spaceConfigs, err := m.Cfg.GetSpaceConfigs()
 	if err != nil {
 		return err
 	}
 
-	for _, input := range spaceConfigs {
-		if input.NamedQuota != "" && input.EnableSpaceQuota {
-			return fmt.Errorf("cannot have named quota %s and enable-space-quota for org/space %s/%s", input.NamedQuota, input.Org, input.Space)
+	errs := util.RunGrouped(ctx, m.Parallelism, len(spaceConfigs), func(i int) string { return spaceConfigs[i].Org }, true, func(i int) error {
+		input := spaceConfigs[i]
+		if m.Failures.Skip(input.Org, input.Space) {
+			lo.G.Infof("skipping space [%s/%s] quotas as it failed in an earlier step", input.Org, input.Space)
+			return nil
+		}
+		return m.Failures.Add(input.Org, input.Space, m.createSpaceQuotas(ctx, input))
+	})
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// createSpaceQuotas - spaces of the same org are never processed concurrently as they share the org's named quotas
+func (m *Manager) createSpaceQuotas(ctx context.Context, input config.SpaceConfig) error {
+	if input.NamedQuota != "" && input.EnableSpaceQuota {
+		return fmt.Errorf("cannot have named quota %s and enable-space-quota for org/space %s/%s", input.NamedQuota, input.Org, input.Space)
+	}
+	if input.NamedQuota != "" || input.EnableSpaceQuota {
+		space, err := m.SpaceMgr.FindSpace(ctx, input.Org, input.Space)
+		if err != nil {
+			return errors.Wrap(err, "Finding spaces")
+		}
+		quotas, err := m.ListAllSpaceQuotasForOrg(ctx, space.Relationships.Organization.Data.GUID)
+		if err != nil {
+			return errors.Wrap(err, "ListAllSpaceQuotasForOrg")
 		}
-		if input.NamedQuota != "" || input.EnableSpaceQuota {
-			space, err := m.SpaceMgr.FindSpace(input.Org, input.Space)
-			if err != nil {
-				return errors.Wrap(err, "Finding spaces")
-			}
-			quotas, err := m.ListAllSpaceQuotasForOrg(space.Relationships.Organization.Data.GUID)
-			if err != nil {
-				return errors.Wrap(err, "ListAllSpaceQuotasForOrg")
-			}
 
-			orgQuotas, err := m.ListAllOrgQuotas()
+		orgQuotas, err := m.ListAllOrgQuotas(ctx)
+		if err != nil {
+			return err
+		}
+		if input.NamedQuota != "" {
+			spaceQuotas, err := m.Cfg.GetSpaceQuotas(input.Org)
 			if err != nil {
 				return err
 			}
-			if input.NamedQuota != "" {
-				spaceQuotas, err := m.Cfg.GetSpaceQuotas(input.Org)
+
+			for _, spaceQuotaConfig := range spaceQuotas {
+				err = m.createSpaceQuota(ctx, spaceQuotaConfig, space, quotas, orgQuotas)
 				if err != nil {
 					return err
 				}
-
-				for _, spaceQuotaConfig := range spaceQuotas {
-					err = m.createSpaceQuota(spaceQuotaConfig, space, quotas, orgQuotas)
-					if err != nil {
-						return err
-					}
-				}
-			} else {
-				if input.EnableSpaceQuota {
-					quotaDef := input.GetQuota()
-					err = m.createSpaceQuota(quotaDef, space, quotas, orgQuotas)
-					if err != nil {
-						return err
-					}
-					input.NamedQuota = input.Space
+			}
+		} else {
+			if input.EnableSpaceQuota {
+				quotaDef := input.GetQuota()
+				err = m.createSpaceQuota(ctx, quotaDef, space, quotas, orgQuotas)
+				if err != nil {
+					return err
 				}
+				input.NamedQuota = input.Space
 			}
-			spaceQuota := quotas[input.NamedQuota]
+		}
+		spaceQuota := quotas[input.NamedQuota]
 
-			if m.Peek != true {
-				if spaceQuota != nil && (space.Relationships.Quota.Data == nil || space.Relationships.Quota.Data.GUID != spaceQuota.GUID) {
-					if err = m.AssignQuotaToSpace(space, spaceQuota); err != nil {
-						return err
-					}
-				}
+		if spaceQuota != nil && (space.Relationships.Quota == nil || space.Relationships.Quota.Data == nil || space.Relationships.Quota.Data.GUID != spaceQuota.GUID) {
+			if err = m.AssignQuotaToSpace(ctx, input.Org, space, spaceQuota); err != nil {
+				return err
 			}
 		}
 	}
 	return nil
 }
 
-func (m *Manager) createSpaceQuota(input config.SpaceQuota, space *resource.Space, quotas map[string]*resource.SpaceQuota, orgQuotas map[string]*resource.OrganizationQuota) error {
+func (m *Manager) createSpaceQuota(ctx context.Context, input config.SpaceQuota, space *resource.Space, quotas map[string]*resource.SpaceQuota, orgQuotas map[string]*resource.OrganizationQuota) error {
 
-	org, err := m.OrgReader.FindOrg(input.Org)
+	org, err := m.OrgReader.FindOrg(ctx, input.Org)
 	if err != nil {
 		return err
 	}
@@ -159,12 +184,12 @@ func (m *Manager) createSpaceQuota(input config.SpaceQuota, space *resource.Spac
 	}
 
 	if input.IsUnlimitedMemory() {
-		org, err := m.OrgReader.FindOrg(input.Org)
+		org, err := m.OrgReader.FindOrg(ctx, input.Org)
 		if err != nil {
 			return err
 		}
 		for _, orgQuota := range orgQuotas {
-			if org.Relationships.Quota.Data.GUID == orgQuota.GUID {
+			if org.Relationships.Quota.Data != nil && org.Relationships.Quota.Data.GUID == orgQuota.GUID {
 				if orgQuota.Apps.TotalMemoryInMB == nil {
 					memoryLimit = nil
 				} else {
@@ -192,12 +217,30 @@ func (m *Manager) createSpaceQuota(input config.SpaceQuota, space *resource.Spac
 
 	if spaceQuota, ok := quotas[input.Name]; ok {
 		if m.hasSpaceQuotaChanged(spaceQuota, quota) {
-			if err := m.UpdateSpaceQuota(spaceQuota.GUID, quota); err != nil {
+			change := changes.Change{
+				Entity: changes.SpaceQuota, Action: changes.Update, Name: input.Name, Org: input.Org,
+				Before: spaceQuotaValues(spaceQuota.Apps, spaceQuota.Routes, spaceQuota.Services),
+				After:  spaceQuotaValues(*quota.Apps, *quota.Routes, *quota.Services),
+			}
+			m.Recorder.Record(change)
+			err := m.UpdateSpaceQuota(ctx, spaceQuota.GUID, quota)
+			m.applied(change, spaceQuota.GUID, err)
+			if err != nil {
 				return err
 			}
 		}
 	} else {
-		createdQuota, err := m.CreateSpaceQuota(quota)
+		change := changes.Change{
+			Entity: changes.SpaceQuota, Action: changes.Create, Name: input.Name, Org: input.Org,
+			After: spaceQuotaValues(*quota.Apps, *quota.Routes, *quota.Services),
+		}
+		m.Recorder.Record(change)
+		createdQuota, err := m.CreateSpaceQuota(ctx, quota)
+		var guid string
+		if createdQuota != nil {
+			guid = createdQuota.GUID
+		}
+		m.applied(change, guid, err)
 		if err != nil {
 			return err
 		}
@@ -229,18 +272,43 @@ func (m *Manager) hasSpaceQuotaChanged(quota *resource.SpaceQuota, newQuota *res
 	return false
 }
 
+func spaceQuotaValues(apps resource.SpaceQuotaApps, routes resource.SpaceQuotaRoutes, services resource.SpaceQuotaServices) map[string]interface{} {
+	return map[string]interface{}{
+		"apps":     apps,
+		"routes":   routes,
+		"services": services,
+	}
+}
+
+func orgQuotaValues(apps resource.OrganizationQuotaApps, routes resource.OrganizationQuotaRoutes, services resource.OrganizationQuotaServices, domains resource.OrganizationQuotaDomains) map[string]interface{} {
+	return map[string]interface{}{
+		"apps":     apps,
+		"routes":   routes,
+		"services": services,
+		"domains":  domains,
+	}
+}
+
 func (m *Manager) debugCompareOutput(msg string, a interface{}, b interface{}) {
 	aOutput, _ := json.Marshal(a)
 	bOutput, _ := json.Marshal(b)
 	lo.G.Debugf(msg, string(aOutput), string(bOutput))
 }
 
-func (m *Manager) ListAllSpaceQuotasForOrg(orgGUID string) (map[string]*resource.SpaceQuota, error) {
+func (m *Manager) ListAllSpaceQuotasForOrg(ctx context.Context, orgGUID string) (map[string]*resource.SpaceQuota, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 	if m.Peek && strings.Contains(orgGUID, "dry-run-org-guid") {
-		return make(map[string]*resource.SpaceQuota), nil
+		if m.simulatedSpaceQuotas == nil {
+			m.simulatedSpaceQuotas = make(map[string]map[string]*resource.SpaceQuota)
+		}
+		if _, ok := m.simulatedSpaceQuotas[orgGUID]; !ok {
+			m.simulatedSpaceQuotas[orgGUID] = make(map[string]*resource.SpaceQuota)
+		}
+		return m.simulatedSpaceQuotas[orgGUID], nil
 	}
 	if m.SpaceQuotas == nil {
-		spaceQuotas, err := m.SpaceQuoteClient.ListAll(context.Background(), &client.SpaceQuotaListOptions{
+		spaceQuotas, err := m.SpaceQuoteClient.ListAll(util.CallContext(ctx), &client.SpaceQuotaListOptions{
 			ListOptions: &client.ListOptions{
 				PerPage: 5000,
 			},
@@ -263,40 +331,45 @@ func (m *Manager) ListAllSpaceQuotasForOrg(orgGUID string) (map[string]*resource
 	}
 	spaceQuotas := m.SpaceQuotas[orgGUID]
 	if spaceQuotas == nil {
+		// keep the map so quotas created for one space are seen by the next space in the org
 		spaceQuotas = make(map[string]*resource.SpaceQuota)
+		m.SpaceQuotas[orgGUID] = spaceQuotas
 	}
 	lo.G.Debug("Total space quotas returned :", len(spaceQuotas))
 	return spaceQuotas, nil
 }
 
-func (m *Manager) UpdateSpaceQuota(quotaGUID string, quota *resource.SpaceQuotaCreateOrUpdate) error {
+func (m *Manager) UpdateSpaceQuota(ctx context.Context, quotaGUID string, quota *resource.SpaceQuotaCreateOrUpdate) error {
 	if m.Peek {
 		lo.G.Infof("[dry-run]: update space quota %s", *quota.Name)
 		return nil
 	}
 	lo.G.Infof("Updating space quota %s", *quota.Name)
 	quota.Relationships = nil
-	_, err := m.SpaceQuoteClient.Update(context.Background(), quotaGUID, quota)
+	_, err := m.SpaceQuoteClient.Update(util.CallContext(ctx), quotaGUID, quota)
 	return err
 }
 
-func (m *Manager) AssignQuotaToSpace(space *resource.Space, quota *resource.SpaceQuota) error {
+func (m *Manager) AssignQuotaToSpace(ctx context.Context, orgName string, space *resource.Space, quota *resource.SpaceQuota) error {
+	change := changes.Change{Entity: changes.SpaceQuota, Action: changes.Assign, Name: quota.Name, Org: orgName, Space: space.Name, After: quota.Name}
+	m.Recorder.Record(change)
 	if m.Peek {
 		lo.G.Infof("[dry-run]: assigning quota %s to space %s", quota.Name, space.Name)
 		return nil
 	}
 	lo.G.Infof("Assigning quota %s to %s", quota.Name, space.Name)
-	_, err := m.SpaceQuoteClient.Apply(context.Background(), quota.GUID, []string{space.GUID})
+	_, err := m.SpaceQuoteClient.Apply(util.CallContext(ctx), quota.GUID, []string{space.GUID})
+	m.Recorder.Applied(change, quota.GUID, err)
 	return err
 }
 
-func (m *Manager) CreateSpaceQuota(quota *resource.SpaceQuotaCreateOrUpdate) (*resource.SpaceQuota, error) {
+func (m *Manager) CreateSpaceQuota(ctx context.Context, quota *resource.SpaceQuotaCreateOrUpdate) (*resource.SpaceQuota, error) {
 	if m.Peek {
 		lo.G.Infof("[dry-run]: creating quota %s", *quota.Name)
-		return &resource.SpaceQuota{Name: "dry-run-quota", GUID: "dry-run-guid"}, nil
+		return &resource.SpaceQuota{Name: *quota.Name, GUID: fmt.Sprintf("%s-dry-run-space-quota-guid", *quota.Name)}, nil
 	}
 	lo.G.Infof("Creating quota %s", *quota.Name)
-	spaceQuota, err := m.SpaceQuoteClient.Create(context.Background(), quota)
+	spaceQuota, err := m.SpaceQuoteClient.Create(util.CallContext(ctx), quota)
 	if err != nil {
 		return nil, err
 	}
@@ -304,8 +377,8 @@ func (m *Manager) CreateSpaceQuota(quota *resource.SpaceQuotaCreateOrUpdate) (*r
 }
 
 // CreateOrgQuotas -
-func (m *Manager) CreateOrgQuotas() error {
-	quotas, err := m.ListAllOrgQuotas()
+func (m *Manager) CreateOrgQuotas(ctx context.Context) error {
+	quotas, err := m.ListAllOrgQuotas(ctx)
 	if err != nil {
 		return err
 	}
@@ -315,7 +388,10 @@ func (m *Manager) CreateOrgQuotas() error {
 		return err
 	}
 	for _, orgQuotaConfig := range orgQuotas {
-		err = m.createOrgQuota(orgQuotaConfig, quotas)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		err = m.Failures.Add("", "", m.createOrgQuota(ctx, orgQuotaConfig, quotas))
 		if err != nil {
 			return err
 		}
@@ -326,34 +402,48 @@ func (m *Manager) CreateOrgQuotas() error {
 	}
 
 	for _, input := range orgs {
-		if input.NamedQuota != "" && input.EnableOrgQuota {
-			return fmt.Errorf("cannot have named quota %s and enable-org-quota for org %s", input.NamedQuota, input.Org)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if m.Failures.Skip(input.Org, "") {
+			lo.G.Infof("skipping org [%s] quota as it failed in an earlier step", input.Org)
+			continue
 		}
-		if input.EnableOrgQuota || input.NamedQuota != "" {
-			org, err := m.OrgReader.FindOrg(input.Org)
+		if err := m.Failures.Add(input.Org, "", m.updateOrgQuota(ctx, input, quotas)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Manager) updateOrgQuota(ctx context.Context, input config.OrgConfig, quotas map[string]*resource.OrganizationQuota) error {
+	if input.NamedQuota != "" && input.EnableOrgQuota {
+		return fmt.Errorf("cannot have named quota %s and enable-org-quota for org %s", input.NamedQuota, input.Org)
+	}
+	if input.EnableOrgQuota || input.NamedQuota != "" {
+		org, err := m.OrgReader.FindOrg(ctx, input.Org)
+		if err != nil {
+			return err
+		}
+		if input.EnableOrgQuota {
+			quotaDef := input.GetQuota()
+			err = m.createOrgQuota(ctx, quotaDef, quotas)
 			if err != nil {
 				return err
 			}
-			if input.EnableOrgQuota {
-				quotaDef := input.GetQuota()
-				err = m.createOrgQuota(quotaDef, quotas)
-				if err != nil {
-					return err
-				}
-				input.NamedQuota = input.Org
-			}
-			orgQuota := quotas[input.NamedQuota]
-			if orgQuota != nil && (org.Relationships.Quota.Data == nil || org.Relationships.Quota.Data.GUID != orgQuota.GUID) {
-				if err = m.AssignQuotaToOrg(org, orgQuota); err != nil {
-					return err
-				}
+			input.NamedQuota = input.Org
+		}
+		orgQuota := quotas[input.NamedQuota]
+		if orgQuota != nil && (org.Relationships.Quota.Data == nil || org.Relationships.Quota.Data.GUID != orgQuota.GUID) {
+			if err = m.AssignQuotaToOrg(ctx, org, orgQuota); err != nil {
+				return err
 			}
 		}
 	}
 	return nil
 }
 
-func (m *Manager) createOrgQuota(input config.OrgQuota, quotas map[string]*resource.OrganizationQuota) error {
+func (m *Manager) createOrgQuota(ctx context.Context, input config.OrgQuota, quotas map[string]*resource.OrganizationQuota) error {
 
 	quota := &resource.OrganizationQuotaCreateOrUpdate{
 		Name:     &input.Name,
@@ -426,12 +516,30 @@ func (m *Manager) createOrgQuota(input config.OrgQuota, quotas map[string]*resou
 
 	if orgQuota, ok := quotas[input.Name]; ok {
 		if m.hasOrgQuotaChanged(orgQuota, quota) {
-			if err = m.UpdateOrgQuota(orgQuota.GUID, quota); err != nil {
+			change := changes.Change{
+				Entity: changes.OrgQuota, Action: changes.Update, Name: input.Name,
+				Before: orgQuotaValues(orgQuota.Apps, orgQuota.Routes, orgQuota.Services, orgQuota.Domains),
+				After:  orgQuotaValues(*quota.Apps, *quota.Routes, *quota.Services, *quota.Domains),
+			}
+			m.Recorder.Record(change)
+			err = m.UpdateOrgQuota(ctx, orgQuota.GUID, quota)
+			m.applied(change, orgQuota.GUID, err)
+			if err != nil {
 				return err
 			}
 		}
 	} else {
-		createdQuota, err := m.CreateOrgQuota(quota)
+		change := changes.Change{
+			Entity: changes.OrgQuota, Action: changes.Create, Name: input.Name,
+			After: orgQuotaValues(*quota.Apps, *quota.Routes, *quota.Services, *quota.Domains),
+		}
+		m.Recorder.Record(change)
+		createdQuota, err := m.CreateOrgQuota(ctx, quota)
+		var guid string
+		if createdQuota != nil {
+			guid = createdQuota.GUID
+		}
+		m.applied(change, guid, err)
 		if err != nil {
 			return err
 		}
@@ -471,9 +579,9 @@ func (m *Manager) hasOrgQuotaChanged(quota *resource.OrganizationQuota, newQuota
 	return false
 }
 
-func (m *Manager) ListAllOrgQuotas() (map[string]*resource.OrganizationQuota, error) {
+func (m *Manager) ListAllOrgQuotas(ctx context.Context) (map[string]*resource.OrganizationQuota, error) {
 	quotas := make(map[string]*resource.OrganizationQuota)
-	orgQutotas, err := m.OrgQuoteClient.ListAll(context.Background(), &client.OrganizationQuotaListOptions{
+	orgQutotas, err := m.OrgQuoteClient.ListAll(util.CallContext(ctx), &client.OrganizationQuotaListOptions{
 		ListOptions: &client.ListOptions{
 			PerPage: 5000,
 		},
@@ -488,44 +596,55 @@ func (m *Manager) ListAllOrgQuotas() (map[string]*resource.OrganizationQuota, er
 	return quotas, nil
 }
 
-func (m *Manager) CreateOrgQuota(quota *resource.OrganizationQuotaCreateOrUpdate) (*resource.OrganizationQuota, error) {
+func (m *Manager) CreateOrgQuota(ctx context.Context, quota *resource.OrganizationQuotaCreateOrUpdate) (*resource.OrganizationQuota, error) {
 	if m.Peek {
 		lo.G.Infof("[dry-run]: create org quota %s", *quota.Name)
-		return &resource.OrganizationQuota{Name: "dry-run-quota", GUID: "dry-run-quota-guid"}, nil
+		return &resource.OrganizationQuota{Name: *quota.Name, GUID: fmt.Sprintf("%s-dry-run-quota-guid", *quota.Name)}, nil
 	}
 
 	lo.G.Infof("Creating org quota %s", *quota.Name)
-	orgQuota, err := m.OrgQuoteClient.Create(context.Background(), quota)
+	orgQuota, err := m.OrgQuoteClient.Create(util.CallContext(ctx), quota)
 	if err != nil {
 		return nil, err
 	}
 	return orgQuota, nil
 }
 
-func (m *Manager) UpdateOrgQuota(quotaGUID string, quota *resource.OrganizationQuotaCreateOrUpdate) error {
+func (m *Manager) UpdateOrgQuota(ctx context.Context, quotaGUID string, quota *resource.OrganizationQuotaCreateOrUpdate) error {
 	if m.Peek {
 		lo.G.Infof("[dry-run]: update org quota %s", *quota.Name)
 		return nil
 	}
 	lo.G.Infof("Updating org quota %s", *quota.Name)
-	_, err := m.OrgQuoteClient.Update(context.Background(), quotaGUID, quota)
+	_, err := m.OrgQuoteClient.Update(util.CallContext(ctx), quotaGUID, quota)
 	return err
 }
 
-func (m *Manager) AssignQuotaToOrg(org *resource.Organization, quota *resource.OrganizationQuota) error {
+func (m *Manager) AssignQuotaToOrg(ctx context.Context, org *resource.Organization, quota *resource.OrganizationQuota) error {
+	change := changes.Change{Entity: changes.OrgQuota, Action: changes.Assign, Name: quota.Name, Org: org.Name, After: quota.Name}
+	m.Recorder.Record(change)
 	if m.Peek {
 		lo.G.Infof("[dry-run]: assign quota %s to org %s", quota.Name, org.Name)
 		return nil
 	}
 	lo.G.Infof("Assigning quota %s to org %s", quota.Name, org.Name)
-	_, err := m.OrgQuoteClient.Apply(context.Background(), quota.GUID, []string{org.GUID})
+	_, err := m.OrgQuoteClient.Apply(util.CallContext(ctx), quota.GUID, []string{org.GUID})
+	m.Recorder.Applied(change, quota.GUID, err)
 	return err
 }
 
-func (m *Manager) GetSpaceQuota(guid string) (*resource.SpaceQuota, error) {
-	return m.SpaceQuoteClient.Get(context.Background(), guid)
+// applied - reports a change made by one of the exported create and update
+// methods, which don't change anything when peeking
+func (m *Manager) applied(change changes.Change, guid string, err error) {
+	if !m.Peek {
+		m.Recorder.Applied(change, guid, err)
+	}
+}
+
+func (m *Manager) GetSpaceQuota(ctx context.Context, guid string) (*resource.SpaceQuota, error) {
+	return m.SpaceQuoteClient.Get(util.CallContext(ctx), guid)
 }
 
-func (m *Manager) GetOrgQuota(guid string) (*resource.OrganizationQuota, error) {
-	return m.OrgQuoteClient.Get(context.Background(), guid)
+func (m *Manager) GetOrgQuota(ctx context.Context, guid string) (*resource.OrganizationQuota, error) {
+	return m.OrgQuoteClient.Get(util.CallContext(ctx), guid)
 }
@@ -1,11 +1,14 @@
 package quota_test
 
 import (
+	"context"
+
 	"errors"
 
 	"github.com/cloudfoundry-community/go-cfclient/v3/resource"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
 	"github.com/vmwarepivotallabs/cf-mgmt/config"
 	configfakes "github.com/vmwarepivotallabs/cf-mgmt/config/fakes"
 	orgreaderfakes "github.com/vmwarepivotallabs/cf-mgmt/organizationreader/fakes"
@@ -78,14 +81,14 @@ var _ = Describe("given QuotaManager", func() {
 					},
 				},
 			}, nil)
-			quotas, err := quotaMgr.ListAllSpaceQuotasForOrg("orgGUID")
+			quotas, err := quotaMgr.ListAllSpaceQuotasForOrg(context.Background(), "orgGUID")
 			Expect(err).Should(BeNil())
 			Expect(fakeSpaceQuotaClient.ListAllCallCount()).Should(Equal(1))
 			Expect(len(quotas)).Should(Equal(2))
 			Expect(quotas).Should(HaveKey("quota-1"))
 			Expect(quotas).Should(HaveKey("quota-2"))
 
-			quotas, err = quotaMgr.ListAllSpaceQuotasForOrg("orgGUID-other")
+			quotas, err = quotaMgr.ListAllSpaceQuotasForOrg(context.Background(), "orgGUID-other")
 			Expect(err).Should(BeNil())
 			Expect(fakeSpaceQuotaClient.ListAllCallCount()).Should(Equal(1))
 			Expect(len(quotas)).Should(Equal(1))
@@ -93,7 +96,7 @@ var _ = Describe("given QuotaManager", func() {
 		})
 		It("should return an error", func() {
 			fakeSpaceQuotaClient.ListAllReturns(nil, errors.New("error"))
-			_, err := quotaMgr.ListAllSpaceQuotasForOrg("orgGUID")
+			_, err := quotaMgr.ListAllSpaceQuotasForOrg(context.Background(), "orgGUID")
 			Expect(err).ShouldNot(BeNil())
 			Expect(fakeSpaceQuotaClient.ListAllCallCount()).Should(Equal(1))
 		})
@@ -160,7 +163,7 @@ var _ = Describe("given QuotaManager", func() {
 					},
 				},
 			}, nil)
-			err := quotaMgr.CreateSpaceQuotas()
+			err := quotaMgr.CreateSpaceQuotas(context.Background())
 			Expect(err).Should(BeNil())
 			Expect(fakeSpaceQuotaClient.CreateCallCount()).Should(Equal(1))
 			_, quotaRequest := fakeSpaceQuotaClient.CreateArgsForCall(0)
@@ -232,7 +235,7 @@ var _ = Describe("given QuotaManager", func() {
 					},
 				},
 			}, nil)
-			err := quotaMgr.CreateSpaceQuotas()
+			err := quotaMgr.CreateSpaceQuotas(context.Background())
 			Expect(err).Should(BeNil())
 			Expect(fakeSpaceQuotaClient.CreateCallCount()).Should(Equal(1))
 			_, quotaRequest := fakeSpaceQuotaClient.CreateArgsForCall(0)
@@ -247,7 +250,7 @@ var _ = Describe("given QuotaManager", func() {
 
 		It("should error creating a quota", func() {
 			fakeSpaceQuotaClient.CreateReturns(nil, errors.New("error"))
-			err := quotaMgr.CreateSpaceQuotas()
+			err := quotaMgr.CreateSpaceQuotas(context.Background())
 			Expect(err).ShouldNot(BeNil())
 			Expect(fakeSpaceQuotaClient.CreateCallCount()).Should(Equal(1))
 			_, quotaRequest := fakeSpaceQuotaClient.CreateArgsForCall(0)
@@ -281,7 +284,7 @@ var _ = Describe("given QuotaManager", func() {
 				},
 			}, nil)
 			fakeSpaceQuotaClient.UpdateReturns(&resource.SpaceQuota{Name: "space1", GUID: "space-quota-guid"}, nil)
-			err := quotaMgr.CreateSpaceQuotas()
+			err := quotaMgr.CreateSpaceQuotas(context.Background())
 
 			Expect(err).Should(BeNil())
 			Expect(fakeSpaceQuotaClient.UpdateCallCount()).Should(Equal(1))
@@ -326,7 +329,7 @@ var _ = Describe("given QuotaManager", func() {
 				},
 			}, nil)
 			fakeSpaceQuotaClient.UpdateReturns(nil, nil)
-			err := quotaMgr.CreateSpaceQuotas()
+			err := quotaMgr.CreateSpaceQuotas(context.Background())
 			Expect(err).Should(BeNil())
 			Expect(fakeSpaceQuotaClient.CreateCallCount()).Should(Equal(0))
 			Expect(fakeSpaceQuotaClient.UpdateCallCount()).Should(Equal(1))
@@ -380,7 +383,7 @@ var _ = Describe("given QuotaManager", func() {
 					},
 				},
 			}, nil)
-			err := quotaMgr.CreateSpaceQuotas()
+			err := quotaMgr.CreateSpaceQuotas(context.Background())
 			Expect(err).Should(BeNil())
 			Expect(fakeSpaceQuotaClient.CreateCallCount()).Should(Equal(0))
 			Expect(fakeSpaceQuotaClient.UpdateCallCount()).Should(Equal(0))
@@ -402,7 +405,7 @@ var _ = Describe("given QuotaManager", func() {
 				},
 			}, nil)
 			fakeSpaceQuotaClient.UpdateReturns(nil, errors.New("error"))
-			err := quotaMgr.CreateSpaceQuotas()
+			err := quotaMgr.CreateSpaceQuotas(context.Background())
 			Expect(fakeSpaceQuotaClient.CreateCallCount()).Should(Equal(0))
 			Expect(fakeSpaceQuotaClient.UpdateCallCount()).Should(Equal(1))
 			Expect(err).ShouldNot(BeNil())
@@ -427,7 +430,7 @@ var _ = Describe("given QuotaManager", func() {
 					},
 				},
 			}, nil)
-			err := quotaMgr.CreateSpaceQuotas()
+			err := quotaMgr.CreateSpaceQuotas(context.Background())
 			Expect(err).ShouldNot(BeNil())
 			Expect(fakeSpaceQuotaClient.CreateCallCount()).Should(Equal(1))
 			_, quotaRequest := fakeSpaceQuotaClient.CreateArgsForCall(0)
@@ -452,25 +455,41 @@ var _ = Describe("given QuotaManager", func() {
 					},
 				},
 			}, nil)
-			err := quotaMgr.CreateSpaceQuotas()
+			err := quotaMgr.CreateSpaceQuotas(context.Background())
 			Expect(err).Should(BeNil())
 			Expect(fakeSpaceQuotaClient.CreateCallCount()).Should(Equal(0))
 			Expect(fakeSpaceQuotaClient.ApplyCallCount()).Should(Equal(0))
 		})
 
+		It("should peek assign a quota created earlier in the run", func() {
+			quotaMgr.Peek = true
+			quotaMgr.Recorder = changes.NewRecorder()
+			err := quotaMgr.CreateSpaceQuotas(context.Background())
+			Expect(err).Should(BeNil())
+			Expect(fakeSpaceQuotaClient.ApplyCallCount()).Should(Equal(0))
+			Expect(quotaMgr.Recorder.Changes()).Should(ContainElement(changes.Change{
+				Entity: changes.SpaceQuota,
+				Action: changes.Assign,
+				Name:   "space1",
+				Org:    "org1",
+				Space:  "space1",
+				After:  "space1",
+			}))
+		})
+
 		It("Should error getting configs", func() {
 			fakeReader.GetSpaceConfigsReturns(nil, errors.New("error"))
-			err := quotaMgr.CreateSpaceQuotas()
+			err := quotaMgr.CreateSpaceQuotas(context.Background())
 			Expect(err).ShouldNot(BeNil())
 		})
 		It("Should error finding space", func() {
 			fakeSpaceMgr.FindSpaceReturns(nil, errors.New("error"))
-			err := quotaMgr.CreateSpaceQuotas()
+			err := quotaMgr.CreateSpaceQuotas(context.Background())
 			Expect(err).ShouldNot(BeNil())
 		})
 		It("Should error listing space quotas", func() {
 			fakeSpaceQuotaClient.ListAllReturns(nil, errors.New("error"))
-			err := quotaMgr.CreateSpaceQuotas()
+			err := quotaMgr.CreateSpaceQuotas(context.Background())
 			Expect(err).ShouldNot(BeNil())
 		})
 
@@ -522,7 +541,7 @@ var _ = Describe("given QuotaManager", func() {
 					},
 				},
 			}, nil)
-			err := quotaMgr.CreateSpaceQuotas()
+			err := quotaMgr.CreateSpaceQuotas(context.Background())
 			Expect(err).Should(BeNil())
 			Expect(fakeSpaceQuotaClient.CreateCallCount()).Should(Equal(1))
 			_, quotaRequest := fakeSpaceQuotaClient.CreateArgsForCall(0)
@@ -574,7 +593,7 @@ var _ = Describe("given QuotaManager", func() {
 		})
 		It("should create a quota and assign it", func() {
 			fakeOrgQuotaClient.CreateReturns(&resource.OrganizationQuota{Name: "org1", GUID: "org-quota-guid"}, nil)
-			err := quotaMgr.CreateOrgQuotas()
+			err := quotaMgr.CreateOrgQuotas(context.Background())
 			Expect(err).Should(BeNil())
 			Expect(fakeOrgQuotaClient.CreateCallCount()).Should(Equal(1))
 			_, quotaRequest := fakeOrgQuotaClient.CreateArgsForCall(0)
@@ -587,7 +606,7 @@ var _ = Describe("given QuotaManager", func() {
 
 		It("should error creating a quota", func() {
 			fakeOrgQuotaClient.CreateReturns(nil, errors.New("error"))
-			err := quotaMgr.CreateOrgQuotas()
+			err := quotaMgr.CreateOrgQuotas(context.Background())
 			Expect(err).ShouldNot(BeNil())
 			Expect(fakeOrgQuotaClient.CreateCallCount()).Should(Equal(1))
 			_, quotaRequest := fakeOrgQuotaClient.CreateArgsForCall(0)
@@ -605,7 +624,7 @@ var _ = Describe("given QuotaManager", func() {
 				},
 			}, nil)
 			fakeOrgQuotaClient.UpdateReturns(nil, nil)
-			err := quotaMgr.CreateOrgQuotas()
+			err := quotaMgr.CreateOrgQuotas(context.Background())
 			Expect(err).Should(BeNil())
 			Expect(fakeOrgQuotaClient.UpdateCallCount()).Should(Equal(1))
 			_, quotaGUID, quotaRequest := fakeOrgQuotaClient.UpdateArgsForCall(0)
@@ -638,7 +657,7 @@ var _ = Describe("given QuotaManager", func() {
 				},
 			}, nil)
 			fakeOrgQuotaClient.UpdateReturns(nil, nil)
-			err := quotaMgr.CreateOrgQuotas()
+			err := quotaMgr.CreateOrgQuotas(context.Background())
 			Expect(err).Should(BeNil())
 			Expect(fakeOrgQuotaClient.UpdateCallCount()).Should(Equal(1))
 			_, quotaGUID, quotaRequest := fakeOrgQuotaClient.UpdateArgsForCall(0)
@@ -692,7 +711,7 @@ var _ = Describe("given QuotaManager", func() {
 				},
 			}, nil)
 			fakeOrgQuotaClient.UpdateReturns(nil, nil)
-			err := quotaMgr.CreateOrgQuotas()
+			err := quotaMgr.CreateOrgQuotas(context.Background())
 			Expect(err).Should(BeNil())
 			Expect(fakeOrgQuotaClient.UpdateCallCount()).Should(Equal(0))
 			Expect(fakeOrgQuotaClient.ApplyCallCount()).Should(Equal(0))
@@ -719,7 +738,7 @@ var _ = Describe("given QuotaManager", func() {
 				},
 			}, nil)
 			fakeOrgQuotaClient.UpdateReturns(nil, errors.New("error"))
-			err := quotaMgr.CreateOrgQuotas()
+			err := quotaMgr.CreateOrgQuotas(context.Background())
 			Expect(err).ShouldNot(BeNil())
 			Expect(fakeOrgQuotaClient.UpdateCallCount()).Should(Equal(1))
 			Expect(fakeOrgQuotaClient.ApplyCallCount()).Should(Equal(0))
@@ -747,7 +766,7 @@ var _ = Describe("given QuotaManager", func() {
 			}, nil)
 			fakeOrgQuotaClient.UpdateReturns(nil, nil)
 			fakeOrgQuotaClient.ApplyReturns(nil, errors.New("error"))
-			err := quotaMgr.CreateOrgQuotas()
+			err := quotaMgr.CreateOrgQuotas(context.Background())
 			Expect(err).ShouldNot(BeNil())
 			Expect(fakeOrgQuotaClient.UpdateCallCount()).Should(Equal(1))
 			_, quotaGUID, quotaRequest := fakeOrgQuotaClient.UpdateArgsForCall(0)
@@ -757,7 +776,7 @@ var _ = Describe("given QuotaManager", func() {
 		})
 		It("should peek create a quota and peek assign it", func() {
 			quotaMgr.Peek = true
-			err := quotaMgr.CreateOrgQuotas()
+			err := quotaMgr.CreateOrgQuotas(context.Background())
 			Expect(err).Should(BeNil())
 			Expect(fakeOrgQuotaClient.CreateCallCount()).Should(Equal(0))
 			Expect(fakeOrgQuotaClient.ApplyCallCount()).Should(Equal(0))
@@ -765,17 +784,17 @@ var _ = Describe("given QuotaManager", func() {
 
 		It("Should error getting configs", func() {
 			fakeReader.GetOrgConfigsReturns(nil, errors.New("error"))
-			err := quotaMgr.CreateOrgQuotas()
+			err := quotaMgr.CreateOrgQuotas(context.Background())
 			Expect(err).ShouldNot(BeNil())
 		})
 		It("Should error finding org", func() {
 			fakeOrgReader.FindOrgReturns(&resource.Organization{}, errors.New("error"))
-			err := quotaMgr.CreateOrgQuotas()
+			err := quotaMgr.CreateOrgQuotas(context.Background())
 			Expect(err).ShouldNot(BeNil())
 		})
 		It("Should error listing org quotas", func() {
 			fakeOrgQuotaClient.ListAllReturns(nil, errors.New("error"))
-			err := quotaMgr.CreateOrgQuotas()
+			err := quotaMgr.CreateOrgQuotas(context.Background())
 			Expect(err).ShouldNot(BeNil())
 		})
 	})
@@ -784,7 +803,7 @@ var _ = Describe("given QuotaManager", func() {
 		It("should update a quota", func() {
 			fakeSpaceQuotaClient.UpdateReturns(nil, nil)
 
-			err := quotaMgr.UpdateSpaceQuota("quotaGUID", &resource.SpaceQuotaCreateOrUpdate{Name: util.GetStringPointer("quota")})
+			err := quotaMgr.UpdateSpaceQuota(context.Background(), "quotaGUID", &resource.SpaceQuotaCreateOrUpdate{Name: util.GetStringPointer("quota")})
 			Expect(err).Should(BeNil())
 			Expect(fakeSpaceQuotaClient.UpdateCallCount()).Should(Equal(1))
 		})
@@ -792,14 +811,14 @@ var _ = Describe("given QuotaManager", func() {
 			quotaMgr.Peek = true
 			fakeSpaceQuotaClient.UpdateReturns(nil, nil)
 
-			err := quotaMgr.UpdateSpaceQuota("quotaGUID", &resource.SpaceQuotaCreateOrUpdate{Name: util.GetStringPointer("quota")})
+			err := quotaMgr.UpdateSpaceQuota(context.Background(), "quotaGUID", &resource.SpaceQuotaCreateOrUpdate{Name: util.GetStringPointer("quota")})
 			Expect(err).Should(BeNil())
 			Expect(fakeSpaceQuotaClient.UpdateCallCount()).Should(Equal(0))
 		})
 		It("should return an error", func() {
 			fakeSpaceQuotaClient.UpdateReturns(nil, errors.New("error"))
 
-			err := quotaMgr.UpdateSpaceQuota("quotaGUID", &resource.SpaceQuotaCreateOrUpdate{Name: util.GetStringPointer("quota")})
+			err := quotaMgr.UpdateSpaceQuota(context.Background(), "quotaGUID", &resource.SpaceQuotaCreateOrUpdate{Name: util.GetStringPointer("quota")})
 			Expect(err).ShouldNot(BeNil())
 		})
 	})
@@ -809,7 +828,7 @@ var _ = Describe("given QuotaManager", func() {
 			fakeSpaceQuotaClient.CreateReturns(nil, nil)
 			fakeOrgReader.FindOrgReturns(&resource.Organization{Name: "org1", GUID: "org-guid"}, nil)
 
-			_, err := quotaMgr.CreateSpaceQuota(&resource.SpaceQuotaCreateOrUpdate{Name: util.GetStringPointer("quota")})
+			_, err := quotaMgr.CreateSpaceQuota(context.Background(), &resource.SpaceQuotaCreateOrUpdate{Name: util.GetStringPointer("quota")})
 			Expect(err).Should(BeNil())
 			Expect(fakeSpaceQuotaClient.CreateCallCount()).Should(Equal(1))
 		})
@@ -817,14 +836,14 @@ var _ = Describe("given QuotaManager", func() {
 			quotaMgr.Peek = true
 			fakeSpaceQuotaClient.CreateReturns(nil, nil)
 
-			_, err := quotaMgr.CreateSpaceQuota(&resource.SpaceQuotaCreateOrUpdate{Name: util.GetStringPointer("quota")})
+			_, err := quotaMgr.CreateSpaceQuota(context.Background(), &resource.SpaceQuotaCreateOrUpdate{Name: util.GetStringPointer("quota")})
 			Expect(err).Should(BeNil())
 			Expect(fakeSpaceQuotaClient.CreateCallCount()).Should(Equal(0))
 		})
 		It("should return an error", func() {
 			fakeSpaceQuotaClient.CreateReturns(nil, errors.New("error"))
 
-			_, err := quotaMgr.CreateSpaceQuota(&resource.SpaceQuotaCreateOrUpdate{Name: util.GetStringPointer("quota")})
+			_, err := quotaMgr.CreateSpaceQuota(context.Background(), &resource.SpaceQuotaCreateOrUpdate{Name: util.GetStringPointer("quota")})
 			Expect(err).ShouldNot(BeNil())
 		})
 	})
@@ -845,7 +864,7 @@ var _ = Describe("given QuotaManager", func() {
 			fakeOrgQuotaClient.CreateReturns(&resource.OrganizationQuota{GUID: "my-named-quota-guid", Name: "my-named-quota"}, nil)
 			fakeOrgReader.FindOrgReturns(&resource.Organization{Name: "test"}, nil)
 
-			err := quotaMgr.CreateOrgQuotas()
+			err := quotaMgr.CreateOrgQuotas(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeOrgQuotaClient.CreateCallCount()).Should(Equal(1))
 			Expect(fakeOrgQuotaClient.ApplyCallCount()).Should(Equal(1))
@@ -892,7 +911,7 @@ var _ = Describe("given QuotaManager", func() {
 				},
 			}, nil)
 
-			err := quotaMgr.CreateSpaceQuotas()
+			err := quotaMgr.CreateSpaceQuotas(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeSpaceQuotaClient.CreateCallCount()).Should(Equal(1))
 			Expect(fakeSpaceQuotaClient.ApplyCallCount()).Should(Equal(1))
@@ -932,7 +951,7 @@ var _ = Describe("given QuotaManager", func() {
 					},
 				},
 			}, nil)
-			err := quotaMgr.CreateSpaceQuotas()
+			err := quotaMgr.CreateSpaceQuotas(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			_, createQuotaRequest := fakeSpaceQuotaClient.CreateArgsForCall(0)
 			Expect(*createQuotaRequest.Name).Should(Equal("test-space"))
@@ -957,7 +976,7 @@ var _ = Describe("given QuotaManager", func() {
 			fakeSpaceQuotaClient.CreateReturns(&resource.SpaceQuota{GUID: "my-named-quota-guid", Name: "my-named-quota"}, nil)
 			fakeSpaceMgr.FindSpaceReturns(&resource.Space{Name: "test-space"}, nil)
 
-			err := quotaMgr.CreateSpaceQuotas()
+			err := quotaMgr.CreateSpaceQuotas(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeReader.GetSpaceQuotasCallCount()).Should(Equal(0))
 			Expect(fakeSpaceQuotaClient.CreateCallCount()).Should(Equal(0))
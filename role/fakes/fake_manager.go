@@ -2,20 +2,22 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/vmwarepivotallabs/cf-mgmt/role"
 )
 
 type FakeManager struct {
-	AssociateOrgAuditorStub        func(string, string, string, string, string) error
+	AssociateOrgAuditorStub        func(context.Context, string, string, string, string, string) error
 	associateOrgAuditorMutex       sync.RWMutex
 	associateOrgAuditorArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
 		arg5 string
+		arg6 string
 	}
 	associateOrgAuditorReturns struct {
 		result1 error
@@ -23,14 +25,15 @@ type FakeManager struct {
 	associateOrgAuditorReturnsOnCall map[int]struct {
 		result1 error
 	}
-	AssociateOrgBillingManagerStub        func(string, string, string, string, string) error
+	AssociateOrgBillingManagerStub        func(context.Context, string, string, string, string, string) error
 	associateOrgBillingManagerMutex       sync.RWMutex
 	associateOrgBillingManagerArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
 		arg5 string
+		arg6 string
 	}
 	associateOrgBillingManagerReturns struct {
 		result1 error
@@ -38,14 +41,15 @@ type FakeManager struct {
 	associateOrgBillingManagerReturnsOnCall map[int]struct {
 		result1 error
 	}
-	AssociateOrgManagerStub        func(string, string, string, string, string) error
+	AssociateOrgManagerStub        func(context.Context, string, string, string, string, string) error
 	associateOrgManagerMutex       sync.RWMutex
 	associateOrgManagerArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
 		arg5 string
+		arg6 string
 	}
 	associateOrgManagerReturns struct {
 		result1 error
@@ -53,14 +57,15 @@ type FakeManager struct {
 	associateOrgManagerReturnsOnCall map[int]struct {
 		result1 error
 	}
-	AssociateSpaceAuditorStub        func(string, string, string, string, string) error
+	AssociateSpaceAuditorStub        func(context.Context, string, string, string, string, string) error
 	associateSpaceAuditorMutex       sync.RWMutex
 	associateSpaceAuditorArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
 		arg5 string
+		arg6 string
 	}
 	associateSpaceAuditorReturns struct {
 		result1 error
@@ -68,14 +73,15 @@ type FakeManager struct {
 	associateSpaceAuditorReturnsOnCall map[int]struct {
 		result1 error
 	}
-	AssociateSpaceDeveloperStub        func(string, string, string, string, string) error
+	AssociateSpaceDeveloperStub        func(context.Context, string, string, string, string, string) error
 	associateSpaceDeveloperMutex       sync.RWMutex
 	associateSpaceDeveloperArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
 		arg5 string
+		arg6 string
 	}
 	associateSpaceDeveloperReturns struct {
 		result1 error
@@ -83,14 +89,15 @@ type FakeManager struct {
 	associateSpaceDeveloperReturnsOnCall map[int]struct {
 		result1 error
 	}
-	AssociateSpaceManagerStub        func(string, string, string, string, string) error
+	AssociateSpaceManagerStub        func(context.Context, string, string, string, string, string) error
 	associateSpaceManagerMutex       sync.RWMutex
 	associateSpaceManagerArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
 		arg5 string
+		arg6 string
 	}
 	associateSpaceManagerReturns struct {
 		result1 error
@@ -98,14 +105,15 @@ type FakeManager struct {
 	associateSpaceManagerReturnsOnCall map[int]struct {
 		result1 error
 	}
-	AssociateSpaceSupporterStub        func(string, string, string, string, string) error
+	AssociateSpaceSupporterStub        func(context.Context, string, string, string, string, string) error
 	associateSpaceSupporterMutex       sync.RWMutex
 	associateSpaceSupporterArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
 		arg5 string
+		arg6 string
 	}
 	associateSpaceSupporterReturns struct {
 		result1 error
@@ -117,10 +125,11 @@ type FakeManager struct {
 	clearRolesMutex       sync.RWMutex
 	clearRolesArgsForCall []struct {
 	}
-	DeleteUserStub        func(string) error
+	DeleteUserStub        func(context.Context, string) error
 	deleteUserMutex       sync.RWMutex
 	deleteUserArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	deleteUserReturns struct {
 		result1 error
@@ -128,9 +137,10 @@ type FakeManager struct {
 	deleteUserReturnsOnCall map[int]struct {
 		result1 error
 	}
-	InitializeOrgUserRolesMapStub        func() error
+	InitializeOrgUserRolesMapStub        func(context.Context) error
 	initializeOrgUserRolesMapMutex       sync.RWMutex
 	initializeOrgUserRolesMapArgsForCall []struct {
+		arg1 context.Context
 	}
 	initializeOrgUserRolesMapReturns struct {
 		result1 error
@@ -138,9 +148,10 @@ type FakeManager struct {
 	initializeOrgUserRolesMapReturnsOnCall map[int]struct {
 		result1 error
 	}
-	InitializeSpaceUserRolesMapStub        func() error
+	InitializeSpaceUserRolesMapStub        func(context.Context) error
 	initializeSpaceUserRolesMapMutex       sync.RWMutex
 	initializeSpaceUserRolesMapArgsForCall []struct {
+		arg1 context.Context
 	}
 	initializeSpaceUserRolesMapReturns struct {
 		result1 error
@@ -148,10 +159,11 @@ type FakeManager struct {
 	initializeSpaceUserRolesMapReturnsOnCall map[int]struct {
 		result1 error
 	}
-	ListOrgUsersByRoleStub        func(string) (*role.RoleUsers, *role.RoleUsers, *role.RoleUsers, *role.RoleUsers, error)
+	ListOrgUsersByRoleStub        func(context.Context, string) (*role.RoleUsers, *role.RoleUsers, *role.RoleUsers, *role.RoleUsers, error)
 	listOrgUsersByRoleMutex       sync.RWMutex
 	listOrgUsersByRoleArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	listOrgUsersByRoleReturns struct {
 		result1 *role.RoleUsers
@@ -167,10 +179,11 @@ type FakeManager struct {
 		result4 *role.RoleUsers
 		result5 error
 	}
-	ListSpaceUsersByRoleStub        func(string) (*role.RoleUsers, *role.RoleUsers, *role.RoleUsers, *role.RoleUsers, error)
+	ListSpaceUsersByRoleStub        func(context.Context, string) (*role.RoleUsers, *role.RoleUsers, *role.RoleUsers, *role.RoleUsers, error)
 	listSpaceUsersByRoleMutex       sync.RWMutex
 	listSpaceUsersByRoleArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	listSpaceUsersByRoleReturns struct {
 		result1 *role.RoleUsers
@@ -186,13 +199,14 @@ type FakeManager struct {
 		result4 *role.RoleUsers
 		result5 error
 	}
-	RemoveOrgAuditorStub        func(string, string, string, string) error
+	RemoveOrgAuditorStub        func(context.Context, string, string, string, string) error
 	removeOrgAuditorMutex       sync.RWMutex
 	removeOrgAuditorArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
+		arg5 string
 	}
 	removeOrgAuditorReturns struct {
 		result1 error
@@ -200,13 +214,14 @@ type FakeManager struct {
 	removeOrgAuditorReturnsOnCall map[int]struct {
 		result1 error
 	}
-	RemoveOrgBillingManagerStub        func(string, string, string, string) error
+	RemoveOrgBillingManagerStub        func(context.Context, string, string, string, string) error
 	removeOrgBillingManagerMutex       sync.RWMutex
 	removeOrgBillingManagerArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
+		arg5 string
 	}
 	removeOrgBillingManagerReturns struct {
 		result1 error
@@ -214,13 +229,14 @@ type FakeManager struct {
 	removeOrgBillingManagerReturnsOnCall map[int]struct {
 		result1 error
 	}
-	RemoveOrgManagerStub        func(string, string, string, string) error
+	RemoveOrgManagerStub        func(context.Context, string, string, string, string) error
 	removeOrgManagerMutex       sync.RWMutex
 	removeOrgManagerArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
+		arg5 string
 	}
 	removeOrgManagerReturns struct {
 		result1 error
@@ -228,13 +244,14 @@ type FakeManager struct {
 	removeOrgManagerReturnsOnCall map[int]struct {
 		result1 error
 	}
-	RemoveOrgUserStub        func(string, string, string, string) error
+	RemoveOrgUserStub        func(context.Context, string, string, string, string) error
 	removeOrgUserMutex       sync.RWMutex
 	removeOrgUserArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
+		arg5 string
 	}
 	removeOrgUserReturns struct {
 		result1 error
@@ -242,13 +259,14 @@ type FakeManager struct {
 	removeOrgUserReturnsOnCall map[int]struct {
 		result1 error
 	}
-	RemoveSpaceAuditorStub        func(string, string, string, string) error
+	RemoveSpaceAuditorStub        func(context.Context, string, string, string, string) error
 	removeSpaceAuditorMutex       sync.RWMutex
 	removeSpaceAuditorArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
+		arg5 string
 	}
 	removeSpaceAuditorReturns struct {
 		result1 error
@@ -256,13 +274,14 @@ type FakeManager struct {
 	removeSpaceAuditorReturnsOnCall map[int]struct {
 		result1 error
 	}
-	RemoveSpaceDeveloperStub        func(string, string, string, string) error
+	RemoveSpaceDeveloperStub        func(context.Context, string, string, string, string) error
 	removeSpaceDeveloperMutex       sync.RWMutex
 	removeSpaceDeveloperArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
+		arg5 string
 	}
 	removeSpaceDeveloperReturns struct {
 		result1 error
@@ -270,13 +289,14 @@ type FakeManager struct {
 	removeSpaceDeveloperReturnsOnCall map[int]struct {
 		result1 error
 	}
-	RemoveSpaceManagerStub        func(string, string, string, string) error
+	RemoveSpaceManagerStub        func(context.Context, string, string, string, string) error
 	removeSpaceManagerMutex       sync.RWMutex
 	removeSpaceManagerArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
+		arg5 string
 	}
 	removeSpaceManagerReturns struct {
 		result1 error
@@ -284,13 +304,14 @@ type FakeManager struct {
 	removeSpaceManagerReturnsOnCall map[int]struct {
 		result1 error
 	}
-	RemoveSpaceSupporterStub        func(string, string, string, string) error
+	RemoveSpaceSupporterStub        func(context.Context, string, string, string, string) error
 	removeSpaceSupporterMutex       sync.RWMutex
 	removeSpaceSupporterArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
+		arg5 string
 	}
 	removeSpaceSupporterReturns struct {
 		result1 error
@@ -302,22 +323,23 @@ type FakeManager struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeManager) AssociateOrgAuditor(arg1 string, arg2 string, arg3 string, arg4 string, arg5 string) error {
+func (fake *FakeManager) AssociateOrgAuditor(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string, arg6 string) error {
 	fake.associateOrgAuditorMutex.Lock()
 	ret, specificReturn := fake.associateOrgAuditorReturnsOnCall[len(fake.associateOrgAuditorArgsForCall)]
 	fake.associateOrgAuditorArgsForCall = append(fake.associateOrgAuditorArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
 		arg5 string
-	}{arg1, arg2, arg3, arg4, arg5})
+		arg6 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
 	stub := fake.AssociateOrgAuditorStub
 	fakeReturns := fake.associateOrgAuditorReturns
-	fake.recordInvocation("AssociateOrgAuditor", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("AssociateOrgAuditor", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
 	fake.associateOrgAuditorMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4, arg5)
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
 		return ret.result1
@@ -331,17 +353,17 @@ func (fake *FakeManager) AssociateOrgAuditorCallCount() int {
 	return len(fake.associateOrgAuditorArgsForCall)
 }
 
-func (fake *FakeManager) AssociateOrgAuditorCalls(stub func(string, string, string, string, string) error) {
+func (fake *FakeManager) AssociateOrgAuditorCalls(stub func(context.Context, string, string, string, string, string) error) {
 	fake.associateOrgAuditorMutex.Lock()
 	defer fake.associateOrgAuditorMutex.Unlock()
 	fake.AssociateOrgAuditorStub = stub
 }
 
-func (fake *FakeManager) AssociateOrgAuditorArgsForCall(i int) (string, string, string, string, string) {
+func (fake *FakeManager) AssociateOrgAuditorArgsForCall(i int) (context.Context, string, string, string, string, string) {
 	fake.associateOrgAuditorMutex.RLock()
 	defer fake.associateOrgAuditorMutex.RUnlock()
 	argsForCall := fake.associateOrgAuditorArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
 }
 
 func (fake *FakeManager) AssociateOrgAuditorReturns(result1 error) {
@@ -367,22 +389,23 @@ func (fake *FakeManager) AssociateOrgAuditorReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
-func (fake *FakeManager) AssociateOrgBillingManager(arg1 string, arg2 string, arg3 string, arg4 string, arg5 string) error {
+func (fake *FakeManager) AssociateOrgBillingManager(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string, arg6 string) error {
 	fake.associateOrgBillingManagerMutex.Lock()
 	ret, specificReturn := fake.associateOrgBillingManagerReturnsOnCall[len(fake.associateOrgBillingManagerArgsForCall)]
 	fake.associateOrgBillingManagerArgsForCall = append(fake.associateOrgBillingManagerArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
 		arg5 string
-	}{arg1, arg2, arg3, arg4, arg5})
+		arg6 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
 	stub := fake.AssociateOrgBillingManagerStub
 	fakeReturns := fake.associateOrgBillingManagerReturns
-	fake.recordInvocation("AssociateOrgBillingManager", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("AssociateOrgBillingManager", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
 	fake.associateOrgBillingManagerMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4, arg5)
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
 		return ret.result1
@@ -396,17 +419,17 @@ func (fake *FakeManager) AssociateOrgBillingManagerCallCount() int {
 	return len(fake.associateOrgBillingManagerArgsForCall)
 }
 
-func (fake *FakeManager) AssociateOrgBillingManagerCalls(stub func(string, string, string, string, string) error) {
+func (fake *FakeManager) AssociateOrgBillingManagerCalls(stub func(context.Context, string, string, string, string, string) error) {
 	fake.associateOrgBillingManagerMutex.Lock()
 	defer fake.associateOrgBillingManagerMutex.Unlock()
 	fake.AssociateOrgBillingManagerStub = stub
 }
 
-func (fake *FakeManager) AssociateOrgBillingManagerArgsForCall(i int) (string, string, string, string, string) {
+func (fake *FakeManager) AssociateOrgBillingManagerArgsForCall(i int) (context.Context, string, string, string, string, string) {
 	fake.associateOrgBillingManagerMutex.RLock()
 	defer fake.associateOrgBillingManagerMutex.RUnlock()
 	argsForCall := fake.associateOrgBillingManagerArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
 }
 
 func (fake *FakeManager) AssociateOrgBillingManagerReturns(result1 error) {
@@ -432,22 +455,23 @@ func (fake *FakeManager) AssociateOrgBillingManagerReturnsOnCall(i int, result1
 	}{result1}
 }
 
-func (fake *FakeManager) AssociateOrgManager(arg1 string, arg2 string, arg3 string, arg4 string, arg5 string) error {
+func (fake *FakeManager) AssociateOrgManager(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string, arg6 string) error {
 	fake.associateOrgManagerMutex.Lock()
 	ret, specificReturn := fake.associateOrgManagerReturnsOnCall[len(fake.associateOrgManagerArgsForCall)]
 	fake.associateOrgManagerArgsForCall = append(fake.associateOrgManagerArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
 		arg5 string
-	}{arg1, arg2, arg3, arg4, arg5})
+		arg6 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
 	stub := fake.AssociateOrgManagerStub
 	fakeReturns := fake.associateOrgManagerReturns
-	fake.recordInvocation("AssociateOrgManager", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("AssociateOrgManager", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
 	fake.associateOrgManagerMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4, arg5)
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
 		return ret.result1
@@ -461,17 +485,17 @@ func (fake *FakeManager) AssociateOrgManagerCallCount() int {
 	return len(fake.associateOrgManagerArgsForCall)
 }
 
-func (fake *FakeManager) AssociateOrgManagerCalls(stub func(string, string, string, string, string) error) {
+func (fake *FakeManager) AssociateOrgManagerCalls(stub func(context.Context, string, string, string, string, string) error) {
 	fake.associateOrgManagerMutex.Lock()
 	defer fake.associateOrgManagerMutex.Unlock()
 	fake.AssociateOrgManagerStub = stub
 }
 
-func (fake *FakeManager) AssociateOrgManagerArgsForCall(i int) (string, string, string, string, string) {
+func (fake *FakeManager) AssociateOrgManagerArgsForCall(i int) (context.Context, string, string, string, string, string) {
 	fake.associateOrgManagerMutex.RLock()
 	defer fake.associateOrgManagerMutex.RUnlock()
 	argsForCall := fake.associateOrgManagerArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
 }
 
 func (fake *FakeManager) AssociateOrgManagerReturns(result1 error) {
@@ -497,22 +521,23 @@ func (fake *FakeManager) AssociateOrgManagerReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
-func (fake *FakeManager) AssociateSpaceAuditor(arg1 string, arg2 string, arg3 string, arg4 string, arg5 string) error {
+func (fake *FakeManager) AssociateSpaceAuditor(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string, arg6 string) error {
 	fake.associateSpaceAuditorMutex.Lock()
 	ret, specificReturn := fake.associateSpaceAuditorReturnsOnCall[len(fake.associateSpaceAuditorArgsForCall)]
 	fake.associateSpaceAuditorArgsForCall = append(fake.associateSpaceAuditorArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
 		arg5 string
-	}{arg1, arg2, arg3, arg4, arg5})
+		arg6 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
 	stub := fake.AssociateSpaceAuditorStub
 	fakeReturns := fake.associateSpaceAuditorReturns
-	fake.recordInvocation("AssociateSpaceAuditor", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("AssociateSpaceAuditor", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
 	fake.associateSpaceAuditorMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4, arg5)
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
 		return ret.result1
@@ -526,17 +551,17 @@ func (fake *FakeManager) AssociateSpaceAuditorCallCount() int {
 	return len(fake.associateSpaceAuditorArgsForCall)
 }
 
-func (fake *FakeManager) AssociateSpaceAuditorCalls(stub func(string, string, string, string, string) error) {
+func (fake *FakeManager) AssociateSpaceAuditorCalls(stub func(context.Context, string, string, string, string, string) error) {
 	fake.associateSpaceAuditorMutex.Lock()
 	defer fake.associateSpaceAuditorMutex.Unlock()
 	fake.AssociateSpaceAuditorStub = stub
 }
 
-func (fake *FakeManager) AssociateSpaceAuditorArgsForCall(i int) (string, string, string, string, string) {
+func (fake *FakeManager) AssociateSpaceAuditorArgsForCall(i int) (context.Context, string, string, string, string, string) {
 	fake.associateSpaceAuditorMutex.RLock()
 	defer fake.associateSpaceAuditorMutex.RUnlock()
 	argsForCall := fake.associateSpaceAuditorArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
 }
 
 func (fake *FakeManager) AssociateSpaceAuditorReturns(result1 error) {
@@ -562,22 +587,23 @@ func (fake *FakeManager) AssociateSpaceAuditorReturnsOnCall(i int, result1 error
 	}{result1}
 }
 
-func (fake *FakeManager) AssociateSpaceDeveloper(arg1 string, arg2 string, arg3 string, arg4 string, arg5 string) error {
+func (fake *FakeManager) AssociateSpaceDeveloper(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string, arg6 string) error {
 	fake.associateSpaceDeveloperMutex.Lock()
 	ret, specificReturn := fake.associateSpaceDeveloperReturnsOnCall[len(fake.associateSpaceDeveloperArgsForCall)]
 	fake.associateSpaceDeveloperArgsForCall = append(fake.associateSpaceDeveloperArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
 		arg5 string
-	}{arg1, arg2, arg3, arg4, arg5})
+		arg6 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
 	stub := fake.AssociateSpaceDeveloperStub
 	fakeReturns := fake.associateSpaceDeveloperReturns
-	fake.recordInvocation("AssociateSpaceDeveloper", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("AssociateSpaceDeveloper", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
 	fake.associateSpaceDeveloperMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4, arg5)
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
 		return ret.result1
@@ -591,17 +617,17 @@ func (fake *FakeManager) AssociateSpaceDeveloperCallCount() int {
 	return len(fake.associateSpaceDeveloperArgsForCall)
 }
 
-func (fake *FakeManager) AssociateSpaceDeveloperCalls(stub func(string, string, string, string, string) error) {
+func (fake *FakeManager) AssociateSpaceDeveloperCalls(stub func(context.Context, string, string, string, string, string) error) {
 	fake.associateSpaceDeveloperMutex.Lock()
 	defer fake.associateSpaceDeveloperMutex.Unlock()
 	fake.AssociateSpaceDeveloperStub = stub
 }
 
-func (fake *FakeManager) AssociateSpaceDeveloperArgsForCall(i int) (string, string, string, string, string) {
+func (fake *FakeManager) AssociateSpaceDeveloperArgsForCall(i int) (context.Context, string, string, string, string, string) {
 	fake.associateSpaceDeveloperMutex.RLock()
 	defer fake.associateSpaceDeveloperMutex.RUnlock()
 	argsForCall := fake.associateSpaceDeveloperArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
 }
 
 func (fake *FakeManager) AssociateSpaceDeveloperReturns(result1 error) {
@@ -627,22 +653,23 @@ func (fake *FakeManager) AssociateSpaceDeveloperReturnsOnCall(i int, result1 err
 	}{result1}
 }
 
-func (fake *FakeManager) AssociateSpaceManager(arg1 string, arg2 string, arg3 string, arg4 string, arg5 string) error {
+func (fake *FakeManager) AssociateSpaceManager(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string, arg6 string) error {
 	fake.associateSpaceManagerMutex.Lock()
 	ret, specificReturn := fake.associateSpaceManagerReturnsOnCall[len(fake.associateSpaceManagerArgsForCall)]
 	fake.associateSpaceManagerArgsForCall = append(fake.associateSpaceManagerArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
 		arg5 string
-	}{arg1, arg2, arg3, arg4, arg5})
+		arg6 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
 	stub := fake.AssociateSpaceManagerStub
 	fakeReturns := fake.associateSpaceManagerReturns
-	fake.recordInvocation("AssociateSpaceManager", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("AssociateSpaceManager", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
 	fake.associateSpaceManagerMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4, arg5)
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
 		return ret.result1
@@ -656,17 +683,17 @@ func (fake *FakeManager) AssociateSpaceManagerCallCount() int {
 	return len(fake.associateSpaceManagerArgsForCall)
 }
 
-func (fake *FakeManager) AssociateSpaceManagerCalls(stub func(string, string, string, string, string) error) {
+func (fake *FakeManager) AssociateSpaceManagerCalls(stub func(context.Context, string, string, string, string, string) error) {
 	fake.associateSpaceManagerMutex.Lock()
 	defer fake.associateSpaceManagerMutex.Unlock()
 	fake.AssociateSpaceManagerStub = stub
 }
 
-func (fake *FakeManager) AssociateSpaceManagerArgsForCall(i int) (string, string, string, string, string) {
+func (fake *FakeManager) AssociateSpaceManagerArgsForCall(i int) (context.Context, string, string, string, string, string) {
 	fake.associateSpaceManagerMutex.RLock()
 	defer fake.associateSpaceManagerMutex.RUnlock()
 	argsForCall := fake.associateSpaceManagerArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
 }
 
 func (fake *FakeManager) AssociateSpaceManagerReturns(result1 error) {
@@ -692,22 +719,23 @@ func (fake *FakeManager) AssociateSpaceManagerReturnsOnCall(i int, result1 error
 	}{result1}
 }
 
-func (fake *FakeManager) AssociateSpaceSupporter(arg1 string, arg2 string, arg3 string, arg4 string, arg5 string) error {
+func (fake *FakeManager) AssociateSpaceSupporter(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string, arg6 string) error {
 	fake.associateSpaceSupporterMutex.Lock()
 	ret, specificReturn := fake.associateSpaceSupporterReturnsOnCall[len(fake.associateSpaceSupporterArgsForCall)]
 	fake.associateSpaceSupporterArgsForCall = append(fake.associateSpaceSupporterArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
 		arg5 string
-	}{arg1, arg2, arg3, arg4, arg5})
+		arg6 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
 	stub := fake.AssociateSpaceSupporterStub
 	fakeReturns := fake.associateSpaceSupporterReturns
-	fake.recordInvocation("AssociateSpaceSupporter", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("AssociateSpaceSupporter", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
 	fake.associateSpaceSupporterMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4, arg5)
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
 		return ret.result1
@@ -721,17 +749,17 @@ func (fake *FakeManager) AssociateSpaceSupporterCallCount() int {
 	return len(fake.associateSpaceSupporterArgsForCall)
 }
 
-func (fake *FakeManager) AssociateSpaceSupporterCalls(stub func(string, string, string, string, string) error) {
+func (fake *FakeManager) AssociateSpaceSupporterCalls(stub func(context.Context, string, string, string, string, string) error) {
 	fake.associateSpaceSupporterMutex.Lock()
 	defer fake.associateSpaceSupporterMutex.Unlock()
 	fake.AssociateSpaceSupporterStub = stub
 }
 
-func (fake *FakeManager) AssociateSpaceSupporterArgsForCall(i int) (string, string, string, string, string) {
+func (fake *FakeManager) AssociateSpaceSupporterArgsForCall(i int) (context.Context, string, string, string, string, string) {
 	fake.associateSpaceSupporterMutex.RLock()
 	defer fake.associateSpaceSupporterMutex.RUnlock()
 	argsForCall := fake.associateSpaceSupporterArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
 }
 
 func (fake *FakeManager) AssociateSpaceSupporterReturns(result1 error) {
@@ -781,18 +809,19 @@ func (fake *FakeManager) ClearRolesCalls(stub func()) {
 	fake.ClearRolesStub = stub
 }
 
-func (fake *FakeManager) DeleteUser(arg1 string) error {
+func (fake *FakeManager) DeleteUser(arg1 context.Context, arg2 string) error {
 	fake.deleteUserMutex.Lock()
 	ret, specificReturn := fake.deleteUserReturnsOnCall[len(fake.deleteUserArgsForCall)]
 	fake.deleteUserArgsForCall = append(fake.deleteUserArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.DeleteUserStub
 	fakeReturns := fake.deleteUserReturns
-	fake.recordInvocation("DeleteUser", []interface{}{arg1})
+	fake.recordInvocation("DeleteUser", []interface{}{arg1, arg2})
 	fake.deleteUserMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -806,17 +835,17 @@ func (fake *FakeManager) DeleteUserCallCount() int {
 	return len(fake.deleteUserArgsForCall)
 }
 
-func (fake *FakeManager) DeleteUserCalls(stub func(string) error) {
+func (fake *FakeManager) DeleteUserCalls(stub func(context.Context, string) error) {
 	fake.deleteUserMutex.Lock()
 	defer fake.deleteUserMutex.Unlock()
 	fake.DeleteUserStub = stub
 }
 
-func (fake *FakeManager) DeleteUserArgsForCall(i int) string {
+func (fake *FakeManager) DeleteUserArgsForCall(i int) (context.Context, string) {
 	fake.deleteUserMutex.RLock()
 	defer fake.deleteUserMutex.RUnlock()
 	argsForCall := fake.deleteUserArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeManager) DeleteUserReturns(result1 error) {
@@ -842,17 +871,18 @@ func (fake *FakeManager) DeleteUserReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeManager) InitializeOrgUserRolesMap() error {
+func (fake *FakeManager) InitializeOrgUserRolesMap(arg1 context.Context) error {
 	fake.initializeOrgUserRolesMapMutex.Lock()
 	ret, specificReturn := fake.initializeOrgUserRolesMapReturnsOnCall[len(fake.initializeOrgUserRolesMapArgsForCall)]
 	fake.initializeOrgUserRolesMapArgsForCall = append(fake.initializeOrgUserRolesMapArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.InitializeOrgUserRolesMapStub
 	fakeReturns := fake.initializeOrgUserRolesMapReturns
-	fake.recordInvocation("InitializeOrgUserRolesMap", []interface{}{})
+	fake.recordInvocation("InitializeOrgUserRolesMap", []interface{}{arg1})
 	fake.initializeOrgUserRolesMapMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -866,12 +896,19 @@ func (fake *FakeManager) InitializeOrgUserRolesMapCallCount() int {
 	return len(fake.initializeOrgUserRolesMapArgsForCall)
 }
 
-func (fake *FakeManager) InitializeOrgUserRolesMapCalls(stub func() error) {
+func (fake *FakeManager) InitializeOrgUserRolesMapCalls(stub func(context.Context) error) {
 	fake.initializeOrgUserRolesMapMutex.Lock()
 	defer fake.initializeOrgUserRolesMapMutex.Unlock()
 	fake.InitializeOrgUserRolesMapStub = stub
 }
 
+func (fake *FakeManager) InitializeOrgUserRolesMapArgsForCall(i int) context.Context {
+	fake.initializeOrgUserRolesMapMutex.RLock()
+	defer fake.initializeOrgUserRolesMapMutex.RUnlock()
+	argsForCall := fake.initializeOrgUserRolesMapArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeManager) InitializeOrgUserRolesMapReturns(result1 error) {
 	fake.initializeOrgUserRolesMapMutex.Lock()
 	defer fake.initializeOrgUserRolesMapMutex.Unlock()
@@ -895,17 +932,18 @@ func (fake *FakeManager) InitializeOrgUserRolesMapReturnsOnCall(i int, result1 e
 	}{result1}
 }
 
-func (fake *FakeManager) InitializeSpaceUserRolesMap() error {
+func (fake *FakeManager) InitializeSpaceUserRolesMap(arg1 context.Context) error {
 	fake.initializeSpaceUserRolesMapMutex.Lock()
 	ret, specificReturn := fake.initializeSpaceUserRolesMapReturnsOnCall[len(fake.initializeSpaceUserRolesMapArgsForCall)]
 	fake.initializeSpaceUserRolesMapArgsForCall = append(fake.initializeSpaceUserRolesMapArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.InitializeSpaceUserRolesMapStub
 	fakeReturns := fake.initializeSpaceUserRolesMapReturns
-	fake.recordInvocation("InitializeSpaceUserRolesMap", []interface{}{})
+	fake.recordInvocation("InitializeSpaceUserRolesMap", []interface{}{arg1})
 	fake.initializeSpaceUserRolesMapMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -919,12 +957,19 @@ func (fake *FakeManager) InitializeSpaceUserRolesMapCallCount() int {
 	return len(fake.initializeSpaceUserRolesMapArgsForCall)
 }
 
-func (fake *FakeManager) InitializeSpaceUserRolesMapCalls(stub func() error) {
+func (fake *FakeManager) InitializeSpaceUserRolesMapCalls(stub func(context.Context) error) {
 	fake.initializeSpaceUserRolesMapMutex.Lock()
 	defer fake.initializeSpaceUserRolesMapMutex.Unlock()
 	fake.InitializeSpaceUserRolesMapStub = stub
 }
 
+func (fake *FakeManager) InitializeSpaceUserRolesMapArgsForCall(i int) context.Context {
+	fake.initializeSpaceUserRolesMapMutex.RLock()
+	defer fake.initializeSpaceUserRolesMapMutex.RUnlock()
+	argsForCall := fake.initializeSpaceUserRolesMapArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeManager) InitializeSpaceUserRolesMapReturns(result1 error) {
 	fake.initializeSpaceUserRolesMapMutex.Lock()
 	defer fake.initializeSpaceUserRolesMapMutex.Unlock()
@@ -948,18 +993,19 @@ func (fake *FakeManager) InitializeSpaceUserRolesMapReturnsOnCall(i int, result1
 	}{result1}
 }
 
-func (fake *FakeManager) ListOrgUsersByRole(arg1 string) (*role.RoleUsers, *role.RoleUsers, *role.RoleUsers, *role.RoleUsers, error) {
+func (fake *FakeManager) ListOrgUsersByRole(arg1 context.Context, arg2 string) (*role.RoleUsers, *role.RoleUsers, *role.RoleUsers, *role.RoleUsers, error) {
 	fake.listOrgUsersByRoleMutex.Lock()
 	ret, specificReturn := fake.listOrgUsersByRoleReturnsOnCall[len(fake.listOrgUsersByRoleArgsForCall)]
 	fake.listOrgUsersByRoleArgsForCall = append(fake.listOrgUsersByRoleArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.ListOrgUsersByRoleStub
 	fakeReturns := fake.listOrgUsersByRoleReturns
-	fake.recordInvocation("ListOrgUsersByRole", []interface{}{arg1})
+	fake.recordInvocation("ListOrgUsersByRole", []interface{}{arg1, arg2})
 	fake.listOrgUsersByRoleMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3, ret.result4, ret.result5
@@ -973,17 +1019,17 @@ func (fake *FakeManager) ListOrgUsersByRoleCallCount() int {
 	return len(fake.listOrgUsersByRoleArgsForCall)
 }
 
-func (fake *FakeManager) ListOrgUsersByRoleCalls(stub func(string) (*role.RoleUsers, *role.RoleUsers, *role.RoleUsers, *role.RoleUsers, error)) {
+func (fake *FakeManager) ListOrgUsersByRoleCalls(stub func(context.Context, string) (*role.RoleUsers, *role.RoleUsers, *role.RoleUsers, *role.RoleUsers, error)) {
 	fake.listOrgUsersByRoleMutex.Lock()
 	defer fake.listOrgUsersByRoleMutex.Unlock()
 	fake.ListOrgUsersByRoleStub = stub
 }
 
-func (fake *FakeManager) ListOrgUsersByRoleArgsForCall(i int) string {
+func (fake *FakeManager) ListOrgUsersByRoleArgsForCall(i int) (context.Context, string) {
 	fake.listOrgUsersByRoleMutex.RLock()
 	defer fake.listOrgUsersByRoleMutex.RUnlock()
 	argsForCall := fake.listOrgUsersByRoleArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeManager) ListOrgUsersByRoleReturns(result1 *role.RoleUsers, result2 *role.RoleUsers, result3 *role.RoleUsers, result4 *role.RoleUsers, result5 error) {
@@ -1021,18 +1067,19 @@ func (fake *FakeManager) ListOrgUsersByRoleReturnsOnCall(i int, result1 *role.Ro
 	}{result1, result2, result3, result4, result5}
 }
 
-func (fake *FakeManager) ListSpaceUsersByRole(arg1 string) (*role.RoleUsers, *role.RoleUsers, *role.RoleUsers, *role.RoleUsers, error) {
+func (fake *FakeManager) ListSpaceUsersByRole(arg1 context.Context, arg2 string) (*role.RoleUsers, *role.RoleUsers, *role.RoleUsers, *role.RoleUsers, error) {
 	fake.listSpaceUsersByRoleMutex.Lock()
 	ret, specificReturn := fake.listSpaceUsersByRoleReturnsOnCall[len(fake.listSpaceUsersByRoleArgsForCall)]
 	fake.listSpaceUsersByRoleArgsForCall = append(fake.listSpaceUsersByRoleArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.ListSpaceUsersByRoleStub
 	fakeReturns := fake.listSpaceUsersByRoleReturns
-	fake.recordInvocation("ListSpaceUsersByRole", []interface{}{arg1})
+	fake.recordInvocation("ListSpaceUsersByRole", []interface{}{arg1, arg2})
 	fake.listSpaceUsersByRoleMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3, ret.result4, ret.result5
@@ -1046,17 +1093,17 @@ func (fake *FakeManager) ListSpaceUsersByRoleCallCount() int {
 	return len(fake.listSpaceUsersByRoleArgsForCall)
 }
 
-func (fake *FakeManager) ListSpaceUsersByRoleCalls(stub func(string) (*role.RoleUsers, *role.RoleUsers, *role.RoleUsers, *role.RoleUsers, error)) {
+func (fake *FakeManager) ListSpaceUsersByRoleCalls(stub func(context.Context, string) (*role.RoleUsers, *role.RoleUsers, *role.RoleUsers, *role.RoleUsers, error)) {
 	fake.listSpaceUsersByRoleMutex.Lock()
 	defer fake.listSpaceUsersByRoleMutex.Unlock()
 	fake.ListSpaceUsersByRoleStub = stub
 }
 
-func (fake *FakeManager) ListSpaceUsersByRoleArgsForCall(i int) string {
+func (fake *FakeManager) ListSpaceUsersByRoleArgsForCall(i int) (context.Context, string) {
 	fake.listSpaceUsersByRoleMutex.RLock()
 	defer fake.listSpaceUsersByRoleMutex.RUnlock()
 	argsForCall := fake.listSpaceUsersByRoleArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeManager) ListSpaceUsersByRoleReturns(result1 *role.RoleUsers, result2 *role.RoleUsers, result3 *role.RoleUsers, result4 *role.RoleUsers, result5 error) {
@@ -1094,21 +1141,22 @@ func (fake *FakeManager) ListSpaceUsersByRoleReturnsOnCall(i int, result1 *role.
 	}{result1, result2, result3, result4, result5}
 }
 
-func (fake *FakeManager) RemoveOrgAuditor(arg1 string, arg2 string, arg3 string, arg4 string) error {
+func (fake *FakeManager) RemoveOrgAuditor(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string) error {
 	fake.removeOrgAuditorMutex.Lock()
 	ret, specificReturn := fake.removeOrgAuditorReturnsOnCall[len(fake.removeOrgAuditorArgsForCall)]
 	fake.removeOrgAuditorArgsForCall = append(fake.removeOrgAuditorArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
-	}{arg1, arg2, arg3, arg4})
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
 	stub := fake.RemoveOrgAuditorStub
 	fakeReturns := fake.removeOrgAuditorReturns
-	fake.recordInvocation("RemoveOrgAuditor", []interface{}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("RemoveOrgAuditor", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.removeOrgAuditorMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4)
+		return stub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1
@@ -1122,17 +1170,17 @@ func (fake *FakeManager) RemoveOrgAuditorCallCount() int {
 	return len(fake.removeOrgAuditorArgsForCall)
 }
 
-func (fake *FakeManager) RemoveOrgAuditorCalls(stub func(string, string, string, string) error) {
+func (fake *FakeManager) RemoveOrgAuditorCalls(stub func(context.Context, string, string, string, string) error) {
 	fake.removeOrgAuditorMutex.Lock()
 	defer fake.removeOrgAuditorMutex.Unlock()
 	fake.RemoveOrgAuditorStub = stub
 }
 
-func (fake *FakeManager) RemoveOrgAuditorArgsForCall(i int) (string, string, string, string) {
+func (fake *FakeManager) RemoveOrgAuditorArgsForCall(i int) (context.Context, string, string, string, string) {
 	fake.removeOrgAuditorMutex.RLock()
 	defer fake.removeOrgAuditorMutex.RUnlock()
 	argsForCall := fake.removeOrgAuditorArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeManager) RemoveOrgAuditorReturns(result1 error) {
@@ -1158,21 +1206,22 @@ func (fake *FakeManager) RemoveOrgAuditorReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeManager) RemoveOrgBillingManager(arg1 string, arg2 string, arg3 string, arg4 string) error {
+func (fake *FakeManager) RemoveOrgBillingManager(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string) error {
 	fake.removeOrgBillingManagerMutex.Lock()
 	ret, specificReturn := fake.removeOrgBillingManagerReturnsOnCall[len(fake.removeOrgBillingManagerArgsForCall)]
 	fake.removeOrgBillingManagerArgsForCall = append(fake.removeOrgBillingManagerArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
-	}{arg1, arg2, arg3, arg4})
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
 	stub := fake.RemoveOrgBillingManagerStub
 	fakeReturns := fake.removeOrgBillingManagerReturns
-	fake.recordInvocation("RemoveOrgBillingManager", []interface{}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("RemoveOrgBillingManager", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.removeOrgBillingManagerMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4)
+		return stub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1
@@ -1186,17 +1235,17 @@ func (fake *FakeManager) RemoveOrgBillingManagerCallCount() int {
 	return len(fake.removeOrgBillingManagerArgsForCall)
 }
 
-func (fake *FakeManager) RemoveOrgBillingManagerCalls(stub func(string, string, string, string) error) {
+func (fake *FakeManager) RemoveOrgBillingManagerCalls(stub func(context.Context, string, string, string, string) error) {
 	fake.removeOrgBillingManagerMutex.Lock()
 	defer fake.removeOrgBillingManagerMutex.Unlock()
 	fake.RemoveOrgBillingManagerStub = stub
 }
 
-func (fake *FakeManager) RemoveOrgBillingManagerArgsForCall(i int) (string, string, string, string) {
+func (fake *FakeManager) RemoveOrgBillingManagerArgsForCall(i int) (context.Context, string, string, string, string) {
 	fake.removeOrgBillingManagerMutex.RLock()
 	defer fake.removeOrgBillingManagerMutex.RUnlock()
 	argsForCall := fake.removeOrgBillingManagerArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeManager) RemoveOrgBillingManagerReturns(result1 error) {
@@ -1222,21 +1271,22 @@ func (fake *FakeManager) RemoveOrgBillingManagerReturnsOnCall(i int, result1 err
 	}{result1}
 }
 
-func (fake *FakeManager) RemoveOrgManager(arg1 string, arg2 string, arg3 string, arg4 string) error {
+func (fake *FakeManager) RemoveOrgManager(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string) error {
 	fake.removeOrgManagerMutex.Lock()
 	ret, specificReturn := fake.removeOrgManagerReturnsOnCall[len(fake.removeOrgManagerArgsForCall)]
 	fake.removeOrgManagerArgsForCall = append(fake.removeOrgManagerArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
-	}{arg1, arg2, arg3, arg4})
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
 	stub := fake.RemoveOrgManagerStub
 	fakeReturns := fake.removeOrgManagerReturns
-	fake.recordInvocation("RemoveOrgManager", []interface{}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("RemoveOrgManager", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.removeOrgManagerMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4)
+		return stub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1
@@ -1250,17 +1300,17 @@ func (fake *FakeManager) RemoveOrgManagerCallCount() int {
 	return len(fake.removeOrgManagerArgsForCall)
 }
 
-func (fake *FakeManager) RemoveOrgManagerCalls(stub func(string, string, string, string) error) {
+func (fake *FakeManager) RemoveOrgManagerCalls(stub func(context.Context, string, string, string, string) error) {
 	fake.removeOrgManagerMutex.Lock()
 	defer fake.removeOrgManagerMutex.Unlock()
 	fake.RemoveOrgManagerStub = stub
 }
 
-func (fake *FakeManager) RemoveOrgManagerArgsForCall(i int) (string, string, string, string) {
+func (fake *FakeManager) RemoveOrgManagerArgsForCall(i int) (context.Context, string, string, string, string) {
 	fake.removeOrgManagerMutex.RLock()
 	defer fake.removeOrgManagerMutex.RUnlock()
 	argsForCall := fake.removeOrgManagerArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeManager) RemoveOrgManagerReturns(result1 error) {
@@ -1286,21 +1336,22 @@ func (fake *FakeManager) RemoveOrgManagerReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeManager) RemoveOrgUser(arg1 string, arg2 string, arg3 string, arg4 string) error {
+func (fake *FakeManager) RemoveOrgUser(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string) error {
 	fake.removeOrgUserMutex.Lock()
 	ret, specificReturn := fake.removeOrgUserReturnsOnCall[len(fake.removeOrgUserArgsForCall)]
 	fake.removeOrgUserArgsForCall = append(fake.removeOrgUserArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
-	}{arg1, arg2, arg3, arg4})
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
 	stub := fake.RemoveOrgUserStub
 	fakeReturns := fake.removeOrgUserReturns
-	fake.recordInvocation("RemoveOrgUser", []interface{}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("RemoveOrgUser", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.removeOrgUserMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4)
+		return stub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1
@@ -1314,17 +1365,17 @@ func (fake *FakeManager) RemoveOrgUserCallCount() int {
 	return len(fake.removeOrgUserArgsForCall)
 }
 
-func (fake *FakeManager) RemoveOrgUserCalls(stub func(string, string, string, string) error) {
+func (fake *FakeManager) RemoveOrgUserCalls(stub func(context.Context, string, string, string, string) error) {
 	fake.removeOrgUserMutex.Lock()
 	defer fake.removeOrgUserMutex.Unlock()
 	fake.RemoveOrgUserStub = stub
 }
 
-func (fake *FakeManager) RemoveOrgUserArgsForCall(i int) (string, string, string, string) {
+func (fake *FakeManager) RemoveOrgUserArgsForCall(i int) (context.Context, string, string, string, string) {
 	fake.removeOrgUserMutex.RLock()
 	defer fake.removeOrgUserMutex.RUnlock()
 	argsForCall := fake.removeOrgUserArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeManager) RemoveOrgUserReturns(result1 error) {
@@ -1350,21 +1401,22 @@ func (fake *FakeManager) RemoveOrgUserReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeManager) RemoveSpaceAuditor(arg1 string, arg2 string, arg3 string, arg4 string) error {
+func (fake *FakeManager) RemoveSpaceAuditor(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string) error {
 	fake.removeSpaceAuditorMutex.Lock()
 	ret, specificReturn := fake.removeSpaceAuditorReturnsOnCall[len(fake.removeSpaceAuditorArgsForCall)]
 	fake.removeSpaceAuditorArgsForCall = append(fake.removeSpaceAuditorArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
-	}{arg1, arg2, arg3, arg4})
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
 	stub := fake.RemoveSpaceAuditorStub
 	fakeReturns := fake.removeSpaceAuditorReturns
-	fake.recordInvocation("RemoveSpaceAuditor", []interface{}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("RemoveSpaceAuditor", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.removeSpaceAuditorMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4)
+		return stub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1
@@ -1378,17 +1430,17 @@ func (fake *FakeManager) RemoveSpaceAuditorCallCount() int {
 	return len(fake.removeSpaceAuditorArgsForCall)
 }
 
-func (fake *FakeManager) RemoveSpaceAuditorCalls(stub func(string, string, string, string) error) {
+func (fake *FakeManager) RemoveSpaceAuditorCalls(stub func(context.Context, string, string, string, string) error) {
 	fake.removeSpaceAuditorMutex.Lock()
 	defer fake.removeSpaceAuditorMutex.Unlock()
 	fake.RemoveSpaceAuditorStub = stub
 }
 
-func (fake *FakeManager) RemoveSpaceAuditorArgsForCall(i int) (string, string, string, string) {
+func (fake *FakeManager) RemoveSpaceAuditorArgsForCall(i int) (context.Context, string, string, string, string) {
 	fake.removeSpaceAuditorMutex.RLock()
 	defer fake.removeSpaceAuditorMutex.RUnlock()
 	argsForCall := fake.removeSpaceAuditorArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeManager) RemoveSpaceAuditorReturns(result1 error) {
@@ -1414,21 +1466,22 @@ func (fake *FakeManager) RemoveSpaceAuditorReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeManager) RemoveSpaceDeveloper(arg1 string, arg2 string, arg3 string, arg4 string) error {
+func (fake *FakeManager) RemoveSpaceDeveloper(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string) error {
 	fake.removeSpaceDeveloperMutex.Lock()
 	ret, specificReturn := fake.removeSpaceDeveloperReturnsOnCall[len(fake.removeSpaceDeveloperArgsForCall)]
 	fake.removeSpaceDeveloperArgsForCall = append(fake.removeSpaceDeveloperArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
-	}{arg1, arg2, arg3, arg4})
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
 	stub := fake.RemoveSpaceDeveloperStub
 	fakeReturns := fake.removeSpaceDeveloperReturns
-	fake.recordInvocation("RemoveSpaceDeveloper", []interface{}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("RemoveSpaceDeveloper", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.removeSpaceDeveloperMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4)
+		return stub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1
@@ -1442,17 +1495,17 @@ func (fake *FakeManager) RemoveSpaceDeveloperCallCount() int {
 	return len(fake.removeSpaceDeveloperArgsForCall)
 }
 
-func (fake *FakeManager) RemoveSpaceDeveloperCalls(stub func(string, string, string, string) error) {
+func (fake *FakeManager) RemoveSpaceDeveloperCalls(stub func(context.Context, string, string, string, string) error) {
 	fake.removeSpaceDeveloperMutex.Lock()
 	defer fake.removeSpaceDeveloperMutex.Unlock()
 	fake.RemoveSpaceDeveloperStub = stub
 }
 
-func (fake *FakeManager) RemoveSpaceDeveloperArgsForCall(i int) (string, string, string, string) {
+func (fake *FakeManager) RemoveSpaceDeveloperArgsForCall(i int) (context.Context, string, string, string, string) {
 	fake.removeSpaceDeveloperMutex.RLock()
 	defer fake.removeSpaceDeveloperMutex.RUnlock()
 	argsForCall := fake.removeSpaceDeveloperArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeManager) RemoveSpaceDeveloperReturns(result1 error) {
@@ -1478,21 +1531,22 @@ func (fake *FakeManager) RemoveSpaceDeveloperReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
-func (fake *FakeManager) RemoveSpaceManager(arg1 string, arg2 string, arg3 string, arg4 string) error {
+func (fake *FakeManager) RemoveSpaceManager(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string) error {
 	fake.removeSpaceManagerMutex.Lock()
 	ret, specificReturn := fake.removeSpaceManagerReturnsOnCall[len(fake.removeSpaceManagerArgsForCall)]
 	fake.removeSpaceManagerArgsForCall = append(fake.removeSpaceManagerArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
-	}{arg1, arg2, arg3, arg4})
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
 	stub := fake.RemoveSpaceManagerStub
 	fakeReturns := fake.removeSpaceManagerReturns
-	fake.recordInvocation("RemoveSpaceManager", []interface{}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("RemoveSpaceManager", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.removeSpaceManagerMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4)
+		return stub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1
@@ -1506,17 +1560,17 @@ func (fake *FakeManager) RemoveSpaceManagerCallCount() int {
 	return len(fake.removeSpaceManagerArgsForCall)
 }
 
-func (fake *FakeManager) RemoveSpaceManagerCalls(stub func(string, string, string, string) error) {
+func (fake *FakeManager) RemoveSpaceManagerCalls(stub func(context.Context, string, string, string, string) error) {
 	fake.removeSpaceManagerMutex.Lock()
 	defer fake.removeSpaceManagerMutex.Unlock()
 	fake.RemoveSpaceManagerStub = stub
 }
 
-func (fake *FakeManager) RemoveSpaceManagerArgsForCall(i int) (string, string, string, string) {
+func (fake *FakeManager) RemoveSpaceManagerArgsForCall(i int) (context.Context, string, string, string, string) {
 	fake.removeSpaceManagerMutex.RLock()
 	defer fake.removeSpaceManagerMutex.RUnlock()
 	argsForCall := fake.removeSpaceManagerArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeManager) RemoveSpaceManagerReturns(result1 error) {
@@ -1542,21 +1596,22 @@ func (fake *FakeManager) RemoveSpaceManagerReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeManager) RemoveSpaceSupporter(arg1 string, arg2 string, arg3 string, arg4 string) error {
+func (fake *FakeManager) RemoveSpaceSupporter(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string) error {
 	fake.removeSpaceSupporterMutex.Lock()
 	ret, specificReturn := fake.removeSpaceSupporterReturnsOnCall[len(fake.removeSpaceSupporterArgsForCall)]
 	fake.removeSpaceSupporterArgsForCall = append(fake.removeSpaceSupporterArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
-	}{arg1, arg2, arg3, arg4})
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
 	stub := fake.RemoveSpaceSupporterStub
 	fakeReturns := fake.removeSpaceSupporterReturns
-	fake.recordInvocation("RemoveSpaceSupporter", []interface{}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("RemoveSpaceSupporter", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.removeSpaceSupporterMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4)
+		return stub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1
@@ -1570,17 +1625,17 @@ func (fake *FakeManager) RemoveSpaceSupporterCallCount() int {
 	return len(fake.removeSpaceSupporterArgsForCall)
 }
 
-func (fake *FakeManager) RemoveSpaceSupporterCalls(stub func(string, string, string, string) error) {
+func (fake *FakeManager) RemoveSpaceSupporterCalls(stub func(context.Context, string, string, string, string) error) {
 	fake.removeSpaceSupporterMutex.Lock()
 	defer fake.removeSpaceSupporterMutex.Unlock()
 	fake.RemoveSpaceSupporterStub = stub
 }
 
-func (fake *FakeManager) RemoveSpaceSupporterArgsForCall(i int) (string, string, string, string) {
+func (fake *FakeManager) RemoveSpaceSupporterArgsForCall(i int) (context.Context, string, string, string, string) {
 	fake.removeSpaceSupporterMutex.RLock()
 	defer fake.removeSpaceSupporterMutex.RUnlock()
 	argsForCall := fake.removeSpaceSupporterArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeManager) RemoveSpaceSupporterReturns(result1 error) {
@@ -6,11 +6,14 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cloudfoundry-community/go-cfclient/v3/client"
 	"github.com/cloudfoundry-community/go-cfclient/v3/resource"
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
 	"github.com/vmwarepivotallabs/cf-mgmt/uaa"
+	"github.com/vmwarepivotallabs/cf-mgmt/util"
 	"github.com/xchapter7x/lo"
 )
 
@@ -21,50 +24,79 @@ type DefaultManager struct {
 	OrgRoles        map[string]map[string]*RoleUsers
 	SpaceRoles      map[string]map[string]*RoleUsers
 	UAAMgr          uaa.Manager
+	Recorder        *changes.Recorder
 	Peek            bool
 	OrgRolesUsers   map[string]map[string]map[string]string
 	SpaceRolesUsers map[string]map[string]map[string]string
+	// mutex - guards the role caches as spaces of different orgs are reconciled concurrently
+	mutex sync.Mutex
 }
 
-func New(roleClient CFRoleClient, userClient CFUserClient, jobClient CFJobClient, uaaMgr uaa.Manager, peek bool) Manager {
+func New(roleClient CFRoleClient, userClient CFUserClient, jobClient CFJobClient, uaaMgr uaa.Manager, recorder *changes.Recorder, peek bool) Manager {
 	return &DefaultManager{
 		RoleClient: roleClient,
 		UserClient: userClient,
 		JobClient:  jobClient,
 		UAAMgr:     uaaMgr,
+		Recorder:   recorder,
 		Peek:       peek,
 	}
 }
 
+func (m *DefaultManager) recordOrgRole(action changes.Action, orgName, userName, role string) changes.Change {
+	change := roleChange(changes.Change{Entity: changes.OrgRole, Action: action, Name: userName, Org: orgName}, role)
+	m.Recorder.Record(change)
+	return change
+}
+
+// spaceName is the org/space name passed by the user manager
+func (m *DefaultManager) recordSpaceRole(action changes.Action, spaceName, userName, role string) changes.Change {
+	orgName, space := changes.SplitEntityName(spaceName)
+	change := roleChange(changes.Change{Entity: changes.SpaceRole, Action: action, Name: userName, Org: orgName, Space: space}, role)
+	m.Recorder.Record(change)
+	return change
+}
+
+func roleChange(change changes.Change, role string) changes.Change {
+	if change.Action.Destructive() {
+		change.Before = role
+	} else {
+		change.After = role
+	}
+	return change
+}
+
 func (m *DefaultManager) ClearRoles() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 	m.OrgRoles = nil
 	m.SpaceRoles = nil
 	m.OrgRolesUsers = nil
 	m.SpaceRolesUsers = nil
 }
 
-func (m *DefaultManager) ListOrgRoles() ([]*resource.Role, error) {
+func (m *DefaultManager) ListOrgRoles(ctx context.Context) ([]*resource.Role, error) {
 
 	var rolesToReturn []*resource.Role
-	roles, err := m.listRolesForType(resource.OrganizationRoleAuditor.String())
+	roles, err := m.listRolesForType(ctx, resource.OrganizationRoleAuditor.String())
 	if err != nil {
 		return nil, err
 	}
 	rolesToReturn = append(rolesToReturn, roles...)
 
-	roles, err = m.listRolesForType(resource.OrganizationRoleBillingManager.String())
+	roles, err = m.listRolesForType(ctx, resource.OrganizationRoleBillingManager.String())
 	if err != nil {
 		return nil, err
 	}
 	rolesToReturn = append(rolesToReturn, roles...)
 
-	roles, err = m.listRolesForType(resource.OrganizationRoleManager.String())
+	roles, err = m.listRolesForType(ctx, resource.OrganizationRoleManager.String())
 	if err != nil {
 		return nil, err
 	}
 	rolesToReturn = append(rolesToReturn, roles...)
 
-	roles, err = m.listRolesForType(resource.OrganizationRoleUser.String())
+	roles, err = m.listRolesForType(ctx, resource.OrganizationRoleUser.String())
 	if err != nil {
 		return nil, err
 	}
@@ -72,27 +104,27 @@ func (m *DefaultManager) ListOrgRoles() ([]*resource.Role, error) {
 	return rolesToReturn, err
 }
 
-func (m *DefaultManager) ListSpaceRoles() ([]*resource.Role, error) {
+func (m *DefaultManager) ListSpaceRoles(ctx context.Context) ([]*resource.Role, error) {
 	var rolesToReturn []*resource.Role
-	roles, err := m.listRolesForType(resource.SpaceRoleAuditor.String())
+	roles, err := m.listRolesForType(ctx, resource.SpaceRoleAuditor.String())
 	if err != nil {
 		return nil, err
 	}
 	rolesToReturn = append(rolesToReturn, roles...)
 
-	roles, err = m.listRolesForType(resource.SpaceRoleDeveloper.String())
+	roles, err = m.listRolesForType(ctx, resource.SpaceRoleDeveloper.String())
 	if err != nil {
 		return nil, err
 	}
 	rolesToReturn = append(rolesToReturn, roles...)
 
-	roles, err = m.listRolesForType(resource.SpaceRoleManager.String())
+	roles, err = m.listRolesForType(ctx, resource.SpaceRoleManager.String())
 	if err != nil {
 		return nil, err
 	}
 	rolesToReturn = append(rolesToReturn, roles...)
 
-	roles, err = m.listRolesForType(resource.SpaceRoleSupporter.String())
+	roles, err = m.listRolesForType(ctx, resource.SpaceRoleSupporter.String())
 	if err != nil {
 		return nil, err
 	}
@@ -100,8 +132,8 @@ func (m *DefaultManager) ListSpaceRoles() ([]*resource.Role, error) {
 	return rolesToReturn, err
 }
 
-func (m *DefaultManager) listRolesForType(roleType string) ([]*resource.Role, error) {
-	roles, err := m.RoleClient.ListAll(context.Background(), &client.RoleListOptions{
+func (m *DefaultManager) listRolesForType(ctx context.Context, roleType string) ([]*resource.Role, error) {
+	roles, err := m.RoleClient.ListAll(util.CallContext(ctx), &client.RoleListOptions{
 		Types: client.Filter{
 			Values: []string{roleType},
 		},
@@ -121,15 +153,21 @@ func (m *DefaultManager) listRolesForType(roleType string) ([]*resource.Role, er
 	return roles, err
 }
 
-func (m *DefaultManager) InitializeSpaceUserRolesMap() error {
+func (m *DefaultManager) InitializeSpaceUserRolesMap(ctx context.Context) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.initializeSpaceUserRolesMap(ctx)
+}
+
+func (m *DefaultManager) initializeSpaceUserRolesMap(ctx context.Context) error {
 	spaceV3UsersRolesMap := make(map[string]map[string][]*uaa.User)
-	roles, err := m.ListSpaceRoles()
+	roles, err := m.ListSpaceRoles(ctx)
 	if err != nil {
 		return err
 	}
 	for _, role := range roles {
 		spaceGUID := role.Relationships.Space.Data.GUID
-		user, err := m.getUserForGUID(role.Relationships.User.Data.GUID)
+		user, err := m.getUserForGUID(ctx, role.Relationships.User.Data.GUID)
 		if err != nil {
 			return err
 		}
@@ -143,7 +181,7 @@ func (m *DefaultManager) InitializeSpaceUserRolesMap() error {
 	spaceUsersRoleMap := make(map[string]map[string]*RoleUsers)
 	for key, val := range spaceV3UsersRolesMap {
 		for role, users := range val {
-			uaaUsers, err := m.GetUAAUsers()
+			uaaUsers, err := m.GetUAAUsers(ctx)
 			if err != nil {
 				return err
 			}
@@ -168,15 +206,21 @@ func (m *DefaultManager) InitializeSpaceUserRolesMap() error {
 	return nil
 }
 
-func (m *DefaultManager) InitializeOrgUserRolesMap() error {
+func (m *DefaultManager) InitializeOrgUserRolesMap(ctx context.Context) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.initializeOrgUserRolesMap(ctx)
+}
+
+func (m *DefaultManager) initializeOrgUserRolesMap(ctx context.Context) error {
 	orgV3UsersRolesMap := make(map[string]map[string][]*uaa.User)
-	roles, err := m.ListOrgRoles()
+	roles, err := m.ListOrgRoles(ctx)
 	if err != nil {
 		return err
 	}
 	for _, role := range roles {
 		orgGUID := role.Relationships.Org.Data.GUID
-		user, err := m.getUserForGUID(role.Relationships.User.Data.GUID)
+		user, err := m.getUserForGUID(ctx, role.Relationships.User.Data.GUID)
 		if err != nil {
 			return err
 		}
@@ -190,7 +234,7 @@ func (m *DefaultManager) InitializeOrgUserRolesMap() error {
 	orgUsersRoleMap := make(map[string]map[string]*RoleUsers)
 	for key, val := range orgV3UsersRolesMap {
 		for role, users := range val {
-			uaaUsers, err := m.GetUAAUsers()
+			uaaUsers, err := m.GetUAAUsers(ctx)
 			if err != nil {
 				return err
 			}
@@ -215,7 +259,7 @@ func (m *DefaultManager) InitializeOrgUserRolesMap() error {
 	return nil
 }
 
-func (m DefaultManager) buildUserMap(keyFunction func(role *resource.Role) string, roles []*resource.Role) map[string]map[string]map[string]string {
+func (m *DefaultManager) buildUserMap(keyFunction func(role *resource.Role) string, roles []*resource.Role) map[string]map[string]map[string]string {
 	result := make(map[string]map[string]map[string]string)
 	for _, role := range roles {
 		guid := keyFunction(role)
@@ -273,11 +317,13 @@ func asJson(role *resource.Role) string {
 	return string(bytes)
 }
 
-func (m *DefaultManager) GetUAAUsers() (*uaa.Users, error) {
-	return m.UAAMgr.ListUsers()
+func (m *DefaultManager) GetUAAUsers(ctx context.Context) (*uaa.Users, error) {
+	return m.UAAMgr.ListUsers(ctx)
 }
 
 func (m *DefaultManager) UpdateOrgRoleUsers(orgGUID string, roleUser *RoleUsers) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 	orgRoles, ok := m.OrgRoles[orgGUID]
 	if !ok {
 		orgRoles = make(map[string]*RoleUsers)
@@ -286,12 +332,14 @@ func (m *DefaultManager) UpdateOrgRoleUsers(orgGUID string, roleUser *RoleUsers)
 	m.OrgRoles[orgGUID] = orgRoles
 }
 
-func (m *DefaultManager) ListOrgUsersByRole(orgGUID string) (*RoleUsers, *RoleUsers, *RoleUsers, *RoleUsers, error) {
+func (m *DefaultManager) ListOrgUsersByRole(ctx context.Context, orgGUID string) (*RoleUsers, *RoleUsers, *RoleUsers, *RoleUsers, error) {
 	if m.Peek && strings.Contains(orgGUID, "dry-run-org-guid") {
 		return InitRoleUsers(), InitRoleUsers(), InitRoleUsers(), InitRoleUsers(), nil
 	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 	if m.OrgRoles == nil {
-		err := m.InitializeOrgUserRolesMap()
+		err := m.initializeOrgUserRolesMap(ctx)
 		if err != nil {
 			return nil, nil, nil, nil, err
 		}
@@ -299,13 +347,15 @@ func (m *DefaultManager) ListOrgUsersByRole(orgGUID string) (*RoleUsers, *RoleUs
 	return m.getOrgRole(orgGUID, resource.OrganizationRoleUser.String()), m.getOrgRole(orgGUID, resource.OrganizationRoleManager.String()), m.getOrgRole(orgGUID, resource.OrganizationRoleBillingManager.String()), m.getOrgRole(orgGUID, resource.OrganizationRoleAuditor.String()), nil
 }
 
-func (m *DefaultManager) ListSpaceUsersByRole(spaceGUID string) (*RoleUsers, *RoleUsers, *RoleUsers, *RoleUsers, error) {
+func (m *DefaultManager) ListSpaceUsersByRole(ctx context.Context, spaceGUID string) (*RoleUsers, *RoleUsers, *RoleUsers, *RoleUsers, error) {
 
 	if m.Peek && strings.Contains(spaceGUID, "dry-run-space-guid") {
 		return InitRoleUsers(), InitRoleUsers(), InitRoleUsers(), InitRoleUsers(), nil
 	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 	if m.SpaceRoles == nil {
-		err := m.InitializeSpaceUserRolesMap()
+		err := m.initializeSpaceUserRolesMap(ctx)
 		if err != nil {
 			return nil, nil, nil, nil, err
 		}
@@ -337,8 +387,8 @@ func (m *DefaultManager) getSpaceRole(spaceGUID, role string) *RoleUsers {
 	return roleUser
 }
 
-func (m *DefaultManager) getUserForGUID(guid string) (*uaa.User, error) {
-	uaaUsers, err := m.GetUAAUsers()
+func (m *DefaultManager) getUserForGUID(ctx context.Context, guid string) (*uaa.User, error) {
+	uaaUsers, err := m.GetUAAUsers(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -346,7 +396,7 @@ func (m *DefaultManager) getUserForGUID(guid string) (*uaa.User, error) {
 	if user != nil {
 		return user, nil
 	} else {
-		user, err := m.GetUser(guid)
+		user, err := m.GetUser(ctx, guid)
 		if err != nil {
 			return nil, err
 		}
@@ -358,22 +408,29 @@ func (m *DefaultManager) getUserForGUID(guid string) (*uaa.User, error) {
 	}
 }
 
-func (m *DefaultManager) GetUser(userGuid string) (*resource.User, error) {
-	user, err := m.UserClient.Get(context.Background(), userGuid)
+func (m *DefaultManager) GetUser(ctx context.Context, userGuid string) (*resource.User, error) {
+	user, err := m.UserClient.Get(util.CallContext(ctx), userGuid)
 	return user, err
 }
 
-func (m *DefaultManager) DeleteUser(userGuid string) error {
-	_, err := m.UserClient.Delete(context.Background(), userGuid)
+func (m *DefaultManager) DeleteUser(ctx context.Context, userGuid string) error {
+	change := changes.Change{Entity: changes.User, Action: changes.Delete, Name: userGuid}
+	m.Recorder.Record(change)
+	if m.Peek {
+		lo.G.Infof("[dry-run]: deleting orphaned user with guid %s", userGuid)
+		return nil
+	}
+	_, err := m.UserClient.Delete(util.CallContext(ctx), userGuid)
+	m.Recorder.Applied(change, userGuid, err)
 	return err
 }
 
-func (m *DefaultManager) deleteRole(roleGUID string) error {
-	jobGUID, err := m.RoleClient.Delete(context.Background(), roleGUID)
+func (m *DefaultManager) deleteRole(ctx context.Context, roleGUID string) error {
+	jobGUID, err := m.RoleClient.Delete(util.CallContext(ctx), roleGUID)
 	if err != nil {
 		return err
 	}
-	err = m.JobClient.PollComplete(context.Background(), jobGUID, &client.PollingOptions{
+	err = m.JobClient.PollComplete(util.CallContext(ctx), jobGUID, &client.PollingOptions{
 		FailedState:   "FAILED",
 		Timeout:       time.Second * 30,
 		CheckInterval: time.Second,
@@ -5,19 +5,22 @@ import (
 	"fmt"
 
 	"github.com/cloudfoundry-community/go-cfclient/v3/resource"
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
+	"github.com/vmwarepivotallabs/cf-mgmt/util"
 	"github.com/xchapter7x/lo"
 )
 
-func (m *DefaultManager) AddUserToOrg(orgGUID string, userName, userGUID string) error {
+func (m *DefaultManager) AddUserToOrg(ctx context.Context, orgGUID, orgName, userName, userGUID string) error {
 	if m.Peek {
 		return nil
 	}
-	orgUsers, _, _, _, err := m.ListOrgUsersByRole(orgGUID)
+	orgUsers, _, _, _, err := m.ListOrgUsersByRole(ctx, orgGUID)
 	if err != nil {
 		return err
 	}
 	if !orgUsers.HasUserForGUID(userName, userGUID) {
-		_, err := m.RoleClient.CreateOrganizationRole(context.Background(), orgGUID, userGUID, resource.OrganizationRoleUser)
+		_, err := m.RoleClient.CreateOrganizationRole(util.CallContext(ctx), orgGUID, userGUID, resource.OrganizationRoleUser)
+		m.Recorder.Applied(changes.Change{Entity: changes.OrgRole, Action: changes.Assign, Name: userName, Org: orgName, After: "user"}, userGUID, err)
 		if err != nil {
 			lo.G.Debugf("Error adding user [%s] to org with guid [%s] but should have succeeded missing from org roles %+v, message: [%s]", userName, userGUID, orgUsers, err.Error())
 			return err
@@ -28,52 +31,59 @@ func (m *DefaultManager) AddUserToOrg(orgGUID string, userName, userGUID string)
 	return nil
 }
 
-func (m *DefaultManager) AssociateOrgAuditor(orgGUID, orgName, entityGUID, userName, userGUID string) error {
-	err := m.AddUserToOrg(orgGUID, userName, userGUID)
+func (m *DefaultManager) AssociateOrgAuditor(ctx context.Context, orgGUID, orgName, entityGUID, userName, userGUID string) error {
+	err := m.AddUserToOrg(ctx, orgGUID, orgName, userName, userGUID)
 	if err != nil {
 		return err
 	}
+	change := m.recordOrgRole(changes.Assign, orgName, userName, "auditor")
 	if m.Peek {
 		lo.G.Infof("[dry-run]: Add User %s to role %s for org %s", userName, "auditor", orgName)
 		return nil
 	}
 
 	lo.G.Infof("Add User %s to role %s for org %s", userName, "auditor", orgName)
-	_, err = m.RoleClient.CreateOrganizationRole(context.Background(), orgGUID, userGUID, resource.OrganizationRoleAuditor)
+	_, err = m.RoleClient.CreateOrganizationRole(util.CallContext(ctx), orgGUID, userGUID, resource.OrganizationRoleAuditor)
+	m.Recorder.Applied(change, userGUID, err)
 	return err
 }
 
-func (m *DefaultManager) AssociateOrgManager(orgGUID, orgName, entityGUID, userName, userGUID string) error {
-	err := m.AddUserToOrg(orgGUID, userName, userGUID)
+func (m *DefaultManager) AssociateOrgManager(ctx context.Context, orgGUID, orgName, entityGUID, userName, userGUID string) error {
+	err := m.AddUserToOrg(ctx, orgGUID, orgName, userName, userGUID)
 	if err != nil {
 		return err
 	}
+	change := m.recordOrgRole(changes.Assign, orgName, userName, "manager")
 	if m.Peek {
 		lo.G.Infof("[dry-run]: Add User %s to role %s for org %s", userName, "manager", orgName)
 		return nil
 	}
 
 	lo.G.Infof("Add User %s to role %s for org %s", userName, "manager", orgName)
-	_, err = m.RoleClient.CreateOrganizationRole(context.Background(), orgGUID, userGUID, resource.OrganizationRoleManager)
+	_, err = m.RoleClient.CreateOrganizationRole(util.CallContext(ctx), orgGUID, userGUID, resource.OrganizationRoleManager)
+	m.Recorder.Applied(change, userGUID, err)
 	return err
 }
 
-func (m *DefaultManager) AssociateOrgBillingManager(orgGUID, orgName, entityGUID, userName, userGUID string) error {
-	err := m.AddUserToOrg(orgGUID, userName, userGUID)
+func (m *DefaultManager) AssociateOrgBillingManager(ctx context.Context, orgGUID, orgName, entityGUID, userName, userGUID string) error {
+	err := m.AddUserToOrg(ctx, orgGUID, orgName, userName, userGUID)
 	if err != nil {
 		return err
 	}
+	change := m.recordOrgRole(changes.Assign, orgName, userName, "billing manager")
 	if m.Peek {
 		lo.G.Infof("[dry-run]: Add User %s to role %s for org %s", userName, "billing manager", orgName)
 		return nil
 	}
 
 	lo.G.Infof("Add User %s to role %s for org %s", userName, "billing manager", orgName)
-	_, err = m.RoleClient.CreateOrganizationRole(context.Background(), orgGUID, userGUID, resource.OrganizationRoleBillingManager)
+	_, err = m.RoleClient.CreateOrganizationRole(util.CallContext(ctx), orgGUID, userGUID, resource.OrganizationRoleBillingManager)
+	m.Recorder.Applied(change, userGUID, err)
 	return err
 }
 
-func (m *DefaultManager) RemoveOrgAuditor(orgName, orgGUID, userName, userGUID string) error {
+func (m *DefaultManager) RemoveOrgAuditor(ctx context.Context, orgName, orgGUID, userName, userGUID string) error {
+	change := m.recordOrgRole(changes.Unassign, orgName, userName, "auditor")
 	if m.Peek {
 		lo.G.Infof("[dry-run]: removing user %s from org %s with role %s", userName, orgName, "auditor")
 		return nil
@@ -83,9 +93,12 @@ func (m *DefaultManager) RemoveOrgAuditor(orgName, orgGUID, userName, userGUID s
 	if err != nil {
 		return err
 	}
-	return m.deleteRole(roleGUID)
+	err = m.deleteRole(ctx, roleGUID)
+	m.Recorder.Applied(change, userGUID, err)
+	return err
 }
-func (m *DefaultManager) RemoveOrgBillingManager(orgName, orgGUID, userName, userGUID string) error {
+func (m *DefaultManager) RemoveOrgBillingManager(ctx context.Context, orgName, orgGUID, userName, userGUID string) error {
+	change := m.recordOrgRole(changes.Unassign, orgName, userName, "billing manager")
 	if m.Peek {
 		lo.G.Infof("[dry-run]: removing user %s from org %s with role %s", userName, orgName, "billing manager")
 		return nil
@@ -95,10 +108,13 @@ func (m *DefaultManager) RemoveOrgBillingManager(orgName, orgGUID, userName, use
 	if err != nil {
 		return err
 	}
-	return m.deleteRole(roleGUID)
+	err = m.deleteRole(ctx, roleGUID)
+	m.Recorder.Applied(change, userGUID, err)
+	return err
 }
 
-func (m *DefaultManager) RemoveOrgManager(orgName, orgGUID, userName, userGUID string) error {
+func (m *DefaultManager) RemoveOrgManager(ctx context.Context, orgName, orgGUID, userName, userGUID string) error {
+	change := m.recordOrgRole(changes.Unassign, orgName, userName, "manager")
 	if m.Peek {
 		lo.G.Infof("[dry-run]: removing user %s from org %s with role %s", userName, orgName, "manager")
 		return nil
@@ -108,10 +124,13 @@ func (m *DefaultManager) RemoveOrgManager(orgName, orgGUID, userName, userGUID s
 	if err != nil {
 		return err
 	}
-	return m.deleteRole(roleGUID)
+	err = m.deleteRole(ctx, roleGUID)
+	m.Recorder.Applied(change, userGUID, err)
+	return err
 }
 
-func (m *DefaultManager) RemoveOrgUser(orgName, orgGUID, userName, userGUID string) error {
+func (m *DefaultManager) RemoveOrgUser(ctx context.Context, orgName, orgGUID, userName, userGUID string) error {
+	change := m.recordOrgRole(changes.Unassign, orgName, userName, "user")
 	if m.Peek {
 		lo.G.Infof("[dry-run]: removing user %s from org %s with role %s", userName, orgName, "user")
 		return nil
@@ -121,10 +140,14 @@ func (m *DefaultManager) RemoveOrgUser(orgName, orgGUID, userName, userGUID stri
 	if err != nil {
 		return err
 	}
-	return m.deleteRole(roleGUID)
+	err = m.deleteRole(ctx, roleGUID)
+	m.Recorder.Applied(change, userGUID, err)
+	return err
 }
 
 func (m *DefaultManager) GetOrgRoleGUID(orgGUID, userGUID, role string) (string, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 	orgs, ok := m.OrgRolesUsers[orgGUID]
 	if !ok {
 		return "", fmt.Errorf("org with guid[%s] has no roles", orgGUID)
@@ -1,12 +1,15 @@
 package role_test
 
 import (
+	"context"
+
 	"errors"
 
 	"github.com/cloudfoundry-community/go-cfclient/v3/resource"
 	uaaclient "github.com/cloudfoundry-community/go-uaa"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
 	. "github.com/vmwarepivotallabs/cf-mgmt/role"
 	"github.com/vmwarepivotallabs/cf-mgmt/role/fakes"
 	"github.com/vmwarepivotallabs/cf-mgmt/uaa"
@@ -39,7 +42,7 @@ var _ = Describe("given RoleManager", func() {
 
 		Context("AddUserToOrg", func() {
 			It("should associate user", func() {
-				err := roleManager.AddUserToOrg("test-org-guid", "test", "test-user-guid")
+				err := roleManager.AddUserToOrg(context.Background(), "test-org-guid", "test-org", "test", "test-user-guid")
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(roleClient.CreateOrganizationRoleCallCount()).To(Equal(1))
 				_, orgGUID, userGUID, role := roleClient.CreateOrganizationRoleArgsForCall(0)
@@ -49,16 +52,31 @@ var _ = Describe("given RoleManager", func() {
 
 			})
 
+			It("should report the change with its org", func() {
+				outcomes := changes.NewOutcomes()
+				roleManager.Recorder = changes.NewRecorder()
+				roleManager.Recorder.AddSink(outcomes)
+				err := roleManager.AddUserToOrg(context.Background(), "test-org-guid", "test-org", "test", "test-user-guid")
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(outcomes.Succeeded()).Should(ConsistOf(changes.Change{
+					Entity: changes.OrgRole,
+					Action: changes.Assign,
+					Name:   "test",
+					Org:    "test-org",
+					After:  "user",
+				}))
+			})
+
 			It("should peek", func() {
 				roleManager.Peek = true
-				err := roleManager.AddUserToOrg("test-org-guid", "test", "test-user-guid")
+				err := roleManager.AddUserToOrg(context.Background(), "test-org-guid", "test-org", "test", "test-user-guid")
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(roleClient.CreateOrganizationRoleCallCount()).To(Equal(0))
 			})
 
 			It("should error", func() {
 				roleClient.CreateOrganizationRoleReturns(nil, errors.New("error"))
-				err := roleManager.AddUserToOrg("test-org-guid", "test", "test-user-guid")
+				err := roleManager.AddUserToOrg(context.Background(), "test-org-guid", "test-org", "test", "test-user-guid")
 				Expect(err).Should(HaveOccurred())
 				Expect(roleClient.CreateOrganizationRoleCallCount()).To(Equal(1))
 				_, orgGUID, userGUID, role := roleClient.CreateOrganizationRoleArgsForCall(0)
@@ -71,7 +89,7 @@ var _ = Describe("given RoleManager", func() {
 		Context("AssociateOrgAuditor", func() {
 			It("Should succeed", func() {
 				roleClient.CreateOrganizationRoleReturns(nil, nil)
-				err := roleManager.AssociateOrgAuditor("orgGUID", "org", "", "userName", "user-guid")
+				err := roleManager.AssociateOrgAuditor(context.Background(), "orgGUID", "org", "", "userName", "user-guid")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(roleClient.CreateOrganizationRoleCallCount()).To(Equal(2))
 				_, orgGUID, userGUID, role := roleClient.CreateOrganizationRoleArgsForCall(0)
@@ -87,7 +105,7 @@ var _ = Describe("given RoleManager", func() {
 
 			It("Should fail", func() {
 				roleClient.CreateOrganizationRoleReturns(nil, errors.New("error"))
-				err := roleManager.AssociateOrgAuditor("orgGUID", "org", "", "userName", "user-guid")
+				err := roleManager.AssociateOrgAuditor(context.Background(), "orgGUID", "org", "", "userName", "user-guid")
 				Expect(err).To(HaveOccurred())
 				Expect(roleClient.CreateOrganizationRoleCallCount()).To(Equal(1))
 				_, orgGUID, userGUID, role := roleClient.CreateOrganizationRoleArgsForCall(0)
@@ -99,7 +117,7 @@ var _ = Describe("given RoleManager", func() {
 			It("Should peek", func() {
 				roleManager.Peek = true
 				roleClient.CreateOrganizationRoleReturns(nil, nil)
-				err := roleManager.AssociateOrgAuditor("orgGUID", "org", "", "userName", "user-guid")
+				err := roleManager.AssociateOrgAuditor(context.Background(), "orgGUID", "org", "", "userName", "user-guid")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(roleClient.CreateOrganizationRoleCallCount()).To(Equal(0))
 			})
@@ -108,7 +126,7 @@ var _ = Describe("given RoleManager", func() {
 		Context("AssociateOrgBillingManager", func() {
 			It("Should succeed", func() {
 				roleClient.CreateOrganizationRoleReturns(nil, nil)
-				err := roleManager.AssociateOrgBillingManager("orgGUID", "org", "", "userName", "user-guid")
+				err := roleManager.AssociateOrgBillingManager(context.Background(), "orgGUID", "org", "", "userName", "user-guid")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(roleClient.CreateOrganizationRoleCallCount()).To(Equal(2))
 				_, orgGUID, userGUID, role := roleClient.CreateOrganizationRoleArgsForCall(0)
@@ -124,7 +142,7 @@ var _ = Describe("given RoleManager", func() {
 
 			It("Should fail", func() {
 				roleClient.CreateOrganizationRoleReturns(nil, errors.New("error"))
-				err := roleManager.AssociateOrgBillingManager("orgGUID", "org", "", "userName", "user-guid")
+				err := roleManager.AssociateOrgBillingManager(context.Background(), "orgGUID", "org", "", "userName", "user-guid")
 				Expect(err).To(HaveOccurred())
 				Expect(roleClient.CreateOrganizationRoleCallCount()).To(Equal(1))
 
@@ -137,7 +155,7 @@ var _ = Describe("given RoleManager", func() {
 			It("Should peek", func() {
 				roleManager.Peek = true
 				roleClient.CreateOrganizationRoleReturns(nil, nil)
-				err := roleManager.AssociateOrgBillingManager("orgGUID", "org", "", "userName", "user-guid")
+				err := roleManager.AssociateOrgBillingManager(context.Background(), "orgGUID", "org", "", "userName", "user-guid")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(roleClient.CreateOrganizationRoleCallCount()).To(Equal(0))
 			})
@@ -146,7 +164,7 @@ var _ = Describe("given RoleManager", func() {
 		Context("AssociateOrgManager", func() {
 			It("Should succeed", func() {
 				roleClient.CreateOrganizationRoleReturns(nil, nil)
-				err := roleManager.AssociateOrgManager("orgGUID", "org", "", "userName", "user-guid")
+				err := roleManager.AssociateOrgManager(context.Background(), "orgGUID", "org", "", "userName", "user-guid")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(roleClient.CreateOrganizationRoleCallCount()).To(Equal(2))
 				_, orgGUID, userGUID, role := roleClient.CreateOrganizationRoleArgsForCall(0)
@@ -162,7 +180,7 @@ var _ = Describe("given RoleManager", func() {
 
 			It("Should fail", func() {
 				roleClient.CreateOrganizationRoleReturns(nil, errors.New("error"))
-				err := roleManager.AssociateOrgManager("orgGUID", "org", "", "userName", "user-guid")
+				err := roleManager.AssociateOrgManager(context.Background(), "orgGUID", "org", "", "userName", "user-guid")
 				Expect(err).To(HaveOccurred())
 				Expect(roleClient.CreateOrganizationRoleCallCount()).To(Equal(1))
 
@@ -175,7 +193,7 @@ var _ = Describe("given RoleManager", func() {
 			It("Should peek", func() {
 				roleManager.Peek = true
 				roleClient.CreateOrganizationRoleReturns(nil, nil)
-				err := roleManager.AssociateOrgManager("orgGUID", "org", "", "userName", "user-guid")
+				err := roleManager.AssociateOrgManager(context.Background(), "orgGUID", "org", "", "userName", "user-guid")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(roleClient.CreateOrganizationRoleCallCount()).To(Equal(0))
 			})
@@ -219,13 +237,13 @@ var _ = Describe("given RoleManager", func() {
 						},
 					},
 				}, nil)
-				err := roleManager.InitializeOrgUserRolesMap()
+				err := roleManager.InitializeOrgUserRolesMap(context.Background())
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(roleClient.ListAllCallCount()).To(Equal(4))
 			})
 			Context("RemoveOrgAuditor", func() {
 				It("should succeed", func() {
-					err := roleManager.RemoveOrgAuditor("orgName", "test-org-guid", "test", "test-user-guid")
+					err := roleManager.RemoveOrgAuditor(context.Background(), "orgName", "test-org-guid", "test", "test-user-guid")
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(roleClient.DeleteCallCount()).To(Equal(1))
 					_, roleGUID := roleClient.DeleteArgsForCall(0)
@@ -234,14 +252,14 @@ var _ = Describe("given RoleManager", func() {
 
 				It("should peek", func() {
 					roleManager.Peek = true
-					err := roleManager.RemoveOrgAuditor("orgName", "test-org-guid", "test", "test-user-guid")
+					err := roleManager.RemoveOrgAuditor(context.Background(), "orgName", "test-org-guid", "test", "test-user-guid")
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(roleClient.DeleteCallCount()).To(Equal(0))
 				})
 
 				It("should error", func() {
 					roleClient.DeleteReturns("", errors.New("error"))
-					err := roleManager.RemoveOrgAuditor("orgName", "test-org-guid", "test", "test-user-guid")
+					err := roleManager.RemoveOrgAuditor(context.Background(), "orgName", "test-org-guid", "test", "test-user-guid")
 					Expect(err).Should(HaveOccurred())
 					Expect(roleClient.DeleteCallCount()).To(Equal(1))
 					_, roleGUID := roleClient.DeleteArgsForCall(0)
@@ -251,7 +269,7 @@ var _ = Describe("given RoleManager", func() {
 
 			Context("RemoveOrgBillingManager", func() {
 				It("should succeed", func() {
-					err := roleManager.RemoveOrgBillingManager("orgName", "test-org-guid", "test", "test-user-guid")
+					err := roleManager.RemoveOrgBillingManager(context.Background(), "orgName", "test-org-guid", "test", "test-user-guid")
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(roleClient.DeleteCallCount()).To(Equal(1))
 					_, roleGUID := roleClient.DeleteArgsForCall(0)
@@ -260,14 +278,14 @@ var _ = Describe("given RoleManager", func() {
 
 				It("should peek", func() {
 					roleManager.Peek = true
-					err := roleManager.RemoveOrgBillingManager("orgName", "test-org-guid", "test", "test-user-guid")
+					err := roleManager.RemoveOrgBillingManager(context.Background(), "orgName", "test-org-guid", "test", "test-user-guid")
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(roleClient.DeleteCallCount()).To(Equal(0))
 				})
 
 				It("should error", func() {
 					roleClient.DeleteReturns("", errors.New("error"))
-					err := roleManager.RemoveOrgBillingManager("orgName", "test-org-guid", "test", "test-user-guid")
+					err := roleManager.RemoveOrgBillingManager(context.Background(), "orgName", "test-org-guid", "test", "test-user-guid")
 					Expect(err).Should(HaveOccurred())
 					Expect(roleClient.DeleteCallCount()).To(Equal(1))
 					_, roleGUID := roleClient.DeleteArgsForCall(0)
@@ -278,7 +296,7 @@ var _ = Describe("given RoleManager", func() {
 			Context("RemoveOrgManager", func() {
 				It("should succeed", func() {
 					roleClient.ListAllReturns([]*resource.Role{}, nil)
-					err := roleManager.RemoveOrgManager("orgName", "test-org-guid", "test", "test-user-guid")
+					err := roleManager.RemoveOrgManager(context.Background(), "orgName", "test-org-guid", "test", "test-user-guid")
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(roleClient.DeleteCallCount()).To(Equal(1))
 					_, roleGUID := roleClient.DeleteArgsForCall(0)
@@ -287,14 +305,14 @@ var _ = Describe("given RoleManager", func() {
 
 				It("should peek", func() {
 					roleManager.Peek = true
-					err := roleManager.RemoveOrgManager("orgName", "test-org-guid", "test", "test-user-guid")
+					err := roleManager.RemoveOrgManager(context.Background(), "orgName", "test-org-guid", "test", "test-user-guid")
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(roleClient.DeleteCallCount()).To(Equal(0))
 				})
 
 				It("should error", func() {
 					roleClient.DeleteReturns("", errors.New("error"))
-					err := roleManager.RemoveOrgManager("orgName", "test-org-guid", "test", "test-user-guid")
+					err := roleManager.RemoveOrgManager(context.Background(), "orgName", "test-org-guid", "test", "test-user-guid")
 					Expect(err).Should(HaveOccurred())
 					Expect(roleClient.DeleteCallCount()).To(Equal(1))
 					_, roleGUID := roleClient.DeleteArgsForCall(0)
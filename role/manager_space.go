@@ -5,10 +5,13 @@ import (
 	"fmt"
 
 	"github.com/cloudfoundry-community/go-cfclient/v3/resource"
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
+	"github.com/vmwarepivotallabs/cf-mgmt/util"
 	"github.com/xchapter7x/lo"
 )
 
-func (m *DefaultManager) RemoveSpaceAuditor(spaceName, spaceGUID, userName, userGUID string) error {
+func (m *DefaultManager) RemoveSpaceAuditor(ctx context.Context, spaceName, spaceGUID, userName, userGUID string) error {
+	change := m.recordSpaceRole(changes.Unassign, spaceName, userName, "auditor")
 	if m.Peek {
 		lo.G.Infof("[dry-run]: removing user %s from org/space %s with role %s", userName, spaceName, "Auditor")
 		return nil
@@ -18,9 +21,12 @@ func (m *DefaultManager) RemoveSpaceAuditor(spaceName, spaceGUID, userName, user
 	if err != nil {
 		return err
 	}
-	return m.deleteRole(roleGUID)
+	err = m.deleteRole(ctx, roleGUID)
+	m.Recorder.Applied(change, userGUID, err)
+	return err
 }
-func (m *DefaultManager) RemoveSpaceDeveloper(spaceName, spaceGUID, userName, userGUID string) error {
+func (m *DefaultManager) RemoveSpaceDeveloper(ctx context.Context, spaceName, spaceGUID, userName, userGUID string) error {
+	change := m.recordSpaceRole(changes.Unassign, spaceName, userName, "developer")
 	if m.Peek {
 		lo.G.Infof("[dry-run]: removing user %s from org/space %s with role %s", userName, spaceName, "Developer")
 		return nil
@@ -30,9 +36,12 @@ func (m *DefaultManager) RemoveSpaceDeveloper(spaceName, spaceGUID, userName, us
 	if err != nil {
 		return err
 	}
-	return m.deleteRole(roleGUID)
+	err = m.deleteRole(ctx, roleGUID)
+	m.Recorder.Applied(change, userGUID, err)
+	return err
 }
-func (m *DefaultManager) RemoveSpaceManager(spaceName, spaceGUID, userName, userGUID string) error {
+func (m *DefaultManager) RemoveSpaceManager(ctx context.Context, spaceName, spaceGUID, userName, userGUID string) error {
+	change := m.recordSpaceRole(changes.Unassign, spaceName, userName, "manager")
 	if m.Peek {
 		lo.G.Infof("[dry-run]: removing user %s from org/space %s with role %s", userName, spaceName, "Manager")
 		return nil
@@ -42,9 +51,12 @@ func (m *DefaultManager) RemoveSpaceManager(spaceName, spaceGUID, userName, user
 	if err != nil {
 		return err
 	}
-	return m.deleteRole(roleGUID)
+	err = m.deleteRole(ctx, roleGUID)
+	m.Recorder.Applied(change, userGUID, err)
+	return err
 }
-func (m *DefaultManager) RemoveSpaceSupporter(spaceName, spaceGUID, userName, userGUID string) error {
+func (m *DefaultManager) RemoveSpaceSupporter(ctx context.Context, spaceName, spaceGUID, userName, userGUID string) error {
+	change := m.recordSpaceRole(changes.Unassign, spaceName, userName, "supporter")
 	if m.Peek {
 		lo.G.Infof("[dry-run]: removing user %s from org/space %s with role %s", userName, spaceName, "Supporter")
 		return nil
@@ -54,67 +66,83 @@ func (m *DefaultManager) RemoveSpaceSupporter(spaceName, spaceGUID, userName, us
 	if err != nil {
 		return err
 	}
-	return m.deleteRole(roleGUID)
+	err = m.deleteRole(ctx, roleGUID)
+	m.Recorder.Applied(change, userGUID, err)
+	return err
 }
 
-func (m *DefaultManager) AssociateSpaceAuditor(orgGUID, spaceName, spaceGUID, userName, userGUID string) error {
-	err := m.AddUserToOrg(orgGUID, userName, userGUID)
+func (m *DefaultManager) AssociateSpaceAuditor(ctx context.Context, orgGUID, spaceName, spaceGUID, userName, userGUID string) error {
+	orgName, _ := changes.SplitEntityName(spaceName)
+	err := m.AddUserToOrg(ctx, orgGUID, orgName, userName, userGUID)
 	if err != nil {
 		return err
 	}
+	change := m.recordSpaceRole(changes.Assign, spaceName, userName, "auditor")
 	if m.Peek {
 		lo.G.Infof("[dry-run]: adding %s to role %s for org/space %s", userName, "auditor", spaceName)
 		return nil
 	}
 
 	lo.G.Infof("adding %s to role %s for org/space %s", userName, "auditor", spaceName)
-	_, err = m.RoleClient.CreateSpaceRole(context.Background(), spaceGUID, userGUID, resource.SpaceRoleAuditor)
+	_, err = m.RoleClient.CreateSpaceRole(util.CallContext(ctx), spaceGUID, userGUID, resource.SpaceRoleAuditor)
+	m.Recorder.Applied(change, userGUID, err)
 	return err
 }
-func (m *DefaultManager) AssociateSpaceManager(orgGUID, spaceName, spaceGUID, userName, userGUID string) error {
-	err := m.AddUserToOrg(orgGUID, userName, userGUID)
+func (m *DefaultManager) AssociateSpaceManager(ctx context.Context, orgGUID, spaceName, spaceGUID, userName, userGUID string) error {
+	orgName, _ := changes.SplitEntityName(spaceName)
+	err := m.AddUserToOrg(ctx, orgGUID, orgName, userName, userGUID)
 	if err != nil {
 		return err
 	}
+	change := m.recordSpaceRole(changes.Assign, spaceName, userName, "manager")
 	if m.Peek {
 		lo.G.Infof("[dry-run]: adding %s to role %s for org/space %s", userName, "manager", spaceName)
 		return nil
 	}
 
 	lo.G.Infof("adding %s to role %s for org/space %s", userName, "manager", spaceName)
-	_, err = m.RoleClient.CreateSpaceRole(context.Background(), spaceGUID, userGUID, resource.SpaceRoleManager)
+	_, err = m.RoleClient.CreateSpaceRole(util.CallContext(ctx), spaceGUID, userGUID, resource.SpaceRoleManager)
+	m.Recorder.Applied(change, userGUID, err)
 	return err
 }
-func (m *DefaultManager) AssociateSpaceDeveloper(orgGUID, spaceName, spaceGUID, userName, userGUID string) error {
-	err := m.AddUserToOrg(orgGUID, userName, userGUID)
+func (m *DefaultManager) AssociateSpaceDeveloper(ctx context.Context, orgGUID, spaceName, spaceGUID, userName, userGUID string) error {
+	orgName, _ := changes.SplitEntityName(spaceName)
+	err := m.AddUserToOrg(ctx, orgGUID, orgName, userName, userGUID)
 	if err != nil {
 		return err
 	}
+	change := m.recordSpaceRole(changes.Assign, spaceName, userName, "developer")
 	if m.Peek {
 		lo.G.Infof("[dry-run]: adding %s to role %s for org/space %s", userName, "developer", spaceName)
 		return nil
 	}
 
 	lo.G.Infof("adding %s to role %s for org/space %s", userName, "developer", spaceName)
-	_, err = m.RoleClient.CreateSpaceRole(context.Background(), spaceGUID, userGUID, resource.SpaceRoleDeveloper)
+	_, err = m.RoleClient.CreateSpaceRole(util.CallContext(ctx), spaceGUID, userGUID, resource.SpaceRoleDeveloper)
+	m.Recorder.Applied(change, userGUID, err)
 	return err
 }
-func (m *DefaultManager) AssociateSpaceSupporter(orgGUID, spaceName, spaceGUID, userName, userGUID string) error {
-	err := m.AddUserToOrg(orgGUID, userName, userGUID)
+func (m *DefaultManager) AssociateSpaceSupporter(ctx context.Context, orgGUID, spaceName, spaceGUID, userName, userGUID string) error {
+	orgName, _ := changes.SplitEntityName(spaceName)
+	err := m.AddUserToOrg(ctx, orgGUID, orgName, userName, userGUID)
 	if err != nil {
 		return err
 	}
+	change := m.recordSpaceRole(changes.Assign, spaceName, userName, "supporter")
 	if m.Peek {
 		lo.G.Infof("[dry-run]: adding %s to role %s for org/space %s", userName, "supporter", spaceName)
 		return nil
 	}
 
 	lo.G.Infof("adding %s to role %s for org/space %s", userName, "supporter", spaceName)
-	_, err = m.RoleClient.CreateSpaceRole(context.Background(), spaceGUID, userGUID, resource.SpaceRoleSupporter)
+	_, err = m.RoleClient.CreateSpaceRole(util.CallContext(ctx), spaceGUID, userGUID, resource.SpaceRoleSupporter)
+	m.Recorder.Applied(change, userGUID, err)
 	return err
 }
 
 func (m *DefaultManager) GetSpaceRoleGUID(spaceGUID, userGUID, role string) (string, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 	spaces, ok := m.SpaceRolesUsers[spaceGUID]
 	if !ok {
 		return "", fmt.Errorf("space with guid[%s] has no roles", spaceGUID)
@@ -130,8 +158,8 @@ func (m *DefaultManager) GetSpaceRoleGUID(spaceGUID, userGUID, role string) (str
 	return roleGUID, nil
 }
 
-// func (m *DefaultManager) AssociateSpaceAuditor(input UsersInput, userName, userGUID string) error {
-// 	err := m.RoleMgr.AddUserToOrg(input.OrgGUID, userName, userGUID)
+// func (m *DefaultManager) AssociateSpaceAuditor(ctx context.Context, input UsersInput, userName, userGUID string) error {
+// 	err := m.RoleMgr.AddUserToOrg(ctx, input.OrgGUID, userName, userGUID)
 // 	if err != nil {
 // 		return err
 // 	}
@@ -144,8 +172,8 @@ func (m *DefaultManager) GetSpaceRoleGUID(spaceGUID, userGUID, role string) (str
 // 	_, err = m.Client.CreateV3SpaceRole(input.SpaceGUID, userGUID, SPACE_AUDITOR)
 // 	return err
 // }
-// func (m *DefaultManager) AssociateSpaceDeveloper(input UsersInput, userName, userGUID string) error {
-// 	err := m.RoleMgr.AddUserToOrg(input.OrgGUID, userName, userGUID)
+// func (m *DefaultManager) AssociateSpaceDeveloper(ctx context.Context, input UsersInput, userName, userGUID string) error {
+// 	err := m.RoleMgr.AddUserToOrg(ctx, input.OrgGUID, userName, userGUID)
 // 	if err != nil {
 // 		return err
 // 	}
@@ -157,8 +185,8 @@ func (m *DefaultManager) GetSpaceRoleGUID(spaceGUID, userGUID, role string) (str
 // 	_, err = m.Client.CreateV3SpaceRole(input.SpaceGUID, userGUID, SPACE_DEVELOPER)
 // 	return err
 // }
-// func (m *DefaultManager) AssociateSpaceManager(input UsersInput, userName, userGUID string) error {
-// 	err := m.RoleMgr.AddUserToOrg(input.OrgGUID, userName, userGUID)
+// func (m *DefaultManager) AssociateSpaceManager(ctx context.Context, input UsersInput, userName, userGUID string) error {
+// 	err := m.RoleMgr.AddUserToOrg(ctx, input.OrgGUID, userName, userGUID)
 // 	if err != nil {
 // 		return err
 // 	}
@@ -172,13 +200,13 @@ func (m *DefaultManager) GetSpaceRoleGUID(spaceGUID, userGUID, role string) (str
 // 	return err
 // }
 
-// func (m *DefaultManager) AssociateSpaceSupporter(input UsersInput, userName, userGUID string) error {
+// func (m *DefaultManager) AssociateSpaceSupporter(ctx context.Context, input UsersInput, userName, userGUID string) error {
 
 // 	if !m.SupportsSpaceSupporter {
 // 		lo.G.Infof("this instance of cloud foundry does not support space_supporter role")
 // 		return nil
 // 	}
-// 	err := m.RoleMgr.AddUserToOrg(input.OrgGUID, userName, userGUID)
+// 	err := m.RoleMgr.AddUserToOrg(ctx, input.OrgGUID, userName, userGUID)
 // 	if err != nil {
 // 		return err
 // 	}
@@ -1,6 +1,8 @@
 package role_test
 
 import (
+	"context"
+
 	"errors"
 
 	"github.com/cloudfoundry-community/go-cfclient/v3/resource"
@@ -80,13 +82,13 @@ var _ = Describe("given RoleManager", func() {
 						},
 					},
 				}, nil)
-				err := roleManager.InitializeSpaceUserRolesMap()
+				err := roleManager.InitializeSpaceUserRolesMap(context.Background())
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(roleClient.ListAllCallCount()).To(Equal(4))
 			})
 			Context("RemoveSpaceAuditor", func() {
 				It("should succeed", func() {
-					err := roleManager.RemoveSpaceAuditor("orgName/spaceName", "test-space-guid", "test", "test-user-guid")
+					err := roleManager.RemoveSpaceAuditor(context.Background(), "orgName/spaceName", "test-space-guid", "test", "test-user-guid")
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(roleClient.DeleteCallCount()).To(Equal(1))
 					_, roleGUID := roleClient.DeleteArgsForCall(0)
@@ -95,14 +97,14 @@ var _ = Describe("given RoleManager", func() {
 
 				It("should peek", func() {
 					roleManager.Peek = true
-					err := roleManager.RemoveSpaceAuditor("orgName/spaceName", "test-space-guid", "test", "test-user-guid")
+					err := roleManager.RemoveSpaceAuditor(context.Background(), "orgName/spaceName", "test-space-guid", "test", "test-user-guid")
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(roleClient.DeleteCallCount()).To(Equal(0))
 				})
 
 				It("should error", func() {
 					roleClient.DeleteReturns("", errors.New("error"))
-					err := roleManager.RemoveSpaceAuditor("orgName/spaceName", "test-space-guid", "test", "test-user-guid")
+					err := roleManager.RemoveSpaceAuditor(context.Background(), "orgName/spaceName", "test-space-guid", "test", "test-user-guid")
 					Expect(err).Should(HaveOccurred())
 					Expect(roleClient.DeleteCallCount()).To(Equal(1))
 					_, roleGUID := roleClient.DeleteArgsForCall(0)
@@ -112,7 +114,7 @@ var _ = Describe("given RoleManager", func() {
 
 			Context("RemoveSpaceManager", func() {
 				It("should succeed", func() {
-					err := roleManager.RemoveSpaceManager("orgName/spaceName", "test-space-guid", "test", "test-user-guid")
+					err := roleManager.RemoveSpaceManager(context.Background(), "orgName/spaceName", "test-space-guid", "test", "test-user-guid")
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(roleClient.DeleteCallCount()).To(Equal(1))
 					_, roleGUID := roleClient.DeleteArgsForCall(0)
@@ -121,14 +123,14 @@ var _ = Describe("given RoleManager", func() {
 
 				It("should peek", func() {
 					roleManager.Peek = true
-					err := roleManager.RemoveSpaceManager("orgName/spaceName", "test-space-guid", "test", "test-user-guid")
+					err := roleManager.RemoveSpaceManager(context.Background(), "orgName/spaceName", "test-space-guid", "test", "test-user-guid")
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(roleClient.DeleteCallCount()).To(Equal(0))
 				})
 
 				It("should error", func() {
 					roleClient.DeleteReturns("", errors.New("error"))
-					err := roleManager.RemoveSpaceManager("orgName/spaceName", "test-space-guid", "test", "test-user-guid")
+					err := roleManager.RemoveSpaceManager(context.Background(), "orgName/spaceName", "test-space-guid", "test", "test-user-guid")
 					Expect(err).Should(HaveOccurred())
 					Expect(roleClient.DeleteCallCount()).To(Equal(1))
 					_, roleGUID := roleClient.DeleteArgsForCall(0)
@@ -138,7 +140,7 @@ var _ = Describe("given RoleManager", func() {
 
 			Context("RemoveSpaceDeveloper", func() {
 				It("should succeed", func() {
-					err := roleManager.RemoveSpaceDeveloper("orgName/spaceName", "test-space-guid", "test", "test-user-guid")
+					err := roleManager.RemoveSpaceDeveloper(context.Background(), "orgName/spaceName", "test-space-guid", "test", "test-user-guid")
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(roleClient.DeleteCallCount()).To(Equal(1))
 					_, roleGUID := roleClient.DeleteArgsForCall(0)
@@ -147,14 +149,14 @@ var _ = Describe("given RoleManager", func() {
 
 				It("should peek", func() {
 					roleManager.Peek = true
-					err := roleManager.RemoveSpaceDeveloper("orgName/spaceName", "test-space-guid", "test", "test-user-guid")
+					err := roleManager.RemoveSpaceDeveloper(context.Background(), "orgName/spaceName", "test-space-guid", "test", "test-user-guid")
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(roleClient.DeleteCallCount()).To(Equal(0))
 				})
 
 				It("should error", func() {
 					roleClient.DeleteReturns("", errors.New("error"))
-					err := roleManager.RemoveSpaceDeveloper("orgName/spaceName", "test-space-guid", "test", "test-user-guid")
+					err := roleManager.RemoveSpaceDeveloper(context.Background(), "orgName/spaceName", "test-space-guid", "test", "test-user-guid")
 					Expect(err).Should(HaveOccurred())
 					Expect(roleClient.DeleteCallCount()).To(Equal(1))
 					_, roleGUID := roleClient.DeleteArgsForCall(0)
@@ -164,7 +166,7 @@ var _ = Describe("given RoleManager", func() {
 
 			Context("RemoveSpaceSupporter", func() {
 				It("should succeed", func() {
-					err := roleManager.RemoveSpaceSupporter("orgName/spaceName", "test-space-guid", "test", "test-user-guid")
+					err := roleManager.RemoveSpaceSupporter(context.Background(), "orgName/spaceName", "test-space-guid", "test", "test-user-guid")
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(roleClient.DeleteCallCount()).To(Equal(1))
 					_, roleGUID := roleClient.DeleteArgsForCall(0)
@@ -173,14 +175,14 @@ var _ = Describe("given RoleManager", func() {
 
 				It("should peek", func() {
 					roleManager.Peek = true
-					err := roleManager.RemoveSpaceSupporter("orgName/spaceName", "test-space-guid", "test", "test-user-guid")
+					err := roleManager.RemoveSpaceSupporter(context.Background(), "orgName/spaceName", "test-space-guid", "test", "test-user-guid")
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(roleClient.DeleteCallCount()).To(Equal(0))
 				})
 
 				It("should error", func() {
 					roleClient.DeleteReturns("", errors.New("error"))
-					err := roleManager.RemoveSpaceSupporter("orgName/spaceName", "test-space-guid", "test", "test-user-guid")
+					err := roleManager.RemoveSpaceSupporter(context.Background(), "orgName/spaceName", "test-space-guid", "test", "test-user-guid")
 					Expect(err).Should(HaveOccurred())
 					Expect(roleClient.DeleteCallCount()).To(Equal(1))
 					_, roleGUID := roleClient.DeleteArgsForCall(0)
@@ -193,7 +195,7 @@ var _ = Describe("given RoleManager", func() {
 			Context("SpaceAuditor", func() {
 				It("Should succeed on AssociateSpaceAuditor", func() {
 					roleClient.CreateSpaceRoleReturns(nil, nil)
-					err := roleManager.AssociateSpaceAuditor("orgGUID", "spaceName", "spaceGUID", "userName", "user-guid")
+					err := roleManager.AssociateSpaceAuditor(context.Background(), "orgGUID", "spaceName", "spaceGUID", "userName", "user-guid")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(roleClient.CreateSpaceRoleCallCount()).To(Equal(1))
 					Expect(roleClient.CreateOrganizationRoleCallCount()).To(Equal(1))
@@ -210,14 +212,14 @@ var _ = Describe("given RoleManager", func() {
 				It("Should peek", func() {
 					roleManager.Peek = true
 					roleClient.CreateSpaceRoleReturns(nil, nil)
-					err := roleManager.AssociateSpaceAuditor("orgGUID", "spaceName", "spaceGUID", "userName", "user-guid")
+					err := roleManager.AssociateSpaceAuditor(context.Background(), "orgGUID", "spaceName", "spaceGUID", "userName", "user-guid")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(roleClient.CreateSpaceRoleCallCount()).To(Equal(0))
 					Expect(roleClient.CreateOrganizationRoleCallCount()).To(Equal(0))
 				})
 				It("Should fail", func() {
 					roleClient.CreateSpaceRoleReturns(nil, errors.New("error"))
-					err := roleManager.AssociateSpaceAuditor("orgGUID", "spaceName", "spaceGUID", "userName", "user-guid")
+					err := roleManager.AssociateSpaceAuditor(context.Background(), "orgGUID", "spaceName", "spaceGUID", "userName", "user-guid")
 					Expect(err).Should(HaveOccurred())
 					Expect(roleClient.CreateSpaceRoleCallCount()).To(Equal(1))
 					Expect(roleClient.CreateOrganizationRoleCallCount()).To(Equal(1))
@@ -236,7 +238,7 @@ var _ = Describe("given RoleManager", func() {
 			Context("SpaceManager", func() {
 				It("Should succeed on AssociateSpaceManager", func() {
 					roleClient.CreateSpaceRoleReturns(nil, nil)
-					err := roleManager.AssociateSpaceManager("orgGUID", "spaceName", "spaceGUID", "userName", "user-guid")
+					err := roleManager.AssociateSpaceManager(context.Background(), "orgGUID", "spaceName", "spaceGUID", "userName", "user-guid")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(roleClient.CreateSpaceRoleCallCount()).To(Equal(1))
 					Expect(roleClient.CreateOrganizationRoleCallCount()).To(Equal(1))
@@ -253,14 +255,14 @@ var _ = Describe("given RoleManager", func() {
 				It("Should peek", func() {
 					roleManager.Peek = true
 					roleClient.CreateSpaceRoleReturns(nil, nil)
-					err := roleManager.AssociateSpaceAuditor("orgGUID", "spaceName", "spaceGUID", "userName", "user-guid")
+					err := roleManager.AssociateSpaceAuditor(context.Background(), "orgGUID", "spaceName", "spaceGUID", "userName", "user-guid")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(roleClient.CreateSpaceRoleCallCount()).To(Equal(0))
 					Expect(roleClient.CreateOrganizationRoleCallCount()).To(Equal(0))
 				})
 				It("Should fail", func() {
 					roleClient.CreateSpaceRoleReturns(nil, errors.New("error"))
-					err := roleManager.AssociateSpaceManager("orgGUID", "spaceName", "spaceGUID", "userName", "user-guid")
+					err := roleManager.AssociateSpaceManager(context.Background(), "orgGUID", "spaceName", "spaceGUID", "userName", "user-guid")
 					Expect(err).Should(HaveOccurred())
 					Expect(roleClient.CreateSpaceRoleCallCount()).To(Equal(1))
 					Expect(roleClient.CreateOrganizationRoleCallCount()).To(Equal(1))
@@ -279,7 +281,7 @@ var _ = Describe("given RoleManager", func() {
 			Context("SpaceDeveloper", func() {
 				It("Should succeed on AssociateSpaceDeveloper", func() {
 					roleClient.CreateSpaceRoleReturns(nil, nil)
-					err := roleManager.AssociateSpaceDeveloper("orgGUID", "spaceName", "spaceGUID", "userName", "user-guid")
+					err := roleManager.AssociateSpaceDeveloper(context.Background(), "orgGUID", "spaceName", "spaceGUID", "userName", "user-guid")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(roleClient.CreateSpaceRoleCallCount()).To(Equal(1))
 					Expect(roleClient.CreateOrganizationRoleCallCount()).To(Equal(1))
@@ -296,14 +298,14 @@ var _ = Describe("given RoleManager", func() {
 				It("Should peek", func() {
 					roleManager.Peek = true
 					roleClient.CreateSpaceRoleReturns(nil, nil)
-					err := roleManager.AssociateSpaceDeveloper("orgGUID", "spaceName", "spaceGUID", "userName", "user-guid")
+					err := roleManager.AssociateSpaceDeveloper(context.Background(), "orgGUID", "spaceName", "spaceGUID", "userName", "user-guid")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(roleClient.CreateSpaceRoleCallCount()).To(Equal(0))
 					Expect(roleClient.CreateOrganizationRoleCallCount()).To(Equal(0))
 				})
 				It("Should fail", func() {
 					roleClient.CreateSpaceRoleReturns(nil, errors.New("error"))
-					err := roleManager.AssociateSpaceDeveloper("orgGUID", "spaceName", "spaceGUID", "userName", "user-guid")
+					err := roleManager.AssociateSpaceDeveloper(context.Background(), "orgGUID", "spaceName", "spaceGUID", "userName", "user-guid")
 					Expect(err).Should(HaveOccurred())
 					Expect(roleClient.CreateSpaceRoleCallCount()).To(Equal(1))
 					Expect(roleClient.CreateOrganizationRoleCallCount()).To(Equal(1))
@@ -322,7 +324,7 @@ var _ = Describe("given RoleManager", func() {
 			Context("SpaceSupporter", func() {
 				It("Should succeed on AssociateSpaceSupporter", func() {
 					roleClient.CreateSpaceRoleReturns(nil, nil)
-					err := roleManager.AssociateSpaceSupporter("orgGUID", "spaceName", "spaceGUID", "userName", "user-guid")
+					err := roleManager.AssociateSpaceSupporter(context.Background(), "orgGUID", "spaceName", "spaceGUID", "userName", "user-guid")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(roleClient.CreateSpaceRoleCallCount()).To(Equal(1))
 					Expect(roleClient.CreateOrganizationRoleCallCount()).To(Equal(1))
@@ -339,14 +341,14 @@ var _ = Describe("given RoleManager", func() {
 				It("Should peek", func() {
 					roleManager.Peek = true
 					roleClient.CreateSpaceRoleReturns(nil, nil)
-					err := roleManager.AssociateSpaceSupporter("orgGUID", "spaceName", "spaceGUID", "userName", "user-guid")
+					err := roleManager.AssociateSpaceSupporter(context.Background(), "orgGUID", "spaceName", "spaceGUID", "userName", "user-guid")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(roleClient.CreateSpaceRoleCallCount()).To(Equal(0))
 					Expect(roleClient.CreateOrganizationRoleCallCount()).To(Equal(0))
 				})
 				It("Should fail", func() {
 					roleClient.CreateSpaceRoleReturns(nil, errors.New("error"))
-					err := roleManager.AssociateSpaceSupporter("orgGUID", "spaceName", "spaceGUID", "userName", "user-guid")
+					err := roleManager.AssociateSpaceSupporter(context.Background(), "orgGUID", "spaceName", "spaceGUID", "userName", "user-guid")
 					Expect(err).Should(HaveOccurred())
 					Expect(roleClient.CreateSpaceRoleCallCount()).To(Equal(1))
 					Expect(roleClient.CreateOrganizationRoleCallCount()).To(Equal(1))
@@ -1,6 +1,8 @@
 package role_test
 
 import (
+	"context"
+
 	"github.com/cloudfoundry-community/go-cfclient/v3/resource"
 	uaaclient "github.com/cloudfoundry-community/go-uaa"
 	. "github.com/onsi/ginkgo/v2"
@@ -35,7 +37,7 @@ var _ = Describe("given RoleManager", func() {
 				roleClient.ListAllReturns([]*resource.Role{
 					{GUID: "role-guid"},
 				}, nil)
-				results, err := roleManager.ListSpaceRoles()
+				results, err := roleManager.ListSpaceRoles(context.Background())
 				Expect(err).NotTo(HaveOccurred())
 				Expect(len(results)).To(Equal(4))
 			})
@@ -50,7 +52,7 @@ var _ = Describe("given RoleManager", func() {
 						Type: "space-manager",
 					},
 				}, nil)
-				results, err := roleManager.ListSpaceRoles()
+				results, err := roleManager.ListSpaceRoles(context.Background())
 				Expect(err).To(HaveOccurred())
 				Expect(results).To(BeNil())
 			})
@@ -61,7 +63,7 @@ var _ = Describe("given RoleManager", func() {
 				roleClient.ListAllReturns([]*resource.Role{
 					{GUID: "role-guid"},
 				}, nil)
-				results, err := roleManager.ListOrgRoles()
+				results, err := roleManager.ListOrgRoles(context.Background())
 				Expect(err).NotTo(HaveOccurred())
 				Expect(len(results)).To(Equal(4))
 			})
@@ -19,29 +19,29 @@ type RoleUser struct {
 
 type Manager interface {
 	ClearRoles()
-	DeleteUser(userGuid string) error
-	InitializeSpaceUserRolesMap() error
-	ListSpaceUsersByRole(spaceGUID string) (*RoleUsers, *RoleUsers, *RoleUsers, *RoleUsers, error)
-	InitializeOrgUserRolesMap() error
-	ListOrgUsersByRole(orgGUID string) (*RoleUsers, *RoleUsers, *RoleUsers, *RoleUsers, error)
-	AssociateOrgAuditor(orgGUID, orgName, entityGUID, userName, userGUID string) error
-	AssociateOrgManager(orgGUID, orgName, entityGUID, userName, userGUID string) error
-	AssociateOrgBillingManager(orgGUID, orgName, entityGUID, userName, userGUID string) error
-
-	RemoveOrgManager(orgName, orgGUID, userName, userGUID string) error
-	RemoveOrgBillingManager(orgName, orgGUID, userName, userGUID string) error
-	RemoveOrgAuditor(orgName, orgGUID, userName, userGUID string) error
-	RemoveOrgUser(orgName, orgGUID, userName, userGUID string) error
-
-	AssociateSpaceAuditor(orgGUID, spaceName, spaceGUID, userName, userGUID string) error
-	AssociateSpaceManager(orgGUID, spaceName, spaceGUID, userName, userGUID string) error
-	AssociateSpaceDeveloper(orgGUID, spaceName, spaceGUID, userName, userGUID string) error
-	AssociateSpaceSupporter(orgGUID, spaceName, spaceGUID, userName, userGUID string) error
-
-	RemoveSpaceAuditor(spaceName, spaceGUID, userName, userGUID string) error
-	RemoveSpaceDeveloper(spaceName, spaceGUID, userName, userGUID string) error
-	RemoveSpaceManager(spaceName, spaceGUID, userName, userGUID string) error
-	RemoveSpaceSupporter(spaceName, spaceGUID, userName, userGUID string) error
+	DeleteUser(ctx context.Context, userGuid string) error
+	InitializeSpaceUserRolesMap(ctx context.Context) error
+	ListSpaceUsersByRole(ctx context.Context, spaceGUID string) (*RoleUsers, *RoleUsers, *RoleUsers, *RoleUsers, error)
+	InitializeOrgUserRolesMap(ctx context.Context) error
+	ListOrgUsersByRole(ctx context.Context, orgGUID string) (*RoleUsers, *RoleUsers, *RoleUsers, *RoleUsers, error)
+	AssociateOrgAuditor(ctx context.Context, orgGUID, orgName, entityGUID, userName, userGUID string) error
+	AssociateOrgManager(ctx context.Context, orgGUID, orgName, entityGUID, userName, userGUID string) error
+	AssociateOrgBillingManager(ctx context.Context, orgGUID, orgName, entityGUID, userName, userGUID string) error
+
+	RemoveOrgManager(ctx context.Context, orgName, orgGUID, userName, userGUID string) error
+	RemoveOrgBillingManager(ctx context.Context, orgName, orgGUID, userName, userGUID string) error
+	RemoveOrgAuditor(ctx context.Context, orgName, orgGUID, userName, userGUID string) error
+	RemoveOrgUser(ctx context.Context, orgName, orgGUID, userName, userGUID string) error
+
+	AssociateSpaceAuditor(ctx context.Context, orgGUID, spaceName, spaceGUID, userName, userGUID string) error
+	AssociateSpaceManager(ctx context.Context, orgGUID, spaceName, spaceGUID, userName, userGUID string) error
+	AssociateSpaceDeveloper(ctx context.Context, orgGUID, spaceName, spaceGUID, userName, userGUID string) error
+	AssociateSpaceSupporter(ctx context.Context, orgGUID, spaceName, spaceGUID, userName, userGUID string) error
+
+	RemoveSpaceAuditor(ctx context.Context, spaceName, spaceGUID, userName, userGUID string) error
+	RemoveSpaceDeveloper(ctx context.Context, spaceName, spaceGUID, userName, userGUID string) error
+	RemoveSpaceManager(ctx context.Context, spaceName, spaceGUID, userName, userGUID string) error
+	RemoveSpaceSupporter(ctx context.Context, spaceName, spaceGUID, userName, userGUID string) error
 }
 
 type CFRoleClient interface {
@@ -2,6 +2,7 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/cloudfoundry-community/go-cfclient/v3/resource"
@@ -9,9 +10,10 @@ import (
 )
 
 type FakeManager struct {
-	AssignDefaultSecurityGroupsStub        func() error
+	AssignDefaultSecurityGroupsStub        func(context.Context) error
 	assignDefaultSecurityGroupsMutex       sync.RWMutex
 	assignDefaultSecurityGroupsArgsForCall []struct {
+		arg1 context.Context
 	}
 	assignDefaultSecurityGroupsReturns struct {
 		result1 error
@@ -19,9 +21,10 @@ type FakeManager struct {
 	assignDefaultSecurityGroupsReturnsOnCall map[int]struct {
 		result1 error
 	}
-	CreateApplicationSecurityGroupsStub        func() error
+	CreateApplicationSecurityGroupsStub        func(context.Context) error
 	createApplicationSecurityGroupsMutex       sync.RWMutex
 	createApplicationSecurityGroupsArgsForCall []struct {
+		arg1 context.Context
 	}
 	createApplicationSecurityGroupsReturns struct {
 		result1 error
@@ -29,9 +32,10 @@ type FakeManager struct {
 	createApplicationSecurityGroupsReturnsOnCall map[int]struct {
 		result1 error
 	}
-	CreateGlobalSecurityGroupsStub        func() error
+	CreateGlobalSecurityGroupsStub        func(context.Context) error
 	createGlobalSecurityGroupsMutex       sync.RWMutex
 	createGlobalSecurityGroupsArgsForCall []struct {
+		arg1 context.Context
 	}
 	createGlobalSecurityGroupsReturns struct {
 		result1 error
@@ -39,10 +43,11 @@ type FakeManager struct {
 	createGlobalSecurityGroupsReturnsOnCall map[int]struct {
 		result1 error
 	}
-	GetSecurityGroupRulesStub        func(string) ([]byte, error)
+	GetSecurityGroupRulesStub        func(context.Context, string) ([]byte, error)
 	getSecurityGroupRulesMutex       sync.RWMutex
 	getSecurityGroupRulesArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	getSecurityGroupRulesReturns struct {
 		result1 []byte
@@ -52,9 +57,10 @@ type FakeManager struct {
 		result1 []byte
 		result2 error
 	}
-	ListDefaultSecurityGroupsStub        func() (map[string]*resource.SecurityGroup, error)
+	ListDefaultSecurityGroupsStub        func(context.Context) (map[string]*resource.SecurityGroup, error)
 	listDefaultSecurityGroupsMutex       sync.RWMutex
 	listDefaultSecurityGroupsArgsForCall []struct {
+		arg1 context.Context
 	}
 	listDefaultSecurityGroupsReturns struct {
 		result1 map[string]*resource.SecurityGroup
@@ -64,9 +70,10 @@ type FakeManager struct {
 		result1 map[string]*resource.SecurityGroup
 		result2 error
 	}
-	ListNonDefaultSecurityGroupsStub        func() (map[string]*resource.SecurityGroup, error)
+	ListNonDefaultSecurityGroupsStub        func(context.Context) (map[string]*resource.SecurityGroup, error)
 	listNonDefaultSecurityGroupsMutex       sync.RWMutex
 	listNonDefaultSecurityGroupsArgsForCall []struct {
+		arg1 context.Context
 	}
 	listNonDefaultSecurityGroupsReturns struct {
 		result1 map[string]*resource.SecurityGroup
@@ -76,10 +83,11 @@ type FakeManager struct {
 		result1 map[string]*resource.SecurityGroup
 		result2 error
 	}
-	ListSpaceSecurityGroupsStub        func(string) (map[string]string, error)
+	ListSpaceSecurityGroupsStub        func(context.Context, string) (map[string]string, error)
 	listSpaceSecurityGroupsMutex       sync.RWMutex
 	listSpaceSecurityGroupsArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	listSpaceSecurityGroupsReturns struct {
 		result1 map[string]string
@@ -93,20 +101,22 @@ type FakeManager struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeManager) AssignDefaultSecurityGroups() error {
+func (fake *FakeManager) AssignDefaultSecurityGroups(arg1 context.Context) error {
 	fake.assignDefaultSecurityGroupsMutex.Lock()
 	ret, specificReturn := fake.assignDefaultSecurityGroupsReturnsOnCall[len(fake.assignDefaultSecurityGroupsArgsForCall)]
 	fake.assignDefaultSecurityGroupsArgsForCall = append(fake.assignDefaultSecurityGroupsArgsForCall, struct {
-	}{})
-	fake.recordInvocation("AssignDefaultSecurityGroups", []interface{}{})
+		arg1 context.Context
+	}{arg1})
+	stub := fake.AssignDefaultSecurityGroupsStub
+	fakeReturns := fake.assignDefaultSecurityGroupsReturns
+	fake.recordInvocation("AssignDefaultSecurityGroups", []interface{}{arg1})
 	fake.assignDefaultSecurityGroupsMutex.Unlock()
-	if fake.AssignDefaultSecurityGroupsStub != nil {
-		return fake.AssignDefaultSecurityGroupsStub()
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.assignDefaultSecurityGroupsReturns
 	return fakeReturns.result1
 }
 
@@ -116,12 +126,19 @@ func (fake *FakeManager) AssignDefaultSecurityGroupsCallCount() int {
 	return len(fake.assignDefaultSecurityGroupsArgsForCall)
 }
 
-func (fake *FakeManager) AssignDefaultSecurityGroupsCalls(stub func() error) {
+func (fake *FakeManager) AssignDefaultSecurityGroupsCalls(stub func(context.Context) error) {
 	fake.assignDefaultSecurityGroupsMutex.Lock()
 	defer fake.assignDefaultSecurityGroupsMutex.Unlock()
 	fake.AssignDefaultSecurityGroupsStub = stub
 }
 
+func (fake *FakeManager) AssignDefaultSecurityGroupsArgsForCall(i int) context.Context {
+	fake.assignDefaultSecurityGroupsMutex.RLock()
+	defer fake.assignDefaultSecurityGroupsMutex.RUnlock()
+	argsForCall := fake.assignDefaultSecurityGroupsArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeManager) AssignDefaultSecurityGroupsReturns(result1 error) {
 	fake.assignDefaultSecurityGroupsMutex.Lock()
 	defer fake.assignDefaultSecurityGroupsMutex.Unlock()
@@ -145,20 +162,22 @@ func (fake *FakeManager) AssignDefaultSecurityGroupsReturnsOnCall(i int, result1
 	}{result1}
 }
 
-func (fake *FakeManager) CreateApplicationSecurityGroups() error {
+func (fake *FakeManager) CreateApplicationSecurityGroups(arg1 context.Context) error {
 	fake.createApplicationSecurityGroupsMutex.Lock()
 	ret, specificReturn := fake.createApplicationSecurityGroupsReturnsOnCall[len(fake.createApplicationSecurityGroupsArgsForCall)]
 	fake.createApplicationSecurityGroupsArgsForCall = append(fake.createApplicationSecurityGroupsArgsForCall, struct {
-	}{})
-	fake.recordInvocation("CreateApplicationSecurityGroups", []interface{}{})
+		arg1 context.Context
+	}{arg1})
+	stub := fake.CreateApplicationSecurityGroupsStub
+	fakeReturns := fake.createApplicationSecurityGroupsReturns
+	fake.recordInvocation("CreateApplicationSecurityGroups", []interface{}{arg1})
 	fake.createApplicationSecurityGroupsMutex.Unlock()
-	if fake.CreateApplicationSecurityGroupsStub != nil {
-		return fake.CreateApplicationSecurityGroupsStub()
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.createApplicationSecurityGroupsReturns
 	return fakeReturns.result1
 }
 
@@ -168,12 +187,19 @@ func (fake *FakeManager) CreateApplicationSecurityGroupsCallCount() int {
 	return len(fake.createApplicationSecurityGroupsArgsForCall)
 }
 
-func (fake *FakeManager) CreateApplicationSecurityGroupsCalls(stub func() error) {
+func (fake *FakeManager) CreateApplicationSecurityGroupsCalls(stub func(context.Context) error) {
 	fake.createApplicationSecurityGroupsMutex.Lock()
 	defer fake.createApplicationSecurityGroupsMutex.Unlock()
 	fake.CreateApplicationSecurityGroupsStub = stub
 }
 
+func (fake *FakeManager) CreateApplicationSecurityGroupsArgsForCall(i int) context.Context {
+	fake.createApplicationSecurityGroupsMutex.RLock()
+	defer fake.createApplicationSecurityGroupsMutex.RUnlock()
+	argsForCall := fake.createApplicationSecurityGroupsArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeManager) CreateApplicationSecurityGroupsReturns(result1 error) {
 	fake.createApplicationSecurityGroupsMutex.Lock()
 	defer fake.createApplicationSecurityGroupsMutex.Unlock()
@@ -197,20 +223,22 @@ func (fake *FakeManager) CreateApplicationSecurityGroupsReturnsOnCall(i int, res
 	}{result1}
 }
 
-func (fake *FakeManager) CreateGlobalSecurityGroups() error {
+func (fake *FakeManager) CreateGlobalSecurityGroups(arg1 context.Context) error {
 	fake.createGlobalSecurityGroupsMutex.Lock()
 	ret, specificReturn := fake.createGlobalSecurityGroupsReturnsOnCall[len(fake.createGlobalSecurityGroupsArgsForCall)]
 	fake.createGlobalSecurityGroupsArgsForCall = append(fake.createGlobalSecurityGroupsArgsForCall, struct {
-	}{})
-	fake.recordInvocation("CreateGlobalSecurityGroups", []interface{}{})
+		arg1 context.Context
+	}{arg1})
+	stub := fake.CreateGlobalSecurityGroupsStub
+	fakeReturns := fake.createGlobalSecurityGroupsReturns
+	fake.recordInvocation("CreateGlobalSecurityGroups", []interface{}{arg1})
 	fake.createGlobalSecurityGroupsMutex.Unlock()
-	if fake.CreateGlobalSecurityGroupsStub != nil {
-		return fake.CreateGlobalSecurityGroupsStub()
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.createGlobalSecurityGroupsReturns
 	return fakeReturns.result1
 }
 
@@ -220,12 +248,19 @@ func (fake *FakeManager) CreateGlobalSecurityGroupsCallCount() int {
 	return len(fake.createGlobalSecurityGroupsArgsForCall)
 }
 
-func (fake *FakeManager) CreateGlobalSecurityGroupsCalls(stub func() error) {
+func (fake *FakeManager) CreateGlobalSecurityGroupsCalls(stub func(context.Context) error) {
 	fake.createGlobalSecurityGroupsMutex.Lock()
 	defer fake.createGlobalSecurityGroupsMutex.Unlock()
 	fake.CreateGlobalSecurityGroupsStub = stub
 }
 
+func (fake *FakeManager) CreateGlobalSecurityGroupsArgsForCall(i int) context.Context {
+	fake.createGlobalSecurityGroupsMutex.RLock()
+	defer fake.createGlobalSecurityGroupsMutex.RUnlock()
+	argsForCall := fake.createGlobalSecurityGroupsArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeManager) CreateGlobalSecurityGroupsReturns(result1 error) {
 	fake.createGlobalSecurityGroupsMutex.Lock()
 	defer fake.createGlobalSecurityGroupsMutex.Unlock()
@@ -249,21 +284,23 @@ func (fake *FakeManager) CreateGlobalSecurityGroupsReturnsOnCall(i int, result1
 	}{result1}
 }
 
-func (fake *FakeManager) GetSecurityGroupRules(arg1 string) ([]byte, error) {
+func (fake *FakeManager) GetSecurityGroupRules(arg1 context.Context, arg2 string) ([]byte, error) {
 	fake.getSecurityGroupRulesMutex.Lock()
 	ret, specificReturn := fake.getSecurityGroupRulesReturnsOnCall[len(fake.getSecurityGroupRulesArgsForCall)]
 	fake.getSecurityGroupRulesArgsForCall = append(fake.getSecurityGroupRulesArgsForCall, struct {
-		arg1 string
-	}{arg1})
-	fake.recordInvocation("GetSecurityGroupRules", []interface{}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetSecurityGroupRulesStub
+	fakeReturns := fake.getSecurityGroupRulesReturns
+	fake.recordInvocation("GetSecurityGroupRules", []interface{}{arg1, arg2})
 	fake.getSecurityGroupRulesMutex.Unlock()
-	if fake.GetSecurityGroupRulesStub != nil {
-		return fake.GetSecurityGroupRulesStub(arg1)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.getSecurityGroupRulesReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -273,17 +310,17 @@ func (fake *FakeManager) GetSecurityGroupRulesCallCount() int {
 	return len(fake.getSecurityGroupRulesArgsForCall)
 }
 
-func (fake *FakeManager) GetSecurityGroupRulesCalls(stub func(string) ([]byte, error)) {
+func (fake *FakeManager) GetSecurityGroupRulesCalls(stub func(context.Context, string) ([]byte, error)) {
 	fake.getSecurityGroupRulesMutex.Lock()
 	defer fake.getSecurityGroupRulesMutex.Unlock()
 	fake.GetSecurityGroupRulesStub = stub
 }
 
-func (fake *FakeManager) GetSecurityGroupRulesArgsForCall(i int) string {
+func (fake *FakeManager) GetSecurityGroupRulesArgsForCall(i int) (context.Context, string) {
 	fake.getSecurityGroupRulesMutex.RLock()
 	defer fake.getSecurityGroupRulesMutex.RUnlock()
 	argsForCall := fake.getSecurityGroupRulesArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeManager) GetSecurityGroupRulesReturns(result1 []byte, result2 error) {
@@ -312,20 +349,22 @@ func (fake *FakeManager) GetSecurityGroupRulesReturnsOnCall(i int, result1 []byt
 	}{result1, result2}
 }
 
-func (fake *FakeManager) ListDefaultSecurityGroups() (map[string]*resource.SecurityGroup, error) {
+func (fake *FakeManager) ListDefaultSecurityGroups(arg1 context.Context) (map[string]*resource.SecurityGroup, error) {
 	fake.listDefaultSecurityGroupsMutex.Lock()
 	ret, specificReturn := fake.listDefaultSecurityGroupsReturnsOnCall[len(fake.listDefaultSecurityGroupsArgsForCall)]
 	fake.listDefaultSecurityGroupsArgsForCall = append(fake.listDefaultSecurityGroupsArgsForCall, struct {
-	}{})
-	fake.recordInvocation("ListDefaultSecurityGroups", []interface{}{})
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ListDefaultSecurityGroupsStub
+	fakeReturns := fake.listDefaultSecurityGroupsReturns
+	fake.recordInvocation("ListDefaultSecurityGroups", []interface{}{arg1})
 	fake.listDefaultSecurityGroupsMutex.Unlock()
-	if fake.ListDefaultSecurityGroupsStub != nil {
-		return fake.ListDefaultSecurityGroupsStub()
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.listDefaultSecurityGroupsReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -335,12 +374,19 @@ func (fake *FakeManager) ListDefaultSecurityGroupsCallCount() int {
 	return len(fake.listDefaultSecurityGroupsArgsForCall)
 }
 
-func (fake *FakeManager) ListDefaultSecurityGroupsCalls(stub func() (map[string]*resource.SecurityGroup, error)) {
+func (fake *FakeManager) ListDefaultSecurityGroupsCalls(stub func(context.Context) (map[string]*resource.SecurityGroup, error)) {
 	fake.listDefaultSecurityGroupsMutex.Lock()
 	defer fake.listDefaultSecurityGroupsMutex.Unlock()
 	fake.ListDefaultSecurityGroupsStub = stub
 }
 
+func (fake *FakeManager) ListDefaultSecurityGroupsArgsForCall(i int) context.Context {
+	fake.listDefaultSecurityGroupsMutex.RLock()
+	defer fake.listDefaultSecurityGroupsMutex.RUnlock()
+	argsForCall := fake.listDefaultSecurityGroupsArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeManager) ListDefaultSecurityGroupsReturns(result1 map[string]*resource.SecurityGroup, result2 error) {
 	fake.listDefaultSecurityGroupsMutex.Lock()
 	defer fake.listDefaultSecurityGroupsMutex.Unlock()
@@ -367,20 +413,22 @@ func (fake *FakeManager) ListDefaultSecurityGroupsReturnsOnCall(i int, result1 m
 	}{result1, result2}
 }
 
-func (fake *FakeManager) ListNonDefaultSecurityGroups() (map[string]*resource.SecurityGroup, error) {
+func (fake *FakeManager) ListNonDefaultSecurityGroups(arg1 context.Context) (map[string]*resource.SecurityGroup, error) {
 	fake.listNonDefaultSecurityGroupsMutex.Lock()
 	ret, specificReturn := fake.listNonDefaultSecurityGroupsReturnsOnCall[len(fake.listNonDefaultSecurityGroupsArgsForCall)]
 	fake.listNonDefaultSecurityGroupsArgsForCall = append(fake.listNonDefaultSecurityGroupsArgsForCall, struct {
-	}{})
-	fake.recordInvocation("ListNonDefaultSecurityGroups", []interface{}{})
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ListNonDefaultSecurityGroupsStub
+	fakeReturns := fake.listNonDefaultSecurityGroupsReturns
+	fake.recordInvocation("ListNonDefaultSecurityGroups", []interface{}{arg1})
 	fake.listNonDefaultSecurityGroupsMutex.Unlock()
-	if fake.ListNonDefaultSecurityGroupsStub != nil {
-		return fake.ListNonDefaultSecurityGroupsStub()
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.listNonDefaultSecurityGroupsReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -390,12 +438,19 @@ func (fake *FakeManager) ListNonDefaultSecurityGroupsCallCount() int {
 	return len(fake.listNonDefaultSecurityGroupsArgsForCall)
 }
 
-func (fake *FakeManager) ListNonDefaultSecurityGroupsCalls(stub func() (map[string]*resource.SecurityGroup, error)) {
+func (fake *FakeManager) ListNonDefaultSecurityGroupsCalls(stub func(context.Context) (map[string]*resource.SecurityGroup, error)) {
 	fake.listNonDefaultSecurityGroupsMutex.Lock()
 	defer fake.listNonDefaultSecurityGroupsMutex.Unlock()
 	fake.ListNonDefaultSecurityGroupsStub = stub
 }
 
+func (fake *FakeManager) ListNonDefaultSecurityGroupsArgsForCall(i int) context.Context {
+	fake.listNonDefaultSecurityGroupsMutex.RLock()
+	defer fake.listNonDefaultSecurityGroupsMutex.RUnlock()
+	argsForCall := fake.listNonDefaultSecurityGroupsArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeManager) ListNonDefaultSecurityGroupsReturns(result1 map[string]*resource.SecurityGroup, result2 error) {
 	fake.listNonDefaultSecurityGroupsMutex.Lock()
 	defer fake.listNonDefaultSecurityGroupsMutex.Unlock()
@@ -422,21 +477,23 @@ func (fake *FakeManager) ListNonDefaultSecurityGroupsReturnsOnCall(i int, result
 	}{result1, result2}
 }
 
-func (fake *FakeManager) ListSpaceSecurityGroups(arg1 string) (map[string]string, error) {
+func (fake *FakeManager) ListSpaceSecurityGroups(arg1 context.Context, arg2 string) (map[string]string, error) {
 	fake.listSpaceSecurityGroupsMutex.Lock()
 	ret, specificReturn := fake.listSpaceSecurityGroupsReturnsOnCall[len(fake.listSpaceSecurityGroupsArgsForCall)]
 	fake.listSpaceSecurityGroupsArgsForCall = append(fake.listSpaceSecurityGroupsArgsForCall, struct {
-		arg1 string
-	}{arg1})
-	fake.recordInvocation("ListSpaceSecurityGroups", []interface{}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ListSpaceSecurityGroupsStub
+	fakeReturns := fake.listSpaceSecurityGroupsReturns
+	fake.recordInvocation("ListSpaceSecurityGroups", []interface{}{arg1, arg2})
 	fake.listSpaceSecurityGroupsMutex.Unlock()
-	if fake.ListSpaceSecurityGroupsStub != nil {
-		return fake.ListSpaceSecurityGroupsStub(arg1)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.listSpaceSecurityGroupsReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -446,17 +503,17 @@ func (fake *FakeManager) ListSpaceSecurityGroupsCallCount() int {
 	return len(fake.listSpaceSecurityGroupsArgsForCall)
 }
 
-func (fake *FakeManager) ListSpaceSecurityGroupsCalls(stub func(string) (map[string]string, error)) {
+func (fake *FakeManager) ListSpaceSecurityGroupsCalls(stub func(context.Context, string) (map[string]string, error)) {
 	fake.listSpaceSecurityGroupsMutex.Lock()
 	defer fake.listSpaceSecurityGroupsMutex.Unlock()
 	fake.ListSpaceSecurityGroupsStub = stub
 }
 
-func (fake *FakeManager) ListSpaceSecurityGroupsArgsForCall(i int) string {
+func (fake *FakeManager) ListSpaceSecurityGroupsArgsForCall(i int) (context.Context, string) {
 	fake.listSpaceSecurityGroupsMutex.RLock()
 	defer fake.listSpaceSecurityGroupsMutex.RUnlock()
 	argsForCall := fake.listSpaceSecurityGroupsArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeManager) ListSpaceSecurityGroupsReturns(result1 map[string]string, result2 error) {
@@ -6,22 +6,29 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/cloudfoundry-community/go-cfclient/v3/resource"
 
 	"github.com/pkg/errors"
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
 	"github.com/vmwarepivotallabs/cf-mgmt/config"
+	"github.com/vmwarepivotallabs/cf-mgmt/failures"
 	"github.com/vmwarepivotallabs/cf-mgmt/space"
+	"github.com/vmwarepivotallabs/cf-mgmt/util"
 	"github.com/xchapter7x/lo"
 )
 
 // NewManager -
-func NewManager(client CFSecurityGroupClient, spaceMgr space.Manager, cfg config.Reader, peek bool) Manager {
+func NewManager(client CFSecurityGroupClient, spaceMgr space.Manager, cfg config.Reader, parallelism int, failures *failures.Collector, recorder *changes.Recorder, peek bool) Manager {
 	return &DefaultManager{
 		Cfg:          cfg,
 		Client:       client,
 		SpaceManager: spaceMgr,
+		Failures:     failures,
+		Recorder:     recorder,
 		Peek:         peek,
+		Parallelism:  parallelism,
 	}
 }
 
@@ -30,16 +37,23 @@ type DefaultManager struct {
 	Cfg          config.Reader
 	SpaceManager space.Manager
 	Client       CFSecurityGroupClient
+	Failures     *failures.Collector
+	Recorder     *changes.Recorder
 	Peek         bool
+	Parallelism  int
+	mutex        sync.Mutex
+	// simulated - security groups created or globally (un)assigned while peeking,
+	// layered over the groups returned by cloud controller so later steps can see them
+	simulated map[string]*resource.SecurityGroup
 }
 
 // CreateApplicationSecurityGroups -
-func (m *DefaultManager) CreateApplicationSecurityGroups() error {
+func (m *DefaultManager) CreateApplicationSecurityGroups(ctx context.Context) error {
 	spaceConfigs, err := m.Cfg.GetSpaceConfigs()
 	if err != nil {
 		return errors.Wrap(err, "Getting space configs")
 	}
-	sgs, err := m.ListNonDefaultSecurityGroups()
+	sgs, err := m.ListNonDefaultSecurityGroups(ctx)
 	if err != nil {
 		return err
 	}
@@ -56,90 +70,103 @@ func (m *DefaultManager) CreateApplicationSecurityGroups() error {
 		}
 	}
 
-	for _, input := range spaceConfigs {
-		space, err := m.SpaceManager.FindSpace(input.Org, input.Space)
-		if err != nil {
-			return errors.Wrapf(err, "Finding org/space %s/%s", input.Org, input.Space)
-		}
-		existingSpaceSecurityGroups, err := m.ListSpaceSecurityGroups(space.GUID)
-		if err != nil {
-			return errors.Wrapf(err, "Unabled to list existing space security groups for org/space [%s/%s]", input.Org, input.Space)
+	errs := util.RunGrouped(ctx, m.Parallelism, len(spaceConfigs), func(i int) string { return spaceConfigs[i].Org }, true, func(i int) error {
+		input := spaceConfigs[i]
+		if m.Failures.Skip(input.Org, input.Space) {
+			lo.G.Infof("skipping space [%s/%s] security groups as it failed in an earlier step", input.Org, input.Space)
+			return nil
 		}
-		lo.G.Debugf("Existing space security groups %+v", existingSpaceSecurityGroups)
-		// iterate through and assign named security groups to the space - ensuring that they are up to date is
-		// done elsewhere.
-		for _, securityGroupName := range input.ASGs {
-			if sgInfo, ok := sgs[securityGroupName]; ok {
-				if _, ok := existingSpaceSecurityGroups[securityGroupName]; !ok {
-					err := m.AssignSecurityGroupToSpace(space, sgInfo)
-					if err != nil {
-						return err
-					}
-				} else {
-					delete(existingSpaceSecurityGroups, securityGroupName)
+		return m.Failures.Add(input.Org, input.Space, m.createApplicationSecurityGroups(ctx, input, sgs, globalConfig, skipUnassignSecurityGroupRegex))
+	})
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+func (m *DefaultManager) createApplicationSecurityGroups(ctx context.Context, input config.SpaceConfig, sgs map[string]*resource.SecurityGroup, globalConfig *config.GlobalConfig, skipUnassignSecurityGroupRegex *regexp.Regexp) error {
+	space, err := m.SpaceManager.FindSpace(ctx, input.Org, input.Space)
+	if err != nil {
+		return errors.Wrapf(err, "Finding org/space %s/%s", input.Org, input.Space)
+	}
+	existingSpaceSecurityGroups, err := m.ListSpaceSecurityGroups(ctx, space.GUID)
+	if err != nil {
+		return errors.Wrapf(err, "Unabled to list existing space security groups for org/space [%s/%s]", input.Org, input.Space)
+	}
+	lo.G.Debugf("Existing space security groups %+v", existingSpaceSecurityGroups)
+	// iterate through and assign named security groups to the space - ensuring that they are up to date is
+	// done elsewhere.
+	for _, securityGroupName := range input.ASGs {
+		if sgInfo, ok := sgs[securityGroupName]; ok {
+			if _, ok := existingSpaceSecurityGroups[securityGroupName]; !ok {
+				err := m.AssignSecurityGroupToSpace(ctx, input.Org, space, sgInfo)
+				if err != nil {
+					return err
 				}
 			} else {
-				return fmt.Errorf("Security group [%s] does not exist as a non-running and non-staging security group [%v+]", securityGroupName, sgs)
+				delete(existingSpaceSecurityGroups, securityGroupName)
 			}
+		} else {
+			return fmt.Errorf("Security group [%s] does not exist as a non-running and non-staging security group [%v+]", securityGroupName, sgs)
 		}
+	}
 
-		spaceSecurityGroupName := fmt.Sprintf("%s-%s", input.Org, input.Space)
-		if input.EnableSecurityGroup {
-			var sgInfo *resource.SecurityGroup
-			var ok bool
-			if sgInfo, ok = sgs[spaceSecurityGroupName]; ok {
-				changed, err := m.hasSecurityGroupChanged(sgInfo, input.GetSecurityGroupContents())
-				if err != nil {
-					return errors.Wrapf(err, "Checking if security group %s has changed", spaceSecurityGroupName)
-				}
-				if changed {
-					if err := m.UpdateSecurityGroup(sgInfo, input.GetSecurityGroupContents()); err != nil {
-						return err
-					}
-				}
-			} else {
-				securityGroup, err := m.CreateSecurityGroup(spaceSecurityGroupName, input.GetSecurityGroupContents())
-				if err != nil {
-					return errors.Wrapf(err, "Creating security group %s for %s/%s security-group.json", spaceSecurityGroupName, input.Org, input.Space)
-				}
-				sgInfo = securityGroup
+	spaceSecurityGroupName := fmt.Sprintf("%s-%s", input.Org, input.Space)
+	if input.EnableSecurityGroup {
+		var sgInfo *resource.SecurityGroup
+		var ok bool
+		if sgInfo, ok = sgs[spaceSecurityGroupName]; ok {
+			changed, err := m.hasSecurityGroupChanged(sgInfo, input.GetSecurityGroupContents())
+			if err != nil {
+				return errors.Wrapf(err, "Checking if security group %s has changed", spaceSecurityGroupName)
 			}
-			if _, ok := existingSpaceSecurityGroups[spaceSecurityGroupName]; !ok {
-				err := m.AssignSecurityGroupToSpace(space, sgInfo)
-				if err != nil {
+			if changed {
+				if err := m.UpdateSecurityGroup(ctx, sgInfo, input.GetSecurityGroupContents()); err != nil {
 					return err
 				}
-			} else {
-				delete(existingSpaceSecurityGroups, spaceSecurityGroupName)
 			}
+		} else {
+			securityGroup, err := m.CreateSecurityGroup(ctx, spaceSecurityGroupName, input.GetSecurityGroupContents())
+			if err != nil {
+				return errors.Wrapf(err, "Creating security group %s for %s/%s security-group.json", spaceSecurityGroupName, input.Org, input.Space)
+			}
+			sgInfo = securityGroup
+		}
+		if _, ok := existingSpaceSecurityGroups[spaceSecurityGroupName]; !ok {
+			err := m.AssignSecurityGroupToSpace(ctx, input.Org, space, sgInfo)
+			if err != nil {
+				return err
+			}
+		} else {
+			delete(existingSpaceSecurityGroups, spaceSecurityGroupName)
 		}
+	}
 
-		if input.EnableUnassignSecurityGroup {
-			lo.G.Debugf("Existing space security groups after %+v", existingSpaceSecurityGroups)
-			for sgName, _ := range existingSpaceSecurityGroups {
-				if sgInfo, ok := sgs[sgName]; ok {
-					if globalConfig.SkipUnassignSecurityGroupRegex != "" && skipUnassignSecurityGroupRegex.MatchString(sgName) {
-						lo.G.Debugf("Skip unassign as security group name [%s] matches global config regex [%s]", sgName, globalConfig.SkipUnassignSecurityGroupRegex)
-						continue
-					} else {
-						lo.G.Debugf("Not skip unassign as security group name [%s] does not match global config regex [%s]", sgName, globalConfig.SkipUnassignSecurityGroupRegex)
-					}
-					err := m.UnassignSecurityGroupToSpace(space, sgInfo)
-					if err != nil {
-						return err
-					}
+	if input.EnableUnassignSecurityGroup {
+		lo.G.Debugf("Existing space security groups after %+v", existingSpaceSecurityGroups)
+		for sgName, _ := range existingSpaceSecurityGroups {
+			if sgInfo, ok := sgs[sgName]; ok {
+				if globalConfig.SkipUnassignSecurityGroupRegex != "" && skipUnassignSecurityGroupRegex.MatchString(sgName) {
+					lo.G.Debugf("Skip unassign as security group name [%s] matches global config regex [%s]", sgName, globalConfig.SkipUnassignSecurityGroupRegex)
+					continue
 				} else {
-					return fmt.Errorf("Security group [%s] does not exist as a non-running and non-staging security group [%v+]", sgName, sgs)
+					lo.G.Debugf("Not skip unassign as security group name [%s] does not match global config regex [%s]", sgName, globalConfig.SkipUnassignSecurityGroupRegex)
+				}
+				err := m.UnassignSecurityGroupToSpace(ctx, input.Org, space, sgInfo)
+				if err != nil {
+					return err
 				}
+			} else {
+				return fmt.Errorf("Security group [%s] does not exist as a non-running and non-staging security group [%v+]", sgName, sgs)
 			}
 		}
 	}
 	return nil
 }
 
-func (m *DefaultManager) ListSecurityGroups() (map[string]*resource.SecurityGroup, error) {
+func (m *DefaultManager) ListSecurityGroups(ctx context.Context) (map[string]*resource.SecurityGroup, error) {
 	securityGroups := make(map[string]*resource.SecurityGroup)
-	secGroups, err := m.Client.ListAll(context.Background(), nil)
+	secGroups, err := m.Client.ListAll(util.CallContext(ctx), nil)
 	if err != nil {
 		return securityGroups, err
 	}
@@ -147,12 +174,26 @@ func (m *DefaultManager) ListSecurityGroups() (map[string]*resource.SecurityGrou
 	for _, sg := range secGroups {
 		securityGroups[sg.Name] = sg
 	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for name, sg := range m.simulated {
+		securityGroups[name] = sg
+	}
 	return securityGroups, nil
 }
 
+func (m *DefaultManager) simulate(sg resource.SecurityGroup) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.simulated == nil {
+		m.simulated = make(map[string]*resource.SecurityGroup)
+	}
+	m.simulated[sg.Name] = &sg
+}
+
 // CreateGlobalSecurityGroups -
-func (m *DefaultManager) CreateGlobalSecurityGroups() error {
-	sgs, err := m.ListSecurityGroups()
+func (m *DefaultManager) CreateGlobalSecurityGroups(ctx context.Context) error {
+	sgs, err := m.ListSecurityGroups(ctx)
 	if err != nil {
 		return err
 	}
@@ -164,11 +205,11 @@ func (m *DefaultManager) CreateGlobalSecurityGroups() error {
 	if err != nil {
 		return errors.Wrap(err, "Getting Default ASG Configs")
 	}
-	err = m.processSecurityGroups(securityGroupConfigs, sgs)
+	err = m.processSecurityGroups(ctx, securityGroupConfigs, sgs)
 	if err != nil {
 		return err
 	}
-	err = m.processSecurityGroups(defaultSecurityGroupConfigs, sgs)
+	err = m.processSecurityGroups(ctx, defaultSecurityGroupConfigs, sgs)
 	if err != nil {
 		return err
 	}
@@ -177,8 +218,8 @@ func (m *DefaultManager) CreateGlobalSecurityGroups() error {
 }
 
 // AssignDefaultSecurityGroups -
-func (m *DefaultManager) AssignDefaultSecurityGroups() error {
-	sgs, err := m.ListSecurityGroups()
+func (m *DefaultManager) AssignDefaultSecurityGroups(ctx context.Context) error {
+	sgs, err := m.ListSecurityGroups(ctx)
 	if err != nil {
 		return err
 	}
@@ -188,9 +229,12 @@ func (m *DefaultManager) AssignDefaultSecurityGroups() error {
 	}
 
 	for _, runningGroup := range globalConfig.RunningSecurityGroups {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		if group, ok := sgs[runningGroup]; ok {
 			if !group.GloballyEnabled.Running {
-				err = m.AssignSecurityGroupGlobalRunning(group)
+				err = m.AssignSecurityGroupGlobalRunning(ctx, group)
 				if err != nil {
 					return err
 				}
@@ -199,15 +243,19 @@ func (m *DefaultManager) AssignDefaultSecurityGroups() error {
 			if !m.Peek {
 				return fmt.Errorf("Running security group [%s] does not exist", runningGroup)
 			} else {
+				m.Recorder.Record(changes.Change{Entity: changes.SecurityGroup, Action: changes.Assign, Name: runningGroup, After: "running"})
 				lo.G.Infof("[dry-run]: assigning yet to be created sg %s as running security group", runningGroup)
 			}
 		}
 	}
 
 	for _, stagingGroup := range globalConfig.StagingSecurityGroups {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		if group, ok := sgs[stagingGroup]; ok {
 			if !group.GloballyEnabled.Staging {
-				err = m.AssignSecurityGroupGlobalStaging(group)
+				err = m.AssignSecurityGroupGlobalStaging(ctx, group)
 				if err != nil {
 					return err
 				}
@@ -216,6 +264,7 @@ func (m *DefaultManager) AssignDefaultSecurityGroups() error {
 			if !m.Peek {
 				return fmt.Errorf("Staging security group [%s] does not exist", stagingGroup)
 			} else {
+				m.Recorder.Record(changes.Change{Entity: changes.SecurityGroup, Action: changes.Assign, Name: stagingGroup, After: "staging"})
 				lo.G.Infof("[dry-run]: assigning yet to be created sg %s as staging security group", stagingGroup)
 			}
 		}
@@ -223,14 +272,17 @@ func (m *DefaultManager) AssignDefaultSecurityGroups() error {
 
 	if globalConfig.EnableUnassignSecurityGroups {
 		for groupName, group := range sgs {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
 			if group.GloballyEnabled.Running && !m.contains(globalConfig.RunningSecurityGroups, groupName) {
-				err = m.UnassignSecurityGroupGlobalRunning(group)
+				err = m.UnassignSecurityGroupGlobalRunning(ctx, group)
 				if err != nil {
 					return err
 				}
 			}
 			if group.GloballyEnabled.Staging && !m.contains(globalConfig.StagingSecurityGroups, groupName) {
-				err = m.UnassignSecurityGroupGlobalStaging(group)
+				err = m.UnassignSecurityGroupGlobalStaging(ctx, group)
 				if err != nil {
 					return err
 				}
@@ -251,8 +303,11 @@ func (m *DefaultManager) contains(list []string, groupName string) bool {
 	return false
 }
 
-func (m *DefaultManager) processSecurityGroups(securityGroupConfigs []config.ASGConfig, sgs map[string]*resource.SecurityGroup) error {
+func (m *DefaultManager) processSecurityGroups(ctx context.Context, securityGroupConfigs []config.ASGConfig, sgs map[string]*resource.SecurityGroup) error {
 	for _, input := range securityGroupConfigs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		sgName := input.Name
 
 		// For every named security group
@@ -263,12 +318,12 @@ func (m *DefaultManager) processSecurityGroups(securityGroupConfigs []config.ASG
 				return errors.Wrapf(err, "Processing %s security group", sgName)
 			}
 			if changed {
-				if err := m.UpdateSecurityGroup(sgInfo, input.Rules); err != nil {
+				if err := m.UpdateSecurityGroup(ctx, sgInfo, input.Rules); err != nil {
 					return err
 				}
 			}
 		} else {
-			if _, err := m.CreateSecurityGroup(sgName, input.Rules); err != nil {
+			if _, err := m.CreateSecurityGroup(ctx, sgName, input.Rules); err != nil {
 				return err
 			}
 		}
@@ -340,31 +395,38 @@ func (m *DefaultManager) isSecurityGroupAssignedToSpace(space *resource.Space, s
 	}
 	return false
 }
-func (m *DefaultManager) AssignSecurityGroupToSpace(space *resource.Space, secGroup *resource.SecurityGroup) error {
+func (m *DefaultManager) AssignSecurityGroupToSpace(ctx context.Context, orgName string, space *resource.Space, secGroup *resource.SecurityGroup) error {
 	if m.isSecurityGroupAssignedToSpace(space, secGroup) {
 		lo.G.Debugf("Security group %s is already assigned to space %s, skipping", secGroup.Name, space.Name)
 		return nil
 	}
+	change := changes.Change{Entity: changes.SecurityGroup, Action: changes.Assign, Name: secGroup.Name, Org: orgName, Space: space.Name}
+	m.Recorder.Record(change)
 	if m.Peek {
 		lo.G.Infof("[dry-run]: assigning security group %s to space %s", secGroup.Name, space.Name)
 		return nil
 	}
 	lo.G.Infof("assigning security group %s to space %s", secGroup.Name, space.Name)
-	_, err := m.Client.BindRunningSecurityGroup(context.Background(), secGroup.GUID, []string{space.GUID})
+	_, err := m.Client.BindRunningSecurityGroup(util.CallContext(ctx), secGroup.GUID, []string{space.GUID})
+	m.Recorder.Applied(change, secGroup.GUID, err)
 	return err
 }
 
-func (m *DefaultManager) UnassignSecurityGroupToSpace(space *resource.Space, secGroup *resource.SecurityGroup) error {
+func (m *DefaultManager) UnassignSecurityGroupToSpace(ctx context.Context, orgName string, space *resource.Space, secGroup *resource.SecurityGroup) error {
 	if !m.isSecurityGroupAssignedToSpace(space, secGroup) {
 		lo.G.Debugf("Security group %s isn't assigned to space %s, skipping", secGroup.Name, space.Name)
 		return nil
 	}
+	change := changes.Change{Entity: changes.SecurityGroup, Action: changes.Unassign, Name: secGroup.Name, Org: orgName, Space: space.Name}
+	m.Recorder.Record(change)
 	if m.Peek {
 		lo.G.Infof("[dry-run]: unassigning security group %s to space %s", secGroup.Name, space.Name)
 		return nil
 	}
 	lo.G.Infof("unassigning security group %s to space %s", secGroup.Name, space.Name)
-	return m.Client.UnBindRunningSecurityGroup(context.Background(), secGroup.GUID, space.GUID)
+	err := m.Client.UnBindRunningSecurityGroup(util.CallContext(ctx), secGroup.GUID, space.GUID)
+	m.Recorder.Applied(change, secGroup.GUID, err)
+	return err
 }
 
 func (m *DefaultManager) removeDestinationWhitespace(rules []*resource.SecurityGroupRule) []*resource.SecurityGroupRule {
@@ -383,10 +445,17 @@ func (m *DefaultManager) removeDestinationWhitespace(rules []*resource.SecurityG
 	return rulesToReturn
 }
 
-func (m *DefaultManager) CreateSecurityGroup(sgName, contents string) (*resource.SecurityGroup, error) {
+func (m *DefaultManager) CreateSecurityGroup(ctx context.Context, sgName, contents string) (*resource.SecurityGroup, error) {
+	change := changes.Change{Entity: changes.SecurityGroup, Action: changes.Create, Name: sgName, After: contents}
+	m.Recorder.Record(change)
 	if m.Peek {
 		lo.G.Infof("[dry-run]: creating securityGroup %s with contents %s", sgName, contents)
-		return &resource.SecurityGroup{Name: "dry-run-name", GUID: "dry-run-guid"}, nil
+		sg := resource.SecurityGroup{Name: sgName, GUID: fmt.Sprintf("%s-dry-run-security-group-guid", sgName)}
+		if err := json.Unmarshal([]byte(contents), &sg.Rules); err != nil {
+			return nil, err
+		}
+		m.simulate(sg)
+		return &sg, nil
 	}
 	securityGroupRules := []*resource.SecurityGroupRule{}
 	err := json.Unmarshal([]byte(contents), &securityGroupRules)
@@ -404,7 +473,13 @@ func (m *DefaultManager) CreateSecurityGroup(sgName, contents string) (*resource
 		},
 		Rules: rulesToUse,
 	}
-	return m.Client.Create(context.Background(), r)
+	sg, err := m.Client.Create(util.CallContext(ctx), r)
+	var guid string
+	if sg != nil {
+		guid = sg.GUID
+	}
+	m.Recorder.Applied(change, guid, err)
+	return sg, err
 }
 
 func (m *DefaultManager) rulesAsString(rules []*resource.SecurityGroupRule) string {
@@ -443,7 +518,13 @@ func (m *DefaultManager) rulesAsString(rules []*resource.SecurityGroupRule) stri
 	return ruleString
 }
 
-func (m *DefaultManager) UpdateSecurityGroup(sg *resource.SecurityGroup, contents string) error {
+func (m *DefaultManager) UpdateSecurityGroup(ctx context.Context, sg *resource.SecurityGroup, contents string) error {
+	var change changes.Change
+	if m.Recorder != nil {
+		before, _ := json.Marshal(sg.Rules)
+		change = changes.Change{Entity: changes.SecurityGroup, Action: changes.Update, Name: sg.Name, Before: string(before), After: contents}
+		m.Recorder.Record(change)
+	}
 	if m.Peek {
 		lo.G.Infof("[dry-run]: updating securityGroup %s with contents %s", sg.Name, contents)
 		return nil
@@ -460,13 +541,14 @@ func (m *DefaultManager) UpdateSecurityGroup(sg *resource.SecurityGroup, content
 		Name:  sg.Name,
 		Rules: rulesToUse,
 	}
-	_, err = m.Client.Update(context.Background(), sg.GUID, r)
+	_, err = m.Client.Update(util.CallContext(ctx), sg.GUID, r)
+	m.Recorder.Applied(change, sg.GUID, err)
 	return err
 }
 
-func (m *DefaultManager) ListNonDefaultSecurityGroups() (map[string]*resource.SecurityGroup, error) {
+func (m *DefaultManager) ListNonDefaultSecurityGroups(ctx context.Context) (map[string]*resource.SecurityGroup, error) {
 	securityGroups := make(map[string]*resource.SecurityGroup)
-	groupMap, err := m.ListSecurityGroups()
+	groupMap, err := m.ListSecurityGroups(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -478,9 +560,9 @@ func (m *DefaultManager) ListNonDefaultSecurityGroups() (map[string]*resource.Se
 	return securityGroups, nil
 }
 
-func (m *DefaultManager) ListDefaultSecurityGroups() (map[string]*resource.SecurityGroup, error) {
+func (m *DefaultManager) ListDefaultSecurityGroups(ctx context.Context) (map[string]*resource.SecurityGroup, error) {
 	securityGroups := make(map[string]*resource.SecurityGroup)
-	groupMap, err := m.ListSecurityGroups()
+	groupMap, err := m.ListSecurityGroups(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -492,9 +574,14 @@ func (m *DefaultManager) ListDefaultSecurityGroups() (map[string]*resource.Secur
 	return securityGroups, nil
 }
 
-func (m *DefaultManager) AssignSecurityGroupGlobalRunning(sg *resource.SecurityGroup) error {
+func (m *DefaultManager) AssignSecurityGroupGlobalRunning(ctx context.Context, sg *resource.SecurityGroup) error {
+	change := changes.Change{Entity: changes.SecurityGroup, Action: changes.Assign, Name: sg.Name, After: "running"}
+	m.Recorder.Record(change)
 	if m.Peek {
 		lo.G.Infof("[dry-run]: assigning sg %s as running security group", sg.Name)
+		simulatedSG := *sg
+		simulatedSG.GloballyEnabled.Running = true
+		m.simulate(simulatedSG)
 		return nil
 	}
 	lo.G.Infof("assigning sg %s as running security group", sg.Name)
@@ -505,13 +592,19 @@ func (m *DefaultManager) AssignSecurityGroupGlobalRunning(sg *resource.SecurityG
 		},
 	}
 	sg.GloballyEnabled.Running = true
-	_, err := m.Client.Update(context.Background(), sg.GUID, r)
+	_, err := m.Client.Update(util.CallContext(ctx), sg.GUID, r)
+	m.Recorder.Applied(change, sg.GUID, err)
 	return err
 }
 
-func (m *DefaultManager) AssignSecurityGroupGlobalStaging(sg *resource.SecurityGroup) error {
+func (m *DefaultManager) AssignSecurityGroupGlobalStaging(ctx context.Context, sg *resource.SecurityGroup) error {
+	change := changes.Change{Entity: changes.SecurityGroup, Action: changes.Assign, Name: sg.Name, After: "staging"}
+	m.Recorder.Record(change)
 	if m.Peek {
 		lo.G.Infof("[dry-run]: assigning sg %s as staging security group", sg.Name)
+		simulatedSG := *sg
+		simulatedSG.GloballyEnabled.Staging = true
+		m.simulate(simulatedSG)
 		return nil
 	}
 
@@ -523,13 +616,19 @@ func (m *DefaultManager) AssignSecurityGroupGlobalStaging(sg *resource.SecurityG
 		},
 	}
 	sg.GloballyEnabled.Staging = true
-	_, err := m.Client.Update(context.Background(), sg.GUID, r)
+	_, err := m.Client.Update(util.CallContext(ctx), sg.GUID, r)
+	m.Recorder.Applied(change, sg.GUID, err)
 	return err
 }
 
-func (m *DefaultManager) UnassignSecurityGroupGlobalRunning(sg *resource.SecurityGroup) error {
+func (m *DefaultManager) UnassignSecurityGroupGlobalRunning(ctx context.Context, sg *resource.SecurityGroup) error {
+	change := changes.Change{Entity: changes.SecurityGroup, Action: changes.Unassign, Name: sg.Name, Before: "running"}
+	m.Recorder.Record(change)
 	if m.Peek {
 		lo.G.Infof("[dry-run]: unassinging sg %s as running security group", sg.Name)
+		simulatedSG := *sg
+		simulatedSG.GloballyEnabled.Running = false
+		m.simulate(simulatedSG)
 		return nil
 	}
 	lo.G.Infof("unassinging sg %s as running security group", sg.Name)
@@ -539,13 +638,19 @@ func (m *DefaultManager) UnassignSecurityGroupGlobalRunning(sg *resource.Securit
 		},
 	}
 	sg.GloballyEnabled.Running = false
-	_, err := m.Client.Update(context.Background(), sg.GUID, r)
+	_, err := m.Client.Update(util.CallContext(ctx), sg.GUID, r)
+	m.Recorder.Applied(change, sg.GUID, err)
 	return err
 }
 
-func (m *DefaultManager) UnassignSecurityGroupGlobalStaging(sg *resource.SecurityGroup) error {
+func (m *DefaultManager) UnassignSecurityGroupGlobalStaging(ctx context.Context, sg *resource.SecurityGroup) error {
+	change := changes.Change{Entity: changes.SecurityGroup, Action: changes.Unassign, Name: sg.Name, Before: "staging"}
+	m.Recorder.Record(change)
 	if m.Peek {
 		lo.G.Infof("[dry-run]: unassigning sg %s as staging security group", sg.Name)
+		simulatedSG := *sg
+		simulatedSG.GloballyEnabled.Staging = false
+		m.simulate(simulatedSG)
 		return nil
 	}
 	lo.G.Infof("unassigning sg %s as staging security group", sg.Name)
@@ -555,24 +660,25 @@ func (m *DefaultManager) UnassignSecurityGroupGlobalStaging(sg *resource.Securit
 		},
 	}
 	sg.GloballyEnabled.Staging = false
-	_, err := m.Client.Update(context.Background(), sg.GUID, r)
+	_, err := m.Client.Update(util.CallContext(ctx), sg.GUID, r)
+	m.Recorder.Applied(change, sg.GUID, err)
 	return err
 }
 
-func (m *DefaultManager) GetSecurityGroupRules(sgGUID string) ([]byte, error) {
-	secGroup, err := m.Client.Get(context.Background(), sgGUID)
+func (m *DefaultManager) GetSecurityGroupRules(ctx context.Context, sgGUID string) ([]byte, error) {
+	secGroup, err := m.Client.Get(util.CallContext(ctx), sgGUID)
 	if err != nil {
 		return nil, err
 	}
 	return json.MarshalIndent(secGroup.Rules, "", "\t")
 }
 
-func (m *DefaultManager) ListSpaceSecurityGroups(spaceGUID string) (map[string]string, error) {
+func (m *DefaultManager) ListSpaceSecurityGroups(ctx context.Context, spaceGUID string) (map[string]string, error) {
 	names := make(map[string]string)
 	if strings.Contains(spaceGUID, "dry-run-space-guid") {
 		return names, nil
 	}
-	secGroups, err := m.Client.ListRunningForSpaceAll(context.Background(), spaceGUID, nil)
+	secGroups, err := m.Client.ListRunningForSpaceAll(util.CallContext(ctx), spaceGUID, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,8 @@
 package securitygroup_test
 
 import (
+	"context"
+
 	"encoding/json"
 	"errors"
 
@@ -8,6 +10,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
 	"github.com/vmwarepivotallabs/cf-mgmt/config"
 	configfakes "github.com/vmwarepivotallabs/cf-mgmt/config/fakes"
 	"github.com/vmwarepivotallabs/cf-mgmt/securitygroup"
@@ -89,14 +92,14 @@ var _ = Describe("given Security Group Manager", func() {
 					},
 				},
 			}, nil)
-			groups, err := securityMgr.ListNonDefaultSecurityGroups()
+			groups, err := securityMgr.ListNonDefaultSecurityGroups(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(len(groups)).Should(Equal(2))
 		})
 
 		It("returns error", func() {
 			fakeClient.ListAllReturns(nil, errors.New("error"))
-			_, err := securityMgr.ListNonDefaultSecurityGroups()
+			_, err := securityMgr.ListNonDefaultSecurityGroups(context.Background())
 			Expect(err).Should(HaveOccurred())
 			Expect(fakeClient.ListAllCallCount()).Should(Equal(1))
 		})
@@ -122,14 +125,14 @@ var _ = Describe("given Security Group Manager", func() {
 					},
 				},
 			}, nil)
-			groups, err := securityMgr.ListDefaultSecurityGroups()
+			groups, err := securityMgr.ListDefaultSecurityGroups(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(len(groups)).Should(Equal(2))
 		})
 
 		It("returns error", func() {
 			fakeClient.ListAllReturns(nil, errors.New("error"))
-			_, err := securityMgr.ListDefaultSecurityGroups()
+			_, err := securityMgr.ListDefaultSecurityGroups(context.Background())
 			Expect(err).Should(HaveOccurred())
 			Expect(fakeClient.ListAllCallCount()).Should(Equal(1))
 		})
@@ -183,12 +186,20 @@ var _ = Describe("given Security Group Manager", func() {
 					GUID: "dns-guid",
 				},
 			}, nil)
-			err := securityMgr.CreateApplicationSecurityGroups()
+			securityMgr.Recorder = changes.NewRecorder()
+			err := securityMgr.CreateApplicationSecurityGroups(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeClient.BindRunningSecurityGroupCallCount()).Should(Equal(1))
 			_, sgGUID, spaceGUIDs := fakeClient.BindRunningSecurityGroupArgsForCall(0)
 			Expect(sgGUID).Should(Equal("dns-guid"))
 			Expect(spaceGUIDs[0]).Should(Equal("space1-guid"))
+			Expect(securityMgr.Recorder.Changes()).Should(ConsistOf(changes.Change{
+				Entity: changes.SecurityGroup,
+				Action: changes.Assign,
+				Name:   "dns",
+				Org:    "org1",
+				Space:  "space1",
+			}))
 		})
 
 		It("Should not assign global group to space that is already assigned", func() {
@@ -213,7 +224,7 @@ var _ = Describe("given Security Group Manager", func() {
 					GUID: "dns-guid",
 				},
 			}, nil)
-			err := securityMgr.CreateApplicationSecurityGroups()
+			err := securityMgr.CreateApplicationSecurityGroups(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeClient.ListRunningForSpaceAllCallCount()).Should(Equal(1))
 			Expect(fakeClient.BindRunningSecurityGroupCallCount()).Should(Equal(0))
@@ -264,7 +275,7 @@ var _ = Describe("given Security Group Manager", func() {
 					GUID: "ntp-guid",
 				},
 			}, nil)
-			err := securityMgr.CreateApplicationSecurityGroups()
+			err := securityMgr.CreateApplicationSecurityGroups(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeClient.ListRunningForSpaceAllCallCount()).Should(Equal(1))
 			Expect(fakeClient.BindRunningSecurityGroupCallCount()).Should(Equal(0))
@@ -307,7 +318,7 @@ var _ = Describe("given Security Group Manager", func() {
 					Rules: []resource.SecurityGroupRule{},
 				},
 			}, nil)
-			err := securityMgr.CreateApplicationSecurityGroups()
+			err := securityMgr.CreateApplicationSecurityGroups(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeClient.ListRunningForSpaceAllCallCount()).Should(Equal(1))
 			Expect(fakeClient.BindRunningSecurityGroupCallCount()).Should(Equal(0))
@@ -363,7 +374,7 @@ var _ = Describe("given Security Group Manager", func() {
 					},
 				},
 			}, nil)
-			err := securityMgr.CreateApplicationSecurityGroups()
+			err := securityMgr.CreateApplicationSecurityGroups(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeClient.ListRunningForSpaceAllCallCount()).Should(Equal(1))
 			Expect(fakeClient.BindRunningSecurityGroupCallCount()).Should(Equal(0))
@@ -417,7 +428,7 @@ var _ = Describe("given Security Group Manager", func() {
 					Rules: []resource.SecurityGroupRule{},
 				},
 			}, nil)
-			err := securityMgr.CreateApplicationSecurityGroups()
+			err := securityMgr.CreateApplicationSecurityGroups(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeClient.ListRunningForSpaceAllCallCount()).Should(Equal(1))
 			Expect(fakeClient.BindRunningSecurityGroupCallCount()).Should(Equal(0))
@@ -441,7 +452,7 @@ var _ = Describe("given Security Group Manager", func() {
 				},
 			}, nil)
 			fakeClient.BindRunningSecurityGroupReturns(nil, errors.New("error"))
-			err := securityMgr.CreateApplicationSecurityGroups()
+			err := securityMgr.CreateApplicationSecurityGroups(context.Background())
 			Expect(err).Should(HaveOccurred())
 			Expect(fakeClient.BindRunningSecurityGroupCallCount()).Should(Equal(1))
 		})
@@ -456,7 +467,7 @@ var _ = Describe("given Security Group Manager", func() {
 				},
 			}
 			fakeReader.GetSpaceConfigsReturns(spaceConfigs, nil)
-			err := securityMgr.CreateApplicationSecurityGroups()
+			err := securityMgr.CreateApplicationSecurityGroups(context.Background())
 			Expect(err).Should(HaveOccurred())
 			Expect(err.Error()).Should(ContainSubstring("Security group [dns] does not exist"))
 			Expect(fakeClient.BindRunningSecurityGroupCallCount()).Should(Equal(0))
@@ -465,7 +476,7 @@ var _ = Describe("given Security Group Manager", func() {
 		It("Should create and assign group to space", func() {
 			fakeClient.ListAllReturns(nil, nil)
 			fakeClient.CreateReturns(&resource.SecurityGroup{Name: "org1-space1", GUID: "org1-space1-guid"}, nil)
-			err := securityMgr.CreateApplicationSecurityGroups()
+			err := securityMgr.CreateApplicationSecurityGroups(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeClient.CreateCallCount()).Should(Equal(1))
 			Expect(fakeClient.BindRunningSecurityGroupCallCount()).Should(Equal(1))
@@ -485,7 +496,7 @@ var _ = Describe("given Security Group Manager", func() {
 					},
 				},
 			}, nil)
-			err := securityMgr.CreateApplicationSecurityGroups()
+			err := securityMgr.CreateApplicationSecurityGroups(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeClient.UpdateCallCount()).Should(Equal(1))
 			Expect(fakeClient.BindRunningSecurityGroupCallCount()).Should(Equal(1))
@@ -513,7 +524,7 @@ var _ = Describe("given Security Group Manager", func() {
 					},
 				},
 			}, nil)
-			err := securityMgr.CreateApplicationSecurityGroups()
+			err := securityMgr.CreateApplicationSecurityGroups(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeClient.UpdateCallCount()).Should(Equal(1))
 			Expect(fakeClient.BindRunningSecurityGroupCallCount()).Should(Equal(0))
@@ -523,12 +534,24 @@ var _ = Describe("given Security Group Manager", func() {
 			securityMgr.Peek = true
 			fakeClient.ListAllReturns(nil, nil)
 			fakeClient.CreateReturns(&resource.SecurityGroup{Name: "org1-space1", GUID: "org1-space1-guid"}, nil)
-			err := securityMgr.CreateApplicationSecurityGroups()
+			err := securityMgr.CreateApplicationSecurityGroups(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeClient.CreateCallCount()).Should(Equal(0))
 			Expect(fakeClient.BindRunningSecurityGroupCallCount()).Should(Equal(0))
 		})
 
+		It("Should list a peeked security group as non default", func() {
+			securityMgr.Peek = true
+			fakeClient.ListAllReturns(nil, nil)
+			sg, err := securityMgr.CreateSecurityGroup(context.Background(), "new-asg", "[]")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(sg.Name).Should(Equal("new-asg"))
+			Expect(sg.GUID).Should(Equal("new-asg-dry-run-security-group-guid"))
+			sgs, err := securityMgr.ListNonDefaultSecurityGroups(context.Background())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(sgs).Should(HaveKey("new-asg"))
+		})
+
 		It("Should not update and not assign group to space", func() {
 			securityMgr.Peek = true
 			fakeClient.ListAllReturns([]*resource.SecurityGroup{
@@ -541,7 +564,7 @@ var _ = Describe("given Security Group Manager", func() {
 					},
 				},
 			}, nil)
-			err := securityMgr.CreateApplicationSecurityGroups()
+			err := securityMgr.CreateApplicationSecurityGroups(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeClient.UpdateCallCount()).Should(Equal(0))
 			Expect(fakeClient.BindRunningSecurityGroupCallCount()).Should(Equal(0))
@@ -549,18 +572,18 @@ var _ = Describe("given Security Group Manager", func() {
 
 		It("Should error on get space config", func() {
 			fakeReader.GetSpaceConfigsReturns(nil, errors.New("error"))
-			err := securityMgr.CreateApplicationSecurityGroups()
+			err := securityMgr.CreateApplicationSecurityGroups(context.Background())
 			Expect(err).Should(HaveOccurred())
 		})
 
 		It("Should error listing security groups", func() {
 			fakeClient.ListAllReturns(nil, errors.New("error"))
-			err := securityMgr.CreateApplicationSecurityGroups()
+			err := securityMgr.CreateApplicationSecurityGroups(context.Background())
 			Expect(err).Should(HaveOccurred())
 		})
 		It("Should error returning space", func() {
 			fakeSpaceMgr.FindSpaceReturns(nil, errors.New("error"))
-			err := securityMgr.CreateApplicationSecurityGroups()
+			err := securityMgr.CreateApplicationSecurityGroups(context.Background())
 			Expect(err).Should(HaveOccurred())
 		})
 	})
@@ -574,7 +597,7 @@ var _ = Describe("given Security Group Manager", func() {
 				},
 			}
 			fakeReader.GetASGConfigsReturns(asgConfigs, nil)
-			err := securityMgr.CreateGlobalSecurityGroups()
+			err := securityMgr.CreateGlobalSecurityGroups(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeClient.CreateCallCount()).Should(Equal(1))
 		})
@@ -587,7 +610,7 @@ var _ = Describe("given Security Group Manager", func() {
 				},
 			}
 			fakeReader.GetDefaultASGConfigsReturns(asgConfigs, nil)
-			err := securityMgr.CreateGlobalSecurityGroups()
+			err := securityMgr.CreateGlobalSecurityGroups(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeClient.CreateCallCount()).Should(Equal(1))
 		})
@@ -607,7 +630,7 @@ var _ = Describe("given Security Group Manager", func() {
 				},
 			}, nil)
 			fakeReader.GetASGConfigsReturns(asgConfigs, nil)
-			err := securityMgr.CreateGlobalSecurityGroups()
+			err := securityMgr.CreateGlobalSecurityGroups(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeClient.UpdateCallCount()).Should(Equal(1))
 		})
@@ -630,7 +653,7 @@ var _ = Describe("given Security Group Manager", func() {
 				},
 			}, nil)
 			fakeReader.GetASGConfigsReturns(asgConfigs, nil)
-			err = securityMgr.CreateGlobalSecurityGroups()
+			err = securityMgr.CreateGlobalSecurityGroups(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeClient.UpdateCallCount()).Should(Equal(0))
 		})
@@ -644,7 +667,7 @@ var _ = Describe("given Security Group Manager", func() {
 			}
 			fakeReader.GetASGConfigsReturns(asgConfigs, nil)
 			fakeClient.CreateReturns(nil, errors.New("error"))
-			err := securityMgr.CreateGlobalSecurityGroups()
+			err := securityMgr.CreateGlobalSecurityGroups(context.Background())
 			Expect(err).Should(HaveOccurred())
 			Expect(fakeClient.CreateCallCount()).Should(Equal(1))
 		})
@@ -665,7 +688,7 @@ var _ = Describe("given Security Group Manager", func() {
 			}, nil)
 			fakeClient.UpdateReturns(nil, errors.New("error"))
 			fakeReader.GetASGConfigsReturns(asgConfigs, nil)
-			err := securityMgr.CreateGlobalSecurityGroups()
+			err := securityMgr.CreateGlobalSecurityGroups(context.Background())
 			Expect(err).Should(HaveOccurred())
 			Expect(fakeClient.UpdateCallCount()).Should(Equal(1))
 		})
@@ -686,24 +709,24 @@ var _ = Describe("given Security Group Manager", func() {
 			}, nil)
 			fakeClient.UpdateReturns(nil, errors.New("error"))
 			fakeReader.GetDefaultASGConfigsReturns(asgConfigs, nil)
-			err := securityMgr.CreateGlobalSecurityGroups()
+			err := securityMgr.CreateGlobalSecurityGroups(context.Background())
 			Expect(err).Should(HaveOccurred())
 			Expect(fakeClient.UpdateCallCount()).Should(Equal(1))
 		})
 
 		It("should error on getting asg config", func() {
 			fakeReader.GetASGConfigsReturns(nil, errors.New("errorr"))
-			err := securityMgr.CreateGlobalSecurityGroups()
+			err := securityMgr.CreateGlobalSecurityGroups(context.Background())
 			Expect(err).Should(HaveOccurred())
 		})
 		It("should error on getting default asg config", func() {
 			fakeReader.GetDefaultASGConfigsReturns(nil, errors.New("errorr"))
-			err := securityMgr.CreateGlobalSecurityGroups()
+			err := securityMgr.CreateGlobalSecurityGroups(context.Background())
 			Expect(err).Should(HaveOccurred())
 		})
 		It("should error on getting security groups", func() {
 			fakeClient.ListAllReturns(nil, errors.New("errorr"))
-			err := securityMgr.CreateGlobalSecurityGroups()
+			err := securityMgr.CreateGlobalSecurityGroups(context.Background())
 			Expect(err).Should(HaveOccurred())
 		})
 
@@ -715,7 +738,7 @@ var _ = Describe("given Security Group Manager", func() {
 				},
 			}
 			fakeReader.GetASGConfigsReturns(asgConfigs, nil)
-			err := securityMgr.CreateGlobalSecurityGroups()
+			err := securityMgr.CreateGlobalSecurityGroups(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeClient.CreateCallCount()).Should(Equal(1))
 			_, securityGroupCreate := fakeClient.CreateArgsForCall(0)
@@ -732,7 +755,7 @@ var _ = Describe("given Security Group Manager", func() {
 				},
 			}
 			fakeReader.GetASGConfigsReturns(asgConfigs, nil)
-			err := securityMgr.CreateGlobalSecurityGroups()
+			err := securityMgr.CreateGlobalSecurityGroups(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeClient.CreateCallCount()).Should(Equal(1))
 			_, securityGroupCreate := fakeClient.CreateArgsForCall(0)
@@ -754,7 +777,7 @@ var _ = Describe("given Security Group Manager", func() {
 			fakeReader.GetGlobalConfigReturns(&config.GlobalConfig{
 				RunningSecurityGroups: []string{"asg-1"},
 			}, nil)
-			err := securityMgr.AssignDefaultSecurityGroups()
+			err := securityMgr.AssignDefaultSecurityGroups(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeClient.UpdateCallCount()).Should(Equal(1))
 			_, sgGUID, updateRequest := fakeClient.UpdateArgsForCall(0)
@@ -777,7 +800,7 @@ var _ = Describe("given Security Group Manager", func() {
 			fakeReader.GetGlobalConfigReturns(&config.GlobalConfig{
 				RunningSecurityGroups: []string{"asg-1"},
 			}, nil)
-			err := securityMgr.AssignDefaultSecurityGroups()
+			err := securityMgr.AssignDefaultSecurityGroups(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeClient.UpdateCallCount()).Should(Equal(0))
 		})
@@ -787,7 +810,7 @@ var _ = Describe("given Security Group Manager", func() {
 			fakeReader.GetGlobalConfigReturns(&config.GlobalConfig{
 				RunningSecurityGroups: []string{"asg-1"},
 			}, nil)
-			err := securityMgr.AssignDefaultSecurityGroups()
+			err := securityMgr.AssignDefaultSecurityGroups(context.Background())
 			Expect(err).Should(HaveOccurred())
 			Expect(err.Error()).Should(Equal("Running security group [asg-1] does not exist"))
 		})
@@ -803,7 +826,7 @@ var _ = Describe("given Security Group Manager", func() {
 			fakeReader.GetGlobalConfigReturns(&config.GlobalConfig{
 				StagingSecurityGroups: []string{"asg-1"},
 			}, nil)
-			err := securityMgr.AssignDefaultSecurityGroups()
+			err := securityMgr.AssignDefaultSecurityGroups(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeClient.UpdateCallCount()).Should(Equal(1))
 			_, sgGUID, updateRequest := fakeClient.UpdateArgsForCall(0)
@@ -826,7 +849,7 @@ var _ = Describe("given Security Group Manager", func() {
 			fakeReader.GetGlobalConfigReturns(&config.GlobalConfig{
 				StagingSecurityGroups: []string{"asg-1"},
 			}, nil)
-			err := securityMgr.AssignDefaultSecurityGroups()
+			err := securityMgr.AssignDefaultSecurityGroups(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeClient.UpdateCallCount()).Should(Equal(0))
 		})
@@ -836,7 +859,7 @@ var _ = Describe("given Security Group Manager", func() {
 			fakeReader.GetGlobalConfigReturns(&config.GlobalConfig{
 				StagingSecurityGroups: []string{"asg-1"},
 			}, nil)
-			err := securityMgr.AssignDefaultSecurityGroups()
+			err := securityMgr.AssignDefaultSecurityGroups(context.Background())
 			Expect(err).Should(HaveOccurred())
 			Expect(err.Error()).Should(Equal("Staging security group [asg-1] does not exist"))
 		})
@@ -856,7 +879,7 @@ var _ = Describe("given Security Group Manager", func() {
 			fakeReader.GetGlobalConfigReturns(&config.GlobalConfig{
 				EnableUnassignSecurityGroups: true,
 			}, nil)
-			err := securityMgr.AssignDefaultSecurityGroups()
+			err := securityMgr.AssignDefaultSecurityGroups(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeClient.UpdateCallCount()).Should(Equal(1))
 			_, sgGUID, updateRequest := fakeClient.UpdateArgsForCall(0)
@@ -878,7 +901,7 @@ var _ = Describe("given Security Group Manager", func() {
 			fakeReader.GetGlobalConfigReturns(&config.GlobalConfig{
 				EnableUnassignSecurityGroups: true,
 			}, nil)
-			err := securityMgr.AssignDefaultSecurityGroups()
+			err := securityMgr.AssignDefaultSecurityGroups(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeClient.UpdateCallCount()).Should(Equal(1))
 			_, sgGUID, updateRequest := fakeClient.UpdateArgsForCall(0)
@@ -893,13 +916,13 @@ var _ = Describe("given Security Group Manager", func() {
 				{Name: "1", GUID: "1-guid"},
 				{Name: "2", GUID: "2-guid"},
 			}, nil)
-			secGroups, err := securityMgr.ListSpaceSecurityGroups("spaceGUID")
+			secGroups, err := securityMgr.ListSpaceSecurityGroups(context.Background(), "spaceGUID")
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(len(secGroups)).Should(Equal(2))
 		})
 		It("Should error", func() {
 			fakeClient.ListRunningForSpaceAllReturns(nil, errors.New("error"))
-			_, err := securityMgr.ListSpaceSecurityGroups("spaceGUID")
+			_, err := securityMgr.ListSpaceSecurityGroups(context.Background(), "spaceGUID")
 			Expect(err).Should(HaveOccurred())
 		})
 	})
@@ -914,20 +937,20 @@ var _ = Describe("given Security Group Manager", func() {
 				GUID:  "1-guid",
 				Rules: securityGroupRules,
 			}, nil)
-			bytes, err := securityMgr.GetSecurityGroupRules("sgGUID")
+			bytes, err := securityMgr.GetSecurityGroupRules(context.Background(), "sgGUID")
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(bytes).ShouldNot(BeNil())
 		})
 		It("Should error", func() {
 			fakeClient.GetReturns(nil, errors.New("error"))
-			_, err := securityMgr.GetSecurityGroupRules("sgGUID")
+			_, err := securityMgr.GetSecurityGroupRules(context.Background(), "sgGUID")
 			Expect(err).Should(HaveOccurred())
 		})
 	})
 
 	Context("UnassignSecurityGroupGlobalStaging", func() {
 		It("Should succeed", func() {
-			err := securityMgr.UnassignSecurityGroupGlobalStaging(&resource.SecurityGroup{
+			err := securityMgr.UnassignSecurityGroupGlobalStaging(context.Background(), &resource.SecurityGroup{
 				Name: "sec-group",
 				GUID: "seg-group-guid",
 			})
@@ -938,7 +961,7 @@ var _ = Describe("given Security Group Manager", func() {
 		})
 		It("Should peek", func() {
 			securityMgr.Peek = true
-			err := securityMgr.UnassignSecurityGroupGlobalStaging(&resource.SecurityGroup{
+			err := securityMgr.UnassignSecurityGroupGlobalStaging(context.Background(), &resource.SecurityGroup{
 				Name: "sec-group",
 				GUID: "seg-group-guid",
 			})
@@ -949,7 +972,7 @@ var _ = Describe("given Security Group Manager", func() {
 
 	Context("UnassignSecurityGroupGlobalRunning", func() {
 		It("Should succeed", func() {
-			err := securityMgr.UnassignSecurityGroupGlobalRunning(&resource.SecurityGroup{
+			err := securityMgr.UnassignSecurityGroupGlobalRunning(context.Background(), &resource.SecurityGroup{
 				Name: "sec-group",
 				GUID: "seg-group-guid",
 			})
@@ -961,7 +984,7 @@ var _ = Describe("given Security Group Manager", func() {
 		})
 		It("Should peek", func() {
 			securityMgr.Peek = true
-			err := securityMgr.UnassignSecurityGroupGlobalRunning(&resource.SecurityGroup{
+			err := securityMgr.UnassignSecurityGroupGlobalRunning(context.Background(), &resource.SecurityGroup{
 				Name: "sec-group",
 				GUID: "seg-group-guid",
 			})
@@ -972,7 +995,7 @@ var _ = Describe("given Security Group Manager", func() {
 
 	Context("AssignSecurityGroupGlobalStaging", func() {
 		It("Should succeed", func() {
-			err := securityMgr.AssignSecurityGroupGlobalStaging(&resource.SecurityGroup{
+			err := securityMgr.AssignSecurityGroupGlobalStaging(context.Background(), &resource.SecurityGroup{
 				Name: "sec-group",
 				GUID: "seg-group-guid",
 			})
@@ -984,7 +1007,7 @@ var _ = Describe("given Security Group Manager", func() {
 		})
 		It("Should peek", func() {
 			securityMgr.Peek = true
-			err := securityMgr.AssignSecurityGroupGlobalStaging(&resource.SecurityGroup{
+			err := securityMgr.AssignSecurityGroupGlobalStaging(context.Background(), &resource.SecurityGroup{
 				Name: "sec-group",
 				GUID: "seg-group-guid",
 			})
@@ -995,7 +1018,7 @@ var _ = Describe("given Security Group Manager", func() {
 
 	Context("AssignSecurityGroupGlobalRunning", func() {
 		It("Should succeed", func() {
-			err := securityMgr.AssignSecurityGroupGlobalRunning(&resource.SecurityGroup{
+			err := securityMgr.AssignSecurityGroupGlobalRunning(context.Background(), &resource.SecurityGroup{
 				Name: "sec-group",
 				GUID: "seg-group-guid",
 			})
@@ -1007,7 +1030,7 @@ var _ = Describe("given Security Group Manager", func() {
 		})
 		It("Should peek", func() {
 			securityMgr.Peek = true
-			err := securityMgr.AssignSecurityGroupGlobalRunning(&resource.SecurityGroup{
+			err := securityMgr.AssignSecurityGroupGlobalRunning(context.Background(), &resource.SecurityGroup{
 				Name: "sec-group",
 				GUID: "seg-group-guid",
 			})
@@ -8,13 +8,13 @@ import (
 )
 
 type Manager interface {
-	ListNonDefaultSecurityGroups() (map[string]*resource.SecurityGroup, error)
-	ListDefaultSecurityGroups() (map[string]*resource.SecurityGroup, error)
-	ListSpaceSecurityGroups(spaceGUID string) (map[string]string, error)
-	GetSecurityGroupRules(sgGUID string) ([]byte, error)
-	CreateApplicationSecurityGroups() error
-	CreateGlobalSecurityGroups() error
-	AssignDefaultSecurityGroups() error
+	ListNonDefaultSecurityGroups(ctx context.Context) (map[string]*resource.SecurityGroup, error)
+	ListDefaultSecurityGroups(ctx context.Context) (map[string]*resource.SecurityGroup, error)
+	ListSpaceSecurityGroups(ctx context.Context, spaceGUID string) (map[string]string, error)
+	GetSecurityGroupRules(ctx context.Context, sgGUID string) ([]byte, error)
+	CreateApplicationSecurityGroups(ctx context.Context) error
+	CreateGlobalSecurityGroups(ctx context.Context) error
+	AssignDefaultSecurityGroups(ctx context.Context) error
 }
 
 type CFSecurityGroupClient interface {
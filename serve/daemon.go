@@ -0,0 +1,216 @@
+package serve
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/xchapter7x/lo"
+)
+
+const (
+	Success = "success"
+	Failure = "failure"
+
+	TriggerStartup      = "startup"
+	TriggerInterval     = "interval"
+	TriggerConfigChange = "config-change"
+)
+
+// RunFunc - applies the configuration once, returning how many changes it made
+type RunFunc func() (int, error)
+
+// Status - the state of the daemon and the outcome of its last run
+type Status struct {
+	Ready             bool       `json:"ready"`
+	Running           bool       `json:"running"`
+	Runs              int        `json:"runs"`
+	ConfigFingerprint string     `json:"config_fingerprint,omitempty"`
+	LastTrigger       string     `json:"last_trigger,omitempty"`
+	LastStart         *time.Time `json:"last_start,omitempty"`
+	LastEnd           *time.Time `json:"last_end,omitempty"`
+	LastDuration      string     `json:"last_duration,omitempty"`
+	LastResult        string     `json:"last_result,omitempty"`
+	LastError         string     `json:"last_error,omitempty"`
+	LastChanges       int        `json:"last_changes"`
+	LastSuccess       *time.Time `json:"last_success,omitempty"`
+}
+
+// Daemon - applies the configuration on start up, every interval and whenever
+// the config directory or one of its overlays changes, one run at a time
+type Daemon struct {
+	ConfigDir     string
+	Overlays      []string
+	Interval      time.Duration
+	WatchInterval time.Duration
+	Run           RunFunc
+	mutex         sync.Mutex
+	status        Status
+	now           func() time.Time
+}
+
+// NewDaemon - an interval or watch interval of 0 disables that trigger
+func NewDaemon(configDir string, interval, watchInterval time.Duration, run RunFunc) *Daemon {
+	return &Daemon{
+		ConfigDir:     configDir,
+		Interval:      interval,
+		WatchInterval: watchInterval,
+		Run:           run,
+		now:           time.Now,
+	}
+}
+
+// Start - runs once and then on every trigger until stop is closed.  A run in
+// progress when stop is closed is finished before Start returns.
+func (d *Daemon) Start(stop <-chan struct{}) {
+	fingerprint, err := Fingerprint(append([]string{d.ConfigDir}, d.Overlays...)...)
+	if err != nil {
+		lo.G.Errorf("unable to read config directory %s: %v", d.ConfigDir, err)
+	}
+	d.runOnce(TriggerStartup, fingerprint)
+
+	var interval, watch <-chan time.Time
+	if d.Interval > 0 {
+		ticker := time.NewTicker(d.Interval)
+		defer ticker.Stop()
+		interval = ticker.C
+	}
+	if d.WatchInterval > 0 {
+		ticker := time.NewTicker(d.WatchInterval)
+		defer ticker.Stop()
+		watch = ticker.C
+	}
+	for {
+		select {
+		case <-stop:
+			return
+		case <-interval:
+			current, err := Fingerprint(append([]string{d.ConfigDir}, d.Overlays...)...)
+			if err != nil {
+				lo.G.Errorf("unable to read config directory %s: %v", d.ConfigDir, err)
+			}
+			d.runOnce(TriggerInterval, current)
+		case <-watch:
+			current, err := Fingerprint(append([]string{d.ConfigDir}, d.Overlays...)...)
+			if err != nil {
+				lo.G.Errorf("unable to read config directory %s: %v", d.ConfigDir, err)
+				continue
+			}
+			if current != d.Status().ConfigFingerprint {
+				lo.G.Infof("config directory %s changed", d.ConfigDir)
+				d.runOnce(TriggerConfigChange, current)
+			}
+		}
+	}
+}
+
+func (d *Daemon) runOnce(trigger, fingerprint string) {
+	start := d.now()
+	d.mutex.Lock()
+	d.status.Running = true
+	d.status.LastTrigger = trigger
+	d.status.LastStart = &start
+	d.status.ConfigFingerprint = fingerprint
+	d.mutex.Unlock()
+
+	lo.G.Infof("starting run triggered by %s", trigger)
+	changes, err := d.Run()
+	end := d.now()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.status.Running = false
+	d.status.Runs++
+	d.status.LastEnd = &end
+	d.status.LastDuration = end.Sub(start).String()
+	d.status.LastChanges = changes
+	if err != nil {
+		d.status.Ready = false
+		d.status.LastResult = Failure
+		d.status.LastError = err.Error()
+		lo.G.Errorf("run triggered by %s failed after %s: %v", trigger, d.status.LastDuration, err)
+		return
+	}
+	d.status.Ready = true
+	d.status.LastResult = Success
+	d.status.LastError = ""
+	d.status.LastSuccess = &end
+	lo.G.Infof("run triggered by %s made %d change(s) in %s", trigger, changes, d.status.LastDuration)
+}
+
+// Status - a copy of the current status
+func (d *Daemon) Status() Status {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.status
+}
+
+// Handler - serves /healthz, which is ok while the process is up, /readyz, which
+// is ok once the last run succeeded, and the status as json on /status
+func (d *Daemon) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok\n")
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if !d.Status().Ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			io.WriteString(w, "not ready\n")
+			return
+		}
+		io.WriteString(w, "ok\n")
+	})
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(d.Status())
+	})
+	return mux
+}
+
+// Fingerprint - a hash of the name and contents of every file in the config
+// directories, ignoring the .git directory
+func Fingerprint(configDirs ...string) (string, error) {
+	hash := sha256.New()
+	for _, configDir := range configDirs {
+		err := filepath.Walk(configDir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				if info.Name() == ".git" {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			relativePath, err := filepath.Rel(configDir, path)
+			if err != nil {
+				return err
+			}
+			file, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			io.WriteString(hash, relativePath)
+			hash.Write([]byte{0})
+			if _, err := io.Copy(hash, file); err != nil {
+				return err
+			}
+			hash.Write([]byte{0})
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+		hash.Write([]byte{1})
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
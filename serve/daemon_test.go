@@ -0,0 +1,126 @@
+package serve_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vmwarepivotallabs/cf-mgmt/serve"
+)
+
+var _ = Describe("Daemon", func() {
+	var (
+		configDir string
+		mutex     sync.Mutex
+		runs      int
+		runErr    error
+		run       serve.RunFunc
+	)
+	BeforeEach(func() {
+		var err error
+		configDir, err = os.MkdirTemp("", "cf-mgmt-serve")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(os.WriteFile(filepath.Join(configDir, "orgs.yml"), []byte("orgs: []\n"), 0644)).Should(Succeed())
+		runs = 0
+		runErr = nil
+		run = func() (int, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			runs++
+			return 2, runErr
+		}
+	})
+	AfterEach(func() {
+		os.RemoveAll(configDir)
+	})
+	runCount := func() int {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return runs
+	}
+	get := func(daemon *serve.Daemon, path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		daemon.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		return recorder
+	}
+
+	It("runs on start up and is ready once the run succeeds", func() {
+		daemon := serve.NewDaemon(configDir, 0, 0, run)
+		Expect(get(daemon, "/readyz").Code).Should(Equal(http.StatusServiceUnavailable))
+		stop := make(chan struct{})
+		close(stop)
+		daemon.Start(stop)
+		Expect(runCount()).Should(Equal(1))
+		Expect(get(daemon, "/healthz").Code).Should(Equal(http.StatusOK))
+		Expect(get(daemon, "/readyz").Code).Should(Equal(http.StatusOK))
+
+		var status serve.Status
+		Expect(json.Unmarshal(get(daemon, "/status").Body.Bytes(), &status)).Should(Succeed())
+		Expect(status.Runs).Should(Equal(1))
+		Expect(status.LastResult).Should(Equal(serve.Success))
+		Expect(status.LastTrigger).Should(Equal(serve.TriggerStartup))
+		Expect(status.LastChanges).Should(Equal(2))
+		Expect(status.ConfigFingerprint).ShouldNot(BeEmpty())
+	})
+
+	It("isn't ready when the last run failed", func() {
+		runErr = errors.New("boom")
+		daemon := serve.NewDaemon(configDir, 0, 0, run)
+		stop := make(chan struct{})
+		close(stop)
+		daemon.Start(stop)
+		Expect(get(daemon, "/readyz").Code).Should(Equal(http.StatusServiceUnavailable))
+		Expect(daemon.Status().LastResult).Should(Equal(serve.Failure))
+		Expect(daemon.Status().LastError).Should(Equal("boom"))
+	})
+
+	It("runs again when the config directory changes", func() {
+		daemon := serve.NewDaemon(configDir, 0, 10*time.Millisecond, run)
+		stop := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			daemon.Start(stop)
+			close(done)
+		}()
+		Eventually(runCount).Should(Equal(1))
+		Consistently(runCount, 50*time.Millisecond).Should(Equal(1))
+
+		Expect(os.WriteFile(filepath.Join(configDir, "orgs.yml"), []byte("orgs: [foo]\n"), 0644)).Should(Succeed())
+		Eventually(runCount).Should(Equal(2))
+		Expect(daemon.Status().LastTrigger).Should(Equal(serve.TriggerConfigChange))
+		close(stop)
+		Eventually(done).Should(BeClosed())
+	})
+
+	It("runs every interval", func() {
+		daemon := serve.NewDaemon(configDir, 10*time.Millisecond, 0, run)
+		stop := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			daemon.Start(stop)
+			close(done)
+		}()
+		Eventually(runCount).Should(BeNumerically(">=", 3))
+		close(stop)
+		Eventually(done).Should(BeClosed())
+	})
+
+	Context("Fingerprint", func() {
+		It("changes with the contents and ignores .git", func() {
+			before, err := serve.Fingerprint(configDir)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(os.MkdirAll(filepath.Join(configDir, ".git"), 0755)).Should(Succeed())
+			Expect(os.WriteFile(filepath.Join(configDir, ".git", "HEAD"), []byte("ref"), 0644)).Should(Succeed())
+			Expect(serve.Fingerprint(configDir)).Should(Equal(before))
+			Expect(os.WriteFile(filepath.Join(configDir, "orgs.yml"), []byte("orgs: [foo]\n"), 0644)).Should(Succeed())
+			Expect(serve.Fingerprint(configDir)).ShouldNot(Equal(before))
+		})
+	})
+})
@@ -1,9 +1,11 @@
 package legacy
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
 	"github.com/vmwarepivotallabs/cf-mgmt/config"
 	"github.com/vmwarepivotallabs/cf-mgmt/organizationreader"
 	"github.com/vmwarepivotallabs/cf-mgmt/util"
@@ -12,11 +14,12 @@ import (
 
 func NewManager(client CFClient,
 	orgReader organizationreader.Reader,
-	cfg config.Reader, peek bool) *Manager {
+	cfg config.Reader, recorder *changes.Recorder, peek bool) *Manager {
 	return &Manager{
 		Client:    client,
 		OrgReader: orgReader,
 		Cfg:       cfg,
+		Recorder:  recorder,
 		Peek:      peek,
 	}
 }
@@ -25,10 +28,11 @@ type Manager struct {
 	Client    CFClient
 	Cfg       config.Reader
 	OrgReader organizationreader.Reader
+	Recorder  *changes.Recorder
 	Peek      bool
 }
 
-func (m *Manager) Apply() error {
+func (m *Manager) Apply(ctx context.Context) error {
 	globalCfg, err := m.Cfg.GetGlobalConfig()
 	if err != nil {
 		return err
@@ -51,7 +55,7 @@ func (m *Manager) Apply() error {
 	if err != nil {
 		return err
 	}
-	err = m.EnableProtectedOrgServiceAccess(serviceInfo, orgConfig.ProtectedOrgList())
+	err = m.EnableProtectedOrgServiceAccess(ctx, serviceInfo, orgConfig.ProtectedOrgList())
 	if err != nil {
 		return err
 	}
@@ -61,12 +65,12 @@ func (m *Manager) Apply() error {
 		return err
 	}
 
-	err = m.EnableOrgServiceAccess(serviceInfo, orgConfigs)
+	err = m.EnableOrgServiceAccess(ctx, serviceInfo, orgConfigs)
 	if err != nil {
 		return err
 	}
 
-	err = m.RemoveUnknownVisibilites(serviceInfo)
+	err = m.RemoveUnknownVisibilites(ctx, serviceInfo)
 	if err != nil {
 		return err
 	}
@@ -74,16 +78,23 @@ func (m *Manager) Apply() error {
 }
 
 // RemoveUnknownVisibilites - will remove any service plan visiblities that are not known by cf-mgmt
-func (m *Manager) RemoveUnknownVisibilites(serviceInfo *ServiceInfo) error {
+func (m *Manager) RemoveUnknownVisibilites(ctx context.Context, serviceInfo *ServiceInfo) error {
 	for servicePlanName, servicePlan := range serviceInfo.AllPlans() {
 		for _, plan := range servicePlan {
 			for _, visibility := range plan.ListVisibilities() {
+				org, err := m.OrgReader.FindOrgByGUID(ctx, visibility.OrganizationGuid)
+				if err != nil {
+					return err
+				}
+				change := changes.Change{Entity: changes.ServiceAccess, Action: changes.Unassign, Name: fmt.Sprintf("%s/%s", servicePlanName, plan.Name), Org: org.Name}
+				m.Recorder.Record(change)
 				if m.Peek {
 					lo.G.Infof("[dry-run]: removing plan %s for service %s to org with guid %s", plan.Name, servicePlanName, visibility.OrganizationGuid)
 					continue
 				}
 				lo.G.Infof("removing plan %s for service %s to org with guid %s", plan.Name, servicePlanName, visibility.OrganizationGuid)
-				err := m.Client.DeleteServicePlanVisibilityByPlanAndOrg(visibility.ServicePlanGuid, visibility.OrganizationGuid, false)
+				err = m.Client.DeleteServicePlanVisibilityByPlanAndOrg(visibility.ServicePlanGuid, visibility.OrganizationGuid, false)
+				m.Recorder.Applied(change, visibility.ServicePlanGuid, err)
 				if err != nil {
 					return err
 				}
@@ -138,22 +149,28 @@ func (m *Manager) ListServiceInfo() (*ServiceInfo, error) {
 	return serviceInfo, nil
 }
 
-func (m *Manager) EnableProtectedOrgServiceAccess(serviceInfo *ServiceInfo, protectedOrgs []string) error {
-	orgs, err := m.OrgReader.ListOrgs()
+func (m *Manager) EnableProtectedOrgServiceAccess(ctx context.Context, serviceInfo *ServiceInfo, protectedOrgs []string) error {
+	orgs, err := m.OrgReader.ListOrgs(ctx)
 	if err != nil {
 		return err
 	}
 	for _, org := range orgs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		if util.Matches(org.Name, protectedOrgs) {
 			for serviceName, plans := range serviceInfo.AllPlans() {
 				for _, servicePlan := range plans {
 					if !servicePlan.OrgHasAccess(org.GUID) {
+						change := changes.Change{Entity: changes.ServiceAccess, Action: changes.Assign, Name: fmt.Sprintf("%s/%s", serviceName, servicePlan.Name), Org: org.Name}
+						m.Recorder.Record(change)
 						if m.Peek {
 							lo.G.Infof("[dry-run]: adding plan %s for service %s to org %s", servicePlan.Name, serviceName, org.Name)
 							continue
 						}
 						lo.G.Infof("adding plan %s for service %s to org %s", servicePlan.Name, serviceName, org.Name)
 						_, err = m.Client.CreateServicePlanVisibility(servicePlan.GUID, org.GUID)
+						m.Recorder.Applied(change, servicePlan.GUID, err)
 						if err != nil {
 							return err
 						}
@@ -167,10 +184,13 @@ func (m *Manager) EnableProtectedOrgServiceAccess(serviceInfo *ServiceInfo, prot
 	return nil
 }
 
-func (m *Manager) EnableOrgServiceAccess(serviceInfo *ServiceInfo, orgConfigs []config.OrgConfig) error {
+func (m *Manager) EnableOrgServiceAccess(ctx context.Context, serviceInfo *ServiceInfo, orgConfigs []config.OrgConfig) error {
 	for _, orgConfig := range orgConfigs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		if orgConfig.ServiceAccess != nil {
-			org, err := m.OrgReader.FindOrg(orgConfig.Org)
+			org, err := m.OrgReader.FindOrg(ctx, orgConfig.Org)
 			if err != nil {
 				return err
 			}
@@ -182,12 +202,15 @@ func (m *Manager) EnableOrgServiceAccess(serviceInfo *ServiceInfo, orgConfigs []
 				}
 				for _, servicePlan := range servicePlans {
 					if !servicePlan.OrgHasAccess(org.GUID) {
+						change := changes.Change{Entity: changes.ServiceAccess, Action: changes.Assign, Name: fmt.Sprintf("%s/%s", serviceName, servicePlan.Name), Org: org.Name}
+						m.Recorder.Record(change)
 						if m.Peek {
 							lo.G.Infof("[dry-run]: adding plan %s for service %s to org %s", servicePlan.Name, serviceName, org.Name)
 							continue
 						}
 						lo.G.Infof("adding plan %s for service %s to org %s", servicePlan.Name, serviceName, org.Name)
 						_, err = m.Client.CreateServicePlanVisibility(servicePlan.GUID, org.GUID)
+						m.Recorder.Applied(change, servicePlan.GUID, err)
 						if err != nil {
 							return err
 						}
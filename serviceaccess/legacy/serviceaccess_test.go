@@ -1,10 +1,13 @@
 package legacy_test
 
 import (
+	"context"
+
 	"errors"
 	"fmt"
 	"net/url"
 
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
 	"github.com/vmwarepivotallabs/cf-mgmt/config"
 	. "github.com/vmwarepivotallabs/cf-mgmt/serviceaccess/legacy"
 
@@ -27,7 +30,7 @@ var _ = Describe("Serviceaccess", func() {
 		fakeCFClient = &fakes.FakeCFClient{}
 		fakeOrgReader = &orgfakes.FakeReader{}
 		fakeReader = &configfakes.FakeReader{}
-		manager = NewManager(fakeCFClient, fakeOrgReader, fakeReader, false)
+		manager = NewManager(fakeCFClient, fakeOrgReader, fakeReader, nil, false)
 	})
 
 	Context("Apply", func() {
@@ -58,7 +61,8 @@ var _ = Describe("Serviceaccess", func() {
 			}, nil)
 
 			fakeOrgReader.FindOrgReturns(&resource.Organization{Name: "test-org", GUID: "test-org-guid"}, nil)
-			err := manager.Apply()
+			fakeOrgReader.FindOrgByGUIDReturns(&resource.Organization{Name: "org1", GUID: "org1-guid"}, nil)
+			err := manager.Apply(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.MakeServicePlanPrivateCallCount()).To(Equal(1))
 			Expect(fakeCFClient.CreateServicePlanVisibilityCallCount()).To(Equal(2))
@@ -80,8 +84,18 @@ var _ = Describe("Serviceaccess", func() {
 			servicePlanInfo := serviceInfo.AddPlan("p-mysql", cfclient.ServicePlan{Guid: "10mb-guid", Name: "10mb"})
 			servicePlanInfo.AddOrg("system-org-guid", cfclient.ServicePlanVisibility{ServicePlanGuid: "service-plan-guid", OrganizationGuid: "unknown_org_guid"})
 
-			err := manager.RemoveUnknownVisibilites(serviceInfo)
+			fakeOrgReader.FindOrgByGUIDReturns(&resource.Organization{GUID: "unknown_org_guid", Name: "unknown-org"}, nil)
+			manager.Recorder = changes.NewRecorder()
+			err := manager.RemoveUnknownVisibilites(context.Background(), serviceInfo)
 			Expect(err).ShouldNot(HaveOccurred())
+			_, orgGUID := fakeOrgReader.FindOrgByGUIDArgsForCall(0)
+			Expect(orgGUID).To(Equal("unknown_org_guid"))
+			Expect(manager.Recorder.Changes()).To(ConsistOf(changes.Change{
+				Entity: changes.ServiceAccess,
+				Action: changes.Unassign,
+				Name:   "p-mysql/10mb",
+				Org:    "unknown-org",
+			}))
 			Expect(fakeCFClient.DeleteServicePlanVisibilityByPlanAndOrgCallCount()).To(Equal(1))
 			visibilityGUID, orgGUID, async := fakeCFClient.DeleteServicePlanVisibilityByPlanAndOrgArgsForCall(0)
 			Expect(visibilityGUID).To(Equal("service-plan-guid"))
@@ -97,7 +111,7 @@ var _ = Describe("Serviceaccess", func() {
 			serviceInfo.AddPlan("p-mysql", cfclient.ServicePlan{Guid: "20mb-guid", Name: "20mb"})
 
 			fakeOrgReader.ListOrgsReturns([]*resource.Organization{{GUID: "system-org-guid", Name: "system"}}, nil)
-			err := manager.EnableProtectedOrgServiceAccess(serviceInfo, []string{"system"})
+			err := manager.EnableProtectedOrgServiceAccess(context.Background(), serviceInfo, []string{"system"})
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.CreateServicePlanVisibilityCallCount()).To(Equal(2))
 		})
@@ -108,7 +122,7 @@ var _ = Describe("Serviceaccess", func() {
 			servicePlanInfo.AddOrg("system-org-guid", cfclient.ServicePlanVisibility{Guid: "visibility-guid"})
 
 			fakeOrgReader.ListOrgsReturns([]*resource.Organization{{GUID: "system-org-guid", Name: "system"}}, nil)
-			err := manager.EnableProtectedOrgServiceAccess(serviceInfo, []string{"system"})
+			err := manager.EnableProtectedOrgServiceAccess(context.Background(), serviceInfo, []string{"system"})
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.CreateServicePlanVisibilityCallCount()).To(Equal(1))
 			servicePlanGUID, orgGUID := fakeCFClient.CreateServicePlanVisibilityArgsForCall(0)
@@ -121,7 +135,7 @@ var _ = Describe("Serviceaccess", func() {
 			servicePlanInfo.AddOrg("system-org-guid", cfclient.ServicePlanVisibility{Guid: "visibility-guid"})
 
 			fakeOrgReader.ListOrgsReturns([]*resource.Organization{{GUID: "system-org-guid", Name: "system"}}, nil)
-			err := manager.EnableProtectedOrgServiceAccess(serviceInfo, []string{"system"})
+			err := manager.EnableProtectedOrgServiceAccess(context.Background(), serviceInfo, []string{"system"})
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.CreateServicePlanVisibilityCallCount()).To(Equal(0))
 		})
@@ -130,7 +144,7 @@ var _ = Describe("Serviceaccess", func() {
 			serviceInfo := &ServiceInfo{}
 			serviceInfo.AddPlan("p-mysql", cfclient.ServicePlan{Guid: "10mb-guid", Name: "10mb"})
 			fakeOrgReader.ListOrgsReturns(nil, errors.New("Org not found"))
-			err := manager.EnableProtectedOrgServiceAccess(serviceInfo, []string{"system"})
+			err := manager.EnableProtectedOrgServiceAccess(context.Background(), serviceInfo, []string{"system"})
 			Expect(err).Should(MatchError("Org not found"))
 			Expect(fakeCFClient.CreateServicePlanVisibilityCallCount()).To(Equal(0))
 		})
@@ -141,7 +155,7 @@ var _ = Describe("Serviceaccess", func() {
 
 			fakeOrgReader.ListOrgsReturns([]*resource.Organization{{GUID: "system-org-guid", Name: "system"}}, nil)
 			fakeCFClient.CreateServicePlanVisibilityReturns(cfclient.ServicePlanVisibility{}, errors.New("Error creating visibility"))
-			err := manager.EnableProtectedOrgServiceAccess(serviceInfo, []string{"system"})
+			err := manager.EnableProtectedOrgServiceAccess(context.Background(), serviceInfo, []string{"system"})
 			Expect(err).Should(MatchError("Error creating visibility"))
 			Expect(fakeCFClient.CreateServicePlanVisibilityCallCount()).To(Equal(1))
 		})
@@ -160,7 +174,7 @@ var _ = Describe("Serviceaccess", func() {
 				},
 			}
 			fakeOrgReader.FindOrgReturns(&resource.Organization{GUID: "test-org-guid", Name: "test-org"}, nil)
-			err := manager.EnableOrgServiceAccess(serviceInfo, orgConfigs)
+			err := manager.EnableOrgServiceAccess(context.Background(), serviceInfo, orgConfigs)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.CreateServicePlanVisibilityCallCount()).To(Equal(1))
 			servicePlanGUID, orgGUID := fakeCFClient.CreateServicePlanVisibilityArgsForCall(0)
@@ -182,7 +196,7 @@ var _ = Describe("Serviceaccess", func() {
 				},
 			}
 			fakeOrgReader.FindOrgReturns(&resource.Organization{GUID: "test-org-guid", Name: "test-org"}, nil)
-			err := manager.EnableOrgServiceAccess(serviceInfo, orgConfigs)
+			err := manager.EnableOrgServiceAccess(context.Background(), serviceInfo, orgConfigs)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.CreateServicePlanVisibilityCallCount()).To(Equal(0))
 		})
@@ -200,7 +214,7 @@ var _ = Describe("Serviceaccess", func() {
 				},
 			}
 			fakeOrgReader.FindOrgReturns(&resource.Organization{GUID: "test-org-guid", Name: "test-org"}, nil)
-			err := manager.EnableOrgServiceAccess(serviceInfo, orgConfigs)
+			err := manager.EnableOrgServiceAccess(context.Background(), serviceInfo, orgConfigs)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.CreateServicePlanVisibilityCallCount()).To(Equal(0))
 		})
@@ -218,7 +232,7 @@ var _ = Describe("Serviceaccess", func() {
 				},
 			}
 			fakeOrgReader.FindOrgReturns(&resource.Organization{GUID: "test-org-guid", Name: "test-org"}, errors.New("Org not found"))
-			err := manager.EnableOrgServiceAccess(serviceInfo, orgConfigs)
+			err := manager.EnableOrgServiceAccess(context.Background(), serviceInfo, orgConfigs)
 			Expect(err).Should(MatchError("Org not found"))
 			Expect(fakeCFClient.CreateServicePlanVisibilityCallCount()).To(Equal(0))
 		})
@@ -237,7 +251,7 @@ var _ = Describe("Serviceaccess", func() {
 			}
 			fakeOrgReader.FindOrgReturns(&resource.Organization{GUID: "test-org-guid", Name: "test-org"}, nil)
 			fakeCFClient.CreateServicePlanVisibilityReturns(cfclient.ServicePlanVisibility{}, errors.New("Error creating visibility"))
-			err := manager.EnableOrgServiceAccess(serviceInfo, orgConfigs)
+			err := manager.EnableOrgServiceAccess(context.Background(), serviceInfo, orgConfigs)
 			Expect(err).Should(MatchError("Error creating visibility"))
 			Expect(fakeCFClient.CreateServicePlanVisibilityCallCount()).To(Equal(1))
 		})
@@ -1,8 +1,11 @@
 package serviceaccess
 
 import (
+	"context"
+	"fmt"
 	"strings"
 
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
 	"github.com/vmwarepivotallabs/cf-mgmt/config"
 	"github.com/vmwarepivotallabs/cf-mgmt/organizationreader"
 	"github.com/vmwarepivotallabs/cf-mgmt/serviceaccess/legacy"
@@ -12,13 +15,14 @@ import (
 
 func NewManager(client CFClient,
 	orgReader organizationreader.Reader,
-	cfg config.Reader, peek bool) *Manager {
+	cfg config.Reader, recorder *changes.Recorder, peek bool) *Manager {
 	return &Manager{
 		Client:    client,
 		OrgReader: orgReader,
 		Cfg:       cfg,
+		Recorder:  recorder,
 		Peek:      peek,
-		LegacyMgr: legacy.NewManager(client, orgReader, cfg, peek),
+		LegacyMgr: legacy.NewManager(client, orgReader, cfg, recorder, peek),
 	}
 }
 
@@ -26,11 +30,16 @@ type Manager struct {
 	Client    CFClient
 	Cfg       config.Reader
 	OrgReader organizationreader.Reader
+	Recorder  *changes.Recorder
 	Peek      bool
 	LegacyMgr *legacy.Manager
 }
 
-func (m *Manager) Apply() error {
+func planName(servicePlan *ServicePlanInfo) string {
+	return fmt.Sprintf("%s/%s", servicePlan.ServiceName, servicePlan.Name)
+}
+
+func (m *Manager) Apply(ctx context.Context) error {
 	globalCfg, err := m.Cfg.GetGlobalConfig()
 	if err != nil {
 		return err
@@ -50,21 +59,21 @@ func (m *Manager) Apply() error {
 
 		if len(orgList) > 0 && !globalCfg.IgnoreLegacyServiceAccess {
 			lo.G.Warning("**** Deprecated **** - run `cf-mgmt export-service-access-config` and check in configuration changes as services-access for orgs [%s] is no longer supported in orgConfig.yml", strings.Join(orgList, ","))
-			return m.LegacyMgr.Apply()
+			return m.LegacyMgr.Apply(ctx)
 		}
 	}
 	serviceInfo, err := m.ListServiceInfo()
 	if err != nil {
 		return err
 	}
-	protectedOrgs, err := m.ProtectedOrgList()
+	protectedOrgs, err := m.ProtectedOrgList(ctx)
 	if err != nil {
 		return err
 	}
-	return m.UpdateServiceAccess(globalCfg, serviceInfo, protectedOrgs)
+	return m.UpdateServiceAccess(ctx, globalCfg, serviceInfo, protectedOrgs)
 }
 
-func (m *Manager) UpdateServiceAccess(globalCfg *config.GlobalConfig, serviceInfo *ServiceInfo, protectedOrgs []string) error {
+func (m *Manager) UpdateServiceAccess(ctx context.Context, globalCfg *config.GlobalConfig, serviceInfo *ServiceInfo, protectedOrgs []string) error {
 	if !globalCfg.EnableServiceAccess {
 		lo.G.Info("Service Access is not enabled.  Set enable-service-access: true in cf-mgmt.yml")
 		return nil
@@ -73,22 +82,25 @@ func (m *Manager) UpdateServiceAccess(globalCfg *config.GlobalConfig, serviceInf
 	for _, broker := range serviceInfo.StandardBrokers() {
 		for _, service := range broker.Services() {
 			for _, plan := range service.Plans() {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
 				planInfo := globalCfg.GetPlanInfo(broker.Name, service.Name, plan.Name)
 				if planInfo.NoAccess {
-					err := m.EnsureNoAccess(plan)
+					err := m.EnsureNoAccess(ctx, plan)
 					if err != nil {
 						return err
 					}
 					continue
 				}
 				if planInfo.Limited {
-					err := m.EnsureLimitedAccess(plan, planInfo.Orgs, protectedOrgs)
+					err := m.EnsureLimitedAccess(ctx, plan, planInfo.Orgs, protectedOrgs)
 					if err != nil {
 						return err
 					}
 					continue
 				}
-				err := m.EnsurePublicAccess(plan)
+				err := m.EnsurePublicAccess(ctx, plan)
 				if err != nil {
 					return err
 				}
@@ -98,29 +110,29 @@ func (m *Manager) UpdateServiceAccess(globalCfg *config.GlobalConfig, serviceInf
 	return nil
 }
 
-func (m *Manager) EnsurePublicAccess(plan *ServicePlanInfo) error {
+func (m *Manager) EnsurePublicAccess(ctx context.Context, plan *ServicePlanInfo) error {
 	if !plan.Public {
 		err := m.MakePublic(plan)
 		if err != nil {
 			return err
 		}
 	}
-	return m.RemoveVisibilities(plan)
+	return m.RemoveVisibilities(ctx, plan)
 }
 
-func (m *Manager) EnsureLimitedAccess(plan *ServicePlanInfo, orgs, protectedOrgs []string) error {
+func (m *Manager) EnsureLimitedAccess(ctx context.Context, plan *ServicePlanInfo, orgs, protectedOrgs []string) error {
 	lo.G.Debugf("Current Visibilities %+v for plan %s", plan.ListVisibilities(), plan.ServiceName)
 	err := m.MakePrivate(plan)
 	if err != nil {
 		return err
 	}
 	for _, orgName := range m.uniqueOrgs(orgs, protectedOrgs) {
-		err = m.CreatePlanVisibility(plan, orgName)
+		err = m.CreatePlanVisibility(ctx, plan, orgName)
 		if err != nil {
 			return err
 		}
 	}
-	return m.RemoveVisibilities(plan)
+	return m.RemoveVisibilities(ctx, plan)
 }
 
 func (m *Manager) uniqueOrgs(orgs, protectedOrgs []string) []string {
@@ -143,22 +155,22 @@ func (m *Manager) uniqueOrgs(orgs, protectedOrgs []string) []string {
 	return orgList
 }
 
-func (m *Manager) EnsureNoAccess(plan *ServicePlanInfo) error {
+func (m *Manager) EnsureNoAccess(ctx context.Context, plan *ServicePlanInfo) error {
 	if plan.Public {
 		err := m.MakePrivate(plan)
 		if err != nil {
 			return err
 		}
 	}
-	return m.RemoveVisibilities(plan)
+	return m.RemoveVisibilities(ctx, plan)
 }
 
-func (m *Manager) ProtectedOrgList() ([]string, error) {
+func (m *Manager) ProtectedOrgList(ctx context.Context) ([]string, error) {
 	orgConfig, err := m.Cfg.Orgs()
 	if err != nil {
 		return nil, err
 	}
-	orgs, err := m.OrgReader.ListOrgs()
+	orgs, err := m.OrgReader.ListOrgs(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -171,18 +183,21 @@ func (m *Manager) ProtectedOrgList() ([]string, error) {
 	return orgList, nil
 }
 
-func (m *Manager) CreatePlanVisibility(servicePlan *ServicePlanInfo, orgName string) error {
-	org, err := m.OrgReader.FindOrg(orgName)
+func (m *Manager) CreatePlanVisibility(ctx context.Context, servicePlan *ServicePlanInfo, orgName string) error {
+	org, err := m.OrgReader.FindOrg(ctx, orgName)
 	if err != nil {
 		return err
 	}
 	if !servicePlan.OrgHasAccess(org.GUID) {
+		change := changes.Change{Entity: changes.ServiceAccess, Action: changes.Assign, Name: planName(servicePlan), Org: orgName}
+		m.Recorder.Record(change)
 		if m.Peek {
 			lo.G.Infof("[dry-run]: adding plan %s for service %s to org %s", servicePlan.Name, servicePlan.ServiceName, orgName)
 			return nil
 		}
 		lo.G.Infof("adding plan %s for service %s to org %s", servicePlan.Name, servicePlan.ServiceName, orgName)
 		_, err = m.Client.CreateServicePlanVisibility(servicePlan.GUID, org.GUID)
+		m.Recorder.Applied(change, servicePlan.GUID, err)
 		if err != nil {
 			return err
 		}
@@ -195,12 +210,15 @@ func (m *Manager) CreatePlanVisibility(servicePlan *ServicePlanInfo, orgName str
 
 func (m *Manager) MakePublic(servicePlan *ServicePlanInfo) error {
 	if !servicePlan.Public {
+		change := changes.Change{Entity: changes.ServiceAccess, Action: changes.Update, Name: planName(servicePlan), Before: "private", After: "public"}
+		m.Recorder.Record(change)
 		if m.Peek {
 			lo.G.Infof("[dry-run]: Making plan %s for service %s public", servicePlan.Name, servicePlan.ServiceName)
 			return nil
 		}
 		lo.G.Infof("Making plan %s for service %s public", servicePlan.Name, servicePlan.ServiceName)
 		err := m.Client.MakeServicePlanPublic(servicePlan.GUID)
+		m.Recorder.Applied(change, servicePlan.GUID, err)
 		if err != nil {
 			return err
 		}
@@ -210,12 +228,15 @@ func (m *Manager) MakePublic(servicePlan *ServicePlanInfo) error {
 
 func (m *Manager) MakePrivate(servicePlan *ServicePlanInfo) error {
 	if servicePlan.Public {
+		change := changes.Change{Entity: changes.ServiceAccess, Action: changes.Update, Name: planName(servicePlan), Before: "public", After: "private"}
+		m.Recorder.Record(change)
 		if m.Peek {
 			lo.G.Infof("[dry-run]: Making plan %s for service %s private", servicePlan.Name, servicePlan.ServiceName)
 			return nil
 		}
 		lo.G.Infof("Making plan %s for service %s private", servicePlan.Name, servicePlan.ServiceName)
 		err := m.Client.MakeServicePlanPrivate(servicePlan.GUID)
+		m.Recorder.Applied(change, servicePlan.GUID, err)
 		if err != nil {
 			return err
 		}
@@ -223,18 +244,21 @@ func (m *Manager) MakePrivate(servicePlan *ServicePlanInfo) error {
 	return nil
 }
 
-func (m *Manager) RemoveVisibilities(servicePlan *ServicePlanInfo) error {
+func (m *Manager) RemoveVisibilities(ctx context.Context, servicePlan *ServicePlanInfo) error {
 	for _, visibility := range servicePlan.ListVisibilities() {
-		org, err := m.OrgReader.FindOrgByGUID(visibility.OrgGUID)
+		org, err := m.OrgReader.FindOrgByGUID(ctx, visibility.OrgGUID)
 		if err != nil {
 			return err
 		}
+		change := changes.Change{Entity: changes.ServiceAccess, Action: changes.Unassign, Name: planName(servicePlan), Org: org.Name}
+		m.Recorder.Record(change)
 		if m.Peek {
 			lo.G.Infof("[dry-run]: removing plan %s for service %s from org %s", servicePlan.Name, servicePlan.ServiceName, org.Name)
 			continue
 		}
 		lo.G.Infof("removing plan %s for service %s from org %s", servicePlan.Name, servicePlan.ServiceName, org.Name)
 		err = m.Client.DeleteServicePlanVisibilityByPlanAndOrg(visibility.ServicePlanGUID, visibility.OrgGUID, false)
+		m.Recorder.Applied(change, servicePlan.GUID, err)
 		if err != nil {
 			return err
 		}
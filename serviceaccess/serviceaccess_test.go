@@ -1,6 +1,8 @@
 package serviceaccess_test
 
 import (
+	"context"
+
 	"errors"
 
 	cfclient "github.com/cloudfoundry-community/go-cfclient"
@@ -25,7 +27,7 @@ var _ = Describe("Serviceaccess", func() {
 		fakeCFClient = &fakes.FakeCFClient{}
 		fakeOrgReader = &orgfakes.FakeReader{}
 		fakeReader = &configfakes.FakeReader{}
-		manager = NewManager(fakeCFClient, fakeOrgReader, fakeReader, false)
+		manager = NewManager(fakeCFClient, fakeOrgReader, fakeReader, nil, false)
 	})
 
 	Context("UpdateServiceAccess", func() {
@@ -34,7 +36,7 @@ var _ = Describe("Serviceaccess", func() {
 				EnableServiceAccess: false,
 			}
 			serviceInfo := &ServiceInfo{}
-			err := manager.UpdateServiceAccess(globalCfg, serviceInfo, []string{})
+			err := manager.UpdateServiceAccess(context.Background(), globalCfg, serviceInfo, []string{})
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.MakeServicePlanPrivateCallCount()).Should(Equal(0))
 			Expect(fakeCFClient.MakeServicePlanPublicCallCount()).Should(Equal(0))
@@ -53,7 +55,7 @@ var _ = Describe("Serviceaccess", func() {
 			servicePlan := &ServicePlanInfo{Name: "small", ServiceName: "p-mysql", GUID: "small-guid", Public: true}
 			service.AddPlan(servicePlan)
 			protectedOrgs := []string{"system"}
-			err := manager.UpdateServiceAccess(globalCfg, serviceInfo, protectedOrgs)
+			err := manager.UpdateServiceAccess(context.Background(), globalCfg, serviceInfo, protectedOrgs)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.MakeServicePlanPrivateCallCount()).Should(Equal(0))
 			Expect(fakeCFClient.MakeServicePlanPublicCallCount()).Should(Equal(0))
@@ -72,7 +74,7 @@ var _ = Describe("Serviceaccess", func() {
 			servicePlan := &ServicePlanInfo{Name: "small", ServiceName: "p-mysql", GUID: "small-guid", Public: false}
 			service.AddPlan(servicePlan)
 			protectedOrgs := []string{"system"}
-			err := manager.UpdateServiceAccess(globalCfg, serviceInfo, protectedOrgs)
+			err := manager.UpdateServiceAccess(context.Background(), globalCfg, serviceInfo, protectedOrgs)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.MakeServicePlanPrivateCallCount()).Should(Equal(0))
 			Expect(fakeCFClient.MakeServicePlanPublicCallCount()).Should(Equal(1))
@@ -102,7 +104,7 @@ var _ = Describe("Serviceaccess", func() {
 			servicePlan := &ServicePlanInfo{Name: "small", ServiceName: "p-mysql", GUID: "small-guid", Public: true}
 			service.AddPlan(servicePlan)
 			protectedOrgs := []string{"system"}
-			err := manager.UpdateServiceAccess(globalCfg, serviceInfo, protectedOrgs)
+			err := manager.UpdateServiceAccess(context.Background(), globalCfg, serviceInfo, protectedOrgs)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.MakeServicePlanPrivateCallCount()).Should(Equal(1))
 			Expect(fakeCFClient.MakeServicePlanPublicCallCount()).Should(Equal(0))
@@ -139,7 +141,7 @@ var _ = Describe("Serviceaccess", func() {
 			protectedOrgs := []string{"system"}
 
 			fakeOrgReader.FindOrgReturns(&resource.Organization{GUID: "test-org-guid"}, nil)
-			err := manager.UpdateServiceAccess(globalCfg, serviceInfo, protectedOrgs)
+			err := manager.UpdateServiceAccess(context.Background(), globalCfg, serviceInfo, protectedOrgs)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.MakeServicePlanPrivateCallCount()).Should(Equal(1))
 			Expect(fakeCFClient.MakeServicePlanPublicCallCount()).Should(Equal(0))
@@ -169,7 +171,7 @@ var _ = Describe("Serviceaccess", func() {
 				serviceInfo.AddBroker(spaceScopedBroker)
 				protectedOrgs := []string{}
 
-				err := manager.UpdateServiceAccess(globalCfg, serviceInfo, protectedOrgs)
+				err := manager.UpdateServiceAccess(context.Background(), globalCfg, serviceInfo, protectedOrgs)
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(fakeCFClient.MakeServicePlanPublicCallCount()).Should(Equal(0))
 			})
@@ -184,7 +186,7 @@ var _ = Describe("Serviceaccess", func() {
 				Public:      false,
 				ServiceName: "a-service",
 			}
-			err := manager.EnsurePublicAccess(plan)
+			err := manager.EnsurePublicAccess(context.Background(), plan)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.MakeServicePlanPrivateCallCount()).Should(Equal(0))
 			Expect(fakeCFClient.MakeServicePlanPublicCallCount()).Should(Equal(1))
@@ -199,7 +201,7 @@ var _ = Describe("Serviceaccess", func() {
 				ServiceName: "a-service",
 			}
 			fakeCFClient.MakeServicePlanPublicReturns(errors.New("error making plan public"))
-			err := manager.EnsurePublicAccess(plan)
+			err := manager.EnsurePublicAccess(context.Background(), plan)
 			Expect(err).Should(MatchError("error making plan public"))
 			Expect(fakeCFClient.MakeServicePlanPrivateCallCount()).Should(Equal(0))
 			Expect(fakeCFClient.MakeServicePlanPublicCallCount()).Should(Equal(1))
@@ -215,7 +217,7 @@ var _ = Describe("Serviceaccess", func() {
 				ServiceName: "a-service",
 			}
 			manager.Peek = true
-			err := manager.EnsurePublicAccess(plan)
+			err := manager.EnsurePublicAccess(context.Background(), plan)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.MakeServicePlanPrivateCallCount()).Should(Equal(0))
 			Expect(fakeCFClient.MakeServicePlanPublicCallCount()).Should(Equal(0))
@@ -230,7 +232,7 @@ var _ = Describe("Serviceaccess", func() {
 				Public:      true,
 				ServiceName: "a-service",
 			}
-			err := manager.EnsureNoAccess(plan)
+			err := manager.EnsureNoAccess(context.Background(), plan)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.MakeServicePlanPrivateCallCount()).Should(Equal(1))
 			Expect(fakeCFClient.MakeServicePlanPublicCallCount()).Should(Equal(0))
@@ -245,7 +247,7 @@ var _ = Describe("Serviceaccess", func() {
 				ServiceName: "a-service",
 			}
 			fakeCFClient.MakeServicePlanPrivateReturns(errors.New("error making plan private"))
-			err := manager.EnsureNoAccess(plan)
+			err := manager.EnsureNoAccess(context.Background(), plan)
 			Expect(err).Should(MatchError("error making plan private"))
 			Expect(fakeCFClient.MakeServicePlanPrivateCallCount()).Should(Equal(1))
 			Expect(fakeCFClient.MakeServicePlanPublicCallCount()).Should(Equal(0))
@@ -260,7 +262,7 @@ var _ = Describe("Serviceaccess", func() {
 				ServiceName: "a-service",
 			}
 			manager.Peek = true
-			err := manager.EnsureNoAccess(plan)
+			err := manager.EnsureNoAccess(context.Background(), plan)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.MakeServicePlanPrivateCallCount()).Should(Equal(0))
 			Expect(fakeCFClient.MakeServicePlanPublicCallCount()).Should(Equal(0))
@@ -276,7 +278,7 @@ var _ = Describe("Serviceaccess", func() {
 				ServiceName: "a-service",
 			}
 			fakeOrgReader.FindOrgReturns(&resource.Organization{GUID: "test-org-guid"}, nil)
-			err := manager.EnsureLimitedAccess(plan, []string{"test-org"}, []string{})
+			err := manager.EnsureLimitedAccess(context.Background(), plan, []string{"test-org"}, []string{})
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.MakeServicePlanPrivateCallCount()).Should(Equal(1))
 			Expect(fakeCFClient.MakeServicePlanPublicCallCount()).Should(Equal(0))
@@ -299,7 +301,7 @@ var _ = Describe("Serviceaccess", func() {
 				ServicePlanGUID: "a-plan-guid",
 			})
 			fakeOrgReader.FindOrgReturns(&resource.Organization{GUID: "test-org-guid"}, nil)
-			err := manager.EnsureLimitedAccess(plan, []string{"test-org"}, []string{})
+			err := manager.EnsureLimitedAccess(context.Background(), plan, []string{"test-org"}, []string{})
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.MakeServicePlanPrivateCallCount()).Should(Equal(0))
 			Expect(fakeCFClient.MakeServicePlanPublicCallCount()).Should(Equal(0))
@@ -313,7 +315,7 @@ var _ = Describe("Serviceaccess", func() {
 				ServiceName: "a-service",
 			}
 			fakeOrgReader.FindOrgReturns(&resource.Organization{GUID: "test-org-guid"}, nil)
-			err := manager.EnsureLimitedAccess(plan, []string{}, []string{})
+			err := manager.EnsureLimitedAccess(context.Background(), plan, []string{}, []string{})
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.MakeServicePlanPrivateCallCount()).Should(Equal(1))
 			Expect(fakeCFClient.MakeServicePlanPublicCallCount()).Should(Equal(0))
@@ -331,7 +333,7 @@ var _ = Describe("Serviceaccess", func() {
 			}
 			manager.Peek = true
 			fakeOrgReader.FindOrgReturns(&resource.Organization{GUID: "test-org-guid"}, nil)
-			err := manager.EnsureLimitedAccess(plan, []string{"test-org"}, []string{})
+			err := manager.EnsureLimitedAccess(context.Background(), plan, []string{"test-org"}, []string{})
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.MakeServicePlanPrivateCallCount()).Should(Equal(0))
 			Expect(fakeCFClient.MakeServicePlanPublicCallCount()).Should(Equal(0))
@@ -345,7 +347,7 @@ var _ = Describe("Serviceaccess", func() {
 				ServiceName: "a-service",
 			}
 			fakeCFClient.MakeServicePlanPrivateReturns(errors.New("error making private"))
-			err := manager.EnsureLimitedAccess(plan, []string{"test-org"}, []string{})
+			err := manager.EnsureLimitedAccess(context.Background(), plan, []string{"test-org"}, []string{})
 			Expect(err).Should(MatchError("error making private"))
 			Expect(fakeCFClient.MakeServicePlanPrivateCallCount()).Should(Equal(1))
 			Expect(fakeCFClient.MakeServicePlanPublicCallCount()).Should(Equal(0))
@@ -359,7 +361,7 @@ var _ = Describe("Serviceaccess", func() {
 				ServiceName: "a-service",
 			}
 			fakeOrgReader.FindOrgReturns(nil, errors.New("error getting org"))
-			err := manager.EnsureLimitedAccess(plan, []string{"test-org"}, []string{})
+			err := manager.EnsureLimitedAccess(context.Background(), plan, []string{"test-org"}, []string{})
 			Expect(err).Should(MatchError("error getting org"))
 			Expect(fakeCFClient.MakeServicePlanPrivateCallCount()).Should(Equal(1))
 			Expect(fakeCFClient.MakeServicePlanPublicCallCount()).Should(Equal(0))
@@ -374,7 +376,7 @@ var _ = Describe("Serviceaccess", func() {
 				{Name: "bar"},
 			}, nil)
 			fakeReader.OrgsReturns(&config.Orgs{}, nil)
-			protectedOrgsList, err := manager.ProtectedOrgList()
+			protectedOrgsList, err := manager.ProtectedOrgList(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(len(protectedOrgsList)).Should(BeEquivalentTo(1))
 		})
@@ -385,7 +387,7 @@ var _ = Describe("Serviceaccess", func() {
 				{Name: "bar"},
 			}, nil)
 			fakeReader.OrgsReturns(&config.Orgs{}, errors.New("Getting org config"))
-			protectedOrgsList, err := manager.ProtectedOrgList()
+			protectedOrgsList, err := manager.ProtectedOrgList(context.Background())
 			Expect(err).Should(MatchError("Getting org config"))
 			Expect(len(protectedOrgsList)).Should(BeEquivalentTo(0))
 		})
@@ -396,7 +398,7 @@ var _ = Describe("Serviceaccess", func() {
 				{Name: "bar"},
 			}, errors.New("Getting orgs"))
 			fakeReader.OrgsReturns(&config.Orgs{}, nil)
-			protectedOrgsList, err := manager.ProtectedOrgList()
+			protectedOrgsList, err := manager.ProtectedOrgList(context.Background())
 			Expect(err).Should(MatchError("Getting orgs"))
 			Expect(len(protectedOrgsList)).Should(BeEquivalentTo(0))
 		})
@@ -407,7 +409,7 @@ var _ = Describe("Serviceaccess", func() {
 			servicePlan := &ServicePlanInfo{
 				GUID: "a-plan-guid",
 			}
-			err := manager.CreatePlanVisibility(servicePlan, "test-org")
+			err := manager.CreatePlanVisibility(context.Background(), servicePlan, "test-org")
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.CreateServicePlanVisibilityCallCount()).Should(Equal(1))
 			planGUID, orgGUID := fakeCFClient.CreateServicePlanVisibilityArgsForCall(0)
@@ -421,7 +423,7 @@ var _ = Describe("Serviceaccess", func() {
 				GUID: "a-plan-guid",
 			}
 			servicePlan.AddOrg(&Visibility{OrgGUID: "test-org-guid"})
-			err := manager.CreatePlanVisibility(servicePlan, "test-org")
+			err := manager.CreatePlanVisibility(context.Background(), servicePlan, "test-org")
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.CreateServicePlanVisibilityCallCount()).Should(Equal(0))
 			Expect(servicePlan.OrgHasAccess("test-org-guid")).Should(BeFalse())
@@ -433,7 +435,7 @@ var _ = Describe("Serviceaccess", func() {
 				GUID: "a-plan-guid",
 			}
 			fakeCFClient.CreateServicePlanVisibilityReturns(cfclient.ServicePlanVisibility{}, errors.New("creating visiblity"))
-			err := manager.CreatePlanVisibility(servicePlan, "test-org")
+			err := manager.CreatePlanVisibility(context.Background(), servicePlan, "test-org")
 			Expect(err).Should(MatchError("creating visiblity"))
 			Expect(fakeCFClient.CreateServicePlanVisibilityCallCount()).Should(Equal(1))
 			planGUID, orgGUID := fakeCFClient.CreateServicePlanVisibilityArgsForCall(0)
@@ -449,7 +451,7 @@ var _ = Describe("Serviceaccess", func() {
 				GUID: "a-plan-guid",
 			}
 			servicePlan.AddOrg(&Visibility{OrgGUID: "test-org-guid", ServicePlanGUID: "service-plan-guid"})
-			err := manager.RemoveVisibilities(servicePlan)
+			err := manager.RemoveVisibilities(context.Background(), servicePlan)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.DeleteServicePlanVisibilityByPlanAndOrgCallCount()).Should(Equal(1))
 			planGUID, orgGUID, async := fakeCFClient.DeleteServicePlanVisibilityByPlanAndOrgArgsForCall(0)
@@ -465,7 +467,7 @@ var _ = Describe("Serviceaccess", func() {
 			}
 			servicePlan.AddOrg(&Visibility{OrgGUID: "test-org-guid", ServicePlanGUID: "service-plan-guid"})
 			manager.Peek = true
-			err := manager.RemoveVisibilities(servicePlan)
+			err := manager.RemoveVisibilities(context.Background(), servicePlan)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.DeleteServicePlanVisibilityByPlanAndOrgCallCount()).Should(Equal(0))
 		})
@@ -476,7 +478,7 @@ var _ = Describe("Serviceaccess", func() {
 				GUID: "a-plan-guid",
 			}
 			servicePlan.AddOrg(&Visibility{OrgGUID: "test-org-guid", ServicePlanGUID: "service-plan-guid"})
-			err := manager.RemoveVisibilities(servicePlan)
+			err := manager.RemoveVisibilities(context.Background(), servicePlan)
 			Expect(err).Should(MatchError("getting org by guid"))
 			Expect(fakeCFClient.DeleteServicePlanVisibilityByPlanAndOrgCallCount()).Should(Equal(0))
 		})
@@ -488,7 +490,7 @@ var _ = Describe("Serviceaccess", func() {
 			}
 			fakeCFClient.DeleteServicePlanVisibilityByPlanAndOrgReturns(errors.New("deleting visibility"))
 			servicePlan.AddOrg(&Visibility{OrgGUID: "test-org-guid", ServicePlanGUID: "service-plan-guid"})
-			err := manager.RemoveVisibilities(servicePlan)
+			err := manager.RemoveVisibilities(context.Background(), servicePlan)
 			Expect(err).Should(MatchError("deleting visibility"))
 			Expect(fakeCFClient.DeleteServicePlanVisibilityByPlanAndOrgCallCount()).Should(Equal(1))
 			planGUID, orgGUID, async := fakeCFClient.DeleteServicePlanVisibilityByPlanAndOrgArgsForCall(0)
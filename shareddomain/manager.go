@@ -1,19 +1,23 @@
 package shareddomain
 
 import (
+	"context"
 	"strings"
 
 	"code.cloudfoundry.org/routing-api/models"
 	cfclient "github.com/cloudfoundry-community/go-cfclient"
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
 	"github.com/vmwarepivotallabs/cf-mgmt/config"
 	"github.com/xchapter7x/lo"
 )
 
 type Manager struct {
-	CFClient      CFClient
-	RoutingClient RoutingClient
-	Cfg           config.Reader
-	Peek          bool
+	CFClient            CFClient
+	RoutingClient       RoutingClient
+	Cfg                 config.Reader
+	AllowLargeDeletions bool
+	Recorder            *changes.Recorder
+	Peek                bool
 }
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -30,16 +34,18 @@ type RoutingClient interface {
 	RouterGroups() ([]models.RouterGroup, error)
 }
 
-func NewManager(cfclient CFClient, routingClient RoutingClient, cfg config.Reader, peek bool) *Manager {
+func NewManager(cfclient CFClient, routingClient RoutingClient, cfg config.Reader, allowLargeDeletions bool, recorder *changes.Recorder, peek bool) *Manager {
 	return &Manager{
-		CFClient:      cfclient,
-		RoutingClient: routingClient,
-		Cfg:           cfg,
-		Peek:          peek,
+		CFClient:            cfclient,
+		RoutingClient:       routingClient,
+		Cfg:                 cfg,
+		AllowLargeDeletions: allowLargeDeletions,
+		Recorder:            recorder,
+		Peek:                peek,
 	}
 }
 
-func (m *Manager) Apply() error {
+func (m *Manager) Apply(ctx context.Context) error {
 	global, err := m.Cfg.GetGlobalConfig()
 	if err != nil {
 		return err
@@ -54,7 +60,12 @@ func (m *Manager) Apply() error {
 		domainMap[strings.ToLower(domain.Name)] = domain.Guid
 	}
 	for expectedDomain, sharedDomainConfig := range global.SharedDomains {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		if _, ok := domainMap[strings.ToLower(expectedDomain)]; !ok {
+			change := changes.Change{Entity: changes.SharedDomain, Action: changes.Create, Name: expectedDomain, After: map[string]interface{}{"internal": sharedDomainConfig.Internal, "router-group": sharedDomainConfig.RouterGroup}}
+			m.Recorder.Record(change)
 			if m.Peek {
 				lo.G.Infof("[dry-run]: create shared domain %s as internal [%t] for router group [%s]", expectedDomain, sharedDomainConfig.Internal, sharedDomainConfig.RouterGroup)
 				continue
@@ -69,7 +80,12 @@ func (m *Manager) Apply() error {
 				routerGroupGUID = routingGroup.Guid
 			}
 			lo.G.Infof("create shared domain %s as internal [%t] for router group [%s]", expectedDomain, sharedDomainConfig.Internal, sharedDomainConfig.RouterGroup)
-			_, err := m.CFClient.CreateSharedDomain(expectedDomain, sharedDomainConfig.Internal, routerGroupGUID)
+			domain, err := m.CFClient.CreateSharedDomain(expectedDomain, sharedDomainConfig.Internal, routerGroupGUID)
+			var guid string
+			if domain != nil {
+				guid = domain.Guid
+			}
+			m.Recorder.Applied(change, guid, err)
 			if err != nil {
 				return err
 			}
@@ -78,13 +94,24 @@ func (m *Manager) Apply() error {
 		}
 	}
 	if global.EnableDeleteSharedDomains {
+		if !m.AllowLargeDeletions {
+			if err := global.DeletionLimits.SharedDomains.Check("shared domains", len(domainMap), len(currentDomains)); err != nil {
+				return err
+			}
+		}
 		for domain, domainGUID := range domainMap {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			change := changes.Change{Entity: changes.SharedDomain, Action: changes.Delete, Name: domain}
+			m.Recorder.Record(change)
 			if m.Peek {
 				lo.G.Infof("[dry-run]: deleting shared domain %s", domain)
 				continue
 			}
 			lo.G.Infof("deleting shared domain %s", domain)
 			err := m.CFClient.DeleteSharedDomain(domainGUID, false)
+			m.Recorder.Applied(change, domainGUID, err)
 			if err != nil {
 				return err
 			}
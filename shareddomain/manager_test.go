@@ -1,6 +1,8 @@
 package shareddomain_test
 
 import (
+	"context"
+
 	"errors"
 
 	"code.cloudfoundry.org/routing-api/models"
@@ -25,7 +27,7 @@ var _ = Describe("Manager", func() {
 		fakeCFClient = &fakes.FakeCFClient{}
 		fakeRoutingClient = &fakes.FakeRoutingClient{}
 		fakeCfg = &fakeconfig.FakeReader{}
-		manager = NewManager(fakeCFClient, fakeRoutingClient, fakeCfg, false)
+		manager = NewManager(fakeCFClient, fakeRoutingClient, fakeCfg, false, nil, false)
 		fakeCfg.GetGlobalConfigReturns(&config.GlobalConfig{
 			SharedDomains: map[string]config.SharedDomain{
 				"foo.bar":        {},
@@ -35,7 +37,7 @@ var _ = Describe("Manager", func() {
 	})
 	Context("Apply", func() {
 		It("Should create 2 shared domains", func() {
-			err := manager.Apply()
+			err := manager.Apply(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.CreateSharedDomainCallCount()).To(Equal(2))
 			Expect(fakeCFClient.DeleteSharedDomainCallCount()).To(Equal(0))
@@ -58,7 +60,7 @@ var _ = Describe("Manager", func() {
 			fakeRoutingClient.RouterGroupWithNameReturns(models.RouterGroup{
 				Guid: "default-tcp-guid",
 			}, nil)
-			err := manager.Apply()
+			err := manager.Apply(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.CreateSharedDomainCallCount()).To(Equal(1))
 			Expect(fakeCFClient.DeleteSharedDomainCallCount()).To(Equal(0))
@@ -79,7 +81,7 @@ var _ = Describe("Manager", func() {
 					Guid: "default.domain.guid",
 				},
 			}, nil)
-			err := manager.Apply()
+			err := manager.Apply(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.CreateSharedDomainCallCount()).To(Equal(0))
 			Expect(fakeCFClient.DeleteSharedDomainCallCount()).To(Equal(0))
@@ -99,7 +101,7 @@ var _ = Describe("Manager", func() {
 					Guid: "default.domain.guid",
 				},
 			}, nil)
-			err := manager.Apply()
+			err := manager.Apply(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.CreateSharedDomainCallCount()).To(Equal(0))
 			Expect(fakeCFClient.DeleteSharedDomainCallCount()).To(Equal(2))
@@ -111,21 +113,55 @@ var _ = Describe("Manager", func() {
 		})
 	})
 
+	Context("Apply with deletion limits", func() {
+		BeforeEach(func() {
+			fakeCfg.GetGlobalConfigReturns(&config.GlobalConfig{
+				EnableDeleteSharedDomains: true,
+				DeletionLimits: config.DeletionLimits{
+					SharedDomains: config.DeletionLimit{MaxPercent: 50},
+				},
+			}, nil)
+			fakeCFClient.ListSharedDomainsReturns([]cfclient.SharedDomain{
+				{
+					Name: "foo.bar",
+					Guid: "foo.bar.guid",
+				},
+				{
+					Name: "default.domain",
+					Guid: "default.domain.guid",
+				},
+			}, nil)
+		})
+
+		It("Should refuse to delete more shared domains than the limit", func() {
+			err := manager.Apply(context.Background())
+			Expect(err).To(MatchError(ContainSubstring("refusing to delete 2 of 2 shared domains")))
+			Expect(fakeCFClient.DeleteSharedDomainCallCount()).To(Equal(0))
+		})
+
+		It("Should delete more shared domains than the limit when allowed", func() {
+			manager = NewManager(fakeCFClient, fakeRoutingClient, fakeCfg, true, nil, false)
+			err := manager.Apply(context.Background())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(fakeCFClient.DeleteSharedDomainCallCount()).To(Equal(2))
+		})
+	})
+
 	Context("errors", func() {
 		It("should error on getting config", func() {
 			fakeCfg.GetGlobalConfigReturns(nil, errors.New("error getting config"))
-			err := manager.Apply()
+			err := manager.Apply(context.Background())
 			Expect(err).To(MatchError("error getting config"))
 		})
 
 		It("should error listing domains", func() {
 			fakeCFClient.ListSharedDomainsReturns(nil, errors.New("error getting shared domains"))
-			err := manager.Apply()
+			err := manager.Apply(context.Background())
 			Expect(err).To(MatchError("error getting shared domains"))
 		})
 		It("should error creating domains", func() {
 			fakeCFClient.CreateSharedDomainReturns(nil, errors.New("error creating shared domain"))
-			err := manager.Apply()
+			err := manager.Apply(context.Background())
 			Expect(err).To(MatchError("error creating shared domain"))
 		})
 		It("should error deleting domains", func() {
@@ -143,17 +179,17 @@ var _ = Describe("Manager", func() {
 				},
 			}, nil)
 			fakeCFClient.DeleteSharedDomainReturns(errors.New("error deleting shared domain"))
-			err := manager.Apply()
+			err := manager.Apply(context.Background())
 			Expect(err).To(MatchError("error deleting shared domain"))
 		})
 	})
 
 	Context("peek", func() {
 		BeforeEach(func() {
-			manager = NewManager(fakeCFClient, fakeRoutingClient, fakeCfg, true)
+			manager = NewManager(fakeCFClient, fakeRoutingClient, fakeCfg, false, nil, true)
 		})
 		It("Should not create 2 shared domains", func() {
-			err := manager.Apply()
+			err := manager.Apply(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.CreateSharedDomainCallCount()).To(Equal(0))
 			Expect(fakeCFClient.DeleteSharedDomainCallCount()).To(Equal(0))
@@ -172,7 +208,7 @@ var _ = Describe("Manager", func() {
 					Guid: "default.domain.guid",
 				},
 			}, nil)
-			err := manager.Apply()
+			err := manager.Apply(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeCFClient.CreateSharedDomainCallCount()).To(Equal(0))
 			Expect(fakeCFClient.DeleteSharedDomainCallCount()).To(Equal(0))
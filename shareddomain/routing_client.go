@@ -0,0 +1,82 @@
+package shareddomain
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	routing_api "code.cloudfoundry.org/routing-api"
+	"code.cloudfoundry.org/routing-api/models"
+)
+
+// RoutingAPIClient - a RoutingClient that calls the routing api with the http
+// client of the other apis, so its requests are retried, rate limited and
+// counted like theirs, which the routing api client with its own http client
+// can't be.  A current token is fetched for every call as a long running
+// process would otherwise keep using one that expired.
+type RoutingAPIClient struct {
+	URL        string
+	HTTPClient *http.Client
+	Token      func() (string, error)
+}
+
+// NewRoutingAPIClient - token returns a bearer token, with or without the
+// bearer prefix
+func NewRoutingAPIClient(routingURL string, httpClient *http.Client, token func() (string, error)) *RoutingAPIClient {
+	return &RoutingAPIClient{
+		URL:        strings.TrimSuffix(routingURL, "/"),
+		HTTPClient: httpClient,
+		Token:      token,
+	}
+}
+
+// RouterGroupWithName -
+func (c *RoutingAPIClient) RouterGroupWithName(name string) (models.RouterGroup, error) {
+	routerGroups, err := c.listRouterGroups(url.Values{"name": []string{name}})
+	if err != nil {
+		return models.RouterGroup{}, err
+	}
+	if len(routerGroups) == 0 {
+		return models.RouterGroup{}, fmt.Errorf("router group %s not found", name)
+	}
+	return routerGroups[0], nil
+}
+
+// RouterGroups -
+func (c *RoutingAPIClient) RouterGroups() ([]models.RouterGroup, error) {
+	return c.listRouterGroups(nil)
+}
+
+func (c *RoutingAPIClient) listRouterGroups(query url.Values) ([]models.RouterGroup, error) {
+	token, err := c.Token()
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodGet, c.URL+"/routing/v1/router_groups", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = query.Encode()
+	//needs to not include bearer prefix
+	req.Header.Set("Authorization", "bearer "+strings.Replace(token, "bearer ", "", 1))
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusUnauthorized {
+		return nil, routing_api.NewError(routing_api.UnauthorizedError, "unauthorized")
+	}
+	if res.StatusCode > 299 {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("listing router groups failed with status %d: %s", res.StatusCode, strings.TrimSpace(string(body)))
+	}
+	var routerGroups []models.RouterGroup
+	if err := json.NewDecoder(res.Body).Decode(&routerGroups); err != nil {
+		return nil, err
+	}
+	return routerGroups, nil
+}
@@ -0,0 +1,62 @@
+package shareddomain_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/vmwarepivotallabs/cf-mgmt/shareddomain"
+	"github.com/vmwarepivotallabs/cf-mgmt/util"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RoutingAPIClient", func() {
+	var (
+		server   *httptest.Server
+		requests []*http.Request
+		statuses []int
+		client   *RoutingAPIClient
+	)
+	BeforeEach(func() {
+		requests, statuses = nil, nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r)
+			if len(requests) <= len(statuses) {
+				w.WriteHeader(statuses[len(requests)-1])
+				return
+			}
+			w.Write([]byte(`[{"guid":"tcp-guid","name":"default-tcp","type":"tcp"}]`))
+		}))
+		httpClient := &http.Client{Transport: util.NewRetryTransport(http.DefaultTransport, 2, time.Millisecond, time.Millisecond, nil)}
+		client = NewRoutingAPIClient(server.URL+"/", httpClient, func() (string, error) { return "bearer a-token", nil })
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("lists the router group with the name", func() {
+		routerGroup, err := client.RouterGroupWithName("default-tcp")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(routerGroup.Guid).Should(Equal("tcp-guid"))
+		Expect(requests).Should(HaveLen(1))
+		Expect(requests[0].URL.Path).Should(Equal("/routing/v1/router_groups"))
+		Expect(requests[0].URL.Query().Get("name")).Should(Equal("default-tcp"))
+		Expect(requests[0].Header.Get("Authorization")).Should(Equal("bearer a-token"))
+	})
+
+	It("retries with the transport of its http client", func() {
+		statuses = []int{http.StatusBadGateway}
+		routerGroups, err := client.RouterGroups()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(routerGroups).Should(HaveLen(1))
+		Expect(requests).Should(HaveLen(2))
+	})
+
+	It("errors when the routing api does", func() {
+		statuses = []int{http.StatusNotFound}
+		_, err := client.RouterGroups()
+		Expect(err).Should(MatchError(ContainSubstring("status 404")))
+	})
+})
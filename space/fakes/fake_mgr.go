@@ -2,6 +2,7 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/cloudfoundry-community/go-cfclient/v3/resource"
@@ -9,9 +10,10 @@ import (
 )
 
 type FakeManager struct {
-	CreateSpacesStub        func() error
+	CreateSpacesStub        func(context.Context) error
 	createSpacesMutex       sync.RWMutex
 	createSpacesArgsForCall []struct {
+		arg1 context.Context
 	}
 	createSpacesReturns struct {
 		result1 error
@@ -19,9 +21,10 @@ type FakeManager struct {
 	createSpacesReturnsOnCall map[int]struct {
 		result1 error
 	}
-	DeleteSpacesStub        func() error
+	DeleteSpacesStub        func(context.Context) error
 	deleteSpacesMutex       sync.RWMutex
 	deleteSpacesArgsForCall []struct {
+		arg1 context.Context
 	}
 	deleteSpacesReturns struct {
 		result1 error
@@ -29,11 +32,12 @@ type FakeManager struct {
 	deleteSpacesReturnsOnCall map[int]struct {
 		result1 error
 	}
-	DeleteSpacesForOrgStub        func(string, string) error
+	DeleteSpacesForOrgStub        func(context.Context, string, string) error
 	deleteSpacesForOrgMutex       sync.RWMutex
 	deleteSpacesForOrgArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	deleteSpacesForOrgReturns struct {
 		result1 error
@@ -41,11 +45,12 @@ type FakeManager struct {
 	deleteSpacesForOrgReturnsOnCall map[int]struct {
 		result1 error
 	}
-	FindSpaceStub        func(string, string) (*resource.Space, error)
+	FindSpaceStub        func(context.Context, string, string) (*resource.Space, error)
 	findSpaceMutex       sync.RWMutex
 	findSpaceArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	findSpaceReturns struct {
 		result1 *resource.Space
@@ -55,10 +60,11 @@ type FakeManager struct {
 		result1 *resource.Space
 		result2 error
 	}
-	GetSpaceIsolationSegmentGUIDStub        func(*resource.Space) (string, error)
+	GetSpaceIsolationSegmentGUIDStub        func(context.Context, *resource.Space) (string, error)
 	getSpaceIsolationSegmentGUIDMutex       sync.RWMutex
 	getSpaceIsolationSegmentGUIDArgsForCall []struct {
-		arg1 *resource.Space
+		arg1 context.Context
+		arg2 *resource.Space
 	}
 	getSpaceIsolationSegmentGUIDReturns struct {
 		result1 string
@@ -68,10 +74,11 @@ type FakeManager struct {
 		result1 string
 		result2 error
 	}
-	IsSSHEnabledStub        func(*resource.Space) (bool, error)
+	IsSSHEnabledStub        func(context.Context, *resource.Space) (bool, error)
 	isSSHEnabledMutex       sync.RWMutex
 	isSSHEnabledArgsForCall []struct {
-		arg1 *resource.Space
+		arg1 context.Context
+		arg2 *resource.Space
 	}
 	isSSHEnabledReturns struct {
 		result1 bool
@@ -81,10 +88,11 @@ type FakeManager struct {
 		result1 bool
 		result2 error
 	}
-	ListSpacesStub        func(string) ([]*resource.Space, error)
+	ListSpacesStub        func(context.Context, string) ([]*resource.Space, error)
 	listSpacesMutex       sync.RWMutex
 	listSpacesArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	listSpacesReturns struct {
 		result1 []*resource.Space
@@ -94,9 +102,10 @@ type FakeManager struct {
 		result1 []*resource.Space
 		result2 error
 	}
-	UpdateSpacesStub        func() error
+	UpdateSpacesStub        func(context.Context) error
 	updateSpacesMutex       sync.RWMutex
 	updateSpacesArgsForCall []struct {
+		arg1 context.Context
 	}
 	updateSpacesReturns struct {
 		result1 error
@@ -104,9 +113,10 @@ type FakeManager struct {
 	updateSpacesReturnsOnCall map[int]struct {
 		result1 error
 	}
-	UpdateSpacesMetadataStub        func() error
+	UpdateSpacesMetadataStub        func(context.Context) error
 	updateSpacesMetadataMutex       sync.RWMutex
 	updateSpacesMetadataArgsForCall []struct {
+		arg1 context.Context
 	}
 	updateSpacesMetadataReturns struct {
 		result1 error
@@ -118,17 +128,18 @@ type FakeManager struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeManager) CreateSpaces() error {
+func (fake *FakeManager) CreateSpaces(arg1 context.Context) error {
 	fake.createSpacesMutex.Lock()
 	ret, specificReturn := fake.createSpacesReturnsOnCall[len(fake.createSpacesArgsForCall)]
 	fake.createSpacesArgsForCall = append(fake.createSpacesArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.CreateSpacesStub
 	fakeReturns := fake.createSpacesReturns
-	fake.recordInvocation("CreateSpaces", []interface{}{})
+	fake.recordInvocation("CreateSpaces", []interface{}{arg1})
 	fake.createSpacesMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -142,12 +153,19 @@ func (fake *FakeManager) CreateSpacesCallCount() int {
 	return len(fake.createSpacesArgsForCall)
 }
 
-func (fake *FakeManager) CreateSpacesCalls(stub func() error) {
+func (fake *FakeManager) CreateSpacesCalls(stub func(context.Context) error) {
 	fake.createSpacesMutex.Lock()
 	defer fake.createSpacesMutex.Unlock()
 	fake.CreateSpacesStub = stub
 }
 
+func (fake *FakeManager) CreateSpacesArgsForCall(i int) context.Context {
+	fake.createSpacesMutex.RLock()
+	defer fake.createSpacesMutex.RUnlock()
+	argsForCall := fake.createSpacesArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeManager) CreateSpacesReturns(result1 error) {
 	fake.createSpacesMutex.Lock()
 	defer fake.createSpacesMutex.Unlock()
@@ -171,17 +189,18 @@ func (fake *FakeManager) CreateSpacesReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeManager) DeleteSpaces() error {
+func (fake *FakeManager) DeleteSpaces(arg1 context.Context) error {
 	fake.deleteSpacesMutex.Lock()
 	ret, specificReturn := fake.deleteSpacesReturnsOnCall[len(fake.deleteSpacesArgsForCall)]
 	fake.deleteSpacesArgsForCall = append(fake.deleteSpacesArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.DeleteSpacesStub
 	fakeReturns := fake.deleteSpacesReturns
-	fake.recordInvocation("DeleteSpaces", []interface{}{})
+	fake.recordInvocation("DeleteSpaces", []interface{}{arg1})
 	fake.deleteSpacesMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -195,12 +214,19 @@ func (fake *FakeManager) DeleteSpacesCallCount() int {
 	return len(fake.deleteSpacesArgsForCall)
 }
 
-func (fake *FakeManager) DeleteSpacesCalls(stub func() error) {
+func (fake *FakeManager) DeleteSpacesCalls(stub func(context.Context) error) {
 	fake.deleteSpacesMutex.Lock()
 	defer fake.deleteSpacesMutex.Unlock()
 	fake.DeleteSpacesStub = stub
 }
 
+func (fake *FakeManager) DeleteSpacesArgsForCall(i int) context.Context {
+	fake.deleteSpacesMutex.RLock()
+	defer fake.deleteSpacesMutex.RUnlock()
+	argsForCall := fake.deleteSpacesArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeManager) DeleteSpacesReturns(result1 error) {
 	fake.deleteSpacesMutex.Lock()
 	defer fake.deleteSpacesMutex.Unlock()
@@ -224,19 +250,20 @@ func (fake *FakeManager) DeleteSpacesReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeManager) DeleteSpacesForOrg(arg1 string, arg2 string) error {
+func (fake *FakeManager) DeleteSpacesForOrg(arg1 context.Context, arg2 string, arg3 string) error {
 	fake.deleteSpacesForOrgMutex.Lock()
 	ret, specificReturn := fake.deleteSpacesForOrgReturnsOnCall[len(fake.deleteSpacesForOrgArgsForCall)]
 	fake.deleteSpacesForOrgArgsForCall = append(fake.deleteSpacesForOrgArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
 	stub := fake.DeleteSpacesForOrgStub
 	fakeReturns := fake.deleteSpacesForOrgReturns
-	fake.recordInvocation("DeleteSpacesForOrg", []interface{}{arg1, arg2})
+	fake.recordInvocation("DeleteSpacesForOrg", []interface{}{arg1, arg2, arg3})
 	fake.deleteSpacesForOrgMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -250,17 +277,17 @@ func (fake *FakeManager) DeleteSpacesForOrgCallCount() int {
 	return len(fake.deleteSpacesForOrgArgsForCall)
 }
 
-func (fake *FakeManager) DeleteSpacesForOrgCalls(stub func(string, string) error) {
+func (fake *FakeManager) DeleteSpacesForOrgCalls(stub func(context.Context, string, string) error) {
 	fake.deleteSpacesForOrgMutex.Lock()
 	defer fake.deleteSpacesForOrgMutex.Unlock()
 	fake.DeleteSpacesForOrgStub = stub
 }
 
-func (fake *FakeManager) DeleteSpacesForOrgArgsForCall(i int) (string, string) {
+func (fake *FakeManager) DeleteSpacesForOrgArgsForCall(i int) (context.Context, string, string) {
 	fake.deleteSpacesForOrgMutex.RLock()
 	defer fake.deleteSpacesForOrgMutex.RUnlock()
 	argsForCall := fake.deleteSpacesForOrgArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeManager) DeleteSpacesForOrgReturns(result1 error) {
@@ -286,19 +313,20 @@ func (fake *FakeManager) DeleteSpacesForOrgReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeManager) FindSpace(arg1 string, arg2 string) (*resource.Space, error) {
+func (fake *FakeManager) FindSpace(arg1 context.Context, arg2 string, arg3 string) (*resource.Space, error) {
 	fake.findSpaceMutex.Lock()
 	ret, specificReturn := fake.findSpaceReturnsOnCall[len(fake.findSpaceArgsForCall)]
 	fake.findSpaceArgsForCall = append(fake.findSpaceArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
 	stub := fake.FindSpaceStub
 	fakeReturns := fake.findSpaceReturns
-	fake.recordInvocation("FindSpace", []interface{}{arg1, arg2})
+	fake.recordInvocation("FindSpace", []interface{}{arg1, arg2, arg3})
 	fake.findSpaceMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -312,17 +340,17 @@ func (fake *FakeManager) FindSpaceCallCount() int {
 	return len(fake.findSpaceArgsForCall)
 }
 
-func (fake *FakeManager) FindSpaceCalls(stub func(string, string) (*resource.Space, error)) {
+func (fake *FakeManager) FindSpaceCalls(stub func(context.Context, string, string) (*resource.Space, error)) {
 	fake.findSpaceMutex.Lock()
 	defer fake.findSpaceMutex.Unlock()
 	fake.FindSpaceStub = stub
 }
 
-func (fake *FakeManager) FindSpaceArgsForCall(i int) (string, string) {
+func (fake *FakeManager) FindSpaceArgsForCall(i int) (context.Context, string, string) {
 	fake.findSpaceMutex.RLock()
 	defer fake.findSpaceMutex.RUnlock()
 	argsForCall := fake.findSpaceArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeManager) FindSpaceReturns(result1 *resource.Space, result2 error) {
@@ -351,18 +379,19 @@ func (fake *FakeManager) FindSpaceReturnsOnCall(i int, result1 *resource.Space,
 	}{result1, result2}
 }
 
-func (fake *FakeManager) GetSpaceIsolationSegmentGUID(arg1 *resource.Space) (string, error) {
+func (fake *FakeManager) GetSpaceIsolationSegmentGUID(arg1 context.Context, arg2 *resource.Space) (string, error) {
 	fake.getSpaceIsolationSegmentGUIDMutex.Lock()
 	ret, specificReturn := fake.getSpaceIsolationSegmentGUIDReturnsOnCall[len(fake.getSpaceIsolationSegmentGUIDArgsForCall)]
 	fake.getSpaceIsolationSegmentGUIDArgsForCall = append(fake.getSpaceIsolationSegmentGUIDArgsForCall, struct {
-		arg1 *resource.Space
-	}{arg1})
+		arg1 context.Context
+		arg2 *resource.Space
+	}{arg1, arg2})
 	stub := fake.GetSpaceIsolationSegmentGUIDStub
 	fakeReturns := fake.getSpaceIsolationSegmentGUIDReturns
-	fake.recordInvocation("GetSpaceIsolationSegmentGUID", []interface{}{arg1})
+	fake.recordInvocation("GetSpaceIsolationSegmentGUID", []interface{}{arg1, arg2})
 	fake.getSpaceIsolationSegmentGUIDMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -376,17 +405,17 @@ func (fake *FakeManager) GetSpaceIsolationSegmentGUIDCallCount() int {
 	return len(fake.getSpaceIsolationSegmentGUIDArgsForCall)
 }
 
-func (fake *FakeManager) GetSpaceIsolationSegmentGUIDCalls(stub func(*resource.Space) (string, error)) {
+func (fake *FakeManager) GetSpaceIsolationSegmentGUIDCalls(stub func(context.Context, *resource.Space) (string, error)) {
 	fake.getSpaceIsolationSegmentGUIDMutex.Lock()
 	defer fake.getSpaceIsolationSegmentGUIDMutex.Unlock()
 	fake.GetSpaceIsolationSegmentGUIDStub = stub
 }
 
-func (fake *FakeManager) GetSpaceIsolationSegmentGUIDArgsForCall(i int) *resource.Space {
+func (fake *FakeManager) GetSpaceIsolationSegmentGUIDArgsForCall(i int) (context.Context, *resource.Space) {
 	fake.getSpaceIsolationSegmentGUIDMutex.RLock()
 	defer fake.getSpaceIsolationSegmentGUIDMutex.RUnlock()
 	argsForCall := fake.getSpaceIsolationSegmentGUIDArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeManager) GetSpaceIsolationSegmentGUIDReturns(result1 string, result2 error) {
@@ -415,18 +444,19 @@ func (fake *FakeManager) GetSpaceIsolationSegmentGUIDReturnsOnCall(i int, result
 	}{result1, result2}
 }
 
-func (fake *FakeManager) IsSSHEnabled(arg1 *resource.Space) (bool, error) {
+func (fake *FakeManager) IsSSHEnabled(arg1 context.Context, arg2 *resource.Space) (bool, error) {
 	fake.isSSHEnabledMutex.Lock()
 	ret, specificReturn := fake.isSSHEnabledReturnsOnCall[len(fake.isSSHEnabledArgsForCall)]
 	fake.isSSHEnabledArgsForCall = append(fake.isSSHEnabledArgsForCall, struct {
-		arg1 *resource.Space
-	}{arg1})
+		arg1 context.Context
+		arg2 *resource.Space
+	}{arg1, arg2})
 	stub := fake.IsSSHEnabledStub
 	fakeReturns := fake.isSSHEnabledReturns
-	fake.recordInvocation("IsSSHEnabled", []interface{}{arg1})
+	fake.recordInvocation("IsSSHEnabled", []interface{}{arg1, arg2})
 	fake.isSSHEnabledMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -440,17 +470,17 @@ func (fake *FakeManager) IsSSHEnabledCallCount() int {
 	return len(fake.isSSHEnabledArgsForCall)
 }
 
-func (fake *FakeManager) IsSSHEnabledCalls(stub func(*resource.Space) (bool, error)) {
+func (fake *FakeManager) IsSSHEnabledCalls(stub func(context.Context, *resource.Space) (bool, error)) {
 	fake.isSSHEnabledMutex.Lock()
 	defer fake.isSSHEnabledMutex.Unlock()
 	fake.IsSSHEnabledStub = stub
 }
 
-func (fake *FakeManager) IsSSHEnabledArgsForCall(i int) *resource.Space {
+func (fake *FakeManager) IsSSHEnabledArgsForCall(i int) (context.Context, *resource.Space) {
 	fake.isSSHEnabledMutex.RLock()
 	defer fake.isSSHEnabledMutex.RUnlock()
 	argsForCall := fake.isSSHEnabledArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeManager) IsSSHEnabledReturns(result1 bool, result2 error) {
@@ -479,18 +509,19 @@ func (fake *FakeManager) IsSSHEnabledReturnsOnCall(i int, result1 bool, result2
 	}{result1, result2}
 }
 
-func (fake *FakeManager) ListSpaces(arg1 string) ([]*resource.Space, error) {
+func (fake *FakeManager) ListSpaces(arg1 context.Context, arg2 string) ([]*resource.Space, error) {
 	fake.listSpacesMutex.Lock()
 	ret, specificReturn := fake.listSpacesReturnsOnCall[len(fake.listSpacesArgsForCall)]
 	fake.listSpacesArgsForCall = append(fake.listSpacesArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.ListSpacesStub
 	fakeReturns := fake.listSpacesReturns
-	fake.recordInvocation("ListSpaces", []interface{}{arg1})
+	fake.recordInvocation("ListSpaces", []interface{}{arg1, arg2})
 	fake.listSpacesMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -504,17 +535,17 @@ func (fake *FakeManager) ListSpacesCallCount() int {
 	return len(fake.listSpacesArgsForCall)
 }
 
-func (fake *FakeManager) ListSpacesCalls(stub func(string) ([]*resource.Space, error)) {
+func (fake *FakeManager) ListSpacesCalls(stub func(context.Context, string) ([]*resource.Space, error)) {
 	fake.listSpacesMutex.Lock()
 	defer fake.listSpacesMutex.Unlock()
 	fake.ListSpacesStub = stub
 }
 
-func (fake *FakeManager) ListSpacesArgsForCall(i int) string {
+func (fake *FakeManager) ListSpacesArgsForCall(i int) (context.Context, string) {
 	fake.listSpacesMutex.RLock()
 	defer fake.listSpacesMutex.RUnlock()
 	argsForCall := fake.listSpacesArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeManager) ListSpacesReturns(result1 []*resource.Space, result2 error) {
@@ -543,17 +574,18 @@ func (fake *FakeManager) ListSpacesReturnsOnCall(i int, result1 []*resource.Spac
 	}{result1, result2}
 }
 
-func (fake *FakeManager) UpdateSpaces() error {
+func (fake *FakeManager) UpdateSpaces(arg1 context.Context) error {
 	fake.updateSpacesMutex.Lock()
 	ret, specificReturn := fake.updateSpacesReturnsOnCall[len(fake.updateSpacesArgsForCall)]
 	fake.updateSpacesArgsForCall = append(fake.updateSpacesArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.UpdateSpacesStub
 	fakeReturns := fake.updateSpacesReturns
-	fake.recordInvocation("UpdateSpaces", []interface{}{})
+	fake.recordInvocation("UpdateSpaces", []interface{}{arg1})
 	fake.updateSpacesMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -567,12 +599,19 @@ func (fake *FakeManager) UpdateSpacesCallCount() int {
 	return len(fake.updateSpacesArgsForCall)
 }
 
-func (fake *FakeManager) UpdateSpacesCalls(stub func() error) {
+func (fake *FakeManager) UpdateSpacesCalls(stub func(context.Context) error) {
 	fake.updateSpacesMutex.Lock()
 	defer fake.updateSpacesMutex.Unlock()
 	fake.UpdateSpacesStub = stub
 }
 
+func (fake *FakeManager) UpdateSpacesArgsForCall(i int) context.Context {
+	fake.updateSpacesMutex.RLock()
+	defer fake.updateSpacesMutex.RUnlock()
+	argsForCall := fake.updateSpacesArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeManager) UpdateSpacesReturns(result1 error) {
 	fake.updateSpacesMutex.Lock()
 	defer fake.updateSpacesMutex.Unlock()
@@ -596,17 +635,18 @@ func (fake *FakeManager) UpdateSpacesReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeManager) UpdateSpacesMetadata() error {
+func (fake *FakeManager) UpdateSpacesMetadata(arg1 context.Context) error {
 	fake.updateSpacesMetadataMutex.Lock()
 	ret, specificReturn := fake.updateSpacesMetadataReturnsOnCall[len(fake.updateSpacesMetadataArgsForCall)]
 	fake.updateSpacesMetadataArgsForCall = append(fake.updateSpacesMetadataArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.UpdateSpacesMetadataStub
 	fakeReturns := fake.updateSpacesMetadataReturns
-	fake.recordInvocation("UpdateSpacesMetadata", []interface{}{})
+	fake.recordInvocation("UpdateSpacesMetadata", []interface{}{arg1})
 	fake.updateSpacesMetadataMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -620,12 +660,19 @@ func (fake *FakeManager) UpdateSpacesMetadataCallCount() int {
 	return len(fake.updateSpacesMetadataArgsForCall)
 }
 
-func (fake *FakeManager) UpdateSpacesMetadataCalls(stub func() error) {
+func (fake *FakeManager) UpdateSpacesMetadataCalls(stub func(context.Context) error) {
 	fake.updateSpacesMetadataMutex.Lock()
 	defer fake.updateSpacesMetadataMutex.Unlock()
 	fake.UpdateSpacesMetadataStub = stub
 }
 
+func (fake *FakeManager) UpdateSpacesMetadataArgsForCall(i int) context.Context {
+	fake.updateSpacesMetadataMutex.RLock()
+	defer fake.updateSpacesMetadataMutex.RUnlock()
+	argsForCall := fake.updateSpacesMetadataArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeManager) UpdateSpacesMetadataReturns(result1 error) {
 	fake.updateSpacesMetadataMutex.Lock()
 	defer fake.updateSpacesMetadataMutex.Unlock()
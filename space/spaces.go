@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -11,44 +12,59 @@ import (
 
 	"github.com/cloudfoundry-community/go-cfclient/v3/client"
 	"github.com/cloudfoundry-community/go-cfclient/v3/resource"
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
 	"github.com/vmwarepivotallabs/cf-mgmt/config"
+	"github.com/vmwarepivotallabs/cf-mgmt/failures"
 	"github.com/vmwarepivotallabs/cf-mgmt/organizationreader"
 	"github.com/vmwarepivotallabs/cf-mgmt/uaa"
+	"github.com/vmwarepivotallabs/cf-mgmt/util"
 	"github.com/xchapter7x/lo"
 )
 
 // NewManager -
 func NewManager(spaceClient CFSpaceClient, spaceFeatureClient CFSpaceFeatureClient, uaaMgr uaa.Manager,
 	orgReader organizationreader.Reader,
-	cfg config.Reader, peek bool) Manager {
+	cfg config.Reader, parallelism int, allowLargeDeletions bool, failures *failures.Collector, recorder *changes.Recorder, peek bool) Manager {
 	return &DefaultManager{
-		Cfg:                cfg,
-		UAAMgr:             uaaMgr,
-		SpaceClient:        spaceClient,
-		SpaceFeatureClient: spaceFeatureClient,
-		OrgReader:          orgReader,
-		Peek:               peek,
+		Cfg:                 cfg,
+		UAAMgr:              uaaMgr,
+		SpaceClient:         spaceClient,
+		SpaceFeatureClient:  spaceFeatureClient,
+		OrgReader:           orgReader,
+		Failures:            failures,
+		Recorder:            recorder,
+		Peek:                peek,
+		Parallelism:         parallelism,
+		AllowLargeDeletions: allowLargeDeletions,
 	}
 }
 
 // DefaultManager -
 type DefaultManager struct {
-	Cfg                config.Reader
-	SpaceClient        CFSpaceClient
-	SpaceFeatureClient CFSpaceFeatureClient
-	UAAMgr             uaa.Manager
-	OrgReader          organizationreader.Reader
-	Peek               bool
-	spaces             []*resource.Space
+	Cfg                 config.Reader
+	SpaceClient         CFSpaceClient
+	SpaceFeatureClient  CFSpaceFeatureClient
+	UAAMgr              uaa.Manager
+	OrgReader           organizationreader.Reader
+	Failures            *failures.Collector
+	Recorder            *changes.Recorder
+	Peek                bool
+	Parallelism         int
+	AllowLargeDeletions bool
+	mutex               sync.Mutex
+	spaces              []*resource.Space
+	// simulated - spaces created or renamed while peeking, layered over the
+	// spaces returned by cloud controller so later steps can see them
+	simulated []*resource.Space
 }
 
-func (m *DefaultManager) UpdateSpaceSSH(sshAllowed bool, space *resource.Space, orgName string) error {
-	return m.SpaceFeatureClient.EnableSSH(context.Background(), space.GUID, sshAllowed)
+func (m *DefaultManager) UpdateSpaceSSH(ctx context.Context, sshAllowed bool, space *resource.Space, orgName string) error {
+	return m.SpaceFeatureClient.EnableSSH(util.CallContext(ctx), space.GUID, sshAllowed)
 }
 
-func (m *DefaultManager) init() error {
+func (m *DefaultManager) init(ctx context.Context) error {
 	if m.spaces == nil {
-		spaces, err := m.SpaceClient.ListAll(context.Background(), &client.SpaceListOptions{
+		spaces, err := m.SpaceClient.ListAll(util.CallContext(ctx), &client.SpaceListOptions{
 			ListOptions: &client.ListOptions{
 				PerPage: 5000,
 			},
@@ -57,76 +73,128 @@ func (m *DefaultManager) init() error {
 			return err
 		}
 		m.spaces = spaces
+		for _, space := range m.simulated {
+			m.spaces = addOrReplaceSpace(m.spaces, space)
+		}
 	}
 	return nil
 }
 
+func (m *DefaultManager) addSimulatedSpace(space *resource.Space) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.simulated = addOrReplaceSpace(m.simulated, space)
+	if m.spaces != nil {
+		m.spaces = addOrReplaceSpace(m.spaces, space)
+	}
+}
+
+func addOrReplaceSpace(spaces []*resource.Space, space *resource.Space) []*resource.Space {
+	for i, existingSpace := range spaces {
+		if existingSpace.GUID == space.GUID {
+			spaces[i] = space
+			return spaces
+		}
+	}
+	return append(spaces, space)
+}
+
 // UpdateSpaces -
-func (m *DefaultManager) UpdateSpaces() error {
+func (m *DefaultManager) UpdateSpaces(ctx context.Context) error {
 	m.spaces = nil
 	spaceConfigs, err := m.Cfg.GetSpaceConfigs()
 	if err != nil {
 		return err
 	}
-	err = m.init()
+	err = m.init(ctx)
 	if err != nil {
 		return err
 	}
-	for _, input := range spaceConfigs {
-		space, err := m.FindSpace(input.Org, input.Space)
-		if err != nil {
-			continue
+	errs := util.RunGrouped(ctx, m.Parallelism, len(spaceConfigs), func(i int) string { return spaceConfigs[i].Org }, true, func(i int) error {
+		input := spaceConfigs[i]
+		if m.Failures.Skip(input.Org, input.Space) {
+			lo.G.Infof("skipping space [%s/%s] as it failed in an earlier step", input.Org, input.Space)
+			return nil
 		}
-		lo.G.Debug("Processing space", space.Name)
-		sshEnabled, err := m.IsSSHEnabled(space)
+		return m.Failures.Add(input.Org, input.Space, m.updateSpace(ctx, input))
+	})
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	m.spaces = nil
+	return nil
+}
+
+func (m *DefaultManager) updateSpace(ctx context.Context, input config.SpaceConfig) error {
+	space, err := m.FindSpace(ctx, input.Org, input.Space)
+	if err != nil {
+		return nil
+	}
+	lo.G.Debug("Processing space", space.Name)
+	sshEnabled, err := m.IsSSHEnabled(ctx, space)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("Unable to query is space ssh enabled for %s/%s", input.Org, input.Space))
+	}
+	if input.AllowSSHUntil != "" {
+		allowUntil, err := time.Parse(time.RFC3339, input.AllowSSHUntil)
 		if err != nil {
-			return errors.Wrap(err, fmt.Sprintf("Unable to query is space ssh enabled for %s/%s", input.Org, input.Space))
+			return errors.Wrap(err, fmt.Sprintf("Unable to parse %s with format %s", input.AllowSSHUntil, time.RFC3339))
 		}
-		if input.AllowSSHUntil != "" {
-			allowUntil, err := time.Parse(time.RFC3339, input.AllowSSHUntil)
+		if allowUntil.After(time.Now()) && !sshEnabled {
+			change := m.recordSSH(input.Org, space.Name, sshEnabled, true)
+			if m.Peek {
+				lo.G.Infof("[dry-run]: temporarily enabling sshAllowed for org/space %s/%s until %s", input.Org, space.Name, input.AllowSSHUntil)
+				return nil
+			}
+			lo.G.Infof("temporarily enabling sshAllowed for org/space %s/%s until %s", input.Org, space.Name, input.AllowSSHUntil)
+			err = m.UpdateSpaceSSH(ctx, true, space, input.Org)
+			m.Recorder.Applied(change, space.GUID, err)
 			if err != nil {
-				return errors.Wrap(err, fmt.Sprintf("Unable to parse %s with format %s", input.AllowSSHUntil, time.RFC3339))
+				return err
 			}
-			if allowUntil.After(time.Now()) && !sshEnabled {
-				if m.Peek {
-					lo.G.Infof("[dry-run]: temporarily enabling sshAllowed for org/space %s/%s until %s", input.Org, space.Name, input.AllowSSHUntil)
-					continue
-				}
-				lo.G.Infof("temporarily enabling sshAllowed for org/space %s/%s until %s", input.Org, space.Name, input.AllowSSHUntil)
-				if err := m.UpdateSpaceSSH(true, space, input.Org); err != nil {
-					return err
-				}
+		}
+		if allowUntil.Before(time.Now()) && sshEnabled {
+			change := m.recordSSH(input.Org, space.Name, sshEnabled, false)
+			if m.Peek {
+				lo.G.Infof("[dry-run]: removing temporarily enabling sshAllowed for org/space %s/%s as past %s", input.Org, space.Name, input.AllowSSHUntil)
+				return nil
 			}
-			if allowUntil.Before(time.Now()) && sshEnabled {
-				if m.Peek {
-					lo.G.Infof("[dry-run]: removing temporarily enabling sshAllowed for org/space %s/%s as past %s", input.Org, space.Name, input.AllowSSHUntil)
-					continue
-				}
-				lo.G.Infof("removing temporarily enabling sshAllowed for org/space %s/%s as past %s", input.Org, space.Name, input.AllowSSHUntil)
-				if err := m.UpdateSpaceSSH(false, space, input.Org); err != nil {
-					return err
-				}
+			lo.G.Infof("removing temporarily enabling sshAllowed for org/space %s/%s as past %s", input.Org, space.Name, input.AllowSSHUntil)
+			err = m.UpdateSpaceSSH(ctx, false, space, input.Org)
+			m.Recorder.Applied(change, space.GUID, err)
+			if err != nil {
+				return err
 			}
-		} else {
-			if input.AllowSSH != sshEnabled {
-				if m.Peek {
-					lo.G.Infof("[dry-run]: setting sshAllowed to %v for org/space %s/%s", input.AllowSSH, input.Org, space.Name)
-					continue
-				}
-				lo.G.Infof("setting sshAllowed to %v for org/space %s/%s", input.AllowSSH, input.Org, space.Name)
-				if err := m.UpdateSpaceSSH(input.AllowSSH, space, input.Org); err != nil {
-					return err
-				}
+		}
+	} else {
+		if input.AllowSSH != sshEnabled {
+			change := m.recordSSH(input.Org, space.Name, sshEnabled, input.AllowSSH)
+			if m.Peek {
+				lo.G.Infof("[dry-run]: setting sshAllowed to %v for org/space %s/%s", input.AllowSSH, input.Org, space.Name)
+				return nil
+			}
+			lo.G.Infof("setting sshAllowed to %v for org/space %s/%s", input.AllowSSH, input.Org, space.Name)
+			err = m.UpdateSpaceSSH(ctx, input.AllowSSH, space, input.Org)
+			m.Recorder.Applied(change, space.GUID, err)
+			if err != nil {
+				return err
 			}
 		}
 	}
-	m.spaces = nil
 	return nil
 }
 
-func (m *DefaultManager) ListSpaces(orgGUID string) ([]*resource.Space, error) {
+func (m *DefaultManager) recordSSH(orgName, spaceName string, before, after bool) changes.Change {
+	change := changes.Change{Entity: changes.SpaceSSH, Action: changes.Update, Name: spaceName, Org: orgName, Space: spaceName, Before: before, After: after}
+	m.Recorder.Record(change)
+	return change
+}
+
+func (m *DefaultManager) ListSpaces(ctx context.Context, orgGUID string) ([]*resource.Space, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 	if m.spaces == nil {
-		err := m.init()
+		err := m.init(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -142,12 +210,12 @@ func (m *DefaultManager) ListSpaces(orgGUID string) ([]*resource.Space, error) {
 }
 
 // FindSpace -
-func (m *DefaultManager) FindSpace(orgName, spaceName string) (*resource.Space, error) {
-	orgGUID, err := m.OrgReader.GetOrgGUID(orgName)
+func (m *DefaultManager) FindSpace(ctx context.Context, orgName, spaceName string) (*resource.Space, error) {
+	orgGUID, err := m.OrgReader.GetOrgGUID(ctx, orgName)
 	if err != nil {
 		return nil, err
 	}
-	spaces, err := m.ListSpaces(orgGUID)
+	spaces, err := m.ListSpaces(ctx, orgGUID)
 	if err != nil {
 		return nil, err
 	}
@@ -157,13 +225,14 @@ func (m *DefaultManager) FindSpace(orgName, spaceName string) (*resource.Space,
 		}
 	}
 	if m.Peek {
+		dryRunOrgGUID := fmt.Sprintf("%s-dry-run-org-guid", orgName)
 		return &resource.Space{
 			Name: spaceName,
-			GUID: fmt.Sprintf("%s-dry-run-space-guid", spaceName),
+			GUID: dryRunSpaceGUID(dryRunOrgGUID, spaceName),
 			Relationships: &resource.SpaceRelationships{
 				Organization: &resource.ToOneRelationship{
 					Data: &resource.Relationship{
-						GUID: fmt.Sprintf("%s-dry-run-org-guid", orgName),
+						GUID: dryRunOrgGUID,
 					},
 				},
 			},
@@ -172,13 +241,27 @@ func (m *DefaultManager) FindSpace(orgName, spaceName string) (*resource.Space,
 	return nil, fmt.Errorf("space [%s] not found in org [%s]", spaceName, orgName)
 }
 
-func (m *DefaultManager) CreateSpace(spaceName, orgName, orgGUID string) error {
+func (m *DefaultManager) CreateSpace(ctx context.Context, spaceName, orgName, orgGUID string) error {
+	change := changes.Change{Entity: changes.Space, Action: changes.Create, Name: spaceName, Org: orgName, Space: spaceName}
+	m.Recorder.Record(change)
 	if m.Peek {
 		lo.G.Infof("[dry-run]: create space %s for org %s", spaceName, orgName)
+		m.addSimulatedSpace(&resource.Space{
+			Name: spaceName,
+			GUID: dryRunSpaceGUID(orgGUID, spaceName),
+			Relationships: &resource.SpaceRelationships{
+				Organization: &resource.ToOneRelationship{
+					Data: &resource.Relationship{
+						GUID: orgGUID,
+					},
+				},
+				Quota: &resource.ToOneRelationship{},
+			},
+		})
 		return nil
 	}
 	lo.G.Infof("create space %s for org %s", spaceName, orgName)
-	space, err := m.SpaceClient.Create(context.Background(), &resource.SpaceCreate{
+	space, err := m.SpaceClient.Create(util.CallContext(ctx), &resource.SpaceCreate{
 		Name: spaceName,
 		Relationships: &resource.SpaceRelationships{
 			Organization: &resource.ToOneRelationship{
@@ -188,64 +271,66 @@ func (m *DefaultManager) CreateSpace(spaceName, orgName, orgGUID string) error {
 			},
 		},
 	})
+	var guid string
+	if space != nil {
+		guid = space.GUID
+	}
+	m.Recorder.Applied(change, guid, err)
+	if err != nil {
+		return err
+	}
 	m.spaces = append(m.spaces, space)
-	return err
+	return nil
 }
 
-func (m *DefaultManager) RenameSpace(originalSpaceName, spaceName, orgName string) error {
+func (m *DefaultManager) RenameSpace(ctx context.Context, originalSpaceName, spaceName, orgName string) error {
+	change := changes.Change{Entity: changes.Space, Action: changes.Update, Name: spaceName, Org: orgName, Space: spaceName, Before: originalSpaceName, After: spaceName}
+	m.Recorder.Record(change)
 	if m.Peek {
 		lo.G.Infof("[dry-run]: rename space %s for org %s to %s", originalSpaceName, orgName, spaceName)
+		space, err := m.FindSpace(ctx, orgName, originalSpaceName)
+		if err != nil {
+			return err
+		}
+		renamedSpace := *space
+		renamedSpace.Name = spaceName
+		m.addSimulatedSpace(&renamedSpace)
 		return nil
 	}
 	lo.G.Infof("rename space %s for org %s to %s", originalSpaceName, orgName, spaceName)
 
-	space, err := m.FindSpace(orgName, originalSpaceName)
+	space, err := m.FindSpace(ctx, orgName, originalSpaceName)
 	if err != nil {
 		return err
 	}
-	_, err = m.SpaceClient.Update(context.Background(), space.GUID, &resource.SpaceUpdate{
+	_, err = m.SpaceClient.Update(util.CallContext(ctx), space.GUID, &resource.SpaceUpdate{
 		Name: spaceName,
 	})
+	m.Recorder.Applied(change, space.GUID, err)
 	space.Name = spaceName
 	return err
 }
 
 // CreateSpaces -
-func (m *DefaultManager) CreateSpaces() error {
+func (m *DefaultManager) CreateSpaces(ctx context.Context) error {
 	m.spaces = nil
 	configSpaceList, err := m.Cfg.GetSpaceConfigs()
 	if err != nil {
 		return err
 	}
-	err = m.init()
+	err = m.init(ctx)
 	if err != nil {
 		return err
 	}
 	for _, space := range configSpaceList {
-		orgGUID, err := m.OrgReader.GetOrgGUID(space.Org)
-		if err != nil {
+		if err := ctx.Err(); err != nil {
 			return err
 		}
-		spaces, err := m.ListSpaces(orgGUID)
-		if err != nil {
+		if m.Failures.Skip(space.Org, space.Space) {
+			lo.G.Infof("skipping space [%s/%s] as it failed in an earlier step", space.Org, space.Space)
 			continue
 		}
-
-		if m.doesSpaceExist(spaces, space.Space) {
-			lo.G.Debugf("[%s] space already exists in org [%s]", space.Space, space.Org)
-			continue
-		} else if doesSpaceExistFromRename(space.OriginalSpace, spaces) {
-			lo.G.Debugf("renamed space [%s] already exists as [%s]", space.Space, space.OriginalSpace)
-			if err = m.RenameSpace(space.OriginalSpace, space.Space, space.Org); err != nil {
-				return err
-			}
-
-			continue
-		} else {
-			lo.G.Debugf("[%s] space doesn't exist in [%v]", space.Space, spaces)
-		}
-		if err = m.CreateSpace(space.Space, space.Org, orgGUID); err != nil {
-			lo.G.Error(err)
+		if err := m.Failures.Add(space.Org, space.Space, m.createSpace(ctx, space)); err != nil {
 			return err
 		}
 	}
@@ -253,6 +338,32 @@ func (m *DefaultManager) CreateSpaces() error {
 	return nil
 }
 
+func (m *DefaultManager) createSpace(ctx context.Context, space config.SpaceConfig) error {
+	orgGUID, err := m.OrgReader.GetOrgGUID(ctx, space.Org)
+	if err != nil {
+		return err
+	}
+	spaces, err := m.ListSpaces(ctx, orgGUID)
+	if err != nil {
+		return nil
+	}
+
+	if m.doesSpaceExist(spaces, space.Space) {
+		lo.G.Debugf("[%s] space already exists in org [%s]", space.Space, space.Org)
+		return nil
+	} else if doesSpaceExistFromRename(space.OriginalSpace, spaces) {
+		lo.G.Debugf("renamed space [%s] already exists as [%s]", space.Space, space.OriginalSpace)
+		return m.RenameSpace(ctx, space.OriginalSpace, space.Space, space.Org)
+	} else {
+		lo.G.Debugf("[%s] space doesn't exist in [%v]", space.Space, spaces)
+	}
+	if err = m.CreateSpace(ctx, space.Space, space.Org, orgGUID); err != nil {
+		lo.G.Error(err)
+		return err
+	}
+	return nil
+}
+
 func (m *DefaultManager) doesSpaceExist(spaces []*resource.Space, spaceName string) bool {
 	for _, space := range spaces {
 		if strings.EqualFold(space.Name, spaceName) {
@@ -271,75 +382,124 @@ func doesSpaceExistFromRename(spaceName string, spaces []*resource.Space) bool {
 	return false
 }
 
-func (m *DefaultManager) DeleteSpaces() error {
+func (m *DefaultManager) DeleteSpaces(ctx context.Context) error {
 	m.spaces = nil
 	configSpaceList, err := m.Cfg.Spaces()
 	if err != nil {
 		return err
 	}
 
-	err = m.init()
+	err = m.init(ctx)
 	if err != nil {
 		return err
 	}
+	var deletions []spaceDeletion
+	totalSpaces := 0
 	for _, input := range configSpaceList {
 		if !input.EnableDeleteSpaces {
 			lo.G.Debugf("Space deletion is not enabled for %s.  Set enable-delete-spaces: true in spaces.yml", input.Org)
 			continue //Skip all orgs that have not opted-in
 		}
-		renamedSpaces := make(map[string]string)
-		configuredSpaces := make(map[string]bool)
-		for _, spaceName := range input.Spaces {
-			spaceCfg, err := m.Cfg.GetSpaceConfig(input.Org, spaceName)
-			if err != nil {
+		if m.Failures.Skip(input.Org, "") {
+			lo.G.Infof("skipping space deletion for org [%s] as it failed in an earlier step", input.Org)
+			continue
+		}
+		spaces, spacesToDelete, err := m.spacesToDelete(ctx, input)
+		if err != nil {
+			if err := m.Failures.Add(input.Org, "", err); err != nil {
 				return err
 			}
-			if spaceCfg.OriginalSpace != "" {
-				renamedSpaces[spaceCfg.OriginalSpace] = spaceName
-			}
-			configuredSpaces[spaceName] = true
+			continue
 		}
+		totalSpaces += len(spaces)
+		for _, space := range spacesToDelete {
+			deletions = append(deletions, spaceDeletion{orgName: input.Org, space: space})
+		}
+	}
 
-		org, err := m.OrgReader.FindOrg(input.Org)
+	if len(deletions) > 0 && !m.AllowLargeDeletions {
+		globalCfg, err := m.Cfg.GetGlobalConfig()
 		if err != nil {
 			return err
 		}
-		spaces, err := m.ListSpaces(org.GUID)
-		if err != nil {
+		if err := globalCfg.DeletionLimits.Spaces.Check("spaces", len(deletions), totalSpaces); err != nil {
 			return err
 		}
+	}
 
-		spacesToDelete := make([]*resource.Space, 0)
-		for _, space := range spaces {
-			if _, exists := configuredSpaces[space.Name]; !exists {
-				if _, renamed := renamedSpaces[space.Name]; !renamed {
-					spacesToDelete = append(spacesToDelete, space)
-				}
-			}
+	for _, deletion := range deletions {
+		if err := ctx.Err(); err != nil {
+			return err
 		}
-
-		for _, space := range spacesToDelete {
-			if err := m.DeleteSpace(space, input.Org); err != nil {
-				return err
-			}
+		if err := m.Failures.Add(deletion.orgName, deletion.space.Name, m.DeleteSpace(ctx, deletion.space, deletion.orgName)); err != nil {
+			return err
 		}
 	}
 	m.spaces = nil
 	return nil
 }
 
+type spaceDeletion struct {
+	orgName string
+	space   *resource.Space
+}
+
+// spacesToDelete - returns the spaces of the org and those of them that aren't configured
+func (m *DefaultManager) spacesToDelete(ctx context.Context, input config.Spaces) ([]*resource.Space, []*resource.Space, error) {
+	renamedSpaces := make(map[string]string)
+	configuredSpaces := make(map[string]bool)
+	for _, spaceName := range input.Spaces {
+		spaceCfg, err := m.Cfg.GetSpaceConfig(input.Org, spaceName)
+		if err != nil {
+			return nil, nil, err
+		}
+		if spaceCfg.OriginalSpace != "" {
+			renamedSpaces[spaceCfg.OriginalSpace] = spaceName
+		}
+		configuredSpaces[spaceName] = true
+	}
+
+	org, err := m.OrgReader.FindOrg(ctx, input.Org)
+	if err != nil {
+		return nil, nil, err
+	}
+	spaces, err := m.ListSpaces(ctx, org.GUID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	selection := config.SelectionOf(m.Cfg)
+	spacesToDelete := make([]*resource.Space, 0)
+	for _, space := range spaces {
+		if _, exists := configuredSpaces[space.Name]; !exists {
+			if !selection.IncludesUnconfiguredSpace(input.Org, space.Name) {
+				lo.G.Debugf("Space [%s/%s] is not selected - will not be deleted", input.Org, space.Name)
+				continue
+			}
+			if _, renamed := renamedSpaces[space.Name]; !renamed {
+				spacesToDelete = append(spacesToDelete, space)
+			}
+		}
+	}
+
+	return spaces, spacesToDelete, nil
+}
+
 // DeleteSpace - deletes a space based on GUID
-func (m *DefaultManager) DeleteSpace(space *resource.Space, orgName string) error {
+func (m *DefaultManager) DeleteSpace(ctx context.Context, space *resource.Space, orgName string) error {
+	change := changes.Change{Entity: changes.Space, Action: changes.Delete, Name: space.Name, Org: orgName, Space: space.Name}
+	m.Recorder.Record(change)
 	if m.Peek {
 		lo.G.Infof("[dry-run]: delete space with %s from org %s", space.Name, orgName)
 		return nil
 	}
 	lo.G.Infof("delete space with %s from org %s", space.Name, orgName)
-	_, err := m.SpaceClient.Delete(context.Background(), space.GUID)
+	_, err := m.SpaceClient.Delete(util.CallContext(ctx), space.GUID)
+	m.Recorder.Applied(change, space.GUID, err)
 	return err
 }
 
-func (m *DefaultManager) UpdateSpacesMetadata() error {
+func (m *DefaultManager) UpdateSpacesMetadata(ctx context.Context) error {
 	spaceConfigs, err := m.Cfg.GetSpaceConfigs()
 	if err != nil {
 		return err
@@ -350,121 +510,139 @@ func (m *DefaultManager) UpdateSpacesMetadata() error {
 		return err
 	}
 
-	for _, spaceConfig := range spaceConfigs {
-		if spaceConfig.Metadata != nil {
-			space, err := m.FindSpace(spaceConfig.Org, spaceConfig.Space)
-			if err != nil {
-				continue
-			}
-			if space.Metadata == nil {
-				space.Metadata = &resource.Metadata{}
-			}
+	errs := util.RunGrouped(ctx, m.Parallelism, len(spaceConfigs), func(i int) string { return spaceConfigs[i].Org }, true, func(i int) error {
+		spaceConfig := spaceConfigs[i]
+		if m.Failures.Skip(spaceConfig.Org, spaceConfig.Space) {
+			lo.G.Infof("skipping space [%s/%s] metadata as it failed in an earlier step", spaceConfig.Org, spaceConfig.Space)
+			return nil
+		}
+		return m.Failures.Add(spaceConfig.Org, spaceConfig.Space, m.updateSpaceMetadata(ctx, globalCfg, spaceConfig))
+	})
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
 
-			spaceYamlOriginal, err := yaml.Marshal(space.Metadata)
-			if err != nil {
-				return err
-			}
-			//clear any labels that start with the prefix
-			for key, _ := range space.Metadata.Labels {
-				if strings.Contains(key, globalCfg.MetadataPrefix) {
-					space.Metadata.Labels[key] = nil
-				}
+func (m *DefaultManager) updateSpaceMetadata(ctx context.Context, globalCfg *config.GlobalConfig, spaceConfig config.SpaceConfig) error {
+	if spaceConfig.Metadata != nil {
+		space, err := m.FindSpace(ctx, spaceConfig.Org, spaceConfig.Space)
+		if err != nil {
+			return nil
+		}
+		if space.Metadata == nil {
+			space.Metadata = &resource.Metadata{}
+		}
+
+		spaceYamlOriginal, err := yaml.Marshal(space.Metadata)
+		if err != nil {
+			return err
+		}
+		//clear any labels that start with the prefix
+		for key, _ := range space.Metadata.Labels {
+			if strings.Contains(key, globalCfg.MetadataPrefix) {
+				space.Metadata.Labels[key] = nil
 			}
-			if spaceConfig.Metadata.Labels != nil {
-				for key, value := range spaceConfig.Metadata.Labels {
-					if len(value) > 0 {
-						if globalCfg.UseMetadataPrefix {
-							if strings.Contains(key, globalCfg.MetadataPrefix) {
-								space.Metadata.SetLabel(globalCfg.MetadataPrefix, strings.ReplaceAll(key, globalCfg.MetadataPrefix+"/", ""), value)
-							} else {
-								space.Metadata.SetLabel(globalCfg.MetadataPrefix, key, value)
-							}
+		}
+		if spaceConfig.Metadata.Labels != nil {
+			for key, value := range spaceConfig.Metadata.Labels {
+				if len(value) > 0 {
+					if globalCfg.UseMetadataPrefix {
+						if strings.Contains(key, globalCfg.MetadataPrefix) {
+							space.Metadata.SetLabel(globalCfg.MetadataPrefix, strings.ReplaceAll(key, globalCfg.MetadataPrefix+"/", ""), value)
 						} else {
-							space.Metadata.SetLabel("", key, value)
+							space.Metadata.SetLabel(globalCfg.MetadataPrefix, key, value)
 						}
 					} else {
-						if globalCfg.UseMetadataPrefix {
-							if strings.Contains(key, globalCfg.MetadataPrefix) {
-								space.Metadata.RemoveLabel(globalCfg.MetadataPrefix, strings.ReplaceAll(key, globalCfg.MetadataPrefix+"/", ""))
-							} else {
-								space.Metadata.RemoveLabel(globalCfg.MetadataPrefix, key)
-							}
+						space.Metadata.SetLabel("", key, value)
+					}
+				} else {
+					if globalCfg.UseMetadataPrefix {
+						if strings.Contains(key, globalCfg.MetadataPrefix) {
+							space.Metadata.RemoveLabel(globalCfg.MetadataPrefix, strings.ReplaceAll(key, globalCfg.MetadataPrefix+"/", ""))
 						} else {
-							space.Metadata.RemoveLabel("", key)
+							space.Metadata.RemoveLabel(globalCfg.MetadataPrefix, key)
 						}
+					} else {
+						space.Metadata.RemoveLabel("", key)
 					}
 				}
 			}
-			//clear any labels that start with the prefix
-			for key, _ := range space.Metadata.Annotations {
-				if strings.Contains(key, globalCfg.MetadataPrefix) {
-					space.Metadata.Annotations[key] = nil
-				}
+		}
+		//clear any labels that start with the prefix
+		for key, _ := range space.Metadata.Annotations {
+			if strings.Contains(key, globalCfg.MetadataPrefix) {
+				space.Metadata.Annotations[key] = nil
 			}
-			if spaceConfig.Metadata.Annotations != nil {
-				for key, value := range spaceConfig.Metadata.Annotations {
-					if len(value) > 0 {
-						if globalCfg.UseMetadataPrefix {
-							if strings.Contains(key, globalCfg.MetadataPrefix) {
-								space.Metadata.SetAnnotation(globalCfg.MetadataPrefix, strings.ReplaceAll(key, globalCfg.MetadataPrefix+"/", ""), value)
-							} else {
-								space.Metadata.SetAnnotation(globalCfg.MetadataPrefix, key, value)
-							}
+		}
+		if spaceConfig.Metadata.Annotations != nil {
+			for key, value := range spaceConfig.Metadata.Annotations {
+				if len(value) > 0 {
+					if globalCfg.UseMetadataPrefix {
+						if strings.Contains(key, globalCfg.MetadataPrefix) {
+							space.Metadata.SetAnnotation(globalCfg.MetadataPrefix, strings.ReplaceAll(key, globalCfg.MetadataPrefix+"/", ""), value)
 						} else {
-							space.Metadata.SetAnnotation("", key, value)
+							space.Metadata.SetAnnotation(globalCfg.MetadataPrefix, key, value)
 						}
 					} else {
-						if globalCfg.UseMetadataPrefix {
-							if strings.Contains(key, globalCfg.MetadataPrefix) {
-								space.Metadata.RemoveAnnotation(globalCfg.MetadataPrefix, strings.ReplaceAll(key, globalCfg.MetadataPrefix+"/", ""))
-							} else {
-								space.Metadata.RemoveAnnotation(globalCfg.MetadataPrefix, key)
-							}
+						space.Metadata.SetAnnotation("", key, value)
+					}
+				} else {
+					if globalCfg.UseMetadataPrefix {
+						if strings.Contains(key, globalCfg.MetadataPrefix) {
+							space.Metadata.RemoveAnnotation(globalCfg.MetadataPrefix, strings.ReplaceAll(key, globalCfg.MetadataPrefix+"/", ""))
 						} else {
-							space.Metadata.RemoveAnnotation("", key)
+							space.Metadata.RemoveAnnotation(globalCfg.MetadataPrefix, key)
 						}
+					} else {
+						space.Metadata.RemoveAnnotation("", key)
 					}
 				}
 			}
+		}
 
-			spaceYamlNew, err := yaml.Marshal(space.Metadata)
+		spaceYamlNew, err := yaml.Marshal(space.Metadata)
+		if err != nil {
+			return err
+		}
+		if strings.EqualFold(string(spaceYamlNew), string(spaceYamlOriginal)) {
+			lo.G.Debugf("No changes to yaml old [%s] and new [%s]", string(spaceYamlOriginal), string(spaceYamlNew))
+		} else {
+			change := changes.Change{Entity: changes.SpaceMetadata, Action: changes.Update, Name: space.Name, Org: spaceConfig.Org, Space: space.Name, Before: string(spaceYamlOriginal), After: string(spaceYamlNew)}
+			m.Recorder.Record(change)
+			err = m.UpdateSpaceMetadata(ctx, change, spaceConfig.Org, space)
 			if err != nil {
 				return err
 			}
-			if strings.EqualFold(string(spaceYamlNew), string(spaceYamlOriginal)) {
-				lo.G.Debugf("No changes to yaml old [%s] and new [%s]", string(spaceYamlOriginal), string(spaceYamlNew))
-			} else {
-				err = m.UpdateSpaceMetadata(spaceConfig.Org, space)
-				if err != nil {
-					return err
-				}
-			}
-
 		}
 	}
 	return nil
 }
 
-func (m *DefaultManager) UpdateSpaceMetadata(org string, space *resource.Space) error {
+func (m *DefaultManager) UpdateSpaceMetadata(ctx context.Context, change changes.Change, org string, space *resource.Space) error {
 	if m.Peek {
 		lo.G.Infof("[dry-run]: update org/space %s/%s metadata", org, space.Name)
 		return nil
 	}
 	lo.G.Infof("update org/space %s/%s metadata", org, space.Name)
-	_, err := m.SpaceClient.Update(context.Background(), space.GUID, &resource.SpaceUpdate{
+	_, err := m.SpaceClient.Update(util.CallContext(ctx), space.GUID, &resource.SpaceUpdate{
 		Name:     space.Name,
 		Metadata: space.Metadata,
 	})
+	m.Recorder.Applied(change, space.GUID, err)
 	return err
 }
 
-func (m *DefaultManager) DeleteSpacesForOrg(orgGUID, orgName string) (err error) {
-	spaces, err := m.ListSpaces(orgGUID)
+func (m *DefaultManager) DeleteSpacesForOrg(ctx context.Context, orgGUID, orgName string) (err error) {
+	spaces, err := m.ListSpaces(ctx, orgGUID)
 	if err != nil {
 		return err
 	}
 	for _, space := range spaces {
-		err := m.DeleteSpace(space, orgName)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		err := m.DeleteSpace(ctx, space, orgName)
 		if err != nil {
 			return err
 		}
@@ -472,17 +650,30 @@ func (m *DefaultManager) DeleteSpacesForOrg(orgGUID, orgName string) (err error)
 	return nil
 }
 
-func (m *DefaultManager) IsSSHEnabled(space *resource.Space) (bool, error) {
-	if m.Peek && space.GUID == fmt.Sprintf("%s-dry-run-space-guid", space.Name) {
+// dryRunSpaceGUID - the guid of a space simulated while peeking, which includes the
+// org so that new orgs with a space of the same name don't replace each other's space
+func dryRunSpaceGUID(orgGUID, spaceName string) string {
+	return fmt.Sprintf("%s-%s-dry-run-space-guid", orgGUID, spaceName)
+}
+
+func isDryRunSpace(space *resource.Space) bool {
+	if space.Relationships == nil || space.Relationships.Organization == nil || space.Relationships.Organization.Data == nil {
+		return false
+	}
+	return space.GUID == dryRunSpaceGUID(space.Relationships.Organization.Data.GUID, space.Name)
+}
+
+func (m *DefaultManager) IsSSHEnabled(ctx context.Context, space *resource.Space) (bool, error) {
+	if m.Peek && isDryRunSpace(space) {
 		lo.G.Infof("[dry-run]: checking if ssh enabled for org/space %s", space.Name)
 		return true, nil
 	}
-	return m.SpaceFeatureClient.IsSSHEnabled(context.Background(), space.GUID)
+	return m.SpaceFeatureClient.IsSSHEnabled(util.CallContext(ctx), space.GUID)
 }
-func (m *DefaultManager) GetSpaceIsolationSegmentGUID(space *resource.Space) (string, error) {
-	if m.Peek && space.GUID == fmt.Sprintf("%s-dry-run-space-guid", space.Name) {
+func (m *DefaultManager) GetSpaceIsolationSegmentGUID(ctx context.Context, space *resource.Space) (string, error) {
+	if m.Peek && isDryRunSpace(space) {
 		lo.G.Infof("[dry-run]: checking if ssh enabled for org/space %s", space.Name)
 		return fmt.Sprintf("%s-dry-run-space-isolation-guid", space.Name), nil
 	}
-	return m.SpaceClient.GetAssignedIsolationSegment(context.Background(), space.GUID)
+	return m.SpaceClient.GetAssignedIsolationSegment(util.CallContext(ctx), space.GUID)
 }
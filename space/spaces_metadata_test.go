@@ -1,6 +1,8 @@
 package space_test
 
 import (
+	"context"
+
 	"github.com/cloudfoundry-community/go-cfclient/v3/resource"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -72,7 +74,7 @@ var _ = Describe("given SpaceManager", func() {
 			}
 			fakeOrgMgr.GetOrgGUIDReturns("testOrgGUID", nil)
 			fakeSpaceClient.ListAllReturns(spaces, nil)
-			err := spaceManager.UpdateSpacesMetadata()
+			err := spaceManager.UpdateSpacesMetadata(context.Background())
 			Expect(err).Should(BeNil())
 
 			Expect(fakeSpaceClient.UpdateCallCount()).Should(Equal(1))
@@ -114,7 +116,7 @@ var _ = Describe("given SpaceManager", func() {
 			}
 			fakeOrgMgr.GetOrgGUIDReturns("testOrgGUID", nil)
 			fakeSpaceClient.ListAllReturns(spaces, nil)
-			err := spaceManager.UpdateSpacesMetadata()
+			err := spaceManager.UpdateSpacesMetadata(context.Background())
 			Expect(err).Should(BeNil())
 
 			Expect(fakeSpaceClient.UpdateCallCount()).Should(Equal(1))
@@ -155,7 +157,7 @@ var _ = Describe("given SpaceManager", func() {
 			}
 			fakeOrgMgr.GetOrgGUIDReturns("testOrgGUID", nil)
 			fakeSpaceClient.ListAllReturns(spaces, nil)
-			err := spaceManager.UpdateSpacesMetadata()
+			err := spaceManager.UpdateSpacesMetadata(context.Background())
 			Expect(err).Should(BeNil())
 
 			Expect(fakeSpaceClient.UpdateCallCount()).Should(Equal(1))
@@ -196,7 +198,7 @@ var _ = Describe("given SpaceManager", func() {
 			}
 			fakeOrgMgr.GetOrgGUIDReturns("testOrgGUID", nil)
 			fakeSpaceClient.ListAllReturns(spaces, nil)
-			err := spaceManager.UpdateSpacesMetadata()
+			err := spaceManager.UpdateSpacesMetadata(context.Background())
 			Expect(err).Should(BeNil())
 
 			Expect(fakeSpaceClient.UpdateCallCount()).Should(Equal(1))
@@ -1,6 +1,8 @@
 package space_test
 
 import (
+	"context"
+
 	"errors"
 	"fmt"
 
@@ -9,6 +11,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/vmwarepivotallabs/cf-mgmt/config"
 	configfakes "github.com/vmwarepivotallabs/cf-mgmt/config/fakes"
+	"github.com/vmwarepivotallabs/cf-mgmt/failures"
 	"github.com/vmwarepivotallabs/cf-mgmt/uaa"
 
 	"time"
@@ -34,6 +37,7 @@ var _ = Describe("given SpaceManager", func() {
 		fakeOrgMgr = new(orgfakes.FakeReader)
 		fakeReader = new(configfakes.FakeReader)
 		fakeSpaceClient = new(spacefakes.FakeCFSpaceClient)
+		fakeReader.GetGlobalConfigReturns(&config.GlobalConfig{}, nil)
 		fakeSpaceFeatureClient = new(spacefakes.FakeCFSpaceFeatureClient)
 		spaceManager = space.DefaultManager{
 			Cfg:                fakeReader,
@@ -61,7 +65,7 @@ var _ = Describe("given SpaceManager", func() {
 			}
 			fakeOrgMgr.GetOrgGUIDReturns("testOrgGUID", nil)
 			fakeSpaceClient.ListAllReturns(spaces, nil)
-			space, err := spaceManager.FindSpace("testOrg", "testSpace")
+			space, err := spaceManager.FindSpace(context.Background(), "testOrg", "testSpace")
 			Expect(err).Should(BeNil())
 			Expect(space).ShouldNot(BeNil())
 			Expect(space.Name).Should(Equal("testSpace"))
@@ -81,19 +85,19 @@ var _ = Describe("given SpaceManager", func() {
 			}
 			fakeOrgMgr.GetOrgGUIDReturns("testOrgGUID", nil)
 			fakeSpaceClient.ListAllReturns(spaces, nil)
-			_, err := spaceManager.FindSpace("testOrg", "testSpace2")
+			_, err := spaceManager.FindSpace(context.Background(), "testOrg", "testSpace2")
 			Expect(err).Should(HaveOccurred())
 		})
 
 		It("should return an error if unable to get OrgGUID", func() {
 			fakeOrgMgr.GetOrgGUIDReturns("", fmt.Errorf("test"))
-			_, err := spaceManager.FindSpace("testOrg", "testSpace2")
+			_, err := spaceManager.FindSpace(context.Background(), "testOrg", "testSpace2")
 			Expect(err).Should(HaveOccurred())
 		})
 		It("should return an error if unable to get Spaces", func() {
 			fakeOrgMgr.GetOrgGUIDReturns("testOrgGUID", nil)
 			fakeSpaceClient.ListAllReturns(nil, fmt.Errorf("test"))
-			_, err := spaceManager.FindSpace("testOrg", "testSpace2")
+			_, err := spaceManager.FindSpace(context.Background(), "testOrg", "testSpace2")
 			Expect(err).Should(HaveOccurred())
 		})
 	})
@@ -124,7 +128,7 @@ var _ = Describe("given SpaceManager", func() {
 					},
 				},
 			}, nil)
-			Expect(spaceManager.CreateSpaces()).Should(Succeed())
+			Expect(spaceManager.CreateSpaces(context.Background())).Should(Succeed())
 			Expect(fakeSpaceClient.CreateCallCount()).Should(Equal(2))
 			var spaceNames []string
 			_, spaceRequest := fakeSpaceClient.CreateArgsForCall(0)
@@ -136,6 +140,49 @@ var _ = Describe("given SpaceManager", func() {
 			Expect(spaceNames).Should(ConsistOf([]string{"space1", "space2"}))
 		})
 
+		It("should keep creating spaces after one fails when collecting failures", func() {
+			spaceManager.Failures = failures.NewCollector()
+			fakeReader.GetSpaceConfigsReturns([]config.SpaceConfig{
+				{Org: "org1", Space: "space1"},
+				{Org: "org1", Space: "space2"},
+				{Org: "org2", Space: "space3"},
+			}, nil)
+			fakeOrgMgr.GetOrgGUIDReturns("testOrgGUID", nil)
+			fakeSpaceClient.ListAllReturns([]*resource.Space{}, nil)
+			fakeSpaceClient.CreateReturns(&resource.Space{
+				Name: "created",
+				Relationships: &resource.SpaceRelationships{
+					Organization: &resource.ToOneRelationship{
+						Data: &resource.Relationship{
+							GUID: "testOrgGUID",
+						},
+					},
+				},
+			}, nil)
+			fakeSpaceClient.CreateReturnsOnCall(0, nil, errors.New("create failed"))
+			Expect(spaceManager.CreateSpaces(context.Background())).Should(Succeed())
+			Expect(fakeSpaceClient.CreateCallCount()).Should(Equal(3))
+			Expect(spaceManager.Failures.Failures()).Should(HaveLen(1))
+			Expect(spaceManager.Failures.Skip("org1", "space1")).Should(BeTrue())
+			Expect(spaceManager.Failures.Skip("org1", "space2")).Should(BeFalse())
+		})
+
+		It("should skip spaces of an org that failed in an earlier step", func() {
+			spaceManager.Failures = failures.NewCollector()
+			spaceManager.Failures.Add("org1", "", errors.New("create org failed"))
+			fakeReader.GetSpaceConfigsReturns([]config.SpaceConfig{
+				{Org: "org1", Space: "space1"},
+				{Org: "org2", Space: "space2"},
+			}, nil)
+			fakeOrgMgr.GetOrgGUIDReturns("testOrgGUID", nil)
+			fakeSpaceClient.ListAllReturns([]*resource.Space{}, nil)
+			fakeSpaceClient.CreateReturns(&resource.Space{}, nil)
+			Expect(spaceManager.CreateSpaces(context.Background())).Should(Succeed())
+			Expect(fakeSpaceClient.CreateCallCount()).Should(Equal(1))
+			_, spaceRequest := fakeSpaceClient.CreateArgsForCall(0)
+			Expect(spaceRequest.Name).Should(Equal("space2"))
+		})
+
 		It("should create 1 space", func() {
 			spaces := []*resource.Space{
 				{
@@ -152,7 +199,7 @@ var _ = Describe("given SpaceManager", func() {
 			fakeOrgMgr.GetOrgGUIDReturns("testOrgGUID", nil)
 			fakeSpaceClient.ListAllReturns(spaces, nil)
 
-			Expect(spaceManager.CreateSpaces()).Should(Succeed())
+			Expect(spaceManager.CreateSpaces(context.Background())).Should(Succeed())
 			Expect(fakeSpaceClient.CreateCallCount()).Should(Equal(1))
 			_, spaceRequest := fakeSpaceClient.CreateArgsForCall(0)
 			Expect(spaceRequest.Relationships.Organization.Data.GUID).Should(Equal("testOrgGUID"))
@@ -161,7 +208,7 @@ var _ = Describe("given SpaceManager", func() {
 
 		It("should create error if unable to get orgGUID", func() {
 			fakeOrgMgr.GetOrgGUIDReturns("", errors.New("error1"))
-			Expect(spaceManager.CreateSpaces()).ShouldNot(Succeed())
+			Expect(spaceManager.CreateSpaces(context.Background())).ShouldNot(Succeed())
 		})
 
 		It("should rename a space", func() {
@@ -186,12 +233,89 @@ var _ = Describe("given SpaceManager", func() {
 			}
 			fakeOrgMgr.GetOrgGUIDReturns("testOrgGUID", nil)
 			fakeSpaceClient.ListAllReturns(spaces, nil)
-			Expect(spaceManager.CreateSpaces()).Should(Succeed())
+			Expect(spaceManager.CreateSpaces(context.Background())).Should(Succeed())
 			Expect(fakeSpaceClient.UpdateCallCount()).Should(Equal(1))
 			_, spaceGUID, spaceRequest := fakeSpaceClient.UpdateArgsForCall(0)
 			Expect(spaceGUID).Should(Equal("space1-guid"))
 			Expect(spaceRequest.Name).Should(Equal("new-space1"))
 		})
+
+		It("should simulate created spaces when peeking", func() {
+			spaceManager.Peek = true
+			fakeOrgMgr.GetOrgGUIDReturns("testOrgGUID", nil)
+			fakeSpaceClient.ListAllReturns([]*resource.Space{}, nil)
+			Expect(spaceManager.CreateSpaces(context.Background())).Should(Succeed())
+			Expect(fakeSpaceClient.CreateCallCount()).Should(Equal(0))
+			spaces, err := spaceManager.ListSpaces(context.Background(), "testOrgGUID")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(spaces).Should(HaveLen(2))
+			space, err := spaceManager.FindSpace(context.Background(), "test", "space1")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(space.GUID).Should(Equal("testOrgGUID-space1-dry-run-space-guid"))
+			Expect(space.Relationships.Organization.Data.GUID).Should(Equal("testOrgGUID"))
+			Expect(space.Relationships.Quota).ShouldNot(BeNil())
+		})
+
+		It("should simulate a space of the same name in two new orgs when peeking", func() {
+			spaceManager.Peek = true
+			fakeReader.GetSpaceConfigsReturns([]config.SpaceConfig{
+				{Org: "org1", Space: "space1"},
+				{Org: "org2", Space: "space1"},
+			}, nil)
+			fakeOrgMgr.GetOrgGUIDStub = func(ctx context.Context, orgName string) (string, error) {
+				return orgName + "-dry-run-org-guid", nil
+			}
+			fakeSpaceClient.ListAllReturns([]*resource.Space{}, nil)
+			Expect(spaceManager.CreateSpaces(context.Background())).Should(Succeed())
+			Expect(fakeSpaceClient.CreateCallCount()).Should(Equal(0))
+			for _, orgName := range []string{"org1", "org2"} {
+				spaces, err := spaceManager.ListSpaces(context.Background(), orgName+"-dry-run-org-guid")
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(spaces).Should(HaveLen(1))
+				space, err := spaceManager.FindSpace(context.Background(), orgName, "space1")
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(space.GUID).Should(Equal(orgName + "-dry-run-org-guid-space1-dry-run-space-guid"))
+				Expect(space.Relationships.Organization.Data.GUID).Should(Equal(orgName + "-dry-run-org-guid"))
+				sshEnabled, err := spaceManager.IsSSHEnabled(context.Background(), space)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(sshEnabled).Should(BeTrue())
+				_, err = spaceManager.GetSpaceIsolationSegmentGUID(context.Background(), space)
+				Expect(err).ShouldNot(HaveOccurred())
+			}
+			Expect(fakeSpaceFeatureClient.IsSSHEnabledCallCount()).Should(Equal(0))
+			Expect(fakeSpaceClient.GetAssignedIsolationSegmentCallCount()).Should(Equal(0))
+		})
+
+		It("should simulate a renamed space when peeking", func() {
+			spaceManager.Peek = true
+			fakeReader.GetSpaceConfigsReturns([]config.SpaceConfig{
+				{
+					Space:         "new-space1",
+					OriginalSpace: "space1",
+				},
+			}, nil)
+			fakeOrgMgr.GetOrgGUIDReturns("testOrgGUID", nil)
+			fakeSpaceClient.ListAllReturns([]*resource.Space{
+				{
+					Name: "space1",
+					GUID: "space1-guid",
+					Relationships: &resource.SpaceRelationships{
+						Organization: &resource.ToOneRelationship{
+							Data: &resource.Relationship{
+								GUID: "testOrgGUID",
+							},
+						},
+					},
+				},
+			}, nil)
+			Expect(spaceManager.CreateSpaces(context.Background())).Should(Succeed())
+			Expect(fakeSpaceClient.UpdateCallCount()).Should(Equal(0))
+			spaces, err := spaceManager.ListSpaces(context.Background(), "testOrgGUID")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(spaces).Should(HaveLen(1))
+			Expect(spaces[0].Name).Should(Equal("new-space1"))
+			Expect(spaces[0].GUID).Should(Equal("space1-guid"))
+		})
 	})
 
 	Context("UpdateSpaces()", func() {
@@ -222,7 +346,7 @@ var _ = Describe("given SpaceManager", func() {
 			fakeSpaceClient.ListAllReturns(spaces, nil)
 			fakeSpaceFeatureClient.IsSSHEnabledReturns(false, nil)
 
-			err := spaceManager.UpdateSpaces()
+			err := spaceManager.UpdateSpaces(context.Background())
 			Expect(err).Should(BeNil())
 			Expect(fakeSpaceClient.UpdateCallCount()).Should(Equal(0))
 			Expect(fakeSpaceFeatureClient.EnableSSHCallCount()).Should(Equal(1))
@@ -231,6 +355,44 @@ var _ = Describe("given SpaceManager", func() {
 			Expect(enableSSH).Should(Equal(true))
 		})
 
+		It("should turn on allow ssh for spaces of several orgs concurrently", func() {
+			spaceManager.Parallelism = 3
+			var spaceConfigs []config.SpaceConfig
+			var spaces []*resource.Space
+			for i := 0; i < 6; i++ {
+				orgName := fmt.Sprintf("org%d", i%3)
+				spaceName := fmt.Sprintf("space%d", i)
+				spaceConfigs = append(spaceConfigs, config.SpaceConfig{Org: orgName, Space: spaceName, AllowSSH: true})
+				spaces = append(spaces, &resource.Space{
+					Name: spaceName,
+					GUID: spaceName + "GUID",
+					Relationships: &resource.SpaceRelationships{
+						Organization: &resource.ToOneRelationship{
+							Data: &resource.Relationship{
+								GUID: orgName + "GUID",
+							},
+						},
+					},
+				})
+			}
+			fakeReader.GetSpaceConfigsReturns(spaceConfigs, nil)
+			fakeOrgMgr.GetOrgGUIDStub = func(_ context.Context, orgName string) (string, error) {
+				return orgName + "GUID", nil
+			}
+			fakeSpaceClient.ListAllReturns(spaces, nil)
+			fakeSpaceFeatureClient.IsSSHEnabledReturns(false, nil)
+
+			err := spaceManager.UpdateSpaces(context.Background())
+			Expect(err).Should(BeNil())
+			Expect(fakeSpaceFeatureClient.EnableSSHCallCount()).Should(Equal(6))
+			var spaceGUIDs []string
+			for i := 0; i < 6; i++ {
+				_, spaceGUID, _ := fakeSpaceFeatureClient.EnableSSHArgsForCall(i)
+				spaceGUIDs = append(spaceGUIDs, spaceGUID)
+			}
+			Expect(spaceGUIDs).Should(ConsistOf("space0GUID", "space1GUID", "space2GUID", "space3GUID", "space4GUID", "space5GUID"))
+		})
+
 		It("should do nothing as ssh didn't change", func() {
 			spaces := []*resource.Space{
 				{
@@ -249,7 +411,7 @@ var _ = Describe("given SpaceManager", func() {
 			fakeSpaceClient.ListAllReturns(spaces, nil)
 			fakeSpaceFeatureClient.IsSSHEnabledReturns(true, nil)
 
-			err := spaceManager.UpdateSpaces()
+			err := spaceManager.UpdateSpaces(context.Background())
 			Expect(err).Should(BeNil())
 			Expect(fakeSpaceClient.UpdateCallCount()).Should(Equal(0))
 			Expect(fakeSpaceFeatureClient.EnableSSHCallCount()).Should(Equal(0))
@@ -281,7 +443,7 @@ var _ = Describe("given SpaceManager", func() {
 			fakeSpaceClient.ListAllReturns(spaces, nil)
 			fakeSpaceFeatureClient.IsSSHEnabledReturns(false, nil)
 
-			err := spaceManager.UpdateSpaces()
+			err := spaceManager.UpdateSpaces(context.Background())
 			Expect(err).Should(BeNil())
 			Expect(fakeSpaceFeatureClient.EnableSSHCallCount()).Should(Equal(1))
 			_, spaceGUID, enableSSH := fakeSpaceFeatureClient.EnableSSHArgsForCall(0)
@@ -315,7 +477,7 @@ var _ = Describe("given SpaceManager", func() {
 			fakeSpaceClient.ListAllReturns(spaces, nil)
 			fakeSpaceFeatureClient.IsSSHEnabledReturns(true, nil)
 
-			err := spaceManager.UpdateSpaces()
+			err := spaceManager.UpdateSpaces(context.Background())
 			Expect(err).Should(BeNil())
 			Expect(fakeSpaceClient.UpdateCallCount()).Should(Equal(0))
 			Expect(fakeSpaceFeatureClient.EnableSSHCallCount()).Should(Equal(1))
@@ -342,7 +504,7 @@ var _ = Describe("given SpaceManager", func() {
 			fakeSpaceClient.ListAllReturns(spaces, nil)
 			fakeSpaceFeatureClient.IsSSHEnabledReturns(false, nil)
 
-			err := spaceManager.UpdateSpaces()
+			err := spaceManager.UpdateSpaces(context.Background())
 			Expect(err).Should(BeNil())
 			Expect(fakeSpaceClient.UpdateCallCount()).Should(Equal(0))
 			Expect(fakeSpaceFeatureClient.EnableSSHCallCount()).Should(Equal(0))
@@ -367,7 +529,7 @@ var _ = Describe("given SpaceManager", func() {
 			fakeSpaceFeatureClient.IsSSHEnabledReturns(false, nil)
 			fakeSpaceFeatureClient.EnableSSHReturns(errors.New("error"))
 
-			err := spaceManager.UpdateSpaces()
+			err := spaceManager.UpdateSpaces(context.Background())
 			Expect(err).ShouldNot(BeNil())
 			Expect(fakeSpaceClient.UpdateCallCount()).Should(Equal(0))
 			Expect(fakeSpaceFeatureClient.EnableSSHCallCount()).Should(Equal(1))
@@ -427,12 +589,95 @@ var _ = Describe("given SpaceManager", func() {
 			}, nil)
 			fakeSpaceClient.ListAllReturns(spaces, nil)
 			fakeSpaceClient.DeleteReturns("", nil)
-			Expect(spaceManager.DeleteSpaces()).Should(Succeed())
+			Expect(spaceManager.DeleteSpaces(context.Background())).Should(Succeed())
 			Expect(fakeSpaceClient.DeleteCallCount()).Should(Equal(1))
 			_, spaceGUID := fakeSpaceClient.DeleteArgsForCall(0)
 			Expect(spaceGUID).Should(Equal("space3-guid"))
 		})
 
+		It("should refuse to delete more spaces than the limit", func() {
+			fakeReader.SpacesReturns([]config.Spaces{
+				{
+					Org:                "test2",
+					Spaces:             []string{"space1"},
+					EnableDeleteSpaces: true,
+				},
+			}, nil)
+			fakeReader.GetGlobalConfigReturns(&config.GlobalConfig{
+				DeletionLimits: config.DeletionLimits{
+					Spaces: config.DeletionLimit{Max: 1},
+				},
+			}, nil)
+			spaces := []*resource.Space{}
+			for _, name := range []string{"space1", "space2", "space3"} {
+				spaces = append(spaces, &resource.Space{
+					Name: name,
+					GUID: name + "-guid",
+					Relationships: &resource.SpaceRelationships{
+						Organization: &resource.ToOneRelationship{
+							Data: &resource.Relationship{
+								GUID: "test2-org-guid",
+							},
+						},
+					},
+				})
+			}
+			fakeOrgMgr.FindOrgReturns(&resource.Organization{
+				Name: "test2",
+				GUID: "test2-org-guid",
+			}, nil)
+			fakeSpaceClient.ListAllReturns(spaces, nil)
+			Expect(spaceManager.DeleteSpaces(context.Background())).Should(MatchError("refusing to delete 2 of 3 spaces as it exceeds the limit of 1 set in cf-mgmt.yml deletion-limits, use --allow-large-deletions to override"))
+			Expect(fakeSpaceClient.DeleteCallCount()).Should(Equal(0))
+
+			spaceManager.AllowLargeDeletions = true
+			Expect(spaceManager.DeleteSpaces(context.Background())).Should(Succeed())
+			Expect(fakeSpaceClient.DeleteCallCount()).Should(Equal(2))
+		})
+
+		It("should only delete selected spaces", func() {
+			fakeReader.SpacesReturns([]config.Spaces{
+				{
+					Org:                "test1",
+					Spaces:             []string{"space1"},
+					EnableDeleteSpaces: true,
+				},
+				{
+					Org:                "test2",
+					Spaces:             []string{},
+					EnableDeleteSpaces: true,
+				},
+			}, nil)
+			fakeReader.GetOrgConfigsReturns([]config.OrgConfig{{Org: "test1"}, {Org: "test2"}}, nil)
+			selection, err := config.NewSelection([]string{"test1"}, "", []string{"space2"}, "")
+			Expect(err).ShouldNot(HaveOccurred())
+			spaceManager.Cfg = config.NewSelectedReader(fakeReader, selection)
+			spaces := []*resource.Space{}
+			for _, name := range []string{"space1", "space2", "space3"} {
+				spaces = append(spaces, &resource.Space{
+					Name: name,
+					GUID: name + "-guid",
+					Relationships: &resource.SpaceRelationships{
+						Organization: &resource.ToOneRelationship{
+							Data: &resource.Relationship{
+								GUID: "test1-org-guid",
+							},
+						},
+					},
+				})
+			}
+			fakeOrgMgr.FindOrgReturns(&resource.Organization{
+				Name: "test1",
+				GUID: "test1-org-guid",
+			}, nil)
+			fakeSpaceClient.ListAllReturns(spaces, nil)
+			Expect(spaceManager.DeleteSpaces(context.Background())).Should(Succeed())
+			Expect(fakeOrgMgr.FindOrgCallCount()).Should(Equal(1))
+			Expect(fakeSpaceClient.DeleteCallCount()).Should(Equal(1))
+			_, spaceGUID := fakeSpaceClient.DeleteArgsForCall(0)
+			Expect(spaceGUID).Should(Equal("space2-guid"))
+		})
+
 		It("should error", func() {
 			spaces := []*resource.Space{
 				{
@@ -475,7 +720,7 @@ var _ = Describe("given SpaceManager", func() {
 			}, nil)
 			fakeSpaceClient.ListAllReturns(spaces, nil)
 			fakeSpaceClient.DeleteReturns("", errors.New("error"))
-			Expect(spaceManager.DeleteSpaces()).ShouldNot(Succeed())
+			Expect(spaceManager.DeleteSpaces(context.Background())).ShouldNot(Succeed())
 			Expect(fakeSpaceClient.DeleteCallCount()).Should(Equal(1))
 			_, spaceGUID := fakeSpaceClient.DeleteArgsForCall(0)
 			Expect(spaceGUID).Should(Equal("space3-guid"))
@@ -526,7 +771,7 @@ var _ = Describe("given SpaceManager", func() {
 			}, nil)
 			fakeSpaceClient.ListAllReturns(spaces, nil)
 			fakeSpaceClient.DeleteReturns("", nil)
-			Expect(spaceManager.DeleteSpaces()).Should(Succeed())
+			Expect(spaceManager.DeleteSpaces(context.Background())).Should(Succeed())
 			Expect(fakeSpaceClient.DeleteCallCount()).Should(Equal(0))
 		})
 	})
@@ -9,15 +9,15 @@ import (
 
 // Manager -
 type Manager interface {
-	FindSpace(orgName, spaceName string) (*resource.Space, error)
-	CreateSpaces() error
-	UpdateSpaces() (err error)
-	DeleteSpaces() (err error)
-	DeleteSpacesForOrg(orgGUID, orgName string) (err error)
-	ListSpaces(orgGUID string) ([]*resource.Space, error)
-	IsSSHEnabled(*resource.Space) (bool, error)
-	UpdateSpacesMetadata() error
-	GetSpaceIsolationSegmentGUID(*resource.Space) (string, error)
+	FindSpace(ctx context.Context, orgName, spaceName string) (*resource.Space, error)
+	CreateSpaces(ctx context.Context) error
+	UpdateSpaces(ctx context.Context) (err error)
+	DeleteSpaces(ctx context.Context) (err error)
+	DeleteSpacesForOrg(ctx context.Context, orgGUID, orgName string) (err error)
+	ListSpaces(ctx context.Context, orgGUID string) ([]*resource.Space, error)
+	IsSSHEnabled(ctx context.Context, space *resource.Space) (bool, error)
+	UpdateSpacesMetadata(ctx context.Context) error
+	GetSpaceIsolationSegmentGUID(ctx context.Context, space *resource.Space) (string, error)
 }
 
 type CFSpaceClient interface {
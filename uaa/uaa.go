@@ -1,11 +1,14 @@
 package uaa
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
 	"github.com/xchapter7x/lo"
 
 	uaaclient "github.com/cloudfoundry-community/go-uaa"
@@ -16,18 +19,21 @@ type uaa interface {
 	ListUsers(filter string, sortBy string, attributes string, sortOrder uaaclient.SortOrder, startIndex int, itemsPerPage int) ([]uaaclient.User, uaaclient.Page, error)
 }
 
-// Manager -
+// Manager - ctx is threaded through for the callers, the uaa client takes no
+// context so its calls are bounded by the request timeout of the http client
 type Manager interface {
 	//Returns a map keyed and valued by user id. User id is converted to lowercase
-	ListUsers() (*Users, error)
-	CreateExternalUser(userName, userEmail, externalID, origin string) (err error)
+	ListUsers(ctx context.Context) (*Users, error)
+	CreateExternalUser(ctx context.Context, userName, userEmail, externalID, origin string) (err error)
 }
 
 // DefaultUAAManager -
 type DefaultUAAManager struct {
-	Peek   bool
-	Client uaa
-	Users  *Users
+	Peek     bool
+	Client   uaa
+	Users    *Users
+	Recorder *changes.Recorder
+	mutex    sync.Mutex
 }
 
 type User struct {
@@ -39,15 +45,13 @@ type User struct {
 }
 
 // NewDefaultUAAManager -
-func NewDefaultUAAManager(sysDomain, clientID, clientSecret, userAgent string, httpClient *http.Client, peek bool) (Manager, error) {
-	target := fmt.Sprintf("https://uaa.%s", sysDomain)
-
+func NewDefaultUAAManager(uaaURL, clientID, clientSecret, userAgent string, httpClient *http.Client, skipSSLValidation bool, recorder *changes.Recorder, peek bool) (Manager, error) {
 	client, err := uaaclient.New(
-		target,
+		uaaURL,
 		uaaclient.WithClientCredentials(clientID, clientSecret, uaaclient.OpaqueToken),
 		uaaclient.WithUserAgent(userAgent),
 		uaaclient.WithClient(httpClient),
-		uaaclient.WithSkipSSLValidation(true),
+		uaaclient.WithSkipSSLValidation(skipSSLValidation),
 	)
 
 	if err != nil {
@@ -55,23 +59,35 @@ func NewDefaultUAAManager(sysDomain, clientID, clientSecret, userAgent string, h
 	}
 
 	return &DefaultUAAManager{
-		Client: client,
-		Peek:   peek,
+		Client:   client,
+		Recorder: recorder,
+		Peek:     peek,
 	}, nil
 }
 
 func (m *DefaultUAAManager) addUser(user User) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 	if m.Users == nil {
 		m.Users = &Users{}
 	}
 	m.Users.Add(user)
 }
 
+// ClearUsers - forgets the cached users so the next ListUsers reads them from uaa
+func (m *DefaultUAAManager) ClearUsers() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.Users = nil
+}
+
 // CreateExternalUser -
-func (m *DefaultUAAManager) CreateExternalUser(userName, userEmail, externalID, origin string) error {
+func (m *DefaultUAAManager) CreateExternalUser(ctx context.Context, userName, userEmail, externalID, origin string) error {
 	if userName == "" || userEmail == "" || externalID == "" {
 		return fmt.Errorf("skipping user as missing name[%s], email[%s] or externalID[%s]", userName, userEmail, externalID)
 	}
+	change := changes.Change{Entity: changes.User, Action: changes.Create, Name: userName, After: map[string]string{"email": userEmail, "external_id": externalID, "origin": origin}}
+	m.Recorder.Record(change)
 	if m.Peek {
 		lo.G.Infof("[dry-run]: successfully added user [%s]", userName)
 		m.addUser(User{
@@ -97,10 +113,12 @@ func (m *DefaultUAAManager) CreateExternalUser(userName, userEmail, externalID,
 	if err != nil {
 		var requestError uaaclient.RequestError
 		if errors.As(err, &requestError) {
-			return fmt.Errorf("got an error calling %s with response %s", requestError.Url, requestError.ErrorResponse)
+			err = fmt.Errorf("got an error calling %s with response %s", requestError.Url, requestError.ErrorResponse)
 		}
+		m.Recorder.Applied(change, "", err)
 		return err
 	}
+	m.Recorder.Applied(change, createdUser.ID, nil)
 	m.addUser(User{
 		Username:   userName,
 		Email:      userEmail,
@@ -113,14 +131,16 @@ func (m *DefaultUAAManager) CreateExternalUser(userName, userEmail, externalID,
 }
 
 // ListUsers - returns uaa.Users
-func (m *DefaultUAAManager) ListUsers() (*Users, error) {
+func (m *DefaultUAAManager) ListUsers(ctx context.Context) (*Users, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 	if m.Users != nil {
 		return m.Users, nil
 	}
 
 	users := &Users{}
 	lo.G.Debug("Getting users from UAA")
-	userList, err := m.ListAllUsers()
+	userList, err := m.ListAllUsers(ctx)
 	if err != nil {
 		var requestError uaaclient.RequestError
 		if errors.As(err, &requestError) {
@@ -148,7 +168,7 @@ func (m *DefaultUAAManager) ListUsers() (*Users, error) {
 	return users, nil
 }
 
-func (m *DefaultUAAManager) ListAllUsers() ([]uaaclient.User, error) {
+func (m *DefaultUAAManager) ListAllUsers(ctx context.Context) ([]uaaclient.User, error) {
 	page := uaaclient.Page{
 		StartIndex:   1,
 		ItemsPerPage: 500,
@@ -1,6 +1,7 @@
 package uaa_test
 
 import (
+	"context"
 	"errors"
 
 	uaaclient "github.com/cloudfoundry-community/go-uaa"
@@ -42,7 +43,7 @@ var _ = Describe("given uaa manager", func() {
 				{Username: "foo3", ID: "foo3-id"},
 				{Username: "cn=admin", ID: "cn=admin-id"},
 			}, uaaclient.Page{ItemsPerPage: 500, StartIndex: 1, TotalResults: 9}, nil)
-			users, err := manager.ListUsers()
+			users, err := manager.ListUsers(context.Background())
 			Expect(fakeuaa.ListUsersCallCount()).Should(Equal(1))
 			Expect(err).ShouldNot(HaveOccurred())
 			keys := make([]string, 0, len(users.List()))
@@ -54,7 +55,7 @@ var _ = Describe("given uaa manager", func() {
 		})
 		It("should return an error", func() {
 			fakeuaa.ListUsersReturns(nil, uaaclient.Page{ItemsPerPage: 500, StartIndex: 1, TotalResults: 10}, errors.New("Got an error"))
-			_, err := manager.ListUsers()
+			_, err := manager.ListUsers(context.Background())
 			Expect(err).Should(HaveOccurred())
 			Expect(fakeuaa.ListUsersCallCount()).Should(Equal(1))
 		})
@@ -74,7 +75,7 @@ var _ = Describe("given uaa manager", func() {
 					}},
 				nil,
 			)
-			err := manager.CreateExternalUser(userName, userEmail, externalID, "ldap")
+			err := manager.CreateExternalUser(context.Background(), userName, userEmail, externalID, "ldap")
 			Expect(err).ShouldNot(HaveOccurred())
 		})
 		It("should successfully create user with complex dn", func() {
@@ -91,7 +92,7 @@ var _ = Describe("given uaa manager", func() {
 					}},
 				nil,
 			)
-			err := manager.CreateExternalUser(userName, userEmail, externalID, "ldap")
+			err := manager.CreateExternalUser(context.Background(), userName, userEmail, externalID, "ldap")
 			Expect(err).ShouldNot(HaveOccurred())
 		})
 
@@ -100,12 +101,12 @@ var _ = Describe("given uaa manager", func() {
 			userEmail := "email"
 			externalID := "userDN"
 			manager.Peek = true
-			err := manager.CreateExternalUser(userName, userEmail, externalID, "ldap")
+			err := manager.CreateExternalUser(context.Background(), userName, userEmail, externalID, "ldap")
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fakeuaa.CreateUserCallCount()).Should(Equal(0))
 		})
 		It("should not invoke post", func() {
-			err := manager.CreateExternalUser("", "", "", "ldap")
+			err := manager.CreateExternalUser(context.Background(), "", "", "", "ldap")
 			Expect(err).Should(HaveOccurred())
 			Expect(fakeuaa.CreateUserCallCount()).Should(Equal(0))
 		})
@@ -127,7 +128,7 @@ var _ = Describe("given uaa manager", func() {
 					}},
 				nil,
 			)
-			err := manager.CreateExternalUser(userName, userEmail, externalID, origin)
+			err := manager.CreateExternalUser(context.Background(), userName, userEmail, externalID, origin)
 			Expect(err).ShouldNot(HaveOccurred())
 		})
 	})
@@ -2,15 +2,20 @@ package uaa
 
 import (
 	"strings"
+	"sync"
 
 	"github.com/xchapter7x/lo"
 )
 
+// Users - cache of uaa users that is safe for concurrent use
 type Users struct {
+	mutex   sync.RWMutex
 	userMap map[string][]User
 }
 
 func (u *Users) Add(user User) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
 	if u.userMap == nil {
 		u.userMap = make(map[string][]User)
 	}
@@ -21,6 +26,8 @@ func (u *Users) Add(user User) {
 }
 
 func (u *Users) List() []User {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
 	if u.userMap == nil {
 		return nil
 	}
@@ -32,6 +39,8 @@ func (u *Users) List() []User {
 }
 
 func (u *Users) Exists(userName string) bool {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
 	if u.userMap == nil {
 		return false
 	}
@@ -40,6 +49,8 @@ func (u *Users) Exists(userName string) bool {
 }
 
 func (u *Users) GetByNameAndOrigin(userName, origin string) *User {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
 	if u.userMap == nil {
 		return nil
 	}
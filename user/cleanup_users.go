@@ -1,9 +1,11 @@
 package user
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pkg/errors"
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
 	"github.com/vmwarepivotallabs/cf-mgmt/config"
 	"github.com/vmwarepivotallabs/cf-mgmt/role"
 	"github.com/vmwarepivotallabs/cf-mgmt/uaa"
@@ -11,10 +13,10 @@ import (
 	"github.com/xchapter7x/lo"
 )
 
-func (m *DefaultManager) removeOrphanedUsers(orphanedUsers []string) error {
+func (m *DefaultManager) removeOrphanedUsers(ctx context.Context, orphanedUsers []string) error {
 	for _, orphanedUser := range orphanedUsers {
 		lo.G.Infof("Deleting orphaned CF user with guid %s", orphanedUser)
-		err := m.RoleMgr.DeleteUser(orphanedUser)
+		err := m.RoleMgr.DeleteUser(ctx, orphanedUser)
 		if err != nil {
 			return err
 		}
@@ -23,82 +25,131 @@ func (m *DefaultManager) removeOrphanedUsers(orphanedUsers []string) error {
 	return nil
 }
 
-// CleanupOrgUsers -
-func (m *DefaultManager) CleanupOrgUsers() []error {
+// CleanupOrgUsers - removes the users of orgs that have no org or space role
+// and deletes the orphaned users, once the removals of every org are known to
+// be within the role-removals deletion limit
+func (m *DefaultManager) CleanupOrgUsers(ctx context.Context) []error {
 	errs := []error{}
 	m.RoleMgr.ClearRoles()
 	orgConfigs, err := m.Cfg.GetOrgConfigs()
 	if err != nil {
 		return []error{err}
 	}
-	uaaUsers, err := m.UAAMgr.ListUsers()
+	uaaUsers, err := m.UAAMgr.ListUsers(ctx)
 	if err != nil {
 		return []error{err}
 	}
 
+	var cleanups []*orgCleanup
+	count, total := 0, 0
 	for _, input := range orgConfigs {
-		if input.RemoveUsers {
-			if err := m.cleanupOrgUsers(uaaUsers, &input); err != nil {
-				errs = append(errs, err)
-			}
-		} else {
+		if err := ctx.Err(); err != nil {
+			return append(errs, err)
+		}
+		if m.Failures.Skip(input.Org, "") {
+			lo.G.Infof("skipping cleanup of org [%s] users as it failed in an earlier step", input.Org)
+			continue
+		}
+		if !input.RemoveUsers {
 			lo.G.Infof("Not Removing Users from org %s", input.Org)
+			continue
+		}
+		cleanup, err := m.planOrgCleanup(ctx, uaaUsers, &input)
+		if err = m.Failures.Add(input.Org, "", err); err != nil {
+			errs = append(errs, err)
+		}
+		if cleanup == nil {
+			continue
+		}
+		cleanups = append(cleanups, cleanup)
+		count += len(cleanup.users) + len(cleanup.orphanedUsers)
+		total += cleanup.orgUserCount + len(cleanup.orphanedUsers)
+	}
+	if err := m.checkRoleRemovals(count, total); err != nil {
+		lo.G.Error(err)
+		return append(errs, err)
+	}
+	for _, cleanup := range cleanups {
+		if err := ctx.Err(); err != nil {
+			return append(errs, err)
+		}
+		if err := m.Failures.Add(cleanup.orgName, "", m.cleanupOrgUsers(ctx, cleanup)); err != nil {
+			errs = append(errs, err)
 		}
 	}
 	return errs
 }
 
-func (m *DefaultManager) cleanupOrgUsers(uaaUsers *uaa.Users, input *config.OrgConfig) error {
-	org, err := m.OrgReader.FindOrg(input.Org)
+// orgCleanup - the users to remove from an org and the orphaned users to delete
+type orgCleanup struct {
+	orgName       string
+	orgGUID       string
+	orgUserCount  int
+	users         []role.RoleUser
+	orphanedUsers []string
+}
+
+func (m *DefaultManager) planOrgCleanup(ctx context.Context, uaaUsers *uaa.Users, input *config.OrgConfig) (*orgCleanup, error) {
+	org, err := m.OrgReader.FindOrg(ctx, input.Org)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	orgUsers, _, _, _, err := m.RoleMgr.ListOrgUsersByRole(org.GUID)
+	orgUsers, _, _, _, err := m.RoleMgr.ListOrgUsersByRole(ctx, org.GUID)
 	if err != nil {
-		return errors.Wrap(err, fmt.Sprintf("Error listing org users for org %s", input.Org))
+		return nil, errors.Wrap(err, fmt.Sprintf("Error listing org users for org %s", input.Org))
 	}
 
-	usersInRoles, err := m.usersInOrgRoles(org.Name, org.GUID)
+	usersInRoles, err := m.usersInOrgRoles(ctx, org.Name, org.GUID)
 	if err != nil {
-		return errors.Wrap(err, fmt.Sprintf("Error usersInOrgRoles for org %s", input.Org))
+		return nil, errors.Wrap(err, fmt.Sprintf("Error usersInOrgRoles for org %s", input.Org))
 	}
 
 	lo.G.Debugf("Users In Roles %+v", usersInRoles)
 
 	cfg, err := m.Cfg.GetGlobalConfig()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	cleanup := &orgCleanup{
+		orgName:       org.Name,
+		orgGUID:       org.GUID,
+		orgUserCount:  len(orgUsers.Users()),
+		orphanedUsers: usersInRoles.OrphanedUsers(),
 	}
 	for _, orgUser := range orgUsers.Users() {
 		uaaUser := uaaUsers.GetByID(orgUser.GUID)
-		var guid string
 		if uaaUser == nil {
 			lo.G.Infof("Unable to find user (%s) GUID from uaa, using org user guid instead", orgUser.UserName)
-			guid = orgUser.GUID
 		} else {
-			guid = uaaUser.GUID
+			orgUser.GUID = uaaUser.GUID
+		}
+		if !util.Matches(orgUser.UserName, cfg.ProtectedUsers) && !usersInRoles.HasUserForGUID(orgUser.UserName, orgUser.GUID) {
+			cleanup.users = append(cleanup.users, orgUser)
 		}
-		if !util.Matches(orgUser.UserName, cfg.ProtectedUsers) {
-			if !usersInRoles.HasUserForGUID(orgUser.UserName, guid) {
-				if m.Peek {
-					lo.G.Infof("[dry-run]: Removing User %s from org %s", orgUser.UserName, input.Org)
-					continue
-				}
-				lo.G.Infof("Removing User %s from org %s", orgUser.UserName, input.Org)
-				err = m.RoleMgr.RemoveOrgUser(org.Name, org.GUID, orgUser.UserName, guid)
-				if err != nil {
-					return err
-				}
-			}
+	}
+	return cleanup, nil
+}
+
+func (m *DefaultManager) cleanupOrgUsers(ctx context.Context, cleanup *orgCleanup) error {
+	for _, orgUser := range cleanup.users {
+		if m.Peek {
+			// the role manager records this change when it isn't a dry run
+			m.Recorder.Record(changes.Change{Entity: changes.OrgRole, Action: changes.Unassign, Name: orgUser.UserName, Org: cleanup.orgName, Before: "user"})
+			lo.G.Infof("[dry-run]: Removing User %s from org %s", orgUser.UserName, cleanup.orgName)
+			continue
+		}
+		lo.G.Infof("Removing User %s from org %s", orgUser.UserName, cleanup.orgName)
+		if err := m.RoleMgr.RemoveOrgUser(ctx, cleanup.orgName, cleanup.orgGUID, orgUser.UserName, orgUser.GUID); err != nil {
+			return err
 		}
 	}
 
-	return m.removeOrphanedUsers(usersInRoles.OrphanedUsers())
+	return m.removeOrphanedUsers(ctx, cleanup.orphanedUsers)
 }
 
-func (m *DefaultManager) unassociatedOrphanedSpaceUser(input UsersInput, userGUIDs []string, unassign func(entityName string, entityGUID string, userName string, userGUID string) error) error {
+func (m *DefaultManager) unassociatedOrphanedSpaceUser(ctx context.Context, input UsersInput, userGUIDs []string, unassign func(ctx context.Context, entityName string, entityGUID string, userName string, userGUID string) error) error {
 	for _, userGUID := range userGUIDs {
-		err := unassign(input.SpaceName, input.SpaceGUID, "orphaned", userGUID)
+		err := unassign(ctx, input.SpaceName, input.SpaceGUID, "orphaned", userGUID)
 		if err != nil {
 			return err
 		}
@@ -106,9 +157,9 @@ func (m *DefaultManager) unassociatedOrphanedSpaceUser(input UsersInput, userGUI
 	return nil
 }
 
-func (m *DefaultManager) unassociatedOrphanedOrgUser(input UsersInput, userGUIDs []string, unassign func(entityName string, entityGUID string, userName string, userGUID string) error) error {
+func (m *DefaultManager) unassociatedOrphanedOrgUser(ctx context.Context, input UsersInput, userGUIDs []string, unassign func(ctx context.Context, entityName string, entityGUID string, userName string, userGUID string) error) error {
 	for _, userGUID := range userGUIDs {
-		err := unassign(input.OrgName, input.OrgGUID, "orphaned", userGUID)
+		err := unassign(ctx, input.OrgName, input.OrgGUID, "orphaned", userGUID)
 		if err != nil {
 			return err
 		}
@@ -116,20 +167,20 @@ func (m *DefaultManager) unassociatedOrphanedOrgUser(input UsersInput, userGUIDs
 	return nil
 }
 
-func (m *DefaultManager) usersInOrgRoles(orgName, orgGUID string) (*role.RoleUsers, error) {
+func (m *DefaultManager) usersInOrgRoles(ctx context.Context, orgName, orgGUID string) (*role.RoleUsers, error) {
 	roleUsers := role.InitRoleUsers()
 
 	userInput := UsersInput{
 		OrgGUID: orgGUID,
 		OrgName: orgName,
 	}
-	_, orgManagers, orgBillingManagers, orgAuditors, err := m.RoleMgr.ListOrgUsersByRole(orgGUID)
+	_, orgManagers, orgBillingManagers, orgAuditors, err := m.RoleMgr.ListOrgUsersByRole(ctx, orgGUID)
 	if err != nil {
 		return nil, err
 	}
 	roleUsers.AddUsers(orgAuditors.Users())
 	roleUsers.AddOrphanedUsers(orgAuditors.OrphanedUsers())
-	err = m.unassociatedOrphanedOrgUser(userInput, orgAuditors.OrphanedUsers(), m.RoleMgr.RemoveOrgAuditor)
+	err = m.unassociatedOrphanedOrgUser(ctx, userInput, orgAuditors.OrphanedUsers(), m.RoleMgr.RemoveOrgAuditor)
 	if err != nil {
 		return nil, err
 	}
@@ -137,7 +188,7 @@ func (m *DefaultManager) usersInOrgRoles(orgName, orgGUID string) (*role.RoleUse
 	roleUsers.AddUsers(orgManagers.Users())
 	roleUsers.AddOrphanedUsers(orgManagers.OrphanedUsers())
 
-	err = m.unassociatedOrphanedOrgUser(userInput, orgManagers.OrphanedUsers(), m.RoleMgr.RemoveOrgManager)
+	err = m.unassociatedOrphanedOrgUser(ctx, userInput, orgManagers.OrphanedUsers(), m.RoleMgr.RemoveOrgManager)
 	if err != nil {
 		return nil, err
 	}
@@ -145,31 +196,31 @@ func (m *DefaultManager) usersInOrgRoles(orgName, orgGUID string) (*role.RoleUse
 	roleUsers.AddUsers(orgBillingManagers.Users())
 	roleUsers.AddOrphanedUsers(orgBillingManagers.OrphanedUsers())
 
-	err = m.unassociatedOrphanedOrgUser(userInput, orgBillingManagers.OrphanedUsers(), m.RoleMgr.RemoveOrgBillingManager)
+	err = m.unassociatedOrphanedOrgUser(ctx, userInput, orgBillingManagers.OrphanedUsers(), m.RoleMgr.RemoveOrgBillingManager)
 	if err != nil {
 		return nil, err
 	}
-	spaces, err := m.SpaceMgr.ListSpaces(orgGUID)
+	spaces, err := m.SpaceMgr.ListSpaces(ctx, orgGUID)
 	if err != nil {
 		return nil, errors.Wrap(err, fmt.Sprintf("Error listing spaces for org %s", orgName))
 	}
 	for _, space := range spaces {
 		userInput.SpaceGUID = space.GUID
 		userInput.SpaceName = space.Name
-		spaceManagers, spaceDevelopers, spaceAuditors, spaceSupporters, err := m.RoleMgr.ListSpaceUsersByRole(space.GUID)
+		spaceManagers, spaceDevelopers, spaceAuditors, spaceSupporters, err := m.RoleMgr.ListSpaceUsersByRole(ctx, space.GUID)
 		if err != nil {
 			return nil, err
 		}
 		roleUsers.AddUsers(spaceAuditors.Users())
 		roleUsers.AddOrphanedUsers(spaceAuditors.OrphanedUsers())
-		err = m.unassociatedOrphanedSpaceUser(userInput, spaceAuditors.OrphanedUsers(), m.RoleMgr.RemoveSpaceAuditor)
+		err = m.unassociatedOrphanedSpaceUser(ctx, userInput, spaceAuditors.OrphanedUsers(), m.RoleMgr.RemoveSpaceAuditor)
 		if err != nil {
 			return nil, err
 		}
 
 		roleUsers.AddUsers(spaceDevelopers.Users())
 		roleUsers.AddOrphanedUsers(spaceDevelopers.OrphanedUsers())
-		err = m.unassociatedOrphanedSpaceUser(userInput, spaceDevelopers.OrphanedUsers(), m.RoleMgr.RemoveSpaceDeveloper)
+		err = m.unassociatedOrphanedSpaceUser(ctx, userInput, spaceDevelopers.OrphanedUsers(), m.RoleMgr.RemoveSpaceDeveloper)
 		if err != nil {
 			return nil, err
 		}
@@ -177,7 +228,7 @@ func (m *DefaultManager) usersInOrgRoles(orgName, orgGUID string) (*role.RoleUse
 		roleUsers.AddUsers(spaceManagers.Users())
 		roleUsers.AddOrphanedUsers(spaceManagers.OrphanedUsers())
 
-		err = m.unassociatedOrphanedSpaceUser(userInput, spaceManagers.OrphanedUsers(), m.RoleMgr.RemoveSpaceManager)
+		err = m.unassociatedOrphanedSpaceUser(ctx, userInput, spaceManagers.OrphanedUsers(), m.RoleMgr.RemoveSpaceManager)
 		if err != nil {
 			return nil, err
 		}
@@ -185,7 +236,7 @@ func (m *DefaultManager) usersInOrgRoles(orgName, orgGUID string) (*role.RoleUse
 		roleUsers.AddUsers(spaceSupporters.Users())
 		roleUsers.AddOrphanedUsers(spaceSupporters.OrphanedUsers())
 
-		err = m.unassociatedOrphanedSpaceUser(userInput, spaceSupporters.OrphanedUsers(), m.RoleMgr.RemoveSpaceSupporter)
+		err = m.unassociatedOrphanedSpaceUser(ctx, userInput, spaceSupporters.OrphanedUsers(), m.RoleMgr.RemoveSpaceSupporter)
 		if err != nil {
 			return nil, err
 		}
@@ -2,6 +2,7 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/vmwarepivotallabs/cf-mgmt/ldap"
@@ -13,10 +14,11 @@ type FakeLdapManager struct {
 	closeMutex       sync.RWMutex
 	closeArgsForCall []struct {
 	}
-	GetUserByDNStub        func(string) (*ldap.User, error)
+	GetUserByDNStub        func(context.Context, string) (*ldap.User, error)
 	getUserByDNMutex       sync.RWMutex
 	getUserByDNArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	getUserByDNReturns struct {
 		result1 *ldap.User
@@ -26,10 +28,11 @@ type FakeLdapManager struct {
 		result1 *ldap.User
 		result2 error
 	}
-	GetUserByIDStub        func(string) (*ldap.User, error)
+	GetUserByIDStub        func(context.Context, string) (*ldap.User, error)
 	getUserByIDMutex       sync.RWMutex
 	getUserByIDArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	getUserByIDReturns struct {
 		result1 *ldap.User
@@ -39,10 +42,11 @@ type FakeLdapManager struct {
 		result1 *ldap.User
 		result2 error
 	}
-	GetUserDNsStub        func(string) ([]string, error)
+	GetUserDNsStub        func(context.Context, string) ([]string, error)
 	getUserDNsMutex       sync.RWMutex
 	getUserDNsArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	getUserDNsReturns struct {
 		result1 []string
@@ -80,18 +84,19 @@ func (fake *FakeLdapManager) CloseCalls(stub func()) {
 	fake.CloseStub = stub
 }
 
-func (fake *FakeLdapManager) GetUserByDN(arg1 string) (*ldap.User, error) {
+func (fake *FakeLdapManager) GetUserByDN(arg1 context.Context, arg2 string) (*ldap.User, error) {
 	fake.getUserByDNMutex.Lock()
 	ret, specificReturn := fake.getUserByDNReturnsOnCall[len(fake.getUserByDNArgsForCall)]
 	fake.getUserByDNArgsForCall = append(fake.getUserByDNArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.GetUserByDNStub
 	fakeReturns := fake.getUserByDNReturns
-	fake.recordInvocation("GetUserByDN", []interface{}{arg1})
+	fake.recordInvocation("GetUserByDN", []interface{}{arg1, arg2})
 	fake.getUserByDNMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -105,17 +110,17 @@ func (fake *FakeLdapManager) GetUserByDNCallCount() int {
 	return len(fake.getUserByDNArgsForCall)
 }
 
-func (fake *FakeLdapManager) GetUserByDNCalls(stub func(string) (*ldap.User, error)) {
+func (fake *FakeLdapManager) GetUserByDNCalls(stub func(context.Context, string) (*ldap.User, error)) {
 	fake.getUserByDNMutex.Lock()
 	defer fake.getUserByDNMutex.Unlock()
 	fake.GetUserByDNStub = stub
 }
 
-func (fake *FakeLdapManager) GetUserByDNArgsForCall(i int) string {
+func (fake *FakeLdapManager) GetUserByDNArgsForCall(i int) (context.Context, string) {
 	fake.getUserByDNMutex.RLock()
 	defer fake.getUserByDNMutex.RUnlock()
 	argsForCall := fake.getUserByDNArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeLdapManager) GetUserByDNReturns(result1 *ldap.User, result2 error) {
@@ -144,18 +149,19 @@ func (fake *FakeLdapManager) GetUserByDNReturnsOnCall(i int, result1 *ldap.User,
 	}{result1, result2}
 }
 
-func (fake *FakeLdapManager) GetUserByID(arg1 string) (*ldap.User, error) {
+func (fake *FakeLdapManager) GetUserByID(arg1 context.Context, arg2 string) (*ldap.User, error) {
 	fake.getUserByIDMutex.Lock()
 	ret, specificReturn := fake.getUserByIDReturnsOnCall[len(fake.getUserByIDArgsForCall)]
 	fake.getUserByIDArgsForCall = append(fake.getUserByIDArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.GetUserByIDStub
 	fakeReturns := fake.getUserByIDReturns
-	fake.recordInvocation("GetUserByID", []interface{}{arg1})
+	fake.recordInvocation("GetUserByID", []interface{}{arg1, arg2})
 	fake.getUserByIDMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -169,17 +175,17 @@ func (fake *FakeLdapManager) GetUserByIDCallCount() int {
 	return len(fake.getUserByIDArgsForCall)
 }
 
-func (fake *FakeLdapManager) GetUserByIDCalls(stub func(string) (*ldap.User, error)) {
+func (fake *FakeLdapManager) GetUserByIDCalls(stub func(context.Context, string) (*ldap.User, error)) {
 	fake.getUserByIDMutex.Lock()
 	defer fake.getUserByIDMutex.Unlock()
 	fake.GetUserByIDStub = stub
 }
 
-func (fake *FakeLdapManager) GetUserByIDArgsForCall(i int) string {
+func (fake *FakeLdapManager) GetUserByIDArgsForCall(i int) (context.Context, string) {
 	fake.getUserByIDMutex.RLock()
 	defer fake.getUserByIDMutex.RUnlock()
 	argsForCall := fake.getUserByIDArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeLdapManager) GetUserByIDReturns(result1 *ldap.User, result2 error) {
@@ -208,18 +214,19 @@ func (fake *FakeLdapManager) GetUserByIDReturnsOnCall(i int, result1 *ldap.User,
 	}{result1, result2}
 }
 
-func (fake *FakeLdapManager) GetUserDNs(arg1 string) ([]string, error) {
+func (fake *FakeLdapManager) GetUserDNs(arg1 context.Context, arg2 string) ([]string, error) {
 	fake.getUserDNsMutex.Lock()
 	ret, specificReturn := fake.getUserDNsReturnsOnCall[len(fake.getUserDNsArgsForCall)]
 	fake.getUserDNsArgsForCall = append(fake.getUserDNsArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.GetUserDNsStub
 	fakeReturns := fake.getUserDNsReturns
-	fake.recordInvocation("GetUserDNs", []interface{}{arg1})
+	fake.recordInvocation("GetUserDNs", []interface{}{arg1, arg2})
 	fake.getUserDNsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -233,17 +240,17 @@ func (fake *FakeLdapManager) GetUserDNsCallCount() int {
 	return len(fake.getUserDNsArgsForCall)
 }
 
-func (fake *FakeLdapManager) GetUserDNsCalls(stub func(string) ([]string, error)) {
+func (fake *FakeLdapManager) GetUserDNsCalls(stub func(context.Context, string) ([]string, error)) {
 	fake.getUserDNsMutex.Lock()
 	defer fake.getUserDNsMutex.Unlock()
 	fake.GetUserDNsStub = stub
 }
 
-func (fake *FakeLdapManager) GetUserDNsArgsForCall(i int) string {
+func (fake *FakeLdapManager) GetUserDNsArgsForCall(i int) (context.Context, string) {
 	fake.getUserDNsMutex.RLock()
 	defer fake.getUserDNsMutex.RUnlock()
 	argsForCall := fake.getUserDNsArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeLdapManager) GetUserDNsReturns(result1 []string, result2 error) {
@@ -2,15 +2,17 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/vmwarepivotallabs/cf-mgmt/user"
 )
 
 type FakeManager struct {
-	CleanupOrgUsersStub        func() []error
+	CleanupOrgUsersStub        func(context.Context) []error
 	cleanupOrgUsersMutex       sync.RWMutex
 	cleanupOrgUsersArgsForCall []struct {
+		arg1 context.Context
 	}
 	cleanupOrgUsersReturns struct {
 		result1 []error
@@ -18,9 +20,14 @@ type FakeManager struct {
 	cleanupOrgUsersReturnsOnCall map[int]struct {
 		result1 []error
 	}
-	UpdateOrgUsersStub        func() []error
+	ResetRoleRemovalsStub        func()
+	resetRoleRemovalsMutex       sync.RWMutex
+	resetRoleRemovalsArgsForCall []struct {
+	}
+	UpdateOrgUsersStub        func(context.Context) []error
 	updateOrgUsersMutex       sync.RWMutex
 	updateOrgUsersArgsForCall []struct {
+		arg1 context.Context
 	}
 	updateOrgUsersReturns struct {
 		result1 []error
@@ -28,9 +35,10 @@ type FakeManager struct {
 	updateOrgUsersReturnsOnCall map[int]struct {
 		result1 []error
 	}
-	UpdateSpaceUsersStub        func() []error
+	UpdateSpaceUsersStub        func(context.Context) []error
 	updateSpaceUsersMutex       sync.RWMutex
 	updateSpaceUsersArgsForCall []struct {
+		arg1 context.Context
 	}
 	updateSpaceUsersReturns struct {
 		result1 []error
@@ -42,17 +50,18 @@ type FakeManager struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeManager) CleanupOrgUsers() []error {
+func (fake *FakeManager) CleanupOrgUsers(arg1 context.Context) []error {
 	fake.cleanupOrgUsersMutex.Lock()
 	ret, specificReturn := fake.cleanupOrgUsersReturnsOnCall[len(fake.cleanupOrgUsersArgsForCall)]
 	fake.cleanupOrgUsersArgsForCall = append(fake.cleanupOrgUsersArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.CleanupOrgUsersStub
 	fakeReturns := fake.cleanupOrgUsersReturns
-	fake.recordInvocation("CleanupOrgUsers", []interface{}{})
+	fake.recordInvocation("CleanupOrgUsers", []interface{}{arg1})
 	fake.cleanupOrgUsersMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -66,12 +75,19 @@ func (fake *FakeManager) CleanupOrgUsersCallCount() int {
 	return len(fake.cleanupOrgUsersArgsForCall)
 }
 
-func (fake *FakeManager) CleanupOrgUsersCalls(stub func() []error) {
+func (fake *FakeManager) CleanupOrgUsersCalls(stub func(context.Context) []error) {
 	fake.cleanupOrgUsersMutex.Lock()
 	defer fake.cleanupOrgUsersMutex.Unlock()
 	fake.CleanupOrgUsersStub = stub
 }
 
+func (fake *FakeManager) CleanupOrgUsersArgsForCall(i int) context.Context {
+	fake.cleanupOrgUsersMutex.RLock()
+	defer fake.cleanupOrgUsersMutex.RUnlock()
+	argsForCall := fake.cleanupOrgUsersArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeManager) CleanupOrgUsersReturns(result1 []error) {
 	fake.cleanupOrgUsersMutex.Lock()
 	defer fake.cleanupOrgUsersMutex.Unlock()
@@ -95,17 +111,42 @@ func (fake *FakeManager) CleanupOrgUsersReturnsOnCall(i int, result1 []error) {
 	}{result1}
 }
 
-func (fake *FakeManager) UpdateOrgUsers() []error {
+func (fake *FakeManager) ResetRoleRemovals() {
+	fake.resetRoleRemovalsMutex.Lock()
+	fake.resetRoleRemovalsArgsForCall = append(fake.resetRoleRemovalsArgsForCall, struct {
+	}{})
+	stub := fake.ResetRoleRemovalsStub
+	fake.recordInvocation("ResetRoleRemovals", []interface{}{})
+	fake.resetRoleRemovalsMutex.Unlock()
+	if stub != nil {
+		fake.ResetRoleRemovalsStub()
+	}
+}
+
+func (fake *FakeManager) ResetRoleRemovalsCallCount() int {
+	fake.resetRoleRemovalsMutex.RLock()
+	defer fake.resetRoleRemovalsMutex.RUnlock()
+	return len(fake.resetRoleRemovalsArgsForCall)
+}
+
+func (fake *FakeManager) ResetRoleRemovalsCalls(stub func()) {
+	fake.resetRoleRemovalsMutex.Lock()
+	defer fake.resetRoleRemovalsMutex.Unlock()
+	fake.ResetRoleRemovalsStub = stub
+}
+
+func (fake *FakeManager) UpdateOrgUsers(arg1 context.Context) []error {
 	fake.updateOrgUsersMutex.Lock()
 	ret, specificReturn := fake.updateOrgUsersReturnsOnCall[len(fake.updateOrgUsersArgsForCall)]
 	fake.updateOrgUsersArgsForCall = append(fake.updateOrgUsersArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.UpdateOrgUsersStub
 	fakeReturns := fake.updateOrgUsersReturns
-	fake.recordInvocation("UpdateOrgUsers", []interface{}{})
+	fake.recordInvocation("UpdateOrgUsers", []interface{}{arg1})
 	fake.updateOrgUsersMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -119,12 +160,19 @@ func (fake *FakeManager) UpdateOrgUsersCallCount() int {
 	return len(fake.updateOrgUsersArgsForCall)
 }
 
-func (fake *FakeManager) UpdateOrgUsersCalls(stub func() []error) {
+func (fake *FakeManager) UpdateOrgUsersCalls(stub func(context.Context) []error) {
 	fake.updateOrgUsersMutex.Lock()
 	defer fake.updateOrgUsersMutex.Unlock()
 	fake.UpdateOrgUsersStub = stub
 }
 
+func (fake *FakeManager) UpdateOrgUsersArgsForCall(i int) context.Context {
+	fake.updateOrgUsersMutex.RLock()
+	defer fake.updateOrgUsersMutex.RUnlock()
+	argsForCall := fake.updateOrgUsersArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeManager) UpdateOrgUsersReturns(result1 []error) {
 	fake.updateOrgUsersMutex.Lock()
 	defer fake.updateOrgUsersMutex.Unlock()
@@ -148,17 +196,18 @@ func (fake *FakeManager) UpdateOrgUsersReturnsOnCall(i int, result1 []error) {
 	}{result1}
 }
 
-func (fake *FakeManager) UpdateSpaceUsers() []error {
+func (fake *FakeManager) UpdateSpaceUsers(arg1 context.Context) []error {
 	fake.updateSpaceUsersMutex.Lock()
 	ret, specificReturn := fake.updateSpaceUsersReturnsOnCall[len(fake.updateSpaceUsersArgsForCall)]
 	fake.updateSpaceUsersArgsForCall = append(fake.updateSpaceUsersArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.UpdateSpaceUsersStub
 	fakeReturns := fake.updateSpaceUsersReturns
-	fake.recordInvocation("UpdateSpaceUsers", []interface{}{})
+	fake.recordInvocation("UpdateSpaceUsers", []interface{}{arg1})
 	fake.updateSpaceUsersMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -172,12 +221,19 @@ func (fake *FakeManager) UpdateSpaceUsersCallCount() int {
 	return len(fake.updateSpaceUsersArgsForCall)
 }
 
-func (fake *FakeManager) UpdateSpaceUsersCalls(stub func() []error) {
+func (fake *FakeManager) UpdateSpaceUsersCalls(stub func(context.Context) []error) {
 	fake.updateSpaceUsersMutex.Lock()
 	defer fake.updateSpaceUsersMutex.Unlock()
 	fake.UpdateSpaceUsersStub = stub
 }
 
+func (fake *FakeManager) UpdateSpaceUsersArgsForCall(i int) context.Context {
+	fake.updateSpaceUsersMutex.RLock()
+	defer fake.updateSpaceUsersMutex.RUnlock()
+	argsForCall := fake.updateSpaceUsersArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeManager) UpdateSpaceUsersReturns(result1 []error) {
 	fake.updateSpaceUsersMutex.Lock()
 	defer fake.updateSpaceUsersMutex.Unlock()
@@ -206,6 +262,8 @@ func (fake *FakeManager) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.cleanupOrgUsersMutex.RLock()
 	defer fake.cleanupOrgUsersMutex.RUnlock()
+	fake.resetRoleRemovalsMutex.RLock()
+	defer fake.resetRoleRemovalsMutex.RUnlock()
 	fake.updateOrgUsersMutex.RLock()
 	defer fake.updateOrgUsersMutex.RUnlock()
 	fake.updateSpaceUsersMutex.RLock()
@@ -1,6 +1,7 @@
 package user
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -11,14 +12,33 @@ import (
 	"github.com/xchapter7x/lo"
 )
 
-func (m *DefaultManager) SyncLdapUsers(roleUsers *role.RoleUsers, usersInput UsersInput) error {
+// UnresolvedGroupsError - returned by SyncLdapUsers when removing users is
+// enabled but some of the role's ldap groups, or their nested groups, are
+// missing, ambiguous or empty.
+// Users of the groups that resolved are still added, but none are removed as
+// the role's membership isn't known.
+type UnresolvedGroupsError struct {
+	Entity string
+	Role   string
+	Groups []*ldap.GroupError
+}
+
+func (e *UnresolvedGroupsError) Error() string {
+	groups := make([]string, len(e.Groups))
+	for i, groupErr := range e.Groups {
+		groups[i] = groupErr.Error()
+	}
+	return fmt.Sprintf("not removing %s users of %s as ldap groups could not be resolved: %s", e.Role, e.Entity, strings.Join(groups, ", "))
+}
+
+func (m *DefaultManager) SyncLdapUsers(ctx context.Context, roleUsers *role.RoleUsers, usersInput UsersInput) error {
 	origin := m.LdapConfig.Origin
 	if m.LdapConfig.Enabled {
-		uaaUsers, err := m.GetUAAUsers()
+		uaaUsers, err := m.GetUAAUsers(ctx)
 		if err != nil {
 			return err
 		}
-		ldapUsers, err := m.GetLDAPUsers(usersInput)
+		ldapUsers, groupErrs, err := m.GetLDAPUsers(ctx, usersInput)
 		if err != nil {
 			return err
 		}
@@ -29,7 +49,7 @@ func (m *DefaultManager) SyncLdapUsers(roleUsers *role.RoleUsers, usersInput Use
 			uaaUser := uaaUsers.GetByNameAndOrigin(userID, origin)
 			if uaaUser == nil {
 				lo.G.Debugf("User %s doesn't exist in cloud foundry with origin %s, so creating user", userID, origin)
-				if err := m.UAAMgr.CreateExternalUser(userID, userToUse.Email, userToUse.UserDN, m.LdapConfig.Origin); err != nil {
+				if err := m.createExternalUser(ctx, uaaUsers, userID, userToUse.Email, userToUse.UserDN, m.LdapConfig.Origin); err != nil {
 					return err
 				}
 			}
@@ -39,7 +59,7 @@ func (m *DefaultManager) SyncLdapUsers(roleUsers *role.RoleUsers, usersInput Use
 					return fmt.Errorf("unable to find user %s for origin %s", userID, origin)
 				}
 				m.dumpRoleUsers(fmt.Sprintf("Adding user [%s] with guid[%s] with origin [%s] as doesn't exist in users for %s/%s - Role %s for entity with guid[%s/%s]", userID, user.GUID, origin, usersInput.OrgName, usersInput.SpaceName, usersInput.Role, usersInput.OrgGUID, usersInput.SpaceGUID), roleUsers.Users())
-				if err := usersInput.AddUser(usersInput.OrgGUID, usersInput.EntityName(), usersInput.EntityGUID(), user.Username, user.GUID); err != nil {
+				if err := usersInput.AddUser(ctx, usersInput.OrgGUID, usersInput.EntityName(), usersInput.EntityGUID(), user.Username, user.GUID); err != nil {
 					return errors.Wrap(err, fmt.Sprintf("User %s with origin %s", user.Username, user.Origin))
 				}
 			} else {
@@ -47,23 +67,36 @@ func (m *DefaultManager) SyncLdapUsers(roleUsers *role.RoleUsers, usersInput Use
 				roleUsers.RemoveUserForOrigin(userID, origin)
 			}
 		}
+		if len(groupErrs) > 0 && usersInput.RemoveUsers {
+			return &UnresolvedGroupsError{Entity: usersInput.EntityName(), Role: usersInput.Role, Groups: groupErrs}
+		}
 	} else {
 		lo.G.Debug("Skipping LDAP sync as LDAP is disabled (enable by updating config/ldap.yml)")
 	}
 	return nil
 }
 
-func (m *DefaultManager) GetLDAPUsers(usersInput UsersInput) ([]ldap.User, error) {
+// GetLDAPUsers - returns the users of the ldap groups and ldap users of the
+// input.  Groups that can't be resolved are returned separately from errors
+// talking to ldap so callers can decide whether the users are complete enough
+// to act on.
+func (m *DefaultManager) GetLDAPUsers(ctx context.Context, usersInput UsersInput) ([]ldap.User, []*ldap.GroupError, error) {
 	origin := m.LdapConfig.Origin
 	var ldapUsers []ldap.User
-	uaaUsers, err := m.GetUAAUsers()
+	var groupErrs []*ldap.GroupError
+	uaaUsers, err := m.GetUAAUsers(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	for _, groupName := range usersInput.UniqueLdapGroupNames() {
-		userDNList, err := m.LdapMgr.GetUserDNs(groupName)
+		userDNList, err := m.LdapMgr.GetUserDNs(ctx, groupName)
+		if groupErr, ok := ldap.AsGroupError(err); ok {
+			lo.G.Errorf("Unable to resolve users for %s/%s - Role %s: %s", usersInput.OrgName, usersInput.SpaceName, usersInput.Role, groupErr)
+			groupErrs = append(groupErrs, groupErr)
+			continue
+		}
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		for _, userDN := range userDNList {
 			lo.G.Debugf("Checking for userDN %s", userDN)
@@ -77,9 +110,9 @@ func (m *DefaultManager) GetLDAPUsers(usersInput UsersInput) ([]ldap.User, error
 				})
 			} else {
 				lo.G.Debugf("UserDN [%s] not found in UAA, executing ldap lookup", userDN)
-				user, err := m.LdapMgr.GetUserByDN(userDN)
+				user, err := m.LdapMgr.GetUserByDN(ctx, userDN)
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 				if user != nil {
 					ldapUsers = append(ldapUsers, *user)
@@ -102,9 +135,9 @@ func (m *DefaultManager) GetLDAPUsers(usersInput UsersInput) ([]ldap.User, error
 			}
 		} else {
 			lo.G.Debugf("User [%s] not found in UAA for origin [%s], executing ldap lookup", userID, origin)
-			user, err := m.LdapMgr.GetUserByID(userID)
+			user, err := m.LdapMgr.GetUserByID(ctx, userID)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			if user != nil {
 				ldapUsers = append(ldapUsers, *user)
@@ -128,7 +161,7 @@ func (m *DefaultManager) GetLDAPUsers(usersInput UsersInput) ([]ldap.User, error
 	for _, uniqueLDAPUser := range uniqueLDAPUsers {
 		ldapUsersToReturn = append(ldapUsersToReturn, uniqueLDAPUser)
 	}
-	return ldapUsersToReturn, nil
+	return ldapUsersToReturn, groupErrs, nil
 }
 
 func (m *DefaultManager) dumpLdapUsers(message string, users []ldap.User) {
@@ -1,6 +1,8 @@
 package user_test
 
 import (
+	"context"
+
 	"errors"
 
 	uaaclient "github.com/cloudfoundry-community/go-uaa"
@@ -64,7 +66,7 @@ var _ = Describe("given UserSpaces", func() {
 				uaaUsers = append(uaaUsers, uaaclient.User{Username: "test_ldap2", Origin: "ldap", ExternalID: "cn=test_ldap2", ID: "test_ldap2-id"})
 				uaaFake.ListUsersReturns(uaaUsers, uaaclient.Page{StartIndex: 1, TotalResults: 2, ItemsPerPage: 500}, nil)
 
-				users, err := userManager.UAAMgr.ListUsers()
+				users, err := userManager.UAAMgr.ListUsers(context.Background())
 				Expect(err).ShouldNot(HaveOccurred())
 				roleUsers, _ = role.NewRoleUsers([]*uaa.User{
 					{Username: "test_ldap", GUID: "test_ldap-id"},
@@ -91,10 +93,10 @@ var _ = Describe("given UserSpaces", func() {
 					},
 					nil)
 
-				err := userManager.SyncLdapUsers(roleUsers, updateUsersInput)
+				err := userManager.SyncLdapUsers(context.Background(), roleUsers, updateUsersInput)
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(roleMgrFake.AssociateSpaceAuditorCallCount()).Should(Equal(1))
-				orgGUID, spaceName, spaceGUID, userName, userGUID := roleMgrFake.AssociateSpaceAuditorArgsForCall(0)
+				_, orgGUID, spaceName, spaceGUID, userName, userGUID := roleMgrFake.AssociateSpaceAuditorArgsForCall(0)
 				Expect(orgGUID).Should(Equal("org_guid"))
 				Expect(userGUID).Should(Equal("test_ldap2-id"))
 				Expect(spaceGUID).Should(Equal("space_guid"))
@@ -115,7 +117,7 @@ var _ = Describe("given UserSpaces", func() {
 				uaaUsers = append(uaaUsers, uaaclient.User{Username: "test_ldap2", Origin: "ldap", ExternalID: "cn=test_ldap2", ID: "test_ldap2-id"})
 				uaaFake.ListUsersReturns(uaaUsers, uaaclient.Page{StartIndex: 1, TotalResults: 10, ItemsPerPage: 500}, nil)
 
-				users, err := userManager.UAAMgr.ListUsers()
+				users, err := userManager.UAAMgr.ListUsers(context.Background())
 				Expect(err).ShouldNot(HaveOccurred())
 				roleUsers, _ = role.NewRoleUsers([]*uaa.User{
 					{Username: "test_ldap", GUID: "test_ldap-id"},
@@ -140,10 +142,10 @@ var _ = Describe("given UserSpaces", func() {
 					},
 					nil)
 
-				err = userManager.SyncLdapUsers(roleUsers, updateUsersInput)
+				err = userManager.SyncLdapUsers(context.Background(), roleUsers, updateUsersInput)
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(roleMgrFake.AssociateSpaceAuditorCallCount()).Should(Equal(1))
-				orgGUID, spaceName, spaceGUID, userName, userGUID := roleMgrFake.AssociateSpaceAuditorArgsForCall(0)
+				_, orgGUID, spaceName, spaceGUID, userName, userGUID := roleMgrFake.AssociateSpaceAuditorArgsForCall(0)
 				Expect(orgGUID).Should(Equal("org_guid"))
 				Expect(userGUID).Should(Equal("test_ldap2-id"))
 				Expect(spaceGUID).Should(Equal("space_guid"))
@@ -172,10 +174,10 @@ var _ = Describe("given UserSpaces", func() {
 					},
 					nil)
 
-				err := userManager.SyncLdapUsers(roleUsers, updateUsersInput)
+				err := userManager.SyncLdapUsers(context.Background(), roleUsers, updateUsersInput)
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(roleMgrFake.AssociateSpaceAuditorCallCount()).Should(Equal(1))
-				orgGUID, spaceName, spaceGUID, userName, userGUID := roleMgrFake.AssociateSpaceAuditorArgsForCall(0)
+				_, orgGUID, spaceName, spaceGUID, userName, userGUID := roleMgrFake.AssociateSpaceAuditorArgsForCall(0)
 				Expect(orgGUID).Should(Equal("org_guid"))
 				Expect(userGUID).Should(Equal("test_ldap2-id"))
 				Expect(spaceGUID).Should(Equal("space_guid"))
@@ -198,7 +200,7 @@ var _ = Describe("given UserSpaces", func() {
 						Email:  "test@test.com",
 					},
 					nil)
-				err := userManager.SyncLdapUsers(roleUsers, updateUsersInput)
+				err := userManager.SyncLdapUsers(context.Background(), roleUsers, updateUsersInput)
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(roleMgrFake.AssociateSpaceAuditorCallCount()).Should(Equal(0))
 			})
@@ -218,7 +220,7 @@ var _ = Describe("given UserSpaces", func() {
 					},
 					nil)
 				uaaFake.CreateUserReturns(&uaaclient.User{ID: "user-guid"}, nil)
-				err := userManager.SyncLdapUsers(roleUsers, updateUsersInput)
+				err := userManager.SyncLdapUsers(context.Background(), roleUsers, updateUsersInput)
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(uaaFake.CreateUserCallCount()).Should(Equal(1))
 				user := uaaFake.CreateUserArgsForCall(0)
@@ -244,9 +246,9 @@ var _ = Describe("given UserSpaces", func() {
 					},
 					nil)
 				uaaFake.CreateUserReturns(nil, errors.New("error"))
-				err := userManager.SyncLdapUsers(roleUsers, updateUsersInput)
+				err := userManager.SyncLdapUsers(context.Background(), roleUsers, updateUsersInput)
 				Expect(err).Should(HaveOccurred())
-				uaaUsers, err := userManager.UAAMgr.ListUsers()
+				uaaUsers, err := userManager.UAAMgr.ListUsers(context.Background())
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(uaaUsers.GetByNameAndOrigin("test_ldap3", "ldap")).Should(BeNil())
 				Expect(uaaFake.CreateUserCallCount()).Should(Equal(1))
@@ -268,7 +270,7 @@ var _ = Describe("given UserSpaces", func() {
 					},
 					nil)
 				roleMgrFake.AssociateSpaceAuditorReturns(errors.New("error"))
-				err := userManager.SyncLdapUsers(roleUsers, updateUsersInput)
+				err := userManager.SyncLdapUsers(context.Background(), roleUsers, updateUsersInput)
 				Expect(err).Should(HaveOccurred())
 				Expect(err.Error()).Should(Equal("User test_ldap2 with origin ldap: error"))
 				Expect(roleMgrFake.AssociateSpaceAuditorCallCount()).Should(Equal(1))
@@ -283,7 +285,7 @@ var _ = Describe("given UserSpaces", func() {
 					RoleUsers: role.InitRoleUsers(),
 				}
 
-				err := userManager.SyncLdapUsers(roleUsers, updateUsersInput)
+				err := userManager.SyncLdapUsers(context.Background(), roleUsers, updateUsersInput)
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(roleMgrFake.AssociateSpaceAuditorCallCount()).Should(Equal(1))
 				Expect(ldapFake.GetUserByIDCallCount()).Should(Equal(0))
@@ -299,7 +301,7 @@ var _ = Describe("given UserSpaces", func() {
 					RoleUsers:      role.InitRoleUsers(),
 				}
 				ldapFake.GetUserDNsReturns([]string{"cn=test_ldap2"}, nil)
-				err := userManager.SyncLdapUsers(roleUsers, updateUsersInput)
+				err := userManager.SyncLdapUsers(context.Background(), roleUsers, updateUsersInput)
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(roleMgrFake.AssociateSpaceAuditorCallCount()).Should(Equal(1))
 				Expect(ldapFake.GetUserDNsCallCount()).Should(Equal(1))
@@ -315,11 +317,61 @@ var _ = Describe("given UserSpaces", func() {
 					RoleUsers: role.InitRoleUsers(),
 				}
 				ldapFake.GetUserByIDReturns(nil, errors.New("error"))
-				err := userManager.SyncLdapUsers(roleUsers, updateUsersInput)
+				err := userManager.SyncLdapUsers(context.Background(), roleUsers, updateUsersInput)
 				Expect(err).Should(HaveOccurred())
 				Expect(err.Error()).Should(Equal("error"))
 				Expect(roleMgrFake.AssociateSpaceAuditorCallCount()).Should(Equal(0))
 			})
+
+			Context("when an ldap group can't be resolved", func() {
+				var updateUsersInput UsersInput
+				BeforeEach(func() {
+					updateUsersInput = UsersInput{
+						LdapGroupNames: []string{"test_group", "missing_group"},
+						SpaceGUID:      "space_guid",
+						OrgGUID:        "org_guid",
+						SpaceName:      "spaceName",
+						OrgName:        "orgName",
+						Role:           "space-auditor",
+						AddUser:        roleMgrFake.AssociateSpaceAuditor,
+						RemoveUser:     roleMgrFake.RemoveSpaceAuditor,
+						RoleUsers:      roleUsers,
+					}
+					ldapFake.GetUserDNsStub = func(_ context.Context, groupName string) ([]string, error) {
+						if groupName == "missing_group" {
+							return nil, &ldap.GroupError{Group: groupName, Reason: ldap.GroupNotFound}
+						}
+						return []string{"cn=test_ldap2"}, nil
+					}
+				})
+
+				It("Should add members of the other groups and refuse removals", func() {
+					updateUsersInput.RemoveUsers = true
+					err := userManager.SyncLdapUsers(context.Background(), roleUsers, updateUsersInput)
+					Expect(err).Should(MatchError("not removing space-auditor users of orgName/spaceName as ldap groups could not be resolved: ldap group [missing_group]: not found"))
+					Expect(roleMgrFake.AssociateSpaceAuditorCallCount()).Should(Equal(1))
+				})
+
+				It("Should not error when removing users is disabled", func() {
+					err := userManager.SyncLdapUsers(context.Background(), roleUsers, updateUsersInput)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(roleMgrFake.AssociateSpaceAuditorCallCount()).Should(Equal(1))
+				})
+
+				It("Should not remove any users of the role", func() {
+					updateUsersInput.RemoveUsers = true
+					err := userManager.SyncUsers(context.Background(), updateUsersInput)
+					Expect(err).Should(BeAssignableToTypeOf(&UnresolvedGroupsError{}))
+					Expect(roleMgrFake.RemoveSpaceAuditorCallCount()).Should(Equal(0))
+				})
+
+				It("Should return errors talking to ldap", func() {
+					ldapFake.GetUserDNsStub = nil
+					ldapFake.GetUserDNsReturns(nil, errors.New("connection refused"))
+					err := userManager.SyncLdapUsers(context.Background(), roleUsers, updateUsersInput)
+					Expect(err).Should(MatchError("connection refused"))
+				})
+			})
 		})
 		Context("UpdateUserInfo", func() {
 
@@ -1,6 +1,7 @@
 package user
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pkg/errors"
@@ -8,9 +9,9 @@ import (
 	"github.com/xchapter7x/lo"
 )
 
-func (m *DefaultManager) SyncSamlUsers(roleUsers *role.RoleUsers, usersInput UsersInput) error {
+func (m *DefaultManager) SyncSamlUsers(ctx context.Context, roleUsers *role.RoleUsers, usersInput UsersInput) error {
 	origin := m.LdapConfig.Origin
-	uaaUsers, err := m.GetUAAUsers()
+	uaaUsers, err := m.GetUAAUsers(ctx)
 	if err != nil {
 		return err
 	}
@@ -18,7 +19,7 @@ func (m *DefaultManager) SyncSamlUsers(roleUsers *role.RoleUsers, usersInput Use
 		uaaUser := uaaUsers.GetByNameAndOrigin(userEmail, origin)
 		if uaaUser == nil {
 			lo.G.Debugf("user %s doesn't exist in cloud foundry with origin %s, so creating user", userEmail, origin)
-			if err := m.UAAMgr.CreateExternalUser(userEmail, userEmail, userEmail, origin); err != nil {
+			if err := m.createExternalUser(ctx, uaaUsers, userEmail, userEmail, userEmail, origin); err != nil {
 				return err
 			}
 		}
@@ -28,7 +29,7 @@ func (m *DefaultManager) SyncSamlUsers(roleUsers *role.RoleUsers, usersInput Use
 		}
 		if !roleUsers.HasUserForOrigin(userEmail, user.Origin) {
 			m.dumpRoleUsers(fmt.Sprintf("Adding user [%s] with guid[%s] with origin [%s] as doesn't exist in users for %s/%s - Role %s", userEmail, user.GUID, origin, usersInput.OrgName, usersInput.SpaceName, usersInput.Role), roleUsers.Users())
-			if err := usersInput.AddUser(usersInput.OrgGUID, usersInput.EntityName(), usersInput.EntityGUID(), user.Username, user.GUID); err != nil {
+			if err := usersInput.AddUser(ctx, usersInput.OrgGUID, usersInput.EntityName(), usersInput.EntityGUID(), user.Username, user.GUID); err != nil {
 				return errors.Wrap(err, fmt.Sprintf("User %s with origin %s", user.Username, user.Origin))
 			}
 		} else {
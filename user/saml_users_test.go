@@ -1,6 +1,8 @@
 package user_test
 
 import (
+	"context"
+
 	"errors"
 
 	uaaclient "github.com/cloudfoundry-community/go-uaa"
@@ -59,7 +61,7 @@ var _ = Describe("SamlUsers", func() {
 			uaaUsers = append(uaaUsers, uaaclient.User{Username: "test2.test2@test.com", Emails: []uaaclient.Email{{Value: "test2.test2@test.com"}}, ExternalID: "test2.test2@test.com", Origin: "saml_origin", ID: "test2-id"})
 			uaaFake.ListUsersReturns(uaaUsers, uaaclient.Page{StartIndex: 1, TotalResults: 2, ItemsPerPage: 500}, nil)
 
-			users, err := userManager.UAAMgr.ListUsers()
+			users, err := userManager.UAAMgr.ListUsers(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			roleUsers, _ = role.NewRoleUsers(
 				[]*uaa.User{
@@ -79,10 +81,10 @@ var _ = Describe("SamlUsers", func() {
 				RoleUsers: role.InitRoleUsers(),
 				AddUser:   roleMgrFake.AssociateSpaceAuditor,
 			}
-			err := userManager.SyncSamlUsers(roleUsers, updateUsersInput)
+			err := userManager.SyncSamlUsers(context.Background(), roleUsers, updateUsersInput)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(roleMgrFake.AssociateSpaceAuditorCallCount()).Should(Equal(1))
-			orgGUID, spaceName, spaceGUID, userName, userGUID := roleMgrFake.AssociateSpaceAuditorArgsForCall(0)
+			_, orgGUID, spaceName, spaceGUID, userName, userGUID := roleMgrFake.AssociateSpaceAuditorArgsForCall(0)
 			Expect(orgGUID).Should(Equal("org_guid"))
 			Expect(userGUID).Should(Equal("test2-id"))
 			Expect(spaceGUID).Should(Equal("space_guid"))
@@ -99,7 +101,7 @@ var _ = Describe("SamlUsers", func() {
 				RoleUsers: role.InitRoleUsers(),
 			}
 			Expect(roleUsers.HasUserForOrigin("test.test@test.com", "saml_origin")).Should(BeTrue())
-			err := userManager.SyncSamlUsers(roleUsers, updateUsersInput)
+			err := userManager.SyncSamlUsers(context.Background(), roleUsers, updateUsersInput)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(roleUsers.HasUserForOrigin("test.test@test.com", "saml_origin")).Should(BeFalse())
 			Expect(uaaFake.CreateUserCallCount()).Should(Equal(0))
@@ -115,7 +117,7 @@ var _ = Describe("SamlUsers", func() {
 				AddUser:   roleMgrFake.AssociateSpaceAuditor,
 				RoleUsers: role.InitRoleUsers(),
 			}
-			err := userManager.SyncSamlUsers(roleUsers, updateUsersInput)
+			err := userManager.SyncSamlUsers(context.Background(), roleUsers, updateUsersInput)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(roleUsers.HasUserForOrigin("Test.Test@test.com", "saml_origin")).Should(BeFalse())
 			Expect(uaaFake.CreateUserCallCount()).Should(Equal(0))
@@ -130,7 +132,7 @@ var _ = Describe("SamlUsers", func() {
 				RoleUsers: role.InitRoleUsers(),
 			}
 			uaaFake.CreateUserReturns(&uaaclient.User{ID: "user-guid"}, nil)
-			err := userManager.SyncSamlUsers(roleUsers, updateUsersInput)
+			err := userManager.SyncSamlUsers(context.Background(), roleUsers, updateUsersInput)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(uaaFake.CreateUserCallCount()).Should(Equal(1))
 			user := uaaFake.CreateUserArgsForCall(0)
@@ -150,7 +152,7 @@ var _ = Describe("SamlUsers", func() {
 			}
 			uaaFake.ListUsersReturns([]uaaclient.User{}, uaaclient.Page{StartIndex: 1, TotalResults: 0, ItemsPerPage: 500}, nil)
 			uaaFake.CreateUserReturns(nil, errors.New("error"))
-			err := userManager.SyncSamlUsers(roleUsers, updateUsersInput)
+			err := userManager.SyncSamlUsers(context.Background(), roleUsers, updateUsersInput)
 			Expect(err).Should(HaveOccurred())
 			Expect(uaaFake.CreateUserCallCount()).Should(Equal(1))
 		})
@@ -172,7 +174,7 @@ var _ = Describe("SamlUsers", func() {
 			}
 			uaaFake.ListUsersReturns(uaaUsers, uaaclient.Page{StartIndex: 1, TotalResults: 0, ItemsPerPage: 500}, nil)
 			roleMgrFake.AssociateSpaceAuditorReturns(errors.New("Got an error"))
-			err := userManager.SyncSamlUsers(roleUsers, updateUsersInput)
+			err := userManager.SyncSamlUsers(context.Background(), roleUsers, updateUsersInput)
 			Expect(err).Should(HaveOccurred())
 			Expect(roleMgrFake.AssociateSpaceAuditorCallCount()).Should(Equal(1))
 		})
@@ -185,7 +187,7 @@ var _ = Describe("SamlUsers", func() {
 			uaaUsers = append(uaaUsers, uaaclient.User{Username: "test.test@test.com", Emails: []uaaclient.Email{{Value: "test.test@test.com"}}, ExternalID: "test.test@test.com", Origin: "saml_original_origin", ID: "test-id"})
 			uaaFake.ListUsersReturns(uaaUsers, uaaclient.Page{StartIndex: 1, TotalResults: 1, ItemsPerPage: 500}, nil)
 
-			users, err := userManager.UAAMgr.ListUsers()
+			users, err := userManager.UAAMgr.ListUsers(context.Background())
 			Expect(err).ShouldNot(HaveOccurred())
 			roleUsers, _ = role.NewRoleUsers(
 				[]*uaa.User{
@@ -208,7 +210,7 @@ var _ = Describe("SamlUsers", func() {
 				RemoveUsers: true,
 			}
 			uaaFake.CreateUserReturns(&uaaclient.User{ID: "new-user-guid"}, nil)
-			err := userManager.SyncUsers(updateUsersInput)
+			err := userManager.SyncUsers(context.Background(), updateUsersInput)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(uaaFake.CreateUserCallCount()).Should(Equal(1))
 			user := uaaFake.CreateUserArgsForCall(0)
@@ -218,7 +220,7 @@ var _ = Describe("SamlUsers", func() {
 			Expect(user.Origin).Should(Equal("saml_origin"))
 
 			Expect(roleMgrFake.AssociateSpaceAuditorCallCount()).Should(Equal(1))
-			orgGUID, spaceName, spaceGUID, userName, userGUID := roleMgrFake.AssociateSpaceAuditorArgsForCall(0)
+			_, orgGUID, spaceName, spaceGUID, userName, userGUID := roleMgrFake.AssociateSpaceAuditorArgsForCall(0)
 			Expect(orgGUID).Should(Equal("org_guid"))
 			Expect(userGUID).Should(Equal("new-user-guid"))
 			Expect(spaceGUID).Should(Equal("space_guid"))
@@ -226,7 +228,7 @@ var _ = Describe("SamlUsers", func() {
 			Expect(userName).Should(Equal("test.test@test.com"))
 
 			Expect(roleMgrFake.RemoveSpaceAuditorCallCount()).Should(Equal(1))
-			spaceName, spaceGUID, userName, userGUID = roleMgrFake.RemoveSpaceAuditorArgsForCall(0)
+			_, spaceName, spaceGUID, userName, userGUID = roleMgrFake.RemoveSpaceAuditorArgsForCall(0)
 			Expect(userGUID).Should(Equal("test-id"))
 			Expect(spaceGUID).Should(Equal("space_guid"))
 			Expect(spaceName).Should(Equal("test-org/test-space"))
@@ -1,19 +1,22 @@
 package user
 
 import (
+	"context"
+
 	"github.com/vmwarepivotallabs/cf-mgmt/ldap"
 )
 
 // Manager - interface type encapsulating Update space users behavior
 type Manager interface {
-	UpdateSpaceUsers() []error
-	UpdateOrgUsers() []error
-	CleanupOrgUsers() []error
+	UpdateSpaceUsers(ctx context.Context) []error
+	UpdateOrgUsers(ctx context.Context) []error
+	CleanupOrgUsers(ctx context.Context) []error
+	ResetRoleRemovals()
 }
 
 type LdapManager interface {
-	GetUserDNs(groupName string) ([]string, error)
-	GetUserByDN(userDN string) (*ldap.User, error)
-	GetUserByID(userID string) (*ldap.User, error)
+	GetUserDNs(ctx context.Context, groupName string) ([]string, error)
+	GetUserByDN(ctx context.Context, userDN string) (*ldap.User, error)
+	GetUserByID(ctx context.Context, userID string) (*ldap.User, error)
 	Close()
 }
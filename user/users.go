@@ -1,12 +1,17 @@
 package user
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
+	"github.com/vmwarepivotallabs/cf-mgmt/changes"
 	"github.com/vmwarepivotallabs/cf-mgmt/config"
+	"github.com/vmwarepivotallabs/cf-mgmt/failures"
 	"github.com/vmwarepivotallabs/cf-mgmt/ldap"
 	"github.com/vmwarepivotallabs/cf-mgmt/organizationreader"
 	"github.com/vmwarepivotallabs/cf-mgmt/role"
@@ -30,45 +35,66 @@ func NewManager(
 	spaceMgr space.Manager,
 	orgReader organizationreader.Reader,
 	uaaMgr uaa.Manager, roleMgr role.Manager, ldapMgr *ldap.Manager,
-	peek bool) (Manager, error) {
+	parallelism int, allowLargeDeletions bool, failures *failures.Collector, recorder *changes.Recorder, peek bool) (Manager, error) {
 
 	ldapConfig, err := cfg.LdapConfig("", "", "")
 	if err != nil {
 		return nil, err
 	}
 	mgr := &DefaultManager{
-		Peek:       peek,
-		SpaceMgr:   spaceMgr,
-		OrgReader:  orgReader,
-		UAAMgr:     uaaMgr,
-		RoleMgr:    roleMgr,
-		LdapMgr:    ldapMgr,
-		Cfg:        cfg,
-		LdapConfig: ldapConfig,
+		Peek:                peek,
+		SpaceMgr:            spaceMgr,
+		OrgReader:           orgReader,
+		UAAMgr:              uaaMgr,
+		RoleMgr:             roleMgr,
+		LdapMgr:             ldapMgr,
+		Cfg:                 cfg,
+		LdapConfig:          ldapConfig,
+		Failures:            failures,
+		Recorder:            recorder,
+		Parallelism:         parallelism,
+		AllowLargeDeletions: allowLargeDeletions,
 	}
 	return mgr, nil
 }
 
 type DefaultManager struct {
-	Cfg        config.Reader
-	SpaceMgr   space.Manager
-	OrgReader  organizationreader.Reader
-	UAAMgr     uaa.Manager
-	RoleMgr    role.Manager
-	Peek       bool
-	LdapMgr    LdapManager
-	LdapConfig *config.LdapConfig
+	Cfg                 config.Reader
+	SpaceMgr            space.Manager
+	OrgReader           organizationreader.Reader
+	UAAMgr              uaa.Manager
+	RoleMgr             role.Manager
+	Peek                bool
+	LdapMgr             LdapManager
+	LdapConfig          *config.LdapConfig
+	Failures            *failures.Collector
+	Recorder            *changes.Recorder
+	Parallelism         int
+	AllowLargeDeletions bool
+	// userMutex - serializes creating uaa users so spaces reconciled concurrently don't create the same user twice
+	userMutex sync.Mutex
+	// removed - the users removed from roles so far in the run
+	removed roleRemovalTally
 }
 
-func (m *DefaultManager) GetUAAUsers() (*uaa.Users, error) {
-	return m.UAAMgr.ListUsers()
+func (m *DefaultManager) GetUAAUsers(ctx context.Context) (*uaa.Users, error) {
+	return m.UAAMgr.ListUsers(ctx)
+}
+
+// createExternalUser - creates the user unless another space created it in the meantime
+func (m *DefaultManager) createExternalUser(ctx context.Context, uaaUsers *uaa.Users, userName, userEmail, externalID, origin string) error {
+	m.userMutex.Lock()
+	defer m.userMutex.Unlock()
+	if uaaUsers.GetByNameAndOrigin(userName, origin) != nil {
+		return nil
+	}
+	return m.UAAMgr.CreateExternalUser(ctx, userName, userEmail, externalID, origin)
 }
 
 // UpdateSpaceUsers -
-func (m *DefaultManager) UpdateSpaceUsers() []error {
-	errs := []error{}
+func (m *DefaultManager) UpdateSpaceUsers(ctx context.Context) []error {
 	m.RoleMgr.ClearRoles()
-	err := m.RoleMgr.InitializeSpaceUserRolesMap()
+	err := m.RoleMgr.InitializeSpaceUserRolesMap(ctx)
 	if err != nil {
 		return []error{err}
 	}
@@ -77,16 +103,23 @@ func (m *DefaultManager) UpdateSpaceUsers() []error {
 		return []error{err}
 	}
 
-	for _, input := range spaceConfigs {
-		if err := m.updateSpaceUsers(&input); err != nil {
-			errs = append(errs, err)
+	removals := &roleRemovals{}
+	errs := util.RunGrouped(ctx, m.Parallelism, len(spaceConfigs), func(i int) string { return spaceConfigs[i].Org }, false, func(i int) error {
+		input := &spaceConfigs[i]
+		if m.Failures.Skip(input.Org, input.Space) {
+			lo.G.Infof("skipping space [%s/%s] users as it failed in an earlier step", input.Org, input.Space)
+			return nil
 		}
+		return m.Failures.Add(input.Org, input.Space, m.updateSpaceUsers(ctx, input, removals))
+	})
+	if ctx.Err() != nil {
+		return errs
 	}
-	return errs
+	return append(errs, m.removeRoleUsers(ctx, removals)...)
 }
 
-func (m *DefaultManager) updateSpaceUsers(input *config.SpaceConfig) error {
-	space, err := m.SpaceMgr.FindSpace(input.Org, input.Space)
+func (m *DefaultManager) updateSpaceUsers(ctx context.Context, input *config.SpaceConfig, removals *roleRemovals) error {
+	space, err := m.SpaceMgr.FindSpace(ctx, input.Org, input.Space)
 	if err != nil {
 		return errors.Wrap(err, fmt.Sprintf("Error finding space for org %s, space %s", input.Org, input.Space))
 	}
@@ -96,12 +129,14 @@ func (m *DefaultManager) updateSpaceUsers(input *config.SpaceConfig) error {
 	lo.G.Debug("")
 	lo.G.Debug("")
 
-	managers, developers, auditors, supporters, err := m.RoleMgr.ListSpaceUsersByRole(space.GUID)
+	managers, developers, auditors, supporters, err := m.RoleMgr.ListSpaceUsersByRole(ctx, space.GUID)
 	if err != nil {
 		return err
 	}
 
-	if err = m.SyncUsers(UsersInput{
+	var roleErrs roleErrors
+
+	if err = roleErrs.add(m.syncUsers(ctx, removals, UsersInput{
 		SpaceName:      space.Name,
 		SpaceGUID:      space.GUID,
 		OrgName:        input.Org,
@@ -115,11 +150,11 @@ func (m *DefaultManager) updateSpaceUsers(input *config.SpaceConfig) error {
 		RemoveUser:     m.RoleMgr.RemoveSpaceDeveloper,
 		AddUser:        m.RoleMgr.AssociateSpaceDeveloper,
 		Role:           SPACE_DEVELOPER,
-	}); err != nil {
-		return errors.Wrap(err, fmt.Sprintf("Error syncing users for org %s, space %s, role %s", input.Org, input.Space, "developer"))
+	}), fmt.Sprintf("Error syncing users for org %s, space %s, role %s", input.Org, input.Space, "developer")); err != nil {
+		return err
 	}
 
-	if err = m.SyncUsers(
+	if err = roleErrs.add(m.syncUsers(ctx, removals,
 		UsersInput{
 			SpaceName:      space.Name,
 			SpaceGUID:      space.GUID,
@@ -134,10 +169,10 @@ func (m *DefaultManager) updateSpaceUsers(input *config.SpaceConfig) error {
 			RemoveUser:     m.RoleMgr.RemoveSpaceManager,
 			AddUser:        m.RoleMgr.AssociateSpaceManager,
 			Role:           SPACE_MANAGER,
-		}); err != nil {
-		return errors.Wrap(err, fmt.Sprintf("Error syncing users for org %s, space %s, role %s", input.Org, input.Space, "manager"))
+		}), fmt.Sprintf("Error syncing users for org %s, space %s, role %s", input.Org, input.Space, "manager")); err != nil {
+		return err
 	}
-	if err = m.SyncUsers(
+	if err = roleErrs.add(m.syncUsers(ctx, removals,
 		UsersInput{
 			SpaceName:      space.Name,
 			SpaceGUID:      space.GUID,
@@ -152,11 +187,11 @@ func (m *DefaultManager) updateSpaceUsers(input *config.SpaceConfig) error {
 			RemoveUser:     m.RoleMgr.RemoveSpaceAuditor,
 			AddUser:        m.RoleMgr.AssociateSpaceAuditor,
 			Role:           SPACE_AUDITOR,
-		}); err != nil {
-		return errors.Wrap(err, fmt.Sprintf("Error syncing users for org %s, space %s, role %s", input.Org, input.Space, "auditor"))
+		}), fmt.Sprintf("Error syncing users for org %s, space %s, role %s", input.Org, input.Space, "auditor")); err != nil {
+		return err
 	}
 
-	if err = m.SyncUsers(UsersInput{
+	if err = roleErrs.add(m.syncUsers(ctx, removals, UsersInput{
 		SpaceName:      space.Name,
 		SpaceGUID:      space.GUID,
 		OrgName:        input.Org,
@@ -170,8 +205,8 @@ func (m *DefaultManager) updateSpaceUsers(input *config.SpaceConfig) error {
 		RemoveUser:     m.RoleMgr.RemoveSpaceSupporter,
 		AddUser:        m.RoleMgr.AssociateSpaceSupporter,
 		Role:           SPACE_SUPPORTER,
-	}); err != nil {
-		return errors.Wrap(err, fmt.Sprintf("Error syncing users for org %s, space %s, role %s", input.Org, input.Space, "developer"))
+	}), fmt.Sprintf("Error syncing users for org %s, space %s, role %s", input.Org, input.Space, "developer")); err != nil {
+		return err
 	}
 
 	lo.G.Debug("")
@@ -179,14 +214,14 @@ func (m *DefaultManager) updateSpaceUsers(input *config.SpaceConfig) error {
 	lo.G.Debugf("Done Processing Org(%s)/Space(%s)", input.Org, input.Space)
 	lo.G.Debug("")
 	lo.G.Debug("")
-	return nil
+	return roleErrs.err()
 }
 
 // UpdateOrgUsers -
-func (m *DefaultManager) UpdateOrgUsers() []error {
+func (m *DefaultManager) UpdateOrgUsers(ctx context.Context) []error {
 	errs := []error{}
 	m.RoleMgr.ClearRoles()
-	err := m.RoleMgr.InitializeOrgUserRolesMap()
+	err := m.RoleMgr.InitializeOrgUserRolesMap(ctx)
 	if err != nil {
 		return []error{err}
 	}
@@ -195,26 +230,36 @@ func (m *DefaultManager) UpdateOrgUsers() []error {
 		return []error{err}
 	}
 
+	removals := &roleRemovals{}
 	for _, input := range orgConfigs {
-		if err := m.updateOrgUsers(&input); err != nil {
+		if err := ctx.Err(); err != nil {
+			return append(errs, err)
+		}
+		if m.Failures.Skip(input.Org, "") {
+			lo.G.Infof("skipping org [%s] users as it failed in an earlier step", input.Org)
+			continue
+		}
+		if err := m.Failures.Add(input.Org, "", m.updateOrgUsers(ctx, &input, removals)); err != nil {
 			errs = append(errs, err)
 		}
 
 	}
-	return errs
+	return append(errs, m.removeRoleUsers(ctx, removals)...)
 }
 
-func (m *DefaultManager) updateOrgUsers(input *config.OrgConfig) error {
-	org, err := m.OrgReader.FindOrg(input.Org)
+func (m *DefaultManager) updateOrgUsers(ctx context.Context, input *config.OrgConfig, removals *roleRemovals) error {
+	org, err := m.OrgReader.FindOrg(ctx, input.Org)
 	if err != nil {
 		return err
 	}
 
-	_, managers, billingManagers, auditors, err := m.RoleMgr.ListOrgUsersByRole(org.GUID)
+	_, managers, billingManagers, auditors, err := m.RoleMgr.ListOrgUsersByRole(ctx, org.GUID)
 	if err != nil {
 		return err
 	}
-	err = m.SyncUsers(
+
+	var roleErrs roleErrors
+	err = roleErrs.add(m.syncUsers(ctx, removals,
 		UsersInput{
 			OrgName:        org.Name,
 			OrgGUID:        org.GUID,
@@ -227,12 +272,12 @@ func (m *DefaultManager) updateOrgUsers(input *config.OrgConfig) error {
 			RemoveUser:     m.RoleMgr.RemoveOrgBillingManager,
 			AddUser:        m.RoleMgr.AssociateOrgBillingManager,
 			Role:           ORG_BILLING_MANAGER,
-		})
+		}), fmt.Sprintf("Error syncing users for org %s role %s", input.Org, "billing_managers"))
 	if err != nil {
-		return errors.Wrap(err, fmt.Sprintf("Error syncing users for org %s role %s", input.Org, "billing_managers"))
+		return err
 	}
 
-	err = m.SyncUsers(UsersInput{
+	err = roleErrs.add(m.syncUsers(ctx, removals, UsersInput{
 		OrgName:        org.Name,
 		OrgGUID:        org.GUID,
 		LdapGroupNames: input.GetAuditorGroups(),
@@ -244,12 +289,12 @@ func (m *DefaultManager) updateOrgUsers(input *config.OrgConfig) error {
 		RemoveUser:     m.RoleMgr.RemoveOrgAuditor,
 		AddUser:        m.RoleMgr.AssociateOrgAuditor,
 		Role:           ORG_AUDITOR,
-	})
+	}), fmt.Sprintf("Error syncing users for org %s role %s", input.Org, "org-auditors"))
 	if err != nil {
-		return errors.Wrap(err, fmt.Sprintf("Error syncing users for org %s role %s", input.Org, "org-auditors"))
+		return err
 	}
 
-	err = m.SyncUsers(UsersInput{
+	err = roleErrs.add(m.syncUsers(ctx, removals, UsersInput{
 		OrgName:        org.Name,
 		OrgGUID:        org.GUID,
 		LdapGroupNames: input.GetManagerGroups(),
@@ -261,13 +306,45 @@ func (m *DefaultManager) updateOrgUsers(input *config.OrgConfig) error {
 		RemoveUser:     m.RoleMgr.RemoveOrgManager,
 		AddUser:        m.RoleMgr.AssociateOrgManager,
 		Role:           ORG_MANAGER,
-	})
+	}), fmt.Sprintf("Error syncing users for org %s role %s", input.Org, "org-manager"))
 
 	if err != nil {
-		return errors.Wrap(err, fmt.Sprintf("Error syncing users for org %s role %s", input.Org, "org-manager"))
+		return err
 	}
 
-	return nil
+	return roleErrs.err()
+}
+
+// roleErrors - the errors of the roles of an org or space whose ldap groups
+// couldn't be resolved.  Only the removals of such a role are refused, so the
+// other roles are still synced.
+type roleErrors []error
+
+// add - returns err, wrapped with message, when syncing the org or space has to
+// stop, and otherwise keeps it for err
+func (r *roleErrors) add(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*UnresolvedGroupsError); ok {
+		*r = append(*r, errors.Wrap(err, message))
+		return nil
+	}
+	return errors.Wrap(err, message)
+}
+
+func (r roleErrors) err() error {
+	if len(r) == 0 {
+		return nil
+	}
+	if len(r) == 1 {
+		return r[0]
+	}
+	messages := make([]string, len(r))
+	for i, err := range r {
+		messages[i] = err.Error()
+	}
+	return errors.New(strings.Join(messages, "; "))
 }
 
 func (m *DefaultManager) dumpRoleUsers(message string, users []role.RoleUser) {
@@ -282,40 +359,174 @@ func (m *DefaultManager) dumpRoleUsers(message string, users []role.RoleUser) {
 }
 
 // SyncUsers
-func (m *DefaultManager) SyncUsers(usersInput UsersInput) error {
+func (m *DefaultManager) SyncUsers(ctx context.Context, usersInput UsersInput) error {
+	return m.syncUsers(ctx, nil, usersInput)
+}
+
+// syncUsers - adds the users of usersInput to its role and then removes the
+// others, or, with removals, queues them for the run to remove once every role
+// is synced
+func (m *DefaultManager) syncUsers(ctx context.Context, removals *roleRemovals, usersInput UsersInput) error {
 	roleUsers := usersInput.RoleUsers
+	currentUserCount := len(roleUsers.Users())
 	m.dumpRoleUsers(fmt.Sprintf("Current Users for %s/%s - Role %s", usersInput.OrgName, usersInput.SpaceName, usersInput.Role), roleUsers.Users())
 
-	if err := m.SyncLdapUsers(roleUsers, usersInput); err != nil {
-		return errors.Wrap(err, "adding ldap users")
+	var unresolvedErr *UnresolvedGroupsError
+	if err := m.SyncLdapUsers(ctx, roleUsers, usersInput); err != nil {
+		var ok bool
+		if unresolvedErr, ok = err.(*UnresolvedGroupsError); !ok {
+			return errors.Wrap(err, "adding ldap users")
+		}
 	}
 	if len(roleUsers.Users()) > 0 {
 		m.dumpRoleUsers(fmt.Sprintf("Users after LDAP sync for %s/%s - Role %s", usersInput.OrgName, usersInput.SpaceName, usersInput.Role), roleUsers.Users())
 	}
 
-	if err := m.SyncInternalUsers(roleUsers, usersInput); err != nil {
+	if err := m.SyncInternalUsers(ctx, roleUsers, usersInput); err != nil {
 		return errors.Wrap(err, "adding internal users")
 	}
 	if len(roleUsers.Users()) > 0 {
 		m.dumpRoleUsers(fmt.Sprintf("Users after Internal sync for %s/%s - Role %s", usersInput.OrgName, usersInput.SpaceName, usersInput.Role), roleUsers.Users())
 	}
 
-	if err := m.SyncSamlUsers(roleUsers, usersInput); err != nil {
+	if err := m.SyncSamlUsers(ctx, roleUsers, usersInput); err != nil {
 		return errors.Wrap(err, "adding saml users")
 	}
 	if len(roleUsers.Users()) > 0 {
 		m.dumpRoleUsers(fmt.Sprintf("Users after SAML sync for %s/%s - Role %s", usersInput.OrgName, usersInput.SpaceName, usersInput.Role), roleUsers.Users())
 	}
 
-	if err := m.RemoveUsers(roleUsers, usersInput); err != nil {
+	if unresolvedErr != nil {
+		lo.G.Error(unresolvedErr)
+		return unresolvedErr
+	}
+	count, err := m.removalCount(roleUsers, usersInput)
+	if err != nil {
+		return err
+	}
+	if removals != nil {
+		removals.add(roleUsers, usersInput, count, currentUserCount)
+		return nil
+	}
+	if err := m.checkRoleRemovals(count, currentUserCount); err != nil {
+		return err
+	}
+	if err := m.RemoveUsers(ctx, roleUsers, usersInput); err != nil {
 		return errors.Wrap(err, "removing users")
 	}
 	return nil
 }
 
-func (m *DefaultManager) SyncInternalUsers(roleUsers *role.RoleUsers, usersInput UsersInput) error {
+// roleRemovals - the users a run is to remove from roles, held back until
+// every role is synced so that the role-removals deletion limit applies to the
+// run as a whole rather than to each role
+type roleRemovals struct {
+	mutex   sync.Mutex
+	pending []pendingRemoval
+	count   int
+	total   int
+}
+
+type pendingRemoval struct {
+	roleUsers  *role.RoleUsers
+	usersInput UsersInput
+}
+
+func (r *roleRemovals) add(roleUsers *role.RoleUsers, usersInput UsersInput, count, currentUserCount int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.pending = append(r.pending, pendingRemoval{roleUsers: roleUsers, usersInput: usersInput})
+	r.count += count
+	r.total += currentUserCount
+}
+
+// removeRoleUsers - removes the users the run queued, in config order, unless
+// there are more than the role-removals deletion limit allows, in which case
+// none are
+func (m *DefaultManager) removeRoleUsers(ctx context.Context, removals *roleRemovals) []error {
+	if err := m.checkRoleRemovals(removals.count, removals.total); err != nil {
+		lo.G.Error(err)
+		return []error{err}
+	}
+	pending := removals.pending
+	// the roles of a space are queued in order, the spaces in whatever order they ran
+	sort.SliceStable(pending, func(i, j int) bool {
+		if pending[i].usersInput.OrgName != pending[j].usersInput.OrgName {
+			return pending[i].usersInput.OrgName < pending[j].usersInput.OrgName
+		}
+		return pending[i].usersInput.SpaceName < pending[j].usersInput.SpaceName
+	})
+	return util.RunGrouped(ctx, m.Parallelism, len(pending), func(i int) string { return pending[i].usersInput.OrgName }, false, func(i int) error {
+		// only roles that synced are queued, so a role of an org or space that
+		// failed afterwards, such as one whose ldap groups weren't resolved, still
+		// has its users removed
+		usersInput := pending[i].usersInput
+		if err := m.RemoveUsers(ctx, pending[i].roleUsers, usersInput); err != nil {
+			err = errors.Wrap(err, fmt.Sprintf("Error removing users for %s role %s", usersInput.EntityName(), usersInput.Role))
+			return m.Failures.Add(usersInput.OrgName, usersInput.SpaceName, err)
+		}
+		return nil
+	})
+}
+
+// removalCount - the users left in roleUsers that RemoveUsers removes
+func (m *DefaultManager) removalCount(roleUsers *role.RoleUsers, usersInput UsersInput) (int, error) {
+	if !usersInput.RemoveUsers || len(roleUsers.Users()) == 0 {
+		return 0, nil
+	}
+	cfg, err := m.Cfg.GetGlobalConfig()
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, roleUser := range roleUsers.Users() {
+		if !util.Matches(roleUser.UserName, cfg.ProtectedUsers) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// roleRemovalTally - the users removed from roles, and the users the roles had,
+// so the role-removals deletion limit covers update-org-users,
+// update-space-users and cleanup-org-users of a run together
+type roleRemovalTally struct {
+	mutex sync.Mutex
+	count int
+	total int
+}
+
+// ResetRoleRemovals - starts counting the users removed from roles for a new run
+func (m *DefaultManager) ResetRoleRemovals() {
+	m.removed.mutex.Lock()
+	defer m.removed.mutex.Unlock()
+	m.removed.count = 0
+	m.removed.total = 0
+}
+
+// checkRoleRemovals - errors when removing count of the total users of roles,
+// on top of those removed earlier in the run, exceeds the role-removals
+// deletion limit, and otherwise adds them to the run's removals
+func (m *DefaultManager) checkRoleRemovals(count, total int) error {
+	m.removed.mutex.Lock()
+	defer m.removed.mutex.Unlock()
+	if !m.AllowLargeDeletions && count > 0 {
+		cfg, err := m.Cfg.GetGlobalConfig()
+		if err != nil {
+			return err
+		}
+		if err := cfg.DeletionLimits.RoleRemovals.Check("users from roles", m.removed.count+count, m.removed.total+total); err != nil {
+			return err
+		}
+	}
+	m.removed.count += count
+	m.removed.total += total
+	return nil
+}
+
+func (m *DefaultManager) SyncInternalUsers(ctx context.Context, roleUsers *role.RoleUsers, usersInput UsersInput) error {
 	origin := "uaa"
-	uaaUsers, err := m.GetUAAUsers()
+	uaaUsers, err := m.GetUAAUsers(ctx)
 	if err != nil {
 		return err
 	}
@@ -331,7 +542,7 @@ func (m *DefaultManager) SyncInternalUsers(roleUsers *role.RoleUsers, usersInput
 				return fmt.Errorf("unable to find user %s for origin %s", lowerUserID, origin)
 			}
 			m.dumpRoleUsers(fmt.Sprintf("Adding user [%s] with guid[%s] with origin [%s] as doesn't exist in users for %s/%s - Role %s", lowerUserID, user.GUID, origin, usersInput.OrgName, usersInput.SpaceName, usersInput.Role), roleUsers.Users())
-			if err := usersInput.AddUser(usersInput.OrgGUID, usersInput.EntityName(), usersInput.EntityGUID(), user.Username, user.GUID); err != nil {
+			if err := usersInput.AddUser(ctx, usersInput.OrgGUID, usersInput.EntityName(), usersInput.EntityGUID(), user.Username, user.GUID); err != nil {
 				return errors.Wrap(err, fmt.Sprintf("adding user %s for origin %s", user.Username, origin))
 			}
 		} else {
@@ -341,7 +552,7 @@ func (m *DefaultManager) SyncInternalUsers(roleUsers *role.RoleUsers, usersInput
 	return nil
 }
 
-func (m *DefaultManager) RemoveUsers(roleUsers *role.RoleUsers, usersInput UsersInput) error {
+func (m *DefaultManager) RemoveUsers(ctx context.Context, roleUsers *role.RoleUsers, usersInput UsersInput) error {
 	if usersInput.RemoveUsers {
 		cfg, err := m.Cfg.GetGlobalConfig()
 		if err != nil {
@@ -354,7 +565,7 @@ func (m *DefaultManager) RemoveUsers(roleUsers *role.RoleUsers, usersInput Users
 		}
 		for _, roleUser := range roleUsers.Users() {
 			if !util.Matches(roleUser.UserName, protectedUsers) {
-				if err := usersInput.RemoveUser(usersInput.EntityName(), usersInput.EntityGUID(), roleUser.UserName, roleUser.GUID); err != nil {
+				if err := usersInput.RemoveUser(ctx, usersInput.EntityName(), usersInput.EntityGUID(), roleUser.UserName, roleUser.GUID); err != nil {
 					return errors.Wrap(err, fmt.Sprintf("error removing user %s", roleUser.UserName))
 				}
 			}
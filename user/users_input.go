@@ -1,6 +1,7 @@
 package user
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -16,8 +17,8 @@ type UsersInput struct {
 	OrgName                                     string
 	RemoveUsers                                 bool
 	RoleUsers                                   *role.RoleUsers
-	AddUser                                     func(orgGUID, entityName, entityGUID, userName, userGUID string) error
-	RemoveUser                                  func(entityName, entityGUID, userName, userGUID string) error
+	AddUser                                     func(ctx context.Context, orgGUID, entityName, entityGUID, userName, userGUID string) error
+	RemoveUser                                  func(ctx context.Context, entityName, entityGUID, userName, userGUID string) error
 	Role                                        string
 }
 
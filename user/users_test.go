@@ -1,6 +1,8 @@
 package user_test
 
 import (
+	"context"
+
 	"errors"
 
 	"github.com/cloudfoundry-community/go-cfclient/v3/resource"
@@ -9,6 +11,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/vmwarepivotallabs/cf-mgmt/config"
 	configfakes "github.com/vmwarepivotallabs/cf-mgmt/config/fakes"
+	"github.com/vmwarepivotallabs/cf-mgmt/ldap"
 	orgfakes "github.com/vmwarepivotallabs/cf-mgmt/organizationreader/fakes"
 	"github.com/vmwarepivotallabs/cf-mgmt/role"
 	rolefakes "github.com/vmwarepivotallabs/cf-mgmt/role/fakes"
@@ -63,7 +66,7 @@ var _ = Describe("given UserSpaces", func() {
 				uaaUsers = append(uaaUsers, uaaclient.User{Username: "test-existing", Origin: "uaa", ID: "test-existing-id"})
 				uaaFake.ListUsersReturns(uaaUsers, uaaclient.Page{StartIndex: 1, TotalResults: len(uaaUsers), ItemsPerPage: 500}, nil)
 
-				users, err := userManager.UAAMgr.ListUsers()
+				users, err := userManager.UAAMgr.ListUsers(context.Background())
 				Expect(err).ShouldNot(HaveOccurred())
 				roleUsers, _ = role.NewRoleUsers([]*uaa.User{
 					{Username: "test-existing", GUID: "test-existing-id"},
@@ -79,9 +82,9 @@ var _ = Describe("given UserSpaces", func() {
 					AddUser:   roleMgrFake.AssociateSpaceAuditor,
 					RoleUsers: role.InitRoleUsers(),
 				}
-				err := userManager.SyncInternalUsers(roleUsers, updateUsersInput)
+				err := userManager.SyncInternalUsers(context.Background(), roleUsers, updateUsersInput)
 				Expect(err).ShouldNot(HaveOccurred())
-				orgGUID, spaceName, spaceGUID, userName, userGUID := roleMgrFake.AssociateSpaceAuditorArgsForCall(0)
+				_, orgGUID, spaceName, spaceGUID, userName, userGUID := roleMgrFake.AssociateSpaceAuditorArgsForCall(0)
 				Expect(orgGUID).Should(Equal("org_guid"))
 				Expect(spaceName).Should(Equal("orgName/spaceName"))
 				Expect(spaceGUID).Should(Equal("space_guid"))
@@ -99,9 +102,9 @@ var _ = Describe("given UserSpaces", func() {
 					AddUser:   roleMgrFake.AssociateSpaceAuditor,
 					RoleUsers: role.InitRoleUsers(),
 				}
-				err := userManager.SyncInternalUsers(roleUsers, updateUsersInput)
+				err := userManager.SyncInternalUsers(context.Background(), roleUsers, updateUsersInput)
 				Expect(err).ShouldNot(HaveOccurred())
-				orgGUID, spaceName, spaceGUID, userName, userGUID := roleMgrFake.AssociateSpaceAuditorArgsForCall(0)
+				_, orgGUID, spaceName, spaceGUID, userName, userGUID := roleMgrFake.AssociateSpaceAuditorArgsForCall(0)
 				Expect(orgGUID).Should(Equal("org_guid"))
 				Expect(spaceName).Should(Equal("orgName/spaceName"))
 				Expect(spaceGUID).Should(Equal("space_guid"))
@@ -117,7 +120,7 @@ var _ = Describe("given UserSpaces", func() {
 					AddUser:   roleMgrFake.AssociateSpaceAuditor,
 					RoleUsers: role.InitRoleUsers(),
 				}
-				err := userManager.SyncInternalUsers(roleUsers, updateUsersInput)
+				err := userManager.SyncInternalUsers(context.Background(), roleUsers, updateUsersInput)
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(roleMgrFake.AssociateSpaceAuditorCallCount()).Should(Equal(0))
 			})
@@ -129,7 +132,7 @@ var _ = Describe("given UserSpaces", func() {
 					AddUser:   roleMgrFake.AssociateSpaceAuditor,
 					RoleUsers: role.InitRoleUsers(),
 				}
-				err := userManager.SyncInternalUsers(roleUsers, updateUsersInput)
+				err := userManager.SyncInternalUsers(context.Background(), roleUsers, updateUsersInput)
 				Expect(err).Should(HaveOccurred())
 				Expect(err.Error()).Should(Equal("user test2 doesn't exist in origin uaa, so must add internal user first"))
 			})
@@ -143,7 +146,7 @@ var _ = Describe("given UserSpaces", func() {
 					RoleUsers: role.InitRoleUsers(),
 				}
 				roleMgrFake.AssociateSpaceAuditorReturns(errors.New("error"))
-				err := userManager.SyncInternalUsers(roleUsers, updateUsersInput)
+				err := userManager.SyncInternalUsers(context.Background(), roleUsers, updateUsersInput)
 				Expect(err).Should(HaveOccurred())
 				Expect(roleMgrFake.AssociateSpaceAuditorCallCount()).Should(Equal(1))
 			})
@@ -171,11 +174,11 @@ var _ = Describe("given UserSpaces", func() {
 					RoleUsers:   role.InitRoleUsers(),
 					Role:        "auditor",
 				}
-				err := userManager.RemoveUsers(roleUsers, updateUsersInput)
+				err := userManager.RemoveUsers(context.Background(), roleUsers, updateUsersInput)
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(roleMgrFake.RemoveSpaceAuditorCallCount()).Should(Equal(1))
 
-				spaceName, spaceGUID, userName, userGUID := roleMgrFake.RemoveSpaceAuditorArgsForCall(0)
+				_, spaceName, spaceGUID, userName, userGUID := roleMgrFake.RemoveSpaceAuditorArgsForCall(0)
 				Expect(spaceName).Should(Equal("orgName/spaceName"))
 				Expect(spaceGUID).Should(Equal("space_guid"))
 				Expect(userName).Should(Equal("test"))
@@ -191,7 +194,7 @@ var _ = Describe("given UserSpaces", func() {
 					RoleUsers:   role.InitRoleUsers(),
 				}
 
-				err := userManager.RemoveUsers(roleUsers, updateUsersInput)
+				err := userManager.RemoveUsers(context.Background(), roleUsers, updateUsersInput)
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(roleMgrFake.RemoveSpaceAuditorCallCount()).Should(Equal(0))
 			})
@@ -214,7 +217,7 @@ var _ = Describe("given UserSpaces", func() {
 					ProtectedUsers: []string{"abcd_123_*"},
 				}, nil)
 
-				err := userManager.RemoveUsers(roleUsers, updateUsersInput)
+				err := userManager.RemoveUsers(context.Background(), roleUsers, updateUsersInput)
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(roleMgrFake.RemoveSpaceAuditorCallCount()).Should(Equal(0))
 			})
@@ -228,7 +231,7 @@ var _ = Describe("given UserSpaces", func() {
 					RoleUsers:   role.InitRoleUsers(),
 				}
 				roleMgrFake.RemoveSpaceAuditorReturns(errors.New("error"))
-				err := userManager.RemoveUsers(roleUsers, updateUsersInput)
+				err := userManager.RemoveUsers(context.Background(), roleUsers, updateUsersInput)
 				Expect(err).Should(HaveOccurred())
 				Expect(roleMgrFake.RemoveSpaceAuditorCallCount()).Should(Equal(1))
 			})
@@ -257,7 +260,7 @@ var _ = Describe("given UserSpaces", func() {
 					GUID: "test-space-guid",
 				}, nil)
 				userManager.LdapConfig = &config.LdapConfig{Enabled: false}
-				err := userManager.UpdateSpaceUsers()
+				err := userManager.UpdateSpaceUsers(context.Background())
 				Expect(len(err)).To(Equal(0))
 			})
 		})
@@ -277,10 +280,160 @@ var _ = Describe("given UserSpaces", func() {
 					GUID: "test-org-guid",
 				}, nil)
 				userManager.LdapConfig = &config.LdapConfig{Enabled: false}
-				err := userManager.UpdateOrgUsers()
+				err := userManager.UpdateOrgUsers(context.Background())
 				Expect(len(err)).To(Equal(0))
 			})
 		})
+		Context("UpdateSpaceUsers with deletion limits", func() {
+			BeforeEach(func() {
+				uaaUsers := []uaaclient.User{}
+				uaaUsers = append(uaaUsers, uaaclient.User{Username: "old-user", Origin: "uaa", ID: "old-user-guid"})
+				uaaFake.ListUsersReturns(uaaUsers, uaaclient.Page{StartIndex: 1, TotalResults: 1, ItemsPerPage: 500}, nil)
+				users, err := userManager.UAAMgr.ListUsers(context.Background())
+				Expect(err).ShouldNot(HaveOccurred())
+				roleMgrFake.ListSpaceUsersByRoleStub = func(ctx context.Context, spaceGUID string) (*role.RoleUsers, *role.RoleUsers, *role.RoleUsers, *role.RoleUsers, error) {
+					developers, _ := role.NewRoleUsers([]*uaa.User{
+						{Username: "old-user", GUID: "old-user-guid"},
+					}, users)
+					return role.InitRoleUsers(), developers, role.InitRoleUsers(), role.InitRoleUsers(), nil
+				}
+				fakeReader.GetSpaceConfigsReturns([]config.SpaceConfig{
+					{Org: "test-org", Space: "space1", RemoveUsers: true},
+					{Org: "test-org", Space: "space2", RemoveUsers: true},
+				}, nil)
+				spaceFake.FindSpaceStub = func(ctx context.Context, orgName, spaceName string) (*resource.Space, error) {
+					return &resource.Space{
+						Name: spaceName,
+						GUID: spaceName + "-guid",
+						Relationships: &resource.SpaceRelationships{
+							Organization: &resource.ToOneRelationship{
+								Data: &resource.Relationship{GUID: "test-org-guid"},
+							},
+						},
+					}, nil
+				}
+				userManager.LdapConfig = &config.LdapConfig{Enabled: false}
+				fakeReader.GetGlobalConfigReturns(&config.GlobalConfig{
+					DeletionLimits: config.DeletionLimits{RoleRemovals: config.DeletionLimit{Max: 1}},
+				}, nil)
+			})
+
+			It("Should refuse every removal when the removals of the run exceed the limit", func() {
+				errs := userManager.UpdateSpaceUsers(context.Background())
+				Expect(errs).Should(HaveLen(1))
+				Expect(errs[0].Error()).Should(ContainSubstring("refusing to delete 2 of 2 users from roles"))
+				Expect(roleMgrFake.RemoveSpaceDeveloperCallCount()).Should(Equal(0))
+			})
+
+			It("Should count the removals of earlier steps of the run", func() {
+				fakeReader.GetGlobalConfigReturns(&config.GlobalConfig{
+					DeletionLimits: config.DeletionLimits{RoleRemovals: config.DeletionLimit{Max: 3}},
+				}, nil)
+				Expect(userManager.UpdateSpaceUsers(context.Background())).Should(BeEmpty())
+				Expect(roleMgrFake.RemoveSpaceDeveloperCallCount()).Should(Equal(2))
+
+				errs := userManager.UpdateSpaceUsers(context.Background())
+				Expect(errs).Should(HaveLen(1))
+				Expect(errs[0].Error()).Should(ContainSubstring("refusing to delete 4 of 4 users from roles"))
+				Expect(roleMgrFake.RemoveSpaceDeveloperCallCount()).Should(Equal(2))
+
+				userManager.ResetRoleRemovals()
+				Expect(userManager.UpdateSpaceUsers(context.Background())).Should(BeEmpty())
+				Expect(roleMgrFake.RemoveSpaceDeveloperCallCount()).Should(Equal(4))
+			})
+
+			It("Should remove the users when large deletions are allowed", func() {
+				userManager.AllowLargeDeletions = true
+				errs := userManager.UpdateSpaceUsers(context.Background())
+				Expect(errs).Should(BeEmpty())
+				Expect(roleMgrFake.RemoveSpaceDeveloperCallCount()).Should(Equal(2))
+			})
+		})
+
+		Context("UpdateSpaceUsers with an unresolved ldap group", func() {
+			BeforeEach(func() {
+				uaaUsers := []uaaclient.User{}
+				uaaUsers = append(uaaUsers, uaaclient.User{Username: "old-user", Origin: "uaa", ID: "old-user-guid"})
+				uaaUsers = append(uaaUsers, uaaclient.User{Username: "new-user", Origin: "uaa", ID: "new-user-guid"})
+				uaaFake.ListUsersReturns(uaaUsers, uaaclient.Page{StartIndex: 1, TotalResults: 2, ItemsPerPage: 500}, nil)
+				users, err := userManager.UAAMgr.ListUsers(context.Background())
+				Expect(err).ShouldNot(HaveOccurred())
+				roleMgrFake.ListSpaceUsersByRoleStub = func(ctx context.Context, spaceGUID string) (*role.RoleUsers, *role.RoleUsers, *role.RoleUsers, *role.RoleUsers, error) {
+					managers, _ := role.NewRoleUsers([]*uaa.User{
+						{Username: "old-user", GUID: "old-user-guid"},
+					}, users)
+					developers, _ := role.NewRoleUsers([]*uaa.User{
+						{Username: "old-user", GUID: "old-user-guid"},
+					}, users)
+					return managers, developers, role.InitRoleUsers(), role.InitRoleUsers(), nil
+				}
+				ldapFake.GetUserDNsReturns(nil, &ldap.GroupError{Group: "missing_group", Reason: ldap.GroupNotFound})
+				fakeReader.GetSpaceConfigsReturns([]config.SpaceConfig{
+					{
+						Org:         "test-org",
+						Space:       "space1",
+						RemoveUsers: true,
+						Developer:   config.UserMgmt{LDAPGroups: []string{"missing_group"}},
+						Manager:     config.UserMgmt{Users: []string{"new-user"}},
+					},
+				}, nil)
+				spaceFake.FindSpaceReturns(&resource.Space{
+					Name: "space1",
+					GUID: "space1-guid",
+					Relationships: &resource.SpaceRelationships{
+						Organization: &resource.ToOneRelationship{
+							Data: &resource.Relationship{GUID: "test-org-guid"},
+						},
+					},
+				}, nil)
+				userManager.LdapConfig = &config.LdapConfig{Enabled: true, Origin: "ldap"}
+			})
+
+			It("Should refuse the removals of that role only and still sync the other roles", func() {
+				errs := userManager.UpdateSpaceUsers(context.Background())
+				Expect(errs).Should(HaveLen(1))
+				Expect(errs[0].Error()).Should(ContainSubstring("ldap group [missing_group]: not found"))
+				Expect(roleMgrFake.RemoveSpaceDeveloperCallCount()).Should(Equal(0))
+				Expect(roleMgrFake.AssociateSpaceManagerCallCount()).Should(Equal(1))
+				Expect(roleMgrFake.RemoveSpaceManagerCallCount()).Should(Equal(1))
+			})
+		})
+
+		Context("CleanupOrgUsers with deletion limits", func() {
+			BeforeEach(func() {
+				uaaUsers := []uaaclient.User{}
+				uaaUsers = append(uaaUsers, uaaclient.User{Username: "old-user", Origin: "uaa", ID: "old-user-guid"})
+				uaaFake.ListUsersReturns(uaaUsers, uaaclient.Page{StartIndex: 1, TotalResults: 1, ItemsPerPage: 500}, nil)
+				users, err := userManager.UAAMgr.ListUsers(context.Background())
+				Expect(err).ShouldNot(HaveOccurred())
+				orgUsers, _ := role.NewRoleUsers([]*uaa.User{
+					{Username: "old-user", GUID: "old-user-guid"},
+				}, users)
+				roleMgrFake.ListOrgUsersByRoleReturns(orgUsers, role.InitRoleUsers(), role.InitRoleUsers(), role.InitRoleUsers(), nil)
+				fakeReader.GetOrgConfigsReturns([]config.OrgConfig{
+					{Org: "test-org", RemoveUsers: true},
+				}, nil)
+				orgFake.FindOrgReturns(&resource.Organization{Name: "test-org", GUID: "test-org-guid"}, nil)
+				fakeReader.GetGlobalConfigReturns(&config.GlobalConfig{
+					DeletionLimits: config.DeletionLimits{RoleRemovals: config.DeletionLimit{MaxPercent: 50}},
+				}, nil)
+			})
+
+			It("Should not remove org users when the cleanup exceeds the limit", func() {
+				errs := userManager.CleanupOrgUsers(context.Background())
+				Expect(errs).Should(HaveLen(1))
+				Expect(errs[0].Error()).Should(ContainSubstring("refusing to delete 1 of 1 users from roles"))
+				Expect(roleMgrFake.RemoveOrgUserCallCount()).Should(Equal(0))
+			})
+
+			It("Should remove org users when large deletions are allowed", func() {
+				userManager.AllowLargeDeletions = true
+				errs := userManager.CleanupOrgUsers(context.Background())
+				Expect(errs).Should(BeEmpty())
+				Expect(roleMgrFake.RemoveOrgUserCallCount()).Should(Equal(1))
+			})
+		})
+
 		Context("Sync Users", func() {
 			var roleUsers *role.RoleUsers
 			BeforeEach(func() {
@@ -289,7 +442,7 @@ var _ = Describe("given UserSpaces", func() {
 				uaaUsers = append(uaaUsers, uaaclient.User{Username: "test", Origin: "ldap", ID: "test-ldap-user-guid", ExternalID: "cn=test"})
 				uaaFake.ListUsersReturns(uaaUsers, uaaclient.Page{StartIndex: 1, TotalResults: 2, ItemsPerPage: 500}, nil)
 
-				users, err := userManager.UAAMgr.ListUsers()
+				users, err := userManager.UAAMgr.ListUsers(context.Background())
 				Expect(err).ShouldNot(HaveOccurred())
 				roleUsers, _ = role.NewRoleUsers([]*uaa.User{}, users)
 
@@ -306,17 +459,17 @@ var _ = Describe("given UserSpaces", func() {
 					RoleUsers: roleUsers,
 				}
 				userManager.LdapConfig = &config.LdapConfig{Enabled: true, Origin: "ldap"}
-				err := userManager.SyncUsers(updateUsersInput)
+				err := userManager.SyncUsers(context.Background(), updateUsersInput)
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(roleMgrFake.AssociateSpaceAuditorCallCount()).Should(Equal(2))
-				orgGUID, spaceName, spaceGUID, userName, userGUID := roleMgrFake.AssociateSpaceAuditorArgsForCall(0)
+				_, orgGUID, spaceName, spaceGUID, userName, userGUID := roleMgrFake.AssociateSpaceAuditorArgsForCall(0)
 				Expect(orgGUID).Should(Equal("org_guid"))
 				Expect(spaceName).Should(Equal("orgName/spaceName"))
 				Expect(spaceGUID).Should(Equal("space_guid"))
 				Expect(userName).Should(Equal("test"))
 				Expect(userGUID).Should(Equal("test-ldap-user-guid"))
 
-				orgGUID, spaceName, spaceGUID, userName, userGUID = roleMgrFake.AssociateSpaceAuditorArgsForCall(1)
+				_, orgGUID, spaceName, spaceGUID, userName, userGUID = roleMgrFake.AssociateSpaceAuditorArgsForCall(1)
 				Expect(orgGUID).Should(Equal("org_guid"))
 				Expect(spaceName).Should(Equal("orgName/spaceName"))
 				Expect(spaceGUID).Should(Equal("space_guid"))
@@ -0,0 +1,11 @@
+package util
+
+import "context"
+
+// CallContext - the context for a cloud foundry api call made while reconciling
+// under ctx.  It keeps the values of ctx but not its cancellation, so the calls
+// already made when a run is interrupted finish rather than leave an entity half
+// changed.  Each call is bounded by the request timeout of the http client instead.
+func CallContext(ctx context.Context) context.Context {
+	return context.WithoutCancel(ctx)
+}
@@ -0,0 +1,144 @@
+package util
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"runtime"
+	"strconv"
+	"sync"
+
+	"github.com/op/go-logging"
+	"github.com/xchapter7x/lo"
+)
+
+var (
+	installGroupedLog sync.Once
+	groupedLogs       = &groupedLog{}
+)
+
+// groupedLog - a logging backend that holds what the workers of RunGrouped log so
+// the lines of each group are written together and in group order, as they are
+// when the groups run one after another.  The first unfinished group logs straight
+// through and the groups after it are held until the groups before them finish.
+// Lines logged by other goroutines, including ones started by a group, are written
+// as they are logged.
+type groupedLog struct {
+	mutex   sync.Mutex
+	out     logging.Backend
+	workers map[uint64]int
+	groups  []*groupRecords
+	next    int
+}
+
+type groupRecords struct {
+	records []groupRecord
+	done    bool
+}
+
+type groupRecord struct {
+	level  logging.Level
+	record *logging.Record
+}
+
+// install - replaces the default backend, which writes to stderr, keeping the
+// levels set for lo.G
+func (g *groupedLog) install() {
+	level, defaultLevel := logging.GetLevel(lo.LOG_MODULE), logging.GetLevel("")
+	g.out = logging.NewLogBackend(stderr{}, "", log.LstdFlags)
+	logging.SetBackend(g)
+	logging.SetLevel(defaultLevel, "")
+	logging.SetLevel(level, lo.LOG_MODULE)
+}
+
+// start - begins holding the lines of count groups, it returns false when another
+// RunGrouped already is
+func (g *groupedLog) start(count int) bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.workers != nil {
+		return false
+	}
+	g.workers = make(map[uint64]int)
+	g.groups = make([]*groupRecords, count)
+	for i := range g.groups {
+		g.groups[i] = &groupRecords{}
+	}
+	g.next = 0
+	return true
+}
+
+// begin - attributes what the calling goroutine logs to group
+func (g *groupedLog) begin(group int) {
+	id := goroutineID()
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.workers[id] = group
+}
+
+// finish - marks group done and writes the groups that are no longer held
+func (g *groupedLog) finish(group int) {
+	id := goroutineID()
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	delete(g.workers, id)
+	g.groups[group].done = true
+	for g.next < len(g.groups) {
+		g.flush(g.groups[g.next])
+		if !g.groups[g.next].done {
+			break
+		}
+		g.next++
+	}
+}
+
+// end - writes what is still held, in group order, and stops holding lines
+func (g *groupedLog) end() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	for ; g.next < len(g.groups); g.next++ {
+		g.flush(g.groups[g.next])
+	}
+	g.workers, g.groups = nil, nil
+}
+
+func (g *groupedLog) flush(group *groupRecords) {
+	for _, held := range group.records {
+		_ = g.out.Log(held.level, 2, held.record)
+	}
+	group.records = nil
+}
+
+// Log - implements logging.Backend
+func (g *groupedLog) Log(level logging.Level, calldepth int, record *logging.Record) error {
+	// formatting now keeps the file and line of the caller for a record that is held
+	record.Formatted(calldepth + 1)
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.workers != nil {
+		if group, ok := g.workers[goroutineID()]; ok && group != g.next {
+			g.groups[group].records = append(g.groups[group].records, groupRecord{level: level, record: record})
+			return nil
+		}
+	}
+	return g.out.Log(level, calldepth+1, record)
+}
+
+// stderr - writes to os.Stderr as it is when written to
+type stderr struct{}
+
+func (stderr) Write(p []byte) (int, error) {
+	return os.Stderr.Write(p)
+}
+
+// goroutineID - the id of the calling goroutine, read from the header of its stack
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	if end := bytes.IndexByte(buf, ' '); end >= 0 {
+		buf = buf[:end]
+	}
+	id, _ := strconv.ParseUint(string(buf), 10, 64)
+	return id
+}
@@ -0,0 +1,104 @@
+package util
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// RunGrouped - runs fn for every item from 0 to count-1 on up to parallelism workers.
+// Items that share a group key (usually the org name) run serially in item order on
+// the same worker so per-org state is never reconciled concurrently.  When failFast is
+// set no new items are started once one has failed.  Errors are returned in item order
+// so the result doesn't depend on scheduling, and what fn logs is held so the lines of
+// each group are written together and in group order.  Once ctx is done no new items
+// are started, the items already running finish, and ctx.Err() is returned after the
+// errors of the items.
+func RunGrouped(ctx context.Context, parallelism, count int, group func(i int) string, failFast bool, fn func(i int) error) []error {
+	errs := make([]error, count)
+	if parallelism <= 1 {
+		for i := 0; i < count && ctx.Err() == nil; i++ {
+			errs[i] = fn(i)
+			if errs[i] != nil && failFast {
+				break
+			}
+		}
+		return withContextErr(ctx, compactErrors(errs))
+	}
+
+	var groups [][]int
+	groupIndex := make(map[string]int)
+	for i := 0; i < count; i++ {
+		key := group(i)
+		index, ok := groupIndex[key]
+		if !ok {
+			index = len(groups)
+			groupIndex[key] = index
+			groups = append(groups, nil)
+		}
+		groups[index] = append(groups[index], i)
+	}
+
+	installGroupedLog.Do(groupedLogs.install)
+	holdLogs := groupedLogs.start(len(groups))
+	if holdLogs {
+		defer groupedLogs.end()
+	}
+
+	var failed atomic.Bool
+	work := make(chan int)
+	var wg sync.WaitGroup
+	if parallelism > len(groups) {
+		parallelism = len(groups)
+	}
+	for w := 0; w < parallelism; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for group := range work {
+				if holdLogs {
+					groupedLogs.begin(group)
+				}
+				for _, i := range groups[group] {
+					if (failFast && failed.Load()) || ctx.Err() != nil {
+						break
+					}
+					// each item has its own slot so no locking is needed
+					errs[i] = fn(i)
+					if errs[i] != nil {
+						failed.Store(true)
+					}
+				}
+				if holdLogs {
+					groupedLogs.finish(group)
+				}
+			}
+		}()
+	}
+	for group := range groups {
+		if (failFast && failed.Load()) || ctx.Err() != nil {
+			break
+		}
+		work <- group
+	}
+	close(work)
+	wg.Wait()
+	return withContextErr(ctx, compactErrors(errs))
+}
+
+func withContextErr(ctx context.Context, errs []error) []error {
+	if err := ctx.Err(); err != nil {
+		return append(errs, err)
+	}
+	return errs
+}
+
+func compactErrors(errs []error) []error {
+	result := []error{}
+	for _, err := range errs {
+		if err != nil {
+			result = append(result, err)
+		}
+	}
+	return result
+}
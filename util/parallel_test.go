@@ -0,0 +1,131 @@
+package util_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	. "github.com/vmwarepivotallabs/cf-mgmt/util"
+	"github.com/xchapter7x/lo"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RunGrouped", func() {
+	var orgs []string
+	BeforeEach(func() {
+		orgs = []string{"org1", "org2", "org1", "org3", "org2", "org1"}
+	})
+	group := func(i int) string { return orgs[i] }
+
+	It("runs every item serially", func() {
+		var ran []int
+		errs := RunGrouped(context.Background(), 1, len(orgs), group, false, func(i int) error {
+			ran = append(ran, i)
+			return nil
+		})
+		Expect(errs).To(BeEmpty())
+		Expect(ran).To(Equal([]int{0, 1, 2, 3, 4, 5}))
+	})
+
+	It("stops at the first error when serial and failing fast", func() {
+		var ran []int
+		errs := RunGrouped(context.Background(), 1, len(orgs), group, true, func(i int) error {
+			ran = append(ran, i)
+			if i == 2 {
+				return fmt.Errorf("item %d", i)
+			}
+			return nil
+		})
+		Expect(errs).To(HaveLen(1))
+		Expect(ran).To(Equal([]int{0, 1, 2}))
+	})
+
+	It("runs items of a group in order and returns errors in item order", func() {
+		var mutex sync.Mutex
+		ranByOrg := make(map[string][]int)
+		errs := RunGrouped(context.Background(), 3, len(orgs), group, false, func(i int) error {
+			mutex.Lock()
+			ranByOrg[orgs[i]] = append(ranByOrg[orgs[i]], i)
+			mutex.Unlock()
+			if i == 4 || i == 1 {
+				return fmt.Errorf("item %d", i)
+			}
+			return nil
+		})
+		Expect(ranByOrg).To(Equal(map[string][]int{
+			"org1": {0, 2, 5},
+			"org2": {1, 4},
+			"org3": {3},
+		}))
+		Expect(errs).To(HaveLen(2))
+		Expect(errs[0]).To(MatchError("item 1"))
+		Expect(errs[1]).To(MatchError("item 4"))
+	})
+
+	It("starts no new items once the context is cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		var ran []int
+		errs := RunGrouped(ctx, 1, len(orgs), group, false, func(i int) error {
+			ran = append(ran, i)
+			if i == 1 {
+				cancel()
+			}
+			return nil
+		})
+		Expect(ran).To(Equal([]int{0, 1}))
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0]).To(MatchError(context.Canceled))
+	})
+
+	It("starts no new groups once the context is cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		ran := false
+		errs := RunGrouped(ctx, 3, len(orgs), group, false, func(i int) error {
+			ran = true
+			return nil
+		})
+		Expect(ran).To(BeFalse())
+		Expect(errs).To(ConsistOf(MatchError(context.Canceled)))
+	})
+
+	It("writes what each group logs together and in group order", func() {
+		stderr := os.Stderr
+		out, err := os.CreateTemp(GinkgoT().TempDir(), "stderr")
+		Expect(err).ShouldNot(HaveOccurred())
+		os.Stderr = out
+		defer func() { os.Stderr = stderr }()
+
+		orgs = []string{"org1", "org1", "org2", "org2"}
+		org2Done := make(chan struct{})
+		errs := RunGrouped(context.Background(), 2, len(orgs), group, false, func(i int) error {
+			if i == 0 {
+				<-org2Done
+			}
+			lo.G.Infof("item %d of %s", i, orgs[i])
+			if i == 3 {
+				close(org2Done)
+			}
+			return nil
+		})
+		Expect(errs).To(BeEmpty())
+
+		logged, err := os.ReadFile(out.Name())
+		Expect(err).ShouldNot(HaveOccurred())
+		var items []string
+		for _, line := range strings.Split(string(logged), "\n") {
+			if index := strings.Index(line, "item "); index >= 0 {
+				items = append(items, line[index:])
+			}
+		}
+		Expect(items).To(Equal([]string{"item 0 of org1", "item 1 of org1", "item 2 of org2", "item 3 of org2"}))
+	})
+
+	It("handles no items", func() {
+		Expect(RunGrouped(context.Background(), 4, 0, group, true, func(i int) error { return nil })).To(BeEmpty())
+	})
+})
@@ -0,0 +1,262 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xchapter7x/lo"
+)
+
+const (
+	// maxRetryAfter - the longest Retry-After that is honoured
+	maxRetryAfter = 5 * time.Minute
+	networkError  = "network"
+)
+
+// RetryTransport - retries requests that failed in a way that is safe to retry
+// with exponential backoff and jitter, and spaces requests out to stay within a
+// requests per second budget.  Idempotent requests are retried after network
+// errors and 429, 502, 503 and 504 responses.  Other requests are only retried
+// when they never reached the server or were rejected with 429.
+type RetryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	limiter    *RateLimiter
+	// OnRetry - called with the reason, a status code or "network", before each retry
+	OnRetry func(reason string)
+	mutex   sync.Mutex
+	retries map[string]int
+	sleep   func(ctx context.Context, d time.Duration) error
+}
+
+// NewRetryTransport - a limiter of nil doesn't limit the request rate
+func NewRetryTransport(roundTripper http.RoundTripper, maxRetries int, minBackoff, maxBackoff time.Duration, limiter *RateLimiter) *RetryTransport {
+	return &RetryTransport{
+		base:       roundTripper,
+		maxRetries: maxRetries,
+		minBackoff: minBackoff,
+		maxBackoff: maxBackoff,
+		limiter:    limiter,
+		retries:    make(map[string]int),
+		sleep:      sleep,
+	}
+}
+
+// RoundTrip - sends req and then, for each retry, a clone of it with a new body
+// from GetBody, as a round tripper mustn't modify the request it is given.  A
+// request with a body but no GetBody isn't retried.
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		if err := t.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+		resp, err := t.base.RoundTrip(attemptReq)
+		reason, retryable := t.retryable(req, resp, err)
+		if !retryable || attempt >= t.maxRetries {
+			return resp, err
+		}
+		attemptReq = req.Clone(req.Context())
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			attemptReq.Body = body
+		}
+		wait := t.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok && retryAfter > wait {
+				wait = retryAfter
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		t.recordRetry(reason)
+		lo.G.Debugf("retrying %s %s in %s after %s (retry %d of %d)", req.Method, req.URL.Path, wait, reason, attempt+1, t.maxRetries)
+		if err := t.sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (t *RetryTransport) retryable(req *http.Request, resp *http.Response, err error) (string, bool) {
+	if err != nil {
+		if req.Context().Err() != nil {
+			return "", false
+		}
+		if idempotent(req.Method) || neverSent(err) {
+			return networkError, true
+		}
+		return "", false
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return strconv.Itoa(resp.StatusCode), true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return strconv.Itoa(resp.StatusCode), idempotent(req.Method)
+	}
+	return "", false
+}
+
+// backoff - the exponential backoff for the attempt, with jitter of up to half
+func (t *RetryTransport) backoff(attempt int) time.Duration {
+	backoff := t.minBackoff
+	for i := 0; i < attempt && backoff < t.maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > t.maxBackoff {
+		backoff = t.maxBackoff
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	half := int64(backoff / 2)
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+func (t *RetryTransport) recordRetry(reason string) {
+	t.mutex.Lock()
+	t.retries[reason]++
+	t.mutex.Unlock()
+	if t.OnRetry != nil {
+		t.OnRetry(reason)
+	}
+}
+
+// Retries - the number of retries by reason since the last reset
+func (t *RetryTransport) Retries() map[string]int {
+	result := make(map[string]int)
+	if t == nil {
+		return result
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	for reason, count := range t.retries {
+		result[reason] = count
+	}
+	return result
+}
+
+// ResetRetries -
+func (t *RetryTransport) ResetRetries() {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.retries = make(map[string]int)
+}
+
+// RetrySummary - the retries, e.g. "5 api request(s) (429: 2, 502: 3)", or an
+// empty string when nothing was retried
+func RetrySummary(retries map[string]int) string {
+	if len(retries) == 0 {
+		return ""
+	}
+	var reasons []string
+	total := 0
+	for reason, count := range retries {
+		reasons = append(reasons, fmt.Sprintf("%s: %d", reason, count))
+		total += count
+	}
+	sort.Strings(reasons)
+	return fmt.Sprintf("%d api request(s) (%s)", total, strings.Join(reasons, ", "))
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// neverSent - true when the connection couldn't be made so the server can't
+// have acted on the request
+func neverSent(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	var wait time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		wait = time.Until(date)
+	} else {
+		return 0, false
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	if wait > maxRetryAfter {
+		wait = maxRetryAfter
+	}
+	return wait, true
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// RateLimiter - spaces calls out evenly to stay within a number of calls per
+// second.  A nil RateLimiter doesn't limit anything.
+type RateLimiter struct {
+	mutex    sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// NewRateLimiter - returns nil, which doesn't limit, when perSecond isn't positive
+func NewRateLimiter(perSecond float64) *RateLimiter {
+	if perSecond <= 0 {
+		return nil
+	}
+	return &RateLimiter{interval: time.Duration(float64(time.Second) / perSecond)}
+}
+
+// Wait - blocks until the next call is allowed
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	l.mutex.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mutex.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+	return sleep(ctx, wait)
+}
@@ -0,0 +1,119 @@
+package util_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/vmwarepivotallabs/cf-mgmt/util"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RetryTransport", func() {
+	var (
+		server    *httptest.Server
+		mutex     sync.Mutex
+		statuses  []int
+		requests  int
+		bodies    []string
+		transport *RetryTransport
+		client    *http.Client
+	)
+	BeforeEach(func() {
+		requests = 0
+		bodies = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			body := make([]byte, r.ContentLength)
+			r.Body.Read(body)
+			bodies = append(bodies, string(body))
+			status := http.StatusOK
+			if requests < len(statuses) {
+				status = statuses[requests]
+			}
+			requests++
+			if status == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", "1")
+			}
+			w.WriteHeader(status)
+		}))
+		transport = NewRetryTransport(http.DefaultTransport, 3, time.Millisecond, 10*time.Millisecond, nil)
+		client = &http.Client{Transport: transport}
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("retries idempotent requests after gateway errors", func() {
+		statuses = []int{http.StatusBadGateway, http.StatusServiceUnavailable}
+		resp, err := client.Get(server.URL)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+		Expect(requests).Should(Equal(3))
+		Expect(transport.Retries()).Should(Equal(map[string]int{"502": 1, "503": 1}))
+		Expect(RetrySummary(transport.Retries())).Should(Equal("2 api request(s) (502: 1, 503: 1)"))
+	})
+
+	It("gives up after the maximum retries", func() {
+		statuses = []int{502, 502, 502, 502, 502}
+		resp, err := client.Get(server.URL)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(resp.StatusCode).Should(Equal(http.StatusBadGateway))
+		Expect(requests).Should(Equal(4))
+	})
+
+	It("doesn't retry posts after gateway errors", func() {
+		statuses = []int{http.StatusBadGateway}
+		resp, err := client.Post(server.URL, "application/json", strings.NewReader("{}"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(resp.StatusCode).Should(Equal(http.StatusBadGateway))
+		Expect(requests).Should(Equal(1))
+	})
+
+	It("retries posts rejected with 429 after Retry-After with the same body", func() {
+		statuses = []int{http.StatusTooManyRequests}
+		start := time.Now()
+		resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"name":"foo"}`))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+		Expect(time.Since(start)).Should(BeNumerically(">=", time.Second))
+		Expect(bodies).Should(Equal([]string{`{"name":"foo"}`, `{"name":"foo"}`}))
+	})
+
+	It("retries with a clone of the request, leaving the one it was given as it is", func() {
+		statuses = []int{http.StatusBadGateway}
+		req, err := http.NewRequest(http.MethodPut, server.URL, strings.NewReader(`{"name":"foo"}`))
+		Expect(err).ShouldNot(HaveOccurred())
+		body := req.Body
+		resp, err := transport.RoundTrip(req)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+		Expect(req.Body).Should(BeIdenticalTo(body))
+		Expect(bodies).Should(Equal([]string{`{"name":"foo"}`, `{"name":"foo"}`}))
+	})
+
+	It("retries requests that couldn't connect", func() {
+		server.Close()
+		_, err := client.Post(server.URL, "application/json", strings.NewReader("{}"))
+		Expect(err).Should(HaveOccurred())
+		Expect(transport.Retries()).Should(Equal(map[string]int{"network": 3}))
+		transport.ResetRetries()
+		Expect(transport.Retries()).Should(BeEmpty())
+	})
+
+	It("limits the request rate", func() {
+		client = &http.Client{Transport: NewRetryTransport(http.DefaultTransport, 0, 0, 0, NewRateLimiter(20))}
+		start := time.Now()
+		for i := 0; i < 5; i++ {
+			resp, err := client.Get(server.URL)
+			Expect(err).ShouldNot(HaveOccurred())
+			resp.Body.Close()
+		}
+		Expect(time.Since(start)).Should(BeNumerically(">=", 200*time.Millisecond))
+	})
+})
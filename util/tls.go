@@ -0,0 +1,50 @@
+package util
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// TLSOptions - how the cloud foundry, uaa and routing api certificates are
+// verified and the client certificate, if any, presented to them
+type TLSOptions struct {
+	SkipSSLValidation bool
+	CACertFile        string
+	ClientCertFile    string
+	ClientKeyFile     string
+}
+
+// Config - a tls.Config for the options.  Certificates in CACertFile are
+// trusted in addition to the system ones.
+func (o TLSOptions) Config() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: o.SkipSSLValidation,
+	}
+	if o.CACertFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		pem, err := os.ReadFile(o.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read ca cert %s: %v", o.CACertFile, err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificates found in ca cert %s", o.CACertFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if o.ClientCertFile != "" || o.ClientKeyFile != "" {
+		if o.ClientCertFile == "" || o.ClientKeyFile == "" {
+			return nil, fmt.Errorf("client-cert and client-key must be set together")
+		}
+		certificate, err := tls.LoadX509KeyPair(o.ClientCertFile, o.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate %s: %v", o.ClientCertFile, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,107 @@
+package util_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/vmwarepivotallabs/cf-mgmt/util"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TLSOptions", func() {
+	var (
+		server *httptest.Server
+		dir    string
+	)
+	BeforeEach(func() {
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		var err error
+		dir, err = os.MkdirTemp("", "cf-mgmt-tls")
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(dir)
+	})
+	get := func(options TLSOptions) error {
+		tlsConfig, err := options.Config()
+		Expect(err).ShouldNot(HaveOccurred())
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+		resp, err := client.Get(server.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	It("verifies certificates by default", func() {
+		Expect(get(TLSOptions{})).Should(HaveOccurred())
+	})
+
+	It("skips verification", func() {
+		Expect(get(TLSOptions{SkipSSLValidation: true})).Should(Succeed())
+	})
+
+	It("trusts the ca cert", func() {
+		caCert := filepath.Join(dir, "ca.pem")
+		Expect(os.WriteFile(caCert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0644)).Should(Succeed())
+		Expect(get(TLSOptions{CACertFile: caCert})).Should(Succeed())
+	})
+
+	It("errors when the ca cert has no certificates", func() {
+		caCert := filepath.Join(dir, "ca.pem")
+		Expect(os.WriteFile(caCert, []byte("not a certificate"), 0644)).Should(Succeed())
+		_, err := TLSOptions{CACertFile: caCert}.Config()
+		Expect(err).Should(MatchError(ContainSubstring("no PEM certificates found")))
+	})
+
+	It("requires the client cert and key together", func() {
+		_, err := TLSOptions{ClientCertFile: "cert.pem"}.Config()
+		Expect(err).Should(MatchError("client-cert and client-key must be set together"))
+	})
+
+	It("presents the client certificate", func() {
+		server.Close()
+		server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+		server.StartTLS()
+		Expect(get(TLSOptions{SkipSSLValidation: true})).Should(HaveOccurred())
+
+		clientCert := filepath.Join(dir, "client.pem")
+		clientKey := filepath.Join(dir, "client.key")
+		certPEM, keyPEM := selfSignedCertificate()
+		Expect(os.WriteFile(clientCert, certPEM, 0644)).Should(Succeed())
+		Expect(os.WriteFile(clientKey, keyPEM, 0600)).Should(Succeed())
+		Expect(get(TLSOptions{SkipSSLValidation: true, ClientCertFile: clientCert, ClientKeyFile: clientKey})).Should(Succeed())
+	})
+})
+
+func selfSignedCertificate() ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ShouldNot(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "cf-mgmt"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).ShouldNot(HaveOccurred())
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	Expect(err).ShouldNot(HaveOccurred())
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}